@@ -6,29 +6,10 @@ import (
 	"os/exec"
 	"path/filepath"
 
-	"github.com/caarlos0/env/v11"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
 
-type database struct {
-	Port         string `env:"DB_PORT"`
-	Host         string `env:"DB_HOST"`
-	Name         string `env:"DB_NAME"`
-	User         string `env:"DB_USER"`
-	Password     string `env:"DB_PASSWORD"`
-	DatabaseKind string `env:"DB_KIND"`
-	SslMode      string `env:"DB_SSL_MODE"`
-}
-
-// GetDatabaseURL returns database URL.
-func (d database) GetDatabaseURL() string {
-	return fmt.Sprintf("%s://%s:%s@%s:%s/%s?sslmode=%s",
-		d.DatabaseKind, d.User, d.Password, d.Host, d.Port,
-		d.Name, d.SslMode,
-	)
-}
-
 func newConsoleCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "console",
@@ -38,56 +19,12 @@ func newConsoleCommand() *cobra.Command {
 details from .env.
 
 Reads DB_HOST, DB_PORT, DB_NAME, DB_USER, DB_PASSWORD, DB_KIND, and
-DB_SSL_MODE from your .env file and connects via usql.`,
+DB_SSL_MODE from your .env file and connects via usql. Equivalent to
+'andurel database console'.`,
 		Example: `  andurel console`,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rootDir, err := findGoModRoot()
-			if err != nil {
-				return err
-			}
-
-			envPath := filepath.Join(rootDir, ".env")
-			if _, err := os.Stat(envPath); err != nil {
-				if os.IsNotExist(err) {
-					return fmt.Errorf(
-						".env file not found at %s\nCreate one to set your environment variables",
-						envPath,
-					)
-				}
-				return err
-			}
-
-			if err := godotenv.Load(envPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: could not load .env file: %v\n", err)
-			}
-
-			dataCfg := database{}
-
-			if err := env.ParseWithOptions(&dataCfg, env.Options{
-				RequiredIfNoDef: true,
-			}); err != nil {
-				return fmt.Errorf("error parsing environment variables: %w", err)
-			}
-
-			usqlPath := filepath.Join(rootDir, "bin", "usql")
-			if _, err := os.Stat(usqlPath); err != nil {
-				if os.IsNotExist(err) {
-					return fmt.Errorf(
-						"usql binary not found at %s\nRun 'andurel tool sync' to download it",
-						usqlPath,
-					)
-				}
-				return err
-			}
-
-			command := exec.Command(usqlPath, dataCfg.GetDatabaseURL())
-			command.Stdout = os.Stdout
-			command.Stderr = os.Stderr
-			command.Stdin = os.Stdin
-			command.Dir = rootDir
-
-			return command.Run()
+			return runDatabaseConsole()
 		},
 	}
 
@@ -102,60 +39,77 @@ func newDblabCommand() *cobra.Command {
 		Long: `Open the dblab interactive database UI in the browser.
 
 Uses DB_HOST, DB_PORT, DB_NAME, DB_USER, DB_PASSWORD, DB_KIND, and
-DB_SSL_MODE from your .env file.`,
+DB_SSL_MODE from your .env file. Equivalent to 'andurel database ui'.`,
 		Example: `  andurel tool dblab`,
 		Args:    cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			rootDir, err := findGoModRoot()
-			if err != nil {
-				return err
-			}
+			return runDatabaseUI()
+		},
+	}
 
-			envPath := filepath.Join(rootDir, ".env")
-			if _, err := os.Stat(envPath); err != nil {
-				if os.IsNotExist(err) {
-					return fmt.Errorf(
-						".env file not found at %s\nCreate one to set your environment variables",
-						envPath,
-					)
-				}
-				return err
-			}
+	return cmd
+}
 
-			if err := godotenv.Load(envPath); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: could not load .env file: %v\n", err)
-			}
+func runDatabaseConsole() error {
+	return runDatabaseTool("usql", func(databaseURL string) []string {
+		return []string{databaseURL}
+	})
+}
 
-			dataCfg := database{}
+func runDatabaseUI() error {
+	return runDatabaseTool("dblab", func(databaseURL string) []string {
+		return []string{"--url", databaseURL}
+	})
+}
 
-			if err := env.ParseWithOptions(&dataCfg, env.Options{
-				RequiredIfNoDef: true,
-			}); err != nil {
-				return fmt.Errorf("error parsing environment variables: %w", err)
-			}
+// runDatabaseTool launches a synced database client from bin/ against the
+// database configured in .env. The connection string is built by the same
+// helper the lifecycle commands use, so credentials are escaped consistently.
+func runDatabaseTool(toolName string, toolArgs func(databaseURL string) []string) error {
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
 
-			dblabPath := filepath.Join(rootDir, "bin", "dblab")
-			if _, err := os.Stat(dblabPath); err != nil {
-				if os.IsNotExist(err) {
-					return fmt.Errorf(
-						"dblab binary not found at %s\nRun 'andurel tool sync' to download it",
-						dblabPath,
-					)
-				}
-				return err
-			}
+	envPath := filepath.Join(rootDir, ".env")
+	if _, err := os.Stat(envPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf(
+				".env file not found at %s\nCreate one to set your environment variables",
+				envPath,
+			)
+		}
+		return err
+	}
 
-			command := exec.Command(dblabPath, "--url", dataCfg.GetDatabaseURL())
-			command.Stdout = os.Stdout
-			command.Stderr = os.Stderr
-			command.Stdin = os.Stdin
-			command.Dir = rootDir
+	if err := godotenv.Load(envPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load .env file: %v\n", err)
+	}
 
-			return command.Run()
-		},
+	cfg, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("error parsing environment variables: %w", err)
 	}
 
-	return cmd
+	binPath := filepath.Join(rootDir, "bin", toolName)
+	if _, err := os.Stat(binPath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf(
+				"%s binary not found at %s\nRun 'andurel tool sync' to download it",
+				toolName,
+				binPath,
+			)
+		}
+		return err
+	}
+
+	command := exec.Command(binPath, toolArgs(databaseURL(cfg, cfg.Name))...)
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	command.Stdin = os.Stdin
+	command.Dir = rootDir
+
+	return command.Run()
 }
 
 func newMailpitCommand() *cobra.Command {
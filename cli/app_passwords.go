@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+// passwordHashSettings are the PASSWORD_HASH_* variables of the generated
+// config/auth.go with their defaults.
+var passwordHashSettings = []struct {
+	key, fallback string
+}{
+	{"PASSWORD_HASH_MEMORY", "19456"},
+	{"PASSWORD_HASH_ITERATIONS", "2"},
+	{"PASSWORD_HASH_PARALLELISM", "1"},
+}
+
+// The salt and key lengths the generated models.HashPassword uses.
+const (
+	passwordSaltLength = 16
+	passwordKeyLength  = 32
+)
+
+const legacyPasswordHash = "legacy hash:salt format"
+
+type passwordHashGroup struct {
+	Format  string `json:"format"`
+	Users   int64  `json:"users"`
+	Current bool   `json:"current"`
+}
+
+type passwordAuditReport struct {
+	Current  string              `json:"current"`
+	Users    int64               `json:"users"`
+	Outdated int64               `json:"outdated"`
+	Groups   []passwordHashGroup `json:"groups"`
+}
+
+var fetchPasswordHashesFunc = fetchPasswordHashes
+
+func newAppCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "app",
+		Short: "Application data maintenance commands",
+		Long: `Commands that inspect the application's own tables in the database
+configured in .env.`,
+	}
+	setAgentMetadata(cmd, "database", "Maintenance of application data in the database configured in .env.")
+
+	cmd.AddCommand(newRehashPasswordsCommand())
+
+	return cmd
+}
+
+func newRehashPasswordsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rehash-passwords",
+		Short: "Report users whose password hashes are outdated",
+		Long: `Count the users whose password hash was made with other argon2id
+parameters than PASSWORD_HASH_MEMORY, PASSWORD_HASH_ITERATIONS and
+PASSWORD_HASH_PARALLELISM in .env, or is stored in the legacy hash:salt
+format.
+
+A password can only be rehashed while its plain text is known, so the app
+rehashes outdated hashes when their owners sign in. Run this after changing
+the parameters to follow how many users have yet to sign in again.`,
+		Example: `  andurel app rehash-passwords
+  andurel app rehash-passwords --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			loadProjectEnv(rootDir)
+
+			current, err := currentPasswordHashFormat()
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Minute)
+			defer cancel()
+
+			hashes, err := fetchPasswordHashesFunc(ctx)
+			if err != nil {
+				return err
+			}
+			report := summarizePasswordHashes(hashes, current)
+
+			summary := fmt.Sprintf("%d of %d users have outdated password hashes", report.Outdated, report.Users)
+
+			opts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if opts.Mode == output.ModeHuman {
+				if opts.Quiet {
+					return nil
+				}
+				return renderPasswordAuditHuman(cmd.OutOrStdout(), report, summary)
+			}
+
+			return output.OK(cmd, report, summary)
+		},
+	}
+	setAgentMetadata(cmd, "database", "Read-only count of users per password hash format; outdated hashes are rehashed when their owners sign in.")
+
+	return cmd
+}
+
+// currentPasswordHashFormat describes the hashes the app makes with the
+// PASSWORD_HASH_* settings in the environment.
+func currentPasswordHashFormat() (string, error) {
+	values := make([]uint64, len(passwordHashSettings))
+	for i, setting := range passwordHashSettings {
+		raw := strings.TrimSpace(os.Getenv(setting.key))
+		if raw == "" {
+			raw = setting.fallback
+		}
+		value, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil || value == 0 {
+			return "", fmt.Errorf("%s %q is not a positive number", setting.key, raw)
+		}
+		values[i] = value
+	}
+
+	return fmt.Sprintf(
+		"argon2id v=19 m=%d,t=%d,p=%d salt=%d key=%d",
+		values[0],
+		values[1],
+		values[2],
+		passwordSaltLength,
+		passwordKeyLength,
+	), nil
+}
+
+// passwordHashFormat describes the algorithm and parameters of a stored
+// password hash, in the PHC string format or the legacy hash:salt format.
+func passwordHashFormat(hash string) string {
+	if rest, ok := strings.CutPrefix(hash, "$argon2id$"); ok {
+		parts := strings.Split(rest, "$")
+		if len(parts) == 4 {
+			salt, saltErr := base64.RawStdEncoding.DecodeString(parts[2])
+			key, keyErr := base64.RawStdEncoding.DecodeString(parts[3])
+			if saltErr == nil && keyErr == nil {
+				return fmt.Sprintf("argon2id %s %s salt=%d key=%d", parts[0], parts[1], len(salt), len(key))
+			}
+		}
+		return "unreadable"
+	}
+
+	if parts := strings.Split(hash, ":"); len(parts) == 2 {
+		return legacyPasswordHash
+	}
+
+	return "unreadable"
+}
+
+func summarizePasswordHashes(hashes []string, current string) passwordAuditReport {
+	counts := map[string]int64{}
+	for _, hash := range hashes {
+		counts[passwordHashFormat(hash)]++
+	}
+
+	report := passwordAuditReport{Current: current, Users: int64(len(hashes))}
+	for format, users := range counts {
+		group := passwordHashGroup{Format: format, Users: users, Current: format == current}
+		if !group.Current {
+			report.Outdated += users
+		}
+		report.Groups = append(report.Groups, group)
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Users != report.Groups[j].Users {
+			return report.Groups[i].Users > report.Groups[j].Users
+		}
+		return report.Groups[i].Format < report.Groups[j].Format
+	})
+
+	return report
+}
+
+func fetchPasswordHashes(ctx context.Context) ([]string, error) {
+	conn, err := connectProjectDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	rows, err := conn.Query(ctx, "SELECT password FROM users")
+	if err != nil {
+		return nil, fmt.Errorf("read password hashes: %w", err)
+	}
+	passwords, err := pgx.CollectRows(rows, pgx.RowTo[[]byte])
+	if err != nil {
+		return nil, fmt.Errorf("read password hashes: %w", err)
+	}
+
+	hashes := make([]string, len(passwords))
+	for i, password := range passwords {
+		hashes[i] = string(password)
+	}
+	return hashes, nil
+}
+
+func renderPasswordAuditHuman(w io.Writer, report passwordAuditReport, summary string) error {
+	if report.Users == 0 {
+		_, err := fmt.Fprintln(w, "No users found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "FORMAT\tUSERS\tSTATUS"); err != nil {
+		return err
+	}
+	for _, group := range report.Groups {
+		status := "outdated"
+		if group.Current {
+			status = "current"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%d\t%s\n", group.Format, group.Users, status); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if report.Outdated == 0 {
+		_, err := fmt.Fprintf(w, "\nAll %d users have current password hashes\n", report.Users)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%s; they are rehashed when their owners next sign in\n", summary)
+	return err
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestRehashPasswordsReportsOutdatedHashes(t *testing.T) {
+	resetCLITestSeams(t)
+	t.Setenv("PASSWORD_HASH_MEMORY", "65536")
+	t.Setenv("PASSWORD_HASH_ITERATIONS", "3")
+	t.Setenv("PASSWORD_HASH_PARALLELISM", "")
+
+	// Base64 of 16 and 32 zero bytes.
+	salt := "AAAAAAAAAAAAAAAAAAAAAA"
+	key := "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	fetchPasswordHashesFunc = func(context.Context) ([]string, error) {
+		return []string{
+			"$argon2id$v=19$m=65536,t=3,p=1$" + salt + "$" + key,
+			"$argon2id$v=19$m=19456,t=2,p=1$" + salt + "$" + key,
+			"$argon2id$v=19$m=19456,t=2,p=1$" + salt + "$" + key,
+			key + ":" + salt,
+			"$argon2id$v=19$broken",
+		}, nil
+	}
+
+	result := executeCLITest(t, "app", "rehash-passwords", "--json")
+	if result.err != nil {
+		t.Fatalf("app rehash-passwords failed: %v", result.err)
+	}
+	var envelope struct {
+		Data passwordAuditReport `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &envelope); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, result.stdout)
+	}
+
+	report := envelope.Data
+	if report.Current != "argon2id v=19 m=65536,t=3,p=1 salt=16 key=32" || report.Users != 5 || report.Outdated != 4 {
+		t.Fatalf("unexpected report: %#v", report)
+	}
+	want := []passwordHashGroup{
+		{Format: "argon2id v=19 m=19456,t=2,p=1 salt=16 key=32", Users: 2},
+		{Format: "argon2id v=19 m=65536,t=3,p=1 salt=16 key=32", Users: 1, Current: true},
+		{Format: legacyPasswordHash, Users: 1},
+		{Format: "unreadable", Users: 1},
+	}
+	if len(report.Groups) != len(want) {
+		t.Fatalf("groups = %#v, want %#v", report.Groups, want)
+	}
+	for i := range want {
+		if report.Groups[i] != want[i] {
+			t.Fatalf("groups[%d] = %#v, want %#v", i, report.Groups[i], want[i])
+		}
+	}
+
+	result = executeCLITest(t, "app", "rehash-passwords")
+	if result.err != nil {
+		t.Fatalf("app rehash-passwords failed: %v", result.err)
+	}
+	if !strings.Contains(result.stdout, "4 of 5 users have outdated password hashes; they are rehashed when their owners next sign in") {
+		t.Fatalf("unexpected output:\n%s", result.stdout)
+	}
+}
+
+func TestRehashPasswordsRejectsInvalidSettings(t *testing.T) {
+	resetCLITestSeams(t)
+	t.Setenv("PASSWORD_HASH_MEMORY", "")
+	t.Setenv("PASSWORD_HASH_ITERATIONS", "0")
+	t.Setenv("PASSWORD_HASH_PARALLELISM", "")
+
+	result := executeCLITest(t, "app", "rehash-passwords")
+	if result.err == nil || !strings.Contains(result.err.Error(), "PASSWORD_HASH_ITERATIONS") {
+		t.Fatalf("expected an invalid PASSWORD_HASH_ITERATIONS error, got %v", result.err)
+	}
+}
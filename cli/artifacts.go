@@ -12,6 +12,8 @@ import (
 	"strings"
 
 	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/pkg/cache"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/spf13/cobra"
 )
 
@@ -37,7 +39,11 @@ type mutationOptions struct {
 	CommandsRun []string
 	Warnings    []string
 	Breadcrumbs []output.Breadcrumb
-	Run         func(rootDir string) error
+	// VerifyProject compiles the whole project after the run instead of only
+	// the packages of the changed files, and rolls back on any compiler
+	// error. Commands that delete code set it.
+	VerifyProject bool
+	Run           func(rootDir string) error
 }
 
 type fileSnapshot map[string]fileState
@@ -65,28 +71,42 @@ func runMutation(cmd *cobra.Command, opts mutationOptions) error {
 		return runDryMutation(cmd, outOpts, opts)
 	}
 
-	before, err := snapshotFilesForReport(opts.RootDir)
+	before, after, err := applyMutation(opts.RootDir, cmd.ErrOrStderr(), func() error {
+		oldWD, _ := os.Getwd()
+		if err := os.Chdir(opts.RootDir); err != nil {
+			return err
+		}
+		defer func() { _ = os.Chdir(oldWD) }()
+		return runWithOptionalStdoutSilence(output.SuppressesHumanOutput(outOpts), func() error {
+			return opts.Run(opts.RootDir)
+		})
+	})
 	if err != nil {
 		return err
 	}
 
-	oldWD, _ := os.Getwd()
-	if err := os.Chdir(opts.RootDir); err != nil {
-		return err
-	}
-	runErr := runWithOptionalStdoutSilence(output.SuppressesHumanOutput(outOpts), func() error {
-		return opts.Run(opts.RootDir)
-	})
-	_ = os.Chdir(oldWD)
-	if runErr != nil {
-		return runErr
+	report := buildMutationReport(opts, before, after)
+	var (
+		diagnostics []string
+		verifyErr   error
+	)
+	if opts.VerifyProject {
+		diagnostics, verifyErr = verifyProjectBuildsFunc(opts.RootDir)
+		if len(diagnostics) > 0 {
+			return rollbackBrokenProjectMutation(opts.RootDir, before, after, diagnostics)
+		}
+	} else {
+		diagnostics, verifyErr = verifyGeneratedCodeFunc(opts.RootDir, append(append([]string{}, report.FilesCreated...), report.FilesUpdated...))
+		if len(diagnostics) > 0 {
+			return rollbackUncompilableMutation(opts.RootDir, before, after, diagnostics)
+		}
 	}
-
-	after, err := snapshotFilesForReport(opts.RootDir)
-	if err != nil {
-		return err
+	if verifyErr != nil {
+		report.Warnings = append(report.Warnings, verifyErr.Error())
+		if !output.SuppressesHumanOutput(outOpts) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", verifyErr)
+		}
 	}
-	report := buildMutationReport(opts, before, after)
 	if output.SuppressesHumanOutput(outOpts) {
 		return output.OK(cmd, report, mutationSummary(report), opts.Breadcrumbs...)
 	}
@@ -106,6 +126,9 @@ func runDryMutation(cmd *cobra.Command, outOpts output.Options, opts mutationOpt
 	if err := copyDir(opts.RootDir, tempRoot); err != nil {
 		return err
 	}
+	if err := linkToolDirs(opts.RootDir, tempRoot); err != nil {
+		return err
+	}
 
 	before, err := snapshotFilesForReport(tempRoot)
 	if err != nil {
@@ -120,9 +143,14 @@ func runDryMutation(cmd *cobra.Command, outOpts output.Options, opts mutationOpt
 	findGoModRoot = func() (string, error) {
 		return tempRoot, nil
 	}
+	// The generators cache the project root they found, which is the real
+	// project when it was looked up before the run; they must write to the
+	// copy.
+	cache.ClearFileSystemCache()
 	runErr := runWithOptionalStdoutSilence(output.SuppressesHumanOutput(outOpts), func() error {
 		return opts.Run(tempRoot)
 	})
+	cache.ClearFileSystemCache()
 	findGoModRoot = originalFindGoModRoot
 	_ = os.Chdir(oldWD)
 	if runErr != nil {
@@ -156,11 +184,36 @@ func runDryMutation(cmd *cobra.Command, outOpts output.Options, opts mutationOpt
 				return err
 			}
 		}
+		diff := report.Diff
+		if diff == "" {
+			diff = buildTextDiff(before, after, changedPaths(report))
+		}
+		if diff != "" {
+			if _, err := fmt.Fprintf(cmd.OutOrStdout(), "\n%s", diff); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 	return output.OK(cmd, report, mutationSummary(report), opts.Breadcrumbs...)
 }
 
+// linkToolDirs links the project's bin and node_modules into the dry-run
+// copy, which leaves them out, so generators still find templ and the other
+// project tools.
+func linkToolDirs(rootDir, tempRoot string) error {
+	for _, name := range []string{"bin", "node_modules"} {
+		source := filepath.Join(rootDir, name)
+		if _, err := os.Stat(source); err != nil {
+			continue
+		}
+		if err := os.Symlink(source, filepath.Join(tempRoot, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func buildMutationReport(opts mutationOptions, before, after fileSnapshot) mutationReport {
 	report := mutationReport{
 		Action:       opts.Action,
@@ -201,12 +254,17 @@ func buildMutationReport(opts mutationOptions, before, after fileSnapshot) mutat
 	sort.Strings(report.FilesDeleted)
 	sort.Strings(report.RoutesAdded)
 	if opts.Diff {
-		report.Diff = buildTextDiff(before, after, append(append([]string{}, report.FilesCreated...), report.FilesUpdated...))
+		report.Diff = buildTextDiff(before, after, changedPaths(report))
 	}
 
 	return report
 }
 
+func changedPaths(report mutationReport) []string {
+	paths := append(append([]string{}, report.FilesCreated...), report.FilesUpdated...)
+	return append(paths, report.FilesDeleted...)
+}
+
 func mutationSummary(report mutationReport) string {
 	verb := "Changed"
 	if report.DryRun {
@@ -329,16 +387,34 @@ func copyFile(src, dst string, mode os.FileMode) (err error) {
 	return err
 }
 
+// buildTextDiff returns a unified diff of the text files in paths, with
+// three lines of context, as git diff prints it. Created files are diffed
+// against /dev/null, and files missing from after are shown as deleted.
 func buildTextDiff(before, after fileSnapshot, paths []string) string {
 	var b strings.Builder
 	sort.Strings(paths)
 	for _, path := range paths {
-		afterState := after[path]
-		if !isTextContent(afterState.Content) {
+		beforeState, existed := before[path]
+		afterState, exists := after[path]
+		if !isTextContent(beforeState.Content) || !isTextContent(afterState.Content) {
 			continue
 		}
-		beforeContent := before[path].Content
-		if len(beforeContent) > 0 && !isTextContent(beforeContent) {
+
+		fromFile, toFile := "a/"+path, "b/"+path
+		if !existed {
+			fromFile = "/dev/null"
+		}
+		if !exists {
+			toFile = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitDiffLines(beforeState.Content),
+			B:        splitDiffLines(afterState.Content),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil || diff == "" {
 			continue
 		}
 		b.WriteString("diff --git a/")
@@ -346,21 +422,40 @@ func buildTextDiff(before, after fileSnapshot, paths []string) string {
 		b.WriteString(" b/")
 		b.WriteString(path)
 		b.WriteByte('\n')
-		if len(beforeContent) == 0 {
-			b.WriteString("--- /dev/null\n")
-		} else {
-			b.WriteString("--- a/")
-			b.WriteString(path)
-			b.WriteByte('\n')
-		}
-		b.WriteString("+++ b/")
-		b.WriteString(path)
-		b.WriteByte('\n')
-		b.WriteString(simpleLineDiff(string(beforeContent), string(afterState.Content)))
+		if !existed {
+			fmt.Fprintf(&b, "new file mode %o\n", gitFileMode(afterState.Mode))
+		}
+		if !exists {
+			fmt.Fprintf(&b, "deleted file mode %o\n", gitFileMode(beforeState.Mode))
+		}
+		b.WriteString(diff)
 	}
 	return b.String()
 }
 
+// gitFileMode returns the mode git records for a file: 100755 when it is
+// executable, else 100644.
+func gitFileMode(mode os.FileMode) uint32 {
+	if mode&0o111 != 0 {
+		return 0o100755
+	}
+	return 0o100644
+}
+
+// splitDiffLines splits content into lines that keep their newline, adding
+// one to a last line without it so the diff stays line-oriented.
+func splitDiffLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
+
 func runWithOptionalStdoutSilence(silence bool, run func() error) error {
 	if !silence {
 		return run()
@@ -392,22 +487,3 @@ func runWithOptionalStdoutSilence(silence bool, run func() error) error {
 func isTextContent(content []byte) bool {
 	return !bytes.Contains(content, []byte{0})
 }
-
-func simpleLineDiff(before, after string) string {
-	var b strings.Builder
-	if before != "" {
-		for line := range strings.SplitSeq(strings.TrimSuffix(before, "\n"), "\n") {
-			b.WriteString("-")
-			b.WriteString(line)
-			b.WriteByte('\n')
-		}
-	}
-	if after != "" {
-		for line := range strings.SplitSeq(strings.TrimSuffix(after, "\n"), "\n") {
-			b.WriteString("+")
-			b.WriteString(line)
-			b.WriteByte('\n')
-		}
-	}
-	return b.String()
-}
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/pkg/cache"
 	"github.com/spf13/cobra"
 )
 
@@ -71,6 +73,15 @@ func TestBuildMutationReportSummarizesChangesRoutesAndDiff(t *testing.T) {
 	if strings.Contains(report.Diff, "assets/logo.png") {
 		t.Fatalf("binary file should be omitted from diff:\n%s", report.Diff)
 	}
+	for _, want := range []string{
+		"--- a/app/models/post.go\n+++ b/app/models/post.go\n@@ -1 +1 @@\n-old\n+new\n",
+		"new file mode 100644\n--- /dev/null\n+++ b/router/routes/posts.go\n@@ -0,0 +1 @@\n+posts route\n",
+		"deleted file mode 100644\n--- a/router/routes/old.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-old route\n",
+	} {
+		if !strings.Contains(report.Diff, want) {
+			t.Fatalf("expected diff to contain %q:\n%s", want, report.Diff)
+		}
+	}
 	if got, want := mutationSummary(report), "Would change 4 files for generate model"; got != want {
 		t.Fatalf("mutationSummary = %q, want %q", got, want)
 	}
@@ -183,6 +194,100 @@ func TestRunMutationDryRunReportsChangesWithoutMutatingOriginal(t *testing.T) {
 	}
 }
 
+func TestRunMutationDryRunPrintsUnifiedDiff(t *testing.T) {
+	root := t.TempDir()
+	lines := []string{"package models", "", "type Post struct {", "\tTitle string", "}", "", "func a() {}", "func b() {}", "func c() {}", "func d() {}"}
+	writeTestFile(t, root, "app/models/post.go", strings.Join(lines, "\n")+"\n")
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{Use: "andurel"}
+	output.RegisterPersistentFlags(cmd)
+	cmd.SetOut(&out)
+
+	err := runMutation(cmd, mutationOptions{
+		Action:  "generate model",
+		RootDir: root,
+		DryRun:  true,
+		Run: func(rootDir string) error {
+			changed := append([]string{}, lines...)
+			changed[3] = "\tTitle string\n\tBody  string"
+			writeTestFile(t, rootDir, "app/models/post.go", strings.Join(changed, "\n")+"\n")
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("runMutation dry run: %v", err)
+	}
+
+	want := `Dry run: Would change 1 files for generate model
+  update app/models/post.go
+
+diff --git a/app/models/post.go b/app/models/post.go
+--- a/app/models/post.go
++++ b/app/models/post.go
+@@ -2,6 +2,7 @@
+ 
+ type Post struct {
+ 	Title string
++	Body  string
+ }
+ 
+ func a() {}
+`
+	if out.String() != want {
+		t.Fatalf("dry run output:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestRunMutationDryRunWritesToCopyWhenRootIsCached(t *testing.T) {
+	cache.ClearFileSystemCache()
+	t.Cleanup(cache.ClearFileSystemCache)
+
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "bin/templ", "#!/bin/sh\n")
+	oldWD, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(root); err != nil {
+		t.Fatal(err)
+	}
+	_, err = files.NewUnifiedFileManager().FindGoModRoot()
+	if chdirErr := os.Chdir(oldWD); chdirErr != nil {
+		t.Fatal(chdirErr)
+	}
+	if err != nil {
+		t.Fatalf("FindGoModRoot: %v", err)
+	}
+
+	cmd := &cobra.Command{Use: "andurel"}
+	output.RegisterPersistentFlags(cmd)
+	cmd.SetOut(&bytes.Buffer{})
+	err = runMutation(cmd, mutationOptions{
+		Action:  "generate model",
+		RootDir: root,
+		DryRun:  true,
+		Run: func(string) error {
+			projectRoot, err := files.NewUnifiedFileManager().FindGoModRoot()
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(filepath.Join(projectRoot, "bin", "templ")); err != nil {
+				return err
+			}
+			writeTestFile(t, projectRoot, "models/post.go", "package models\n")
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("runMutation dry run: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "models", "post.go")); !os.IsNotExist(err) {
+		t.Fatalf("dry run wrote to the project, stat err: %v", err)
+	}
+}
+
 func TestRunMutationErrorsForMissingRunnerAndRoot(t *testing.T) {
 	cmd := &cobra.Command{Use: "andurel"}
 	output.RegisterPersistentFlags(cmd)
@@ -9,12 +9,15 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/layout/versions"
 	"github.com/spf13/cobra"
 )
 
+var buildNow = time.Now
+
 func newBuildCommand() *cobra.Command {
 	var versionFlag string
 
@@ -29,7 +32,8 @@ This command:
   • Builds Vite assets (if Inertia is configured)
   • Downloads Go dependencies
   • Compiles the application binary as a static Linux binary
-  • Injects a version string via ldflags (from --version or git describe)`,
+  • Injects the version (from --version or git describe), git commit,
+    build time and andurel version into internal/buildinfo via ldflags`,
 		Example: `  andurel build
   andurel build --version 1.2.3`,
 		Args: cobra.ExactArgs(0),
@@ -150,10 +154,13 @@ func buildApp(rootDir string, versionFlag string) error {
 		}
 	}
 
-	args := []string{"build", "-v"}
-	if appVersion != "" {
-		args = append(args, "-ldflags", fmt.Sprintf("-X main.appVersion=%s", appVersion))
+	modulePath, err := readModulePathFrom(rootDir)
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
 	}
+	commit, _ := detectGitCommit(rootDir)
+
+	args := []string{"build", "-v", "-ldflags", buildLdflags(modulePath, appVersion, commit, lock.Version)}
 	args = append(args, "-o", binName, "./cmd/app")
 
 	fmt.Printf("Building %s...\n", binName)
@@ -214,6 +221,33 @@ func inertiaPackageManagerCommands(runtime string) (shellCommand, shellCommand,
 	}
 }
 
+// buildLdflags sets the build information of internal/buildinfo. main.appVersion
+// is kept for applications generated before internal/buildinfo existed.
+func buildLdflags(modulePath, appVersion, commit, frameworkVersion string) string {
+	pkg := modulePath + "/internal/buildinfo"
+	flags := []string{"-X " + pkg + ".BuildTime=" + buildNow().UTC().Format(time.RFC3339)}
+	if appVersion != "" {
+		flags = append(flags, "-X "+pkg+".Version="+appVersion, "-X main.appVersion="+appVersion)
+	}
+	if commit != "" {
+		flags = append(flags, "-X "+pkg+".Commit="+commit)
+	}
+	if frameworkVersion != "" {
+		flags = append(flags, "-X "+pkg+".Framework="+frameworkVersion)
+	}
+	return strings.Join(flags, " ")
+}
+
+func detectGitCommit(rootDir string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = rootDir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 func detectGitVersion(rootDir string) (string, error) {
 	cmd := exec.Command("git", "describe", "--tags", "--always", "--dirty")
 	cmd.Dir = rootDir
@@ -225,6 +259,15 @@ func detectGitVersion(rootDir string) (string, error) {
 }
 
 func extractModuleName(rootDir string) (string, error) {
+	modulePath, err := readModulePathFrom(rootDir)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(modulePath, "/")
+	return parts[len(parts)-1], nil
+}
+
+func readModulePathFrom(rootDir string) (string, error) {
 	content, err := os.ReadFile(filepath.Join(rootDir, "go.mod"))
 	if err != nil {
 		return "", fmt.Errorf("could not open go.mod: %w", err)
@@ -233,9 +276,7 @@ func extractModuleName(rootDir string) (string, error) {
 	for scanner.Scan() {
 		line := strings.TrimSpace(scanner.Text())
 		if strings.HasPrefix(line, "module ") {
-			modulePath := strings.TrimSpace(strings.TrimPrefix(line, "module"))
-			parts := strings.Split(modulePath, "/")
-			return parts[len(parts)-1], nil
+			return strings.TrimSpace(strings.TrimPrefix(line, "module")), nil
 		}
 	}
 	return "", fmt.Errorf("module directive not found in go.mod")
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mbvlabs/andurel/layout"
 )
@@ -155,6 +156,10 @@ func TestBuildAppRunsExpectedToolchain(t *testing.T) {
 		return nil
 	}
 
+	originalBuildNow := buildNow
+	t.Cleanup(func() { buildNow = originalBuildNow })
+	buildNow = func() time.Time { return time.Date(2026, 10, 18, 9, 30, 0, 0, time.FixedZone("CEST", 2*60*60)) }
+
 	if err := buildApp(root, "1.2.3"); err != nil {
 		t.Fatalf("buildApp: %v", err)
 	}
@@ -169,7 +174,9 @@ func TestBuildAppRunsExpectedToolchain(t *testing.T) {
 		"pnpm install --frozen-lockfile",
 		"pnpm run build",
 		"go mod download",
-		"go build -v -ldflags -X main.appVersion=1.2.3 -o app ./cmd/app",
+		"go build -v -ldflags -X example.com/app/internal/buildinfo.BuildTime=2026-10-18T07:30:00Z " +
+			"-X example.com/app/internal/buildinfo.Version=1.2.3 -X main.appVersion=1.2.3 " +
+			"-X example.com/app/internal/buildinfo.Framework=test -o app ./cmd/app",
 	} {
 		if !strings.Contains(log, want) {
 			t.Fatalf("command log missing %q:\n%s", want, log)
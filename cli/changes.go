@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout/upgrade"
+	"github.com/spf13/cobra"
+)
+
+func newChangesCommand(version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Show upgrade notes for this project",
+		Long: `Show the breaking changes and manual steps between the framework version in
+andurel.lock and the installed CLI version.
+
+The notes come from the changelog embedded in the CLI. The command also lists
+the files andurel upgrade will not migrate: application-owned files the manual
+steps touch, and framework files that no longer carry the andurel version
+marker. Use --to to check a different release.`,
+		Example: `  andurel changes
+  andurel changes --to v1.6.0
+  andurel changes --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := cmd.Flags().GetString("to")
+			if err != nil {
+				return err
+			}
+			if target == "" {
+				target = version
+			}
+			return runChanges(cmd, target)
+		},
+	}
+
+	cmd.Flags().String("to", "", "Target Andurel version (default: the installed CLI version)")
+	setAgentMetadata(cmd, "introspection", "Read-only upgrade notes: breaking changes, manual steps, and files upgrade cannot migrate.")
+	return cmd
+}
+
+func runChanges(cmd *cobra.Command, target string) error {
+	canonical, ok := canonicalAndurelVersion(target)
+	if !ok {
+		return output.NewError(
+			output.CodeUsage,
+			fmt.Sprintf("%q is not a release version", target),
+			output.ExitUsage,
+			"Pass a release with --to, e.g. 'andurel changes --to v1.6.0'.",
+		)
+	}
+
+	projectRoot, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+	report, err := upgrade.BuildChangesReport(projectRoot, canonical)
+	if err != nil {
+		return err
+	}
+
+	opts, err := output.ParseOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.Mode == output.ModeHuman {
+		if opts.Quiet {
+			return nil
+		}
+		return renderChangesHuman(cmd.OutOrStdout(), report)
+	}
+
+	summary := fmt.Sprintf(
+		"%d releases with upgrade notes, %d files to migrate by hand",
+		len(report.Changes),
+		len(report.ManualFiles),
+	)
+	return output.OK(cmd, report, summary, output.Breadcrumb{Command: "andurel upgrade --dry-run --json", Description: "Preview the automatic upgrade"})
+}
+
+func renderChangesHuman(w io.Writer, report *upgrade.ChangesReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Changes from %s to %s\n", report.FromVersion, report.ToVersion)
+
+	if len(report.Changes) == 0 {
+		b.WriteString("\nNo breaking changes or manual steps.\n")
+	}
+	for _, entry := range report.Changes {
+		fmt.Fprintf(&b, "\n%s\n", entry.Version)
+		if len(entry.Breaking) > 0 {
+			b.WriteString("  Breaking changes:\n")
+			for _, change := range entry.Breaking {
+				fmt.Fprintf(&b, "    - %s\n", change)
+			}
+		}
+		if len(entry.ManualSteps) > 0 {
+			b.WriteString("  Manual steps:\n")
+			for i, step := range entry.ManualSteps {
+				fmt.Fprintf(&b, "    %d. %s\n", i+1, step)
+			}
+		}
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return err
+	}
+
+	if len(report.ManualFiles) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "\nFiles to migrate by hand:"); err != nil {
+		return err
+	}
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, file := range report.ManualFiles {
+		reason := file.Reason
+		if file.Version != "" {
+			reason = file.Version + ": " + reason
+		}
+		if _, err := fmt.Fprintf(table, "  %s\t%s\n", file.Path, reason); err != nil {
+			return err
+		}
+	}
+	return table.Flush()
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout/upgrade"
+)
+
+func TestChangesRequiresReleaseVersion(t *testing.T) {
+	result := runCLITest(t, "changes")
+	if result.err == nil {
+		t.Fatal("expected a development CLI version to be rejected")
+	}
+	if code := output.ExitCode(result.err); code != output.ExitUsage {
+		t.Fatalf("exit code = %d, want %d: %v", code, output.ExitUsage, result.err)
+	}
+	if !strings.Contains(result.err.Error(), `"test" is not a release version`) {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+}
+
+func TestRenderChangesHuman(t *testing.T) {
+	report := &upgrade.ChangesReport{
+		FromVersion: "v1.5.2",
+		ToVersion:   "v1.6.0",
+		Changes: []upgrade.ChangelogEntry{{
+			Version:     "v1.5.4",
+			Breaking:    []string{"Session recovery moved into the router."},
+			ManualSteps: []string{"Add router/cookies/session.go.", "Run go vet ./..."},
+		}},
+		ManualFiles: []upgrade.ManualFile{
+			{Path: "router/cookies/session.go", Version: "v1.5.4", Reason: "application-owned"},
+			{Path: "internal/storage/psql.go", Reason: "no version marker"},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := renderChangesHuman(&out, report); err != nil {
+		t.Fatalf("renderChangesHuman: %v", err)
+	}
+	for _, want := range []string{
+		"Changes from v1.5.2 to v1.6.0",
+		"    - Session recovery moved into the router.",
+		"    2. Run go vet ./...",
+		"Files to migrate by hand:",
+		"router/cookies/session.go  v1.5.4: application-owned",
+		"internal/storage/psql.go   no version marker",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := renderChangesHuman(&out, &upgrade.ChangesReport{FromVersion: "v1.5.4", ToVersion: "v1.6.0"}); err != nil {
+		t.Fatalf("renderChangesHuman: %v", err)
+	}
+	if !strings.Contains(out.String(), "No breaking changes or manual steps.") || strings.Contains(out.String(), "Files to migrate") {
+		t.Fatalf("unexpected empty report output:\n%s", out.String())
+	}
+}
@@ -108,16 +108,21 @@ func NewRootCommand(version, date string) *cobra.Command {
 
 	rootCmd.AddCommand(newProjectCommand(version))
 	rootCmd.AddCommand(newGenerateCommand())
+	rootCmd.AddCommand(newDestroyCommand())
+	rootCmd.AddCommand(newRenameModuleCommand())
 	rootCmd.AddCommand(newFmtCommand())
 	rootCmd.AddCommand(newDatabaseCommand())
 
 	rootCmd.AddCommand(newRunAppCommand())
 	rootCmd.AddCommand(newConsoleCommand())
+	rootCmd.AddCommand(newAppCommand())
 	rootCmd.AddCommand(newToolCommand())
 	rootCmd.AddCommand(newExtensionCommand())
 	rootCmd.AddCommand(newBuildCommand())
 	rootCmd.AddCommand(newUpgradeCommand(version))
+	rootCmd.AddCommand(newChangesCommand(version))
 	rootCmd.AddCommand(newDoctorCommand(version))
+	rootCmd.AddCommand(newSupportCommand(version))
 	rootCmd.AddCommand(newCommandsCommand(rootCmd))
 	rootCmd.AddCommand(newProjectInfoCommand())
 	rootCmd.AddCommand(newRoutesCommand())
@@ -126,6 +131,11 @@ func NewRootCommand(version, date string) *cobra.Command {
 	rootCmd.AddCommand(newControllersCommand())
 	rootCmd.AddCommand(newViewsCommand())
 	rootCmd.AddCommand(newJobsCommand())
+	rootCmd.AddCommand(newStatsCommand())
+	rootCmd.AddCommand(newQueriesCommand())
+	rootCmd.AddCommand(newLoadTestCommand())
+	rootCmd.AddCommand(newQueueCommand())
+	rootCmd.AddCommand(newReplayCommand())
 	rootCmd.AddCommand(newConfigCommand())
 	rootCmd.AddCommand(newSkillCommand())
 
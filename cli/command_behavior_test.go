@@ -2,14 +2,18 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/layout"
 )
 
@@ -84,6 +88,99 @@ func TestGenerateModelMapsFlagsToGenerator(t *testing.T) {
 	}
 }
 
+func TestGenerateModelQualifiesTableWithSchema(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	for _, args := range [][]string{
+		{"generate", "model", "Billing.Invoice", "--schema", "billing"},
+		{"generate", "model", "Invoice", "--schema", "billing"},
+	} {
+		if result := executeCLITest(t, args...); result.err != nil {
+			t.Fatalf("%v failed: %v", args, result.err)
+		}
+	}
+	if result := executeCLITest(t, "generate", "model", "Ledger", "--schema", "billing", "--table-name", "ledger_entries"); result.err != nil {
+		t.Fatalf("generate model with --table-name failed: %v", result.err)
+	}
+
+	want := []modelCall{
+		{name: "Invoice", tableName: "billing.invoices"},
+		{name: "Invoice", tableName: "billing.invoices"},
+		{name: "Ledger", tableName: "billing.ledger_entries"},
+	}
+	if !reflect.DeepEqual(fake.modelCalls, want) {
+		t.Fatalf("model calls: expected %#v, got %#v", want, fake.modelCalls)
+	}
+
+	result := executeCLITest(t, "generate", "model", "Billing.Invoice", "--schema", "sales")
+	if result.err == nil || !strings.Contains(result.err.Error(), "is in schema billing, but --schema is sales") {
+		t.Fatalf("expected schema mismatch error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelFromView(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	if result := executeCLITest(t, "generate", "model", "ReportRow", "--from-view", "report_rows"); result.err != nil {
+		t.Fatalf("generate model --from-view failed: %v", result.err)
+	}
+	want := []modelCall{{name: "ReportRow", tableName: "report_rows"}}
+	if !reflect.DeepEqual(fake.modelCalls, want) || !fake.fromView {
+		t.Fatalf("model calls: expected %#v from a view, got %#v (fromView=%t)", want, fake.modelCalls, fake.fromView)
+	}
+
+	result := executeCLITest(t, "generate", "model", "ReportRow", "--from-view", "report_rows", "--table-name", "reports")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--from-view and --table-name") {
+		t.Fatalf("expected a conflicting flags error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelWithTests(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	if result := executeCLITest(t, "generate", "model", "Post", "--with-tests"); result.err != nil {
+		t.Fatalf("generate model --with-tests failed: %v", result.err)
+	}
+	if len(fake.modelCalls) != 1 || !fake.withTests {
+		t.Fatalf("expected one model call with tests, got %#v (withTests=%t)", fake.modelCalls, fake.withTests)
+	}
+
+	result := executeCLITest(t, "generate", "model", "Post", "--with-tests", "--update")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--with-tests cannot be combined with --update") {
+		t.Fatalf("expected a conflicting flags error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelMapsDatabaseNameFlag(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+	var connectedTo string
+	connectSchemaDatabaseFunc = func(_ context.Context, databaseName string) (generator.SchemaQuerier, func(), error) {
+		connectedTo = databaseName
+		return &fakeSchemaDatabase{}, func() {}, nil
+	}
+
+	if result := executeCLITest(t, "generate", "model", "Event", "--database-name", "analytics", "--from-db"); result.err != nil {
+		t.Fatalf("generate model --database-name failed: %v", result.err)
+	}
+	if fake.databaseName != "analytics" || connectedTo != "analytics" {
+		t.Fatalf("expected the analytics database, got generator %q and connection %q", fake.databaseName, connectedTo)
+	}
+
+	result := executeCLITest(t, "generate", "model", "Event", "--database-name", "analytics")
+	if result.err == nil || !strings.Contains(result.err.Error(), filepath.Join("database", "analytics", "migrations")+" does not exist") {
+		t.Fatalf("expected a missing migrations directory error, got %v", result.err)
+	}
+
+	result = executeCLITest(t, "generate", "model", "Event", "--database-name", "Analytics", "--from-db")
+	if result.err == nil || !strings.Contains(result.err.Error(), "lower-case") {
+		t.Fatalf("expected an invalid database name error, got %v", result.err)
+	}
+}
+
 func TestGenerateModelMapsPrimaryKeyToGenerator(t *testing.T) {
 	resetCLITestSeams(t)
 	fake := installFakeGenerator(t)
@@ -107,11 +204,135 @@ func TestGenerateModelMapsPrimaryKeyToGenerator(t *testing.T) {
 	}
 }
 
+func TestGenerateModelMapsAssociationsToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "model", "Comment", "--skip-factory", "--belongs-to", "Post", "--belongs-to", "User", "--has-many", "Reaction")
+	if result.err != nil {
+		t.Fatalf("generate model failed: %v", result.err)
+	}
+
+	want := []associationCall{{
+		name:        "Comment",
+		skipFactory: true,
+		associations: models.Associations{
+			BelongsTo: []string{"Post", "User"},
+			HasMany:   []string{"Reaction"},
+		},
+	}}
+	if !reflect.DeepEqual(fake.associationCalls, want) {
+		t.Fatalf("association calls: expected %#v, got %#v", want, fake.associationCalls)
+	}
+	if len(fake.modelCalls) != 0 {
+		t.Fatalf("expected GenerateModel not to be called, got %#v", fake.modelCalls)
+	}
+
+	result = executeCLITest(t, "generate", "model", "Comment", "--update", "--belongs-to", "Post")
+	if result.err == nil || !strings.Contains(result.err.Error(), "cannot be combined with --update") {
+		t.Fatalf("expected --update conflict error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelMapsJSONTypesToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "model", "User", "--json-type", "settings=UserSettings", "--json-type", "address=Address")
+	if result.err != nil {
+		t.Fatalf("generate model failed: %v", result.err)
+	}
+
+	want := []associationCall{{
+		name:      "User",
+		jsonTypes: map[string]string{"settings": "UserSettings", "address": "Address"},
+	}}
+	if !reflect.DeepEqual(fake.associationCalls, want) {
+		t.Fatalf("json type calls: expected %#v, got %#v", want, fake.associationCalls)
+	}
+
+	result = executeCLITest(t, "generate", "model", "User", "--update", "--json-type", "settings=UserSettings")
+	if result.err == nil || !strings.Contains(result.err.Error(), "cannot be combined with --update") {
+		t.Fatalf("expected --update conflict error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelMapsNullablePointersFlag(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "model", "User", "--nullable-pointers")
+	if result.err != nil {
+		t.Fatalf("generate model failed: %v", result.err)
+	}
+	if !fake.nullablePointers {
+		t.Fatal("expected --nullable-pointers to enable pointer null types")
+	}
+
+	result = executeCLITest(t, "generate", "model", "User", "--update", "--nullable-pointers")
+	if result.err == nil || !strings.Contains(result.err.Error(), "cannot be combined with --update") {
+		t.Fatalf("expected --update conflict error, got %v", result.err)
+	}
+}
+
+func TestGenerateModelMapsConflictOnFlag(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "model", "User", "--conflict-on", "tenant_id,email")
+	if result.err != nil {
+		t.Fatalf("generate model failed: %v", result.err)
+	}
+	if want := []string{"tenant_id", "email"}; !reflect.DeepEqual(fake.conflictColumns, want) {
+		t.Fatalf("conflict columns: expected %v, got %v", want, fake.conflictColumns)
+	}
+
+	result = executeCLITest(t, "generate", "model", "User", "--update", "--conflict-on", "email")
+	if result.err == nil || !strings.Contains(result.err.Error(), "cannot be combined with --update") {
+		t.Fatalf("expected --update conflict error, got %v", result.err)
+	}
+}
+
+type fakeSchemaDatabase struct {
+	generator.SchemaQuerier
+	closed bool
+}
+
+func TestGenerateModelMapsFromDBFlag(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+	db := &fakeSchemaDatabase{}
+	connectSchemaDatabaseFunc = func(context.Context, string) (generator.SchemaQuerier, func(), error) {
+		return db, func() { db.closed = true }, nil
+	}
+
+	result := executeCLITest(t, "generate", "model", "Customer", "--from-db")
+	if result.err != nil {
+		t.Fatalf("generate model failed: %v", result.err)
+	}
+	if fake.schemaDB != db || !db.closed {
+		t.Fatalf("expected the generator to read the database and the connection to close, got %v closed=%v", fake.schemaDB, db.closed)
+	}
+
+	var updateDB generator.SchemaQuerier
+	runModelUpdateFunc = func(_ string, _ bool, _ bool, schemaDB generator.SchemaQuerier) error {
+		updateDB = schemaDB
+		return nil
+	}
+	result = executeCLITest(t, "generate", "model", "Customer", "--update", "--from-db")
+	if result.err != nil {
+		t.Fatalf("generate model update failed: %v", result.err)
+	}
+	if updateDB != db {
+		t.Fatalf("expected --update to read the database, got %v", updateDB)
+	}
+}
+
 func TestGenerateModelUpdateMapsYesFlag(t *testing.T) {
 	resetCLITestSeams(t)
 	var gotName string
 	var gotAutoApply bool
-	runModelUpdateFunc = func(resourceName string, autoApply bool, skipFactory bool) error {
+	runModelUpdateFunc = func(resourceName string, autoApply bool, skipFactory bool, _ generator.SchemaQuerier) error {
 		gotName = resourceName
 		gotAutoApply = autoApply
 		return nil
@@ -232,6 +453,178 @@ func TestGenerateScaffoldAPINestsNamespaceUnderAPI(t *testing.T) {
 	}
 }
 
+func TestGenerateScaffoldPassesFiltersToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "scaffold", "admin/Order", "--filters", "created_at,status")
+	if result.err != nil {
+		t.Fatalf("generate scaffold failed: %v", result.err)
+	}
+
+	want := []filterCall{{
+		name:      "Order",
+		namespace: "admin",
+		columns:   []string{"created_at", "status"},
+	}}
+	if !reflect.DeepEqual(fake.filterCalls, want) {
+		t.Fatalf("filter calls: expected %#v, got %#v", want, fake.filterCalls)
+	}
+}
+
+func TestGenerateScaffoldRejectsFiltersWithAPI(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "scaffold", "Order", "--api", "--filters", "status")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--filters cannot be used") {
+		t.Fatalf("expected --filters conflict error, got %v", result.err)
+	}
+	if len(fake.scaffoldCalls) != 0 || len(fake.filterCalls) != 0 {
+		t.Fatalf("expected no generator calls, got %#v %#v", fake.scaffoldCalls, fake.filterCalls)
+	}
+}
+
+func TestGenerateScaffoldWithFeedRunsFeedAfterScaffold(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "scaffold", "Post", "--with-feed")
+	if result.err != nil {
+		t.Fatalf("generate scaffold failed: %v", result.err)
+	}
+	if len(fake.scaffoldCalls) != 1 {
+		t.Fatalf("expected one scaffold call, got %#v", fake.scaffoldCalls)
+	}
+	want := []feedCall{{name: "Post"}}
+	if !reflect.DeepEqual(fake.feedCalls, want) {
+		t.Fatalf("feed calls: expected %#v, got %#v", want, fake.feedCalls)
+	}
+}
+
+func TestGenerateScaffoldRejectsWithFeedForAPI(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "scaffold", "Post", "--with-feed", "--api")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--with-feed cannot be used with --api") {
+		t.Fatalf("expected --with-feed conflict error, got %v", result.err)
+	}
+	if len(fake.scaffoldCalls) != 0 || len(fake.feedCalls) != 0 {
+		t.Fatalf("expected no generator calls, got %#v %#v", fake.scaffoldCalls, fake.feedCalls)
+	}
+}
+
+func TestGenerateScaffoldWithAddressRunsAddressBeforeScaffold(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+	scaffoldsBefore := -1
+	generateAddressFunc = func(gen cliGenerator, _ string, resourceName, tableName string) error {
+		scaffoldsBefore = len(fake.scaffoldCalls)
+		return gen.GenerateAddress(resourceName, tableName)
+	}
+
+	result := executeCLITest(t, "generate", "scaffold", "Store", "--with-address", "--table-name", "shops")
+	if result.err != nil {
+		t.Fatalf("generate scaffold failed: %v", result.err)
+	}
+	if scaffoldsBefore != 0 || len(fake.scaffoldCalls) != 1 {
+		t.Fatalf("expected addresses before the scaffold, got %d then %#v", scaffoldsBefore, fake.scaffoldCalls)
+	}
+	want := []addressCall{{name: "Store", tableName: "shops"}}
+	if !reflect.DeepEqual(fake.addressCalls, want) {
+		t.Fatalf("address calls: expected %#v, got %#v", want, fake.addressCalls)
+	}
+}
+
+func TestGenerateScaffoldRejectsWithAddressForAPI(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "scaffold", "Store", "--with-address", "--api")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--with-address cannot be used with --api or --inertia") {
+		t.Fatalf("expected --with-address conflict error, got %v", result.err)
+	}
+	if len(fake.scaffoldCalls) != 0 || len(fake.addressCalls) != 0 {
+		t.Fatalf("expected no generator calls, got %#v %#v", fake.scaffoldCalls, fake.addressCalls)
+	}
+}
+
+func TestGenerateSharePassesExpiresToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "share", "Invoice", "--expires", "720h")
+	if result.err != nil {
+		t.Fatalf("generate share failed: %v", result.err)
+	}
+
+	want := []shareCall{{name: "Invoice", tableName: "invoices", ttl: 720 * time.Hour}}
+	if !reflect.DeepEqual(fake.shareCalls, want) {
+		t.Fatalf("share calls: expected %#v, got %#v", want, fake.shareCalls)
+	}
+}
+
+func TestGenerateShareRejectsNegativeExpires(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "share", "Invoice", "--expires", "-1h")
+	if result.err == nil || !strings.Contains(result.err.Error(), "--expires cannot be negative") {
+		t.Fatalf("expected negative --expires error, got %v", result.err)
+	}
+	if len(fake.shareCalls) != 0 {
+		t.Fatalf("expected no generator calls, got %#v", fake.shareCalls)
+	}
+}
+
+func TestGenerateSerializerPassesOptionsToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "serializer", "Order",
+		"--only", "id,total_cents",
+		"--computed", "summary",
+		"--computed", "item_count:int",
+		"--include", "Customer",
+		"--include", "LineItem",
+	)
+	if result.err != nil {
+		t.Fatalf("generate serializer failed: %v", result.err)
+	}
+
+	want := []serializerCall{{
+		name: "Order",
+		opts: generator.SerializerOptions{
+			Only:     []string{"id", "total_cents"},
+			Computed: []string{"summary", "item_count:int"},
+			Include:  []string{"Customer", "LineItem"},
+		},
+	}}
+	if !reflect.DeepEqual(fake.serializerCalls, want) {
+		t.Fatalf("serializer calls: expected %#v, got %#v", want, fake.serializerCalls)
+	}
+}
+
+func TestGenerateCalendarPassesColumnsToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "generate", "calendar", "Event", "--starts-at", "begins_at", "--title", "headline")
+	if result.err != nil {
+		t.Fatalf("generate calendar failed: %v", result.err)
+	}
+
+	want := []calendarCall{{
+		name:      "Event",
+		tableName: "events",
+		columns:   generator.CalendarColumns{StartsAt: "begins_at", Title: "headline"},
+	}}
+	if !reflect.DeepEqual(fake.calendarCalls, want) {
+		t.Fatalf("calendar calls: expected %#v, got %#v", want, fake.calendarCalls)
+	}
+}
+
 func TestGenerateScaffoldRejectsInvalidNamespaceBeforeGenerator(t *testing.T) {
 	resetCLITestSeams(t)
 	fake := installFakeGenerator(t)
@@ -245,6 +638,84 @@ func TestGenerateScaffoldRejectsInvalidNamespaceBeforeGenerator(t *testing.T) {
 	}
 }
 
+func TestDestroyResourceMapsNamespaceToGenerator(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "destroy", "resource", "admin/Widget")
+	if result.err != nil {
+		t.Fatalf("destroy resource failed: %v", result.err)
+	}
+
+	want := []destroyCall{{name: "Widget", namespace: "admin"}}
+	if !reflect.DeepEqual(fake.destroyCalls, want) {
+		t.Fatalf("destroy calls: expected %#v, got %#v", want, fake.destroyCalls)
+	}
+}
+
+func TestDestroyResourceAPINestsNamespaceUnderAPI(t *testing.T) {
+	resetCLITestSeams(t)
+	fake := installFakeGenerator(t)
+
+	result := executeCLITest(t, "destroy", "resource", "User", "--api")
+	if result.err != nil {
+		t.Fatalf("destroy resource failed: %v", result.err)
+	}
+
+	want := []destroyCall{{name: "User", namespace: "api"}}
+	if !reflect.DeepEqual(fake.destroyCalls, want) {
+		t.Fatalf("destroy calls: expected %#v, got %#v", want, fake.destroyCalls)
+	}
+}
+
+func TestDestroyResourceRollsBackWhenOtherCodeStillUsesIt(t *testing.T) {
+	resetCLITestSeams(t)
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	verifyProjectBuildsFunc = verifyProjectBuilds
+
+	rootDir := t.TempDir()
+	writeTestFile(t, rootDir, "go.mod", "module example.com/app\n\ngo 1.21\n")
+	writeTestFile(t, rootDir, "models/product.go", "package models\n\ntype Product struct{ Name string }\n")
+	writeTestFile(t, rootDir, "services/report.go", "package services\n\nimport \"example.com/app/models\"\n\nfunc Report(p models.Product) string { return p.Name }\n")
+
+	originalWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(originalWD)
+	})
+	findGoModRoot = func() (string, error) {
+		return rootDir, nil
+	}
+
+	fake := installFakeGenerator(t)
+	fake.onDestroy = func() {
+		if err := os.Remove(filepath.Join(rootDir, "models", "product.go")); err != nil {
+			t.Errorf("remove model: %v", err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := NewRootCommand("test", "test-date")
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs([]string{"destroy", "resource", "Product"})
+
+	err = cmd.Execute()
+	if err == nil {
+		t.Fatalf("expected destroy resource to fail while services/report.go uses models.Product")
+	}
+	for _, want := range []string{"services/report.go", "models/product.go"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%s", want, err)
+		}
+	}
+	assertFileContent(t, filepath.Join(rootDir, "models", "product.go"), "package models\n\ntype Product struct{ Name string }\n")
+}
+
 func TestGenerateControllerMapsActionsAndVue(t *testing.T) {
 	resetCLITestSeams(t)
 	var got controllerCall
@@ -719,7 +1190,16 @@ CREATE TABLE project_inquiries (
 -- +goose Down
 DROP TABLE project_inquiries;
 `)
-	writeCLITestFile(t, rootDir, "models/project_inquiry.go", "package models\n")
+	writeCLITestFile(t, rootDir, "models/project_inquiry.go", `package models
+
+import "github.com/google/uuid"
+
+type ProjectInquiryEntity struct {
+	ID      uuid.UUID `+"`bun:\"id,pk,type:uuid\"`"+`
+	Email   string    `+"`bun:\"email\"`"+`
+	Message string    `+"`bun:\"message\"`"+`
+}
+`)
 	writeCLITestFile(t, rootDir, "bin/templ", "#!/bin/sh\nexit 0\n")
 	if err := os.Chmod(filepath.Join(rootDir, "bin", "templ"), 0o755); err != nil {
 		t.Fatalf("chmod fake templ: %v", err)
@@ -761,7 +1241,7 @@ func TestGenerateControllerSingleCRUDActionInertiaProjectDefaultsToTemplControll
 	assertCLITestFileContains(t, rootDir, "controllers/project_inquiries.go", "return hypermedia.RenderPage")
 	assertCLITestFileNotContains(t, rootDir, "controllers/project_inquiries.go", "example.com/app/internal/inertia")
 	assertCLITestFileExists(t, rootDir, "views/project_inquiries_resource.templ")
-	assertCLITestFileContains(t, rootDir, "views/project_inquiries_resource.templ", "Items []models.ProjectInquiryEntity")
+	assertCLITestFileContains(t, rootDir, "views/project_inquiries_resource.templ", "[]models.ProjectInquiryEntity")
 	assertCLITestFileNotContains(t, rootDir, "views/project_inquiries_resource.templ", "ProjectinquiryEntity")
 	assertCLITestFileMissing(t, rootDir, filepath.Join("resources", "js", "Pages", "ProjectInquiry", "Index.vue"))
 }
@@ -811,6 +1291,8 @@ var Module = fx.Module(
 	assertCLITestFileContains(t, rootDir, filepath.Join("router", "routes", "api_v1_project_inquiries.go"), `"api.v1.project_inquiries.create"`)
 	assertCLITestFileContains(t, rootDir, "controllers/controller.go", `"example.com/app/controllers/api/v1"`)
 	assertCLITestFileContains(t, rootDir, "controllers/controller.go", "v1.NewProjectInquiries")
+	assertCLITestFileContains(t, rootDir, filepath.Join("controllers", "api", "v1", "project_inquiries.go"), "serializers.NewProjectInquiry(projectInquiry)")
+	assertCLITestFileContains(t, rootDir, filepath.Join("serializers", "project_inquiry.go"), "func NewProjectInquiry(entity models.ProjectInquiryEntity) ProjectInquiry")
 	assertCLITestFileMissing(t, rootDir, "views/api_v1_project_inquiries_resource.templ")
 	if strings.Contains(stdout, "with views") {
 		t.Fatalf("expected API generation output not to mention views, got:\n%s", stdout)
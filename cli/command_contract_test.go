@@ -22,24 +22,34 @@ func TestRootCommandPublicSurface(t *testing.T) {
 	rootCmd := NewRootCommand("test", "test-date")
 
 	expected := []commandContract{
+		{name: "app"},
 		{name: "build"},
+		{name: "changes"},
 		{name: "commands"},
 		{name: "config"},
 		{name: "console", aliases: []string{"c"}},
 		{name: "controllers"},
 		{name: "database", aliases: []string{"d", "db"}},
+		{name: "destroy"},
 		{name: "doctor", aliases: []string{"doc"}},
 		{name: "extension", aliases: []string{"extensions", "ext", "e"}},
 		{name: "fmt", aliases: []string{"f"}},
 		{name: "generate", aliases: []string{"g"}},
 		{name: "jobs"},
+		{name: "loadtest"},
 		{name: "migrations"},
 		{name: "models"},
 		{name: "new", aliases: []string{"n"}},
 		{name: "project"},
+		{name: "queries"},
+		{name: "queue"},
+		{name: "rename-module"},
+		{name: "replay"},
 		{name: "routes"},
 		{name: "run", aliases: []string{"r"}},
 		{name: "skill"},
+		{name: "stats"},
+		{name: "support"},
 		{name: "tool", aliases: []string{"tools", "t"}},
 		{name: "upgrade", aliases: []string{"up"}},
 		{name: "views"},
@@ -53,14 +63,28 @@ func TestGenerateCommandPublicSurface(t *testing.T) {
 	generateCmd := mustFindCommand(t, rootCmd, "generate")
 
 	expected := []commandContract{
+		{name: "analytics"},
+		{name: "autosave"},
+		{name: "backup-job"},
+		{name: "calendar"},
+		{name: "charts"},
 		{name: "controller", aliases: []string{"c"}},
+		{name: "dead-letter-job"},
+		{name: "dev-dashboard"},
 		{name: "email", aliases: []string{"e"}},
 		{name: "factories"},
 		{name: "factory"},
 		{name: "job", aliases: []string{"j"}},
+		{name: "loadtest"},
 		{name: "model", aliases: []string{"m"}},
+		{name: "page"},
+		{name: "progress", aliases: []string{"p"}},
+		{name: "request-recorder"},
 		{name: "routes"},
+		{name: "saved-views"},
 		{name: "scaffold", aliases: []string{"s"}},
+		{name: "serializer"},
+		{name: "share"},
 		{name: "view", aliases: []string{"v"}},
 	}
 
@@ -180,9 +204,23 @@ func TestCommandFlagsContract(t *testing.T) {
 		{path: "generate factory", flags: []string{"check", "sync", "diff"}},
 		{path: "generate factories", flags: []string{"check", "sync", "diff"}},
 		{path: "generate controller", flags: []string{"inertia", "model-name", "dry-run", "diff"}},
-		{path: "generate scaffold", flags: []string{"skip-factory", "table-name", "primary-key", "inertia", "dry-run", "diff"}},
-		{path: "generate job", flags: []string{"queue", "dry-run", "diff"}},
+		{path: "generate scaffold", flags: []string{"skip-factory", "table-name", "primary-key", "inertia", "filters", "with-feed", "with-address", "dry-run", "diff"}},
+		{path: "generate job", flags: []string{"queue", "priority", "max-attempts", "unique", "unique-period", "sensitive", "dry-run", "diff"}},
+		{path: "generate autosave", flags: []string{"max-age", "dry-run", "diff"}},
+		{path: "generate saved-views", flags: []string{"sort", "dry-run", "diff"}},
+		{path: "generate share", flags: []string{"expires", "dry-run", "diff"}},
+		{path: "generate serializer", flags: []string{"only", "except", "computed", "include", "dry-run", "diff"}},
+		{path: "generate calendar", flags: []string{"starts-at", "ends-at", "title", "dry-run", "diff"}},
+		{path: "generate backup-job", flags: []string{"dir", "keep", "interval", "dry-run", "diff"}},
+		{path: "generate dead-letter-job", flags: []string{"interval", "to", "dry-run", "diff"}},
+		{path: "generate progress", flags: []string{"dry-run", "diff"}},
+		{path: "generate dev-dashboard", flags: []string{"dry-run", "diff"}},
+		{path: "generate request-recorder", flags: []string{"dry-run", "diff"}},
+		{path: "generate loadtest", flags: []string{"dry-run", "diff"}},
 		{path: "generate email", flags: []string{"dry-run", "diff"}},
+		{path: "generate page", flags: []string{"dry-run", "diff"}},
+		{path: "destroy resource", flags: []string{"api", "dry-run", "diff"}},
+		{path: "rename-module", flags: []string{"dry-run", "diff"}},
 		{path: "extension add", flags: []string{"dry-run", "diff"}},
 		{path: "extension list", flags: []string{"available"}},
 		{path: "fmt", flags: []string{"check", "skip-templ", "skip-go"}},
@@ -190,9 +228,17 @@ func TestCommandFlagsContract(t *testing.T) {
 		{path: "database nuke", flags: []string{"force"}},
 		{path: "database seed", flags: []string{"list"}},
 		{path: "database rebuild", flags: []string{"force", "skip-seed", "seed"}},
+		{path: "database backup", flags: []string{"dir", "keep", "s3-bucket", "s3-prefix"}},
+		{path: "database restore", flags: []string{"force"}},
+		{path: "database migrate complete-down", flags: []string{"dry-run"}},
+		{path: "queries explain", flags: []string{"analyze", "min-rows"}},
+		{path: "loadtest run", flags: []string{"records", "vus", "duration", "port", "skip-seed"}},
+		{path: "queue retry", flags: []string{"kind", "queue", "since", "dry-run"}},
+		{path: "replay", flags: []string{"url"}},
 		{path: "build", flags: []string{"version"}},
 		{path: "doctor", flags: []string{"verbose"}},
 		{path: "upgrade", flags: []string{"dry-run", "diff", "repair"}},
+		{path: "upgrade go", flags: []string{"to", "dry-run", "diff"}},
 	}
 
 	for _, tt := range tests {
@@ -9,8 +9,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/pkg/cache"
 	"github.com/spf13/cobra"
@@ -136,6 +138,7 @@ func resetCLITestSeams(t *testing.T) {
 	defaultFindGoModRoot := findGoModRoot
 	defaultNewGenerator := newGenerator
 	defaultRunModelUpdate := runModelUpdateFunc
+	defaultConnectSchemaDatabase := connectSchemaDatabaseFunc
 	defaultRunTempl := runTemplFunc
 	defaultRunFmt := runFmtFunc
 	defaultRunGoFmt := runGoFmtFunc
@@ -152,11 +155,30 @@ func resetCLITestSeams(t *testing.T) {
 	defaultOpenAdminConnection := openAdminConnectionFunc
 	defaultRunGoose := runGooseFunc
 	defaultRunSeed := runSeedFunc
+	defaultGenerateAddress := generateAddressFunc
+	defaultFetchQueueStats := fetchQueueStatsFunc
+	defaultExplainQuery := explainQueryFunc
+	defaultLookupK6 := lookupK6
+	defaultStartLoadTestApp := startLoadTestAppFunc
+	defaultRunK6 := runK6Func
+	defaultRetryDiscardedJobs := retryDiscardedJobsFunc
+	defaultFetchPasswordHashes := fetchPasswordHashesFunc
+	defaultGeneratorLogPath := generatorLogPathFunc
+	defaultVerifyGeneratedCode := verifyGeneratedCodeFunc
+	defaultVerifyProjectBuilds := verifyProjectBuildsFunc
+	defaultVerifyGoUpgrade := verifyGoUpgradeFunc
+	defaultMutationInterrupts := mutationInterrupts
+	logPath := filepath.Join(t.TempDir(), "generate.log")
+	generatorLogPathFunc = func() (string, error) { return logPath, nil }
+	verifyGeneratedCodeFunc = func(string, []string) ([]string, error) { return nil, nil }
+	verifyProjectBuildsFunc = func(string) ([]string, error) { return nil, nil }
+	verifyGoUpgradeFunc = func(string) error { return nil }
 
 	t.Cleanup(func() {
 		findGoModRoot = defaultFindGoModRoot
 		newGenerator = defaultNewGenerator
 		runModelUpdateFunc = defaultRunModelUpdate
+		connectSchemaDatabaseFunc = defaultConnectSchemaDatabase
 		runTemplFunc = defaultRunTempl
 		runFmtFunc = defaultRunFmt
 		runGoFmtFunc = defaultRunGoFmt
@@ -173,6 +195,19 @@ func resetCLITestSeams(t *testing.T) {
 		openAdminConnectionFunc = defaultOpenAdminConnection
 		runGooseFunc = defaultRunGoose
 		runSeedFunc = defaultRunSeed
+		generateAddressFunc = defaultGenerateAddress
+		fetchQueueStatsFunc = defaultFetchQueueStats
+		explainQueryFunc = defaultExplainQuery
+		lookupK6 = defaultLookupK6
+		startLoadTestAppFunc = defaultStartLoadTestApp
+		runK6Func = defaultRunK6
+		retryDiscardedJobsFunc = defaultRetryDiscardedJobs
+		fetchPasswordHashesFunc = defaultFetchPasswordHashes
+		generatorLogPathFunc = defaultGeneratorLogPath
+		verifyGeneratedCodeFunc = defaultVerifyGeneratedCode
+		verifyProjectBuildsFunc = defaultVerifyProjectBuilds
+		verifyGoUpgradeFunc = defaultVerifyGoUpgrade
+		mutationInterrupts = defaultMutationInterrupts
 		cache.ClearFileSystemCache()
 	})
 }
@@ -192,12 +227,26 @@ func stubLatestAndurelVersion(t *testing.T, version string, err error) {
 type fakeGenerator struct {
 	modelCalls       []modelCall
 	modelWithPKCalls []modelWithPKCall
+	associationCalls []associationCall
 	scaffoldCalls    []scaffoldCall
+	filterCalls      []filterCall
+	shareCalls       []shareCall
+	calendarCalls    []calendarCall
+	feedCalls        []feedCall
+	addressCalls     []addressCall
+	serializerCalls  []serializerCall
+	destroyCalls     []destroyCall
 	controllerCalls  []controllerCall
 	factoryCalls     []factoryCall
 	factoriesCalls   []generator.FactorySyncOptions
 	factoryResult    *generator.FactorySyncResult
 	factoriesResult  []*generator.FactorySyncResult
+	nullablePointers bool
+	conflictColumns  []string
+	fromView         bool
+	withTests        bool
+	databaseName     string
+	schemaDB         generator.SchemaQuerier
 	modelUpdateCalls []string
 	modelUpdate      *generator.UpdateModelResult
 	modelUpdateErr   error
@@ -205,6 +254,7 @@ type fakeGenerator struct {
 	modelApplyErr    error
 	err              error
 	onGenerateModel  func()
+	onDestroy        func()
 }
 
 type modelCall struct {
@@ -220,6 +270,20 @@ type modelWithPKCall struct {
 	primaryKey  string
 }
 
+type associationCall struct {
+	name         string
+	tableName    string
+	skipFactory  bool
+	primaryKey   string
+	associations models.Associations
+	jsonTypes    map[string]string
+}
+
+type destroyCall struct {
+	name      string
+	namespace string
+}
+
 type scaffoldCall struct {
 	name        string
 	namespace   string
@@ -230,6 +294,41 @@ type scaffoldCall struct {
 	isAPI       bool
 }
 
+type filterCall struct {
+	name      string
+	namespace string
+	tableName string
+	columns   []string
+}
+
+type shareCall struct {
+	name      string
+	tableName string
+	ttl       time.Duration
+}
+
+type feedCall struct {
+	name      string
+	namespace string
+	tableName string
+}
+
+type addressCall struct {
+	name      string
+	tableName string
+}
+
+type calendarCall struct {
+	name      string
+	tableName string
+	columns   generator.CalendarColumns
+}
+
+type serializerCall struct {
+	name string
+	opts generator.SerializerOptions
+}
+
 type factoryCall struct {
 	name string
 	opts generator.FactorySyncOptions
@@ -267,6 +366,29 @@ func (f *fakeGenerator) GenerateModelWithPK(resourceName string, tableNameOverri
 	return f.err
 }
 
+func (f *fakeGenerator) GenerateModelWithAssociations(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations) error {
+	f.associationCalls = append(f.associationCalls, associationCall{
+		name:         resourceName,
+		tableName:    tableNameOverride,
+		skipFactory:  skipFactory,
+		primaryKey:   primaryKeyColumn,
+		associations: associations,
+	})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateModelWithJSONTypes(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations, jsonTypes map[string]string) error {
+	f.associationCalls = append(f.associationCalls, associationCall{
+		name:         resourceName,
+		tableName:    tableNameOverride,
+		skipFactory:  skipFactory,
+		primaryKey:   primaryKeyColumn,
+		associations: associations,
+		jsonTypes:    jsonTypes,
+	})
+	return f.err
+}
+
 func (f *fakeGenerator) GenerateControllerWithActions(resourceName, namespace, tableName string, actions []string, inertia string, isAPI bool) error {
 	f.controllerCalls = append(f.controllerCalls, controllerCall{
 		name:      resourceName,
@@ -306,6 +428,74 @@ func (f *fakeGenerator) GenerateScaffold(resourceName, namespace, tableName stri
 	return f.err
 }
 
+func (f *fakeGenerator) DestroyResource(resourceName, namespace string) error {
+	f.destroyCalls = append(f.destroyCalls, destroyCall{name: resourceName, namespace: namespace})
+	if f.onDestroy != nil {
+		f.onDestroy()
+	}
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateFilters(resourceName, namespace, tableName string, columns []string) error {
+	f.filterCalls = append(f.filterCalls, filterCall{
+		name:      resourceName,
+		namespace: namespace,
+		tableName: tableName,
+		columns:   append([]string(nil), columns...),
+	})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateShare(resourceName, tableName string, ttl time.Duration) error {
+	f.shareCalls = append(f.shareCalls, shareCall{name: resourceName, tableName: tableName, ttl: ttl})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateCalendar(resourceName, tableName string, columns generator.CalendarColumns) error {
+	f.calendarCalls = append(f.calendarCalls, calendarCall{name: resourceName, tableName: tableName, columns: columns})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateFeed(resourceName, namespace, tableName string) error {
+	f.feedCalls = append(f.feedCalls, feedCall{name: resourceName, namespace: namespace, tableName: tableName})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateAddress(resourceName, tableName string) error {
+	f.addressCalls = append(f.addressCalls, addressCall{name: resourceName, tableName: tableName})
+	return f.err
+}
+
+func (f *fakeGenerator) GenerateSerializer(resourceName string, opts generator.SerializerOptions) error {
+	f.serializerCalls = append(f.serializerCalls, serializerCall{name: resourceName, opts: opts})
+	return f.err
+}
+
+func (f *fakeGenerator) SetNullablePointers(enabled bool) {
+	f.nullablePointers = enabled
+}
+
+func (f *fakeGenerator) SetUpsertConflictColumns(columns []string) {
+	f.conflictColumns = columns
+}
+
+func (f *fakeGenerator) SetFromView(enabled bool) {
+	f.fromView = enabled
+}
+
+func (f *fakeGenerator) SetWithTests(enabled bool) {
+	f.withTests = enabled
+}
+
+func (f *fakeGenerator) SetDatabaseName(name string) error {
+	f.databaseName = name
+	return nil
+}
+
+func (f *fakeGenerator) SetSchemaDatabase(db generator.SchemaQuerier) {
+	f.schemaDB = db
+}
+
 func (f *fakeGenerator) UpdateModel(resourceName string) (*generator.UpdateModelResult, error) {
 	f.modelUpdateCalls = append(f.modelUpdateCalls, resourceName)
 	if f.modelUpdateErr != nil {
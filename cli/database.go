@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -68,7 +69,9 @@ func newDatabaseCommand() *cobra.Command {
 		Aliases: []string{"d", "db"},
 		Short:   "Database management commands",
 		Long: `Commands for managing your Andurel project's database lifecycle:
-create, drop, nuke, rebuild, seed, and run migrations.
+create, drop, nuke, rebuild, seed, and run migrations. Open an interactive
+console or the dblab UI against the database configured in .env, and back
+up or restore it with pg_dump/pg_restore.
 
 Use the subcommands below to manage your database.`,
 	}
@@ -81,6 +84,10 @@ Use the subcommands below to manage your database.`,
 		newDBNukeCommand(),
 		newDBRebuildCommand(),
 		newMigrateCommand(),
+		newDBConsoleCommand(),
+		newDBUICommand(),
+		newDBBackupCommand(),
+		newDBRestoreCommand(),
 	)
 
 	return cmd
@@ -94,12 +101,21 @@ func newMigrateCommand() *cobra.Command {
 		Long: `Manage database migrations for the current project using goose.
 
 Migrations live in database/migrations/ as SQL files. Create a new
-migration, apply pending ones, rollback, check status, or fix gaps.`,
+migration, apply pending ones, rollback, check status, or fix gaps.
+
+Pass --database-name to manage the migrations of a database listed in
+DATABASES instead. They live in database/<name>/migrations/ and run
+against the database configured by the <NAME>_DB_* variables, such as
+ANALYTICS_DB_HOST for --database-name analytics.`,
 		Example: `  andurel database migrate new add_user_role
   andurel database migrate up
   andurel database migrate status
   andurel database migrate down
-  andurel database migrate reset`,
+  andurel database migrate reset
+  andurel database migrate up --database-name analytics
+  andurel database migrate extension citext
+  andurel database migrate diff
+  andurel database migrate complete-down`,
 	}
 
 	cmd.AddCommand(
@@ -111,6 +127,9 @@ migration, apply pending ones, rollback, check status, or fix gaps.`,
 		newDBMigrationResetCommand(),
 		newDBMigrationUpToCommand(),
 		newDBMigrationDownToCommand(),
+		newDBMigrationExtensionCommand(),
+		newDBMigrationDiffCommand(),
+		newDBMigrationCompleteDownCommand(),
 	)
 
 	return cmd
@@ -119,7 +138,8 @@ migration, apply pending ones, rollback, check status, or fix gaps.`,
 // Migration commands
 
 func newDBMigrationNewCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:     "new [name]",
 		Aliases: []string{"n"},
 		Short:   "Create a new SQL migration",
@@ -132,62 +152,82 @@ The name should describe the change, e.g. "create_users_table".`,
 			c = append(c, args...)
 			c = append(c, "sql")
 
-			return runGoose(c...)
+			return runNamedGoose(databaseName, c...)
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationUpCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:   "up",
 		Short: "Apply all pending migrations",
 		Long:  "Apply any pending migrations that have not yet been run against the database.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("up")
+			return runNamedGoose(databaseName, "up")
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationDownCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:   "down",
 		Short: "Rollback the most recent migration",
 		Long:  "Roll back the most recently applied migration.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("down")
+			return runNamedGoose(databaseName, "down")
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationFixCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:   "fix",
 		Short: "Re-number migrations to fix gaps",
 		Long:  "Re-number sequential migrations to close any gaps in the numbering.",
 		Args:  cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("fix")
+			return runNamedGoose(databaseName, "fix")
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationResetCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:     "reset",
 		Aliases: []string{"rs"},
 		Short:   "Roll back all migrations and re-apply them",
 		Long:    "Roll back every migration (down), then re-apply them all (up).",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("reset")
+			return runNamedGoose(databaseName, "reset")
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationUpToCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:     "up-to [version]",
 		Aliases: []string{"upto"},
 		Short:   "Apply migrations up to a specific version",
@@ -195,13 +235,17 @@ func newDBMigrationUpToCommand() *cobra.Command {
 		Args:    cobra.ExactArgs(1),
 		Example: "  andurel database migrate up-to 20250101120000",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("up-to", args[0])
+			return runNamedGoose(databaseName, "up-to", args[0])
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationDownToCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:     "down-to [version]",
 		Aliases: []string{"downto"},
 		Short:   "Rollback migrations down to a specific version",
@@ -209,20 +253,64 @@ func newDBMigrationDownToCommand() *cobra.Command {
 		Args:    cobra.ExactArgs(1),
 		Example: "  andurel database migrate down-to 20250101120000",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("down-to", args[0])
+			return runNamedGoose(databaseName, "down-to", args[0])
 		},
 	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
 }
 
 func newDBMigrationStatusCommand() *cobra.Command {
-	return &cobra.Command{
+	var databaseName string
+	cmd := &cobra.Command{
 		Use:     "status",
 		Aliases: []string{"st"},
 		Short:   "Show current migration status",
 		Long:    "Display the current migration version and list all migrations with their status.",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runGoose("status")
+			return runNamedGoose(databaseName, "status")
+		},
+	}
+	addDatabaseNameFlag(cmd, &databaseName)
+
+	return cmd
+}
+
+// Client commands
+
+func newDBConsoleCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "console",
+		Short: "Open an interactive database console (usql)",
+		Long: `Open an interactive usql console connected to the database configured
+in .env.
+
+The connection string is built from DB_KIND, DB_HOST, DB_PORT, DB_NAME,
+DB_USER, DB_PASSWORD, and DB_SSL_MODE. usql is synced into bin/ by
+'andurel tool sync'.`,
+		Args:    cobra.NoArgs,
+		Example: "  andurel database console",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDatabaseConsole()
+		},
+	}
+}
+
+func newDBUICommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ui",
+		Short: "Open the dblab database UI",
+		Long: `Launch dblab against the database configured in .env.
+
+The connection string is built from DB_KIND, DB_HOST, DB_PORT, DB_NAME,
+DB_USER, DB_PASSWORD, and DB_SSL_MODE. dblab is synced into bin/ by
+'andurel tool sync'.`,
+		Args:    cobra.NoArgs,
+		Example: "  andurel database ui",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDatabaseUI()
 		},
 	}
 }
@@ -441,6 +529,24 @@ func splitNonEmptyLines(value string) []string {
 // Shared helpers
 
 func runGoose(args ...string) error {
+	return runNamedGoose("", args...)
+}
+
+// addDatabaseNameFlag adds --database-name, which points a migration
+// command at a database listed in DATABASES.
+func addDatabaseNameFlag(cmd *cobra.Command, databaseName *string) {
+	cmd.Flags().StringVar(databaseName, "database-name", "", "Database listed in DATABASES to migrate instead of the primary one (e.g. analytics)")
+}
+
+// runNamedGoose runs goose against the migrations and connection of
+// databaseName, or of the primary database when it is empty.
+func runNamedGoose(databaseName string, args ...string) error {
+	if databaseName != "" {
+		if err := generator.ValidateDatabaseName(databaseName); err != nil {
+			return err
+		}
+	}
+
 	rootDir, err := findGoModRoot()
 	if err != nil {
 		return err
@@ -448,7 +554,7 @@ func runGoose(args ...string) error {
 
 	loadProjectEnv(rootDir)
 
-	driver, dbString, err := buildDatabaseURL()
+	driver, dbString, err := buildDatabaseURL(generator.DatabaseEnvPrefix(databaseName))
 	if err != nil {
 		return err
 	}
@@ -464,7 +570,12 @@ func runGoose(args ...string) error {
 		return err
 	}
 
-	migrationDir := filepath.Join(rootDir, "database", "migrations")
+	migrationDir := filepath.Join(rootDir, generator.MigrationsDir(databaseName))
+	if len(args) > 0 && args[0] == "create" {
+		if err := os.MkdirAll(migrationDir, 0o755); err != nil {
+			return err
+		}
+	}
 
 	gooseArgs := append([]string{"-dir", migrationDir, driver, dbString}, args...)
 
@@ -482,35 +593,41 @@ type dbConfig struct {
 }
 
 func loadDatabaseConfig() (dbConfig, error) {
-	dbKind := os.Getenv("DB_KIND")
-	dbPort := os.Getenv("DB_PORT")
-	dbHost := os.Getenv("DB_HOST")
-	dbName := os.Getenv("DB_NAME")
-	dbUser := os.Getenv("DB_USER")
-	dbPass := os.Getenv("DB_PASSWORD")
-	dbSslMode := os.Getenv("DB_SSL_MODE")
+	return loadNamedDatabaseConfig("")
+}
+
+// loadNamedDatabaseConfig reads the DB_* variables with prefix in front,
+// such as ANALYTICS_DB_HOST for the prefix ANALYTICS_.
+func loadNamedDatabaseConfig(prefix string) (dbConfig, error) {
+	dbKind := os.Getenv(prefix + "DB_KIND")
+	dbPort := os.Getenv(prefix + "DB_PORT")
+	dbHost := os.Getenv(prefix + "DB_HOST")
+	dbName := os.Getenv(prefix + "DB_NAME")
+	dbUser := os.Getenv(prefix + "DB_USER")
+	dbPass := os.Getenv(prefix + "DB_PASSWORD")
+	dbSslMode := os.Getenv(prefix + "DB_SSL_MODE")
 
 	var missing []string
 	if dbKind == "" {
-		missing = append(missing, "DB_KIND")
+		missing = append(missing, prefix+"DB_KIND")
 	}
 	if dbPort == "" {
-		missing = append(missing, "DB_PORT")
+		missing = append(missing, prefix+"DB_PORT")
 	}
 	if dbHost == "" {
-		missing = append(missing, "DB_HOST")
+		missing = append(missing, prefix+"DB_HOST")
 	}
 	if dbName == "" {
-		missing = append(missing, "DB_NAME")
+		missing = append(missing, prefix+"DB_NAME")
 	}
 	if dbUser == "" {
-		missing = append(missing, "DB_USER")
+		missing = append(missing, prefix+"DB_USER")
 	}
 	if dbPass == "" {
-		missing = append(missing, "DB_PASSWORD")
+		missing = append(missing, prefix+"DB_PASSWORD")
 	}
 	if dbSslMode == "" {
-		missing = append(missing, "DB_SSL_MODE")
+		missing = append(missing, prefix+"DB_SSL_MODE")
 	}
 
 	if len(missing) > 0 {
@@ -757,8 +874,10 @@ func loadProjectEnv(rootDir string) {
 	_ = godotenv.Load(envPath)
 }
 
-func buildDatabaseURL() (driver, dbString string, err error) {
-	cfg, err := loadDatabaseConfig()
+// buildDatabaseURL builds the goose connection string from the DB_*
+// variables with prefix in front, see loadNamedDatabaseConfig.
+func buildDatabaseURL(prefix string) (driver, dbString string, err error) {
+	cfg, err := loadNamedDatabaseConfig(prefix)
 	if err != nil {
 		return "", "", err
 	}
@@ -769,13 +888,17 @@ func buildDatabaseURL() (driver, dbString string, err error) {
 }
 
 func databaseURL(cfg dbConfig, databaseName string) string {
+	return databaseURLWithUser(cfg, databaseName, url.UserPassword(cfg.User, cfg.Password))
+}
+
+func databaseURLWithUser(cfg dbConfig, databaseName string, user *url.Userinfo) string {
 	query := url.Values{}
 	query.Set("sslmode", cfg.SslMode)
 	escapedPath := "/" + url.PathEscape(databaseName)
 
 	return (&url.URL{
 		Scheme:   cfg.Kind,
-		User:     url.UserPassword(cfg.User, cfg.Password),
+		User:     user,
 		Host:     net.JoinHostPort(cfg.Host, cfg.Port),
 		Path:     "/" + databaseName,
 		RawPath:  escapedPath,
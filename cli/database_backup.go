@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultBackupDir       = "backups"
+	backupTimestampFormat  = "20060102T150405Z"
+	backupS3ExtensionName  = "aws-ses"
+	backupFileExtension    = ".dump"
+	backupS3BucketEnvName  = "BACKUP_S3_BUCKET"
+	backupS3PrefixEnvName  = "BACKUP_S3_PREFIX"
+	backupAwsRegionEnvName = "AWS_REGION"
+)
+
+type backupOptions struct {
+	Dir      string
+	Keep     int
+	S3Bucket string
+	S3Prefix string
+}
+
+var runBackupToolCommand = func(rootDir, name string, args, env []string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	cmd.Dir = rootDir
+	cmd.Env = append(os.Environ(), env...)
+	return cmd.Run()
+}
+
+var lookupBackupTool = exec.LookPath
+var backupNow = time.Now
+
+// Backup commands
+
+func newDBBackupCommand() *cobra.Command {
+	var opts backupOptions
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Dump the configured database with pg_dump",
+		Long: `Dump the database configured in .env to a timestamped pg_dump archive.
+
+Archives are written in pg_dump's custom format to backups/ (or --dir) as
+<DB_NAME>-<UTC timestamp>.dump. Use --keep to prune the oldest archives
+for the database once the new dump succeeds.
+
+When the aws-ses extension is installed, --s3-bucket (or BACKUP_S3_BUCKET)
+uploads the archive with the aws CLI, using AWS_REGION and the
+AWS_SES_ACCESS_KEY_ID/AWS_SES_SECRET_ACCESS_KEY credentials from .env
+unless AWS_ACCESS_KEY_ID is already set.
+
+pg_dump must be on PATH.`,
+		Args: cobra.NoArgs,
+		Example: `  andurel database backup
+  andurel database backup --keep 7
+  andurel database backup --s3-bucket my-backups --s3-prefix production`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return backupDatabase(opts)
+		},
+	}
+	setAgentMetadata(cmd, "database", "Writes a pg_dump archive under backups/. --keep prunes older archives; --s3-bucket requires the aws-ses extension.")
+
+	cmd.Flags().StringVar(&opts.Dir, "dir", defaultBackupDir, "Directory to write backup archives to")
+	cmd.Flags().IntVar(&opts.Keep, "keep", 0, "Number of archives to keep for the database (0 keeps all)")
+	cmd.Flags().StringVar(&opts.S3Bucket, "s3-bucket", "", "Upload the archive to this S3 bucket (requires the aws-ses extension)")
+	cmd.Flags().StringVar(&opts.S3Prefix, "s3-prefix", "", "Key prefix for S3 uploads")
+
+	return cmd
+}
+
+func newDBRestoreCommand() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "restore FILE",
+		Short: "Restore the configured database from a pg_dump archive",
+		Long: `Restore a pg_dump archive into the database configured in .env.
+
+Existing objects are dropped before they are recreated (pg_restore --clean
+--if-exists). Prompts for confirmation unless --force is provided.
+
+pg_restore must be on PATH.`,
+		Args:    cobra.ExactArgs(1),
+		Example: "  andurel database restore backups/app-20260101T030000Z.dump\n  andurel database restore latest.dump --force",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restoreDatabase(args[0], force)
+		},
+	}
+	setAgentMetadata(cmd, "database", "Destructive: replaces database objects from a pg_dump archive. Prompts unless --force is provided.")
+
+	cmd.Flags().BoolVar(&force, "force", false, "Skip the confirmation prompt")
+
+	return cmd
+}
+
+func backupDatabase(opts backupOptions) error {
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+	loadProjectEnv(rootDir)
+
+	cfg, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
+	if opts.Keep < 0 {
+		return fmt.Errorf("--keep must be zero or greater")
+	}
+	if opts.S3Bucket == "" {
+		opts.S3Bucket = os.Getenv(backupS3BucketEnvName)
+	}
+	if opts.S3Prefix == "" {
+		opts.S3Prefix = os.Getenv(backupS3PrefixEnvName)
+	}
+	if opts.S3Bucket != "" {
+		if err := requireBackupS3Extension(rootDir); err != nil {
+			return err
+		}
+	}
+
+	pgDump, err := findBackupTool("pg_dump")
+	if err != nil {
+		return err
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir = defaultBackupDir
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(rootDir, dir)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	archiveName := fmt.Sprintf("%s-%s%s", cfg.Name, backupNow().UTC().Format(backupTimestampFormat), backupFileExtension)
+	archivePath := filepath.Join(dir, archiveName)
+	// pg_dump writes to a temporary name, so a failed dump never looks like
+	// an archive to pruneBackups.
+	partialPath := archivePath + ".tmp"
+
+	dbURL, env := backupConnection(cfg)
+	if err := runBackupToolCommand(rootDir, pgDump, []string{
+		"--format=custom",
+		"--no-owner",
+		"--file", partialPath,
+		"--dbname", dbURL,
+	}, env); err != nil {
+		_ = os.Remove(partialPath)
+		return fmt.Errorf("pg_dump failed: %w", err)
+	}
+	if err := os.Rename(partialPath, archivePath); err != nil {
+		_ = os.Remove(partialPath)
+		return err
+	}
+	fmt.Printf("Database %q backed up to %s\n", cfg.Name, archivePath)
+
+	if opts.S3Bucket != "" {
+		if err := uploadBackupToS3(rootDir, archivePath, opts.S3Bucket, opts.S3Prefix); err != nil {
+			return err
+		}
+	}
+
+	pruned, err := pruneBackups(dir, cfg.Name, opts.Keep)
+	if err != nil {
+		return err
+	}
+	for _, path := range pruned {
+		fmt.Printf("Pruned %s\n", path)
+	}
+
+	return nil
+}
+
+func restoreDatabase(archivePath string, force bool) error {
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+	loadProjectEnv(rootDir)
+
+	cfg, err := loadDatabaseConfig()
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(archivePath) {
+		if _, err := os.Stat(archivePath); err != nil {
+			archivePath = filepath.Join(rootDir, archivePath)
+		}
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("backup archive not found at %s", archivePath)
+		}
+		return err
+	}
+
+	pgRestore, err := findBackupTool("pg_restore")
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		confirmed, err := confirmDestructive("restore", cfg.Name)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			if _, err := fmt.Fprintln(os.Stdout, "Aborted."); err != nil {
+				return err
+			}
+			return errDatabaseOperationAborted
+		}
+	}
+
+	dbURL, env := backupConnection(cfg)
+	if err := runBackupToolCommand(rootDir, pgRestore, []string{
+		"--clean",
+		"--if-exists",
+		"--no-owner",
+		"--dbname", dbURL,
+		archivePath,
+	}, env); err != nil {
+		return fmt.Errorf("pg_restore failed: %w", err)
+	}
+
+	fmt.Printf("Database %q restored from %s\n", cfg.Name, archivePath)
+	return nil
+}
+
+// backupConnection returns the --dbname URL for pg_dump and pg_restore and
+// the environment that goes with it. The password travels in PGPASSWORD
+// rather than the URL, so other users cannot read it from the process list.
+func backupConnection(cfg dbConfig) (string, []string) {
+	dbURL := databaseURLWithUser(cfg, cfg.Name, url.User(cfg.User))
+	if cfg.Password == "" {
+		return dbURL, nil
+	}
+	return dbURL, []string{"PGPASSWORD=" + cfg.Password}
+}
+
+func findBackupTool(name string) (string, error) {
+	path, err := lookupBackupTool(name)
+	if err != nil {
+		return "", output.NewError(
+			output.CodeMissingTool,
+			fmt.Sprintf("%s not found on PATH", name),
+			output.ExitDependency,
+			fmt.Sprintf("Install the PostgreSQL client tools so %s is available.", name),
+		)
+	}
+	return path, nil
+}
+
+func requireBackupS3Extension(rootDir string) error {
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		return fmt.Errorf("S3 uploads require andurel.lock: %w", err)
+	}
+	if _, ok := lock.Extensions[backupS3ExtensionName]; !ok {
+		return output.NewError(
+			output.CodeMissingTool,
+			fmt.Sprintf("S3 uploads require the %s extension", backupS3ExtensionName),
+			output.ExitDependency,
+			"Create the project with --extensions aws-ses or omit --s3-bucket.",
+		)
+	}
+	return nil
+}
+
+func uploadBackupToS3(rootDir, archivePath, bucket, prefix string) error {
+	awsCLI, err := lookupBackupTool("aws")
+	if err != nil {
+		return output.NewError(
+			output.CodeMissingTool,
+			"aws CLI not found on PATH",
+			output.ExitDependency,
+			"Install the AWS CLI to upload backups to S3.",
+		)
+	}
+
+	destination := "s3://" + strings.Trim(bucket, "/") + "/"
+	if trimmed := strings.Trim(prefix, "/"); trimmed != "" {
+		destination += trimmed + "/"
+	}
+	destination += filepath.Base(archivePath)
+
+	if err := runBackupToolCommand(rootDir, awsCLI, []string{"s3", "cp", archivePath, destination}, backupS3Env()); err != nil {
+		return fmt.Errorf("upload to %s failed: %w", destination, err)
+	}
+
+	fmt.Printf("Uploaded %s\n", destination)
+	return nil
+}
+
+// backupS3Env maps the aws-ses credentials onto the standard AWS variables
+// when the shell does not already provide them.
+func backupS3Env() []string {
+	var env []string
+	if os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+		if key := os.Getenv("AWS_SES_ACCESS_KEY_ID"); key != "" {
+			env = append(env, "AWS_ACCESS_KEY_ID="+key)
+		}
+		if secret := os.Getenv("AWS_SES_SECRET_ACCESS_KEY"); secret != "" {
+			env = append(env, "AWS_SECRET_ACCESS_KEY="+secret)
+		}
+	}
+	if region := os.Getenv(backupAwsRegionEnvName); region != "" && os.Getenv("AWS_DEFAULT_REGION") == "" {
+		env = append(env, "AWS_DEFAULT_REGION="+region)
+	}
+	return env
+}
+
+func pruneBackups(dir, databaseName string, keep int) ([]string, error) {
+	if keep <= 0 {
+		return nil, nil
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, databaseName+"-*"+backupFileExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	var archives []string
+	for _, match := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(match), databaseName+"-"), backupFileExtension)
+		if _, err := time.Parse(backupTimestampFormat, stamp); err == nil {
+			archives = append(archives, match)
+		}
+	}
+	if len(archives) <= keep {
+		return nil, nil
+	}
+
+	sort.Strings(archives)
+	stale := archives[:len(archives)-keep]
+	var errs []error
+	for _, archive := range stale {
+		if err := os.Remove(archive); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return stale, errors.Join(errs...)
+}
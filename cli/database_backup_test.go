@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type backupToolCall struct {
+	name string
+	args []string
+	env  []string
+}
+
+func stubBackupTools(t *testing.T, root string) *[]backupToolCall {
+	t.Helper()
+
+	originalFindGoModRoot := findGoModRoot
+	originalRunBackupToolCommand := runBackupToolCommand
+	originalLookupBackupTool := lookupBackupTool
+	originalBackupNow := backupNow
+	t.Cleanup(func() {
+		findGoModRoot = originalFindGoModRoot
+		runBackupToolCommand = originalRunBackupToolCommand
+		lookupBackupTool = originalLookupBackupTool
+		backupNow = originalBackupNow
+	})
+
+	findGoModRoot = func() (string, error) { return root, nil }
+	lookupBackupTool = func(name string) (string, error) { return "/usr/bin/" + name, nil }
+	backupNow = func() time.Time { return time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC) }
+
+	var calls []backupToolCall
+	runBackupToolCommand = func(rootDir, name string, args, env []string) error {
+		calls = append(calls, backupToolCall{name: name, args: append([]string(nil), args...), env: env})
+		for i, arg := range args {
+			if arg == "--file" && i+1 < len(args) {
+				return os.WriteFile(args[i+1], []byte("dump"), 0o644)
+			}
+		}
+		return nil
+	}
+	return &calls
+}
+
+func TestBackupDatabaseRunsPgDumpAndPrunes(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "backups/andurel_test-20260101T000000Z.dump", "old")
+	writeTestFile(t, root, "backups/andurel_test-20260102T000000Z.dump", "old")
+	writeTestFile(t, root, "backups/andurel_test-staging-20250101T000000Z.dump", "other database")
+	setDatabaseEnv(t)
+	t.Setenv(backupS3BucketEnvName, "")
+	calls := stubBackupTools(t, root)
+
+	if err := backupDatabase(backupOptions{Dir: "backups", Keep: 2}); err != nil {
+		t.Fatalf("backupDatabase: %v", err)
+	}
+
+	archive := filepath.Join(root, "backups", "andurel_test-20260708T120000Z.dump")
+	want := []backupToolCall{{
+		name: "/usr/bin/pg_dump",
+		args: []string{
+			"--format=custom",
+			"--no-owner",
+			"--file", archive + ".tmp",
+			"--dbname", "postgres://andurel@127.0.0.1:5432/andurel_test?sslmode=disable",
+		},
+		env: []string{"PGPASSWORD=secret"},
+	}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("backup tool calls = %#v, want %#v", *calls, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "backups", "andurel_test-20260101T000000Z.dump")); !os.IsNotExist(err) {
+		t.Fatalf("expected oldest archive to be pruned, stat err = %v", err)
+	}
+	for _, kept := range []string{"andurel_test-20260102T000000Z.dump", "andurel_test-20260708T120000Z.dump", "andurel_test-staging-20250101T000000Z.dump"} {
+		if _, err := os.Stat(filepath.Join(root, "backups", kept)); err != nil {
+			t.Fatalf("expected %s to be kept: %v", kept, err)
+		}
+	}
+}
+
+func TestBackupDatabaseRemovesPartialDumpWhenPgDumpFails(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "backups/andurel_test-20260101T000000Z.dump", "old")
+	setDatabaseEnv(t)
+	t.Setenv(backupS3BucketEnvName, "")
+	stubBackupTools(t, root)
+	runBackupToolCommand = func(rootDir, name string, args, env []string) error {
+		for i, arg := range args {
+			if arg == "--file" && i+1 < len(args) {
+				if err := os.WriteFile(args[i+1], []byte("partial"), 0o644); err != nil {
+					return err
+				}
+			}
+		}
+		return errors.New("connection lost")
+	}
+
+	if err := backupDatabase(backupOptions{Dir: "backups", Keep: 1}); err == nil || !strings.Contains(err.Error(), "pg_dump failed") {
+		t.Fatalf("expected pg_dump error, got %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, "backups"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if want := []string{"andurel_test-20260101T000000Z.dump"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("backups = %v, want %v", names, want)
+	}
+}
+
+func TestBackupDatabaseS3RequiresAwsExtension(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeGenerateFileTestLock(t, root)
+	setDatabaseEnv(t)
+	calls := stubBackupTools(t, root)
+
+	err := backupDatabase(backupOptions{Dir: "backups", S3Bucket: "app-backups"})
+	if err == nil || !strings.Contains(err.Error(), "aws-ses extension") {
+		t.Fatalf("expected aws-ses extension error, got %v", err)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("expected no tool calls, got %#v", *calls)
+	}
+}
+
+func TestBackupDatabaseUploadsToS3WithSesCredentials(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "andurel.lock", `{
+  "schemaVersion": 1,
+  "version": "test",
+  "tools": {},
+  "extensions": {
+    "aws-ses": {"appliedAt": "2026-01-01T00:00:00Z"}
+  },
+  "scaffoldConfig": {
+    "projectName": "app",
+    "database": "postgresql"
+  }
+}
+`)
+	setDatabaseEnv(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("AWS_SES_ACCESS_KEY_ID", "ses-key")
+	t.Setenv("AWS_SES_SECRET_ACCESS_KEY", "ses-secret")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	calls := stubBackupTools(t, root)
+
+	if err := backupDatabase(backupOptions{Dir: "backups", S3Bucket: "app-backups", S3Prefix: "/production/"}); err != nil {
+		t.Fatalf("backupDatabase: %v", err)
+	}
+
+	if len(*calls) != 2 {
+		t.Fatalf("expected pg_dump and aws calls, got %#v", *calls)
+	}
+	upload := (*calls)[1]
+	wantArgs := []string{
+		"s3", "cp",
+		filepath.Join(root, "backups", "andurel_test-20260708T120000Z.dump"),
+		"s3://app-backups/production/andurel_test-20260708T120000Z.dump",
+	}
+	if upload.name != "/usr/bin/aws" || !reflect.DeepEqual(upload.args, wantArgs) {
+		t.Fatalf("upload call = %#v, want aws %#v", upload, wantArgs)
+	}
+	wantEnv := []string{
+		"AWS_ACCESS_KEY_ID=ses-key",
+		"AWS_SECRET_ACCESS_KEY=ses-secret",
+		"AWS_DEFAULT_REGION=eu-west-1",
+	}
+	if !reflect.DeepEqual(upload.env, wantEnv) {
+		t.Fatalf("upload env = %#v, want %#v", upload.env, wantEnv)
+	}
+}
+
+func TestRestoreDatabaseRunsPgRestore(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "backups/andurel_test-20260708T120000Z.dump", "archive")
+	setDatabaseEnv(t)
+	calls := stubBackupTools(t, root)
+
+	if err := restoreDatabase("backups/andurel_test-20260708T120000Z.dump", true); err != nil {
+		t.Fatalf("restoreDatabase: %v", err)
+	}
+
+	want := []backupToolCall{{
+		name: "/usr/bin/pg_restore",
+		args: []string{
+			"--clean",
+			"--if-exists",
+			"--no-owner",
+			"--dbname", "postgres://andurel@127.0.0.1:5432/andurel_test?sslmode=disable",
+			filepath.Join(root, "backups", "andurel_test-20260708T120000Z.dump"),
+		},
+		env: []string{"PGPASSWORD=secret"},
+	}}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Fatalf("restore tool calls = %#v, want %#v", *calls, want)
+	}
+
+	if err := restoreDatabase("missing.dump", true); err == nil || !strings.Contains(err.Error(), "backup archive not found") {
+		t.Fatalf("expected missing archive error, got %v", err)
+	}
+}
+
+func TestGenerateBackupJobWritesPeriodicJob(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+
+	if err := generateBackupJob("backups", 14, 6*time.Hour); err != nil {
+		t.Fatalf("generateBackupJob failed: %v", err)
+	}
+
+	jobContent := readGeneratedTestFile(t, rootDir, "queue/jobs/database_backup.go")
+	if !strings.Contains(jobContent, "func (DatabaseBackupArgs) Kind() string { return \"database_backup\" }") {
+		t.Fatalf("job file should include kind\n\n%s", jobContent)
+	}
+
+	workerContent := readGeneratedTestFile(t, rootDir, "queue/database_backup.go")
+	for _, want := range []string{
+		"\"example.com/app/config\"",
+		"databaseBackupKeep     = 14",
+		"databaseBackupInterval = 6 * time.Hour",
+		"func NewDatabaseBackupPeriodicJob() *river.PeriodicJob",
+		"if config.Env != server.ProdEnvironment",
+		"exec.CommandContext(ctx, \"pg_dump\"",
+		"\"--dbname\", backupDatabaseURL(w.db),",
+		"\"PGPASSWORD=\"+w.db.Password",
+		"func (w *DatabaseBackupWorker) Timeout(*river.Job[jobs.DatabaseBackupArgs]) time.Duration",
+		"os.Rename(partialPath, dumpPath)",
+	} {
+		if !strings.Contains(workerContent, want) {
+			t.Fatalf("worker file should contain %q\n\n%s", want, workerContent)
+		}
+	}
+
+	workersContent := readGeneratedTestFile(t, rootDir, "queue/workers.go")
+	for _, want := range []string{
+		"NewDatabaseBackupWorker,",
+		"fx.Annotate(NewDatabaseBackupPeriodicJob, fx.ResultTags(periodicJobsGroup)),",
+		"worker *DatabaseBackupWorker) error",
+	} {
+		if !strings.Contains(workersContent, want) {
+			t.Fatalf("workers registration should contain %q\n\n%s", want, workersContent)
+		}
+	}
+
+	if got := goDurationExpr(90 * time.Second); got != "90 * time.Second" {
+		t.Fatalf("goDurationExpr(90s) = %q", got)
+	}
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrationDiffNow         = time.Now
+	migrationNamePattern     = regexp.MustCompile(`^[a-z0-9_]+$`)
+	migrationFileVersionExpr = regexp.MustCompile(`^(\d+)_.*\.sql$`)
+)
+
+func newDBMigrationDiffCommand() *cobra.Command {
+	var pending bool
+
+	cmd := &cobra.Command{
+		Use:   "diff [name]",
+		Short: "Create a migration from changes made to the database",
+		Long: `Compare the schema the migrations build with the database configured in
+.env and write a migration with the statements that bring the migrations
+in line with it: new and dropped tables, added, dropped and changed columns,
+indexes and enum types.
+
+Change the development database by hand, from a console or the dblab UI,
+then run diff to capture the changes. Every migration must be applied
+first. Since the database already has the changes, the new migration is
+recorded as applied; pass --pending to leave it for 'migrate up'. Changes
+the migration does not cover, such as changed foreign keys, are listed as
+comments at its top. Review the migration before committing it.`,
+		Args: cobra.MaximumNArgs(1),
+		Example: `  andurel database migrate diff
+  andurel database migrate diff add_order_shipping`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := "schema_diff"
+			if len(args) == 1 {
+				name = args[0]
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			loadProjectEnv(rootDir)
+
+			conn, err := connectProjectDatabase(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer func() { _ = conn.Close(context.Background()) }()
+
+			return runMigrationDiff(cmd.Context(), conn, rootDir, name, pending)
+		},
+	}
+	setAgentMetadata(cmd, "database", "Writes a goose migration from the differences between the migrations and the database, and records it as applied unless --pending is passed.")
+
+	cmd.Flags().BoolVar(&pending, "pending", false, "Leave the migration unapplied instead of recording it as applied")
+
+	return cmd
+}
+
+func runMigrationDiff(ctx context.Context, conn *pgx.Conn, rootDir, name string, pending bool) error {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	if !migrationNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid migration name %q: use lowercase letters, digits and underscores", name)
+	}
+
+	migrationsDir := filepath.Join(rootDir, "database", "migrations")
+	rows, err := conn.Query(ctx, "SELECT version_id FROM goose_db_version WHERE is_applied")
+	if err != nil {
+		return fmt.Errorf("read applied migrations: %w\nRun 'andurel database migrate up' first", err)
+	}
+	applied, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		return fmt.Errorf("read applied migrations: %w", err)
+	}
+	unapplied, err := pendingMigrations(migrationsDir, applied)
+	if err != nil {
+		return err
+	}
+	if len(unapplied) > 0 {
+		return fmt.Errorf(
+			"%d migrations are not applied to the database (%s)\nRun 'andurel database migrate up' before diffing",
+			len(unapplied),
+			strings.Join(unapplied, ", "),
+		)
+	}
+
+	diff, err := generator.DiffDatabase(ctx, conn, []string{migrationsDir})
+	if err != nil {
+		return err
+	}
+	for _, skipped := range diff.Skipped {
+		fmt.Printf("Skipped %s\n", skipped)
+	}
+	if diff.Empty() {
+		fmt.Println("The migrations match the database")
+		return nil
+	}
+
+	version := migrationDiffNow().UTC().Format("20060102150405")
+	path, err := writeDiffMigration(migrationsDir, version, name, diff)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Created %s\n", filepath.Join("database", "migrations", path))
+	for _, note := range diff.Notes {
+		fmt.Printf("  Note: %s\n", note)
+	}
+
+	if pending {
+		return nil
+	}
+	versionID, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Exec(ctx, "INSERT INTO goose_db_version (version_id, is_applied) VALUES ($1, true)", versionID); err != nil {
+		return fmt.Errorf("record %s as applied: %w", path, err)
+	}
+	fmt.Printf("Recorded %s as applied\n", path)
+	return nil
+}
+
+// pendingMigrations returns the SQL migrations in migrationsDir whose
+// version is not among the applied ones.
+func pendingMigrations(migrationsDir string, applied []int64) ([]string, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	done := make(map[int64]bool, len(applied))
+	for _, version := range applied {
+		done[version] = true
+	}
+
+	var pending []string
+	for _, entry := range entries {
+		m := migrationFileVersionExpr.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %s: %w", entry.Name(), err)
+		}
+		if !done[version] {
+			pending = append(pending, entry.Name())
+		}
+	}
+	return pending, nil
+}
+
+// writeDiffMigration writes the diff to migrationsDir and returns the name
+// of the file.
+func writeDiffMigration(migrationsDir, version, name string, diff *generator.SchemaDiff) (string, error) {
+	fileName := fmt.Sprintf("%s_%s.sql", version, name)
+	if err := os.WriteFile(filepath.Join(migrationsDir, fileName), []byte(diff.Migration()), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+	return fileName, nil
+}
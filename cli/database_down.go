@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+const gooseDownMarker = "-- +goose Down"
+
+func newDBMigrationCompleteDownCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "complete-down [migration]",
+		Short: "Write Down sections for migrations that only have Up",
+		Long: `Synthesize the Down section of migrations that have none, or only goose's
+placeholder, from their Up statements. Created tables, columns, indexes,
+enum types, extensions, views and triggers are dropped, dropped tables and
+columns are restored from the migrations before, and renames are reverted.
+
+Without an argument every such migration in database/migrations/ is
+completed; pass a file name to complete one. Statements that cannot be
+reverted, such as data changes, are listed as comments at the top of the
+section. The result is a starting point: review it before committing.`,
+		Args: cobra.MaximumNArgs(1),
+		Example: `  andurel database migrate complete-down
+  andurel database migrate complete-down 20260102150405_add_orders.sql --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			migrationsDir := filepath.Join(rootDir, "database", "migrations")
+			var files []string
+			if len(args) == 1 {
+				files = []string{filepath.Base(args[0])}
+			} else {
+				files, err = migrationsWithoutDown(migrationsDir)
+				if err != nil {
+					return err
+				}
+				if len(files) == 0 {
+					fmt.Println("Every migration has a Down section")
+					return nil
+				}
+			}
+
+			for _, file := range files {
+				if err := completeDownSection(migrationsDir, file, dryRun); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	setAgentMetadata(cmd, "database", "Writes synthesized goose Down sections into migrations that lack one; use --dry-run to print them instead.")
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the Down sections instead of writing them")
+
+	return cmd
+}
+
+func completeDownSection(migrationsDir, file string, dryRun bool) error {
+	path := filepath.Join(migrationsDir, file)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read migration: %w", err)
+	}
+	if hasDownStatements(string(content)) {
+		return fmt.Errorf("%s already has a Down section", file)
+	}
+
+	down, err := generator.SynthesizeDown([]string{migrationsDir}, path)
+	if err != nil {
+		return err
+	}
+
+	if len(down.Statements) == 0 && len(down.Notes) == 0 {
+		fmt.Printf("Skipped %s: nothing to revert\n", file)
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("%s:\n%s\n", file, down.Section())
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(withDownSection(string(content), down.Section())), 0o644); err != nil {
+		return fmt.Errorf("failed to write migration: %w", err)
+	}
+	fmt.Printf("Completed %s with %d statements\n", filepath.Join("database", "migrations", file), len(down.Statements))
+	for _, note := range down.Notes {
+		fmt.Printf("  Note: %s\n", note)
+	}
+	return nil
+}
+
+// migrationsWithoutDown returns the SQL migrations in migrationsDir whose
+// Down section is missing or empty.
+func migrationsWithoutDown(migrationsDir string) ([]string, error) {
+	entries, err := os.ReadDir(migrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || !migrationFileVersionExpr.MatchString(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(migrationsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration: %w", err)
+		}
+		if !hasDownStatements(string(content)) {
+			files = append(files, entry.Name())
+		}
+	}
+	return files, nil
+}
+
+// hasDownStatements reports whether a goose migration has statements after
+// its Down marker, other than the placeholder 'migrate new' writes.
+func hasDownStatements(content string) bool {
+	_, down, found := strings.Cut(content, gooseDownMarker)
+	if !found {
+		return false
+	}
+
+	for line := range strings.SplitSeq(down, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if strings.HasPrefix(line, "SELECT ") && strings.Contains(line, "SQL query") {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// withDownSection replaces the empty Down section of content, if any, with
+// section.
+func withDownSection(content, section string) string {
+	up, _, _ := strings.Cut(content, gooseDownMarker)
+	return strings.TrimRight(up, "\n") + "\n\n" + section
+}
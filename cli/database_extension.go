@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	postgresExtensionNamePattern = regexp.MustCompile(`^[a-z0-9_][a-z0-9_-]*$`)
+	createExtensionPattern       = regexp.MustCompile(`(?i)create\s+extension\s+(?:if\s+not\s+exists\s+)?"?([a-z0-9_-]+)"?`)
+)
+
+var migrationExtensionNow = time.Now
+
+const extensionMigrationTemplate = `-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS %[1]s;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP EXTENSION IF EXISTS %[1]s;
+-- +goose StatementEnd
+`
+
+func newDBMigrationExtensionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "extension NAME",
+		Aliases: []string{"ext"},
+		Short:   "Create a migration enabling a Postgres extension",
+		Long: `Create a SQL migration in database/migrations/ that enables a Postgres
+extension such as citext, pg_trgm or pgcrypto.
+
+The migration runs CREATE EXTENSION IF NOT EXISTS on the way up and drops
+the extension on the way down. Nothing is written when an existing
+migration already enables the extension.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  andurel database migrate extension citext
+  andurel database migrate extension pg_trgm`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			path, err := generateExtensionMigration(rootDir, args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Created %s\n", path)
+			return nil
+		},
+	}
+	setAgentMetadata(cmd, "database", "Writes a goose migration enabling a Postgres extension. Fails if a migration already enables it.")
+
+	return cmd
+}
+
+func generateExtensionMigration(rootDir, name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !postgresExtensionNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid extension name %q: use lowercase letters, digits, underscores and hyphens", name)
+	}
+
+	migrationsDir := filepath.Join(rootDir, "database", "migrations")
+	existing, err := findExtensionMigration(migrationsDir, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != "" {
+		return "", fmt.Errorf("extension %s is already enabled by %s", name, existing)
+	}
+
+	if err := os.MkdirAll(migrationsDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create migrations directory: %w", err)
+	}
+
+	fileName := fmt.Sprintf(
+		"%s_enable_%s_extension.sql",
+		migrationExtensionNow().UTC().Format("20060102150405"),
+		strings.ReplaceAll(name, "-", "_"),
+	)
+	path := filepath.Join(migrationsDir, fileName)
+	content := fmt.Sprintf(extensionMigrationTemplate, quoteIdentifier(name))
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write migration: %w", err)
+	}
+
+	return filepath.Join("database", "migrations", fileName), nil
+}
+
+// findExtensionMigration returns the name of the first migration that
+// already creates the extension, or an empty string.
+func findExtensionMigration(migrationsDir, name string) (string, error) {
+	files, err := filepath.Glob(filepath.Join(migrationsDir, "*.sql"))
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		for _, match := range createExtensionPattern.FindAllStringSubmatch(string(content), -1) {
+			if strings.EqualFold(match[1], name) {
+				return filepath.Base(file), nil
+			}
+		}
+	}
+
+	return "", nil
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	generatorpkg "github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/spf13/cobra"
+)
+
+func newDestroyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "destroy",
+		Short: "Remove generated code",
+		Long: `Removes code written by the generators, undoing them.
+
+  resource    Remove a resource generated with 'andurel generate scaffold'`,
+		Example: `  andurel destroy resource Product
+  andurel destroy resource admin/Widget --dry-run`,
+	}
+	setAgentMetadata(cmd, "generation", "Deletes generated project files. Use --dry-run to preview the removal.")
+
+	cmd.AddCommand(newDestroyResourceCommand())
+	loadProjectInflections(cmd)
+	recordGeneratorRuns(cmd)
+
+	return cmd
+}
+
+func newDestroyResourceCommand() *cobra.Command {
+	var (
+		api    bool
+		dryRun bool
+		diff   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "resource NAME",
+		Short: "Remove a generated resource",
+		Long: `Removes a resource generated with 'andurel generate scaffold'. Pass the
+resource name in CamelCase as the first argument, with the namespace it
+was generated in, such as admin/Widget.
+
+Deletes the controller, routes and views, and the filters, feed, share
+links and calendar feed generated for the resource. The model, factory
+and serializer are deleted too, unless a controller in another namespace
+still uses the model. The controllers are taken out of
+controllers/controller.go, a deleted model out of models/model.go, and the
+feed out of the sitemap.
+
+Migrations are kept, so the table stays. Generate a migration that drops
+it if the data should go too.
+
+The whole project is compiled afterwards. If any code still uses what was
+removed, the removal is rolled back and the compiler errors are shown.`,
+		Example: `  andurel destroy resource Product
+
+      Removes models/product.go, models/factories/product.go,
+      controllers/products.go, router/routes/products.go and
+      views/products_resource.templ.
+
+  andurel destroy resource User --api
+
+      Removes a resource generated with 'generate scaffold User --api'.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: destroy resource takes exactly 1 argument (the resource name)")
+			}
+			name := args[0]
+			namespace, resourceName, err := naming.ParseNamespacedResource(name)
+			if err != nil {
+				return err
+			}
+			if api {
+				namespace = apiNamespace(namespace)
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:        "destroy resource",
+				Resource:      name,
+				RootDir:       rootDir,
+				DryRun:        dryRun,
+				Diff:          diff,
+				VerifyProject: true,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel generate view", Description: "Recompile the remaining views"},
+				},
+				Run: func(rootDir string) error {
+					gen, err := newGenerator()
+					if err != nil {
+						return err
+					}
+					if err := gen.DestroyResource(resourceName, namespace); err != nil {
+						return err
+					}
+					return refreshRoutesTSAfterInertiaGeneration(rootDir, generatorpkg.ReadInertia(), api)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&api, "api", false, "Remove a JSON API resource generated under controllers/api")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
@@ -87,29 +87,36 @@ This command will check:
   • Environment (Go version, latest stable Andurel release)
   • Configuration (andurel.lock)
   • Code quality (go vet, go mod tidy)
-  • Code generation (templ)`,
+  • Code generation (templ)
+
+With --a11y, doctor also renders the views covered by viewtest snapshot tests
+and checks their HTML for invalid markup, images without alt text, form
+controls without labels, and skipped heading levels.`,
 		Example: `  andurel doctor
-  andurel doctor --verbose`,
+  andurel doctor --verbose
+  andurel doctor --a11y`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			verbose, _ := cmd.Flags().GetBool("verbose")
+			a11y, _ := cmd.Flags().GetBool("a11y")
 			opts, err := output.ParseOptions(cmd)
 			if err != nil {
 				return err
 			}
 			if opts.Mode == output.ModeJSON || opts.Mode == output.ModeAgent {
-				return runDoctorStructured(cmd, currentVersion, verbose)
+				return runDoctorStructured(cmd, currentVersion, verbose, a11y)
 			}
-			return runDoctor(currentVersion, verbose)
+			return runDoctor(currentVersion, verbose, a11y)
 		},
 	}
 
 	doctorCmd.Flags().Bool("verbose", false, "Emit verbose diagnostic output")
+	doctorCmd.Flags().Bool("a11y", false, "Check the HTML and accessibility of views rendered by viewtest snapshot tests")
 
 	return doctorCmd
 }
 
-func runDoctorStructured(cmd *cobra.Command, currentVersion string, verbose bool) error {
-	report, err := collectDoctorReport(currentVersion, verbose)
+func runDoctorStructured(cmd *cobra.Command, currentVersion string, verbose, a11y bool) error {
+	report, err := collectDoctorReport(currentVersion, verbose, false, a11y)
 	if err != nil {
 		return err
 	}
@@ -122,12 +129,23 @@ func runDoctorStructured(cmd *cobra.Command, currentVersion string, verbose bool
 	return nil
 }
 
-func collectDoctorReport(currentVersion string, verbose bool) (doctorReport, error) {
+// collectDoctorReport runs the doctor checks. Offline reports skip the
+// latest release lookup, the only check that contacts a remote service.
+// a11y adds the accessibility checks of rendered views.
+func collectDoctorReport(currentVersion string, verbose, offline, a11y bool) (doctorReport, error) {
 	var results []checkResult
 
+	releaseCheck := checkResult{
+		name:    "Andurel release",
+		status:  statusPass,
+		message: "offline; update check skipped",
+	}
+	if !offline {
+		releaseCheck = checkLatestAndurelRelease(currentVersion)
+	}
 	results = append(results, categorizeResults("environment",
 		checkGoVersion(),
-		checkLatestAndurelRelease(currentVersion),
+		releaseCheck,
 		checkInAndurelProject(),
 	)...)
 
@@ -140,17 +158,26 @@ func collectDoctorReport(currentVersion string, verbose bool) (doctorReport, err
 		checkLockFile(rootDir),
 		checkAndurelVersion(rootDir, currentVersion),
 		checkToolVersions(rootDir, verbose),
+		checkDatabasePool(rootDir),
+		checkCookieSecurity(rootDir),
 	)...)
 
 	results = append(results, categorizeResults("code_quality",
 		checkGoVet(rootDir, verbose),
 		checkGoModTidy(rootDir, verbose),
+		checkControllerRoutes(rootDir),
 	)...)
 
 	results = append(results, categorizeResults("code_generation",
 		codeGenerationChecks(rootDir, verbose)...,
 	)...)
 
+	if a11y {
+		results = append(results, categorizeResults("accessibility",
+			checkViewAccessibility(rootDir, verbose),
+		)...)
+	}
+
 	return buildDoctorReport(currentVersion, rootDir, results), nil
 }
 
@@ -244,7 +271,7 @@ func doctorSummaryMessage(report doctorReport) string {
 	}
 }
 
-func runDoctor(currentVersion string, verbose bool) error {
+func runDoctor(currentVersion string, verbose, a11y bool) error {
 	printDoctorBanner()
 	fmt.Println("Running Andurel project diagnostics...")
 
@@ -276,6 +303,8 @@ func runDoctor(currentVersion string, verbose bool) error {
 		checkLockFile(rootDir),
 		checkAndurelVersion(rootDir, currentVersion),
 		checkToolVersions(rootDir, verbose),
+		checkDatabasePool(rootDir),
+		checkCookieSecurity(rootDir),
 	}
 	results = append(results, configResults...)
 	printResults(configResults, verbose)
@@ -285,6 +314,7 @@ func runDoctor(currentVersion string, verbose bool) error {
 	qualityResults := []checkResult{
 		checkGoVet(rootDir, verbose),
 		checkGoModTidy(rootDir, verbose),
+		checkControllerRoutes(rootDir),
 	}
 	results = append(results, qualityResults...)
 	printResults(qualityResults, verbose)
@@ -295,6 +325,16 @@ func runDoctor(currentVersion string, verbose bool) error {
 	results = append(results, genResults...)
 	printResults(genResults, verbose)
 
+	// Accessibility checks
+	if a11y {
+		fmt.Println("\n=== Accessibility ===")
+		a11yResults := []checkResult{
+			checkViewAccessibility(rootDir, verbose),
+		}
+		results = append(results, a11yResults...)
+		printResults(a11yResults, verbose)
+	}
+
 	// Summary
 	passCount := 0
 	warnCount := 0
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Defaults of the pool settings in the generated config/database.go.
+var databasePoolDefaults = map[string]string{
+	"DB_MAX_CONNS":            "25",
+	"DB_MIN_CONNS":            "2",
+	"DB_MAX_CONN_LIFETIME":    "1h",
+	"DB_STATEMENT_CACHE_MODE": "cache_statement",
+}
+
+var statementCacheModes = map[string]bool{
+	"cache_statement": true,
+	"cache_describe":  true,
+	"describe_exec":   true,
+	"exec":            true,
+	"simple_protocol": true,
+}
+
+// checkDatabasePool flags pool settings in .env, or the process
+// environment, that break or degrade the generated database connection.
+func checkDatabasePool(rootDir string) checkResult {
+	const name = "database pool"
+
+	values, err := godotenv.Read(filepath.Join(rootDir, ".env"))
+	if err != nil && !os.IsNotExist(err) {
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: fmt.Sprintf("could not read .env: %v", err),
+		}
+	}
+	lookup := func(key string) string {
+		if value, ok := os.LookupEnv(key); ok {
+			return value
+		}
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return databasePoolDefaults[key]
+	}
+
+	var failures, warnings []string
+
+	mode := lookup("DB_STATEMENT_CACHE_MODE")
+	if !statementCacheModes[mode] {
+		failures = append(failures, fmt.Sprintf("DB_STATEMENT_CACHE_MODE %q is not a pgx query exec mode; the app will not start", mode))
+	}
+	if usesPgBouncer(lookup("DB_HOST"), lookup("DB_PORT")) && (mode == "cache_statement" || mode == "cache_describe") {
+		warnings = append(warnings, fmt.Sprintf("DB_STATEMENT_CACHE_MODE=%s caches prepared statements per connection, which PgBouncer in transaction pooling mode does not keep; use exec or simple_protocol", mode))
+	}
+
+	maxConns, maxErr := strconv.Atoi(lookup("DB_MAX_CONNS"))
+	minConns, minErr := strconv.Atoi(lookup("DB_MIN_CONNS"))
+	switch {
+	case maxErr != nil:
+		failures = append(failures, fmt.Sprintf("DB_MAX_CONNS %q is not a number", lookup("DB_MAX_CONNS")))
+	case minErr != nil:
+		failures = append(failures, fmt.Sprintf("DB_MIN_CONNS %q is not a number", lookup("DB_MIN_CONNS")))
+	case maxConns <= 0:
+		warnings = append(warnings, "DB_MAX_CONNS is unlimited; a traffic spike can exhaust the server's max_connections")
+	case minConns > maxConns:
+		warnings = append(warnings, fmt.Sprintf("DB_MIN_CONNS (%d) is above DB_MAX_CONNS (%d); only %d connections are warmed up", minConns, maxConns, maxConns))
+	}
+
+	if lifetime, err := time.ParseDuration(lookup("DB_MAX_CONN_LIFETIME")); err != nil {
+		failures = append(failures, fmt.Sprintf("DB_MAX_CONN_LIFETIME %q is not a duration", lookup("DB_MAX_CONN_LIFETIME")))
+	} else if lifetime > 0 && lifetime < time.Minute {
+		warnings = append(warnings, fmt.Sprintf("DB_MAX_CONN_LIFETIME=%s reconnects constantly and empties the statement cache", lifetime))
+	}
+
+	switch {
+	case len(failures) > 0:
+		return checkResult{
+			name:    name,
+			status:  statusFail,
+			message: strings.Join(append(failures, warnings...), "; "),
+			hint:    "Fix the DB_* pool settings in .env.",
+		}
+	case len(warnings) > 0:
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: strings.Join(warnings, "; "),
+			hint:    "Review the DB_* pool settings in .env.",
+		}
+	default:
+		return checkResult{
+			name:    name,
+			status:  statusPass,
+			message: fmt.Sprintf("%d max connections, %s statement cache", maxConns, mode),
+		}
+	}
+}
+
+// usesPgBouncer guesses from the connection target whether the app talks to
+// PgBouncer, which listens on 6432 by default.
+func usesPgBouncer(host, port string) bool {
+	return port == "6432" || strings.Contains(strings.ToLower(host), "pgbouncer")
+}
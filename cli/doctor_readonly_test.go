@@ -53,7 +53,7 @@ var Home = router.NewRoute("/", "home")
 	originalFindGoModRoot := findGoModRoot
 	findGoModRoot = func() (string, error) { return root, nil }
 	t.Cleanup(func() { findGoModRoot = originalFindGoModRoot })
-	if _, err := collectDoctorReport("v1.0.0", true); err != nil {
+	if _, err := collectDoctorReport("v1.0.0", true, false, false); err != nil {
 		t.Fatalf("collect doctor report: %v", err)
 	}
 	if afterReport := snapshotAllTestFiles(t, root); !reflect.DeepEqual(afterReport, before) {
@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mbvlabs/andurel/pkg/routecheck"
+)
+
+// checkControllerRoutes flags route registrations in controllers/ whose
+// handler was renamed or changed, routes added twice, and actions no route
+// reaches.
+func checkControllerRoutes(rootDir string) checkResult {
+	const name = "controller routes"
+
+	problems, err := routecheck.Check(filepath.Join(rootDir, "controllers"))
+	if err != nil {
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: "could not parse controllers",
+			details: []string{err.Error()},
+		}
+	}
+
+	var blocking int
+	details := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		if problem.Blocking() {
+			blocking++
+		}
+		if rel, err := filepath.Rel(rootDir, problem.File); err == nil {
+			problem.File = rel
+		}
+		details = append(details, problem.String())
+	}
+
+	switch {
+	case blocking > 0:
+		return checkResult{
+			name:    name,
+			status:  statusFail,
+			message: fmt.Sprintf("%d route registrations do not match their controller", blocking),
+			details: details,
+			hint:    "Point each route's Handler at an existing func(*echo.Context) error method and remove duplicate registrations.",
+		}
+	case len(problems) > 0:
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: fmt.Sprintf("%d controller actions are not registered", len(problems)),
+			details: details,
+			hint:    "Register the actions in RegisterRoutes or remove them.",
+		}
+	default:
+		return checkResult{
+			name:    name,
+			status:  statusPass,
+			message: "every route handler matches a controller action",
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// Defaults of the cookie settings in the generated config/app.go.
+var cookieSecurityDefaults = map[string]string{
+	"ENVIRONMENT":              "development",
+	"PROTOCOL":                 "http",
+	"SESSION_COOKIE_SAME_SITE": "lax",
+	"CSRF_COOKIE_SAME_SITE":    "strict",
+	"CSRF_ROTATE_ON_LOGIN":     "true",
+}
+
+// checkCookieSecurity flags session and CSRF cookie settings in .env, or the
+// process environment, that the app rejects or that are unsafe in
+// production.
+func checkCookieSecurity(rootDir string) checkResult {
+	const name = "cookie security"
+
+	values, err := godotenv.Read(filepath.Join(rootDir, ".env"))
+	if err != nil && !os.IsNotExist(err) {
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: fmt.Sprintf("could not read .env: %v", err),
+		}
+	}
+	lookup := func(key string) string {
+		if value, ok := os.LookupEnv(key); ok {
+			return strings.TrimSpace(value)
+		}
+		if value, ok := values[key]; ok {
+			return strings.TrimSpace(value)
+		}
+		return cookieSecurityDefaults[key]
+	}
+
+	environment := lookup("ENVIRONMENT")
+	production := environment == "production"
+
+	var failures, warnings []string
+	for _, prefix := range []string{"SESSION_COOKIE", "CSRF_COOKIE"} {
+		sameSite := strings.ToLower(lookup(prefix + "_SAME_SITE"))
+		if sameSite != "lax" && sameSite != "strict" && sameSite != "none" {
+			failures = append(failures, fmt.Sprintf("%s_SAME_SITE %q is not lax, strict or none; the app will not start", prefix, sameSite))
+			continue
+		}
+
+		secure := production
+		if value := lookup(prefix + "_SECURE"); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s_SECURE %q is not a boolean; the app will not start", prefix, value))
+				continue
+			}
+			secure = parsed
+		}
+
+		if sameSite == "none" && !secure {
+			failures = append(failures, fmt.Sprintf("%s_SAME_SITE=none requires %s_SECURE=true; the app will not start", prefix, prefix))
+			continue
+		}
+		if !production {
+			continue
+		}
+		if !secure {
+			warnings = append(warnings, fmt.Sprintf("%s_SECURE=false sends the cookie over plain http", prefix))
+		}
+		if prefix == "SESSION_COOKIE" && sameSite == "none" {
+			warnings = append(warnings, "SESSION_COOKIE_SAME_SITE=none sends the session with cross-site requests")
+		}
+	}
+
+	if production {
+		if protocol := lookup("PROTOCOL"); protocol != "https" {
+			warnings = append(warnings, fmt.Sprintf("PROTOCOL=%s serves production over plain http", protocol))
+		}
+		if rotate, err := strconv.ParseBool(lookup("CSRF_ROTATE_ON_LOGIN")); err == nil && !rotate {
+			warnings = append(warnings, "CSRF_ROTATE_ON_LOGIN=false keeps the CSRF token across sign-ins")
+		}
+		if domain := lookup("SESSION_COOKIE_DOMAIN"); domain != "" {
+			warnings = append(warnings, fmt.Sprintf("SESSION_COOKIE_DOMAIN=%s shares the session with every subdomain", domain))
+		}
+	}
+
+	switch {
+	case len(failures) > 0:
+		return checkResult{
+			name:    name,
+			status:  statusFail,
+			message: strings.Join(append(failures, warnings...), "; "),
+			hint:    "Fix the SESSION_COOKIE_* and CSRF_COOKIE_* settings in .env.",
+		}
+	case len(warnings) > 0:
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: strings.Join(warnings, "; "),
+			hint:    "Review the cookie settings of the production environment.",
+		}
+	default:
+		return checkResult{
+			name:    name,
+			status:  statusPass,
+			message: fmt.Sprintf("session and CSRF cookies are valid for %s", environment),
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -183,7 +184,7 @@ func TestRunDoctorStructuredPassAndFail(t *testing.T) {
 
 	var out bytes.Buffer
 	cmd := newStructuredTestCommand(&out)
-	if err := runDoctorStructured(cmd, "1.2.3", false); err != nil {
+	if err := runDoctorStructured(cmd, "1.2.3", false, false); err != nil {
 		t.Fatalf("runDoctorStructured pass: %v", err)
 	}
 	var envelope output.Envelope
@@ -197,7 +198,7 @@ func TestRunDoctorStructuredPassAndFail(t *testing.T) {
 	findGoModRoot = func() (string, error) {
 		return "", os.ErrNotExist
 	}
-	if err := runDoctorStructured(cmd, "1.2.3", false); err == nil {
+	if err := runDoctorStructured(cmd, "1.2.3", false, false); err == nil {
 		t.Fatalf("expected structured doctor to fail outside project")
 	}
 }
@@ -346,7 +347,7 @@ func TestDoctorCollectReportAndCodeGenerationChecks(t *testing.T) {
 		findGoModRoot = originalFindGoModRoot
 	})
 
-	report, err := collectDoctorReport("1.2.3", true)
+	report, err := collectDoctorReport("1.2.3", true, false, false)
 	if err != nil {
 		t.Fatalf("collectDoctorReport: %v", err)
 	}
@@ -391,7 +392,7 @@ func TestRunDoctorHumanPassWarnAndProjectFailure(t *testing.T) {
 
 	writeExecutable(t, root, "bin/templ", "#!/bin/sh\nexit 0\n")
 	capture := captureProcessOutput(t, &os.Stdout)
-	if err := runDoctor("1.2.3", true); err != nil {
+	if err := runDoctor("1.2.3", true, false); err != nil {
 		t.Fatalf("runDoctor pass: %v", err)
 	}
 	if out := capture(); !strings.Contains(out, "All checks passed") {
@@ -402,7 +403,7 @@ func TestRunDoctorHumanPassWarnAndProjectFailure(t *testing.T) {
 		t.Fatalf("remove templ: %v", err)
 	}
 	capture = captureProcessOutput(t, &os.Stdout)
-	if err := runDoctor("1.2.3", false); err != nil {
+	if err := runDoctor("1.2.3", false, false); err != nil {
 		t.Fatalf("runDoctor warn: %v", err)
 	}
 	if out := capture(); !strings.Contains(out, "warnings to review") {
@@ -411,7 +412,7 @@ func TestRunDoctorHumanPassWarnAndProjectFailure(t *testing.T) {
 
 	findGoModRoot = func() (string, error) { return "", os.ErrNotExist }
 	capture = captureProcessOutput(t, &os.Stdout)
-	if err := runDoctor("1.2.3", false); err == nil {
+	if err := runDoctor("1.2.3", false, false); err == nil {
 		t.Fatalf("expected project failure")
 	}
 	if out := capture(); !strings.Contains(out, "Cannot continue") {
@@ -462,3 +463,148 @@ func writeExecutable(t *testing.T, root, rel, content string) {
 		t.Fatalf("write executable %s: %v", rel, err)
 	}
 }
+
+func TestDoctorDatabasePoolCheck(t *testing.T) {
+	for _, key := range []string{"DB_HOST", "DB_PORT", "DB_MAX_CONNS", "DB_MIN_CONNS", "DB_MAX_CONN_LIFETIME", "DB_STATEMENT_CACHE_MODE"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Skipf("%s is set in the environment", key)
+		}
+	}
+
+	root := t.TempDir()
+	if result := checkDatabasePool(root); result.status != statusPass {
+		t.Fatalf("default pool check = %#v", result)
+	}
+
+	writeTestFile(t, root, ".env", "DB_HOST=pgbouncer.internal\nDB_PORT=6432\nDB_MIN_CONNS=40\n")
+	risky := checkDatabasePool(root)
+	if risky.status != statusWarn {
+		t.Fatalf("risky pool check = %#v", risky)
+	}
+	for _, want := range []string{"PgBouncer", "DB_MIN_CONNS (40) is above DB_MAX_CONNS (25)"} {
+		if !strings.Contains(risky.message, want) {
+			t.Errorf("risky pool message missing %q: %s", want, risky.message)
+		}
+	}
+
+	writeTestFile(t, root, ".env", "DB_PORT=6432\nDB_STATEMENT_CACHE_MODE=simple_protocol\n")
+	if result := checkDatabasePool(root); result.status != statusPass {
+		t.Fatalf("pgbouncer with simple protocol = %#v", result)
+	}
+
+	writeTestFile(t, root, ".env", "DB_STATEMENT_CACHE_MODE=prepared\n")
+	if result := checkDatabasePool(root); result.status != statusFail {
+		t.Fatalf("unknown statement cache mode = %#v", result)
+	}
+}
+
+func TestDoctorControllerRoutesCheck(t *testing.T) {
+	root := t.TempDir()
+	if result := checkControllerRoutes(root); result.status != statusPass {
+		t.Fatalf("project without controllers = %#v", result)
+	}
+
+	controller := `package controllers
+
+import "github.com/labstack/echo/v5"
+
+type Pages struct{}
+
+func (p Pages) RegisterRoutes(r *router.Router) error {
+	_, err := r.AddRoute(echo.Route{Method: "GET", Path: "/", Handler: p.%s})
+	return err
+}
+
+func (p Pages) Home(etx *echo.Context) error { return nil }
+
+func (p Pages) About(etx *echo.Context) error { return nil }
+`
+	writeTestFile(t, root, "controllers/pages.go", fmt.Sprintf(controller, "Home"))
+	unregistered := checkControllerRoutes(root)
+	if unregistered.status != statusWarn {
+		t.Fatalf("unregistered action = %#v", unregistered)
+	}
+	if len(unregistered.details) != 1 || !strings.HasPrefix(unregistered.details[0], filepath.Join("controllers", "pages.go")+":") {
+		t.Errorf("unregistered details = %v", unregistered.details)
+	}
+
+	writeTestFile(t, root, "controllers/pages.go", fmt.Sprintf(controller, "Index"))
+	if result := checkControllerRoutes(root); result.status != statusFail {
+		t.Fatalf("renamed handler = %#v", result)
+	}
+}
+
+func TestDoctorViewAccessibilityCheck(t *testing.T) {
+	root := t.TempDir()
+	if result := checkViewAccessibility(root, false); result.status != statusWarn || !strings.Contains(result.message, "internal/viewtest") {
+		t.Fatalf("project without viewtest = %#v", result)
+	}
+	writeTestFile(t, root, "internal/viewtest/viewtest.go", "package viewtest\n")
+
+	fakePath := t.TempDir()
+	t.Setenv("PATH", fakePath+string(os.PathListSeparator)+os.Getenv("PATH"))
+	render := func(html string) {
+		writeExecutable(t, fakePath, "go", fmt.Sprintf("#!/bin/sh\nmkdir -p \"$VIEWTEST_OUTPUT_DIR\"\nprintf '%s' > \"$VIEWTEST_OUTPUT_DIR/products_show.html\"\n", html))
+	}
+
+	render(`<main>\n<h1>Product</h1>\n<img src="/p.png" alt="Product photo">\n</main>\n`)
+	if result := checkViewAccessibility(root, false); result.status != statusPass {
+		t.Fatalf("accessible view = %#v", result)
+	}
+
+	render(`<main>\n<h1>Product</h1>\n<img src="/p.png">\n</main>\n`)
+	missingAlt := checkViewAccessibility(root, false)
+	if missingAlt.status != statusWarn || len(missingAlt.details) != 1 || missingAlt.details[0] != "products_show:3: <img> has no alt text" {
+		t.Fatalf("missing alt = %#v", missingAlt)
+	}
+
+	render(`<main>\n<h1>Product</h1>\n`)
+	if result := checkViewAccessibility(root, false); result.status != statusFail {
+		t.Fatalf("unclosed element = %#v", result)
+	}
+
+	writeExecutable(t, fakePath, "go", "#!/bin/sh\necho 'views/products_test.go:3: undefined: Product'\nexit 1\n")
+	failed := checkViewAccessibility(root, false)
+	if failed.status != statusFail || !strings.Contains(failed.details[0], "undefined: Product") {
+		t.Fatalf("failing view tests = %#v", failed)
+	}
+}
+
+func TestDoctorCookieSecurityCheck(t *testing.T) {
+	for _, key := range []string{"ENVIRONMENT", "PROTOCOL", "SESSION_COOKIE_SAME_SITE", "SESSION_COOKIE_SECURE", "SESSION_COOKIE_DOMAIN", "CSRF_COOKIE_SAME_SITE", "CSRF_COOKIE_SECURE", "CSRF_ROTATE_ON_LOGIN"} {
+		if _, ok := os.LookupEnv(key); ok {
+			t.Skipf("%s is set in the environment", key)
+		}
+	}
+
+	root := t.TempDir()
+	if result := checkCookieSecurity(root); result.status != statusPass {
+		t.Fatalf("default cookie check = %#v", result)
+	}
+
+	writeTestFile(t, root, ".env", "ENVIRONMENT=production\nPROTOCOL=https\n")
+	if result := checkCookieSecurity(root); result.status != statusPass {
+		t.Fatalf("production defaults = %#v", result)
+	}
+
+	writeTestFile(t, root, ".env", "ENVIRONMENT=production\nPROTOCOL=http\nSESSION_COOKIE_SECURE=false\nSESSION_COOKIE_DOMAIN=example.com\nCSRF_ROTATE_ON_LOGIN=false\n")
+	risky := checkCookieSecurity(root)
+	if risky.status != statusWarn {
+		t.Fatalf("risky production cookies = %#v", risky)
+	}
+	for _, want := range []string{"SESSION_COOKIE_SECURE=false", "PROTOCOL=http", "CSRF_ROTATE_ON_LOGIN=false", "SESSION_COOKIE_DOMAIN=example.com"} {
+		if !strings.Contains(risky.message, want) {
+			t.Errorf("risky cookie message missing %q: %s", want, risky.message)
+		}
+	}
+
+	writeTestFile(t, root, ".env", "CSRF_COOKIE_SAME_SITE=none\n")
+	if result := checkCookieSecurity(root); result.status != statusFail {
+		t.Fatalf("SameSite=none without Secure = %#v", result)
+	}
+
+	writeTestFile(t, root, ".env", "SESSION_COOKIE_SAME_SITE=relaxed\n")
+	if result := checkCookieSecurity(root); result.status != statusFail {
+		t.Fatalf("unknown SameSite value = %#v", result)
+	}
+}
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/pkg/htmlcheck"
+)
+
+const viewAccessibilityTimeout = 5 * time.Minute
+
+// checkViewAccessibility runs the view snapshot tests in a copy of the
+// project with VIEWTEST_OUTPUT_DIR set, so internal/viewtest writes every
+// page it renders, and checks the HTML with htmlcheck.
+func checkViewAccessibility(rootDir string, verbose bool) checkResult {
+	const name = "view accessibility"
+
+	if _, err := os.Stat(filepath.Join(rootDir, "internal", "viewtest", "viewtest.go")); err != nil {
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: "internal/viewtest is missing",
+			hint:    "Run andurel upgrade to add internal/viewtest, then generate view tests with andurel generate scaffold.",
+		}
+	}
+
+	var (
+		rendered map[string][]htmlcheck.Problem
+		testLog  string
+	)
+	err := withDiagnosticProjectCopy(rootDir, func(tempRoot string) error {
+		outputDir := filepath.Join(tempRoot, ".andurel-rendered-views")
+
+		ctx, cancel := context.WithTimeout(context.Background(), viewAccessibilityTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, "go", "test", "-count=1", "./views/...")
+		cmd.Dir = tempRoot
+		cmd.Env = append(os.Environ(), "VIEWTEST_OUTPUT_DIR="+outputDir)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			testLog = strings.TrimSpace(string(output))
+		}
+
+		var err error
+		rendered, err = checkRenderedViews(outputDir)
+		return err
+	})
+	if err != nil {
+		return checkResult{
+			name:    name,
+			status:  statusFail,
+			message: "could not check rendered views",
+			details: []string{err.Error()},
+		}
+	}
+
+	if len(rendered) == 0 {
+		result := checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: "no views were rendered",
+			hint:    "Add viewtest.Snapshot tests under views/; templ scaffolds generate them.",
+		}
+		if testLog != "" {
+			result.status = statusFail
+			result.message = "view tests failed before rendering a view"
+			result.details = strings.Split(testLog, "\n")
+			if !verbose {
+				result.details = truncateDetails(result.details, 20)
+			}
+			result.hint = "Run go test ./views/... and fix the failures."
+		}
+		return result
+	}
+
+	var (
+		details  []string
+		problems int
+		blocking int
+	)
+	for _, view := range slices.Sorted(maps.Keys(rendered)) {
+		for _, problem := range rendered[view] {
+			problems++
+			if problem.Blocking() {
+				blocking++
+			}
+			details = append(details, fmt.Sprintf("%s:%d: %s", view, problem.Line, problem.Message))
+		}
+	}
+	if !verbose {
+		details = truncateDetails(details, 10)
+	}
+
+	switch {
+	case blocking > 0:
+		return checkResult{
+			name:    name,
+			status:  statusFail,
+			message: fmt.Sprintf("%d problems in %d rendered views, %d of them invalid HTML", problems, len(rendered), blocking),
+			details: details,
+			hint:    "Fix the markup in the templ files the views come from, then run andurel generate view.",
+		}
+	case problems > 0:
+		return checkResult{
+			name:    name,
+			status:  statusWarn,
+			message: fmt.Sprintf("%d accessibility problems in %d rendered views", problems, len(rendered)),
+			details: details,
+			hint:    "Add alt text, labels and consecutive heading levels in the templ files the views come from.",
+		}
+	default:
+		return checkResult{
+			name:    name,
+			status:  statusPass,
+			message: fmt.Sprintf("%d rendered views pass", len(rendered)),
+		}
+	}
+}
+
+// checkRenderedViews checks every .html file under dir and returns the
+// problems by view name, the file's path without the extension. A missing
+// directory has no views.
+func checkRenderedViews(dir string) (map[string][]htmlcheck.Problem, error) {
+	views := map[string][]htmlcheck.Problem{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return fs.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".html" {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		problems, err := htmlcheck.Check(file)
+		if err != nil {
+			return fmt.Errorf("check %s: %w", path, err)
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		views[filepath.ToSlash(strings.TrimSuffix(rel, ".html"))] = problems
+		return nil
+	})
+	return views, err
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/pkg/naming"
 	"github.com/spf13/cobra"
 )
 
@@ -40,8 +41,10 @@ generators are available:
   controller  Generate a controller, views, and routes
   scaffold    Generate a complete resource with model, controller, views, and routes
   job         Generate a background job with a worker
+  page        Generate a static page, or replace the onboarding home page
   email       Generate an email template
   routes      Generate TypeScript route helpers for Inertia frontends
+  loadtest    Generate a k6 load test of the GET routes with seeded records
 
 Controller and scaffold names may include one lowercase namespace segment,
 for example admin/Widget. Namespaces generate controllers/admin, admin route
@@ -57,6 +60,7 @@ names, and Admin-prefixed route/view symbols.`,
   andurel generate scaffold admin/Widget
   andurel generate job SendWelcomeEmail
   andurel generate email WelcomeEmail
+  andurel generate page about
   andurel generate routes`,
 	}
 	setAgentMetadata(cmd, "generation", "Requires an Andurel project root for generators that inspect or write project files.")
@@ -68,10 +72,26 @@ names, and Admin-prefixed route/view symbols.`,
 		newGenerateViewsCommand(),
 		newGenerateControllerCommand(),
 		newGenerateScaffoldCommand(),
+		newGenerateAutosaveCommand(),
+		newGenerateSavedViewsCommand(),
+		newGenerateShareCommand(),
+		newGenerateCalendarCommand(),
+		newGenerateSerializerCommand(),
 		newGenerateJobCommand(),
+		newGenerateBackupJobCommand(),
+		newGenerateDeadLetterJobCommand(),
+		newGenerateProgressCommand(),
+		newGenerateDevDashboardCommand(),
+		newGenerateRequestRecorderCommand(),
+		newGenerateAnalyticsCommand(),
+		newGenerateChartsCommand(),
+		newGenerateLoadTestCommand(),
 		newGenerateEmailCommand(),
+		newGeneratePageCommand(),
 		newGenerateRoutesCommand(),
 	)
+	loadProjectInflections(cmd)
+	recordGeneratorRuns(cmd)
 
 	setStandardHelp(cmd,
 		helpCommand{
@@ -98,14 +118,70 @@ names, and Admin-prefixed route/view symbols.`,
 			Use:         "generate scaffold [namespace/]NAME",
 			Description: "generates a complete scaffold resource",
 		},
+		helpCommand{
+			Use:         "generate autosave RESOURCE",
+			Description: "adds draft autosave to a resource's forms",
+		},
+		helpCommand{
+			Use:         "generate saved-views RESOURCE",
+			Description: "adds saved filter and sort views to a resource index",
+		},
+		helpCommand{
+			Use:         "generate share RESOURCE",
+			Description: "adds expiring public share links to a resource",
+		},
+		helpCommand{
+			Use:         "generate calendar RESOURCE",
+			Description: "adds a per-user ICS calendar feed to a resource",
+		},
+		helpCommand{
+			Use:         "generate serializer MODEL",
+			Description: "generates a JSON serializer for a model",
+		},
 		helpCommand{
 			Use:         "generate job NAME",
 			Description: "generates a new background job",
 		},
+		helpCommand{
+			Use:         "generate backup-job",
+			Description: "generates a scheduled database backup job",
+		},
+		helpCommand{
+			Use:         "generate dead-letter-job",
+			Description: "generates a scheduled report of permanently failed jobs",
+		},
+		helpCommand{
+			Use:         "generate progress JOB_NAME",
+			Description: "generates a job with browser progress reporting",
+		},
+		helpCommand{
+			Use:         "generate dev-dashboard",
+			Description: "generates a development-only project health dashboard",
+		},
+		helpCommand{
+			Use:         "generate request-recorder",
+			Description: "generates development middleware that records requests for replay",
+		},
+		helpCommand{
+			Use:         "generate analytics",
+			Description: "generates first-party page view analytics with admin charts",
+		},
+		helpCommand{
+			Use:         "generate charts",
+			Description: "generates inline SVG sparkline, bar, and line chart components",
+		},
+		helpCommand{
+			Use:         "generate loadtest",
+			Description: "generates a k6 load test of the GET routes with seeded records",
+		},
 		helpCommand{
 			Use:         "generate email NAME",
 			Description: "generates a new email template",
 		},
+		helpCommand{
+			Use:         "generate page NAME",
+			Description: "generates a static page, or replaces the onboarding home page",
+		},
 		helpCommand{
 			Use:         "generate routes",
 			Description: "generates TypeScript route helpers for Inertia frontends",
@@ -120,6 +196,26 @@ type createdFileTracker struct {
 	existingFiles map[string]struct{}
 }
 
+// loadProjectInflections makes the generate commands name things with the
+// rules in the project's andurel.inflections.yaml.
+func loadProjectInflections(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		loadProjectInflections(sub)
+		if sub.RunE == nil {
+			continue
+		}
+		run := sub.RunE
+		sub.RunE = func(cmd *cobra.Command, args []string) error {
+			if rootDir, err := findGoModRoot(); err == nil {
+				if err := naming.LoadInflections(rootDir); err != nil {
+					return err
+				}
+			}
+			return run(cmd, args)
+		}
+	}
+}
+
 func withGenerateCleanup(run func(cmd *cobra.Command, args []string) error) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		tracker, trackerErr := newCreatedFileTracker()
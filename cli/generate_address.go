@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mbvlabs/andurel/layout"
+)
+
+const geocodingExtension = "geocoding"
+
+var generateAddressFunc = generateAddress
+
+// generateAddress adds the address columns, geocode job and map component for
+// a resource that is about to be scaffolded. The geocoding client is applied
+// first when the project does not have it yet.
+func generateAddress(gen cliGenerator, rootDir, resourceName, tableName string) error {
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		return err
+	}
+	if _, ok := lock.Extensions[geocodingExtension]; !ok {
+		if _, err := layout.ApplyExtension(rootDir, geocodingExtension); err != nil {
+			return fmt.Errorf("failed to add the %s extension: %w", geocodingExtension, err)
+		}
+	}
+
+	if err := gen.GenerateAddress(resourceName, tableName); err != nil {
+		return err
+	}
+
+	return registerWorkerInQueueModule("Geocode" + resourceName)
+}
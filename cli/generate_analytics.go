@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/spf13/cobra"
+)
+
+type analyticsTemplateData struct {
+	ModulePath    string
+	Retention     string
+	CSSComponents bool
+}
+
+var analyticsFiles = []struct {
+	template string
+	path     string
+}{
+	{"analytics_model.tmpl", filepath.Join("models", "page_view.go")},
+	{"analytics_middleware.tmpl", filepath.Join("router", "middleware", "page_views.go")},
+	{"analytics_job.tmpl", filepath.Join("queue", "jobs", "page_view_rollup.go")},
+	{"analytics_worker.tmpl", filepath.Join("queue", "page_view_rollup.go")},
+	{"analytics_route.tmpl", filepath.Join("router", "routes", "analytics.go")},
+	{"analytics_controller.tmpl", filepath.Join("controllers", "analytics.go")},
+	{"analytics_view.tmpl", filepath.Join("views", "analytics.templ")},
+}
+
+var analyticsNow = time.Now
+
+func newGenerateAnalyticsCommand() *cobra.Command {
+	var retention time.Duration
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "analytics",
+		Short: "Generate first-party page view analytics",
+		Long: `Generates page view analytics that keep visitor data in your own
+database instead of a third-party tracker.
+
+middleware.RecordPageViews is added to router/router.go. It records
+successful page loads in the page_views table: the path without its query,
+the referring site, and a visitor hash made from the session key, the day,
+the client IP, and the user agent. The IP itself is never stored. Asset
+requests, Datastar requests, and known bots are not counted.
+
+An hourly job rolls the page views up into daily totals in page_view_days
+and deletes page views older than --retention. Admins see the daily views
+and the top pages and referrers at /admin/analytics, refreshed over SSE.`,
+		Example: `  andurel generate analytics
+
+      Controller: controllers/analytics.go
+      View:       views/analytics.templ
+
+  andurel generate analytics --retention 720h
+
+      Keeps page views for 30 days; the daily totals are kept for good.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if retention <= 0 {
+				return fmt.Errorf("--retention must be greater than zero")
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate analytics",
+				Resource: "analytics",
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel database migrate up", Description: "Create the page_views and page_view_days tables"},
+					{Command: "andurel run", Description: "Start the server and open /admin/analytics as an admin"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateAnalytics(rootDir, retention)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&retention, "retention", 90*24*time.Hour, "Delete page views older than this; daily totals are kept")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateAnalytics(rootDir string, retention time.Duration) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	if err := generateAnalyticsMigration(); err != nil {
+		return fmt.Errorf("failed to generate page_views migration: %w", err)
+	}
+
+	data := analyticsTemplateData{
+		ModulePath: modulePath,
+		Retention:  goDurationExpr(retention),
+	}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+	createdWorker := false
+	for _, file := range analyticsFiles {
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		render := generateFromTemplate
+		if strings.HasSuffix(file.path, ".templ") {
+			render = renderTemplateToFile
+		}
+		if err := render(file.template, file.path, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", file.path, err)
+		}
+		if file.template == "analytics_worker.tmpl" {
+			createdWorker = true
+		}
+	}
+
+	if err := controllers.NewMainInjector().InjectController("Analytics", "", "analytics"); err != nil {
+		return fmt.Errorf("failed to register analytics controller: %w", err)
+	}
+
+	if createdWorker {
+		if err := registerWorkerInQueueModule("PageViewRollup"); err != nil {
+			return fmt.Errorf("failed to register page view rollup worker: %w", err)
+		}
+		if err := registerPeriodicJobInQueueModule("NewPageViewRollupPeriodicJob"); err != nil {
+			return fmt.Errorf("failed to register page view rollup job: %w", err)
+		}
+	}
+
+	if err := registerGlobalMiddleware("middleware.RecordPageViews(db, authKey)"); err != nil {
+		return err
+	}
+
+	if _, err := ensureChartComponents(); err != nil {
+		return err
+	}
+
+	if err := runTemplFunc("generate"); err != nil {
+		return fmt.Errorf("failed to compile the analytics view: %w", err)
+	}
+
+	fmt.Println("Successfully generated page analytics at /admin/analytics")
+	return nil
+}
+
+func generateAnalyticsMigration() error {
+	existing, err := filepath.Glob(filepath.Join("database", "migrations", "*_create_page_views_table.sql"))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	migrationPath := filepath.Join(
+		"database",
+		"migrations",
+		analyticsNow().UTC().Format("20060102150405")+"_create_page_views_table.sql",
+	)
+	return renderTemplateToFile("analytics_migration.tmpl", migrationPath, nil)
+}
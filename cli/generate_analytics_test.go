@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAnalyticsWritesFilesAndRecordsPageViews(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	resetCLITestSeams(t)
+	templRuns := 0
+	runTemplFunc = func(args ...string) error {
+		templRuns++
+		return nil
+	}
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+	writeTestFile(t, rootDir, "router/router.go", routerMiddlewareFixture)
+
+	originalAnalyticsNow := analyticsNow
+	analyticsNow = func() time.Time { return time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { analyticsNow = originalAnalyticsNow })
+
+	if err := generateAnalytics(rootDir, 30*24*time.Hour); err != nil {
+		t.Fatalf("generateAnalytics failed: %v", err)
+	}
+	if templRuns != 1 {
+		t.Fatalf("templ generate runs = %d, want 1", templRuns)
+	}
+
+	for path, wants := range map[string][]string{
+		"database/migrations/20260708120000_create_page_views_table.sql": {"visitor_hash TEXT NOT NULL", "CREATE TABLE IF NOT EXISTS page_view_days"},
+		"models/page_view.go":             {"func (pageView) Rollup(", "ON CONFLICT (day) DO UPDATE", "func (pageView) TopReferrers("},
+		"router/middleware/page_views.go": {"func RecordPageViews(db storage.Pool, authKey []byte) echo.MiddlewareFunc", "matchesPathPrefix(path, routes.AnalyticsPrefix)", "c.RealIP()"},
+		"queue/page_view_rollup.go":       {"pageViewRetention      = 720 * time.Hour", "models.PageView.Rollup("},
+		"queue/jobs/page_view_rollup.go":  {"return \"page_view_rollup\""},
+		"queue/workers.go":                {"NewPageViewRollupWorker,", "fx.Annotate(NewPageViewRollupPeriodicJob, fx.ResultTags(periodicJobsGroup)),"},
+		"router/routes/analytics.go":      {"const AnalyticsPrefix = \"/admin/analytics\"", "\"analytics.live\""},
+		"controllers/analytics.go":        {"func NewAnalytics(db storage.Pool) Analytics", "!user.IsAdmin", "sse.PatchComponent(views.AnalyticsCharts(a.report(ctx)))"},
+		"views/analytics.templ":           {"templ (a Analytics) Page()", "hypermedia.KeepConnOpen()", "{{ views, visitors := report.totals() }}", "@BarChart(\"analytics-views\", report.viewPoints()"},
+		"views/charts.templ":              {"templ LineChart(id string, points []ChartPoint, attrs templ.Attributes)"},
+		"controllers/controller.go":       {"NewAnalytics,", "c Analytics) error"},
+		"router/router.go":                {"middlewares := []echo.MiddlewareFunc{\n\t\tmiddleware.RecordPageViews(db, authKey),\n\t\tmiddleware.Logger(tel),"},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	analyticsNow = func() time.Time { return time.Date(2026, 7, 9, 12, 0, 0, 0, time.UTC) }
+	if err := generateAnalytics(rootDir, 30*24*time.Hour); err != nil {
+		t.Fatalf("second generateAnalytics failed: %v", err)
+	}
+	migrations, err := filepath.Glob(filepath.Join(rootDir, "database", "migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("glob migrations: %v", err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected one page_views migration, got %v", migrations)
+	}
+	for path, registration := range map[string]string{
+		"router/router.go":          "RecordPageViews",
+		"controllers/controller.go": "NewAnalytics,",
+		"queue/workers.go":          "NewPageViewRollupPeriodicJob",
+	} {
+		if got := strings.Count(readGeneratedTestFile(t, rootDir, path), registration); got != 1 {
+			t.Fatalf("%s registrations in %s = %d, want 1", registration, path, got)
+		}
+	}
+}
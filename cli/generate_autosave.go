@@ -0,0 +1,227 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+type formDraftTemplateData struct {
+	ModulePath string
+	MaxAge     string
+}
+
+// formDraftSharedFiles are generated once per project and reused by every
+// form with autosave.
+var formDraftSharedFiles = []struct {
+	template string
+	path     string
+}{
+	{"form_draft_model.tmpl", filepath.Join("models", "form_draft.go")},
+	{"form_draft_route.tmpl", filepath.Join("router", "routes", "form_drafts.go")},
+	{"form_draft_controller.tmpl", filepath.Join("controllers", "form_drafts.go")},
+	{"form_draft_view.tmpl", filepath.Join("views", "form_draft.go")},
+	{"form_draft_cleanup_job.tmpl", filepath.Join("queue", "jobs", "form_draft_cleanup.go")},
+	{"form_draft_cleanup_worker.tmpl", filepath.Join("queue", "form_draft_cleanup.go")},
+}
+
+var formDraftNow = time.Now
+
+// resourceFormPattern matches the opening tag of a generated create or update
+// form in a Templ resource view.
+var resourceFormPattern = regexp.MustCompile(
+	`<form[^>\n]*data-on:submit=\{ hypermedia\.DataAction\(http\.Method(?:Post|Put), routes\.\w+(?:Create\.URL\(\)|Update\.URL\(([\w.]+)\))\) \}[^>\n]*>`,
+)
+
+func newGenerateAutosaveCommand() *cobra.Command {
+	var maxAge time.Duration
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "autosave RESOURCE",
+		Short: "Add draft autosave to a resource's forms",
+		Long: `Adds autosave to the new and edit forms of a generated Templ resource
+view. Pass the resource name in CamelCase.
+
+While a signed-in user types, the form's signals are saved as a draft a
+second after typing pauses. The draft is restored when the form loads
+again and discarded when the form is submitted. Drafts are keyed by user
+and form, so the edit form of each record has its own draft.
+
+The first run adds the shared pieces: a form_drafts migration and model,
+a controller serving /drafts/:id, the views.FormDraftAutosave helper, and
+a periodic job that deletes drafts older than --max-age.`,
+		Example: `  andurel generate autosave Product
+
+      Adds autosave to the forms in views/products_resource.templ.
+
+  andurel generate autosave Article --max-age 168h
+
+      Deletes drafts that have not been saved for a week.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: autosave takes exactly 1 argument (the resource name)")
+			}
+			if maxAge <= 0 {
+				return fmt.Errorf("--max-age must be greater than zero")
+			}
+			resourceName := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate autosave",
+				Resource: resourceName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel generate view", Description: "Compile the updated resource view"},
+					{Command: "andurel database migrate up", Description: "Create the form_drafts table"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateAutosave(resourceName, maxAge)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&maxAge, "max-age", 30*24*time.Hour, "Delete drafts not saved for this long")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateAutosave(resourceName string, maxAge time.Duration) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	tableName, _ := generator.ResolveTableNameWithFlag("models", resourceName)
+	viewPath := filepath.Join("views", tableName+"_resource.templ")
+	viewContent, err := os.ReadFile(viewPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Templ resource view at %s; generate the resource with 'andurel generate scaffold %s' first", viewPath, resourceName)
+		}
+		return err
+	}
+
+	updatedView, err := addFormDraftAutosave(string(viewContent), tableName)
+	if err != nil {
+		return fmt.Errorf("failed to add autosave to %s: %w", viewPath, err)
+	}
+
+	if err := generateFormDraftMigration(); err != nil {
+		return fmt.Errorf("failed to generate form_drafts migration: %w", err)
+	}
+
+	data := formDraftTemplateData{
+		ModulePath: modulePath,
+		MaxAge:     goDurationExpr(maxAge),
+	}
+	createdWorker := false
+	for _, file := range formDraftSharedFiles {
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		if err := generateFromTemplate(file.template, file.path, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", file.path, err)
+		}
+		if file.template == "form_draft_cleanup_worker.tmpl" {
+			createdWorker = true
+		}
+	}
+
+	if err := controllers.NewMainInjector().InjectController("FormDraft", "", "form_drafts"); err != nil {
+		return fmt.Errorf("failed to register form drafts controller: %w", err)
+	}
+
+	if createdWorker {
+		if err := registerWorkerInQueueModule("FormDraftCleanup"); err != nil {
+			return fmt.Errorf("failed to register draft cleanup worker: %w", err)
+		}
+		if err := registerPeriodicJobInQueueModule("NewFormDraftCleanupPeriodicJob"); err != nil {
+			return fmt.Errorf("failed to register draft cleanup job: %w", err)
+		}
+	}
+
+	if updatedView != string(viewContent) {
+		if err := os.WriteFile(viewPath, []byte(updatedView), constants.FilePermissionPrivate); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully added autosave to %s\n", viewPath)
+	return nil
+}
+
+// addFormDraftAutosave spreads views.FormDraftAutosave onto the create and
+// update forms of a resource view. Forms that already autosave are left as is.
+func addFormDraftAutosave(content, tableName string) (string, error) {
+	matches := resourceFormPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no create or update forms found")
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		tag := content[match[0]:match[1]]
+		b.WriteString(content[last:match[0]])
+		last = match[1]
+
+		if strings.Contains(tag, "FormDraftAutosave(") {
+			b.WriteString(tag)
+			continue
+		}
+
+		key := fmt.Sprintf("FormDraftKey(%q, \"new\")", tableName)
+		if match[2] != -1 {
+			key = fmt.Sprintf("FormDraftKey(%q, \"edit\", %s)", tableName, content[match[2]:match[3]])
+		}
+		b.WriteString(strings.TrimSuffix(tag, ">"))
+		b.WriteString(" { FormDraftAutosave(" + key + ")... }>")
+	}
+	b.WriteString(content[last:])
+
+	return b.String(), nil
+}
+
+func generateFormDraftMigration() error {
+	existing, err := filepath.Glob(filepath.Join("database", "migrations", "*_create_form_drafts_table.sql"))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	migrationPath := filepath.Join(
+		"database",
+		"migrations",
+		formDraftNow().UTC().Format("20060102150405")+"_create_form_drafts_table.sql",
+	)
+	return renderTemplateToFile("form_draft_migration.tmpl", migrationPath, nil)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const productsResourceViewFixture = `package views
+
+templ (pn ProductNew) Page() {
+	<form class="space-y-5" data-indicator:_submitting data-on:submit={ hypermedia.DataAction(http.MethodPost, routes.ProductCreate.URL()) }>
+	</form>
+}
+
+templ (pe ProductEdit) Page() {
+	<form class="space-y-5" data-indicator:_submitting data-on:submit={ hypermedia.DataAction(http.MethodPut, routes.ProductUpdate.URL(pe.Item.ID)) }>
+	</form>
+}
+`
+
+func TestGenerateAutosaveAddsDraftsToResourceForms(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+	writeTestFile(t, rootDir, "views/products_resource.templ", productsResourceViewFixture)
+
+	originalFormDraftNow := formDraftNow
+	formDraftNow = func() time.Time { return time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { formDraftNow = originalFormDraftNow })
+
+	if err := generateAutosave("Product", 7*24*time.Hour); err != nil {
+		t.Fatalf("generateAutosave failed: %v", err)
+	}
+
+	view := readGeneratedTestFile(t, rootDir, "views/products_resource.templ")
+	for _, want := range []string{
+		`routes.ProductCreate.URL()) } { FormDraftAutosave(FormDraftKey("products", "new"))... }>`,
+		`routes.ProductUpdate.URL(pe.Item.ID)) } { FormDraftAutosave(FormDraftKey("products", "edit", pe.Item.ID))... }>`,
+	} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("view should contain %q\n\n%s", want, view)
+		}
+	}
+
+	migration := readGeneratedTestFile(t, rootDir, "database/migrations/20260708120000_create_form_drafts_table.sql")
+	if !strings.Contains(migration, "PRIMARY KEY (user_id, form_key)") {
+		t.Fatalf("migration should key drafts by user and form\n\n%s", migration)
+	}
+
+	for path, wants := range map[string][]string{
+		"models/form_draft.go":             {"On(\"CONFLICT (user_id, form_key) DO UPDATE\")", "func (formDraft) DeleteStale("},
+		"router/routes/form_drafts.go":     {"routing.NewRouteWithStringID(", "\"form_drafts.save\""},
+		"controllers/form_drafts.go":       {"auth.CurrentUser(etx.Request().Context())", "sse.PatchSignals(signals)"},
+		"views/form_draft.go":              {"func FormDraftAutosave(key string) templ.Attributes", "\"data-on:input__debounce.1000ms\""},
+		"queue/form_draft_cleanup.go":      {"formDraftMaxAge          = 168 * time.Hour", "models.FormDraft.DeleteStale("},
+		"queue/jobs/form_draft_cleanup.go": {"return \"form_draft_cleanup\""},
+		"queue/workers.go":                 {"NewFormDraftCleanupWorker,", "fx.Annotate(NewFormDraftCleanupPeriodicJob, fx.ResultTags(periodicJobsGroup)),"},
+		"controllers/controller.go":        {"NewFormDrafts,", "c FormDrafts) error"},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	formDraftNow = func() time.Time { return time.Date(2026, 7, 9, 12, 0, 0, 0, time.UTC) }
+	if err := generateAutosave("Product", 7*24*time.Hour); err != nil {
+		t.Fatalf("second generateAutosave failed: %v", err)
+	}
+
+	if got := readGeneratedTestFile(t, rootDir, "views/products_resource.templ"); got != view {
+		t.Fatalf("second run should leave the view unchanged\n\n%s", got)
+	}
+	migrations, err := filepath.Glob(filepath.Join(rootDir, "database", "migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("glob migrations: %v", err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected one form_drafts migration, got %v", migrations)
+	}
+	if got := strings.Count(readGeneratedTestFile(t, rootDir, "queue/workers.go"), "NewFormDraftCleanupWorker,"); got != 1 {
+		t.Fatalf("cleanup worker registrations = %d, want 1", got)
+	}
+}
+
+func TestGenerateAutosaveRequiresTemplResourceView(t *testing.T) {
+	setupGenerateFileTestProject(t)
+
+	err := generateAutosave("Product", time.Hour)
+	if err == nil || !strings.Contains(err.Error(), "no Templ resource view at views/products_resource.templ") {
+		t.Fatalf("expected missing view error, got %v", err)
+	}
+}
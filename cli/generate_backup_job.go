@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+const databaseBackupJobName = "DatabaseBackup"
+
+type backupWorkerTemplateData struct {
+	ModulePath string
+	Dir        string
+	Keep       int
+	Interval   string
+}
+
+func newGenerateBackupJobCommand() *cobra.Command {
+	var dir string
+	var keep int
+	var interval time.Duration
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "backup-job",
+		Short: "Generate a scheduled database backup job",
+		Long: `Generates a periodic River job that backs up the database with pg_dump
+in production.
+
+This creates queue/jobs/database_backup.go and queue/database_backup.go,
+registers the worker in queue/workers.go, and provides the periodic job
+to the queue processor. The job is a no-op outside production, and the
+production image must include pg_dump.
+
+Use --interval to change the schedule, --dir to change where archives are
+written, and --keep to prune older archives after each run.`,
+		Example: `  andurel generate backup-job
+
+      Backs up the database every 24 hours into backups/, keeping 7 archives.
+
+  andurel generate backup-job --interval 6h --keep 28 --dir /var/backups/app`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be greater than zero")
+			}
+			if keep < 0 {
+				return fmt.Errorf("--keep must be zero or greater")
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate backup-job",
+				Resource: databaseBackupJobName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel jobs --json", Description: "Inspect generated jobs"},
+					{Command: "andurel database backup", Description: "Take a backup from your machine"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateBackupJob(dir, keep, interval)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", defaultBackupDir, "Directory the job writes backup archives to")
+	cmd.Flags().IntVar(&keep, "keep", 7, "Number of archives to keep (0 keeps all)")
+	cmd.Flags().DurationVar(&interval, "interval", 24*time.Hour, "How often the backup runs")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateBackupJob(dir string, keep int, interval time.Duration) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	jobPath := filepath.Join("queue", "jobs", "database_backup.go")
+	if err := generateFromTemplate("backup_job.tmpl", jobPath, nil); err != nil {
+		return fmt.Errorf("failed to generate job file: %w", err)
+	}
+
+	workerPath := filepath.Join("queue", "database_backup.go")
+	if err := generateFromTemplate("backup_worker.tmpl", workerPath, backupWorkerTemplateData{
+		ModulePath: modulePath,
+		Dir:        dir,
+		Keep:       keep,
+		Interval:   goDurationExpr(interval),
+	}); err != nil {
+		return fmt.Errorf("failed to generate worker file: %w", err)
+	}
+
+	if err := registerWorkerInQueueModule(databaseBackupJobName); err != nil {
+		return fmt.Errorf("failed to register worker: %w", err)
+	}
+
+	if err := registerPeriodicJobInQueueModule("New" + databaseBackupJobName + "PeriodicJob"); err != nil {
+		return fmt.Errorf("failed to register periodic job: %w", err)
+	}
+
+	fmt.Println("Successfully generated database backup job")
+	return nil
+}
+
+// goDurationExpr renders d as a Go expression using the largest whole
+// time unit.
+func goDurationExpr(d time.Duration) string {
+	for _, unit := range []struct {
+		size time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+	} {
+		if d%unit.size == 0 {
+			return strconv.FormatInt(int64(d/unit.size), 10) + " * " + unit.name
+		}
+	}
+	return "time.Duration(" + strconv.FormatInt(int64(d), 10) + ")"
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+func newGenerateCalendarCommand() *cobra.Command {
+	var columns generator.CalendarColumns
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "calendar RESOURCE",
+		Short: "Add a per-user ICS calendar feed to a resource",
+		Long: `Adds an ICS calendar feed of upcoming records to a generated Templ
+resource, for bookings, events and other resources with a start time. Pass
+the resource name in CamelCase.
+
+The start column must be a timestamp or date; date columns become all-day
+events. Without flags the first of starts_at, start_at, starts_on,
+start_date, start_time, begins_at, scheduled_at, scheduled_for, event_date
+and date is used. The end column (ends_at, end_at, ...) is optional, and the
+event title comes from title, name, summary or subject when present.
+
+Each signed-in user gets a personal feed URL at
+/calendars/<resource>/<token>/calendar.ics. The token is signed with
+TOKEN_SIGNING_KEY, so changing the key invalidates every subscription, and
+feeds of deleted users stop working. The index page gets a Subscribe panel
+with the URL and instructions for Google Calendar, Apple Calendar and
+Outlook.
+
+The model gets an Upcoming query, used by the feed and available to your
+own code.`,
+		Example: `  andurel generate calendar Booking
+
+      Detects the start, end and title columns of bookings.
+
+  andurel generate calendar Event --starts-at begins_at --ends-at finishes_at --title headline
+
+      Uses the given columns.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: calendar takes exactly 1 argument (the resource name)")
+			}
+			resourceName := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate calendar",
+				Resource: resourceName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel routes", Description: "List the calendar feed routes"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						gen, err := newGenerator()
+						if err != nil {
+							return err
+						}
+						tableName, _ := generator.ResolveTableNameWithFlag("models", resourceName)
+						return gen.GenerateCalendar(resourceName, tableName, columns)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&columns.StartsAt, "starts-at", "", "Column with the event start (detected when empty)")
+	cmd.Flags().StringVar(&columns.EndsAt, "ends-at", "", "Column with the event end (detected when empty)")
+	cmd.Flags().StringVar(&columns.Title, "title", "", "Column used as the event title (detected when empty)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
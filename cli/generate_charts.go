@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type chartsTemplateData struct {
+	ModulePath string
+}
+
+var chartsViewPath = filepath.Join("views", "charts.templ")
+
+func newGenerateChartsCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "charts",
+		Short: "Generate inline SVG chart components",
+		Long: `Generates views/charts.templ with sparkline, bar, and line chart
+components. Charts are rendered on the server as inline SVG from Go values,
+so they need no JavaScript library, and are drawn in the current text color.
+
+Each chart takes an id, so a handler can patch a fresh chart over the old
+one. Wrap charts in views.LiveChart to request such a fragment endpoint on
+an interval, or patch them on a page's existing SSE stream.
+
+The analytics and dev-dashboard generators add the components when they are
+missing. An existing views/charts.templ is left as is.`,
+		Example: `  andurel generate charts
+
+      View: views/charts.templ`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate charts",
+				Resource: "charts",
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel generate analytics", Description: "Generate page analytics drawn with the charts"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateCharts()
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateCharts() error {
+	created, err := ensureChartComponents()
+	if err != nil {
+		return err
+	}
+	if !created {
+		fmt.Printf("%s already exists\n", chartsViewPath)
+		return nil
+	}
+
+	if err := runTemplFunc("generate"); err != nil {
+		return fmt.Errorf("failed to compile the chart components: %w", err)
+	}
+
+	fmt.Printf("Successfully generated chart components in %s\n", chartsViewPath)
+	return nil
+}
+
+// ensureChartComponents writes views/charts.templ unless it exists, and
+// reports whether it did. Generators whose views draw charts call it before
+// compiling their views.
+func ensureChartComponents() (bool, error) {
+	if _, err := os.Stat(chartsViewPath); err == nil {
+		return false, nil
+	}
+
+	modulePath, err := readModulePath()
+	if err != nil {
+		return false, fmt.Errorf("failed to read module path: %w", err)
+	}
+	if err := renderTemplateToFile("charts_view.tmpl", chartsViewPath, chartsTemplateData{ModulePath: modulePath}); err != nil {
+		return false, fmt.Errorf("failed to generate %s: %w", chartsViewPath, err)
+	}
+	return true, nil
+}
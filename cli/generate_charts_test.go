@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateChartsWritesComponentsOnce(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	resetCLITestSeams(t)
+	templRuns := 0
+	runTemplFunc = func(args ...string) error {
+		templRuns++
+		return nil
+	}
+
+	if err := generateCharts(); err != nil {
+		t.Fatalf("generateCharts failed: %v", err)
+	}
+	if templRuns != 1 {
+		t.Fatalf("templ generate runs = %d, want 1", templRuns)
+	}
+
+	view := readGeneratedTestFile(t, rootDir, "views/charts.templ")
+	for _, want := range []string{
+		"type ChartPoint struct",
+		"templ Sparkline(id string, values []float64, attrs templ.Attributes)",
+		"templ BarChart(id string, points []ChartPoint, attrs templ.Attributes)",
+		"templ LineChart(id string, points []ChartPoint, attrs templ.Attributes)",
+		"templ LiveChart(url string, interval time.Duration)",
+		`"data-on-interval__duration." + strconv.FormatInt(interval.Milliseconds(), 10) + "ms": hypermedia.DataAction(http.MethodGet, url)`,
+	} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("views/charts.templ should contain %q\n\n%s", want, view)
+		}
+	}
+
+	writeTestFile(t, rootDir, "views/charts.templ", "package views\n")
+	if err := generateCharts(); err != nil {
+		t.Fatalf("second generateCharts failed: %v", err)
+	}
+	if got := readGeneratedTestFile(t, rootDir, "views/charts.templ"); got != "package views\n" {
+		t.Fatalf("second run should leave views/charts.templ alone, got\n\n%s", got)
+	}
+	if templRuns != 1 {
+		t.Fatalf("templ generate runs after second run = %d, want 1", templRuns)
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"net/mail"
+	"path/filepath"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+const deadLetterReportJobName = "DeadLetterReport"
+
+type deadLetterWorkerTemplateData struct {
+	ModulePath string
+	Interval   string
+	Recipients []string
+}
+
+func newGenerateDeadLetterJobCommand() *cobra.Command {
+	var interval time.Duration
+	var recipients []string
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "dead-letter-job",
+		Short: "Generate a scheduled report of permanently failed jobs",
+		Long: `Generates a periodic River job that reports jobs River discarded after
+their last attempt.
+
+This creates queue/jobs/dead_letter_report.go and queue/dead_letter_report.go,
+registers the worker in queue/workers.go, and provides the periodic job
+to the queue processor. Each run groups the jobs discarded since the
+previous run by kind and queue, logs a warning per group, and emails the
+summary to every admin user.
+
+Use --to to email fixed addresses instead of the admin users, and
+'andurel queue retry' to requeue the jobs once the cause is fixed.`,
+		Example: `  andurel generate dead-letter-job
+
+      Emails the admin users every hour when jobs were discarded.
+
+  andurel generate dead-letter-job --interval 15m --to ops@example.com`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be greater than zero")
+			}
+			for _, recipient := range recipients {
+				if _, err := mail.ParseAddress(recipient); err != nil {
+					return fmt.Errorf("--to %q is not an email address", recipient)
+				}
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate dead-letter-job",
+				Resource: deadLetterReportJobName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel queue stats", Description: "Inspect job counts per queue"},
+					{Command: "andurel queue retry --kind KIND", Description: "Requeue discarded jobs"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateDeadLetterJob(interval, recipients)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", time.Hour, "How often the report runs")
+	cmd.Flags().StringSliceVar(&recipients, "to", nil, "Email addresses to send the report to (default: admin users)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateDeadLetterJob(interval time.Duration, recipients []string) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	jobPath := filepath.Join("queue", "jobs", "dead_letter_report.go")
+	if err := generateFromTemplate("dead_letter_job.tmpl", jobPath, nil); err != nil {
+		return fmt.Errorf("failed to generate job file: %w", err)
+	}
+
+	workerPath := filepath.Join("queue", "dead_letter_report.go")
+	if err := generateFromTemplate("dead_letter_worker.tmpl", workerPath, deadLetterWorkerTemplateData{
+		ModulePath: modulePath,
+		Interval:   goDurationExpr(interval),
+		Recipients: recipients,
+	}); err != nil {
+		return fmt.Errorf("failed to generate worker file: %w", err)
+	}
+
+	if err := registerWorkerInQueueModule(deadLetterReportJobName); err != nil {
+		return fmt.Errorf("failed to register worker: %w", err)
+	}
+
+	if err := registerPeriodicJobInQueueModule("New" + deadLetterReportJobName + "PeriodicJob"); err != nil {
+		return fmt.Errorf("failed to register periodic job: %w", err)
+	}
+
+	fmt.Println("Successfully generated dead letter report job")
+	return nil
+}
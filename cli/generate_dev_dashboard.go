@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+type devDashboardTemplateData struct {
+	ModulePath    string
+	CSSComponents bool
+}
+
+var devDashboardFiles = []struct {
+	template string
+	path     string
+}{
+	{"dev_dashboard_middleware.tmpl", filepath.Join("router", "middleware", "dev_requests.go")},
+	{"dev_dashboard_route.tmpl", filepath.Join("router", "routes", "dev_dashboard.go")},
+	{"dev_dashboard_controller.tmpl", filepath.Join("controllers", "dev_dashboard.go")},
+	{"dev_dashboard_view.tmpl", filepath.Join("views", "dev_dashboard.templ")},
+}
+
+func newGenerateDevDashboardCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "dev-dashboard",
+		Short: "Generate a development-only project health dashboard",
+		Long: `Generates a dashboard at /dev/dashboard that is only served when
+ENVIRONMENT is development.
+
+The page lists the registered routes, migration status, and configuration
+values with secrets redacted. Recent requests and River queue stats refresh
+every few seconds over SSE, and the output of 'andurel doctor' is streamed in
+once it finishes. Requests are recorded in memory by
+middleware.RecordDevRequests, which is added to router/router.go and does
+nothing outside development.`,
+		Example: `  andurel generate dev-dashboard
+
+      Controller: controllers/dev_dashboard.go
+      View:       views/dev_dashboard.templ`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate dev-dashboard",
+				Resource: "dev-dashboard",
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel run", Description: "Start the server and open /dev/dashboard"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateDevDashboard(rootDir)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateDevDashboard(rootDir string) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	data := devDashboardTemplateData{ModulePath: modulePath}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+	for _, file := range devDashboardFiles {
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		render := generateFromTemplate
+		if strings.HasSuffix(file.path, ".templ") {
+			render = renderTemplateToFile
+		}
+		if err := render(file.template, file.path, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", file.path, err)
+		}
+	}
+
+	if err := controllers.NewMainInjector().InjectController("DevDashboard", "", "dev_dashboard"); err != nil {
+		return fmt.Errorf("failed to register dev dashboard controller: %w", err)
+	}
+
+	if err := registerGlobalMiddleware("middleware.RecordDevRequests"); err != nil {
+		return err
+	}
+
+	if _, err := ensureChartComponents(); err != nil {
+		return err
+	}
+
+	if err := runTemplFunc("generate"); err != nil {
+		return fmt.Errorf("failed to compile the dev dashboard view: %w", err)
+	}
+
+	fmt.Println("Successfully generated the dev dashboard at /dev/dashboard")
+	return nil
+}
+
+// registerGlobalMiddleware adds expression to the global middleware list in
+// router/router.go, or tells the user to when the list is not found.
+func registerGlobalMiddleware(expression string) error {
+	routerPath := filepath.Join("router", "router.go")
+	content, err := os.ReadFile(routerPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", routerPath, err)
+	}
+	updated, ok := addGlobalMiddleware(string(content), expression)
+	if !ok {
+		fmt.Printf("Could not find the middleware list in %s; add %s to it\n", routerPath, expression)
+		return nil
+	}
+	if updated == string(content) {
+		return nil
+	}
+	if err := os.WriteFile(routerPath, []byte(updated), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	return files.FormatGoFile(routerPath)
+}
+
+// addGlobalMiddleware puts expression first in the global middleware list of
+// router/router.go, so it also sees requests rejected by later middleware. It
+// reports false when the list is not found.
+func addGlobalMiddleware(content, expression string) (string, bool) {
+	if strings.Contains(content, expression+",") {
+		return content, true
+	}
+
+	const list = "middlewares := []echo.MiddlewareFunc{\n"
+	before, after, found := strings.Cut(content, list)
+	if !found {
+		return content, false
+	}
+	return before + list + "\t\t" + expression + ",\n" + after, true
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+const routerMiddlewareFixture = `package router
+
+func SetupGlobalMiddleware() ([]echo.MiddlewareFunc, error) {
+	middlewares := []echo.MiddlewareFunc{
+		middleware.Logger(tel),
+		echomw.Recover(),
+	}
+
+	return middlewares, nil
+}
+`
+
+func TestGenerateDevDashboardWritesFilesAndRecordsRequests(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	resetCLITestSeams(t)
+	templRuns := 0
+	runTemplFunc = func(args ...string) error {
+		templRuns++
+		return nil
+	}
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+	writeTestFile(t, rootDir, "router/router.go", routerMiddlewareFixture)
+
+	if err := generateDevDashboard(rootDir); err != nil {
+		t.Fatalf("generateDevDashboard failed: %v", err)
+	}
+	if templRuns != 1 {
+		t.Fatalf("templ generate runs = %d, want 1", templRuns)
+	}
+
+	for path, wants := range map[string][]string{
+		"router/middleware/dev_requests.go": {"func RecordDevRequests(next echo.HandlerFunc) echo.HandlerFunc", "config.Env != server.DevEnvironment", "func RecentDevRequests() []DevRequest"},
+		"router/routes/dev_dashboard.go":    {"const DevDashboardPrefix = \"/dev\"", "\"dev_dashboard.live\""},
+		"controllers/dev_dashboard.go":      {"func NewDevDashboard(db storage.Pool, cfg config.Config) DevDashboard", "FROM river_job", "goose_db_version", "exec.CommandContext(ctx, andurel, \"doctor\")"},
+		"views/dev_dashboard.templ":         {"templ (d DevDashboard) Page()", "hypermedia.KeepConnOpen()", "templ DevDoctor(report DevDoctorReport)", "@BarChart(\"dev-queue-chart\", stats.statePoints()"},
+		"views/charts.templ":                {"templ BarChart(id string, points []ChartPoint, attrs templ.Attributes)"},
+		"controllers/controller.go":         {"NewDevDashboard,", "c DevDashboard) error"},
+		"router/router.go":                  {"middlewares := []echo.MiddlewareFunc{\n\t\tmiddleware.RecordDevRequests,\n\t\tmiddleware.Logger(tel),"},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	if err := generateDevDashboard(rootDir); err != nil {
+		t.Fatalf("second generateDevDashboard failed: %v", err)
+	}
+	if got := strings.Count(readGeneratedTestFile(t, rootDir, "router/router.go"), "RecordDevRequests"); got != 1 {
+		t.Fatalf("request recorder registrations = %d, want 1", got)
+	}
+	if got := strings.Count(readGeneratedTestFile(t, rootDir, "controllers/controller.go"), "NewDevDashboard,"); got != 1 {
+		t.Fatalf("dev dashboard controller registrations = %d, want 1", got)
+	}
+}
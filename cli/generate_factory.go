@@ -93,10 +93,18 @@ func runFactorySyncCommand(cmd *cobra.Command, names []string, opts generator.Fa
 		return err
 	}
 
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+
 	var results []*generator.FactorySyncResult
-	if len(names) == 0 {
-		results, err = gen.SyncFactories(opts)
-	} else {
+	if _, _, err := applyMutation(rootDir, cmd.ErrOrStderr(), func() error {
+		if len(names) == 0 {
+			var syncErr error
+			results, syncErr = gen.SyncFactories(opts)
+			return syncErr
+		}
 		for _, name := range names {
 			result, syncErr := gen.SyncFactory(name, opts)
 			if syncErr != nil {
@@ -104,8 +112,8 @@ func runFactorySyncCommand(cmd *cobra.Command, names []string, opts generator.Fa
 			}
 			results = append(results, result)
 		}
-	}
-	if err != nil {
+		return nil
+	}); err != nil {
 		return err
 	}
 
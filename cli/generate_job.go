@@ -5,7 +5,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mbvlabs/andurel/cli/output"
 	"github.com/mbvlabs/andurel/generator/files"
@@ -16,18 +18,64 @@ import (
 )
 
 type jobTemplateData struct {
-	PascalName string
-	SnakeName  string
-	QueueName  string
+	ModulePath   string
+	PascalName   string
+	SnakeName    string
+	QueueName    string
+	Priority     int
+	MaxAttempts  int
+	UniqueByArgs bool
+	UniquePeriod string
+	Sensitive    bool
+}
+
+// HasInsertOpts reports whether the job needs an InsertOpts method.
+func (d jobTemplateData) HasInsertOpts() bool {
+	return d.QueueName != "" || d.Priority != 0 || d.MaxAttempts != 0 || d.UniqueByArgs || d.UniquePeriod != ""
 }
 
+// Imports returns the quoted import paths of the job file, with an empty
+// entry between the standard library and other groups.
+func (d jobTemplateData) Imports() []string {
+	var std, external []string
+	if d.UniquePeriod != "" {
+		std = append(std, strconv.Quote("time"))
+	}
+	if d.HasInsertOpts() {
+		external = append(external, strconv.Quote("github.com/riverqueue/river"))
+	}
+	if d.Sensitive {
+		external = append(external, strconv.Quote(d.ModulePath+"/internal/jobcrypt"))
+	}
+
+	if len(std) > 0 && len(external) > 0 {
+		return append(append(std, ""), external...)
+	}
+	return append(std, external...)
+}
+
+// jobOptions are the River insert options set with generate job flags.
+type jobOptions struct {
+	Queue        string
+	Priority     int
+	MaxAttempts  int
+	Unique       bool
+	UniquePeriod time.Duration
+	Sensitive    bool
+}
+
+// newQueueWorkers is the worker count a queue gets when generate job
+// registers it in queue/queues.go.
+const newQueueWorkers = 10
+
 type workerTemplateData struct {
 	ModulePath string
 	PascalName string
+	Sensitive  bool
 }
 
 func newGenerateJobCommand() *cobra.Command {
-	var queueName string
+	var opts jobOptions
 	var dryRun bool
 	var diff bool
 
@@ -44,7 +92,17 @@ queue/workers/workers.go.
 
 Use the --queue flag to assign the job to a specific queue. This
 generates an InsertOpts method on the args struct that River uses
-when inserting the job.`,
+when inserting the job, and registers the queue in queue/queues.go
+with 10 workers. QUEUE_WORKERS changes the count per environment.
+
+--priority sets the job's priority from 1 (highest) to 4 within its
+queue, --max-attempts overrides QUEUE_MAX_ATTEMPTS for the job, and
+--unique and --unique-period skip inserting a job while an equal one
+(same args, or same period) is pending.
+
+--sensitive wraps the job's data in a jobcrypt.Sensitive payload that is
+encrypted with AES-GCM when the job is inserted and decrypted before the
+worker runs, so personal data is not stored in plaintext in river_job.`,
 		Example: `  andurel generate job SendWelcomeEmail
 
       Creates a SendWelcomeEmail job and worker on the default queue.
@@ -54,7 +112,16 @@ when inserting the job.`,
   andurel generate job ProcessPayment --queue=financial
 
       Creates a ProcessPayment job on the "financial" queue with an
-      InsertOpts method.`,
+      InsertOpts method.
+
+  andurel generate job SyncInventory --priority=1 --unique --unique-period=1h
+
+      Runs ahead of other default queue jobs and is inserted at most once
+      an hour for the same args.
+
+  andurel generate job ExportUserData --sensitive
+
+      Stores the job's payload encrypted in river_job.args.`,
 		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 1 {
@@ -82,21 +149,44 @@ when inserting the job.`,
 				},
 				Run: func(rootDir string) error {
 					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
-						return generateJob(name, queueName)
+						return generateJob(name, opts)
 					})(cmd, args)
 				},
 			})
 		},
 	}
 
-	cmd.Flags().StringVar(&queueName, "queue", "", "Assign the job to a specific queue")
+	cmd.Flags().StringVar(&opts.Queue, "queue", "", "Assign the job to a specific queue")
+	cmd.Flags().IntVar(&opts.Priority, "priority", 0, "Job priority from 1 (highest) to 4")
+	cmd.Flags().IntVar(&opts.MaxAttempts, "max-attempts", 0, "Attempts before the job is discarded (default: QUEUE_MAX_ATTEMPTS)")
+	cmd.Flags().BoolVar(&opts.Unique, "unique", false, "Skip inserting the job while one with the same args is pending")
+	cmd.Flags().DurationVar(&opts.UniquePeriod, "unique-period", 0, "Insert the job at most once per period")
+	cmd.Flags().BoolVar(&opts.Sensitive, "sensitive", false, "Encrypt the job's payload in river_job")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
 	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
 
 	return cmd
 }
 
-func generateJob(name, queueName string) error {
+func generateJob(name string, opts jobOptions) error {
+	if opts.Priority < 0 || opts.Priority > 4 {
+		return fmt.Errorf("invalid --priority %d: use 1 (highest) to 4", opts.Priority)
+	}
+	if opts.MaxAttempts < 0 {
+		return fmt.Errorf("invalid --max-attempts %d: must be positive", opts.MaxAttempts)
+	}
+	if opts.UniquePeriod < 0 {
+		return fmt.Errorf("invalid --unique-period %s: must be positive", opts.UniquePeriod)
+	}
+	if opts.Sensitive && opts.Unique {
+		return fmt.Errorf("--unique cannot be combined with --sensitive: encrypted args never compare equal")
+	}
+	if opts.Sensitive {
+		if _, err := os.Stat(filepath.Join("internal", "jobcrypt", "jobcrypt.go")); os.IsNotExist(err) {
+			return fmt.Errorf("--sensitive needs internal/jobcrypt/jobcrypt.go; run 'andurel upgrade' to add it")
+		}
+	}
+
 	modulePath, err := readModulePath()
 	if err != nil {
 		return fmt.Errorf("failed to read module path: %w", err)
@@ -105,12 +195,23 @@ func generateJob(name, queueName string) error {
 	snakeName := naming.ToSnakeCase(name)
 	pascalName := naming.ToPascalCase(snakeName)
 
+	var uniquePeriod string
+	if opts.UniquePeriod > 0 {
+		uniquePeriod = goDurationExpr(opts.UniquePeriod)
+	}
+
 	// Generate queue/jobs/<snake>.go
 	jobPath := filepath.Join("queue", "jobs", snakeName+".go")
 	if err := generateFromTemplate("job.tmpl", jobPath, jobTemplateData{
-		PascalName: pascalName,
-		SnakeName:  snakeName,
-		QueueName:  queueName,
+		ModulePath:   modulePath,
+		PascalName:   pascalName,
+		SnakeName:    snakeName,
+		QueueName:    opts.Queue,
+		Priority:     opts.Priority,
+		MaxAttempts:  opts.MaxAttempts,
+		UniqueByArgs: opts.Unique,
+		UniquePeriod: uniquePeriod,
+		Sensitive:    opts.Sensitive,
 	}); err != nil {
 		return fmt.Errorf("failed to generate job file: %w", err)
 	}
@@ -119,6 +220,7 @@ func generateJob(name, queueName string) error {
 	if err := generateFromTemplate("worker.tmpl", workerPath, workerTemplateData{
 		ModulePath: modulePath,
 		PascalName: pascalName,
+		Sensitive:  opts.Sensitive,
 	}); err != nil {
 		return fmt.Errorf("failed to generate worker file: %w", err)
 	}
@@ -127,11 +229,67 @@ func generateJob(name, queueName string) error {
 		return fmt.Errorf("failed to register worker: %w", err)
 	}
 
+	if err := registerQueue(opts.Queue); err != nil {
+		return fmt.Errorf("failed to register queue: %w", err)
+	}
+
 	fmt.Printf("Successfully generated job %s\n", name)
 	return nil
 }
 
+// registerQueue adds a queue to the queues map in queue/queues.go. Projects
+// without the file configure their queues in queue/queue.go by hand.
+func registerQueue(queueName string) error {
+	if queueName == "" || queueName == "default" {
+		return nil
+	}
+
+	queuesGoPath := filepath.Join("queue", "queues.go")
+	content, err := os.ReadFile(queuesGoPath)
+	if os.IsNotExist(err) {
+		fmt.Printf("Add the %q queue to the River client's Queues in queue/queue.go\n", queueName)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", queuesGoPath, err)
+	}
+
+	contentStr := string(content)
+	if strings.Contains(contentStr, strconv.Quote(queueName)+":") {
+		return nil
+	}
+
+	const declaration = "var queues = map[string]int{"
+	declIdx := strings.Index(contentStr, declaration)
+	if declIdx == -1 {
+		return fmt.Errorf("failed to locate %s in %s", strings.TrimSuffix(declaration, "{"), queuesGoPath)
+	}
+	closeIdx := strings.Index(contentStr[declIdx:], "\n}")
+	if closeIdx == -1 {
+		return fmt.Errorf("failed to locate the end of the queues map in %s", queuesGoPath)
+	}
+	closeIdx += declIdx + 1
+
+	entry := fmt.Sprintf("\t%s: %d,\n", strconv.Quote(queueName), newQueueWorkers)
+	contentStr = contentStr[:closeIdx] + entry + contentStr[closeIdx:]
+	if err := os.WriteFile(queuesGoPath, []byte(contentStr), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	return files.FormatGoFile(queuesGoPath)
+}
+
 func generateFromTemplate(tmplName, outputPath string, data any) error {
+	if err := renderTemplateToFile(tmplName, outputPath, data); err != nil {
+		return err
+	}
+
+	return files.FormatGoFile(outputPath)
+}
+
+// renderTemplateToFile writes a rendered generator template without Go
+// formatting, for .templ and .sql outputs.
+func renderTemplateToFile(tmplName, outputPath string, data any) error {
 	if _, err := os.Stat(outputPath); err == nil {
 		return fmt.Errorf("file %s already exists", outputPath)
 	}
@@ -145,11 +303,7 @@ func generateFromTemplate(tmplName, outputPath string, data any) error {
 		return err
 	}
 
-	if err := os.WriteFile(outputPath, []byte(content), constants.FilePermissionPrivate); err != nil {
-		return err
-	}
-
-	return files.FormatGoFile(outputPath)
+	return os.WriteFile(outputPath, []byte(content), constants.FilePermissionPrivate)
 }
 
 func registerWorkerInQueueModule(pascalName string) error {
@@ -207,6 +361,33 @@ func registerWorkerInQueueModule(pascalName string) error {
 	return nil
 }
 
+func registerPeriodicJobInQueueModule(constructor string) error {
+	workersGoPath := filepath.Join("queue", "workers.go")
+	content, err := os.ReadFile(workersGoPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", workersGoPath, err)
+	}
+
+	provider := fmt.Sprintf("fx.Annotate(%s, fx.ResultTags(periodicJobsGroup))", constructor)
+	nextContent, changed, err := ensureProviderEntry(
+		string(content),
+		"var wrksConstructors = fx.Provide(",
+		provider,
+	)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.WriteFile(workersGoPath, []byte(nextContent), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	return files.FormatGoFile(workersGoPath)
+}
+
 func ensureProviderEntry(content, provideDeclaration, constructorRef string) (string, bool, error) {
 	if hasRegistrationReference(content, constructorRef) {
 		return content, false, nil
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mbvlabs/andurel/pkg/cache"
 )
@@ -12,7 +13,7 @@ import (
 func TestGenerateJobWritesJobWorkerAndRegistration(t *testing.T) {
 	rootDir := setupGenerateFileTestProject(t)
 
-	if err := generateJob("ProcessPayment", "financial"); err != nil {
+	if err := generateJob("ProcessPayment", jobOptions{Queue: "financial"}); err != nil {
 		t.Fatalf("generateJob failed: %v", err)
 	}
 
@@ -50,10 +51,88 @@ func TestGenerateJobWritesJobWorkerAndRegistration(t *testing.T) {
 	}
 }
 
+func TestGenerateJobWritesInsertOptsAndRegistersQueue(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	queuesPath := filepath.Join(rootDir, "queue", "queues.go")
+	if err := os.WriteFile(queuesPath, []byte(queueQueuesFixture), 0o644); err != nil {
+		t.Fatalf("write queues fixture: %v", err)
+	}
+
+	if err := generateJob("SyncInventory", jobOptions{
+		Queue:        "inventory",
+		Priority:     1,
+		MaxAttempts:  5,
+		Unique:       true,
+		UniquePeriod: time.Hour,
+	}); err != nil {
+		t.Fatalf("generateJob failed: %v", err)
+	}
+	if err := generateJob("ReindexInventory", jobOptions{Queue: "inventory", UniquePeriod: 90 * time.Second}); err != nil {
+		t.Fatalf("generateJob failed: %v", err)
+	}
+
+	jobContent := readGeneratedTestFile(t, rootDir, "queue/jobs/sync_inventory.go")
+	for _, want := range []string{
+		"\"time\"",
+		"Queue:       \"inventory\",",
+		"Priority:    1,",
+		"MaxAttempts: 5,",
+		"ByArgs:   true,",
+		"ByPeriod: 1 * time.Hour,",
+	} {
+		if !strings.Contains(jobContent, want) {
+			t.Fatalf("job file should contain %q\n\n%s", want, jobContent)
+		}
+	}
+	if content := readGeneratedTestFile(t, rootDir, "queue/jobs/reindex_inventory.go"); !strings.Contains(content, "ByPeriod: 90 * time.Second,") {
+		t.Fatalf("job file should render the unique period\n\n%s", content)
+	}
+
+	queuesContent := readGeneratedTestFile(t, rootDir, "queue/queues.go")
+	if strings.Count(queuesContent, "\"inventory\":") != 1 || !strings.Contains(queuesContent, "10,\n}") {
+		t.Fatalf("queues.go should register the inventory queue once\n\n%s", queuesContent)
+	}
+
+	if err := generateJob("AuditInventory", jobOptions{Priority: 5}); err == nil {
+		t.Fatal("expected invalid priority error")
+	}
+}
+
+func TestGenerateJobSensitiveWrapsPayload(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+
+	if err := generateJob("ExportUserData", jobOptions{Sensitive: true}); err == nil || !strings.Contains(err.Error(), "andurel upgrade") {
+		t.Fatalf("expected missing jobcrypt error, got %v", err)
+	}
+
+	writeTestFile(t, rootDir, "internal/jobcrypt/jobcrypt.go", "package jobcrypt\n")
+	if err := generateJob("ExportUserData", jobOptions{Sensitive: true}); err != nil {
+		t.Fatalf("generateJob failed: %v", err)
+	}
+
+	jobContent := readGeneratedTestFile(t, rootDir, "queue/jobs/export_user_data.go")
+	for _, want := range []string{
+		"import \"example.com/app/internal/jobcrypt\"",
+		"Payload jobcrypt.Sensitive[ExportUserDataPayload] `json:\"payload\"`",
+		"type ExportUserDataPayload struct{}",
+	} {
+		if !strings.Contains(jobContent, want) {
+			t.Fatalf("job file should contain %q\n\n%s", want, jobContent)
+		}
+	}
+	if workerContent := readGeneratedTestFile(t, rootDir, "queue/export_user_data.go"); !strings.Contains(workerContent, "payload := job.Args.Payload.Value") {
+		t.Fatalf("worker should read the decrypted payload\n\n%s", workerContent)
+	}
+
+	if err := generateJob("ExportOrders", jobOptions{Sensitive: true, Unique: true}); err == nil {
+		t.Fatal("expected --unique with --sensitive error")
+	}
+}
+
 func TestGenerateJobDefaultQueueOmitsInsertOpts(t *testing.T) {
 	rootDir := setupGenerateFileTestProject(t)
 
-	if err := generateJob("SendWelcomeEmail", ""); err != nil {
+	if err := generateJob("SendWelcomeEmail", jobOptions{}); err != nil {
 		t.Fatalf("generateJob failed: %v", err)
 	}
 
@@ -77,7 +156,7 @@ func TestGenerateJobWritesQueueWorkerAndModuleRegistration(t *testing.T) {
 		t.Fatalf("write queue workers fixture: %v", err)
 	}
 
-	if err := generateJob("ProcessPayment", "financial"); err != nil {
+	if err := generateJob("ProcessPayment", jobOptions{Queue: "financial"}); err != nil {
 		t.Fatalf("generateJob failed: %v", err)
 	}
 
@@ -220,3 +299,12 @@ var WorkersModule = fx.Module(
 	}),
 )
 `
+
+const queueQueuesFixture = `package queue
+
+import "github.com/riverqueue/river"
+
+var queues = map[string]int{
+	river.QueueDefault: 100,
+}
+`
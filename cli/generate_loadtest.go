@@ -0,0 +1,352 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/spf13/cobra"
+)
+
+const (
+	loadTestScriptPath = "loadtest/script.js"
+	loadTestRoutesPath = "loadtest/routes.json"
+	loadTestSeedPath   = "database/seeds/loadtest.go"
+	loadTestSeedName   = "loadtest"
+)
+
+// loadTestRoute is a GET route the k6 scenario requests. Routes with an :id
+// name the table whose seeded records fill it.
+type loadTestRoute struct {
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	Table string `json:"table,omitempty"`
+}
+
+type loadTestSeedData struct {
+	ModulePath string
+	Models     []loadTestSeedModel
+}
+
+type loadTestSeedModel struct {
+	Name     string
+	Entity   string
+	Table    string
+	IDField  string
+	Variable string
+	Parents  []loadTestSeedParent
+}
+
+type loadTestSeedParent struct {
+	Variable string
+	Field    string
+	Pointer  bool
+}
+
+func newGenerateLoadTestCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "Generate a k6 load test of the GET routes with seeded records",
+		Long: `Generates a k6 scenario in loadtest/ and a loadtest seed in
+database/seeds/loadtest.go.
+
+The seed creates records of every model with a factory, spreading records
+that belong to another table over its records, and writes their IDs to
+tmp/loadtest/records.json. loadtest/routes.json lists the GET routes the
+controllers register; routes with an :id get the ID of a seeded record of
+their resource. loadtest/script.js requests them at random.
+
+Run it again after adding resources: the seed and routes.json are
+regenerated, while script.js is only written when missing, so your changes
+to it are kept. Start the load test with 'andurel loadtest run'.`,
+		Example: `  andurel generate loadtest
+
+      Scenario: loadtest/script.js
+      Routes:   loadtest/routes.json
+      Seed:     database/seeds/loadtest.go`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate loadtest",
+				Resource: "loadtest",
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel loadtest run", Description: "Seed the database, start the app in production mode and run k6"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateLoadTest()
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateLoadTest() error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	seedsPath := filepath.Join("database", "seeds", "seeds.go")
+	if _, err := os.Stat(seedsPath); err != nil {
+		return fmt.Errorf("the loadtest seed is registered in %s, which was not found: %w", seedsPath, err)
+	}
+
+	models, skipped, err := generator.LoadTestModels(
+		"models",
+		filepath.Join("models", "factories"),
+		[]string{filepath.Join("database", "migrations")},
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := writeLoadTestSeed(modulePath, models); err != nil {
+		return err
+	}
+	if err := registerLoadTestSeed(seedsPath); err != nil {
+		return err
+	}
+
+	tables := make(map[string]bool, len(models))
+	for _, model := range models {
+		if model.IDField != "" {
+			tables[model.Table] = true
+		}
+	}
+	routes, unmatched, err := loadTestRoutes(".", tables)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(routes, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(loadTestRoutesPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(loadTestRoutesPath, append(content, '\n'), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(loadTestScriptPath); os.IsNotExist(err) {
+		if err := renderTemplateToFile("loadtest_script.tmpl", loadTestScriptPath, nil); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", loadTestScriptPath, err)
+		}
+	}
+
+	fmt.Printf("Successfully generated the load test: %d routes in %s, records of %d models in %s\n",
+		len(routes), loadTestRoutesPath, len(models), loadTestSeedPath)
+	for _, skip := range skipped {
+		fmt.Printf("  Not seeding %s: %s\n", skip.Model, skip.Reason)
+	}
+	for _, name := range unmatched {
+		fmt.Printf("  Not requesting %s: no seeded records fill its parameters\n", name)
+	}
+	return nil
+}
+
+func writeLoadTestSeed(modulePath string, models []generator.LoadTestModel) error {
+	variables := make(map[string]string, len(models))
+	data := loadTestSeedData{ModulePath: modulePath}
+	for _, model := range models {
+		variable := naming.ToLowerCamelCase(model.Name) + "Records"
+		variables[model.Table] = variable
+
+		seedModel := loadTestSeedModel{
+			Name:     model.Name,
+			Entity:   model.Entity,
+			Table:    model.Table,
+			IDField:  model.IDField,
+			Variable: variable,
+		}
+		for _, parent := range model.Parents {
+			seedModel.Parents = append(seedModel.Parents, loadTestSeedParent{
+				Variable: variables[parent.Table],
+				Field:    parent.Field,
+				Pointer:  parent.Pointer,
+			})
+		}
+		data.Models = append(data.Models, seedModel)
+	}
+
+	content, err := templates.RenderTemplateUsingGlobal("loadtest_seed.tmpl", data)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(loadTestSeedPath, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	return files.FormatGoFile(loadTestSeedPath)
+}
+
+// registerLoadTestSeed adds LoadTest to the seed Registry in seedsPath, or
+// tells the user to when the Registry is not found.
+func registerLoadTestSeed(seedsPath string) error {
+	content, err := os.ReadFile(seedsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", seedsPath, err)
+	}
+	if strings.Contains(string(content), "LoadTest,") {
+		return nil
+	}
+
+	const registry = "var Registry = map[string]Runner{\n"
+	start := strings.Index(string(content), registry)
+	end := -1
+	if start >= 0 {
+		end = strings.Index(string(content)[start:], "\n}")
+	}
+	if end < 0 {
+		fmt.Printf("Could not find the seed Registry in %s; add %q: LoadTest to it\n", seedsPath, loadTestSeedName)
+		return nil
+	}
+	end += start + 1
+
+	updated := string(content)[:end] + fmt.Sprintf("\t%q: LoadTest,\n", loadTestSeedName) + string(content)[end:]
+	if err := os.WriteFile(seedsPath, []byte(updated), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	return files.FormatGoFile(seedsPath)
+}
+
+// loadTestRoutes returns the routes in router/routes that the controllers
+// register for GET. Routes with a single :id are kept when their resource's
+// table is in tables, and returned by name as unmatched otherwise. Routes
+// with other parameters are left out.
+func loadTestRoutes(rootDir string, tables map[string]bool) ([]loadTestRoute, []string, error) {
+	manifest, err := collectRouteManifest(rootDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	getRoutes, err := controllerGetRoutes(filepath.Join(rootDir, "controllers"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	routes := []loadTestRoute{}
+	var unmatched []string
+	for _, route := range manifest.Routes {
+		if !getRoutes[route.Variable] {
+			continue
+		}
+
+		switch {
+		case len(route.Params) == 0:
+			routes = append(routes, loadTestRoute{Name: route.Name, Path: route.Path})
+		case len(route.Params) == 1 && route.Params[0].Name == "id":
+			segments := strings.Split(route.Name, ".")
+			if len(segments) < 2 || !tables[segments[len(segments)-2]] {
+				unmatched = append(unmatched, route.Name)
+				continue
+			}
+			routes = append(routes, loadTestRoute{Name: route.Name, Path: route.Path, Table: segments[len(segments)-2]})
+		}
+	}
+
+	return routes, unmatched, nil
+}
+
+// controllerGetRoutes returns the variables of router/routes that the
+// controllers in dir register with Method: http.MethodGet.
+func controllerGetRoutes(dir string) (map[string]bool, error) {
+	getRoutes := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return fmt.Errorf("parse controller %s: %w", path, err)
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			lit, ok := node.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+			var method, variable string
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				key, ok := kv.Key.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				switch key.Name {
+				case "Method":
+					if sel, ok := kv.Value.(*ast.SelectorExpr); ok {
+						method = sel.Sel.Name
+					}
+				case "Path":
+					variable = routesPathVariable(kv.Value)
+				}
+			}
+			if method == "MethodGet" && variable != "" {
+				getRoutes[variable] = true
+			}
+			return true
+		})
+		return nil
+	})
+
+	return getRoutes, err
+}
+
+// routesPathVariable returns X of a routes.X.Path() call.
+func routesPathVariable(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return ""
+	}
+	pathSel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || pathSel.Sel.Name != "Path" {
+		return ""
+	}
+	routeSel, ok := pathSel.X.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	if pkg, ok := routeSel.X.(*ast.Ident); !ok || pkg.Name != "routes" {
+		return ""
+	}
+	return routeSel.Sel.Name
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const loadTestSeedsFixture = `package seeds
+
+type Runner func(context.Context, storage.Executor) error
+
+var Registry = map[string]Runner{
+	"default":     Development,
+	"development": Development,
+}
+`
+
+const loadTestControllerFixture = `package controllers
+
+func (p Products) RegisterRoutes(r *router.Router) error {
+	_, err := r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.ProductIndex.Path(),
+		Name:    routes.ProductIndex.Name(),
+		Handler: p.Index,
+	})
+	_, err = r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.ProductShow.Path(),
+		Name:    routes.ProductShow.Name(),
+		Handler: p.Show,
+	})
+	_, err = r.AddRoute(echo.Route{
+		Method:  http.MethodPost,
+		Path:    routes.ProductCreate.Path(),
+		Name:    routes.ProductCreate.Name(),
+		Handler: p.Create,
+	})
+	_, err = r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.ConfirmEmail.Path(),
+		Name:    routes.ConfirmEmail.Name(),
+		Handler: p.Confirm,
+	})
+	return err
+}
+`
+
+const loadTestRoutesFixture = `package routes
+
+const ProductPrefix = "/products"
+
+var ProductIndex = routing.NewSimpleRoute("", "products.index", ProductPrefix)
+
+var ProductShow = routing.NewRouteWithUUIDID("/:id", "products.show", ProductPrefix)
+
+var ProductCreate = routing.NewSimpleRoute("", "products.create", ProductPrefix)
+
+var ConfirmEmail = routing.NewRouteWithToken("/confirm/:token", "confirmations.show", "")
+`
+
+func TestGenerateLoadTestWritesSeedRoutesAndScenario(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "database/seeds/seeds.go", loadTestSeedsFixture)
+	writeTestFile(t, rootDir, "database/migrations/00001_create_products.sql", `-- +goose Up
+CREATE TABLE products (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL
+);
+CREATE TABLE variants (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id)
+);
+-- +goose Down
+DROP TABLE variants;
+DROP TABLE products;
+`)
+	writeTestFile(t, rootDir, "models/product.go", "package models\n\ntype ProductEntity struct {\n\tbun.BaseModel `bun:\"table:products,alias:products\"`\n}\n")
+	writeTestFile(t, rootDir, "models/variant.go", "package models\n\ntype VariantEntity struct {\n\tbun.BaseModel `bun:\"table:variants,alias:variants\"`\n}\n")
+	writeTestFile(t, rootDir, "models/factories/product.go", "package factories\n\nfunc CreateProduct(ctx context.Context, exec storage.Executor, opts ...ProductOption) (models.ProductEntity, error) {}\n")
+	writeTestFile(t, rootDir, "models/factories/variant.go", "package factories\n\nfunc CreateVariant(ctx context.Context, exec storage.Executor, productID uuid.UUID, opts ...VariantOption) (models.VariantEntity, error) {}\n")
+	writeTestFile(t, rootDir, "controllers/products.go", loadTestControllerFixture)
+	writeTestFile(t, rootDir, "router/routes/products.go", loadTestRoutesFixture)
+
+	if err := generateLoadTest(); err != nil {
+		t.Fatalf("generateLoadTest failed: %v", err)
+	}
+
+	seed := readGeneratedTestFile(t, rootDir, "database/seeds/loadtest.go")
+	for _, want := range []string{
+		`"example.com/app/models/factories"`,
+		"productRecords := make([]models.ProductEntity, 0, count)",
+		"created, err := factories.CreateVariant(ctx, exec,\n\t\t\tproductRecords[i%len(productRecords)].ID,\n\t\t)",
+		`records["variants"] = append(records["variants"], fmt.Sprint(created.ID))`,
+		`const LoadTestRecordsFile = "tmp/loadtest/records.json"`,
+	} {
+		if !strings.Contains(seed, want) {
+			t.Fatalf("loadtest.go should contain %q\n\n%s", want, seed)
+		}
+	}
+	if strings.Index(seed, "productRecords :=") > strings.Index(seed, "variantRecords :=") {
+		t.Fatalf("products should be seeded before the variants that need them\n\n%s", seed)
+	}
+
+	seeds := readGeneratedTestFile(t, rootDir, "database/seeds/seeds.go")
+	if !strings.Contains(seeds, "\t\"development\": Development,\n\t\"loadtest\":    LoadTest,\n}") {
+		t.Fatalf("seeds.go should register the loadtest seed\n\n%s", seeds)
+	}
+
+	var routes []loadTestRoute
+	if err := json.Unmarshal([]byte(readGeneratedTestFile(t, rootDir, "loadtest/routes.json")), &routes); err != nil {
+		t.Fatalf("parse routes.json: %v", err)
+	}
+	wantRoutes := []loadTestRoute{
+		{Name: "products.index", Path: "/products"},
+		{Name: "products.show", Path: "/products/:id", Table: "products"},
+	}
+	if !reflect.DeepEqual(routes, wantRoutes) {
+		t.Fatalf("routes = %#v, want %#v", routes, wantRoutes)
+	}
+
+	script := readGeneratedTestFile(t, rootDir, "loadtest/script.js")
+	if !strings.Contains(script, "open('../tmp/loadtest/records.json')") {
+		t.Fatalf("script.js should read the seeded records\n\n%s", script)
+	}
+
+	if err := os.WriteFile(filepath.Join(rootDir, "loadtest", "script.js"), []byte("// tuned\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateLoadTest(); err != nil {
+		t.Fatalf("second generateLoadTest failed: %v", err)
+	}
+	if got := readGeneratedTestFile(t, rootDir, "loadtest/script.js"); got != "// tuned\n" {
+		t.Fatalf("script.js should be kept, got\n%s", got)
+	}
+	if got := strings.Count(readGeneratedTestFile(t, rootDir, "database/seeds/seeds.go"), "LoadTest,"); got != 1 {
+		t.Fatalf("loadtest seed registrations = %d, want 1", got)
+	}
+}
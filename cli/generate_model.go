@@ -1,9 +1,16 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/models"
+	"github.com/mbvlabs/andurel/pkg/naming"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +23,16 @@ func newGenerateModelCommand() *cobra.Command {
 		primaryKeyColumn string
 		dryRun           bool
 		diff             bool
+		belongsTo        []string
+		hasMany          []string
+		jsonTypes        map[string]string
+		nullablePointers bool
+		conflictOn       []string
+		fromDB           bool
+		schema           string
+		fromView         string
+		databaseName     string
+		withTests        bool
 	)
 
 	cmd := &cobra.Command{
@@ -35,7 +52,61 @@ For example, if a migration creates a "posts" table, running:
 will generate a Post model with columns matching the posts table.
 
 Use --update to sync an existing model file with migration changes. Applying an
-update also syncs the matching factory unless --skip-factory is passed.`,
+update also syncs the matching factory unless --skip-factory is passed.
+
+Use --belongs-to and --has-many to relate the model to other models. A
+belongs-to adds the related entity, a FindBy<ForeignKey> query and a preload
+scope; a has-many adds the related entities and a preload scope. The foreign
+key columns must exist in the migrations.
+
+Use --json-type to decode a json or jsonb column into a struct you declare in
+the models package instead of json.RawMessage. bun marshals the field with
+encoding/json on insert and unmarshals it on scan.
+
+Use --nullable-pointers to generate nullable columns as pointers, such as
+*string and *time.Time, instead of the null type in andurel.lock. A nil
+pointer is stored as NULL. --update, controllers and views keep the null
+type the model was generated with.
+
+Use --conflict-on to choose the columns Upsert matches an existing row by.
+They need a unique constraint or index. Without it, a model whose key is a
+generated uuid or serial upserts on the table's only other unique key, such
+as a unique email column, and every other model on its primary key.
+
+Use --from-db to read the table from the database configured in .env
+instead of the migrations, such as for a legacy database the migrations do
+not create. Columns, keys, indexes and enum types come from pg_catalog and
+map to the same Go types. It works with --update too.
+
+Use --schema for a table outside the default public schema. The model's
+table becomes schema-qualified, as in table:billing.invoices, so bun
+queries it without a search_path. Billing.Invoice is short for Invoice
+--schema billing. Controllers and views are not generated for these tables
+yet.
+
+Use --from-view to generate a read-only model from a database view, such as
+a reporting query. The columns are inferred from the CREATE VIEW statement
+in the migrations: columns of the tables it selects from keep their types,
+and expressions need a cast (expression::type) unless they are common
+functions such as count or sum. The model gets the query methods but no
+Create, Update, Upsert or Destroy, and no factory. A materialized view also
+gets Refresh. Views have no primary key, so Find is only generated for a
+column passed with --primary-key. Combine it with --from-db to read the
+view's columns from the database instead.
+
+Use --database-name for a table in a database listed in DATABASES, such as
+an analytics database. The table is read from database/<name>/migrations,
+or with --from-db from the database configured by the <NAME>_DB_*
+variables, and the model declares the connection in a <Model>Database
+constant. Controllers, views and updates of the model use it to read the
+same migrations, and the controller queries that connection.
+
+Use --with-tests to also generate models/NAME_test.go. It creates, finds,
+updates and destroys a record built by the model's factory, and pages
+through records, against a migrated database from database.NewTestDB.
+Required foreign keys are filled with records from the referenced models'
+factories, so generate those models first. Run the test with go test
+./models/; it needs Docker to start the database.`,
 		Example: `  andurel generate model Post
 
       Generates a Post model from the existing posts table migration.
@@ -46,6 +117,50 @@ update also syncs the matching factory unless --skip-factory is passed.`,
 
       Generates a User model from the people_data table migration.
 
+  andurel generate model Comment --belongs-to Post
+
+      Generates a Comment model with a Post field, FindByPostID and WithPost.
+      The comments table must have a post_id column.
+
+  andurel generate model Post --has-many Comment
+
+      Generates a Post model with a Comments field and WithComments.
+
+  andurel generate model User --json-type settings=UserSettings
+
+      Generates a User model with a Settings UserSettings field. Declare
+      UserSettings in the models package.
+
+  andurel generate model Post --nullable-pointers
+
+      Generates a Post model where a nullable subtitle column is *string.
+
+  andurel generate model Subscriber --conflict-on email
+
+      Generates a Subscriber model whose Upsert updates the row with the
+      same email.
+
+  andurel generate model Customer --from-db
+
+      Generates a Customer model from the customers table in the database.
+
+  andurel generate model Billing.Invoice --schema billing
+
+      Generates an Invoice model from the billing.invoices table migration.
+
+  andurel generate model ReportRow --from-view report_rows
+
+      Generates a read-only ReportRow model from the report_rows view.
+
+  andurel generate model Event --database-name analytics
+
+      Generates an Event model from the events table in
+      database/analytics/migrations, stored in the analytics database.
+
+  andurel generate model Post --with-tests
+
+      Generates a Post model, its factory and models/post_test.go.
+
   andurel generate model Post --update
 
       Shows pending model and factory changes and prompts to apply them.
@@ -65,12 +180,57 @@ update also syncs the matching factory unless --skip-factory is passed.`,
 			if len(args) > 1 {
 				return fmt.Errorf("too many arguments: model takes exactly 1 argument (the model name)")
 			}
-			name := args[0]
+			if fromView != "" {
+				if tableName != "" {
+					return fmt.Errorf("--from-view and --table-name both name the table; pass only --from-view")
+				}
+				if updateModel {
+					return fmt.Errorf("--from-view cannot be combined with --update; updates read the view the model was generated from")
+				}
+				tableName = fromView
+			}
+			name, table, err := qualifyModelTable(args[0], schema, tableName)
+			if err != nil {
+				return err
+			}
+			associations := models.Associations{BelongsTo: belongsTo, HasMany: hasMany}
+			if updateModel && !associations.IsEmpty() {
+				return fmt.Errorf("--belongs-to and --has-many cannot be combined with --update")
+			}
+			if updateModel && len(jsonTypes) > 0 {
+				return fmt.Errorf("--json-type cannot be combined with --update; updates keep the struct types already in the model")
+			}
+			if updateModel && nullablePointers {
+				return fmt.Errorf("--nullable-pointers cannot be combined with --update; updates keep the null type already in the model")
+			}
+			if updateModel && databaseName != "" && !fromDB {
+				return fmt.Errorf("--database-name needs --from-db with --update; updates read the migrations of the database the model declares")
+			}
+			if updateModel && len(conflictOn) > 0 {
+				return fmt.Errorf("--conflict-on cannot be combined with --update; updates keep the Upsert already in the model")
+			}
+			if updateModel && withTests {
+				return fmt.Errorf("--with-tests cannot be combined with --update; tests are generated with a new model")
+			}
 
 			rootDir, err := findGoModRoot()
 			if err != nil {
 				return err
 			}
+			if err := checkDatabaseName(rootDir, databaseName, !fromDB); err != nil {
+				return err
+			}
+
+			var schemaDB generator.SchemaQuerier
+			if fromDB {
+				loadProjectEnv(rootDir)
+				db, closeDB, err := connectSchemaDatabaseFunc(cmd.Context(), databaseName)
+				if err != nil {
+					return err
+				}
+				defer closeDB()
+				schemaDB = db
+			}
 
 			return runMutation(cmd, mutationOptions{
 				Action:   "generate model",
@@ -83,17 +243,33 @@ update also syncs the matching factory unless --skip-factory is passed.`,
 				},
 				Run: func(rootDir string) error {
 					if updateModel {
-						return runModelUpdateFunc(name, autoApply, skipFactory)
+						return runModelUpdateFunc(name, autoApply, skipFactory, schemaDB)
 					}
 					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
 						gen, err := newGenerator()
 						if err != nil {
 							return err
 						}
+						gen.SetNullablePointers(nullablePointers)
+						gen.SetUpsertConflictColumns(conflictOn)
+						gen.SetFromView(fromView != "")
+						gen.SetWithTests(withTests)
+						if err := gen.SetDatabaseName(databaseName); err != nil {
+							return err
+						}
+						if schemaDB != nil {
+							gen.SetSchemaDatabase(schemaDB)
+						}
+						if len(jsonTypes) > 0 {
+							return gen.GenerateModelWithJSONTypes(name, table, skipFactory, primaryKeyColumn, associations, jsonTypes)
+						}
+						if !associations.IsEmpty() {
+							return gen.GenerateModelWithAssociations(name, table, skipFactory, primaryKeyColumn, associations)
+						}
 						if primaryKeyColumn != "" {
-							return gen.GenerateModelWithPK(name, tableName, skipFactory, primaryKeyColumn)
+							return gen.GenerateModelWithPK(name, table, skipFactory, primaryKeyColumn)
 						}
-						return gen.GenerateModel(name, tableName, skipFactory)
+						return gen.GenerateModel(name, table, skipFactory)
 					})(cmd, args)
 				},
 			})
@@ -105,8 +281,72 @@ update also syncs the matching factory unless --skip-factory is passed.`,
 	cmd.Flags().BoolVar(&updateModel, "update", false, "Update an existing model from migration changes")
 	cmd.Flags().BoolVar(&autoApply, "yes", false, "Apply changes without prompting for confirmation")
 	cmd.Flags().StringVar(&primaryKeyColumn, "primary-key", "", "Specify the primary key column (skips interactive detection)")
+	cmd.Flags().StringSliceVar(&belongsTo, "belongs-to", nil, "Models this model belongs to (repeatable, e.g. --belongs-to Post)")
+	cmd.Flags().StringSliceVar(&hasMany, "has-many", nil, "Models that belong to this model (repeatable, e.g. --has-many Comment)")
+	cmd.Flags().StringToStringVar(&jsonTypes, "json-type", nil, "Decode a json/jsonb column into a models struct (repeatable, e.g. --json-type settings=UserSettings)")
+	cmd.Flags().BoolVar(&nullablePointers, "nullable-pointers", false, "Generate nullable columns as pointers (e.g. *string) instead of the null type in andurel.lock")
+	cmd.Flags().StringSliceVar(&conflictOn, "conflict-on", nil, "Columns Upsert matches an existing row by (e.g. --conflict-on email)")
+	cmd.Flags().StringVar(&schema, "schema", "", "Schema the table is in when it is not the default public schema")
+	cmd.Flags().StringVar(&fromView, "from-view", "", "Generate a read-only model from this database view")
+	cmd.Flags().BoolVar(&fromDB, "from-db", false, "Read the table from the database in .env instead of the migrations")
+	cmd.Flags().StringVar(&databaseName, "database-name", "", "Database listed in DATABASES the table is in (e.g. analytics)")
+	cmd.Flags().BoolVar(&withTests, "with-tests", false, "Generate models/NAME_test.go testing Create, Find, Update, Destroy and Paginate")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
 	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
 
 	return cmd
 }
+
+// connectSchemaDatabase connects to the project database that --from-db
+// reads tables from, the one named in DATABASES when databaseName is set.
+// The returned func closes the connection.
+func connectSchemaDatabase(ctx context.Context, databaseName string) (generator.SchemaQuerier, func(), error) {
+	conn, err := connectNamedDatabase(ctx, generator.DatabaseEnvPrefix(databaseName))
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, func() { _ = conn.Close(context.Background()) }, nil
+}
+
+// checkDatabaseName validates --database-name and, when the table is read
+// from the migrations, that the database has a migrations directory.
+func checkDatabaseName(rootDir, databaseName string, needMigrations bool) error {
+	if databaseName == "" {
+		return nil
+	}
+	if err := generator.ValidateDatabaseName(databaseName); err != nil {
+		return err
+	}
+	if !needMigrations {
+		return nil
+	}
+
+	dir := generator.MigrationsDir(databaseName)
+	if info, err := os.Stat(filepath.Join(rootDir, dir)); err != nil || !info.IsDir() {
+		return fmt.Errorf("%s does not exist; create the first migration with 'andurel database migrate new NAME --database-name %s'", dir, databaseName)
+	}
+	return nil
+}
+
+// qualifyModelTable splits a Billing.Invoice model name into the model and
+// its schema, and qualifies the table with the schema, as in
+// billing.invoices.
+func qualifyModelTable(name, schema, tableName string) (string, string, error) {
+	if prefix, model, ok := strings.Cut(name, "."); ok {
+		prefixSchema := naming.ToSnakeCase(prefix)
+		if schema != "" && schema != prefixSchema {
+			return "", "", fmt.Errorf("model %s is in schema %s, but --schema is %s", name, prefixSchema, schema)
+		}
+		name, schema = model, prefixSchema
+	}
+	if schema == "" || schema == "public" {
+		return name, tableName, nil
+	}
+	if strings.Contains(tableName, ".") {
+		return "", "", fmt.Errorf("--table-name %s already names a schema; pass the table without it together with --schema", tableName)
+	}
+	if tableName == "" {
+		tableName = naming.DeriveTableName(name)
+	}
+	return name, schema + "." + tableName, nil
+}
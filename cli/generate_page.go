@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/spf13/cobra"
+)
+
+type pageTemplateData struct {
+	PascalName string
+	Title      string
+}
+
+// onboardingFiles are the onboarding checklist that new projects show as
+// their home page, removed once 'generate page home' replaces it.
+var onboardingFiles = []string{
+	filepath.Join("views", "onboarding.templ"),
+	filepath.Join("views", "onboarding_templ.go"),
+	filepath.Join("controllers", "onboarding.go"),
+	filepath.Join("router", "routes", "onboarding.go"),
+}
+
+// onboardingHomeView matches the view Pages.Home renders in a new project.
+var onboardingHomeView = regexp.MustCompile(`views\.Onboarding\{[^}]*\}\.Page\(\)`)
+
+func newGeneratePageCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "page NAME",
+		Short: "Generate a static page",
+		Long: `Generates a page rendered by the Pages controller. Pass the page name in
+snake_case or CamelCase.
+
+Writes the view to views/NAME.templ, adds a route to router/routes/pages.go,
+and a handler that renders the view to controllers/pages.go. The page is
+served at /NAME, with underscores turned into dashes.
+
+'andurel generate page home' replaces the onboarding checklist new projects
+show at /: Pages.Home renders views/home.templ instead, and the checklist's
+view, controller and routes are removed.`,
+		Example: `  andurel generate page about
+
+      View:  views/about.templ
+      Route: routes.AboutPage at /about
+
+  andurel generate page home
+
+      Replaces the onboarding checklist with views/home.templ.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: page takes exactly 1 argument (the page name)")
+			}
+			name := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate page",
+				Resource: name,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel run", Description: "Start the server and open the page"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generatePage(name)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generatePage(name string) error {
+	snakeName := naming.ToSnakeCase(name)
+	if !regexp.MustCompile(`^[a-z][a-z0-9_]*$`).MatchString(snakeName) {
+		return fmt.Errorf("invalid page name %q: use letters, digits and underscores, starting with a letter", name)
+	}
+	pascalName := naming.ToPascalCase(snakeName)
+
+	var words []string
+	for word := range strings.SplitSeq(snakeName, "_") {
+		words = append(words, naming.CapitalizeWord(word))
+	}
+	data := pageTemplateData{PascalName: pascalName, Title: strings.Join(words, " ")}
+
+	pagesPath := filepath.Join("controllers", "pages.go")
+	pages, err := os.ReadFile(pagesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", pagesPath, err)
+	}
+
+	if snakeName == "home" {
+		updated := onboardingHomeView.ReplaceAllString(string(pages), "views.HomePage{}.Page()")
+		if updated == string(pages) {
+			return fmt.Errorf("%s does not render the onboarding checklist; the home page has already been replaced", pagesPath)
+		}
+		if err := renderTemplateToFile("page_view.tmpl", filepath.Join("views", "home.templ"), data); err != nil {
+			return fmt.Errorf("failed to generate the home page view: %w", err)
+		}
+		if err := writeGoFile(pagesPath, updated); err != nil {
+			return err
+		}
+		if err := removeOnboarding(); err != nil {
+			return err
+		}
+	} else {
+		if strings.Contains(string(pages), "func (p Pages) "+pascalName+"(") {
+			return fmt.Errorf("%s already has a %s handler", pagesPath, pascalName)
+		}
+		if err := renderTemplateToFile("page_view.tmpl", filepath.Join("views", snakeName+".templ"), data); err != nil {
+			return fmt.Errorf("failed to generate the %s page view: %w", snakeName, err)
+		}
+		if err := addPageRoute(snakeName, pascalName); err != nil {
+			return err
+		}
+		updated, ok := addPageHandler(string(pages), pascalName)
+		if !ok {
+			return fmt.Errorf("could not find the route registrations in %s", pagesPath)
+		}
+		if err := writeGoFile(pagesPath, updated); err != nil {
+			return err
+		}
+	}
+
+	if err := runTemplFunc("generate"); err != nil {
+		return fmt.Errorf("failed to compile the %s page view: %w", snakeName, err)
+	}
+
+	fmt.Printf("Successfully generated the %s page\n", snakeName)
+	return nil
+}
+
+// removeOnboarding deletes the onboarding checklist and takes its controller
+// out of controllers.Module.
+func removeOnboarding() error {
+	if err := controllers.NewMainInjector().RemoveController("", "onboarding"); err != nil {
+		return fmt.Errorf("failed to unregister the onboarding controller: %w", err)
+	}
+	for _, path := range onboardingFiles {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func addPageRoute(snakeName, pascalName string) error {
+	routesPath := filepath.Join("router", "routes", "pages.go")
+	content, err := os.ReadFile(routesPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", routesPath, err)
+	}
+	if strings.Contains(string(content), "var "+pascalName+"Page ") {
+		return fmt.Errorf("%s already declares %sPage", routesPath, pascalName)
+	}
+
+	route := fmt.Sprintf(`
+var %sPage = routing.NewSimpleRoute(
+	"/%s",
+	"pages.%s",
+	"",
+)
+`, pascalName, naming.ToKebabCase(snakeName), snakeName)
+
+	return writeGoFile(routesPath, strings.TrimRight(string(content), "\n")+"\n"+route)
+}
+
+// addPageHandler registers a GET route for the page ahead of the error pages
+// in Pages.RegisterRoutes and appends its handler. It reports false when the
+// registrations are not found.
+func addPageHandler(content, pascalName string) (string, bool) {
+	const errorPages = "\t_ = r.AddRouteNotFound(p.NotFound)\n"
+	before, after, found := strings.Cut(content, errorPages)
+	if !found {
+		return content, false
+	}
+
+	registration := fmt.Sprintf(`	_, err = r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.%[1]sPage.Path(),
+		Name:    routes.%[1]sPage.Name(),
+		Handler: p.%[1]s,
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+`, pascalName)
+
+	handler := fmt.Sprintf(`
+func (p Pages) %[1]s(etx *echo.Context) error {
+	return hypermedia.RenderPage(etx, views.%[1]sPage{}.Page())
+}
+`, pascalName)
+
+	return before + registration + errorPages + strings.TrimRight(after, "\n") + "\n" + handler, true
+}
+
+func writeGoFile(path, content string) error {
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return files.FormatGoFile(path)
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const pagesControllerFixture = `package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"example.com/app/config"
+	"example.com/app/internal/hypermedia"
+	"example.com/app/internal/server"
+	"example.com/app/router"
+	"example.com/app/router/routes"
+	"example.com/app/views"
+
+	"github.com/labstack/echo/v5"
+)
+
+type Pages struct{}
+
+func (p Pages) RegisterRoutes(r *router.Router) error {
+	errs := []error{}
+
+	_, err := r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.HomePage.Path(),
+		Name:    routes.HomePage.Name(),
+		Handler: p.Home,
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	_ = r.AddRouteNotFound(p.NotFound)
+
+	return errors.Join(errs...)
+}
+
+func (p Pages) Home(etx *echo.Context) error {
+	return hypermedia.RenderPage(etx, views.Onboarding{Check: config.Env == server.DevEnvironment}.Page())
+}
+
+func (p Pages) NotFound(etx *echo.Context) error {
+	return hypermedia.RenderPage(etx, views.NotFound())
+}
+`
+
+const pagesRoutesFixture = `package routes
+
+import (
+	"example.com/app/internal/routing"
+)
+
+var HomePage = routing.NewSimpleRoute(
+	"/",
+	"pages.home",
+	"",
+)
+`
+
+func setupGeneratePageTestProject(t *testing.T) string {
+	t.Helper()
+
+	rootDir := setupGenerateFileTestProject(t)
+	resetCLITestSeams(t)
+	runTemplFunc = func(args ...string) error { return nil }
+
+	writeTestFile(t, rootDir, "controllers/pages.go", pagesControllerFixture)
+	writeTestFile(t, rootDir, "router/routes/pages.go", pagesRoutesFixture)
+	writeTestFile(t, rootDir, "controllers/controller.go", strings.Replace(
+		controllersModuleFixture,
+		"\tfx.Invoke(func(r *router.Router, c Pages) error {",
+		"\tfx.Invoke(func(r *router.Router, c Onboarding) error {\n\t\treturn c.RegisterRoutes(r)\n\t}),\n\tfx.Invoke(func(r *router.Router, c Pages) error {",
+		1,
+	))
+	for _, path := range onboardingFiles {
+		writeTestFile(t, rootDir, filepath.ToSlash(path), "package onboarding\n")
+	}
+
+	return rootDir
+}
+
+func TestGeneratePageAddsRouteHandlerAndView(t *testing.T) {
+	rootDir := setupGeneratePageTestProject(t)
+
+	if err := generatePage("about_us"); err != nil {
+		t.Fatalf("generatePage failed: %v", err)
+	}
+
+	for path, wants := range map[string][]string{
+		"views/about_us.templ":   {"type AboutUsPage struct{}", "templ (p AboutUsPage) Page()", `@base(SetTitle("About Us"))`},
+		"router/routes/pages.go": {"var AboutUsPage = routing.NewSimpleRoute(\n\t\"/about-us\",\n\t\"pages.about_us\","},
+		"controllers/pages.go": {
+			"Handler: p.AboutUs,\n\t})\n\tif err != nil {\n\t\terrs = append(errs, err)\n\t}\n\n\t_ = r.AddRouteNotFound(p.NotFound)",
+			"func (p Pages) AboutUs(etx *echo.Context) error {\n\treturn hypermedia.RenderPage(etx, views.AboutUsPage{}.Page())\n}",
+			"views.Onboarding{",
+		},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	if err := generatePage("AboutUs"); err == nil || !strings.Contains(err.Error(), "already has a AboutUs handler") {
+		t.Fatalf("expected duplicate page error, got %v", err)
+	}
+}
+
+func TestGeneratePageHomeReplacesOnboarding(t *testing.T) {
+	rootDir := setupGeneratePageTestProject(t)
+
+	if err := generatePage("home"); err != nil {
+		t.Fatalf("generatePage failed: %v", err)
+	}
+
+	pages := readGeneratedTestFile(t, rootDir, "controllers/pages.go")
+	if !strings.Contains(pages, "return hypermedia.RenderPage(etx, views.HomePage{}.Page())") {
+		t.Fatalf("Home should render views.HomePage\n\n%s", pages)
+	}
+	for _, unused := range []string{"views.Onboarding", `"example.com/app/config"`, `"example.com/app/internal/server"`} {
+		if strings.Contains(pages, unused) {
+			t.Fatalf("controllers/pages.go should not contain %q\n\n%s", unused, pages)
+		}
+	}
+	if controller := readGeneratedTestFile(t, rootDir, "controllers/controller.go"); strings.Contains(controller, "Onboarding") {
+		t.Fatalf("onboarding controller should be unregistered\n\n%s", controller)
+	}
+	for _, path := range onboardingFiles {
+		if _, err := os.Stat(filepath.Join(rootDir, path)); !os.IsNotExist(err) {
+			t.Fatalf("%s should be removed, stat err = %v", path, err)
+		}
+	}
+	if view := readGeneratedTestFile(t, rootDir, "views/home.templ"); !strings.Contains(view, "templ (p HomePage) Page()") {
+		t.Fatalf("views/home.templ should declare HomePage\n\n%s", view)
+	}
+
+	if err := generatePage("home"); err == nil || !strings.Contains(err.Error(), "already been replaced") {
+		t.Fatalf("expected already replaced error, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/spf13/cobra"
+)
+
+type progressTemplateData struct {
+	ModulePath string
+	PascalName string
+}
+
+// progressSharedFiles are generated once per project and reused by every
+// progress-reporting job.
+var progressSharedFiles = []struct {
+	template string
+	path     string
+}{
+	{"progress_model.tmpl", filepath.Join("models", "job_progress.go")},
+	{"progress_helper.tmpl", filepath.Join("queue", "progress.go")},
+	{"progress_route.tmpl", filepath.Join("router", "routes", "job_progress.go")},
+	{"progress_controller.tmpl", filepath.Join("controllers", "job_progress.go")},
+	{"progress_view.tmpl", filepath.Join("views", "job_progress.templ")},
+}
+
+var progressNow = time.Now
+
+func newGenerateProgressCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:     "progress JOB_NAME",
+		Aliases: []string{"p"},
+		Short:   "Generate a job with browser progress reporting",
+		Long: `Generates a background job that reports its progress to the browser.
+Pass the job name in CamelCase.
+
+The first run adds the shared pieces: a job_progress migration and model,
+a queue.ReportProgress helper for workers, and a controller that streams a
+views.JobProgress component over SSE from /jobs/:id/progress. Each run then
+creates the job and a worker that calls queue.ReportProgress. If the job
+already exists, only the shared pieces are added.
+
+Render views.JobProgress(jobID) with the ID returned when the job is
+inserted, then run 'andurel generate view' and 'andurel database migrate up'.`,
+		Example: `  andurel generate progress ImportContacts
+
+      Creates the ImportContacts job and worker with progress reporting.
+      Job:    queue/jobs/import_contacts.go
+      Worker: queue/import_contacts.go`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: progress takes exactly 1 argument (the job name)")
+			}
+			name := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate progress",
+				Resource: name,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel generate view", Description: "Compile the progress component"},
+					{Command: "andurel database migrate up", Description: "Create the job_progress table"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateProgress(name)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateProgress(name string) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	snakeName := naming.ToSnakeCase(name)
+	pascalName := naming.ToPascalCase(snakeName)
+	data := progressTemplateData{
+		ModulePath: modulePath,
+		PascalName: pascalName,
+	}
+
+	if err := generateProgressMigration(); err != nil {
+		return fmt.Errorf("failed to generate job_progress migration: %w", err)
+	}
+
+	for _, file := range progressSharedFiles {
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		if filepath.Ext(file.path) == ".templ" {
+			err = renderTemplateToFile(file.template, file.path, data)
+		} else {
+			err = generateFromTemplate(file.template, file.path, data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to generate %s: %w", file.path, err)
+		}
+	}
+
+	if err := controllers.NewMainInjector().InjectController("JobProgress", "", "job_progress"); err != nil {
+		return fmt.Errorf("failed to register job progress controller: %w", err)
+	}
+
+	workerPath := filepath.Join("queue", snakeName+".go")
+	if _, err := os.Stat(workerPath); err == nil {
+		fmt.Printf("Job %s already exists; call queue.ReportProgress from its worker\n", name)
+		return nil
+	}
+
+	jobPath := filepath.Join("queue", "jobs", snakeName+".go")
+	if err := generateFromTemplate("job.tmpl", jobPath, jobTemplateData{
+		PascalName: pascalName,
+		SnakeName:  snakeName,
+	}); err != nil {
+		return fmt.Errorf("failed to generate job file: %w", err)
+	}
+
+	if err := generateFromTemplate("progress_worker.tmpl", workerPath, data); err != nil {
+		return fmt.Errorf("failed to generate worker file: %w", err)
+	}
+
+	if err := registerWorkerInQueueModule(pascalName); err != nil {
+		return fmt.Errorf("failed to register worker: %w", err)
+	}
+
+	fmt.Printf("Successfully generated job %s with progress reporting\n", name)
+	return nil
+}
+
+func generateProgressMigration() error {
+	existing, err := filepath.Glob(filepath.Join("database", "migrations", "*_create_job_progress_table.sql"))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	migrationPath := filepath.Join(
+		"database",
+		"migrations",
+		progressNow().UTC().Format("20060102150405")+"_create_job_progress_table.sql",
+	)
+	return renderTemplateToFile("progress_migration.tmpl", migrationPath, nil)
+}
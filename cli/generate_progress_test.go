@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const controllersModuleFixture = `package controllers
+
+import (
+	"example.com/app/router"
+
+	"go.uber.org/fx"
+)
+
+var constructors = fx.Provide(
+	NewPages,
+)
+
+var Module = fx.Module(
+	"controllers",
+	constructors,
+	fx.Invoke(func(r *router.Router, c Pages) error {
+		return c.RegisterRoutes(r)
+	}),
+)
+`
+
+func TestGenerateProgressWritesSharedPiecesOnceAndWorker(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+
+	originalProgressNow := progressNow
+	progressNow = func() time.Time { return time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { progressNow = originalProgressNow })
+
+	if err := generateProgress("ImportContacts"); err != nil {
+		t.Fatalf("generateProgress failed: %v", err)
+	}
+
+	migration := readGeneratedTestFile(t, rootDir, "database/migrations/20260708120000_create_job_progress_table.sql")
+	if !strings.Contains(migration, "CREATE TABLE IF NOT EXISTS job_progress") {
+		t.Fatalf("migration should create job_progress\n\n%s", migration)
+	}
+
+	for path, wants := range map[string][]string{
+		"models/job_progress.go":        {"bun:\"table:job_progress,alias:job_progress\"", "func (jobProgress) Report(", "CONFLICT (job_id) DO UPDATE"},
+		"queue/progress.go":             {"func ReportProgress[T river.JobArgs](", "models.JobProgress.Report(ctx, db, job.ID, job.Kind, percent, step)"},
+		"router/routes/job_progress.go": {"routing.NewRouteWithBigSerialID(", "\"/:id/progress\""},
+		"controllers/job_progress.go":   {"func NewJobProgress(db storage.Pool) JobProgress", "hypermedia.NewBroadcaster(etx)", "sse.PatchComponent(views.JobProgressBar(progress))"},
+		"views/job_progress.templ":      {"templ JobProgress(jobID int64)", "hypermedia.DataAction(http.MethodGet, routes.JobProgressStream.URL(jobID))", "templ JobProgressBar(progress models.JobProgressEntity)"},
+		"queue/import_contacts.go":      {"func NewImportContactsWorker(db storage.Pool) *ImportContactsWorker", "ReportProgress(ctx, w.db.Executor(), job, 100, \"Done\")"},
+		"queue/jobs/import_contacts.go": {"func (ImportContactsArgs) Kind() string { return \"import_contacts\" }"},
+		"queue/workers.go":              {"NewImportContactsWorker,", "worker *ImportContactsWorker) error"},
+		"controllers/controller.go":     {"NewJobProgress,", "c JobProgress) error"},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	progressNow = func() time.Time { return time.Date(2026, 7, 9, 12, 0, 0, 0, time.UTC) }
+	if err := generateProgress("ExportReport"); err != nil {
+		t.Fatalf("second generateProgress failed: %v", err)
+	}
+
+	migrations, err := filepath.Glob(filepath.Join(rootDir, "database", "migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("glob migrations: %v", err)
+	}
+	if len(migrations) != 1 {
+		t.Fatalf("expected one job_progress migration, got %v", migrations)
+	}
+	if got := strings.Count(readGeneratedTestFile(t, rootDir, "controllers/controller.go"), "NewJobProgress,"); got != 1 {
+		t.Fatalf("job progress controller registrations = %d, want 1", got)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, "queue", "export_report.go")); err != nil {
+		t.Fatalf("expected second worker: %v", err)
+	}
+}
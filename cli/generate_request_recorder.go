@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+func newGenerateRequestRecorderCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "request-recorder",
+		Short: "Generate development middleware that records requests for replay",
+		Long: `Generates middleware.RecordRequests and adds it to router/router.go.
+
+When ENVIRONMENT is development, every request except assets is written to
+tmp/requests as a HAR file with its method, URL, headers, body, and response
+status. Send a recording to the local server again with 'andurel replay' to
+reproduce webhook and form bugs. Bodies over 1 MiB are cut off. The files hold
+cookies and form values as sent, and tmp is ignored by git. Outside
+development the middleware does nothing.`,
+		Example: `  andurel generate request-recorder
+
+      Middleware: router/middleware/request_recorder.go
+      Recordings: tmp/requests/*.har`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate request-recorder",
+				Resource: "request-recorder",
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel run", Description: "Start the server and send the requests to record"},
+					{Command: "andurel replay tmp/requests/<file>.har", Description: "Send a recorded request again"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateRequestRecorder()
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateRequestRecorder() error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	path := filepath.Join("router", "middleware", "request_recorder.go")
+	if _, err := os.Stat(path); err != nil {
+		if err := generateFromTemplate("request_recorder_middleware.tmpl", path, struct{ ModulePath string }{modulePath}); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", path, err)
+		}
+	}
+
+	if err := registerGlobalMiddleware("middleware.RecordRequests"); err != nil {
+		return err
+	}
+
+	fmt.Println("Successfully generated the request recorder; requests are written to tmp/requests in development")
+	return nil
+}
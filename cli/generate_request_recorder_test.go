@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateRequestRecorderWritesMiddlewareAndRegistersIt(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "router/router.go", routerMiddlewareFixture)
+
+	for range 2 {
+		if err := generateRequestRecorder(); err != nil {
+			t.Fatalf("generateRequestRecorder failed: %v", err)
+		}
+	}
+
+	middleware := readGeneratedTestFile(t, rootDir, "router/middleware/request_recorder.go")
+	for _, want := range []string{
+		"func RecordRequests(next echo.HandlerFunc) echo.HandlerFunc",
+		"config.Env != server.DevEnvironment",
+		`const RecordedRequestsDir = "tmp/requests"`,
+		`const replayHeader = "` + replayHeader + `"`,
+	} {
+		if !strings.Contains(middleware, want) {
+			t.Fatalf("request_recorder.go should contain %q\n\n%s", want, middleware)
+		}
+	}
+
+	router := readGeneratedTestFile(t, rootDir, "router/router.go")
+	if !strings.Contains(router, "middlewares := []echo.MiddlewareFunc{\n\t\tmiddleware.RecordRequests,\n\t\tmiddleware.Logger(tel),") {
+		t.Fatalf("router should register the recorder first\n\n%s", router)
+	}
+	if got := strings.Count(router, "RecordRequests"); got != 1 {
+		t.Fatalf("request recorder registrations = %d, want 1", got)
+	}
+}
@@ -0,0 +1,296 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/spf13/cobra"
+)
+
+type savedViewTemplateData struct {
+	ModulePath    string
+	CSSComponents bool
+}
+
+// savedViewSharedFiles are generated once per project and reused by every
+// index with saved views.
+var savedViewSharedFiles = []struct {
+	template string
+	path     string
+}{
+	{"saved_view_model.tmpl", filepath.Join("models", "saved_view.go")},
+	{"saved_view_route.tmpl", filepath.Join("router", "routes", "saved_views.go")},
+	{"saved_view_controller.tmpl", filepath.Join("controllers", "saved_views.go")},
+	{"saved_view_view.tmpl", filepath.Join("views", "saved_views.templ")},
+}
+
+var savedViewNow = time.Now
+
+var sortColumnPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+func newGenerateSavedViewsCommand() *cobra.Command {
+	var sortColumns []string
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "saved-views RESOURCE",
+		Short: "Let users save named filter and sort views of a resource index",
+		Long: `Adds saved views to the index of a generated Templ resource. Pass the
+resource name in CamelCase.
+
+A saved view stores the index's query string, such as the filters from
+'generate scaffold --filters' and the sort, under a name for the signed-in
+user. The index header gets a dropdown listing the user's views for the
+resource, a form to save the current query, and the sortable columns.
+Opening /products?view=<id> applies the saved query.
+
+Use --sort to let the index sort by the listed columns with ?sort=column
+or ?sort=-column for descending order.
+
+The first run adds the shared pieces: a saved_views migration and model,
+a SavedViews controller serving /saved-views, and the
+views.SavedViewsDropdown component.`,
+		Example: `  andurel generate saved-views Product
+
+      Adds the saved views dropdown to views/products_resource.templ.
+
+  andurel generate saved-views Order --sort created_at,total
+
+      Also lets the orders index sort by created_at and total.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: saved-views takes exactly 1 argument (the resource name)")
+			}
+			for _, column := range sortColumns {
+				if !sortColumnPattern.MatchString(column) {
+					return fmt.Errorf("invalid --sort column %q: use snake_case column names", column)
+				}
+			}
+			resourceName := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate saved-views",
+				Resource: resourceName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel generate view", Description: "Compile the updated resource view"},
+					{Command: "andurel database migrate up", Description: "Create the saved_views table"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						return generateSavedViews(rootDir, resourceName, sortColumns)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&sortColumns, "sort", nil, "Comma-separated columns the index can be sorted by")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func generateSavedViews(rootDir, resourceName string, sortColumns []string) error {
+	modulePath, err := readModulePath()
+	if err != nil {
+		return fmt.Errorf("failed to read module path: %w", err)
+	}
+
+	tableName, _ := generator.ResolveTableNameWithFlag("models", resourceName)
+	viewPath := filepath.Join("views", tableName+"_resource.templ")
+	controllerPath := filepath.Join("controllers", tableName+".go")
+
+	viewContent, err := os.ReadFile(viewPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Templ resource view at %s; generate the resource with 'andurel generate scaffold %s' first", viewPath, resourceName)
+		}
+		return err
+	}
+	controllerContent, err := os.ReadFile(controllerPath)
+	if err != nil {
+		return fmt.Errorf("failed to read controller %s: %w", controllerPath, err)
+	}
+
+	updatedController, err := addSavedViewsToController(string(controllerContent), resourceName, tableName, sortColumns)
+	if err != nil {
+		return fmt.Errorf("failed to add saved views to %s: %w", controllerPath, err)
+	}
+	updatedView, err := addSavedViewsToView(string(viewContent), resourceName)
+	if err != nil {
+		return fmt.Errorf("failed to add saved views to %s: %w", viewPath, err)
+	}
+
+	if err := generateSavedViewMigration(); err != nil {
+		return fmt.Errorf("failed to generate saved_views migration: %w", err)
+	}
+
+	data := savedViewTemplateData{ModulePath: modulePath}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+	for _, file := range savedViewSharedFiles {
+		if _, err := os.Stat(file.path); err == nil {
+			continue
+		}
+		render := generateFromTemplate
+		if strings.HasSuffix(file.path, ".templ") {
+			render = renderTemplateToFile
+		}
+		if err := render(file.template, file.path, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", file.path, err)
+		}
+	}
+
+	if err := controllers.NewMainInjector().InjectController("SavedView", "", "saved_views"); err != nil {
+		return fmt.Errorf("failed to register saved views controller: %w", err)
+	}
+
+	if err := os.WriteFile(controllerPath, []byte(updatedController), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	if err := files.FormatGoFile(controllerPath); err != nil {
+		return err
+	}
+	if strings.Contains(updatedController, "models.SortScope(") {
+		if err := controllers.EnsureSortScope(); err != nil {
+			return fmt.Errorf("failed to generate sort scope: %w", err)
+		}
+	}
+	if err := os.WriteFile(viewPath, []byte(updatedView), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully added saved views to %s\n", viewPath)
+	return nil
+}
+
+// addSavedViewsToController loads the saved views menu at the top of the
+// scaffolded Index action, applies --sort to the paginated query, and passes
+// the menu to the index view.
+func addSavedViewsToController(content, resourceName, tableName string, sortColumns []string) (string, error) {
+	if strings.Contains(content, "loadSavedViews(") {
+		return "", fmt.Errorf("saved views are already enabled")
+	}
+
+	index := regexp.MustCompile(`(?m)^func \((\w+) \w+\) Index\(etx \*echo\.Context\) error \{\n`)
+	loc := index.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("no Index action found")
+	}
+	receiver := content[loc[2]:loc[3]]
+
+	args := []string{strconv.Quote(tableName), "routes." + resourceName + "Index.URL()"}
+	for _, column := range sortColumns {
+		args = append(args, strconv.Quote(column))
+	}
+	load := fmt.Sprintf(
+		"\tsavedViews, redirect, err := loadSavedViews(etx, %s.db.Executor(), %s)\n"+
+			"\tif err != nil {\n\t\treturn hypermedia.RenderPage(etx, views.InternalError())\n\t}\n"+
+			"\tif redirect != \"\" {\n\t\treturn etx.Redirect(http.StatusSeeOther, redirect)\n\t}\n\n",
+		receiver,
+		strings.Join(args, ", "),
+	)
+	updated := content[:loc[1]] + load + content[loc[1]:]
+
+	// Index actions that render a data table sort by its headers already.
+	if len(sortColumns) > 0 && !strings.Contains(updated, "models.SortScope(") {
+		paginate := regexp.MustCompile(`models\.` + regexp.QuoteMeta(resourceName) + `\.Paginate\(\n(?:\t\t.*\n)*?\t\tperPage,\n`)
+		p := paginate.FindStringIndex(updated)
+		if p == nil {
+			return "", fmt.Errorf("no %s.Paginate call found in the Index action", resourceName)
+		}
+		sortScope := fmt.Sprintf("\t\tmodels.SortScope(etx.QueryParam(\"sort\"), %s),\n", strings.Join(args[2:], ", "))
+		updated = updated[:p[1]] + sortScope + updated[p[1]:]
+	}
+
+	render := fmt.Sprintf("views.%sIndex{", resourceName)
+	idx := strings.Index(updated, render)
+	if idx < 0 {
+		return "", fmt.Errorf("no %sIndex render found in the Index action", resourceName)
+	}
+	insertAt := idx + len(render)
+	return updated[:insertAt] + "SavedViews: savedViews, " + updated[insertAt:], nil
+}
+
+// addSavedViewsToView adds the menu to the index view data and renders the
+// dropdown next to the "New" link in the index header.
+func addSavedViewsToView(content, resourceName string) (string, error) {
+	structDecl := regexp.MustCompile(`type ` + regexp.QuoteMeta(resourceName) + `Index struct \{\n(\t+)Items\s+\[\]models\.\w+\n`)
+	loc := structDecl.FindStringSubmatchIndex(content)
+	if loc == nil {
+		return "", fmt.Errorf("no %sIndex struct found", resourceName)
+	}
+	indent := content[loc[2]:loc[3]]
+	updated := content[:loc[1]] + indent + "SavedViews SavedViewsMenu\n" + content[loc[1]:]
+
+	page := regexp.MustCompile(`templ \((\w+) ` + regexp.QuoteMeta(resourceName) + `Index\) Page\(\) \{`)
+	m := page.FindStringSubmatchIndex(updated)
+	if m == nil {
+		return "", fmt.Errorf("no %sIndex page found", resourceName)
+	}
+	dropdown := "@SavedViewsDropdown(" + updated[m[2]:m[3]] + ".SavedViews)"
+
+	heading := regexp.MustCompile(`(?m)^(\t*)<h1[^\n]*</h1>\n`)
+	h := heading.FindStringSubmatchIndex(updated[m[1]:])
+	if h == nil {
+		return "", fmt.Errorf("no heading found in the %sIndex page", resourceName)
+	}
+	lineIndent := updated[m[1]+h[2] : m[1]+h[3]]
+	after := m[1] + h[1]
+
+	rest := updated[after:]
+	lineEnd := strings.Index(rest, "\n")
+	if lineEnd >= 0 && strings.Contains(rest[:lineEnd], "routes."+resourceName+"New.URL()") {
+		newLink := strings.TrimLeft(rest[:lineEnd], "\t")
+		group := lineIndent + "<div class=\"flex items-center gap-3\">\n" +
+			lineIndent + "\t" + dropdown + "\n" +
+			lineIndent + "\t" + newLink + "\n" +
+			lineIndent + "</div>\n"
+		return updated[:after] + group + rest[lineEnd+1:], nil
+	}
+
+	return updated[:after] + lineIndent + dropdown + "\n" + rest, nil
+}
+
+func generateSavedViewMigration() error {
+	existing, err := filepath.Glob(filepath.Join("database", "migrations", "*_create_saved_views_table.sql"))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	migrationPath := filepath.Join(
+		"database",
+		"migrations",
+		savedViewNow().UTC().Format("20060102150405")+"_create_saved_views_table.sql",
+	)
+	return renderTemplateToFile("saved_view_migration.tmpl", migrationPath, nil)
+}
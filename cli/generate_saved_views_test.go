@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const productsControllerFixture = `package controllers
+
+import (
+	"net/http"
+
+	"example.com/app/internal/hypermedia"
+	"example.com/app/models"
+	"example.com/app/views"
+
+	"github.com/labstack/echo/v5"
+)
+
+func (p Products) Index(etx *echo.Context) error {
+	page := int64(1)
+	perPage := int64(25)
+
+	productsList, err := models.Product.Paginate(
+		etx.Request().Context(),
+		p.db.Executor(),
+		page,
+		perPage,
+	)
+	if err != nil {
+		return hypermedia.RenderPage(etx, views.InternalError())
+	}
+
+	return hypermedia.RenderPage(etx, views.ProductIndex{Items: productsList.Products}.Page())
+}
+`
+
+const productsIndexViewFixture = `package views
+
+type ProductIndex struct {
+	Items []models.ProductEntity
+}
+
+templ (pi ProductIndex) Page() {
+	<div class="flex flex-wrap items-center justify-between gap-4">
+		<h1 class="text-2xl font-semibold text-slate-100">Products</h1>
+		<a href={ routes.ProductNew.URL() } class="btn">New Product</a>
+	</div>
+	if len(pi.Items) == 0 {
+		<p>No products found.</p>
+	}
+}
+`
+
+func TestGenerateSavedViewsAddsMenuToResourceIndex(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+	writeTestFile(t, rootDir, "controllers/products.go", productsControllerFixture)
+	writeTestFile(t, rootDir, "views/products_resource.templ", productsIndexViewFixture)
+
+	originalSavedViewNow := savedViewNow
+	savedViewNow = func() time.Time { return time.Date(2026, 7, 8, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { savedViewNow = originalSavedViewNow })
+
+	if err := generateSavedViews(rootDir, "Product", []string{"name", "created_at"}); err != nil {
+		t.Fatalf("generateSavedViews failed: %v", err)
+	}
+
+	for path, wants := range map[string][]string{
+		"controllers/products.go": {
+			`savedViews, redirect, err := loadSavedViews(etx, p.db.Executor(), "products", routes.ProductIndex.URL(), "name", "created_at")`,
+			"return etx.Redirect(http.StatusSeeOther, redirect)",
+			"\t\tperPage,\n\t\tmodels.SortScope(etx.QueryParam(\"sort\"), \"name\", \"created_at\"),\n\t)",
+			"views.ProductIndex{SavedViews: savedViews, Items: productsList.Products}",
+		},
+		"views/products_resource.templ": {
+			"\tSavedViews SavedViewsMenu\n",
+			"\t\t<div class=\"flex items-center gap-3\">\n\t\t\t@SavedViewsDropdown(pi.SavedViews)\n\t\t\t<a href={ routes.ProductNew.URL() }",
+		},
+		"database/migrations/20260708120000_create_saved_views_table.sql": {"UNIQUE (user_id, resource, name)"},
+		"models/saved_view.go":         {"On(\"CONFLICT (user_id, resource, name) DO UPDATE\")"},
+		"models/sort.go":               {"func SortScope(sort string, allowed ...string)"},
+		"router/routes/saved_views.go": {"\"saved_views.create\"", "routing.NewRouteWithUUIDID("},
+		"controllers/saved_views.go":   {"func loadSavedViews(", "func isLocalPath(target string) bool"},
+		"views/saved_views.templ":      {"templ SavedViewsDropdown(menu SavedViewsMenu)"},
+		"controllers/controller.go":    {"NewSavedViews,", "c SavedViews) error"},
+	} {
+		content := readGeneratedTestFile(t, rootDir, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	err := generateSavedViews(rootDir, "Product", nil)
+	if err == nil || !strings.Contains(err.Error(), "already enabled") {
+		t.Fatalf("expected second run to report saved views already enabled, got %v", err)
+	}
+}
+
+func TestGenerateSavedViewsKeepsDataTableSort(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+	writeTestFile(t, rootDir, "controllers/controller.go", controllersModuleFixture)
+	writeTestFile(t, rootDir, "controllers/products.go", `package controllers
+
+func (p Products) Index(etx *echo.Context) error {
+	table := views.NewDataTable(etx.QueryParams(), views.ProductColumns)
+
+	productsList, err := models.Product.Paginate(
+		etx.Request().Context(),
+		p.db.Executor(),
+		table.Page,
+		table.PerPage,
+		models.SortScope(table.Sort, views.ProductColumns.SortKeys()...),
+	)
+	if err != nil {
+		return err
+	}
+
+	index := views.ProductIndex{Items: productsList.Products, DataTable: table}
+	return hypermedia.RenderPage(etx, index.Page())
+}
+`)
+	writeTestFile(t, rootDir, "views/products_resource.templ", productsIndexViewFixture)
+	writeTestFile(t, rootDir, "models/sort.go", "package models\n\nfunc SortScope(sort string, allowed ...string) {}\n")
+
+	if err := generateSavedViews(rootDir, "Product", []string{"name"}); err != nil {
+		t.Fatalf("generateSavedViews failed: %v", err)
+	}
+
+	content := readGeneratedTestFile(t, rootDir, "controllers/products.go")
+	if got := strings.Count(content, "models.SortScope("); got != 1 {
+		t.Fatalf("SortScope calls = %d, want the data table's only\n\n%s", got, content)
+	}
+	if !strings.Contains(content, "views.ProductIndex{SavedViews: savedViews, Items: productsList.Products, DataTable: table}") {
+		t.Fatalf("saved views should be passed to the index view\n\n%s", content)
+	}
+}
+
+func TestGenerateSavedViewsRequiresTemplResourceView(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+
+	err := generateSavedViews(rootDir, "Product", nil)
+	if err == nil || !strings.Contains(err.Error(), "no Templ resource view at views/products_resource.templ") {
+		t.Fatalf("expected missing view error, got %v", err)
+	}
+}
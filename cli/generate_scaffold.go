@@ -17,6 +17,12 @@ func newGenerateScaffoldCommand() *cobra.Command {
 		primaryKeyColumn string
 		inertia          bool
 		api              bool
+		filters          []string
+		withFeed         bool
+		withAddress      bool
+		nullablePointers bool
+		conflictOn       []string
+		databaseName     string
 		dryRun           bool
 		diff             bool
 	)
@@ -41,7 +47,38 @@ edit, update, destroy.
 
 Use --api to generate a JSON API controller instead of views. The
 scaffold creates the model and an API controller under controllers/api
-with echo.JSON responses. No views are generated.`,
+with echo.JSON responses. No views are generated.
+
+Use --filters to add index filters for the listed columns. Timestamp and
+date columns get a from/to date range, numeric columns a min/max range,
+and text or boolean columns a select. The filters are parsed from query
+params into a typed Filters struct in the controller and applied as WHERE
+clauses on the paginated query.
+
+Use --with-feed on content resources such as posts or articles to add an
+Atom feed of the newest records at /feeds/<resource>.atom. Entries are
+ordered by published_at, or created_at, and take their title and content
+from columns like title and body. The feed sends ETag and Last-Modified
+headers so readers only download it when it changes, and it is listed in
+sitemap.xml.
+
+Use --with-address to give the resource a postal address. A migration adds
+address_line1, address_line2, city, region, postal_code and country
+columns, plus latitude, longitude and geocoded_at, to the table. Creating
+or updating a record queues a job that looks the address up with the
+geocoding client (Nominatim or Google) and stores the coordinates, and the
+show page embeds a map once they are known. The geocoding extension is
+added first if the project does not have it.
+
+Use --nullable-pointers to generate the model's nullable columns as
+pointers, such as *string, instead of the null type in andurel.lock. The
+controller and views convert empty form fields to nil.
+
+Use --conflict-on to choose the columns the model's Upsert matches an
+existing row by, as with 'andurel generate model'.
+
+Use --database-name for a table in a database listed in DATABASES, as with
+'andurel generate model'. The controller queries that connection.`,
 		Example: `  andurel generate scaffold Post
 
       Generates a full Post resource with model, CRUD controller, views, and routes.
@@ -61,6 +98,23 @@ with echo.JSON responses. No views are generated.`,
 
       Generates a model, JSON API controller, and routes. No views.
 
+  andurel generate scaffold Order --filters created_at,status
+
+      Adds a created_at date range and a status select above the index.
+      Filters:    models/order_filters.go, controllers/orders_filters.go,
+                  views/orders_filters.templ, views/filters.templ
+
+  andurel generate scaffold Post --with-feed
+
+      Adds an Atom feed of the newest posts at /feeds/posts.atom.
+      Feed:       controllers/posts_feed.go, router/routes/posts_feed.go
+
+  andurel generate scaffold Store --with-address
+
+      Adds address columns to stores, geocoded in the background.
+      Address:    models/store_address.go, queue/geocode_store.go,
+                  queue/jobs/geocode_store.go, views/maps.templ
+
   andurel generate scaffold User --table-name=people_data
 
       Generates a User resource from the people_data table.`,
@@ -77,6 +131,15 @@ with echo.JSON responses. No views are generated.`,
 			if err != nil {
 				return err
 			}
+			if len(filters) > 0 && (api || inertia) {
+				return fmt.Errorf("--filters cannot be used with --api or --inertia")
+			}
+			if withFeed && api {
+				return fmt.Errorf("--with-feed cannot be used with --api")
+			}
+			if withAddress && (api || inertia) {
+				return fmt.Errorf("--with-address cannot be used with --api or --inertia")
+			}
 			if api {
 				namespace = apiNamespace(namespace)
 			}
@@ -85,6 +148,12 @@ with echo.JSON responses. No views are generated.`,
 			if err != nil {
 				return err
 			}
+			if err := checkDatabaseName(rootDir, databaseName, true); err != nil {
+				return err
+			}
+			if databaseName != "" && (len(filters) > 0 || withFeed || withAddress) {
+				return fmt.Errorf("--database-name cannot be combined with --filters, --with-feed or --with-address yet")
+			}
 
 			return runMutation(cmd, mutationOptions{
 				Action:   "generate scaffold",
@@ -106,10 +175,30 @@ with echo.JSON responses. No views are generated.`,
 						if err != nil {
 							return err
 						}
+						gen.SetNullablePointers(nullablePointers)
+						gen.SetUpsertConflictColumns(conflictOn)
+						if err := gen.SetDatabaseName(databaseName); err != nil {
+							return err
+						}
 
+						if withAddress {
+							if err := generateAddressFunc(gen, rootDir, resourceName, tableName); err != nil {
+								return err
+							}
+						}
 						if err := gen.GenerateScaffold(resourceName, namespace, tableName, skipFactory, primaryKeyColumn, inertiaStr, api); err != nil {
 							return err
 						}
+						if len(filters) > 0 {
+							if err := gen.GenerateFilters(resourceName, namespace, tableName, filters); err != nil {
+								return err
+							}
+						}
+						if withFeed {
+							if err := gen.GenerateFeed(resourceName, namespace, tableName); err != nil {
+								return err
+							}
+						}
 						return refreshRoutesTSAfterInertiaGeneration(rootDir, inertiaStr, api)
 					})(cmd, args)
 				},
@@ -122,6 +211,12 @@ with echo.JSON responses. No views are generated.`,
 	cmd.Flags().StringVar(&primaryKeyColumn, "primary-key", "", "Specify the primary key column (skips interactive detection)")
 	cmd.Flags().BoolVar(&api, "api", false, "Generate a JSON API controller under controllers/api")
 	cmd.Flags().BoolVar(&inertia, "inertia", false, "Generate Inertia views using the adapter configured in andurel.lock")
+	cmd.Flags().StringSliceVar(&filters, "filters", nil, "Comma-separated columns to filter the index by (e.g. created_at,status)")
+	cmd.Flags().BoolVar(&withFeed, "with-feed", false, "Add an Atom feed of the newest records under /feeds")
+	cmd.Flags().BoolVar(&withAddress, "with-address", false, "Add structured address columns geocoded in the background")
+	cmd.Flags().BoolVar(&nullablePointers, "nullable-pointers", false, "Generate nullable columns as pointers (e.g. *string) instead of the null type in andurel.lock")
+	cmd.Flags().StringSliceVar(&conflictOn, "conflict-on", nil, "Columns the model's Upsert matches an existing row by (e.g. --conflict-on email)")
+	cmd.Flags().StringVar(&databaseName, "database-name", "", "Database listed in DATABASES the table is in (e.g. analytics)")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
 	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
 
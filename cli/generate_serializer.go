@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+func newGenerateSerializerCommand() *cobra.Command {
+	var opts generator.SerializerOptions
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "serializer MODEL",
+		Short: "Generate a JSON serializer for a model",
+		Long: `Generates serializers/<model>.go with a presentation struct for an
+existing model. Pass the model name in CamelCase.
+
+API controllers, exports and webhooks send the serializer instead of the
+model entity, so a column is only exposed once it is added to the struct.
+Every column is included by default except passwords, encrypted_* columns
+and columns ending in _token, _secret or _digest. --only picks the columns
+to expose and --except leaves more out.
+
+--computed adds fields derived from the entity, as name or name:type, each
+filled by a function in the generated file. --include nests another model's
+serializer: a model this one has a foreign key to nests as an object with
+WithX(entity), any other as a list with WithXs(entities). Included models
+get a default serializer when they have none.
+
+'generate scaffold --api' and 'generate controller --api' create the default
+serializer for their model and respond with it.`,
+		Example: `  andurel generate serializer Product
+
+      Exposes every non-sensitive Product column.
+
+  andurel generate serializer User --only id,email,created_at --computed display_name
+
+      Exposes three columns and a computed displayName string.
+
+  andurel generate serializer Order --include Customer --include LineItem
+
+      Nests the customer (orders.customer_id) and the line items.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: serializer takes exactly 1 argument (the model name)")
+			}
+			resourceName := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate serializer",
+				Resource: resourceName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						gen, err := newGenerator()
+						if err != nil {
+							return err
+						}
+						return gen.GenerateSerializer(resourceName, opts)
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.Only, "only", nil, "Columns to expose (default: all non-sensitive columns)")
+	cmd.Flags().StringSliceVar(&opts.Except, "except", nil, "Columns to leave out")
+	cmd.Flags().StringArrayVar(&opts.Computed, "computed", nil, "Computed field as name or name:type (repeatable)")
+	cmd.Flags().StringSliceVar(&opts.Include, "include", nil, "Models to nest (repeatable)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+func newGenerateShareCommand() *cobra.Command {
+	var expires time.Duration
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "share RESOURCE",
+		Short: "Add expiring public share links to a resource",
+		Long: `Adds share links to a generated Templ resource. Pass the resource name in
+CamelCase.
+
+The show page gets a Share panel that creates and revokes links. Anyone
+with a link can open a read-only, print-friendly copy of the record at
+/shared/<resource>/<token> without signing in, which suits invoices and
+reports. The token is signed with TOKEN_SIGNING_KEY, so changing the key
+invalidates every link.
+
+Links expire after --expires; pass 0 for links that never expire. Revoked
+and expired links render not found.
+
+The first run adds the shared pieces: a share_links migration and model,
+the views.ShareLinks panel, and a views.publicLayout without the app
+navigation.`,
+		Example: `  andurel generate share Invoice
+
+      Links to invoices expire after a week.
+
+  andurel generate share Report --expires 720h
+
+      Links to reports expire after 30 days.
+
+  andurel generate share Product --expires 0
+
+      Links to products stay valid until revoked.`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: share takes exactly 1 argument (the resource name)")
+			}
+			if expires < 0 {
+				return fmt.Errorf("--expires cannot be negative")
+			}
+			resourceName := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "generate share",
+				Resource: resourceName,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "andurel database migrate up", Description: "Create the share_links table"},
+				},
+				Run: func(rootDir string) error {
+					return withGenerateCleanup(func(_ *cobra.Command, _ []string) error {
+						gen, err := newGenerator()
+						if err != nil {
+							return err
+						}
+						tableName, _ := generator.ResolveTableNameWithFlag("models", resourceName)
+						return gen.GenerateShare(resourceName, tableName, expires.Round(time.Second))
+					})(cmd, args)
+				},
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&expires, "expires", 7*24*time.Hour, "How long new share links stay valid (0 never expires)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
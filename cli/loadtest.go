@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+const (
+	loadTestDir          = "tmp/loadtest"
+	loadTestRecordsPath  = "tmp/loadtest/records.json"
+	loadTestStartTimeout = time.Minute
+)
+
+var (
+	lookupK6             = exec.LookPath
+	startLoadTestAppFunc = startLoadTestApp
+	runK6Func            = runK6
+)
+
+type loadTestOptions struct {
+	records  int
+	vus      int
+	duration string
+	port     int
+	skipSeed bool
+}
+
+func newLoadTestCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "Run the generated k6 load test",
+		Long:  `Commands for the load test generated with 'andurel generate loadtest'.`,
+	}
+	setAgentMetadata(cmd, "development", "Load testing commands; needs k6 installed and the database configured in .env.")
+
+	cmd.AddCommand(newLoadTestRunCommand())
+
+	return cmd
+}
+
+func newLoadTestRunCommand() *cobra.Command {
+	var opts loadTestOptions
+
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Seed the database, start the app in production mode and run k6",
+		Long: `Run the k6 scenario in loadtest/script.js against the application.
+
+The command runs the loadtest seed against the database configured in .env,
+builds cmd/app and starts it with ENVIRONMENT=production on --port, waits
+for the health route, and runs k6 with BASE_URL pointing at it. The app is
+stopped when k6 finishes. Its output is written to tmp/loadtest/app.log and
+the k6 summary to tmp/loadtest/summary.json.
+
+Seeding adds records to the database, so point .env at a database you can
+fill, not production. --vus and --duration override the options of the
+script. Production mode needs what the app requires there, such as a real
+email sender in cmd/app/main.go.`,
+		Example: `  andurel loadtest run
+  andurel loadtest run --vus 50 --duration 2m
+  andurel loadtest run --records 1000
+  andurel loadtest run --skip-seed`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLoadTest(cmd, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.records, "records", 100, "Records the seed creates of each model")
+	cmd.Flags().IntVar(&opts.vus, "vus", 0, "Virtual users k6 runs at once (default from the script)")
+	cmd.Flags().StringVar(&opts.duration, "duration", "", "How long k6 runs, e.g. 30s or 2m (default from the script)")
+	cmd.Flags().IntVar(&opts.port, "port", 8090, "Port the app listens on during the test")
+	cmd.Flags().BoolVar(&opts.skipSeed, "skip-seed", false, "Reuse the records of the last seed")
+
+	return cmd
+}
+
+func runLoadTest(cmd *cobra.Command, opts loadTestOptions) error {
+	if opts.records < 1 {
+		return fmt.Errorf("--records must be at least 1")
+	}
+
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, loadTestScriptPath)); err != nil {
+		return output.NewError(
+			output.CodeUsage,
+			fmt.Sprintf("load test scenario not found at %s", loadTestScriptPath),
+			output.ExitUsage,
+			"Run 'andurel generate loadtest' first.",
+		)
+	}
+	k6, err := lookupK6("k6")
+	if err != nil {
+		return output.NewError(
+			output.CodeMissingTool,
+			"k6 not found in PATH",
+			output.ExitDependency,
+			"Install k6 from https://grafana.com/docs/k6/latest/set-up/install-k6/.",
+		)
+	}
+
+	loadProjectEnv(rootDir)
+
+	if opts.skipSeed {
+		if _, err := os.Stat(filepath.Join(rootDir, loadTestRecordsPath)); err != nil {
+			return fmt.Errorf("no seeded records at %s; run without --skip-seed", loadTestRecordsPath)
+		}
+	} else {
+		if err := os.Setenv("LOADTEST_RECORDS", strconv.Itoa(opts.records)); err != nil {
+			return err
+		}
+		if err := runSeedFunc(cmd, loadTestSeedName, false); err != nil {
+			return err
+		}
+	}
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d", opts.port)
+	healthPath := "/"
+	if manifest, err := collectRouteManifest(rootDir); err == nil {
+		for _, route := range manifest.Routes {
+			if route.Name == "api.health" {
+				healthPath = route.Path
+			}
+		}
+	}
+
+	stop, err := startLoadTestAppFunc(cmd.Context(), rootDir, opts.port, baseURL+healthPath, cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+
+	k6Err := runK6Func(cmd.Context(), rootDir, k6, loadTestK6Args(baseURL, opts), cmd.OutOrStdout(), cmd.ErrOrStderr())
+	return errors.Join(k6Err, stop())
+}
+
+func loadTestK6Args(baseURL string, opts loadTestOptions) []string {
+	args := []string{"run", "-e", "BASE_URL=" + baseURL, "--summary-export", filepath.Join(loadTestDir, "summary.json")}
+	if opts.vus > 0 {
+		args = append(args, "--vus", strconv.Itoa(opts.vus))
+	}
+	if opts.duration != "" {
+		args = append(args, "--duration", opts.duration)
+	}
+	return append(args, loadTestScriptPath)
+}
+
+// startLoadTestApp builds cmd/app, starts it in production mode on port and
+// waits until healthURL answers. The returned function stops it.
+func startLoadTestApp(ctx context.Context, rootDir string, port int, healthURL string, w io.Writer) (func() error, error) {
+	if err := os.MkdirAll(filepath.Join(rootDir, loadTestDir), 0o755); err != nil {
+		return nil, err
+	}
+
+	binary := filepath.Join(rootDir, loadTestDir, "app")
+	if _, err := fmt.Fprintln(w, "Building cmd/app..."); err != nil {
+		return nil, err
+	}
+	build := exec.CommandContext(ctx, "go", "build", "-o", binary, "./cmd/app")
+	build.Dir = rootDir
+	build.Stdout = w
+	build.Stderr = w
+	if err := build.Run(); err != nil {
+		return nil, fmt.Errorf("build failed: %w", err)
+	}
+
+	logPath := filepath.Join(loadTestDir, "app.log")
+	logFile, err := os.Create(filepath.Join(rootDir, logPath))
+	if err != nil {
+		return nil, err
+	}
+
+	app := exec.Command(binary)
+	app.Dir = rootDir
+	app.Env = append(os.Environ(), "ENVIRONMENT=production", "HOST=127.0.0.1", "PORT="+strconv.Itoa(port))
+	app.Stdout = logFile
+	app.Stderr = logFile
+	if err := app.Start(); err != nil {
+		logFile.Close()
+		return nil, fmt.Errorf("start app: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- app.Wait()
+		logFile.Close()
+	}()
+	stop := func() error {
+		_ = app.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(10 * time.Second):
+			_ = app.Process.Kill()
+			<-exited
+		}
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "Waiting for the app on port %d...\n", port); err != nil {
+		return nil, errors.Join(err, stop())
+	}
+	deadline := time.Now().Add(loadTestStartTimeout)
+	for {
+		select {
+		case err := <-exited:
+			return nil, fmt.Errorf("the app exited before it was ready (%v); see %s", err, logPath)
+		case <-ctx.Done():
+			return nil, errors.Join(ctx.Err(), stop())
+		case <-time.After(250 * time.Millisecond):
+		}
+
+		resp, err := http.Get(healthURL) //nolint:gosec // the URL is the local app
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusInternalServerError {
+				return stop, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, errors.Join(fmt.Errorf("the app did not answer %s within %s; see %s", healthURL, loadTestStartTimeout, logPath), stop())
+		}
+	}
+}
+
+func runK6(ctx context.Context, rootDir, k6 string, args []string, stdout, stderr io.Writer) error {
+	run := exec.CommandContext(ctx, k6, args...)
+	run.Dir = rootDir
+	run.Stdout = stdout
+	run.Stderr = stderr
+	if err := run.Run(); err != nil {
+		return fmt.Errorf("k6 failed: %w", err)
+	}
+	return nil
+}
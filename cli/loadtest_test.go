@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestLoadTestRunSeedsStartsAppAndRunsK6(t *testing.T) {
+	resetCLITestSeams(t)
+	t.Setenv("LOADTEST_RECORDS", "")
+	root := t.TempDir()
+	findGoModRoot = func() (string, error) { return root, nil }
+	writeTestFile(t, root, "go.mod", "module example.com/app\n\ngo 1.26\n")
+	writeTestFile(t, root, "loadtest/script.js", "export default function () {}\n")
+	writeTestFile(t, root, "router/routes/api.go", `package routes
+
+const APIPrefix = "/api"
+
+var Health = routing.NewSimpleRoute("/health", "api.health", APIPrefix)
+`)
+
+	var steps []string
+	lookupK6 = func(string) (string, error) { return "/usr/local/bin/k6", nil }
+	runSeedFunc = func(_ *cobra.Command, name string, _ bool) error {
+		steps = append(steps, "seed "+name+" "+os.Getenv("LOADTEST_RECORDS"))
+		return nil
+	}
+	startLoadTestAppFunc = func(_ context.Context, _ string, port int, healthURL string, _ io.Writer) (func() error, error) {
+		steps = append(steps, "start "+healthURL)
+		return func() error {
+			steps = append(steps, "stop")
+			return nil
+		}, nil
+	}
+	var k6Args []string
+	runK6Func = func(_ context.Context, _ string, k6 string, args []string, _, _ io.Writer) error {
+		steps = append(steps, "k6")
+		k6Args = args
+		return nil
+	}
+
+	run := func(args ...string) error {
+		cmd := NewRootCommand("test", "test-date")
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	if err := run("loadtest", "run", "--records", "500", "--vus", "25", "--port", "9000"); err != nil {
+		t.Fatalf("loadtest run failed: %v", err)
+	}
+	wantSteps := []string{"seed loadtest 500", "start http://127.0.0.1:9000/api/health", "k6", "stop"}
+	if !reflect.DeepEqual(steps, wantSteps) {
+		t.Fatalf("steps = %q, want %q", steps, wantSteps)
+	}
+	wantArgs := []string{"run", "-e", "BASE_URL=http://127.0.0.1:9000", "--summary-export", "tmp/loadtest/summary.json", "--vus", "25", "loadtest/script.js"}
+	if !reflect.DeepEqual(k6Args, wantArgs) {
+		t.Fatalf("k6 args = %q, want %q", k6Args, wantArgs)
+	}
+
+	steps = nil
+	if err := run("loadtest", "run", "--skip-seed"); err == nil || !strings.Contains(err.Error(), "run without --skip-seed") {
+		t.Fatalf("skip-seed without records error = %v", err)
+	}
+	if len(steps) != 0 {
+		t.Fatalf("steps = %q, want none before the records exist", steps)
+	}
+
+	if err := os.Remove(root + "/loadtest/script.js"); err != nil {
+		t.Fatal(err)
+	}
+	if err := run("loadtest", "run"); err == nil || !strings.Contains(err.Error(), "load test scenario not found") {
+		t.Fatalf("missing scenario error = %v", err)
+	}
+}
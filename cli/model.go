@@ -7,13 +7,18 @@ import (
 	"io"
 	"os"
 	"strings"
+
+	"github.com/mbvlabs/andurel/generator"
 )
 
-func runModelUpdate(resourceName string, autoApply bool, skipFactory bool) error {
+func runModelUpdate(resourceName string, autoApply bool, skipFactory bool, schemaDB generator.SchemaQuerier) error {
 	gen, err := newGenerator()
 	if err != nil {
 		return err
 	}
+	if schemaDB != nil {
+		gen.SetSchemaDatabase(schemaDB)
+	}
 
 	result, err := gen.UpdateModel(resourceName)
 	if err != nil {
@@ -26,7 +31,7 @@ func runModelUpdate(resourceName string, autoApply bool, skipFactory bool) error
 		result.FactoryHasChanges = false
 	}
 
-	if !result.HasChanges && !result.FactoryHasChanges {
+	if !result.HasChanges && !result.FactoryHasChanges && !result.EnumsHasChanges {
 		fmt.Println("No changes — model is already up to date.")
 		return nil
 	}
@@ -55,6 +60,17 @@ func runModelUpdate(resourceName string, autoApply bool, skipFactory bool) error
 		fmt.Println()
 	}
 
+	if result.EnumsHasChanges {
+		enumsDiff, err := result.EnumsDiff()
+		if err != nil {
+			return fmt.Errorf("failed to compute enums diff: %w", err)
+		}
+
+		fmt.Printf("Changes to %s:\n\n", result.EnumsPath)
+		printColoredDiff(enumsDiff)
+		fmt.Println()
+	}
+
 	if !autoApply {
 		confirmed, err := confirmModelApply()
 		if err != nil {
@@ -76,6 +92,9 @@ func runModelUpdate(resourceName string, autoApply bool, skipFactory bool) error
 	if result.FactoryHasChanges {
 		fmt.Printf("Updated %s\n", result.FactoryPath)
 	}
+	if result.EnumsHasChanges {
+		fmt.Printf("Updated %s\n", result.EnumsPath)
+	}
 	return nil
 }
 
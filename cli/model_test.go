@@ -28,7 +28,7 @@ func TestRunModelUpdateAppliesModelAndFactory(t *testing.T) {
 	fake.modelUpdate = changedModelUpdate()
 	capture := captureProcessOutput(t, &os.Stdout)
 
-	err := runModelUpdate("Widget", true, false)
+	err := runModelUpdate("Widget", true, false, nil)
 	out := capture()
 	if err != nil {
 		t.Fatalf("runModelUpdate: %v", err)
@@ -62,7 +62,7 @@ func TestRunModelUpdateSkipsFactory(t *testing.T) {
 	}
 	capture := captureProcessOutput(t, &os.Stdout)
 
-	err := runModelUpdate("Widget", true, true)
+	err := runModelUpdate("Widget", true, true, nil)
 	out := capture()
 	if err != nil {
 		t.Fatalf("runModelUpdate: %v", err)
@@ -88,7 +88,7 @@ func TestRunModelUpdatePrompts(t *testing.T) {
 		t.Cleanup(func() { os.Stdin = originalStdin })
 		capture := captureProcessOutput(t, &os.Stdout)
 
-		err := runModelUpdate("Widget", false, false)
+		err := runModelUpdate("Widget", false, false, nil)
 		out := capture()
 		if err != nil {
 			t.Fatalf("runModelUpdate: %v", err)
@@ -110,7 +110,7 @@ func TestRunModelUpdatePrompts(t *testing.T) {
 		t.Cleanup(func() { os.Stdin = originalStdin })
 		capture := captureProcessOutput(t, &os.Stdout)
 
-		err := runModelUpdate("Widget", false, false)
+		err := runModelUpdate("Widget", false, false, nil)
 		_ = capture()
 		if err != nil {
 			t.Fatalf("runModelUpdate: %v", err)
@@ -133,7 +133,7 @@ func TestRunModelUpdatePrompts(t *testing.T) {
 		t.Cleanup(func() { os.Stdin = originalStdin })
 		capture := captureProcessOutput(t, &os.Stdout)
 
-		err := runModelUpdate("Widget", false, false)
+		err := runModelUpdate("Widget", false, false, nil)
 		_ = capture()
 		if err == nil {
 			t.Fatal("expected prompt input error")
@@ -164,7 +164,7 @@ func TestRunModelUpdateErrors(t *testing.T) {
 			fake := installFakeGenerator(t)
 			tt.configure(fake)
 			capture := captureProcessOutput(t, &os.Stdout)
-			err := runModelUpdate("Widget", true, false)
+			err := runModelUpdate("Widget", true, false, nil)
 			_ = capture()
 			if err == nil {
 				t.Fatal("expected error")
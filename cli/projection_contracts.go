@@ -19,6 +19,7 @@ const projectionAnnotationPrefix = "andurel.projection."
 
 func configureProjectionContracts(root *cobra.Command) error {
 	contracts := []projectionSupport{
+		{path: "changes", jq: true},
 		{path: "commands", jq: true},
 		{path: "config init", jq: true},
 		{path: "config set", jq: true},
@@ -47,6 +48,8 @@ func configureProjectionContracts(root *cobra.Command) error {
 		{path: "routes", jq: true, idsOnly: true, count: true},
 		{path: "skill install", jq: true},
 		{path: "skill show", jq: true},
+		{path: "stats", jq: true},
+		{path: "support bundle", jq: true},
 		{path: "tool", jq: true, idsOnly: true, count: true},
 		{path: "tool list", jq: true, idsOnly: true, count: true},
 		{path: "upgrade", jq: true},
@@ -0,0 +1,211 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+type queriesReport struct {
+	Queries []generator.ModelQuery `json:"queries"`
+}
+
+var explainQueryFunc = explainQuery
+
+func newQueriesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queries",
+		Short: "List the read queries of the generated models",
+		Long: `List the read queries of the generated models by the name they are
+logged and traced under, such as User.FindByEmail, with the SQL they run.
+
+Find, the FindBy finders, All, Paginate and PaginateAfter are listed. The
+SQL is built from the migrations, so it matches what bun sends for them.`,
+		Example: `  andurel queries
+  andurel queries --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			queries, err := projectModelQueries(rootDir)
+			if err != nil {
+				return err
+			}
+
+			opts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if opts.Mode == output.ModeHuman {
+				if opts.Quiet {
+					return nil
+				}
+				return renderModelQueriesHuman(cmd.OutOrStdout(), queries)
+			}
+
+			return output.OK(cmd, queriesReport{Queries: queries}, fmt.Sprintf("Listed %d queries", len(queries)))
+		},
+	}
+	setAgentMetadata(cmd, "introspection", "Read-only list of the generated model read queries and their SQL.")
+
+	cmd.AddCommand(newQueriesExplainCommand())
+
+	return cmd
+}
+
+func newQueriesExplainCommand() *cobra.Command {
+	var opts generator.ExplainOptions
+
+	cmd := &cobra.Command{
+		Use:   "explain <QueryName>",
+		Short: "Show the plan Postgres chooses for a model query",
+		Long: `Run EXPLAIN for a generated model query against the database configured
+in .env and print the plan.
+
+The parameters are taken from a row of the table, so the plan matches real
+data. With --analyze the query runs and the plan reports actual rows and
+times. Sequential scans of tables with at least --min-rows rows are
+flagged with a CREATE INDEX statement for the columns the query filters
+on. Add the index in a migration.`,
+		Example: `  andurel queries explain User.FindByEmail
+  andurel queries explain Order.FindByCustomerID --analyze`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.LargeTableRows < 0 {
+				return fmt.Errorf("--min-rows must not be negative")
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			queries, err := projectModelQueries(rootDir)
+			if err != nil {
+				return err
+			}
+			var query *generator.ModelQuery
+			for i := range queries {
+				if queries[i].Name == args[0] {
+					query = &queries[i]
+					break
+				}
+			}
+			if query == nil {
+				return fmt.Errorf("no query named %q\nRun 'andurel queries' to list them", args[0])
+			}
+
+			loadProjectEnv(rootDir)
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			plan, err := explainQueryFunc(ctx, *query, opts)
+			if err != nil {
+				return err
+			}
+
+			outputOpts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if outputOpts.Mode == output.ModeHuman {
+				if outputOpts.Quiet {
+					return nil
+				}
+				return renderQueryPlanHuman(cmd.OutOrStdout(), plan)
+			}
+
+			return output.OK(cmd, plan, fmt.Sprintf("Explained %s", query.Name))
+		},
+	}
+	setAgentMetadata(cmd, "database", "Runs EXPLAIN for a model query against the database configured in .env; --analyze also runs the query, which only reads.")
+
+	cmd.Flags().BoolVar(&opts.Analyze, "analyze", false, "Run the query and report actual rows and times")
+	cmd.Flags().Int64Var(&opts.LargeTableRows, "min-rows", generator.DefaultLargeTableRows, "Flag sequential scans of tables with at least this many rows")
+
+	return cmd
+}
+
+func projectModelQueries(rootDir string) ([]generator.ModelQuery, error) {
+	return generator.ModelQueries(
+		filepath.Join(rootDir, "models"),
+		[]string{filepath.Join(rootDir, "database", "migrations")},
+	)
+}
+
+func explainQuery(ctx context.Context, query generator.ModelQuery, opts generator.ExplainOptions) (*generator.QueryPlan, error) {
+	conn, err := connectProjectDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	return generator.ExplainQuery(ctx, conn, query, opts)
+}
+
+func renderModelQueriesHuman(w io.Writer, queries []generator.ModelQuery) error {
+	if len(queries) == 0 {
+		_, err := fmt.Fprintln(w, "No model queries found")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "QUERY\tSQL"); err != nil {
+		return err
+	}
+	for _, query := range queries {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\n", query.Name, query.SQL); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func renderQueryPlanHuman(w io.Writer, plan *generator.QueryPlan) error {
+	if _, err := fmt.Fprintf(w, "%s\n%s\n", plan.Query.Name, plan.Query.SQL); err != nil {
+		return err
+	}
+	for i, param := range plan.Params {
+		if _, err := fmt.Fprintf(w, "  $%d = %s\n", i+1, param); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "\n%s\n", plan.Plan); err != nil {
+		return err
+	}
+	if plan.ExecutionMS > 0 {
+		if _, err := fmt.Fprintf(w, "Execution Time: %.3f ms\n", plan.ExecutionMS); err != nil {
+			return err
+		}
+	}
+
+	if len(plan.SeqScans) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(w, "\nSequential scans of large tables:"); err != nil {
+		return err
+	}
+	for _, scan := range plan.SeqScans {
+		line := fmt.Sprintf("  %s (~%d rows)", scan.Table, scan.Rows)
+		if scan.Filter != "" {
+			line += " filtered by " + scan.Filter
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if scan.Suggestion != "" {
+			if _, err := fmt.Fprintf(w, "    %s\n", scan.Suggestion); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator"
+)
+
+func TestQueriesExplainRendersPlanAndSuggestions(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	findGoModRoot = func() (string, error) { return root, nil }
+	writeTestFile(t, root, "go.mod", "module example.com/app\n\ngo 1.26\n")
+	writeTestFile(t, root, "models/user.go", "package models\n\ntype UserEntity struct {\n\tbun.BaseModel `bun:\"table:users\"`\n}\n\n"+
+		"func (u user) FindByEmail() { ctx, query := storage.StartQuery(ctx, \"User.FindByEmail\") }\n")
+	writeTestFile(t, root, "database/migrations/00001_create_users.sql", `-- +goose Up
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE
+);
+
+-- +goose Down
+DROP TABLE users;
+`)
+
+	var explained []generator.ModelQuery
+	explainQueryFunc = func(_ context.Context, query generator.ModelQuery, opts generator.ExplainOptions) (*generator.QueryPlan, error) {
+		explained = append(explained, query)
+		if !opts.Analyze || opts.LargeTableRows != 500 {
+			t.Errorf("options = %#v, want analyze with 500 rows", opts)
+		}
+		return &generator.QueryPlan{
+			Query:  query,
+			Params: []string{"'ada@example.com'"},
+			Plan:   "Seq Scan on users  (cost=0.00..25.00 rows=1)\n  Filter: (email = $1)",
+			SeqScans: []generator.SeqScan{{
+				Table:      "users",
+				Rows:       1200,
+				Filter:     "(email = $1)",
+				Suggestion: "CREATE INDEX users_email_idx ON users (email);",
+			}},
+		}, nil
+	}
+
+	run := func(args ...string) (string, error) {
+		var stdout bytes.Buffer
+		cmd := NewRootCommand("test", "test-date")
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return stdout.String(), err
+	}
+
+	stdout, err := run("queries")
+	if err != nil {
+		t.Fatalf("queries failed: %v", err)
+	}
+	if !strings.Contains(stdout, "User.FindByEmail  SELECT * FROM users WHERE email = $1") {
+		t.Fatalf("unexpected query list:\n%s", stdout)
+	}
+
+	stdout, err = run("queries", "explain", "User.FindByEmail", "--analyze", "--min-rows", "500")
+	if err != nil {
+		t.Fatalf("queries explain failed: %v", err)
+	}
+	for _, want := range []string{"$1 = 'ada@example.com'", "Filter: (email = $1)", "users (~1200 rows)", "CREATE INDEX users_email_idx ON users (email);"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("output missing %q:\n%s", want, stdout)
+		}
+	}
+	if len(explained) != 1 || explained[0].Table != "users" {
+		t.Fatalf("explained = %#v", explained)
+	}
+
+	if _, err := run("queries", "explain", "User.FindByName"); err == nil || !strings.Contains(err.Error(), "andurel queries") {
+		t.Fatalf("unknown query error = %v", err)
+	}
+}
@@ -0,0 +1,333 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+type queueStat struct {
+	Queue                  string  `json:"queue"`
+	Available              int64   `json:"available"`
+	Scheduled              int64   `json:"scheduled"`
+	Running                int64   `json:"running"`
+	Retryable              int64   `json:"retryable"`
+	Completed              int64   `json:"completed"`
+	Discarded              int64   `json:"discarded"`
+	Cancelled              int64   `json:"cancelled"`
+	OldestAvailableSeconds float64 `json:"oldest_available_seconds"`
+}
+
+type queueStatsReport struct {
+	Queues []queueStat `json:"queues"`
+}
+
+const queueStatsQuery = `SELECT
+	queue,
+	count(*) FILTER (WHERE state = 'available'),
+	count(*) FILTER (WHERE state = 'scheduled'),
+	count(*) FILTER (WHERE state = 'running'),
+	count(*) FILTER (WHERE state = 'retryable'),
+	count(*) FILTER (WHERE state = 'completed'),
+	count(*) FILTER (WHERE state = 'discarded'),
+	count(*) FILTER (WHERE state = 'cancelled'),
+	coalesce(extract(epoch FROM now() - min(scheduled_at) FILTER (WHERE state = 'available')), 0)::float8
+FROM river_job
+GROUP BY queue
+ORDER BY queue`
+
+// queueRetryQuery moves discarded jobs back to available the way River's
+// JobRetry does, granting one more attempt to jobs that used up theirs.
+const queueRetryQuery = `UPDATE river_job
+SET
+	state = 'available',
+	scheduled_at = now(),
+	finalized_at = NULL,
+	max_attempts = CASE WHEN attempt >= max_attempts THEN attempt + 1 ELSE max_attempts END
+WHERE state = 'discarded'
+	AND finalized_at >= now() - make_interval(secs => $1)
+	AND (cardinality($2::text[]) = 0 OR kind = ANY($2::text[]))
+	AND ($3 = '' OR queue = $3)`
+
+const queueRetryCountQuery = `SELECT count(*)
+FROM river_job
+WHERE state = 'discarded'
+	AND finalized_at >= now() - make_interval(secs => $1)
+	AND (cardinality($2::text[]) = 0 OR kind = ANY($2::text[]))
+	AND ($3 = '' OR queue = $3)`
+
+type queueRetryOptions struct {
+	Kinds  []string      `json:"kinds"`
+	Queue  string        `json:"queue,omitempty"`
+	Since  time.Duration `json:"-"`
+	DryRun bool          `json:"dry_run"`
+}
+
+type queueRetryReport struct {
+	queueRetryOptions
+	Since    string `json:"since"`
+	Requeued int64  `json:"requeued"`
+}
+
+var (
+	fetchQueueStatsFunc    = fetchQueueStats
+	retryDiscardedJobsFunc = retryDiscardedJobs
+)
+
+func newQueueCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue",
+		Short: "Background job queue commands",
+		Long: `Commands for inspecting the River job queues of the database configured
+in .env and requeueing failed jobs.`,
+	}
+	setAgentMetadata(cmd, "database", "River queue inspection and failed job requeueing against the database configured in .env.")
+
+	cmd.AddCommand(newQueueStatsCommand())
+	cmd.AddCommand(newQueueRetryCommand())
+
+	return cmd
+}
+
+func newQueueStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show job counts per queue",
+		Long: `Print the number of jobs in each state per queue, read from River's
+river_job table, and how long the oldest available job has waited.
+
+A growing available count or wait means the queue needs more workers;
+raise its count in queue/queues.go or with QUEUE_WORKERS.`,
+		Example: `  andurel queue stats
+  andurel queue stats --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			loadProjectEnv(rootDir)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			stats, err := fetchQueueStatsFunc(ctx)
+			if err != nil {
+				return err
+			}
+
+			opts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if opts.Mode == output.ModeHuman {
+				if opts.Quiet {
+					return nil
+				}
+				return renderQueueStatsHuman(cmd.OutOrStdout(), stats)
+			}
+
+			return output.OK(cmd, queueStatsReport{Queues: stats}, fmt.Sprintf("Listed %d queues", len(stats)))
+		},
+	}
+	setAgentMetadata(cmd, "database", "Read-only job counts per River queue and state.")
+
+	return cmd
+}
+
+func newQueueRetryCommand() *cobra.Command {
+	var opts queueRetryOptions
+
+	cmd := &cobra.Command{
+		Use:   "retry",
+		Short: "Requeue discarded jobs",
+		Long: `Move jobs River discarded after their last attempt back to available so
+the workers run them again.
+
+Only jobs discarded within --since are requeued. Narrow them down with
+--kind and --queue, and use --dry-run to count them first. Jobs that used
+up their attempts get one more.`,
+		Example: `  andurel queue retry --kind send_transactional_email --since 1h
+  andurel queue retry --queue mailers --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.Since <= 0 {
+				return fmt.Errorf("--since must be greater than zero")
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			loadProjectEnv(rootDir)
+
+			ctx, cancel := context.WithTimeout(cmd.Context(), 30*time.Second)
+			defer cancel()
+
+			requeued, err := retryDiscardedJobsFunc(ctx, opts)
+			if err != nil {
+				return err
+			}
+
+			summary := fmt.Sprintf("Requeued %d discarded jobs", requeued)
+			if opts.DryRun {
+				summary = fmt.Sprintf("Would requeue %d discarded jobs", requeued)
+			}
+
+			outputOpts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if outputOpts.Mode == output.ModeHuman {
+				if outputOpts.Quiet {
+					return nil
+				}
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), summary)
+				return err
+			}
+
+			return output.OK(cmd, queueRetryReport{
+				queueRetryOptions: opts,
+				Since:             opts.Since.String(),
+				Requeued:          requeued,
+			}, summary)
+		},
+	}
+	setAgentMetadata(cmd, "database", "Requeues discarded River jobs; use --dry-run to count them without changes.")
+
+	cmd.Flags().StringSliceVar(&opts.Kinds, "kind", nil, "Only requeue jobs of these kinds")
+	cmd.Flags().StringVar(&opts.Queue, "queue", "", "Only requeue jobs from this queue")
+	cmd.Flags().DurationVar(&opts.Since, "since", 24*time.Hour, "Only requeue jobs discarded within this window")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Count the jobs without requeueing them")
+
+	return cmd
+}
+
+func connectProjectDatabase(ctx context.Context) (*pgx.Conn, error) {
+	return connectNamedDatabase(ctx, "")
+}
+
+// connectNamedDatabase connects to the database configured by the DB_*
+// variables with prefix in front, see loadNamedDatabaseConfig.
+func connectNamedDatabase(ctx context.Context, prefix string) (*pgx.Conn, error) {
+	cfg, err := loadNamedDatabaseConfig(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pgx.Connect(ctx, databaseURL(cfg, cfg.Name))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"connect to database %q on %s failed",
+			cfg.Name,
+			net.JoinHostPort(cfg.Host, cfg.Port),
+		)
+	}
+
+	return conn, nil
+}
+
+func retryDiscardedJobs(ctx context.Context, opts queueRetryOptions) (int64, error) {
+	conn, err := connectProjectDatabase(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close(ctx)
+
+	kinds := opts.Kinds
+	if kinds == nil {
+		kinds = []string{}
+	}
+	args := []any{opts.Since.Seconds(), kinds, opts.Queue}
+
+	if opts.DryRun {
+		var count int64
+		if err := conn.QueryRow(ctx, queueRetryCountQuery, args...).Scan(&count); err != nil {
+			return 0, fmt.Errorf("count discarded jobs: %w", err)
+		}
+		return count, nil
+	}
+
+	tag, err := conn.Exec(ctx, queueRetryQuery, args...)
+	if err != nil {
+		return 0, fmt.Errorf("requeue discarded jobs: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func fetchQueueStats(ctx context.Context) ([]queueStat, error) {
+	conn, err := connectProjectDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	rows, err := conn.Query(ctx, queueStatsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("query river_job: %w", err)
+	}
+
+	stats, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (queueStat, error) {
+		var stat queueStat
+		err := row.Scan(
+			&stat.Queue,
+			&stat.Available,
+			&stat.Scheduled,
+			&stat.Running,
+			&stat.Retryable,
+			&stat.Completed,
+			&stat.Discarded,
+			&stat.Cancelled,
+			&stat.OldestAvailableSeconds,
+		)
+		return stat, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read river_job: %w", err)
+	}
+
+	return stats, nil
+}
+
+func renderQueueStatsHuman(w io.Writer, stats []queueStat) error {
+	if len(stats) == 0 {
+		_, err := fmt.Fprintln(w, "No jobs found.")
+		return err
+	}
+
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(table, "QUEUE\tAVAILABLE\tSCHEDULED\tRUNNING\tRETRYABLE\tCOMPLETED\tDISCARDED\tCANCELLED\tOLDEST WAIT"); err != nil {
+		return err
+	}
+	for _, stat := range stats {
+		wait := "-"
+		if stat.Available > 0 {
+			wait = time.Duration(stat.OldestAvailableSeconds * float64(time.Second)).Round(time.Second).String()
+		}
+		if _, err := fmt.Fprintf(
+			table,
+			"%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			stat.Queue,
+			stat.Available,
+			stat.Scheduled,
+			stat.Running,
+			stat.Retryable,
+			stat.Completed,
+			stat.Discarded,
+			stat.Cancelled,
+			wait,
+		); err != nil {
+			return err
+		}
+	}
+
+	return table.Flush()
+}
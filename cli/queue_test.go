@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueueStatsRendersTableAndJSON(t *testing.T) {
+	resetCLITestSeams(t)
+	fetchQueueStatsFunc = func(context.Context) ([]queueStat, error) {
+		return []queueStat{
+			{Queue: "default", Available: 3, Running: 2, Completed: 40, OldestAvailableSeconds: 75.4},
+			{Queue: "mailers", Scheduled: 1, Discarded: 2},
+		}, nil
+	}
+
+	result := executeCLITest(t, "queue", "stats")
+	if result.err != nil {
+		t.Fatalf("queue stats failed: %v", result.err)
+	}
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "QUEUE") {
+		t.Fatalf("unexpected table:\n%s", result.stdout)
+	}
+	if fields := strings.Fields(lines[1]); fields[0] != "default" || fields[1] != "3" || fields[len(fields)-1] != "1m15s" {
+		t.Fatalf("unexpected default row: %q", lines[1])
+	}
+	if fields := strings.Fields(lines[2]); fields[len(fields)-1] != "-" {
+		t.Fatalf("queue without available jobs should show no wait: %q", lines[2])
+	}
+
+	result = executeCLITest(t, "queue", "stats", "--json")
+	if result.err != nil {
+		t.Fatalf("queue stats --json failed: %v", result.err)
+	}
+	var envelope struct {
+		Data queueStatsReport `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &envelope); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, result.stdout)
+	}
+	if len(envelope.Data.Queues) != 2 || envelope.Data.Queues[1].Discarded != 2 {
+		t.Fatalf("unexpected report: %#v", envelope.Data)
+	}
+}
+
+func TestQueueRetryPassesFiltersAndReportsCount(t *testing.T) {
+	resetCLITestSeams(t)
+	var got []queueRetryOptions
+	retryDiscardedJobsFunc = func(_ context.Context, opts queueRetryOptions) (int64, error) {
+		got = append(got, opts)
+		return 4, nil
+	}
+
+	result := executeCLITest(t, "queue", "retry", "--kind", "send_transactional_email", "--since", "1h")
+	if result.err != nil {
+		t.Fatalf("queue retry failed: %v", result.err)
+	}
+	if strings.TrimSpace(result.stdout) != "Requeued 4 discarded jobs" {
+		t.Fatalf("unexpected output: %q", result.stdout)
+	}
+	want := queueRetryOptions{Kinds: []string{"send_transactional_email"}, Since: time.Hour}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("retry options = %#v, want %#v", got, want)
+	}
+
+	result = executeCLITest(t, "queue", "retry", "--queue", "mailers", "--dry-run", "--json")
+	if result.err != nil {
+		t.Fatalf("queue retry --dry-run failed: %v", result.err)
+	}
+	var envelope struct {
+		Data queueRetryReport `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &envelope); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, result.stdout)
+	}
+	if !envelope.Data.DryRun || envelope.Data.Queue != "mailers" || envelope.Data.Since != "24h0m0s" || envelope.Data.Requeued != 4 {
+		t.Fatalf("unexpected report: %#v", envelope.Data)
+	}
+
+	if result := executeCLITest(t, "queue", "retry", "--since", "0s"); result.err == nil {
+		t.Fatal("expected --since validation error")
+	}
+}
+
+func TestGenerateDeadLetterJobRendersRecipientsAndRegistersPeriodicJob(t *testing.T) {
+	rootDir := setupGenerateFileTestProject(t)
+
+	if err := generateDeadLetterJob(15*time.Minute, []string{"ops@example.com"}); err != nil {
+		t.Fatalf("generateDeadLetterJob failed: %v", err)
+	}
+
+	jobContent := readGeneratedTestFile(t, rootDir, "queue/jobs/dead_letter_report.go")
+	if !strings.Contains(jobContent, "func (DeadLetterReportArgs) Kind() string { return \"dead_letter_report\" }") {
+		t.Fatalf("job file should include kind\n\n%s", jobContent)
+	}
+
+	workerContent := readGeneratedTestFile(t, rootDir, "queue/dead_letter_report.go")
+	for _, want := range []string{
+		"\"example.com/app/email\"",
+		"const deadLetterReportInterval = 15 * time.Minute",
+		"\t\"ops@example.com\",",
+		"WHERE state = 'discarded' AND finalized_at >= $1",
+		"SELECT email FROM users WHERE is_admin",
+	} {
+		if !strings.Contains(workerContent, want) {
+			t.Fatalf("worker file should contain %q\n\n%s", want, workerContent)
+		}
+	}
+
+	workersContent := readGeneratedTestFile(t, rootDir, "queue/workers.go")
+	for _, want := range []string{
+		"NewDeadLetterReportWorker,",
+		"fx.Annotate(NewDeadLetterReportPeriodicJob, fx.ResultTags(periodicJobsGroup)),",
+	} {
+		if !strings.Contains(workersContent, want) {
+			t.Fatalf("workers registration should contain %q\n\n%s", want, workersContent)
+		}
+	}
+}
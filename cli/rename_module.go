@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/module"
+)
+
+func newRenameModuleCommand() *cobra.Command {
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "rename-module NEW_MODULE_PATH",
+		Short: "Rename the project's Go module",
+		Long: `Renames the project's Go module to NEW_MODULE_PATH.
+
+Rewrites the module directive in go.mod and the imports of the module's
+packages in Go and Templ files, compiled *_templ.go files included. The
+import blocks in the examples of the project's README.md are rewritten too,
+and the project name in andurel.lock when it was taken from the old module
+path.
+
+The project is compiled afterwards. If it does not build, the rename is
+rolled back and the compiler errors are shown. Pass --dry-run to see the
+diff without changing anything.`,
+		Example: `  andurel rename-module github.com/acme/shop
+
+      Rewrites "myapp/models" to "github.com/acme/shop/models" and
+      sets the project name in andurel.lock to shop.
+
+  andurel rename-module github.com/acme/shop --dry-run`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return cmd.Help()
+			}
+			if len(args) > 1 {
+				return fmt.Errorf("too many arguments: rename-module takes exactly 1 argument (the new module path)")
+			}
+			newModule := args[0]
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "rename module",
+				Resource: newModule,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "go build ./...", Description: "Build the renamed project"},
+				},
+				Run: func(rootDir string) error {
+					return renameModule(rootDir, newModule)
+				},
+			})
+		},
+	}
+	setAgentMetadata(cmd, "generation", "Rewrites go.mod, import paths, the README examples and andurel.lock. Use --dry-run --diff to preview the rewrite.")
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func renameModule(rootDir, newModule string) error {
+	if err := module.CheckImportPath(newModule); err != nil {
+		return fmt.Errorf("invalid module path %q: %w", newModule, err)
+	}
+	oldModule, err := readModulePathFrom(rootDir)
+	if err != nil {
+		return err
+	}
+	if oldModule == newModule {
+		return fmt.Errorf("the module is already named %s", newModule)
+	}
+
+	goModPath := filepath.Join(rootDir, "go.mod")
+	goMod, err := os.ReadFile(goModPath)
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	directive := regexp.MustCompile(`(?m)^module\s+` + regexp.QuoteMeta(oldModule) + `\s*$`)
+	if err := writeProjectFile(goModPath, directive.ReplaceAllString(string(goMod), "module "+newModule)); err != nil {
+		return err
+	}
+
+	err = filepath.WalkDir(rootDir, func(filePath string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if filePath != rootDir && (skipGoPackageDir(d.Name()) || d.Name() == "node_modules" || d.Name() == "bin") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".go") && !strings.HasSuffix(d.Name(), ".templ") {
+			return nil
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		renamed := renameImports(string(content), oldModule, newModule)
+		if renamed == string(content) {
+			return nil
+		}
+		return writeProjectFile(filePath, renamed)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The README the scaffold writes imports the module's packages in its
+	// examples.
+	readmePath := filepath.Join(rootDir, "README.md")
+	readme, err := os.ReadFile(readmePath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read README.md: %w", err)
+	}
+	if renamed := renameImports(string(readme), oldModule, newModule); err == nil && renamed != string(readme) {
+		if err := writeProjectFile(readmePath, renamed); err != nil {
+			return err
+		}
+	}
+
+	return renameLockedProject(rootDir, oldModule, newModule)
+}
+
+// renameImports rewrites the paths of the old module's packages in the import
+// declarations of a Go or Templ file. Strings elsewhere, such as a struct tag
+// defaulting to the project name, are left alone.
+func renameImports(src, oldModule, newModule string) string {
+	importPath := regexp.MustCompile(`"` + regexp.QuoteMeta(oldModule) + `(/[^"]*)?"`)
+	replacement := `"` + newModule + `$1"`
+
+	lines := strings.Split(src, "\n")
+	inBlock := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case inBlock && strings.HasPrefix(trimmed, ")"):
+			inBlock = false
+		case inBlock:
+			lines[i] = importPath.ReplaceAllString(line, replacement)
+		case strings.HasPrefix(trimmed, "import ("):
+			inBlock = true
+		case strings.HasPrefix(trimmed, "import "):
+			lines[i] = importPath.ReplaceAllString(line, replacement)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renameLockedProject renames the project in andurel.lock, which new
+// projects take from the last element of their module path.
+func renameLockedProject(rootDir, oldModule, newModule string) error {
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if lock.ScaffoldConfig == nil || lock.ScaffoldConfig.ProjectName != path.Base(oldModule) {
+		return nil
+	}
+	lock.ScaffoldConfig.ProjectName = path.Base(newModule)
+	return lock.WriteLockFile(rootDir)
+}
+
+func writeProjectFile(filePath, content string) error {
+	if err := os.WriteFile(filePath, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return nil
+}
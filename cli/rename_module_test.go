@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenameModuleRewritesImportsConfigAndLock(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module myapp\n\ngo 1.26\n\nrequire github.com/google/uuid v1.6.0\n")
+	writeTestFile(t, root, "andurel.lock", `{
+  "schemaVersion": 1,
+  "version": "test",
+  "tools": {},
+  "scaffoldConfig": {
+    "projectName": "myapp",
+    "database": "postgresql"
+  }
+}
+`)
+	writeTestFile(t, root, "cmd/app/main.go", `package main
+
+import "myapp/config"
+
+func main() { config.Load() }
+`)
+	writeTestFile(t, root, "config/telemetry.go", `package config
+
+import (
+	"myapp/internal/server"
+	srv "myapp/internal/server/v2"
+	"myappextra/pkg"
+)
+
+type telemetry struct {
+	ServiceName string `+"`"+`env:"TELEMETRY_SERVICE_NAME" envDefault:"myapp"`+"`"+`
+}
+`)
+	writeTestFile(t, root, "views/home.templ", "package views\n\nimport \"myapp/router/routes\"\n\ntempl Home() {\n\t<a href={ routes.HomePage.URL() }>myapp</a>\n}\n")
+	writeTestFile(t, root, "README.md", "# myapp\n\n```go\nimport (\n    \"myapp/queue/jobs\"\n)\n```\n\nRun myapp with `go run ./cmd/app`.\n")
+	writeTestFile(t, root, "node_modules/pkg/index.go", "package pkg\n\nimport \"myapp/models\"\n")
+
+	if err := renameModule(root, "github.com/acme/shop"); err != nil {
+		t.Fatalf("renameModule failed: %v", err)
+	}
+
+	for path, wants := range map[string][]string{
+		"go.mod":                    {"module github.com/acme/shop\n", "require github.com/google/uuid v1.6.0"},
+		"andurel.lock":              {`"projectName": "shop"`},
+		"cmd/app/main.go":           {`import "github.com/acme/shop/config"`},
+		"config/telemetry.go":       {`"github.com/acme/shop/internal/server"`, `srv "github.com/acme/shop/internal/server/v2"`, `"myappextra/pkg"`, `envDefault:"myapp"`},
+		"views/home.templ":          {`import "github.com/acme/shop/router/routes"`, ">myapp</a>"},
+		"README.md":                 {"# myapp\n", `"github.com/acme/shop/queue/jobs"`, "Run myapp with"},
+		"node_modules/pkg/index.go": {`import "myapp/models"`},
+	} {
+		content := readGeneratedTestFile(t, root, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+
+	if err := renameModule(root, "github.com/acme/shop"); err == nil || !strings.Contains(err.Error(), "already named") {
+		t.Fatalf("expected already named error, got %v", err)
+	}
+	if err := renameModule(root, "github.com/acme/shop store"); err == nil || !strings.Contains(err.Error(), "invalid module path") {
+		t.Fatalf("expected invalid module path error, got %v", err)
+	}
+}
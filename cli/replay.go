@@ -0,0 +1,244 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+// replayHeader marks replayed requests so the request recorder does not
+// record them again.
+const replayHeader = "X-Andurel-Replay"
+
+// replaySkippedHeaders are set by the HTTP client for the new connection
+// instead of copied from the recording.
+var replaySkippedHeaders = map[string]bool{
+	"accept-encoding":   true,
+	"connection":        true,
+	"content-length":    true,
+	"host":              true,
+	"keep-alive":        true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+type harFile struct {
+	Log struct {
+		Entries []harEntry `json:"entries"`
+	} `json:"log"`
+}
+
+type harEntry struct {
+	Request struct {
+		Method   string         `json:"method"`
+		URL      string         `json:"url"`
+		Headers  []harNameValue `json:"headers"`
+		BodySize int            `json:"bodySize"`
+		PostData *struct {
+			Text     string `json:"text"`
+			Encoding string `json:"_encoding"`
+		} `json:"postData"`
+	} `json:"request"`
+	Response struct {
+		Status int `json:"status"`
+	} `json:"response"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type replayResult struct {
+	File           string `json:"file"`
+	Method         string `json:"method"`
+	URL            string `json:"url"`
+	RecordedStatus int    `json:"recorded_status,omitempty"`
+	Status         int    `json:"status"`
+	Duration       string `json:"duration"`
+	Truncated      bool   `json:"truncated,omitempty"`
+}
+
+func newReplayCommand() *cobra.Command {
+	var baseURL string
+
+	cmd := &cobra.Command{
+		Use:   "replay FILE...",
+		Short: "Send recorded requests to the local server again",
+		Long: `Send the requests in HAR files to the local server again, with their
+recorded method, path, query, headers, and body.
+
+Recordings come from the middleware added by 'andurel generate
+request-recorder', which writes them to tmp/requests, but HAR exports from a
+browser work too. Requests go to --url, which defaults to localhost on the
+PORT in .env. Redirects are not followed, so each line shows the status the
+server answered with next to the recorded one. Session cookies and CSRF tokens
+are sent as recorded and only work while that session is valid.`,
+		Example: `  andurel replay tmp/requests/20261018T101502.123456-post-webhooks_stripe.har
+  andurel replay tmp/requests/*.har --url http://localhost:3000`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if baseURL == "" {
+				rootDir, err := findGoModRoot()
+				if err != nil {
+					return err
+				}
+				loadProjectEnv(rootDir)
+				port := os.Getenv("PORT")
+				if port == "" {
+					port = "8080"
+				}
+				baseURL = "http://localhost:" + port
+			}
+
+			results, err := replayRequests(cmd.Context(), baseURL, args)
+			if err != nil {
+				return err
+			}
+
+			opts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if opts.Mode == output.ModeHuman {
+				if opts.Quiet {
+					return nil
+				}
+				return renderReplayResultsHuman(cmd.OutOrStdout(), results)
+			}
+			return output.OK(cmd, results, fmt.Sprintf("Replayed %d requests", len(results)))
+		},
+	}
+	setAgentMetadata(cmd, "development", "Sends recorded HAR requests to the running local server; needs 'andurel run' in another terminal.")
+
+	cmd.Flags().StringVar(&baseURL, "url", "", "Base URL of the server (default http://localhost:$PORT)")
+
+	return cmd
+}
+
+func replayRequests(ctx context.Context, baseURL string, paths []string) ([]replayResult, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("--url %q must be an absolute URL such as http://localhost:8080", baseURL)
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	var results []replayResult
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var har harFile
+		if err := json.Unmarshal(content, &har); err != nil {
+			return nil, fmt.Errorf("%s is not a HAR file: %w", path, err)
+		}
+		if len(har.Log.Entries) == 0 {
+			return nil, fmt.Errorf("%s has no recorded requests", path)
+		}
+
+		for _, entry := range har.Log.Entries {
+			result, err := replayEntry(ctx, client, base, filepath.Base(path), entry)
+			if err != nil {
+				return nil, err
+			}
+			result.File = path
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func replayEntry(ctx context.Context, client *http.Client, base *url.URL, name string, entry harEntry) (replayResult, error) {
+	recorded, err := url.Parse(entry.Request.URL)
+	if err != nil {
+		return replayResult{}, fmt.Errorf("recorded URL %q: %w", entry.Request.URL, err)
+	}
+	target := *base
+	target.Path = strings.TrimSuffix(base.Path, "/") + recorded.Path
+	target.RawPath = ""
+	target.RawQuery = recorded.RawQuery
+
+	var body io.Reader
+	if data := entry.Request.PostData; data != nil {
+		raw := []byte(data.Text)
+		if data.Encoding == "base64" {
+			if raw, err = base64.StdEncoding.DecodeString(data.Text); err != nil {
+				return replayResult{}, fmt.Errorf("decode recorded body of %s: %w", name, err)
+			}
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, entry.Request.Method, target.String(), body)
+	if err != nil {
+		return replayResult{}, err
+	}
+	for _, header := range entry.Request.Headers {
+		if replaySkippedHeaders[strings.ToLower(header.Name)] || strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		req.Header.Add(header.Name, header.Value)
+	}
+	req.Header.Set(replayHeader, name)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) && !urlErr.Timeout() {
+			return replayResult{}, fmt.Errorf("%w; is the server running? Start it with 'andurel run'", err)
+		}
+		return replayResult{}, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return replayResult{
+		Method:         req.Method,
+		URL:            target.String(),
+		RecordedStatus: entry.Response.Status,
+		Status:         resp.StatusCode,
+		Duration:       time.Since(start).Round(time.Millisecond).String(),
+		Truncated:      entry.Request.BodySize == -1,
+	}, nil
+}
+
+func renderReplayResultsHuman(w io.Writer, results []replayResult) error {
+	for _, result := range results {
+		line := fmt.Sprintf("%s %s -> %d", result.Method, result.URL, result.Status)
+		if result.RecordedStatus != 0 {
+			line += fmt.Sprintf(" (recorded %d)", result.RecordedStatus)
+		}
+		line += " in " + result.Duration
+		if result.Truncated {
+			line += ", body was truncated when recorded"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
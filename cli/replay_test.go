@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const recordedWebhookHAR = `{
+  "log": {
+    "version": "1.2",
+    "entries": [
+      {
+        "request": {
+          "method": "POST",
+          "url": "http://localhost:8080/webhooks/stripe?attempt=2",
+          "headers": [
+            {"name": "Host", "value": "localhost:8080"},
+            {"name": "Content-Length", "value": "17"},
+            {"name": "Content-Type", "value": "application/json"},
+            {"name": "Stripe-Signature", "value": "t=1,v1=abc"}
+          ],
+          "bodySize": 17,
+          "postData": {"mimeType": "application/json", "text": "{\"type\":\"paid\"}\n"}
+        },
+        "response": {"status": 500}
+      },
+      {
+        "request": {
+          "method": "PUT",
+          "url": "http://localhost:8080/uploads",
+          "headers": [],
+          "bodySize": -1,
+          "postData": {"mimeType": "application/octet-stream", "text": "/wA=", "_encoding": "base64"}
+        },
+        "response": {"status": 201}
+      }
+    ]
+  }
+}`
+
+func TestReplayRequestsSendsRecordedRequests(t *testing.T) {
+	type received struct {
+		method, uri, signature, contentType, replay string
+		body                                        []byte
+	}
+	var requests []received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, received{
+			method:      r.Method,
+			uri:         r.URL.RequestURI(),
+			signature:   r.Header.Get("Stripe-Signature"),
+			contentType: r.Header.Get("Content-Type"),
+			replay:      r.Header.Get(replayHeader),
+			body:        body,
+		})
+		http.Redirect(w, r, "/done", http.StatusSeeOther)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "webhook.har")
+	writeTestFile(t, filepath.Dir(path), "webhook.har", recordedWebhookHAR)
+
+	results, err := replayRequests(t.Context(), server.URL, []string{path})
+	if err != nil {
+		t.Fatalf("replayRequests failed: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("server received %d requests, want 2", len(requests))
+	}
+	webhook := requests[0]
+	if webhook.method != http.MethodPost || webhook.uri != "/webhooks/stripe?attempt=2" || webhook.signature != "t=1,v1=abc" ||
+		webhook.contentType != "application/json" || webhook.replay != "webhook.har" || string(webhook.body) != "{\"type\":\"paid\"}\n" {
+		t.Fatalf("unexpected replayed webhook: %+v", webhook)
+	}
+	if upload := requests[1]; upload.method != http.MethodPut || !bytes.Equal(upload.body, []byte{0xff, 0x00}) {
+		t.Fatalf("unexpected replayed upload: %+v", upload)
+	}
+
+	if results[0].Status != http.StatusSeeOther || results[0].RecordedStatus != http.StatusInternalServerError || results[0].File != path {
+		t.Fatalf("unexpected webhook result: %+v", results[0])
+	}
+	if !results[1].Truncated {
+		t.Fatalf("upload result should be marked truncated: %+v", results[1])
+	}
+
+	var out strings.Builder
+	if err := renderReplayResultsHuman(&out, results); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if want := "POST " + server.URL + "/webhooks/stripe?attempt=2 -> 303 (recorded 500) in "; !strings.HasPrefix(out.String(), want) {
+		t.Fatalf("human output = %q, want prefix %q", out.String(), want)
+	}
+}
+
+func TestReplayRequestsRejectsInvalidInput(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir, "empty.har", `{"log":{"entries":[]}}`)
+	writeTestFile(t, dir, "notes.txt", "not json")
+
+	for _, tt := range []struct {
+		url, file, want string
+	}{
+		{"localhost:8080", "empty.har", "must be an absolute URL"},
+		{"http://localhost:8080", "empty.har", "has no recorded requests"},
+		{"http://localhost:8080", "notes.txt", "is not a HAR file"},
+	} {
+		_, err := replayRequests(t.Context(), tt.url, []string{filepath.Join(dir, tt.file)})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("replayRequests(%q, %s) error = %v, want %q", tt.url, tt.file, err, tt.want)
+		}
+	}
+}
@@ -1,16 +1,34 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/mbvlabs/andurel/generator"
+	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/layout/upgrade"
 )
 
 type cliGenerator interface {
 	GenerateModel(resourceName string, tableNameOverride string, skipFactory bool) error
 	GenerateModelWithPK(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string) error
+	GenerateModelWithAssociations(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations) error
+	GenerateModelWithJSONTypes(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations, jsonTypes map[string]string) error
 	GenerateControllerWithActions(resourceName, namespace, tableName string, actions []string, inertia string, isAPI bool) error
 	GenerateControllerWithActionsForModel(resourceName, namespace, modelName, tableName string, actions []string, inertia string, isAPI bool) error
 	GenerateScaffold(resourceName, namespace, tableName string, skipFactory bool, primaryKeyColumn string, inertia string, isAPI bool) error
+	GenerateFilters(resourceName, namespace, tableName string, columns []string) error
+	GenerateShare(resourceName, tableName string, ttl time.Duration) error
+	GenerateCalendar(resourceName, tableName string, columns generator.CalendarColumns) error
+	GenerateFeed(resourceName, namespace, tableName string) error
+	GenerateAddress(resourceName, tableName string) error
+	GenerateSerializer(resourceName string, opts generator.SerializerOptions) error
+	DestroyResource(resourceName, namespace string) error
+	SetNullablePointers(enabled bool)
+	SetUpsertConflictColumns(columns []string)
+	SetFromView(enabled bool)
+	SetWithTests(enabled bool)
+	SetDatabaseName(name string) error
+	SetSchemaDatabase(db generator.SchemaQuerier)
 	UpdateModel(resourceName string) (*generator.UpdateModelResult, error)
 	ApplyModelUpdate(result *generator.UpdateModelResult) error
 	SyncFactory(resourceName string, opts generator.FactorySyncOptions) (*generator.FactorySyncResult, error)
@@ -26,6 +44,7 @@ var newGenerator = func() (cliGenerator, error) {
 }
 
 var runModelUpdateFunc = runModelUpdate
+var connectSchemaDatabaseFunc = connectSchemaDatabase
 var runTemplFunc = runTempl
 var runFmtFunc = runFmt
 var runGoFmtFunc = runGoFmt
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/mbvlabs/andurel/cli/output"
+)
+
+var (
+	verifyGeneratedCodeFunc = verifyGeneratedCode
+	verifyProjectBuildsFunc = verifyProjectBuilds
+)
+
+// mutationInterrupts delivers the interrupt and termination signals the
+// process receives until stop is called.
+var mutationInterrupts = func() (signals <-chan os.Signal, stop func()) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	return ch, func() { signal.Stop(ch) }
+}
+
+var errMutationInterrupted = errors.New("interrupted")
+
+// applyMutation runs run against the files under root and returns snapshots
+// of them from before and after the run. When run fails, or the process is
+// interrupted while it runs, every change is rolled back and the returned
+// error lists the files that were put back. The first interrupt lets the
+// current step finish, so the rollback starts from files nothing is still
+// writing; a second one quits without rolling back.
+func applyMutation(root string, stderr io.Writer, run func() error) (fileSnapshot, fileSnapshot, error) {
+	before, err := snapshotFilesForReport(root)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	signals, stop := mutationInterrupts()
+	var interrupted atomic.Bool
+	done := make(chan struct{})
+	watched := make(chan struct{})
+	go func() {
+		defer close(watched)
+		select {
+		case <-signals:
+		case <-done:
+			// A signal that arrived as the run finished still counts.
+			select {
+			case <-signals:
+			default:
+				return
+			}
+		}
+		interrupted.Store(true)
+		stop()
+		fmt.Fprintln(stderr, "Interrupted; rolling back once the current step stops. Interrupt again to quit without rolling back.")
+	}()
+	runErr := run()
+	close(done)
+	<-watched
+	stop()
+
+	after, err := snapshotFilesForReport(root)
+	if err != nil {
+		return nil, nil, errors.Join(runErr, err)
+	}
+	if interrupted.Load() {
+		if runErr != nil {
+			runErr = fmt.Errorf("%w: %w", errMutationInterrupted, runErr)
+		} else {
+			runErr = errMutationInterrupted
+		}
+	}
+	if runErr != nil {
+		return nil, nil, rollbackFailedMutation(root, before, after, runErr)
+	}
+
+	return before, after, nil
+}
+
+// verifyGeneratedCode compiles the packages of the Go files in touched, which
+// are slash-separated paths relative to rootDir. It returns the compiler
+// errors reported in touched files. Errors only in other files leave the
+// generated code unproven rather than broken, so they are returned as err
+// and the changes are kept.
+func verifyGeneratedCode(rootDir string, touched []string) ([]string, error) {
+	touchedFiles := make(map[string]bool, len(touched))
+	packageSet := make(map[string]bool)
+	for _, relPath := range touched {
+		if !strings.HasSuffix(relPath, ".go") {
+			continue
+		}
+		touchedFiles[relPath] = true
+		packageSet["./"+path.Dir(relPath)] = true
+	}
+	if len(packageSet) == 0 {
+		return nil, nil
+	}
+	packages := make([]string, 0, len(packageSet))
+	for pkg := range packageSet {
+		packages = append(packages, pkg)
+	}
+	sort.Strings(packages)
+
+	cmd := exec.Command("go", append([]string{"build", "-o", os.DevNull}, packages...)...)
+	cmd.Dir = rootDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err == nil {
+		return nil, nil
+	}
+
+	var diagnostics []string
+	for line := range strings.SplitSeq(stderr.String(), "\n") {
+		file, _, found := strings.Cut(strings.TrimPrefix(strings.TrimSpace(line), "./"), ":")
+		if found && touchedFiles[filepath.ToSlash(file)] {
+			diagnostics = append(diagnostics, strings.TrimSpace(line))
+		}
+	}
+	if len(diagnostics) > 0 {
+		return diagnostics, nil
+	}
+	return nil, fmt.Errorf("go build %s failed outside the generated files: %s", strings.Join(packages, " "), strings.TrimSpace(stderr.String()))
+}
+
+// verifyProjectBuilds compiles every package under rootDir and returns the
+// compiler errors. Removing code can break any package that used it, so
+// unlike verifyGeneratedCode every error counts. An err means the build could
+// not run at all.
+func verifyProjectBuilds(rootDir string) ([]string, error) {
+	cmd := exec.Command("go", "build", "-o", os.DevNull, "./...")
+	cmd.Dir = rootDir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	if err == nil {
+		return nil, nil
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return nil, fmt.Errorf("go build ./... did not run: %w", err)
+	}
+
+	var diagnostics []string
+	for line := range strings.SplitSeq(stderr.String(), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "# ") {
+			continue
+		}
+		diagnostics = append(diagnostics, strings.TrimPrefix(line, "./"))
+	}
+	if len(diagnostics) == 0 {
+		diagnostics = []string{"go build ./... failed: " + exitErr.Error()}
+	}
+	return diagnostics, nil
+}
+
+// rollbackMutation restores the files under root that changed between the
+// before and after snapshots: created files are removed, and updated and
+// deleted files get their previous content back. It returns the restored and
+// removed paths, and the paths it could not roll back.
+func rollbackMutation(root string, before, after fileSnapshot) ([]string, []string, []string) {
+	var restored, removed, failures []string
+	for relPath, afterState := range after {
+		beforeState, existed := before[relPath]
+		fullPath := filepath.Join(root, filepath.FromSlash(relPath))
+		switch {
+		case !existed:
+			if err := os.Remove(fullPath); err != nil {
+				failures = append(failures, fmt.Sprintf("%s (%v)", relPath, err))
+				continue
+			}
+			removed = append(removed, relPath)
+			removeCreatedDirs(root, path.Dir(relPath), before)
+		case beforeState.Hash != afterState.Hash:
+			if err := writeFileAtomic(fullPath, beforeState.Content, beforeState.Mode); err != nil {
+				failures = append(failures, fmt.Sprintf("%s (%v)", relPath, err))
+				continue
+			}
+			restored = append(restored, relPath)
+		}
+	}
+	for relPath, beforeState := range before {
+		if _, exists := after[relPath]; exists {
+			continue
+		}
+		fullPath := filepath.Join(root, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%v)", relPath, err))
+			continue
+		}
+		if err := writeFileAtomic(fullPath, beforeState.Content, beforeState.Mode); err != nil {
+			failures = append(failures, fmt.Sprintf("%s (%v)", relPath, err))
+			continue
+		}
+		restored = append(restored, relPath)
+	}
+
+	sort.Strings(restored)
+	sort.Strings(removed)
+	sort.Strings(failures)
+	return restored, removed, failures
+}
+
+// removeCreatedDirs removes dir and then its parents for as long as they are
+// empty and held no file before the run, which takes away the directories a
+// generator made for the files it created.
+func removeCreatedDirs(root, dir string, before fileSnapshot) {
+	for dir != "." && !snapshotHasDir(before, dir) {
+		if err := os.Remove(filepath.Join(root, filepath.FromSlash(dir))); err != nil {
+			return
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+func snapshotHasDir(snapshot fileSnapshot, dir string) bool {
+	for relPath := range snapshot {
+		if strings.HasPrefix(relPath, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFileAtomic writes content to a temporary file next to path and moves
+// it into place, so an interrupted rollback never leaves a truncated file.
+func writeFileAtomic(path string, content []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".andurel-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	_, writeErr := tmp.Write(content)
+	closeErr := tmp.Close()
+	if err := errors.Join(writeErr, closeErr, os.Chmod(tmpPath, mode.Perm())); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// formatRollback appends what rollbackMutation did to message.
+func formatRollback(message string, restored, removed, failures []string) string {
+	var msg strings.Builder
+	msg.WriteString(message)
+	if len(restored) > 0 {
+		fmt.Fprintf(&msg, "\nRestored %d modified file(s):", len(restored))
+		msg.WriteString(formatPathList(restored, 12))
+	}
+	if len(removed) > 0 {
+		fmt.Fprintf(&msg, "\nRemoved %d created file(s):", len(removed))
+		msg.WriteString(formatPathList(removed, 12))
+	}
+	if len(failures) > 0 {
+		fmt.Fprintf(&msg, "\nCould not roll back %d file(s):", len(failures))
+		msg.WriteString(formatPathList(failures, 12))
+		msg.WriteString("\nRestore these files from version control.")
+	}
+	return msg.String()
+}
+
+// rollbackFailedMutation undoes the changes a failed generator left behind
+// and adds the files it restored to runErr.
+func rollbackFailedMutation(root string, before, after fileSnapshot, runErr error) error {
+	restored, removed, failures := rollbackMutation(root, before, after)
+	if len(restored) == 0 && len(removed) == 0 && len(failures) == 0 {
+		return runErr
+	}
+	return fmt.Errorf("%w%s", runErr, formatRollback("\n", restored, removed, failures))
+}
+
+// rollbackUncompilableMutation undoes a generator run whose output does not
+// compile and reports the compiler errors.
+func rollbackUncompilableMutation(root string, before, after fileSnapshot, diagnostics []string) error {
+	return rollbackWithDiagnostics(
+		root, before, after, diagnostics,
+		"generated code does not compile, so the changes were rolled back:",
+		"Fix the migration or the code the errors point at, then run the generator again.",
+	)
+}
+
+// rollbackBrokenProjectMutation undoes a run that removed code the rest of
+// the project still uses and reports the compiler errors.
+func rollbackBrokenProjectMutation(root string, before, after fileSnapshot, diagnostics []string) error {
+	return rollbackWithDiagnostics(
+		root, before, after, diagnostics,
+		"the project no longer compiles, so the changes were rolled back:",
+		"Remove the uses of the code the errors point at, then run the command again.",
+	)
+}
+
+func rollbackWithDiagnostics(root string, before, after fileSnapshot, diagnostics []string, heading, hint string) error {
+	restored, removed, failures := rollbackMutation(root, before, after)
+
+	var msg strings.Builder
+	msg.WriteString(heading)
+	msg.WriteString(formatPathList(diagnostics, 20))
+	msg.WriteString("\n")
+	return output.NewError(
+		output.CodeGenerationFailed,
+		formatRollback(msg.String(), restored, removed, failures),
+		output.ExitGeneration,
+		hint,
+	)
+}
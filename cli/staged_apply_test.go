@@ -0,0 +1,188 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/spf13/cobra"
+)
+
+func TestRunMutationRollsBackWhenGeneratedCodeDoesNotCompile(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "router/routes/routes.go", "old routes\n")
+	writeTestFile(t, root, "controllers/pages.go", "removed by generator\n")
+
+	var verified []string
+	verifyGeneratedCodeFunc = func(rootDir string, touched []string) ([]string, error) {
+		verified = touched
+		return []string{"models/post.go:3:2: undefined: Post"}, nil
+	}
+
+	cmd := &cobra.Command{Use: "andurel"}
+	output.RegisterPersistentFlags(cmd)
+	err := runMutation(cmd, mutationOptions{
+		Action:  "generate scaffold",
+		RootDir: root,
+		Run: func(rootDir string) error {
+			writeTestFile(t, rootDir, "models/post.go", "package models\n")
+			writeTestFile(t, rootDir, "router/routes/routes.go", "new routes\n")
+			return os.Remove(filepath.Join(rootDir, "controllers", "pages.go"))
+		},
+	})
+
+	var cliErr *output.CLIError
+	if !errors.As(err, &cliErr) || cliErr.Code != output.CodeGenerationFailed {
+		t.Fatalf("runMutation error = %v, want %s", err, output.CodeGenerationFailed)
+	}
+	for _, want := range []string{
+		"models/post.go:3:2: undefined: Post",
+		"Restored 2 modified file(s):",
+		"router/routes/routes.go",
+		"controllers/pages.go",
+		"Removed 1 created file(s):",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %q:\n%s", want, err)
+		}
+	}
+	if strings.Join(verified, ",") != "models/post.go,router/routes/routes.go" {
+		t.Errorf("verified files = %v", verified)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "models", "post.go")); !os.IsNotExist(err) {
+		t.Errorf("created model was not removed, stat err: %v", err)
+	}
+	assertFileContent(t, filepath.Join(root, "router", "routes", "routes.go"), "old routes\n")
+	assertFileContent(t, filepath.Join(root, "controllers", "pages.go"), "removed by generator\n")
+}
+
+func TestRunMutationRestoresModifiedFilesWhenGenerationFails(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "router/routes/routes.go", "old routes\n")
+
+	cmd := &cobra.Command{Use: "andurel"}
+	output.RegisterPersistentFlags(cmd)
+	err := runMutation(cmd, mutationOptions{
+		Action:  "generate scaffold",
+		RootDir: root,
+		Run: func(rootDir string) error {
+			writeTestFile(t, rootDir, "router/routes/routes.go", "half-applied routes\n")
+			return errors.New("render view: template failed")
+		},
+	})
+	if err == nil {
+		t.Fatal("expected generation failure")
+	}
+	if !strings.HasPrefix(err.Error(), "render view: template failed") ||
+		!strings.Contains(err.Error(), "Restored 1 modified file(s):\n  - router/routes/routes.go") {
+		t.Fatalf("unexpected error:\n%s", err)
+	}
+	assertFileContent(t, filepath.Join(root, "router", "routes", "routes.go"), "old routes\n")
+}
+
+func TestRunMutationRollsBackWhenInterrupted(t *testing.T) {
+	resetCLITestSeams(t)
+	signals := make(chan os.Signal, 1)
+	mutationInterrupts = func() (<-chan os.Signal, func()) { return signals, func() {} }
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n")
+	writeTestFile(t, root, "router/routes/routes.go", "old routes\n")
+
+	cmd := &cobra.Command{Use: "andurel"}
+	output.RegisterPersistentFlags(cmd)
+	var stderr strings.Builder
+	cmd.SetErr(&stderr)
+	err := runMutation(cmd, mutationOptions{
+		Action:  "generate scaffold",
+		RootDir: root,
+		Run: func(rootDir string) error {
+			writeTestFile(t, rootDir, "router/routes/routes.go", "half-applied routes\n")
+			writeTestFile(t, rootDir, "views/posts/index.templ", "package posts\n")
+			signals <- os.Interrupt
+			return nil
+		},
+	})
+	if !errors.Is(err, errMutationInterrupted) {
+		t.Fatalf("err = %v, want interrupted", err)
+	}
+	if !strings.Contains(err.Error(), "Removed 1 created file(s):\n  - views/posts/index.templ") {
+		t.Fatalf("unexpected error:\n%s", err)
+	}
+	if !strings.Contains(stderr.String(), "Interrupted; rolling back") {
+		t.Fatalf("stderr = %q", stderr.String())
+	}
+	assertFileContent(t, filepath.Join(root, "router", "routes", "routes.go"), "old routes\n")
+	if _, err := os.Stat(filepath.Join(root, "views")); !os.IsNotExist(err) {
+		t.Fatalf("created views directory should be removed, stat err = %v", err)
+	}
+}
+
+func TestRollbackMutationKeepsDirectoriesThatHeldFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "views/home.templ", "package views\n")
+	before, err := snapshotFilesForReport(root)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	writeTestFile(t, root, "views/posts/index.templ", "package posts\n")
+	writeTestFile(t, root, "models/post.go", "package models\n")
+	after, err := snapshotFilesForReport(root)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+
+	_, removed, failures := rollbackMutation(root, before, after)
+	if len(removed) != 2 || len(failures) != 0 {
+		t.Fatalf("removed = %q, failures = %q", removed, failures)
+	}
+	for _, dir := range []string{"views/posts", "models"} {
+		if _, err := os.Stat(filepath.Join(root, dir)); !os.IsNotExist(err) {
+			t.Fatalf("%s should be removed, stat err = %v", dir, err)
+		}
+	}
+	assertFileContent(t, filepath.Join(root, "views", "home.templ"), "package views\n")
+}
+
+func TestVerifyGeneratedCodeReportsErrorsInTouchedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n\ngo 1.22\n")
+	writeTestFile(t, root, "models/post.go", "package models\n\nvar Post = undefinedName\n")
+	writeTestFile(t, root, "models/user.go", "package models\n\nvar User = 1\n")
+
+	diagnostics, err := verifyGeneratedCode(root, []string{"models/post.go", "views/posts.templ"})
+	if err != nil {
+		t.Fatalf("verifyGeneratedCode: %v", err)
+	}
+	if len(diagnostics) != 1 || !strings.HasPrefix(diagnostics[0], "models/post.go:3:12: undefined: undefinedName") {
+		t.Fatalf("diagnostics = %q", diagnostics)
+	}
+
+	diagnostics, err = verifyGeneratedCode(root, []string{"models/user.go"})
+	if len(diagnostics) != 0 || err == nil {
+		t.Fatalf("errors outside touched files = %q, %v; want a warning only", diagnostics, err)
+	}
+
+	diagnostics, err = verifyGeneratedCode(root, []string{"views/posts.templ"})
+	if diagnostics != nil || err != nil {
+		t.Fatalf("no Go files touched = %q, %v", diagnostics, err)
+	}
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if string(content) != want {
+		t.Fatalf("%s = %q, want %q", path, content, want)
+	}
+}
@@ -0,0 +1,276 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/generator"
+	"github.com/spf13/cobra"
+)
+
+type projectStats struct {
+	Models      int                    `json:"models"`
+	Controllers int                    `json:"controllers"`
+	Views       int                    `json:"views"`
+	Routes      int                    `json:"routes"`
+	Migrations  int                    `json:"migrations"`
+	Jobs        int                    `json:"jobs"`
+	Generated   codeStats              `json:"generated"`
+	HandWritten codeStats              `json:"hand_written"`
+	Schema      *generator.SchemaStats `json:"schema,omitempty"`
+	Warnings    []string               `json:"warnings,omitempty"`
+}
+
+type codeStats struct {
+	Files int `json:"files"`
+	Lines int `json:"lines"`
+}
+
+// generatedCodePattern is the header Go tools use to mark generated files,
+// see https://go.dev/s/generatedcode.
+var generatedCodePattern = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+var packageClausePattern = regexp.MustCompile(`(?m)^package `)
+
+// statsSkippedDirs are not walked when counting lines of code.
+var statsSkippedDirs = map[string]bool{
+	"bin":          true,
+	"node_modules": true,
+	"tmp":          true,
+	"vendor":       true,
+}
+
+func newStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show project metrics",
+		Long: `Show project metrics for audits and reports.
+
+Counts models, controllers, views, routes, migrations and jobs, the lines of
+generated and hand-written Go and templ code, and the tables, columns and
+enums the migrations define. Files with a "// Code generated ... DO NOT
+EDIT." header, such as templ output and the framework files, count as
+generated.`,
+		Example: `  andurel stats
+  andurel stats --json
+  andurel stats --jq .schema`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+			stats, err := collectProjectStats(rootDir)
+			if err != nil {
+				return err
+			}
+			opts, err := output.ParseOptions(cmd)
+			if err != nil {
+				return err
+			}
+			if opts.Mode == output.ModeHuman {
+				if opts.Quiet {
+					return nil
+				}
+				return renderProjectStatsHuman(cmd.OutOrStdout(), stats)
+			}
+			return output.OK(cmd, stats, "Project stats collected")
+		},
+	}
+	setAgentMetadata(cmd, "introspection", "Read-only project metrics: resource counts, generated vs hand-written lines, and schema size.")
+	return cmd
+}
+
+func collectProjectStats(rootDir string) (projectStats, error) {
+	var stats projectStats
+	var err error
+
+	if stats.Models, err = countModels(rootDir); err != nil {
+		return projectStats{}, err
+	}
+	if stats.Controllers, err = countProjectFiles(rootDir, "controllers", ".go"); err != nil {
+		return projectStats{}, err
+	}
+	if stats.Views, err = countProjectFiles(rootDir, "views", ".templ"); err != nil {
+		return projectStats{}, err
+	}
+	migrationsDir := filepath.Join("database", "migrations")
+	if stats.Migrations, err = countProjectFiles(rootDir, migrationsDir, ".sql"); err != nil {
+		return projectStats{}, err
+	}
+	if stats.Jobs, err = countProjectFiles(rootDir, filepath.Join("queue", "jobs"), ".go"); err != nil {
+		return projectStats{}, err
+	}
+
+	manifest, err := collectRouteManifest(rootDir)
+	if err != nil {
+		return projectStats{}, err
+	}
+	stats.Routes = len(manifest.Routes)
+
+	if err := countCodeLines(rootDir, &stats); err != nil {
+		return projectStats{}, err
+	}
+
+	if stats.Migrations > 0 {
+		schema, err := generator.CollectSchemaStats([]string{filepath.Join(rootDir, migrationsDir)})
+		if err != nil {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("schema size unavailable: %v", err))
+		} else {
+			stats.Schema = &schema
+		}
+	}
+
+	return stats, nil
+}
+
+// countModels counts the files in models/ that declare a bun model, leaving
+// out factories and shared helpers.
+func countModels(rootDir string) (int, error) {
+	items, err := listProjectFiles(rootDir, "models", ".go", "model")
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, item := range items {
+		if strings.HasPrefix(item.Path, "models/factories/") || strings.HasSuffix(item.Path, "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(rootDir, filepath.FromSlash(item.Path)))
+		if err != nil {
+			return 0, err
+		}
+		if bytes.Contains(content, []byte("bun.BaseModel")) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func countProjectFiles(rootDir, relDir, ext string) (int, error) {
+	items, err := listProjectFiles(rootDir, relDir, ext, "")
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, item := range items {
+		if !strings.HasSuffix(item.Path, "_test.go") {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func countCodeLines(rootDir string, stats *projectStats) error {
+	return filepath.WalkDir(rootDir, func(path string, entry os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != rootDir && (strings.HasPrefix(name, ".") || statsSkippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".go" && ext != ".templ" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		target := &stats.HandWritten
+		if ext == ".go" && isGeneratedGoSource(content) {
+			target = &stats.Generated
+		}
+		target.Files++
+		target.Lines += countLines(content)
+		return nil
+	})
+}
+
+// isGeneratedGoSource reports whether the generated code header appears
+// before the package clause.
+func isGeneratedGoSource(content []byte) bool {
+	if loc := packageClausePattern.FindIndex(content); loc != nil {
+		content = content[:loc[0]]
+	}
+	return generatedCodePattern.Match(content)
+}
+
+func countLines(content []byte) int {
+	if len(content) == 0 {
+		return 0
+	}
+	lines := bytes.Count(content, []byte("\n"))
+	if content[len(content)-1] != '\n' {
+		lines++
+	}
+	return lines
+}
+
+func renderProjectStatsHuman(w io.Writer, stats projectStats) error {
+	table := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, row := range []struct {
+		label string
+		count int
+	}{
+		{"Models", stats.Models},
+		{"Controllers", stats.Controllers},
+		{"Views", stats.Views},
+		{"Routes", stats.Routes},
+		{"Migrations", stats.Migrations},
+		{"Jobs", stats.Jobs},
+	} {
+		if _, err := fmt.Fprintf(table, "%s\t%d\n", row.label, row.count); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintln(table, "\t"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(table, "CODE\tFILES\tLINES"); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(table, "Generated\t%d\t%d\n", stats.Generated.Files, stats.Generated.Lines); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(table, "Hand-written\t%d\t%d\n", stats.HandWritten.Files, stats.HandWritten.Lines); err != nil {
+		return err
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	if stats.Schema != nil {
+		if _, err := fmt.Fprintf(
+			w,
+			"\nSchema: %d tables, %d columns, %d enums\n",
+			stats.Schema.Tables,
+			stats.Schema.Columns,
+			stats.Schema.Enums,
+		); err != nil {
+			return err
+		}
+		if stats.Schema.SkippedStatements > 0 {
+			if _, err := fmt.Fprintf(w, "%d migration statements could not be applied and were skipped\n", stats.Schema.SkippedStatements); err != nil {
+				return err
+			}
+		}
+	}
+	for _, warning := range stats.Warnings {
+		if _, err := fmt.Fprintf(w, "\nWarning: %s\n", warning); err != nil {
+			return err
+		}
+	}
+	return nil
+}
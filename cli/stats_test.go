@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator"
+)
+
+func TestCollectProjectStats(t *testing.T) {
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module example.com/app\n\ngo 1.26\n")
+	writeTestFile(t, root, "models/user.go", "package models\n\ntype UserEntity struct {\n\tbun.BaseModel `bun:\"table:users\"`\n}\n")
+	writeTestFile(t, root, "models/errors.go", "package models\n\nvar ErrDomainValidation = errors.New(\"validation\")\n")
+	writeTestFile(t, root, "models/user_test.go", "package models\n\n// bun.BaseModel\n")
+	writeTestFile(t, root, "models/factories/user.go", "package factories\n\n// bun.BaseModel\n")
+	writeTestFile(t, root, "controllers/users.go", "package controllers\n")
+	writeTestFile(t, root, "controllers/users_test.go", "package controllers\n")
+	writeTestFile(t, root, "views/users.templ", "package views\n\ntempl Users() {\n}\n")
+	writeTestFile(t, root, "views/users_templ.go", "// Code generated by templ - DO NOT EDIT.\n\npackage views\n")
+	writeTestFile(t, root, "queue/jobs/send_email.go", "package jobs\n")
+	writeTestFile(t, root, "node_modules/pkg/index.go", "package pkg\n")
+	writeTestFile(t, root, "database/migrations/00001_create_users.sql", `-- +goose Up
+CREATE TYPE user_role AS ENUM ('admin', 'member');
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    role user_role NOT NULL
+);
+UPDATE users SET role = 'member';
+CREATE FUNCTION touch() RETURNS TRIGGER AS 'SELECT 1' LANGUAGE sql;
+
+-- +goose Down
+DROP TABLE users;
+`)
+	writeRouteManifestTestFile(t, root, "users.go", `package routes
+
+import "example.com/app/internal/routing"
+
+var UserIndex = routing.NewSimpleRoute("", "users.index", "/users")
+`)
+
+	stats, err := collectProjectStats(root)
+	if err != nil {
+		t.Fatalf("collectProjectStats: %v", err)
+	}
+
+	if stats.Models != 1 || stats.Controllers != 1 || stats.Views != 1 || stats.Routes != 1 || stats.Migrations != 1 || stats.Jobs != 1 {
+		t.Fatalf("unexpected counts: %#v", stats)
+	}
+	if stats.Generated != (codeStats{Files: 1, Lines: 3}) {
+		t.Fatalf("generated = %#v", stats.Generated)
+	}
+	if stats.HandWritten.Files != 9 {
+		t.Fatalf("hand-written files = %d, want 9", stats.HandWritten.Files)
+	}
+	want := generator.SchemaStats{Tables: 1, Columns: 2, Enums: 1, SkippedStatements: 1}
+	if stats.Schema == nil || *stats.Schema != want {
+		t.Fatalf("schema = %#v, want %#v", stats.Schema, want)
+	}
+
+	var out bytes.Buffer
+	if err := renderProjectStatsHuman(&out, stats); err != nil {
+		t.Fatalf("renderProjectStatsHuman: %v", err)
+	}
+	for _, line := range []string{"Models        1", "Generated     1      3", "Schema: 1 tables, 2 columns, 1 enums", "1 migration statements could not be applied"} {
+		if !strings.Contains(out.String(), line) {
+			t.Fatalf("output missing %q:\n%s", line, out.String())
+		}
+	}
+}
+
+func TestIsGeneratedGoSource(t *testing.T) {
+	cases := map[string]bool{
+		"// Code generated by andurel v1.0.0; DO NOT EDIT.\n\npackage storage\n":          true,
+		"//go:build ignore\n\n// Code generated by templ - DO NOT EDIT.\npackage views\n": true,
+		"package models\n\n// Code generated by andurel; DO NOT EDIT.\n":                  false,
+		"// Package models is hand-written.\npackage models\n":                            false,
+	}
+	for source, want := range cases {
+		if got := isGeneratedGoSource([]byte(source)); got != want {
+			t.Errorf("isGeneratedGoSource(%q) = %v, want %v", source, got, want)
+		}
+	}
+}
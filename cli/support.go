@@ -0,0 +1,461 @@
+package cli
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/spf13/cobra"
+)
+
+// maxGeneratorLogEntries caps the generator log kept in the user cache
+// directory, and the entries a support bundle includes.
+const maxGeneratorLogEntries = 50
+
+// generatorLogPathFunc returns the file generate runs are appended to.
+var generatorLogPathFunc = defaultGeneratorLogPath
+
+// supportBundleNow is the clock used to name bundles and stamp log entries.
+var supportBundleNow = time.Now
+
+type generatorLogEntry struct {
+	Time    string   `json:"time"`
+	Project string   `json:"project,omitempty"`
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type supportVersions struct {
+	CLI          string          `json:"cli"`
+	Lock         string          `json:"lock,omitempty"`
+	GoDirective  string          `json:"go_directive,omitempty"`
+	CLIGoVersion string          `json:"cli_go_version"`
+	OS           string          `json:"os"`
+	Arch         string          `json:"arch"`
+	Tools        []toolInfo      `json:"tools"`
+	Extensions   []extensionInfo `json:"extensions"`
+}
+
+type supportConfigKeys struct {
+	User    []string `json:"user"`
+	Project []string `json:"project"`
+	Cache   []string `json:"cache"`
+	Env     []string `json:"env"`
+}
+
+type supportBundleResult struct {
+	Path  string   `json:"path"`
+	Files []string `json:"files"`
+}
+
+func newSupportCommand(version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "support",
+		Short: "Collect diagnostics for bug reports",
+		Long:  "Collect diagnostics to attach to Andurel bug reports.",
+	}
+	setAgentMetadata(cmd, "introspection", "Collects redacted local diagnostics; nothing is uploaded.")
+
+	bundleCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write a redacted diagnostics zip",
+		Long: `Write a zip of diagnostics to attach to a bug report.
+
+The bundle holds the doctor report, the CLI, Go and tool versions,
+andurel.lock, the names of the configured settings and .env variables, and
+the last generate runs. Config and .env values are left out, and the project
+path, module path, project name and home directory are replaced with
+placeholders. Nothing is uploaded, and the doctor report skips the latest
+release lookup.`,
+		Example: `  andurel support bundle
+  andurel support bundle --output /tmp/andurel-support.zip
+  andurel support bundle --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := cmd.Flags().GetString("output")
+			if err != nil {
+				return err
+			}
+			return runSupportBundle(cmd, version, path)
+		},
+	}
+	bundleCmd.Flags().StringP("output", "o", "", "Path of the zip (default: andurel-support-<timestamp>.zip in the project root)")
+	setAgentMetadata(bundleCmd, "introspection", "Writes a redacted diagnostics zip; runs the doctor checks without network lookups.")
+	cmd.AddCommand(bundleCmd)
+
+	return cmd
+}
+
+func runSupportBundle(cmd *cobra.Command, version, path string) error {
+	rootDir, err := findGoModRoot()
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = filepath.Join(rootDir, fmt.Sprintf("andurel-support-%s.zip", supportBundleNow().UTC().Format("20060102-150405")))
+	}
+
+	files, err := collectSupportFiles(rootDir, version)
+	if err != nil {
+		return err
+	}
+	if err := writeSupportBundle(path, files); err != nil {
+		return err
+	}
+
+	result := supportBundleResult{Path: path, Files: make([]string, 0, len(files))}
+	for name := range files {
+		result.Files = append(result.Files, name)
+	}
+	sort.Strings(result.Files)
+
+	opts, err := output.ParseOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.Mode == output.ModeHuman {
+		if opts.Quiet {
+			return nil
+		}
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Support bundle written to %s\n", path)
+		for _, name := range result.Files {
+			fmt.Fprintf(out, "  %s\n", name)
+		}
+		return nil
+	}
+	return output.OK(cmd, result, "Support bundle written to "+path)
+}
+
+// collectSupportFiles returns the redacted bundle contents by file name.
+func collectSupportFiles(rootDir, version string) (map[string][]byte, error) {
+	module, goDirective, _ := readGoModMetadata(rootDir)
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		lock = nil
+	}
+	if lock != nil && lock.ScaffoldConfig != nil && lock.ScaffoldConfig.ProjectName != "" {
+		scaffold := *lock.ScaffoldConfig
+		scaffold.ProjectName = "<project-name>"
+		lock.ScaffoldConfig = &scaffold
+	}
+	home, _ := os.UserHomeDir()
+	redact := newSupportRedactor(rootDir, module, home)
+
+	files := map[string][]byte{}
+	add := func(name string, value any) error {
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(value); err != nil {
+			return fmt.Errorf("encode %s: %w", name, err)
+		}
+		files[name] = []byte(redact.Replace(buf.String()))
+		return nil
+	}
+
+	report, err := collectDoctorReport(version, true, true, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := add("doctor.json", report); err != nil {
+		return nil, err
+	}
+
+	versions := supportVersions{
+		CLI:          version,
+		GoDirective:  goDirective,
+		CLIGoVersion: runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		Tools:        toolInfos(rootDir, lock),
+		Extensions:   extensionInfos(lock),
+	}
+	if lock != nil {
+		versions.Lock = lock.Version
+	}
+	if err := add("versions.json", versions); err != nil {
+		return nil, err
+	}
+
+	if lock != nil {
+		if err := add("andurel.lock", lock); err != nil {
+			return nil, err
+		}
+	}
+
+	keys, err := collectSupportConfigKeys(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := add("config-keys.json", keys); err != nil {
+		return nil, err
+	}
+
+	entries, err := readGeneratorLog(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := add("generator-log.json", entries); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// newSupportRedactor replaces identifying values with placeholders. Longer
+// values are replaced first so a project path inside the home directory
+// becomes <project> rather than ~/<directory>.
+func newSupportRedactor(rootDir, module, home string) *strings.Replacer {
+	pairs := [][2]string{
+		{rootDir, "<project>"},
+		{filepath.ToSlash(rootDir), "<project>"},
+		{module, "<module>"},
+		{home, "~"},
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return len(pairs[i][0]) > len(pairs[j][0]) })
+
+	var oldnew []string
+	for _, pair := range pairs {
+		if pair[0] == "" || pair[0] == "/" || pair[0] == "." {
+			continue
+		}
+		oldnew = append(oldnew, pair[0], pair[1])
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+// collectSupportConfigKeys lists the names of the configured Andurel
+// settings and .env variables, without their values.
+func collectSupportConfigKeys(rootDir string) (supportConfigKeys, error) {
+	keys := supportConfigKeys{}
+	for _, scope := range []struct {
+		path func() (string, error)
+		keys *[]string
+	}{
+		{userConfigPath, &keys.User},
+		{func() (string, error) { return filepath.Join(rootDir, ".andurel", "config.json"), nil }, &keys.Project},
+		{cacheConfigPath, &keys.Cache},
+	} {
+		*scope.keys = []string{}
+		path, err := scope.path()
+		if err != nil {
+			continue
+		}
+		cfg, err := readOptionalAgentConfig(path)
+		if err != nil {
+			return supportConfigKeys{}, err
+		}
+		*scope.keys = agentConfigKeys(cfg)
+	}
+
+	envKeys, err := readEnvKeys(filepath.Join(rootDir, ".env"))
+	if err != nil {
+		return supportConfigKeys{}, err
+	}
+	keys.Env = envKeys
+	return keys, nil
+}
+
+func agentConfigKeys(cfg agentConfig) []string {
+	keys := []string{}
+	for key, value := range map[string]string{
+		"preferred_generator_mode": cfg.PreferredGeneratorMode,
+		"javascript_runtime":       cfg.JavaScriptRuntime,
+		"default_namespace":        cfg.DefaultNamespace,
+		"output_format":            cfg.OutputFormat,
+	} {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	for key := range cfg.CommonDatabaseCommandOptions {
+		keys = append(keys, "common_database_command_options."+key)
+	}
+	for key := range cfg.Values {
+		keys = append(keys, "values."+key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// readEnvKeys returns the variable names declared in a .env file.
+func readEnvKeys(path string) ([]string, error) {
+	keys := []string{}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		if key, _, ok := strings.Cut(line, "="); ok && strings.TrimSpace(key) != "" {
+			keys = append(keys, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func writeSupportBundle(path string, files map[string][]byte) error {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	modified := supportBundleNow()
+	for _, name := range names {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return fmt.Errorf("add %s to support bundle: %w", name, err)
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			return fmt.Errorf("add %s to support bundle: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("close support bundle: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("write support bundle: %w", err)
+	}
+	return nil
+}
+
+func defaultGeneratorLogPath() (string, error) {
+	base, err := userCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "generate.log"), nil
+}
+
+// recordGeneratorRuns wraps every generate subcommand so its outcome is
+// appended to the generator log that support bundles include. Logging
+// failures never fail the command.
+func recordGeneratorRuns(cmd *cobra.Command) {
+	for _, sub := range cmd.Commands() {
+		recordGeneratorRuns(sub)
+		if sub.RunE == nil {
+			continue
+		}
+		run := sub.RunE
+		sub.RunE = func(cmd *cobra.Command, args []string) error {
+			project, _ := findGoModRoot()
+			err := run(cmd, args)
+			entry := generatorLogEntry{
+				Time:    supportBundleNow().UTC().Format(time.RFC3339),
+				Project: project,
+				Command: cmd.CommandPath(),
+				Args:    args,
+			}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			_ = appendGeneratorLog(entry)
+			return err
+		}
+	}
+}
+
+func appendGeneratorLog(entry generatorLogEntry) error {
+	path, err := generatorLogPathFunc()
+	if err != nil {
+		return err
+	}
+	entries, err := readGeneratorLogFile(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, entry)
+	if len(entries) > maxGeneratorLogEntries {
+		entries = entries[len(entries)-maxGeneratorLogEntries:]
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), 0o600)
+}
+
+// readGeneratorLog returns the logged generate runs of the project at
+// rootDir, oldest first.
+func readGeneratorLog(rootDir string) ([]generatorLogEntry, error) {
+	path, err := generatorLogPathFunc()
+	if err != nil {
+		// Without a cache directory nothing was logged.
+		return []generatorLogEntry{}, nil
+	}
+	entries, err := readGeneratorLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	project := []generatorLogEntry{}
+	for _, entry := range entries {
+		if entry.Project == rootDir {
+			project = append(project, entry)
+		}
+	}
+	return project, nil
+}
+
+func readGeneratorLogFile(path string) ([]generatorLogEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []generatorLogEntry
+	decoder := json.NewDecoder(file)
+	for {
+		var entry generatorLogEntry
+		if err := decoder.Decode(&entry); err == io.EOF {
+			break
+		} else if err != nil {
+			// A damaged log only loses history; start over from here.
+			return entries, nil
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/spf13/cobra"
+)
+
+func TestSupportBundleRedactsProjectDetails(t *testing.T) {
+	resetCLITestSeams(t)
+	stubLatestAndurelVersion(t, "", errors.New("support bundles must not look up releases"))
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	root := t.TempDir()
+	writeGoModule(t, root)
+	writeTestFile(t, root, "main.go", "package main\n\nfunc main() {}\n")
+	writeTestFile(t, root, ".env", "# comment\nDB_PASSWORD=hunter2\nexport SESSION_KEY=s3cret\n")
+	writeTestFile(t, root, ".andurel/config.json", `{"default_namespace":"admin","values":{"api_token":"tok-123"}}`)
+	lock := layout.NewAndurelLock("v1.2.3")
+	lock.ScaffoldConfig = &layout.ScaffoldConfig{ProjectName: "acme-billing", Database: "postgresql"}
+	if err := lock.WriteLockFile(root); err != nil {
+		t.Fatalf("write lock: %v", err)
+	}
+	findGoModRoot = func() (string, error) { return root, nil }
+
+	if err := appendGeneratorLog(generatorLogEntry{Project: root, Command: "andurel generate model", Args: []string{"Invoice"}, Error: "open " + root + "/models: denied"}); err != nil {
+		t.Fatalf("appendGeneratorLog: %v", err)
+	}
+	if err := appendGeneratorLog(generatorLogEntry{Project: "/elsewhere", Command: "andurel generate job"}); err != nil {
+		t.Fatalf("appendGeneratorLog: %v", err)
+	}
+
+	files, err := collectSupportFiles(root, "v1.2.3")
+	if err != nil {
+		t.Fatalf("collectSupportFiles: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := writeSupportBundle(path, files); err != nil {
+		t.Fatalf("writeSupportBundle: %v", err)
+	}
+
+	contents := readSupportBundle(t, path)
+	names := make([]string, 0, len(contents))
+	for name := range contents {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	want := []string{"andurel.lock", "config-keys.json", "doctor.json", "generator-log.json", "versions.json"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("bundle files = %v, want %v", names, want)
+	}
+
+	all := strings.Join([]string{contents["andurel.lock"], contents["config-keys.json"], contents["doctor.json"], contents["generator-log.json"], contents["versions.json"]}, "\n")
+	for _, secret := range []string{root, "example.com/app", "acme-billing", "hunter2", "s3cret", "tok-123", "/elsewhere"} {
+		if strings.Contains(all, secret) {
+			t.Fatalf("bundle leaks %q:\n%s", secret, all)
+		}
+	}
+	for name, want := range map[string]string{
+		"config-keys.json":   `"SESSION_KEY"`,
+		"andurel.lock":       `"projectName": "<project-name>"`,
+		"doctor.json":        "offline; update check skipped",
+		"generator-log.json": `"error": "open <project>/models: denied"`,
+		"versions.json":      `"lock": "v1.2.3"`,
+	} {
+		if !strings.Contains(contents[name], want) {
+			t.Fatalf("%s missing %q:\n%s", name, want, contents[name])
+		}
+	}
+	if !strings.Contains(contents["config-keys.json"], `"values.api_token"`) || !strings.Contains(contents["config-keys.json"], `"default_namespace"`) {
+		t.Fatalf("config keys missing project settings:\n%s", contents["config-keys.json"])
+	}
+}
+
+func TestRecordGeneratorRunsLogsOutcome(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	findGoModRoot = func() (string, error) { return root, nil }
+
+	parent := &cobra.Command{Use: "generate"}
+	parent.AddCommand(&cobra.Command{
+		Use: "model",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("migration not found")
+		},
+	})
+	recordGeneratorRuns(parent)
+	parent.SetArgs([]string{"model", "Invoice"})
+	parent.SetOut(io.Discard)
+	parent.SetErr(io.Discard)
+	if err := parent.Execute(); err == nil || err.Error() != "migration not found" {
+		t.Fatalf("Execute error = %v, want the command's error", err)
+	}
+
+	entries, err := readGeneratorLog(root)
+	if err != nil {
+		t.Fatalf("readGeneratorLog: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("entries = %#v, want one", entries)
+	}
+	if entries[0].Command != "generate model" || !slices.Equal(entries[0].Args, []string{"Invoice"}) || entries[0].Error != "migration not found" {
+		t.Fatalf("unexpected entry: %#v", entries[0])
+	}
+
+	for range maxGeneratorLogEntries + 5 {
+		if err := appendGeneratorLog(generatorLogEntry{Project: root, Command: "generate job"}); err != nil {
+			t.Fatalf("appendGeneratorLog: %v", err)
+		}
+	}
+	entries, err = readGeneratorLog(root)
+	if err != nil {
+		t.Fatalf("readGeneratorLog: %v", err)
+	}
+	if len(entries) != maxGeneratorLogEntries || entries[0].Command != "generate job" {
+		t.Fatalf("log should keep the last %d runs, got %d starting with %q", maxGeneratorLogEntries, len(entries), entries[0].Command)
+	}
+}
+
+func readSupportBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatalf("open bundle: %v", err)
+	}
+	defer reader.Close()
+
+	contents := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", file.Name, err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", file.Name, err)
+		}
+		contents[file.Name] = string(data)
+	}
+	return contents
+}
@@ -32,7 +32,8 @@ Note: This only upgrades framework code. You are responsible for updating
 your application code to work with any API changes in the new version.`,
 		Example: `  andurel upgrade
   andurel upgrade --dry-run
-  andurel upgrade --repair`,
+  andurel upgrade --repair
+  andurel upgrade go --to 1.26`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runUpgrade(cmd, version)
 		},
@@ -42,6 +43,8 @@ your application code to work with any API changes in the new version.`,
 	upgradeCmd.Flags().Bool("diff", false, "Include a text diff preview in structured output")
 	upgradeCmd.Flags().Bool("repair", false, "Restore drifted framework-owned files when already on this version")
 
+	upgradeCmd.AddCommand(newUpgradeGoCommand())
+
 	return upgradeCmd
 }
 
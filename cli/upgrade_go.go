@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/version"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/cli/output"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/modfile"
+)
+
+var verifyGoUpgradeFunc = verifyGoUpgrade
+
+var (
+	// goReleasePattern matches the versions upgrade go accepts, a release
+	// such as 1.26 or 1.26.2.
+	goReleasePattern = regexp.MustCompile(`^1\.[0-9]+(\.[0-9]+)?$`)
+	// dockerGoVersion matches the Go version the docker extension's
+	// Dockerfile builds with, and golang images named with a version.
+	dockerGoVersion = regexp.MustCompile(`(?m)^(ARG GO_VERSION=)[0-9][0-9.]*|(golang:)[0-9][0-9.]*`)
+	// workflowGoVersion matches the go-version input of actions/setup-go,
+	// keeping its quotes. Matrix lists are left alone.
+	workflowGoVersion = regexp.MustCompile(`(?m)^(\s*(?:-\s+)?go-version:\s*)(["']?)[0-9][0-9.x]*(["']?)`)
+	// toolVersionsGo matches the Go entry of an asdf or mise .tool-versions.
+	toolVersionsGo = regexp.MustCompile(`(?m)^((?:golang|go)\s+)\S+`)
+)
+
+// goVersionRewrite replaces the Go version in a file that names one.
+type goVersionRewrite struct {
+	path    string
+	pattern *regexp.Regexp
+	repl    string
+}
+
+func newUpgradeGoCommand() *cobra.Command {
+	var to string
+	var dryRun bool
+	var diff bool
+
+	cmd := &cobra.Command{
+		Use:   "go",
+		Short: "Upgrade the project's Go version",
+		Long: `Moves the project to a newer Go release in one pass.
+
+Updates:
+  - the go directive in go.mod, dropping a toolchain line older than it
+  - the Go version recorded in andurel.lock, which framework files are
+    rendered with on later upgrades
+  - GO_VERSION in the Dockerfile from the docker extension
+  - go-version in the GitHub Actions workflows in .github/workflows
+  - .go-version, and the golang entry of .tool-versions
+
+The project is then built with 'go build ./...' and its tests are compiled
+with 'go test -run ^$ ./...'. If either fails, every change is rolled back
+and the errors are shown. Pass --dry-run to see the diff without changing
+anything.`,
+		Example: `  andurel upgrade go --to 1.26
+
+      go.mod:     go 1.26
+      Dockerfile: ARG GO_VERSION=1.26
+
+  andurel upgrade go --to 1.26.2 --dry-run`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if to == "" {
+				return fmt.Errorf("--to is required, e.g. --to 1.26")
+			}
+
+			rootDir, err := findGoModRoot()
+			if err != nil {
+				return err
+			}
+
+			return runMutation(cmd, mutationOptions{
+				Action:   "upgrade go",
+				Resource: to,
+				RootDir:  rootDir,
+				DryRun:   dryRun,
+				Diff:     diff,
+				CommandsRun: []string{
+					"go build ./...",
+					"go test -run ^$ ./...",
+				},
+				Breadcrumbs: []output.Breadcrumb{
+					{Command: "go test ./...", Description: "Run the tests on the new Go version"},
+					{Command: "git diff", Description: "Review the upgrade"},
+				},
+				Run: func(rootDir string) error {
+					return upgradeGoVersion(rootDir, to)
+				},
+			})
+		},
+	}
+	setAgentMetadata(cmd, "maintenance", "Rewrites go.mod, andurel.lock, the Dockerfile, setup-go workflows and .tool-versions, then builds the project. Use --dry-run --diff to preview.")
+
+	cmd.Flags().StringVar(&to, "to", "", "Go release to move to (e.g. 1.26 or 1.26.2)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Preview file changes without applying")
+	cmd.Flags().BoolVar(&diff, "diff", false, "Include a text diff preview in structured output")
+
+	return cmd
+}
+
+func upgradeGoVersion(rootDir, to string) error {
+	to = strings.TrimPrefix(to, "go")
+	if !goReleasePattern.MatchString(to) {
+		return fmt.Errorf("invalid Go version %q: pass a release such as 1.26 or 1.26.2", to)
+	}
+
+	goModPath := filepath.Join(rootDir, "go.mod")
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	goMod, err := modfile.Parse(goModPath, content, nil)
+	if err != nil {
+		return fmt.Errorf("failed to parse go.mod: %w", err)
+	}
+	if goMod.Go != nil {
+		switch current := goMod.Go.Version; {
+		case version.Compare("go"+to, "go"+current) == 0:
+			return fmt.Errorf("go.mod already targets Go %s", current)
+		case version.Compare("go"+to, "go"+current) < 0:
+			return fmt.Errorf("go.mod targets Go %s, which is newer than %s; upgrade go does not downgrade", current, to)
+		}
+	}
+
+	if err := goMod.AddGoStmt(to); err != nil {
+		return fmt.Errorf("failed to set the go directive: %w", err)
+	}
+	if goMod.Toolchain != nil && version.Compare(goMod.Toolchain.Name, "go"+to) <= 0 {
+		goMod.DropToolchainStmt()
+	}
+	goMod.Cleanup()
+	formatted, err := goMod.Format()
+	if err != nil {
+		return fmt.Errorf("failed to format go.mod: %w", err)
+	}
+	if err := writeProjectFile(goModPath, string(formatted)); err != nil {
+		return err
+	}
+	fmt.Printf("✓ go.mod: go %s\n", to)
+
+	if err := upgradeLockedGoVersion(rootDir, to); err != nil {
+		return err
+	}
+
+	rewrites := []goVersionRewrite{
+		{"Dockerfile", dockerGoVersion, "${1}${2}" + to},
+		{".tool-versions", toolVersionsGo, "${1}" + to},
+	}
+	workflows, _ := filepath.Glob(filepath.Join(rootDir, ".github", "workflows", "*.y*ml"))
+	for _, workflow := range workflows {
+		relPath, _ := filepath.Rel(rootDir, workflow)
+		rewrites = append(rewrites, goVersionRewrite{relPath, workflowGoVersion, "${1}${2}" + to + "${3}"})
+	}
+	for _, rewrite := range rewrites {
+		filePath := filepath.Join(rootDir, rewrite.path)
+		content, err := os.ReadFile(filePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rewrite.path, err)
+		}
+		upgraded := rewrite.pattern.ReplaceAllString(string(content), rewrite.repl)
+		if upgraded == string(content) {
+			continue
+		}
+		if err := writeProjectFile(filePath, upgraded); err != nil {
+			return err
+		}
+		fmt.Printf("✓ %s: Go %s\n", filepath.ToSlash(rewrite.path), to)
+	}
+
+	goVersionFile := filepath.Join(rootDir, ".go-version")
+	if _, err := os.Stat(goVersionFile); err == nil {
+		if err := writeProjectFile(goVersionFile, to+"\n"); err != nil {
+			return err
+		}
+		fmt.Printf("✓ .go-version: Go %s\n", to)
+	}
+
+	fmt.Println("Verifying the project builds with Go " + to + "...")
+	return verifyGoUpgradeFunc(rootDir)
+}
+
+// upgradeLockedGoVersion records the Go version in andurel.lock, which
+// later framework upgrades render templates with.
+func upgradeLockedGoVersion(rootDir, to string) error {
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if lock.ScaffoldConfig == nil {
+		return nil
+	}
+	lock.ScaffoldConfig.GoVersion = to
+	if err := lock.WriteLockFile(rootDir); err != nil {
+		return err
+	}
+	fmt.Printf("✓ andurel.lock: Go %s\n", to)
+	return nil
+}
+
+// verifyGoUpgrade builds the project and compiles its tests, which also
+// fetches the toolchain when the installed go is older than go.mod asks for.
+func verifyGoUpgrade(rootDir string) error {
+	for _, args := range [][]string{
+		{"build", "./..."},
+		{"test", "-run", "^$", "./..."},
+	} {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = rootDir
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("go %s failed after the upgrade: %w\n%s", strings.Join(args, " "), err, strings.TrimSpace(out.String()))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestUpgradeGoVersionRewritesModLockDockerfileAndCI(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module myapp\n\ngo 1.25.3\n\ntoolchain go1.25.4\n\nrequire github.com/google/uuid v1.6.0\n")
+	writeTestFile(t, root, "andurel.lock", `{
+  "schemaVersion": 1,
+  "version": "test",
+  "tools": {},
+  "scaffoldConfig": {
+    "projectName": "myapp",
+    "database": "postgresql",
+    "goVersion": "1.25.3"
+  }
+}
+`)
+	writeTestFile(t, root, "Dockerfile", "ARG GO_VERSION=1.25.3\nFROM golang:${GO_VERSION}-bookworm AS builder\nFROM golang:1.25-alpine AS tools\n")
+	writeTestFile(t, root, ".github/workflows/test.yml", "jobs:\n  test:\n    steps:\n      - uses: actions/setup-go@v5\n        with:\n          go-version: '1.25.3'\n      - uses: actions/setup-go@v5\n        with:\n          go-version-file: go.mod\n")
+	writeTestFile(t, root, ".tool-versions", "nodejs 22.1.0\ngolang 1.25.3\n")
+	writeTestFile(t, root, ".go-version", "1.25.3\n")
+
+	verified := ""
+	verifyGoUpgradeFunc = func(rootDir string) error {
+		verified = rootDir
+		return nil
+	}
+
+	if err := upgradeGoVersion(root, "1.26"); err != nil {
+		t.Fatalf("upgradeGoVersion failed: %v", err)
+	}
+	if verified != root {
+		t.Fatalf("expected the project to be verified, got %q", verified)
+	}
+
+	for path, wants := range map[string][]string{
+		"go.mod":                     {"go 1.26\n", "require github.com/google/uuid v1.6.0"},
+		"andurel.lock":               {`"goVersion": "1.26"`},
+		"Dockerfile":                 {"ARG GO_VERSION=1.26\n", "FROM golang:${GO_VERSION}-bookworm", "FROM golang:1.26-alpine"},
+		".github/workflows/test.yml": {"go-version: '1.26'", "go-version-file: go.mod"},
+		".tool-versions":             {"nodejs 22.1.0\ngolang 1.26\n"},
+		".go-version":                {"1.26\n"},
+	} {
+		content := readGeneratedTestFile(t, root, path)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Fatalf("%s should contain %q\n\n%s", path, want, content)
+			}
+		}
+	}
+	if goMod := readGeneratedTestFile(t, root, "go.mod"); strings.Contains(goMod, "toolchain") {
+		t.Fatalf("go.mod should drop the older toolchain line\n\n%s", goMod)
+	}
+
+	for version, want := range map[string]string{
+		"1.26":   "already targets Go 1.26",
+		"1.25.9": "does not downgrade",
+		"latest": "invalid Go version",
+	} {
+		if err := upgradeGoVersion(root, version); err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("upgradeGoVersion(%q): expected %q error, got %v", version, want, err)
+		}
+	}
+}
+
+func TestUpgradeGoVersionReportsFailedVerification(t *testing.T) {
+	resetCLITestSeams(t)
+	root := t.TempDir()
+	writeTestFile(t, root, "go.mod", "module myapp\n\ngo 1.25\n")
+	verifyGoUpgradeFunc = func(string) error { return errors.New("go build ./... failed after the upgrade") }
+
+	if err := upgradeGoVersion(root, "go1.26"); err == nil || !strings.Contains(err.Error(), "go build ./... failed") {
+		t.Fatalf("expected the verification error, got %v", err)
+	}
+	if goMod := readGeneratedTestFile(t, root, "go.mod"); !strings.Contains(goMod, "go 1.26\n") {
+		t.Fatalf("go.mod should be rewritten before verifying\n\n%s", goMod)
+	}
+}
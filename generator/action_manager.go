@@ -7,7 +7,6 @@ import (
 	"regexp"
 	"strings"
 
-	"github.com/jinzhu/inflection"
 	"github.com/mbvlabs/andurel/generator/controllers"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
@@ -42,7 +41,7 @@ func (am *ActionManager) GenerateAction(config ActionConfig) error {
 	// Resolve naming
 	pluralName := naming.DeriveTableName(config.ControllerName) // e.g. "webhooks"
 	receiverName := naming.ToReceiverName(config.ControllerName)
-	capitalizedPlural := naming.Capitalize(naming.ToCamelCase(pluralName)) // e.g. "Webhooks"
+	capitalizedPlural := naming.ToPascalCase(pluralName) // e.g. "Webhooks"
 
 	// Resolve file paths
 	controllerPath := filepath.Join("controllers", pluralName+".go")
@@ -166,7 +165,7 @@ func (am *ActionManager) validateConfig(config ActionConfig) error {
 
 	// Validate controller name is singular
 	snake := naming.ToSnakeCase(config.ControllerName)
-	if inflection.Singular(snake) != snake {
+	if naming.Singular(snake) != snake {
 		return fmt.Errorf("controller name '%s' should be singular (e.g. %s)",
 			config.ControllerName,
 			naming.DeriveResourceName(naming.DeriveTableName(config.ControllerName)),
@@ -215,7 +214,7 @@ func (am *ActionManager) buildSlugParamsStruct(typeName, path string) string {
 			continue
 		}
 		param := seg[1:]
-		fieldName := naming.Capitalize(naming.ToCamelCase(param))
+		fieldName := naming.ToPascalCase(param)
 		fmt.Fprintf(&fields, "\t%s string `slug:\"%s\"`\n", fieldName, param)
 	}
 	return fmt.Sprintf("type %s struct {\n%s}", typeName, fields.String())
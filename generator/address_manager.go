@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// addressColumn is a column the addresses concern adds when the table lacks
+// it.
+type addressColumn struct {
+	Name       string
+	Definition string
+}
+
+// addressColumns are the postal address parts, in the order they are joined
+// into a geocoding query.
+var addressColumns = []addressColumn{
+	{"address_line1", "TEXT NOT NULL DEFAULT ''"},
+	{"address_line2", "TEXT NOT NULL DEFAULT ''"},
+	{"city", "TEXT NOT NULL DEFAULT ''"},
+	{"region", "TEXT NOT NULL DEFAULT ''"},
+	{"postal_code", "TEXT NOT NULL DEFAULT ''"},
+	{"country", "TEXT NOT NULL DEFAULT ''"},
+}
+
+// geocodedColumns are filled in by the geocode job. They carry the
+// andurel:geocoded annotation so scaffolds leave them out of forms.
+var geocodedColumns = []addressColumn{
+	{"latitude", "DOUBLE PRECISION"},
+	{"longitude", "DOUBLE PRECISION"},
+	{"geocoded_at", "TIMESTAMP WITH TIME ZONE"},
+}
+
+type addressAnnotation struct {
+	Name     string
+	Comment  string
+	Previous string
+	Added    bool
+}
+
+type addressTemplateData struct {
+	ModulePath      string
+	ModelName       string
+	ModelType       string
+	ModelReceiver   string
+	ResourceVar     string
+	SnakeName       string
+	TableName       string
+	IDColumn        string
+	IDType          string
+	AddressColumns  []string
+	AddColumns      []addressColumn
+	AnnotateColumns []addressAnnotation
+	CSSComponents   bool
+}
+
+var addressNow = time.Now
+
+// AddressManager adds structured, geocoded addresses to a resource.
+type AddressManager struct {
+	validator        *InputValidator
+	fileManager      files.Manager
+	projectManager   *ProjectManager
+	migrationManager *MigrationManager
+	config           *UnifiedConfig
+}
+
+// NewAddressManager creates a new address manager.
+func NewAddressManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	migrationManager *MigrationManager,
+	config *UnifiedConfig,
+) *AddressManager {
+	return &AddressManager{
+		validator:        validator,
+		fileManager:      fileManager,
+		projectManager:   projectManager,
+		migrationManager: migrationManager,
+		config:           config,
+	}
+}
+
+// GenerateAddress writes a migration adding the address columns the table
+// is missing, the model methods and job that geocode a record in the
+// background, and the shared map component. Run it before the scaffold so
+// the new columns reach the model and forms.
+func (a *AddressManager) GenerateAddress(resourceName, tableName string) error {
+	if err := a.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+	if tableName == "" {
+		tableName = naming.DeriveTableName(resourceName)
+	}
+
+	cat, err := a.migrationManager.BuildCatalogFromMigrations(tableName, a.config)
+	if err != nil {
+		return err
+	}
+	table, err := cat.GetTable("", tableName)
+	if err != nil {
+		return fmt.Errorf("table %s not found in migrations: %w", tableName, err)
+	}
+	pk := DetectPrimaryKey(cat, tableName)
+	if !pk.Found {
+		return fmt.Errorf("table %s has no primary key to queue geocode jobs with", tableName)
+	}
+	if pk.IsComposite() {
+		return fmt.Errorf("table %s has a composite primary key; geocode jobs need a single key column", tableName)
+	}
+
+	snakeName := naming.ToSnakeCase(resourceName)
+	data := addressTemplateData{
+		ModulePath:    a.projectManager.GetModulePath(),
+		ModelName:     resourceName,
+		ModelType:     naming.ToLowerCamelCaseFromAny(resourceName),
+		ModelReceiver: naming.ToReceiverName(resourceName),
+		ResourceVar:   naming.ToLowerCamelCase(resourceName),
+		SnakeName:     snakeName,
+		TableName:     tableName,
+		IDColumn:      pk.ColumnName,
+		IDType:        pk.GoType,
+	}
+	for _, col := range addressColumns {
+		data.AddressColumns = append(data.AddressColumns, col.Name)
+	}
+	data.AddColumns, data.AnnotateColumns = missingAddressColumns(table)
+
+	rootDir, err := a.fileManager.FindGoModRoot()
+	if err != nil {
+		return err
+	}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+
+	modelPath := filepath.Join(a.config.Paths.Models, snakeName+"_address.go")
+	jobPath := filepath.Join("queue", "jobs", "geocode_"+snakeName+".go")
+	workerPath := filepath.Join("queue", "geocode_"+snakeName+".go")
+	for _, path := range []string{modelPath, jobPath, workerPath} {
+		if a.fileManager.FileExists(path) {
+			return fmt.Errorf("addresses are already enabled for %s: %s exists", resourceName, path)
+		}
+	}
+
+	if len(data.AddColumns) > 0 || len(data.AnnotateColumns) > 0 {
+		migrationPath := filepath.Join(
+			a.config.Paths.Migrations,
+			addressNow().UTC().Format("20060102150405")+"_add_address_to_"+tableName+".sql",
+		)
+		if err := a.render("address_migration.tmpl", migrationPath, data); err != nil {
+			return err
+		}
+	}
+	if err := a.render("address_resource_model.tmpl", modelPath, data); err != nil {
+		return err
+	}
+	if err := a.render("address_job.tmpl", jobPath, data); err != nil {
+		return err
+	}
+	if err := a.render("address_worker.tmpl", workerPath, data); err != nil {
+		return err
+	}
+
+	mapViewPath := filepath.Join(a.config.Paths.Views, "maps.templ")
+	if !a.fileManager.FileExists(mapViewPath) {
+		if err := a.render("address_view.tmpl", mapViewPath, data); err != nil {
+			return err
+		}
+		if err := compileTemplates(rootDir, mapViewPath); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Successfully added addresses to %s\n", resourceName)
+	return nil
+}
+
+// render writes a template to path, formatting Go output.
+func (a *AddressManager) render(templateName, path string, data addressTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := a.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, ".go") {
+		if err := files.FormatGoFile(path); err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// missingAddressColumns returns the address columns table lacks and the
+// geocoded columns that still need the andurel:geocoded annotation.
+func missingAddressColumns(table *catalog.Table) ([]addressColumn, []addressAnnotation) {
+	var add []addressColumn
+	for _, col := range addressColumns {
+		if _, err := table.GetColumn(col.Name); err != nil {
+			add = append(add, col)
+		}
+	}
+
+	var annotate []addressAnnotation
+	for _, col := range geocodedColumns {
+		existing, err := table.GetColumn(col.Name)
+		if err != nil {
+			add = append(add, col)
+			annotate = append(annotate, addressAnnotation{Name: col.Name, Comment: "andurel:geocoded", Added: true})
+			continue
+		}
+		if existing.HasAnnotation("geocoded") {
+			continue
+		}
+		previous := strings.ReplaceAll(existing.Comment, "'", "''")
+		annotate = append(annotate, addressAnnotation{
+			Name:     col.Name,
+			Comment:  strings.TrimSpace(previous + " andurel:geocoded"),
+			Previous: previous,
+		})
+	}
+
+	return add, annotate
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+func TestAddressTemplatesParse(t *testing.T) {
+	service := templates.GetGlobalTemplateService()
+
+	for _, idType := range []string{"uuid.UUID", "int64"} {
+		data := addressTemplateData{
+			ModulePath:     "example.com/app",
+			ModelName:      "Store",
+			ModelType:      "store",
+			ModelReceiver:  "s",
+			ResourceVar:    "store",
+			SnakeName:      "store",
+			TableName:      "stores",
+			IDColumn:       "id",
+			IDType:         idType,
+			AddressColumns: []string{"address_line1", "city"},
+		}
+		for _, name := range []string{
+			"address_resource_model.tmpl",
+			"address_job.tmpl",
+			"address_worker.tmpl",
+		} {
+			content, err := service.RenderTemplate(name, data)
+			if err != nil {
+				t.Fatalf("render %s failed: %v", name, err)
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), name, content, 0); err != nil {
+				t.Fatalf("%s is not valid Go: %v\n%s", name, err, content)
+			}
+		}
+	}
+}
+
+func TestAddressMigration(t *testing.T) {
+	table := catalog.NewTable("", "stores")
+	for _, col := range []*catalog.Column{
+		catalog.NewColumn("id", "uuid"),
+		catalog.NewColumn("city", "text"),
+		catalog.NewColumn("latitude", "double precision").SetComment("andurel:searchable"),
+		catalog.NewColumn("longitude", "double precision").SetComment("andurel:geocoded"),
+	} {
+		if err := table.AddColumn(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data := addressTemplateData{TableName: "stores"}
+	data.AddColumns, data.AnnotateColumns = missingAddressColumns(table)
+
+	var added []string
+	for _, col := range data.AddColumns {
+		added = append(added, col.Name)
+	}
+	if got, want := strings.Join(added, ","), "address_line1,address_line2,region,postal_code,country,geocoded_at"; got != want {
+		t.Fatalf("added columns = %s, want %s", got, want)
+	}
+
+	content, err := templates.GetGlobalTemplateService().RenderTemplate("address_migration.tmpl", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"    ADD COLUMN address_line1 TEXT NOT NULL DEFAULT '',\n",
+		"    ADD COLUMN geocoded_at TIMESTAMP WITH TIME ZONE;\n",
+		"COMMENT ON COLUMN stores.latitude IS 'andurel:searchable andurel:geocoded';\n",
+		"COMMENT ON COLUMN stores.geocoded_at IS 'andurel:geocoded';\n",
+		"-- +goose Down\nCOMMENT ON COLUMN stores.latitude IS 'andurel:searchable';\n",
+		"    DROP COLUMN geocoded_at;",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("migration should contain %q, got:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "stores.longitude") || strings.Contains(content, "COLUMN city") {
+		t.Fatalf("migration should leave existing columns alone, got:\n%s", content)
+	}
+}
@@ -0,0 +1,324 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// calendarStartColumns, calendarEndColumns and calendarTitleColumns are tried
+// in order when no column is given.
+var (
+	calendarStartColumns = []string{
+		"starts_at", "start_at", "starts_on", "start_date", "start_time",
+		"begins_at", "scheduled_at", "scheduled_for", "event_date", "date",
+	}
+	calendarEndColumns   = []string{"ends_at", "end_at", "ends_on", "end_date", "end_time", "finishes_at"}
+	calendarTitleColumns = []string{"title", "name", "summary", "subject"}
+)
+
+// CalendarColumns names the columns an ICS feed reads. Empty fields are
+// detected from the table.
+type CalendarColumns struct {
+	StartsAt string
+	EndsAt   string
+	Title    string
+}
+
+type calendarTemplateData struct {
+	ModulePath     string
+	ResourceName   string
+	ModelName      string
+	ModelType      string
+	ModelReceiver  string
+	ControllerName string
+	ReceiverName   string
+	ResourceVar    string
+	TableName      string
+	CalendarName   string
+	CalendarPrefix string
+	StartColumn    string
+	StartField     string
+	EndColumn      string
+	EndField       string
+	TitleField     string
+	AllDay         bool
+	CSSComponents  bool
+}
+
+// calendarSharedFiles are generated once per project and reused by every
+// resource with a calendar feed.
+var calendarSharedFiles = []struct {
+	template string
+	path     string
+}{
+	{"calendar_controller.tmpl", filepath.Join("controllers", "calendars.go")},
+	{"calendar_view.tmpl", filepath.Join("views", "calendars.templ")},
+}
+
+// CalendarManager adds ICS calendar feeds to a generated resource.
+type CalendarManager struct {
+	validator        *InputValidator
+	fileManager      files.Manager
+	projectManager   *ProjectManager
+	migrationManager *MigrationManager
+	config           *UnifiedConfig
+}
+
+// NewCalendarManager creates a new calendar manager.
+func NewCalendarManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	migrationManager *MigrationManager,
+	config *UnifiedConfig,
+) *CalendarManager {
+	return &CalendarManager{
+		validator:        validator,
+		fileManager:      fileManager,
+		projectManager:   projectManager,
+		migrationManager: migrationManager,
+		config:           config,
+	}
+}
+
+// GenerateCalendar writes a per-user ICS feed of upcoming records, the model
+// query behind it and a subscribe panel on the resource's index page.
+func (c *CalendarManager) GenerateCalendar(resourceName, tableName string, columns CalendarColumns) error {
+	if err := c.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+	if tableName == "" {
+		tableName = naming.DeriveTableName(resourceName)
+	}
+
+	indexViewPath := filepath.Join(c.config.Paths.Views, tableName+"_resource.templ")
+	if !c.fileManager.FileExists(indexViewPath) {
+		return fmt.Errorf(
+			"no Templ resource view at %s; generate the resource with 'andurel generate scaffold %s' first",
+			indexViewPath,
+			resourceName,
+		)
+	}
+
+	cat, err := c.migrationManager.BuildCatalogFromMigrations(tableName, c.config)
+	if err != nil {
+		return err
+	}
+	table, err := cat.GetTable("", tableName)
+	if err != nil {
+		return fmt.Errorf("table %s not found in migrations: %w", tableName, err)
+	}
+
+	controllerName := resourceName + "Calendar"
+	data := calendarTemplateData{
+		ModulePath:     c.projectManager.GetModulePath(),
+		ResourceName:   resourceName,
+		ModelName:      resourceName,
+		ModelType:      naming.ToLowerCamelCaseFromAny(resourceName),
+		ModelReceiver:  naming.ToReceiverName(resourceName),
+		ControllerName: controllerName,
+		ReceiverName:   naming.ToReceiverName(controllerName),
+		ResourceVar:    naming.ToLowerCamelCase(resourceName),
+		TableName:      tableName,
+		CalendarName:   naming.Capitalize(strings.ReplaceAll(tableName, "_", " ")),
+		CalendarPrefix: "/calendars/" + naming.ToKebabCase(tableName),
+	}
+	if err := resolveCalendarColumns(table, columns, &data); err != nil {
+		return err
+	}
+
+	rootDir, err := c.fileManager.FindGoModRoot()
+	if err != nil {
+		return err
+	}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+
+	routesPath := filepath.Join(c.config.Paths.Routes, tableName+"_calendar.go")
+	controllerPath := filepath.Join(c.config.Paths.Controllers, tableName+"_calendar.go")
+	modelPath := filepath.Join(c.config.Paths.Models, naming.ToSnakeCase(resourceName)+"_calendar.go")
+	for _, path := range []string{routesPath, controllerPath, modelPath} {
+		if c.fileManager.FileExists(path) {
+			return fmt.Errorf("a calendar feed already exists for %s: %s exists", resourceName, path)
+		}
+	}
+
+	indexView, err := os.ReadFile(indexViewPath)
+	if err != nil {
+		return fmt.Errorf("failed to read view %s: %w", indexViewPath, err)
+	}
+	updatedIndexView, err := addCalendarToIndexView(string(indexView), resourceName)
+	if err != nil {
+		return fmt.Errorf("failed to add the calendar panel to %s: %w", indexViewPath, err)
+	}
+
+	for _, file := range calendarSharedFiles {
+		if c.fileManager.FileExists(file.path) {
+			continue
+		}
+		if err := c.render(file.template, file.path, data); err != nil {
+			return err
+		}
+	}
+	if err := c.render("calendar_resource_route.tmpl", routesPath, data); err != nil {
+		return err
+	}
+	if err := c.render("calendar_resource_model.tmpl", modelPath, data); err != nil {
+		return err
+	}
+	if err := c.render("calendar_resource_controller.tmpl", controllerPath, data); err != nil {
+		return err
+	}
+	if err := os.WriteFile(indexViewPath, []byte(updatedIndexView), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", indexViewPath, err)
+	}
+
+	if err := controllers.NewMainInjector().InjectController(controllerName, "", naming.ToSnakeCase(controllerName)); err != nil {
+		return fmt.Errorf("failed to register %s controller: %w", controllerName, err)
+	}
+
+	if err := compileTemplates(
+		rootDir,
+		filepath.Join(c.config.Paths.Views, "calendars.templ"),
+		indexViewPath,
+	); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully added a calendar feed to %s\n", resourceName)
+	return nil
+}
+
+// resolveCalendarColumns checks the given columns, or detects them by name,
+// and records their Go fields in data.
+func resolveCalendarColumns(table *catalog.Table, columns CalendarColumns, data *calendarTemplateData) error {
+	start, err := findCalendarColumn(table, columns.StartsAt, calendarStartColumns)
+	if err != nil {
+		return err
+	}
+	if start == nil {
+		return fmt.Errorf("no start column found on %s; pass --starts-at", table.Name)
+	}
+	kind := calendarTimeKind(start.DataType)
+	if kind == "" {
+		return fmt.Errorf("start column %s must be a date or timestamp, not %s", start.Name, start.DataType)
+	}
+	data.StartColumn = start.Name
+	data.StartField = types.FormatFieldName(start.Name)
+	data.AllDay = kind == "date"
+
+	end, err := findCalendarColumn(table, columns.EndsAt, calendarEndColumns)
+	if err != nil {
+		return err
+	}
+	if end != nil {
+		if calendarTimeKind(end.DataType) != kind {
+			return fmt.Errorf("end column %s must have the same type as %s", end.Name, start.Name)
+		}
+		data.EndColumn = end.Name
+		data.EndField = types.FormatFieldName(end.Name)
+	}
+
+	title, err := findCalendarColumn(table, columns.Title, calendarTitleColumns)
+	if err != nil {
+		return err
+	}
+	if title != nil {
+		data.TitleField = types.FormatFieldName(title.Name)
+	}
+
+	return nil
+}
+
+// findCalendarColumn returns the named column, or the first candidate the
+// table has when name is empty. It returns nil when nothing matches.
+func findCalendarColumn(table *catalog.Table, name string, candidates []string) (*catalog.Column, error) {
+	if name != "" {
+		col, err := table.GetColumn(name)
+		if err != nil {
+			return nil, fmt.Errorf("column %s does not exist on table %s", name, table.Name)
+		}
+		return col, nil
+	}
+	for _, candidate := range candidates {
+		if col, err := table.GetColumn(candidate); err == nil {
+			return col, nil
+		}
+	}
+	return nil, nil
+}
+
+// calendarTimeKind returns "date" or "timestamp" for column types a feed can
+// use, and "" otherwise.
+func calendarTimeKind(dataType string) string {
+	switch strings.ToLower(strings.TrimSpace(dataType)) {
+	case "date":
+		return "date"
+	case "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone":
+		return "timestamp"
+	default:
+		return ""
+	}
+}
+
+// render writes a template to path, formatting Go output.
+func (c *CalendarManager) render(templateName, path string, data calendarTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := c.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, ".go") {
+		if err := files.FormatGoFile(path); err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// addCalendarToIndexView renders the subscribe panel loader at the bottom of
+// the scaffolded Index page.
+func addCalendarToIndexView(content, resourceName string) (string, error) {
+	if strings.Contains(content, "@CalendarSubscriptionLoader(") {
+		return "", fmt.Errorf("a calendar feed is already enabled")
+	}
+
+	page := regexp.MustCompile(`templ \(\w+ ` + regexp.QuoteMeta(resourceName) + `Index\) Page\(\) \{`)
+	m := page.FindStringIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no %sIndex page found", resourceName)
+	}
+
+	closing := regexp.MustCompile(`\n(\t*)</div>\n\t*</main>`)
+	c := closing.FindStringSubmatchIndex(content[m[1]:])
+	if c == nil {
+		return "", fmt.Errorf("no closing </main> found in the %sIndex page", resourceName)
+	}
+	indent := content[m[1]+c[2] : m[1]+c[3]]
+	insertAt := m[1] + c[0] + 1
+
+	loader := fmt.Sprintf(
+		"%s\t@CalendarSubscriptionLoader(routes.%sCalendarSubscription.URL())\n",
+		indent,
+		resourceName,
+	)
+	return content[:insertAt] + loader + content[insertAt:], nil
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+func TestCalendarTemplatesParse(t *testing.T) {
+	service := templates.GetGlobalTemplateService()
+
+	tests := map[string]calendarTemplateData{
+		"timed with end and title": {
+			StartColumn: "starts_at",
+			StartField:  "StartsAt",
+			EndColumn:   "ends_at",
+			EndField:    "EndsAt",
+			TitleField:  "Title",
+		},
+		"all day without end or title": {
+			StartColumn: "event_date",
+			StartField:  "EventDate",
+			AllDay:      true,
+		},
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			data.ModulePath = "example.com/app"
+			data.ResourceName = "Event"
+			data.ModelName = "Event"
+			data.ModelType = "event"
+			data.ModelReceiver = "e"
+			data.ControllerName = "EventCalendar"
+			data.ReceiverName = "ec"
+			data.ResourceVar = "event"
+			data.TableName = "events"
+			data.CalendarName = "Events"
+			data.CalendarPrefix = "/calendars/events"
+
+			for _, name := range []string{
+				"calendar_controller.tmpl",
+				"calendar_resource_controller.tmpl",
+				"calendar_resource_model.tmpl",
+				"calendar_resource_route.tmpl",
+			} {
+				content, err := service.RenderTemplate(name, data)
+				if err != nil {
+					t.Fatalf("render %s failed: %v", name, err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), name, content, 0); err != nil {
+					t.Fatalf("%s is not valid Go: %v\n%s", name, err, content)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveCalendarColumns(t *testing.T) {
+	table := catalog.NewTable("", "bookings")
+	for _, col := range []*catalog.Column{
+		catalog.NewColumn("id", "uuid"),
+		catalog.NewColumn("name", "text"),
+		catalog.NewColumn("starts_at", "timestamp with time zone"),
+		catalog.NewColumn("ends_at", "timestamp with time zone"),
+		catalog.NewColumn("day", "date"),
+	} {
+		if err := table.AddColumn(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var data calendarTemplateData
+	if err := resolveCalendarColumns(table, CalendarColumns{}, &data); err != nil {
+		t.Fatalf("resolveCalendarColumns returned error: %v", err)
+	}
+	if data.StartField != "StartsAt" || data.EndField != "EndsAt" || data.TitleField != "Name" || data.AllDay {
+		t.Fatalf("unexpected detected columns: %+v", data)
+	}
+
+	data = calendarTemplateData{}
+	if err := resolveCalendarColumns(table, CalendarColumns{StartsAt: "day", EndsAt: "day"}, &data); err != nil {
+		t.Fatalf("resolveCalendarColumns returned error: %v", err)
+	}
+	if !data.AllDay {
+		t.Fatal("expected a date start column to make all-day events")
+	}
+
+	for columns, want := range map[CalendarColumns]string{
+		{StartsAt: "missing"}:                "does not exist",
+		{StartsAt: "name"}:                   "must be a date or timestamp",
+		{StartsAt: "day", EndsAt: "ends_at"}: "same type",
+	} {
+		err := resolveCalendarColumns(table, columns, &calendarTemplateData{})
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("columns %+v: expected error containing %q, got %v", columns, want, err)
+		}
+	}
+}
+
+func TestAddCalendarToIndexView(t *testing.T) {
+	view := `templ (pi ProductIndex) Page() {
+	@base() {
+		<main>
+			<div class="mx-auto">
+				<table></table>
+			</div>
+		</main>
+	}
+}
+`
+	updated, err := addCalendarToIndexView(view, "Product")
+	if err != nil {
+		t.Fatalf("addCalendarToIndexView returned error: %v", err)
+	}
+	want := "\t\t\t\t<table></table>\n\t\t\t\t@CalendarSubscriptionLoader(routes.ProductCalendarSubscription.URL())\n\t\t\t</div>\n\t\t</main>"
+	if !strings.Contains(updated, want) {
+		t.Fatalf("expected loader before the closing container:\n%s", updated)
+	}
+
+	if _, err := addCalendarToIndexView(updated, "Product"); err == nil || !strings.Contains(err.Error(), "already enabled") {
+		t.Fatalf("expected already enabled error, got %v", err)
+	}
+}
@@ -18,6 +18,7 @@ type UnifiedConfig struct {
 // DatabaseConfig contains database-specific configuration
 type DatabaseConfig struct {
 	Type          string   `yaml:"type"`
+	Name          string   `yaml:"name"` // Connection named in DATABASES, empty for the primary database
 	MigrationDirs []string `yaml:"migration_dirs"`
 	DefaultSchema string   `yaml:"default_schema"`
 	Driver        string   `yaml:"driver"`
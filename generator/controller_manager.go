@@ -9,6 +9,7 @@ import (
 	"github.com/mbvlabs/andurel/generator/controllers"
 	"github.com/mbvlabs/andurel/generator/files"
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
@@ -55,6 +56,9 @@ func (c *ControllerManager) resolvePK(cat *catalog.Catalog, tableName string) (P
 		}
 		return PrimaryKeyInfo{Found: false}, nil
 	}
+	if pkInfo.IsComposite() {
+		return PrimaryKeyInfo{}, errCompositePrimaryKey(tableName, pkInfo)
+	}
 	if !pkInfo.IsNamedID {
 		resolved, err := c.pkResolver.ResolveAlternatePK(pkInfo, tableName)
 		if err != nil {
@@ -109,6 +113,9 @@ func (c *ControllerManager) GenerateControllerWithActionsForModel(
 		modelTableNameOverridden = false
 	}
 
+	if err := validateDefaultSchema(tableName); err != nil {
+		return err
+	}
 	if tableNameOverridden {
 		if err := c.validator.ValidateTableNameOverride(resourceName, tableName); err != nil {
 			return fmt.Errorf("table name validation failed: %w", err)
@@ -118,6 +125,13 @@ func (c *ControllerManager) GenerateControllerWithActionsForModel(
 			return err
 		}
 	}
+	if err := validateControllerIdentifiers(resourceName); err != nil {
+		return err
+	}
+	validationCtx := newControllerValidationContext(resourceName, tableName, namespace, c.config)
+	if err := validateControllerNamesAvailable(validationCtx, namespace); err != nil {
+		return err
+	}
 
 	var modelFileName strings.Builder
 	modelFileName.Grow(len(modelName) + 3) // +3 for ".go"
@@ -144,7 +158,7 @@ func (c *ControllerManager) GenerateControllerWithActionsForModel(
 
 	controllerType := controllers.ResourceController
 
-	nullType := c.readNullType()
+	nullType := c.readNullType(modelName)
 
 	fileGen := controllers.NewFileGenerator()
 	if err := fileGen.GenerateControllerWithActionsForModel(cat, resourceName, namespace, modelName, tableName, modelTableName, controllerType, modulePath, c.config.Database.Type, tableNameOverridden, modelTableNameOverridden, nullType, pkInfo.ColumnName, inertia, actions, isAPI); err != nil {
@@ -197,6 +211,12 @@ func (c *ControllerManager) GenerateControllerFromModel(resourceName string) err
 	if err := validateControllerNotExists(validationCtx); err != nil {
 		return err
 	}
+	if err := validateControllerIdentifiers(resourceName); err != nil {
+		return err
+	}
+	if err := validateControllerNamesAvailable(validationCtx, ""); err != nil {
+		return err
+	}
 
 	if _, err := os.Stat(c.config.Paths.Views); os.IsNotExist(err) {
 		return fmt.Errorf(
@@ -222,7 +242,7 @@ func (c *ControllerManager) GenerateControllerFromModel(resourceName string) err
 
 	controllerType := controllers.ResourceController
 
-	nullType := c.readNullType()
+	nullType := c.readNullType(resourceName)
 	inertia := ""
 
 	fileGen := controllers.NewFileGenerator()
@@ -235,10 +255,18 @@ func (c *ControllerManager) GenerateControllerFromModel(resourceName string) err
 	return nil
 }
 
-// readNullType reads the nullable type strategy from andurel.lock.
-// Defaults to "sql.Null" when not configured.
-func (c *ControllerManager) readNullType() string {
-	return ReadNullType()
+// readNullType returns the nullable type strategy of the model a controller
+// is generated for.
+func (c *ControllerManager) readNullType(modelName string) string {
+	return ReadModelNullType(c.config.Paths.Models, modelName)
+}
+
+// ReadModelNullType returns the nullable type strategy of an existing model's
+// entity struct, so code generated for it uses the same field types. It falls
+// back to ReadNullType when the model does not exist or has no nullable
+// fields.
+func ReadModelNullType(modelsDir, modelName string) string {
+	return types.ModelNullType(BuildModelPath(modelsDir, modelName), modelName+"Entity", ReadNullType())
 }
 
 // ReadNullType reads the nullable type strategy from andurel.lock.
@@ -6,7 +6,6 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/jinzhu/inflection"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
 
@@ -23,7 +22,7 @@ type controllerValidationContext struct {
 }
 
 func newControllerValidationContext(resourceName, tableName, namespace string, config *UnifiedConfig) *controllerValidationContext {
-	pluralResourceName := inflection.Plural(resourceName)
+	pluralResourceName := naming.Plural(resourceName)
 	controllerFieldName := pluralResourceName
 	controllerVarName := naming.ToCamelCase(naming.ToSnakeCase(pluralResourceName))
 	controllerConstructor := controllerVarName + " := new" + pluralResourceName + "(db)"
@@ -8,6 +8,7 @@ import (
 
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/pkg/cache"
+	testsuite "github.com/mbvlabs/andurel/pkg/testing"
 	"github.com/sebdah/goldie/v2"
 )
 
@@ -270,7 +271,7 @@ func TestControllerViewGenerationGoldensVueActionDoesNotInheritTemplViewActions(
 	assertControllerViewGoldenFileMissing(t, filepath.Join("resources", "js", "Pages", "Widget", "Index.vue"))
 	assertGeneratedFileContains(t, "controllers/widgets.go", "testapp/internal/hypermedia")
 	assertGeneratedFileContains(t, "controllers/widgets.go", "testapp/internal/inertia")
-	assertGeneratedFileContains(t, "controllers/widgets.go", "return hypermedia.RenderPage(etx, views.WidgetIndex")
+	assertGeneratedFileContains(t, "controllers/widgets.go", "index := views.WidgetIndex{")
 	assertGeneratedFileContains(t, "controllers/widgets.go", "return inertia.Page(etx, \"Widget/Show\"")
 }
 
@@ -288,7 +289,7 @@ func TestControllerViewGenerationGoldensVueActionUpdatesRegisterRoutes(t *testin
 	assertGeneratedFileContains(t, "controllers/widgets.go", "routes.WidgetShow.Path()")
 	assertGeneratedFileContains(t, "controllers/widgets.go", "Handler: w.Index")
 	assertGeneratedFileContains(t, "controllers/widgets.go", "Handler: w.Show")
-	assertGeneratedFileContains(t, "controllers/widgets.go", "return hypermedia.RenderPage(etx, views.WidgetIndex")
+	assertGeneratedFileContains(t, "controllers/widgets.go", "index := views.WidgetIndex{")
 	assertGeneratedFileContains(t, "controllers/widgets.go", "return inertia.Page(etx, \"Widget/Show\"")
 }
 
@@ -379,6 +380,8 @@ func assertControllerViewArtifacts(t *testing.T, g *goldie.Goldie, fixtureDir st
 		}
 		g.Assert(t, filepath.Join(fixtureDir, path), content)
 	}
+
+	testsuite.AssertControllerRoutes(t, "controllers")
 }
 
 func assertControllerViewGoldenPaths(t *testing.T, g *goldie.Goldie, fixtureDir string, paths []string) {
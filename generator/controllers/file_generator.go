@@ -13,6 +13,7 @@ import (
 
 	"github.com/mbvlabs/andurel/generator/files"
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/pkg/constants"
 	"github.com/mbvlabs/andurel/pkg/naming"
@@ -100,6 +101,12 @@ func (fg *FileGenerator) GenerateControllerWithActionsForModel(
 	if nullType != "" {
 		generator.SetNullType(nullType)
 	}
+	generator.SetDecimalType(fg.decimalType())
+	projectTypes, err := fg.projectTypes()
+	if err != nil {
+		return err
+	}
+	generator.SetProjectTypes(projectTypes)
 	renderActions := actions
 	routeActions := actions
 	mergeIntoExistingController := false
@@ -185,6 +192,12 @@ func (fg *FileGenerator) GenerateControllerWithActionsForModel(
 		return fmt.Errorf("failed to format controller file: %w", err)
 	}
 
+	if strings.Contains(controllerContent, "models.SortScope(") {
+		if err := EnsureSortScope(); err != nil {
+			return fmt.Errorf("failed to generate sort scope: %w", err)
+		}
+	}
+
 	if err := fg.mainInjector.InjectController(resourceName, namespace, pluralName); err != nil {
 		return fmt.Errorf("failed to inject controller: %w", err)
 	}
@@ -511,3 +524,25 @@ func filterControllerActions(content string, actions []string) (string, error) {
 	}
 	return out.String(), nil
 }
+
+// decimalType reads the Go type of numeric columns from andurel.lock.
+func (fg *FileGenerator) decimalType() string {
+	rootDir, err := fg.fileManager.FindGoModRoot()
+	if err != nil {
+		return layout.DecimalTypeFloat64
+	}
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		return layout.DecimalTypeFloat64
+	}
+	return lock.DecimalType()
+}
+
+// projectTypes reads the custom type mappings from andurel.types.yaml.
+func (fg *FileGenerator) projectTypes() ([]types.TypeOverride, error) {
+	rootDir, err := fg.fileManager.FindGoModRoot()
+	if err != nil {
+		return nil, nil
+	}
+	return types.LoadTypeOverrides(rootDir)
+}
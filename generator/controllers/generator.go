@@ -3,13 +3,14 @@ package controllers
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/jinzhu/inflection"
 	"github.com/mbvlabs/andurel/generator/files"
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
 	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/generator/internal/validation"
+	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
 
@@ -32,6 +33,26 @@ type GeneratedField struct {
 	CamelCase     string
 	IsSystemField bool
 	IsPointer     bool
+	EnumType      string // Model enum type (e.g., "models.PostStatus") for enum columns
+	EnumNullable  bool
+	// ArrayElemType is the element type of array columns (e.g., "int32"
+	// for []int32). Forms submit arrays as a comma-separated string.
+	ArrayElemType string
+	// PointerElemType is the element type of nullable columns generated as
+	// pointers (e.g., "int32" for *int32). Forms submit numbers as a string
+	// so an empty field becomes nil.
+	PointerElemType string
+	// ElemParse parses one submitted value into parsed and ElemValue
+	// converts parsed to ArrayElemType or PointerElemType.
+	ElemParse string
+	ElemValue string
+	// ProjectType is the Go type andurel.types.yaml maps the column to,
+	// qualified for the controllers package. ProjectParse is the mapping's
+	// parse expression applied to the submitted value; without one the
+	// value is converted to ProjectType.
+	ProjectType    string
+	ProjectParse   string
+	ProjectPackage string
 }
 
 // GeneratedController contains the template data for generated controllers.
@@ -50,13 +71,16 @@ type GeneratedController struct {
 	ModulePath              string
 	Type                    ControllerType
 	DatabaseType            string
+	DatabaseName            string // Connection named in DATABASES the model's table is in, empty for the primary database
 	TableNameOverridden     bool
 	IDType                  string // "uuid.UUID", "int32", "int64", "string"
-	IsAutoIncrementID       bool   // True for serial/bigserial
+	IsAutoIncrementID       bool   // True for serial/bigserial and identity keys
 	IDGoFieldName           string // Go struct field name of PK (e.g., "ID", "UserID")
 	HasPrimaryKey           bool   // Whether the table has any primary key
 	Actions                 []string
-	IsAPI                   bool // Generate JSON API controller under controllers/api
+	IsAPI                   bool     // Generate JSON API controller under controllers/api
+	Geocoded                bool     // Queue a geocode job after create and update
+	ProjectImports          []string // Packages of the andurel.types.yaml types forms submit
 }
 
 // Config controls controller generation for a resource.
@@ -97,6 +121,16 @@ func (g *Generator) SetNullType(nullType string) {
 	g.typeMapper.NullType = nullType
 }
 
+// SetDecimalType sets the Go type of numeric columns.
+func (g *Generator) SetDecimalType(decimalType string) {
+	g.typeMapper.DecimalType = decimalType
+}
+
+// SetProjectTypes sets the type mappings from andurel.types.yaml.
+func (g *Generator) SetProjectTypes(projectTypes []types.TypeOverride) {
+	g.typeMapper.ProjectTypes = projectTypes
+}
+
 // Build converts catalog metadata and config into generated controller data.
 func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedController, error) {
 	modelName := config.ModelName
@@ -109,11 +143,11 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedContro
 	}
 	// Compute PluralResourceName: use resource name as-is when table name is overridden,
 	// otherwise use standard pluralization
-	pluralResourceName := inflection.Plural(config.ResourceName)
+	pluralResourceName := naming.Plural(config.ResourceName)
 	if config.TableNameOverridden {
 		pluralResourceName = config.ResourceName
 	}
-	modelPluralResourceName := inflection.Plural(modelName)
+	modelPluralResourceName := naming.Plural(modelName)
 	if config.ModelTableNameOverridden {
 		modelPluralResourceName = modelName
 	}
@@ -151,13 +185,29 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedContro
 		if err != nil {
 			return nil, fmt.Errorf("table %s not found: %w", tableName, err)
 		}
+		controller.DatabaseName = cat.Database
+
+		enumTypes := make(map[string]string)
+		for _, enum := range models.BuildEnums(cat) {
+			enumTypes[enum.SQLName] = "models." + enum.Name
+		}
 
 		for _, col := range table.Columns {
 			field, err := g.buildField(col)
 			if err != nil {
 				return nil, fmt.Errorf("failed to build field for column %s: %w", col.Name, err)
 			}
+			if enumType, ok := enumTypes[strings.ToLower(col.DataType)]; ok {
+				field.EnumType = enumType
+				field.EnumNullable = col.IsNullable
+			}
 			controller.Fields = append(controller.Fields, field)
+			if field.ProjectPackage != "" && !field.IsSystemField && !slices.Contains(controller.ProjectImports, field.ProjectPackage) {
+				controller.ProjectImports = append(controller.ProjectImports, field.ProjectPackage)
+			}
+			if col.HasAnnotation("geocoded") {
+				controller.Geocoded = true
+			}
 		}
 
 		// Three-pass PK detection:
@@ -201,10 +251,22 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedContro
 func setControllerPK(controller *GeneratedController, col *catalog.Column) {
 	pkType, _ := validation.ClassifyPrimaryKeyType(col.DataType)
 	controller.IDType = validation.GoType(pkType)
-	controller.IsAutoIncrementID = validation.IsAutoIncrement(col.DataType)
+	controller.IsAutoIncrementID = col.IsAutoIncrement || validation.IsAutoIncrement(col.DataType)
 }
 
 // isNullableType returns true if the given type is a pointer or a null-wrapper type.
+// setProjectType makes field submit a string that is parsed, or converted,
+// into the Go type custom maps its column to.
+func setProjectType(field *GeneratedField, custom types.TypeOverride) {
+	field.GoType = strings.Replace(field.GoType, custom.GoType, custom.QualifiedGoType(), 1)
+	field.GoFormType = "string"
+	field.ProjectType = custom.QualifiedGoType()
+	field.ProjectPackage = custom.Package
+	if custom.Parse != "" {
+		field.ProjectParse = strings.ReplaceAll(custom.Parse, "%s", "payload."+field.Name)
+	}
+}
+
 func isNullableType(goType string) bool {
 	if strings.HasPrefix(goType, "*") {
 		return true
@@ -240,11 +302,25 @@ func resolveControllerBaseType(goType string) string {
 	return strings.TrimPrefix(goType, "*")
 }
 
+// elementParsers maps the element type of an array or pointer column to the
+// call parsing one submitted value and the conversion of its result. String
+// elements need no parsing.
+var elementParsers = map[string][2]string{
+	"string":    {"", ""},
+	"bool":      {"strconv.ParseBool(value)", "parsed"},
+	"int16":     {"strconv.ParseInt(value, 10, 16)", "int16(parsed)"},
+	"int32":     {"strconv.ParseInt(value, 10, 32)", "int32(parsed)"},
+	"int64":     {"strconv.ParseInt(value, 10, 64)", "parsed"},
+	"float32":   {"strconv.ParseFloat(value, 32)", "float32(parsed)"},
+	"float64":   {"strconv.ParseFloat(value, 64)", "parsed"},
+	"uuid.UUID": {"uuid.Parse(value)", "parsed"},
+}
+
 func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
 	var goType string
 	var err error
 
-	goType, _, err = g.typeMapper.MapSQLTypeToGo(col.DataType, col.IsNullable)
+	goType, _, err = g.typeMapper.MapColumnToGo(col)
 	if err != nil {
 		return GeneratedField{}, err
 	}
@@ -256,7 +332,7 @@ func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
 		GoType:        goType,
 		DBName:        col.Name,
 		CamelCase:     types.FormatCamelCase(col.Name),
-		IsSystemField: col.Name == "created_at" || col.Name == "updated_at" || col.IsPrimaryKey,
+		IsSystemField: col.Name == "created_at" || col.Name == "updated_at" || col.Name == "deleted_at" || col.IsPrimaryKey || col.IsAutoIncrement || col.Generated != "" || col.HasAnnotation("geocoded"),
 		IsPointer:     isNullableType(goType),
 	}
 
@@ -277,12 +353,18 @@ func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
 		field.GoFormType = "float64"
 	case "bool":
 		field.GoFormType = "bool"
-	case "[]string":
-		field.GoFormType = "[]string"
-	case "[]int32":
-		field.GoFormType = "[]int32"
+	case types.MoneyGoType, types.DecimalGoType, types.IntervalGoType:
+		field.GoFormType = "string"
 	default:
-		if strings.HasPrefix(goType, "sql.Null") || strings.HasPrefix(goType, "bun.Null") {
+		elem, isArray := strings.CutPrefix(goType, "[]")
+		if custom, ok := g.typeMapper.ProjectType(col.DataType); ok && baseGoType == custom.GoType {
+			setProjectType(&field, custom)
+		} else if parser, ok := elementParsers[elem]; isArray && ok {
+			field.GoFormType = "string"
+			field.ArrayElemType = elem
+			field.ElemParse = parser[0]
+			field.ElemValue = parser[1]
+		} else if strings.HasPrefix(goType, "sql.Null") || strings.HasPrefix(goType, "bun.Null") {
 			field.GoFormType = "string"
 		} else if isNullableType(goType) {
 			field.GoFormType = goType
@@ -291,5 +373,16 @@ func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
 		}
 	}
 
+	if elem, ok := strings.CutPrefix(goType, "*"); ok && elem != "uuid.UUID" {
+		if parser, ok := elementParsers[elem]; ok {
+			field.PointerElemType = elem
+			field.ElemParse = parser[0]
+			field.ElemValue = parser[1]
+			if elem != "bool" {
+				field.GoFormType = "string"
+			}
+		}
+	}
+
 	return field, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 )
 
 func TestIsNullableType(t *testing.T) {
@@ -152,7 +153,7 @@ func TestBuildField_NullableString(t *testing.T) {
 		formType  string
 		isPointer bool
 	}{
-		{"pointer", "pointer", "*string", "*string", true},
+		{"pointer", "pointer", "*string", "string", true},
 		{"sql.Null", "sql.Null", "sql.NullString", "string", true},
 		{"bun.Null", "bun.Null", "bun.NullString", "string", true},
 	}
@@ -194,7 +195,7 @@ func TestBuildField_NullableInt32(t *testing.T) {
 		formType  string
 		isPointer bool
 	}{
-		{"pointer", "pointer", "*int32", "int32", true},
+		{"pointer", "pointer", "*int32", "string", true},
 		{"sql.Null", "sql.Null", "sql.NullInt32", "int32", true},
 		{"bun.Null", "bun.Null", "bun.NullInt32", "int32", true},
 	}
@@ -278,7 +279,7 @@ func TestBuildField_NullableFloat64(t *testing.T) {
 		formType  string
 		isPointer bool
 	}{
-		{"pointer", "pointer", "*float64", "float64", true},
+		{"pointer", "pointer", "*float64", "string", true},
 		{"sql.Null", "sql.Null", "sql.NullFloat64", "float64", true},
 		{"bun.Null", "bun.Null", "bun.NullFloat64", "float64", true},
 	}
@@ -312,6 +313,54 @@ func TestBuildField_NullableFloat64(t *testing.T) {
 	}
 }
 
+func TestBuildField_MoneyColumns(t *testing.T) {
+	gen := NewGenerator("postgresql")
+
+	tests := []struct {
+		col        *catalog.Column
+		wantGoType string
+	}{
+		{&catalog.Column{Name: "price_cents", DataType: "bigint"}, "money.Money"},
+		{&catalog.Column{Name: "fee_cents", DataType: "numeric", IsNullable: true}, "*money.Money"},
+	}
+
+	for _, tt := range tests {
+		field, err := gen.buildField(tt.col)
+		if err != nil {
+			t.Fatalf("buildField(%s) failed: %v", tt.col.Name, err)
+		}
+		if field.GoType != tt.wantGoType {
+			t.Errorf("%s GoType = %q, want %q", tt.col.Name, field.GoType, tt.wantGoType)
+		}
+		if field.GoFormType != "string" {
+			t.Errorf("%s GoFormType = %q, want string", tt.col.Name, field.GoFormType)
+		}
+	}
+}
+
+func TestBuildField_DecimalColumns(t *testing.T) {
+	gen := NewGenerator("postgresql")
+	gen.SetDecimalType("decimal")
+
+	tests := []struct {
+		col        *catalog.Column
+		wantGoType string
+	}{
+		{&catalog.Column{Name: "rate", DataType: "numeric(10,4)"}, "decimal.Decimal"},
+		{&catalog.Column{Name: "discount", DataType: "numeric", IsNullable: true}, "*decimal.Decimal"},
+	}
+
+	for _, tt := range tests {
+		field, err := gen.buildField(tt.col)
+		if err != nil {
+			t.Fatalf("buildField(%s) failed: %v", tt.col.Name, err)
+		}
+		if field.GoType != tt.wantGoType || field.GoFormType != "string" {
+			t.Errorf("%s = %q/%q, want %q/string", tt.col.Name, field.GoType, field.GoFormType, tt.wantGoType)
+		}
+	}
+}
+
 func TestBuildField_SystemFields(t *testing.T) {
 	gen := NewGenerator("postgresql")
 
@@ -387,6 +436,18 @@ func TestInertiaDataTypeAndValue(t *testing.T) {
 			wantType:  "string",
 			wantValue: `func() string { if entity.Subtitle == nil { return "" }; return *entity.Subtitle }()`,
 		},
+		{
+			name:      "money",
+			field:     GeneratedField{Name: "PriceCents", GoType: "money.Money"},
+			wantType:  "string",
+			wantValue: "entity.PriceCents.String()",
+		},
+		{
+			name:      "pointer money",
+			field:     GeneratedField{Name: "FeeCents", GoType: "*money.Money"},
+			wantType:  "string",
+			wantValue: `func() string { if entity.FeeCents == nil { return "" }; return entity.FeeCents.String() }()`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -449,3 +510,254 @@ func TestRenderInertiaControllerUsesDataStructAndRawMessagePlaceholder(t *testin
 		}
 	}
 }
+
+func TestRenderControllerParsesMoneyPayloads(t *testing.T) {
+	controller := &GeneratedController{
+		ResourceName:       "Invoice",
+		PluralName:         "invoices",
+		PluralResourceName: "Invoices",
+		ReceiverName:       "i",
+		Package:            "controllers",
+		ModulePath:         "testapp",
+		Type:               ResourceController,
+		IDType:             "uuid.UUID",
+		IDGoFieldName:      "ID",
+		HasPrimaryKey:      true,
+		Fields: []GeneratedField{
+			{Name: "ID", GoType: "uuid.UUID", GoFormType: "string", IsSystemField: true},
+			{Name: "PriceCents", GoType: "money.Money", GoFormType: "string", CamelCase: "priceCents"},
+			{Name: "FeeCents", GoType: "*money.Money", GoFormType: "string", CamelCase: "feeCents", IsPointer: true},
+		},
+	}
+
+	for _, inertia := range []string{"", "vue"} {
+		rendered, err := NewTemplateRenderer().RenderControllerFile(controller, inertia)
+		if err != nil {
+			t.Fatalf("RenderControllerFile(%q) failed: %v", inertia, err)
+		}
+		for _, snippet := range []string{
+			`"testapp/internal/money"`,
+			"PriceCents    string `json:\"priceCents\"`",
+			"PriceCents:    func() money.Money {",
+			"FeeCents:    func() *money.Money {",
+			"money.Parse(payload.FeeCents, money.DefaultCurrency)",
+		} {
+			if !strings.Contains(rendered, snippet) {
+				t.Fatalf("RenderControllerFile(%q) missing %q\n\n%s", inertia, snippet, rendered)
+			}
+		}
+	}
+}
+
+func TestRenderControllerParsesDecimalPayloads(t *testing.T) {
+	controller := &GeneratedController{
+		ResourceName:       "Rate",
+		PluralName:         "rates",
+		PluralResourceName: "Rates",
+		ReceiverName:       "r",
+		Package:            "controllers",
+		ModulePath:         "testapp",
+		Type:               ResourceController,
+		IDType:             "uuid.UUID",
+		IDGoFieldName:      "ID",
+		HasPrimaryKey:      true,
+		Fields: []GeneratedField{
+			{Name: "ID", GoType: "uuid.UUID", GoFormType: "string", IsSystemField: true},
+			{Name: "Value", GoType: "decimal.Decimal", GoFormType: "string", CamelCase: "value"},
+			{Name: "Cap", GoType: "*decimal.Decimal", GoFormType: "string", CamelCase: "cap", IsPointer: true},
+		},
+	}
+
+	for _, inertia := range []string{"", "vue"} {
+		rendered, err := NewTemplateRenderer().RenderControllerFile(controller, inertia)
+		if err != nil {
+			t.Fatalf("RenderControllerFile(%q) failed: %v", inertia, err)
+		}
+		for _, snippet := range []string{
+			`"github.com/shopspring/decimal"`,
+			"Value:    func() decimal.Decimal {",
+			"return decimal.Zero",
+			"Cap:    func() *decimal.Decimal {",
+			"decimal.NewFromString(payload.Cap)",
+		} {
+			if !strings.Contains(rendered, snippet) {
+				t.Fatalf("RenderControllerFile(%q) missing %q\n\n%s", inertia, snippet, rendered)
+			}
+		}
+	}
+}
+
+func TestRenderControllerParsesIntervalPayloads(t *testing.T) {
+	gen := NewGenerator("postgresql")
+	length, err := gen.buildField(&catalog.Column{Name: "length", DataType: "interval"})
+	if err != nil {
+		t.Fatalf("buildField(length) failed: %v", err)
+	}
+	if length.GoType != "interval.Duration" || length.GoFormType != "string" {
+		t.Fatalf("length = %q/%q, want interval.Duration/string", length.GoType, length.GoFormType)
+	}
+
+	controller := &GeneratedController{
+		ResourceName:       "Shift",
+		PluralName:         "shifts",
+		PluralResourceName: "Shifts",
+		ReceiverName:       "s",
+		Package:            "controllers",
+		ModulePath:         "testapp",
+		Type:               ResourceController,
+		IDType:             "uuid.UUID",
+		IDGoFieldName:      "ID",
+		HasPrimaryKey:      true,
+		Fields: []GeneratedField{
+			{Name: "ID", GoType: "uuid.UUID", GoFormType: "string", IsSystemField: true},
+			{Name: "Length", GoType: "interval.Duration", GoFormType: "string", CamelCase: "length"},
+			{Name: "BreakTime", GoType: "*interval.Duration", GoFormType: "string", CamelCase: "breakTime", IsPointer: true},
+		},
+	}
+
+	for _, inertia := range []string{"", "vue"} {
+		rendered, err := NewTemplateRenderer().RenderControllerFile(controller, inertia)
+		if err != nil {
+			t.Fatalf("RenderControllerFile(%q) failed: %v", inertia, err)
+		}
+		for _, snippet := range []string{
+			`"testapp/internal/interval"`,
+			"Length:    func() interval.Duration {",
+			"interval.Parse(payload.Length)",
+			"BreakTime:    func() *interval.Duration {",
+		} {
+			if !strings.Contains(rendered, snippet) {
+				t.Fatalf("RenderControllerFile(%q) missing %q\n\n%s", inertia, snippet, rendered)
+			}
+		}
+	}
+}
+
+func TestRenderControllerConvertsNullablePointerPayloads(t *testing.T) {
+	gen := NewGenerator("postgresql")
+	gen.SetNullType("pointer")
+
+	var fields []GeneratedField
+	for _, col := range []*catalog.Column{
+		{Name: "nickname", DataType: "text", IsNullable: true},
+		{Name: "stock", DataType: "integer", IsNullable: true},
+		{Name: "active", DataType: "boolean", IsNullable: true},
+	} {
+		field, err := gen.buildField(col)
+		if err != nil {
+			t.Fatalf("buildField(%s) failed: %v", col.Name, err)
+		}
+		fields = append(fields, field)
+	}
+	if fields[1].PointerElemType != "int32" || fields[1].GoFormType != "string" {
+		t.Fatalf("stock = %q/%q, want int32/string", fields[1].PointerElemType, fields[1].GoFormType)
+	}
+	if fields[2].GoFormType != "bool" {
+		t.Fatalf("active GoFormType = %q, want bool", fields[2].GoFormType)
+	}
+
+	controller := &GeneratedController{
+		ResourceName:       "Gadget",
+		PluralName:         "gadgets",
+		PluralResourceName: "Gadgets",
+		ReceiverName:       "g",
+		Package:            "controllers",
+		ModulePath:         "testapp",
+		Type:               ResourceController,
+		IDType:             "uuid.UUID",
+		IDGoFieldName:      "ID",
+		HasPrimaryKey:      true,
+		Fields:             append([]GeneratedField{{Name: "ID", GoType: "uuid.UUID", GoFormType: "string", IsSystemField: true}}, fields...),
+	}
+
+	rendered, err := NewTemplateRenderer().RenderControllerFile(controller, "")
+	if err != nil {
+		t.Fatalf("RenderControllerFile failed: %v", err)
+	}
+	for _, snippet := range []string{
+		`"strings"`,
+		"Nickname:    func() *string {",
+		"Stock:    func() *int32 {",
+		"strconv.ParseInt(value, 10, 32)",
+		"converted := int32(parsed)",
+		"Active:    func() *bool {",
+	} {
+		if !strings.Contains(rendered, snippet) {
+			t.Fatalf("RenderControllerFile missing %q\n\n%s", snippet, rendered)
+		}
+	}
+}
+
+func TestRenderControllerConvertsProjectTypePayloads(t *testing.T) {
+	gen := NewGenerator("postgresql")
+	gen.SetProjectTypes([]types.TypeOverride{
+		{DatabaseType: "inet", GoType: "netip.Addr", Package: "net/netip", Parse: "netip.ParseAddr(%s)"},
+		{DatabaseType: "citext", GoType: "Email"},
+	})
+
+	var fields []GeneratedField
+	for _, col := range []*catalog.Column{
+		{Name: "address", DataType: "inet"},
+		{Name: "fallback", DataType: "inet", IsNullable: true},
+		{Name: "contact", DataType: "citext"},
+	} {
+		field, err := gen.buildField(col)
+		if err != nil {
+			t.Fatalf("buildField(%s) failed: %v", col.Name, err)
+		}
+		fields = append(fields, field)
+	}
+	if fields[1].GoType != "*netip.Addr" || fields[1].GoFormType != "string" {
+		t.Fatalf("fallback = %q/%q, want *netip.Addr/string", fields[1].GoType, fields[1].GoFormType)
+	}
+	if fields[2].ProjectType != "models.Email" {
+		t.Fatalf("contact ProjectType = %q, want models.Email", fields[2].ProjectType)
+	}
+
+	controller := &GeneratedController{
+		ResourceName:       "Host",
+		PluralName:         "hosts",
+		PluralResourceName: "Hosts",
+		ReceiverName:       "h",
+		Package:            "controllers",
+		ModulePath:         "testapp",
+		Type:               ResourceController,
+		IDType:             "uuid.UUID",
+		IDGoFieldName:      "ID",
+		HasPrimaryKey:      true,
+		Fields:             append([]GeneratedField{{Name: "ID", GoType: "uuid.UUID", GoFormType: "string", IsSystemField: true}}, fields...),
+		ProjectImports:     []string{"net/netip"},
+	}
+
+	rendered, err := NewTemplateRenderer().RenderControllerFile(controller, "")
+	if err != nil {
+		t.Fatalf("RenderControllerFile failed: %v", err)
+	}
+	for _, snippet := range []string{
+		`"net/netip"`,
+		"Address:    func() netip.Addr {",
+		"parsed, err := netip.ParseAddr(payload.Address)",
+		"Fallback:    func() *netip.Addr {",
+		"value = &parsed",
+		"Contact:    models.Email(payload.Contact),",
+	} {
+		if !strings.Contains(rendered, snippet) {
+			t.Fatalf("RenderControllerFile missing %q\n\n%s", snippet, rendered)
+		}
+	}
+}
+
+func TestBuildField_GeocodedColumnsAreSystemFields(t *testing.T) {
+	gen := NewGenerator("postgresql")
+
+	col := catalog.NewColumn("latitude", "double precision").SetComment("andurel:geocoded")
+	col.IsNullable = true
+
+	field, err := gen.buildField(col)
+	if err != nil {
+		t.Fatalf("buildField failed: %v", err)
+	}
+	if !field.IsSystemField {
+		t.Error("geocoded columns should be left out of forms")
+	}
+}
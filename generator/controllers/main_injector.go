@@ -32,7 +32,7 @@ func NewMainInjector() *MainInjector {
 // Returns nil if the file or expected module shape is not found, after printing
 // instructions for a manual update.
 func (mi *MainInjector) InjectController(resourceName, namespace, pluralName string) error {
-	capitalizedPlural := naming.Capitalize(naming.ToCamelCase(pluralName))
+	capitalizedPlural := naming.ToPascalCase(pluralName)
 	packageName := naming.ControllerPackageName(namespace)
 
 	rootDir, err := mi.fileManager.FindGoModRoot()
@@ -112,6 +112,85 @@ func (mi *MainInjector) InjectController(resourceName, namespace, pluralName str
 	return nil
 }
 
+// RemoveController takes a resource controller out of controllers.Module,
+// undoing InjectController. The namespace import is dropped once nothing else
+// in the file refers to the package. Returns nil if the file does not exist.
+func (mi *MainInjector) RemoveController(namespace, pluralName string) error {
+	capitalizedPlural := naming.ToPascalCase(pluralName)
+	packageName := naming.ControllerPackageName(namespace)
+
+	rootDir, err := mi.fileManager.FindGoModRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root for controller removal: %w", err)
+	}
+
+	controllerFilePath := filepath.Join(rootDir, controllerFileRelPath)
+	content, err := os.ReadFile(controllerFilePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read controllers/controller.go: %w", err)
+	}
+
+	controllerType := capitalizedPlural
+	if namespace != "" {
+		controllerType = packageName + "." + capitalizedPlural
+	}
+	updated := removeControllerRegistration(string(content), controllerType)
+
+	if namespace != "" && !strings.Contains(stripImports(updated), packageName+".") {
+		modulePath, err := readModulePathFromRoot(rootDir)
+		if err != nil {
+			return fmt.Errorf("failed to read module path for controller removal: %w", err)
+		}
+		importLine := regexp.MustCompile(`(?m)^\t(?:\w+ )?"` + regexp.QuoteMeta(modulePath+"/controllers/"+namespace) + `"\n`)
+		updated = importLine.ReplaceAllString(updated, "")
+	}
+
+	if updated == string(content) {
+		return nil
+	}
+
+	if err := os.WriteFile(controllerFilePath, []byte(updated), 0644); err != nil {
+		return fmt.Errorf("failed to write controllers/controller.go: %w", err)
+	}
+
+	if err := files.FormatGoFile(controllerFilePath); err != nil {
+		return fmt.Errorf("failed to format controllers/controller.go: %w", err)
+	}
+
+	return nil
+}
+
+// removeControllerRegistration drops the constructor and the RegisterRoutes
+// invoke of controllerType, in either of the shapes InjectController writes.
+func removeControllerRegistration(content, controllerType string) string {
+	constructorRef := regexp.QuoteMeta(constructorName(controllerType))
+	constructor := regexp.MustCompile(`(?m)^\t(?:` + constructorRef + `|fx\.Provide\(` + constructorRef + `\)),\n`)
+	content = constructor.ReplaceAllString(content, "")
+
+	invoke := regexp.MustCompile(`(?m)^\tfx\.Invoke\(func\(r \*router\.Router, c ` + regexp.QuoteMeta(controllerType) +
+		`\) error \{\n\t\treturn c\.RegisterRoutes\(r\)\n\t\}\),\n`)
+	return invoke.ReplaceAllString(content, "")
+}
+
+// constructorName is the constructor of controllerType, e.g. admin.NewWidgets
+// for admin.Widgets.
+func constructorName(controllerType string) string {
+	if pkg, name, ok := strings.Cut(controllerType, "."); ok {
+		return pkg + ".New" + name
+	}
+	return "New" + controllerType
+}
+
+// stripImports returns content without its import declarations.
+func stripImports(content string) string {
+	block := regexp.MustCompile(`(?s)import \(.*?\n\)`)
+	single := regexp.MustCompile(`(?m)^import .*$`)
+	return single.ReplaceAllString(block.ReplaceAllString(content, ""), "")
+}
+
 func ensureConstructorRegistration(content, constructorRef string) (string, bool, error) {
 	if hasRegistrationReference(content, constructorRef) {
 		return content, false, nil
@@ -175,7 +254,7 @@ func findMatchingParen(content string, openIdx int) int {
 }
 
 func (mi *MainInjector) printManualInstructions(resourceName, namespace, pluralName string) {
-	capitalizedPlural := naming.Capitalize(naming.ToCamelCase(pluralName))
+	capitalizedPlural := naming.ToPascalCase(pluralName)
 	packageName := naming.ControllerPackageName(namespace)
 	constructorRef := "New" + capitalizedPlural
 	controllerType := capitalizedPlural
@@ -0,0 +1,46 @@
+package controllers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
+)
+
+var sortScopePath = filepath.Join("models", "sort.go")
+
+// EnsureSortScope writes models/sort.go with models.SortScope, which index
+// actions order their page by, unless the models package declares it
+// already. Projects that added saved views before it moved there have it in
+// models/saved_view.go.
+func EnsureSortScope() error {
+	modelFiles, err := filepath.Glob(filepath.Join("models", "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range modelFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if strings.Contains(string(content), "\nfunc SortScope(") {
+			return nil
+		}
+	}
+
+	content, err := templates.GetGlobalTemplateService().RenderTemplate("sort_scope_model.tmpl", nil)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", sortScopePath, err)
+	}
+	if err := os.MkdirAll("models", constants.DirPermissionDefault); err != nil {
+		return err
+	}
+	if err := os.WriteFile(sortScopePath, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sortScopePath, err)
+	}
+	return files.FormatGoFile(sortScopePath)
+}
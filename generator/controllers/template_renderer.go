@@ -134,7 +134,9 @@ func (tr *TemplateRenderer) RenderControllerFile(controller *GeneratedController
 
 func inertiaDataType(field GeneratedField) string {
 	switch field.GoType {
-	case "sql.NullString", "bun.NullString", "json.RawMessage", "*json.RawMessage", "[]byte":
+	case "sql.NullString", "bun.NullString", "json.RawMessage", "*json.RawMessage", "[]byte",
+		"money.Money", "*money.Money", "decimal.Decimal", "*decimal.Decimal",
+		"interval.Duration", "*interval.Duration":
 		return "string"
 	case "sql.NullBool", "bun.NullBool":
 		return "bool"
@@ -179,6 +181,10 @@ func inertiaDataValue(field GeneratedField, source string) string {
 		return "func() string { if " + source + " == nil { return \"\" }; return string(*" + source + ") }()"
 	case "[]byte":
 		return "string(" + source + ")"
+	case "money.Money", "decimal.Decimal", "interval.Duration":
+		return source + ".String()"
+	case "*money.Money", "*decimal.Decimal", "*interval.Duration":
+		return "func() string { if " + source + " == nil { return \"\" }; return " + source + ".String() }()"
 	}
 
 	if strings.HasPrefix(field.GoType, "*") {
@@ -85,6 +85,43 @@ func TestRenderAPIControllerUsesLastNamespaceSegmentAsPackage(t *testing.T) {
 	}
 }
 
+func TestRenderAPIControllerRespondsWithSerializers(t *testing.T) {
+	controller := &GeneratedController{
+		ResourceName:            "Work",
+		ModelName:               "Work",
+		PluralName:              "works",
+		ModelPluralName:         "works",
+		PluralResourceName:      "Works",
+		ModelPluralResourceName: "Works",
+		ReceiverName:            "w",
+		Namespace:               "api",
+		NamespacePascal:         "Api",
+		ModulePath:              "example.com/app",
+		Type:                    ResourceController,
+		IDType:                  "uuid.UUID",
+		IDGoFieldName:           "ID",
+		Actions:                 []string{"index", "show", "create", "update"},
+		IsAPI:                   true,
+	}
+
+	rendered, err := NewTemplateRenderer().RenderControllerFile(controller, "")
+	if err != nil {
+		t.Fatalf("RenderControllerFile returned error: %v", err)
+	}
+
+	for _, part := range []string{
+		`"example.com/app/serializers"`,
+		"etx.JSON(http.StatusOK, serializers.NewWorkList(worksList.Works))",
+		"etx.JSON(http.StatusOK, serializers.NewWork(work))",
+		"etx.JSON(http.StatusCreated, serializers.NewWork(work))",
+	} {
+		if !strings.Contains(rendered, part) {
+			t.Fatalf("expected rendered API controller to contain %q:\n%s", part, rendered)
+		}
+	}
+
+}
+
 func TestRenderNormalControllerStillHonorsRequestedActions(t *testing.T) {
 	controller := &GeneratedController{
 		ResourceName:            "Work",
@@ -15,6 +15,13 @@ type Coordinator struct {
 	ControllerManager *ControllerManager
 	ViewManager       *ViewManager
 	ActionManager     *ActionManager
+	FilterManager     *FilterManager
+	ShareManager      *ShareManager
+	CalendarManager   *CalendarManager
+	FeedManager       *FeedManager
+	AddressManager    *AddressManager
+	SerializerManager *SerializerManager
+	DestroyManager    *DestroyManager
 	projectManager    *ProjectManager
 	config            *UnifiedConfig
 }
@@ -34,6 +41,14 @@ func NewCoordinator() (Coordinator, error) {
 
 	validator := NewInputValidator()
 	fileManager := files.NewUnifiedFileManager()
+
+	rootDir, err := fileManager.FindGoModRoot()
+	if err != nil {
+		return Coordinator{}, fmt.Errorf("failed to find go.mod: %w", err)
+	}
+	if err := naming.LoadInflections(rootDir); err != nil {
+		return Coordinator{}, fmt.Errorf("failed to load inflections: %w", err)
+	}
 	migrationManager := NewMigrationManager()
 
 	// Create generators
@@ -67,16 +82,100 @@ func NewCoordinator() (Coordinator, error) {
 
 	actionManager := NewActionManager()
 
+	filterManager := NewFilterManager(
+		validator,
+		fileManager,
+		projectManager,
+		migrationManager,
+		unifiedConfig,
+	)
+
+	shareManager := NewShareManager(
+		validator,
+		fileManager,
+		projectManager,
+		migrationManager,
+		viewGenerator,
+		unifiedConfig,
+	)
+
+	calendarManager := NewCalendarManager(
+		validator,
+		fileManager,
+		projectManager,
+		migrationManager,
+		unifiedConfig,
+	)
+
+	feedManager := NewFeedManager(
+		validator,
+		fileManager,
+		projectManager,
+		migrationManager,
+		unifiedConfig,
+	)
+
+	addressManager := NewAddressManager(
+		validator,
+		fileManager,
+		projectManager,
+		migrationManager,
+		unifiedConfig,
+	)
+
+	serializerManager := NewSerializerManager(
+		validator,
+		fileManager,
+		projectManager,
+		unifiedConfig,
+	)
+
+	destroyManager := NewDestroyManager(
+		validator,
+		unifiedConfig,
+	)
+
 	return Coordinator{
 		ModelManager:      modelManager,
 		ControllerManager: controllerManager,
 		ViewManager:       viewManager,
 		ActionManager:     actionManager,
+		FilterManager:     filterManager,
+		ShareManager:      shareManager,
+		CalendarManager:   calendarManager,
+		FeedManager:       feedManager,
+		AddressManager:    addressManager,
+		SerializerManager: serializerManager,
+		DestroyManager:    destroyManager,
 		projectManager:    projectManager,
 		config:            unifiedConfig,
 	}, nil
 }
 
+// SetDatabaseName makes generation read the migrations of the connection
+// named in DATABASES, in database/<name>/migrations, and route the generated
+// queries to it. An empty name is the primary database.
+func (c *Coordinator) SetDatabaseName(name string) error {
+	if name != "" {
+		if err := ValidateDatabaseName(name); err != nil {
+			return err
+		}
+	}
+	useDatabase(c.config, name)
+	return nil
+}
+
+// useModelDatabase switches to the database modelName was generated for,
+// unless SetDatabaseName chose one.
+func (c *Coordinator) useModelDatabase(modelName string) {
+	if c.config.Database.Name != "" {
+		return
+	}
+	if name := ExtractDatabaseName(BuildModelPath(c.config.Paths.Models, modelName), modelName); name != "" {
+		useDatabase(c.config, name)
+	}
+}
+
 // GenerateController coordinates controller and optional view generation
 func (c *Coordinator) GenerateController(resourceName, namespace, tableName string, inertia string, isAPI bool) error {
 	return c.GenerateControllerWithActions(resourceName, namespace, tableName, nil, inertia, isAPI)
@@ -95,13 +194,14 @@ func (c *Coordinator) GenerateControllerWithActionsForModel(resourceName, namesp
 	if tableName == "" {
 		tableName = naming.DeriveTableName(resourceName)
 	}
+	c.useModelDatabase(modelName)
 
 	if err := c.ControllerManager.GenerateControllerWithActionsForModel(resourceName, namespace, modelName, tableName, actions, inertia, isAPI); err != nil {
 		return err
 	}
 
 	if isAPI {
-		return nil
+		return c.SerializerManager.EnsureSerializer(modelName)
 	}
 
 	if err := c.ViewManager.GenerateViewWithControllerActionsForModel(resourceName, modelName, tableName, namespace, actions, inertia); err != nil {
@@ -114,6 +214,21 @@ func (c *Coordinator) GenerateControllerWithActionsForModel(resourceName, namesp
 // GenerateScaffold coordinates model, controller, and view generation for a
 // complete resource scaffold.
 func (c *Coordinator) GenerateScaffold(resourceName, namespace, tableName string, skipFactory bool, primaryKeyColumn string, inertia string, isAPI bool) error {
+	if err := validateDefaultSchema(tableName); err != nil {
+		return err
+	}
+	// The model is written first, so check the controller's names before
+	// it rather than fail halfway through the scaffold.
+	if err := validateControllerIdentifiers(resourceName); err != nil {
+		return err
+	}
+	controllerTableName := tableName
+	if controllerTableName == "" {
+		controllerTableName = naming.DeriveTableName(resourceName)
+	}
+	if err := validateControllerNamesAvailable(newControllerValidationContext(resourceName, controllerTableName, namespace, c.config), namespace); err != nil {
+		return err
+	}
 	if primaryKeyColumn != "" {
 		if err := c.ModelManager.GenerateModel(resourceName, tableName, skipFactory, primaryKeyColumn); err != nil {
 			return err
@@ -130,6 +245,7 @@ func (c *Coordinator) GenerateScaffold(resourceName, namespace, tableName string
 
 // GenerateControllerFromModel coordinates controller and view generation from existing model
 func (c *Coordinator) GenerateControllerFromModel(resourceName string) error {
+	c.useModelDatabase(resourceName)
 	if err := c.ControllerManager.GenerateControllerFromModel(resourceName); err != nil {
 		return err
 	}
@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var databaseNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// reservedDatabaseNames are directories under database/ that are not the
+// home of a named connection.
+var reservedDatabaseNames = map[string]bool{
+	"migrations": true,
+	"seeds":      true,
+}
+
+// ValidateDatabaseName checks the name of a connection listed in DATABASES.
+// It names the database/<name>/migrations directory and prefixes the
+// connection's environment variables, so it must be a lower-case
+// identifier.
+func ValidateDatabaseName(name string) error {
+	if !databaseNamePattern.MatchString(name) {
+		return fmt.Errorf("database name %q must be lower-case letters, digits and underscores, starting with a letter", name)
+	}
+	if reservedDatabaseNames[name] {
+		return fmt.Errorf("database name %q is reserved; database/%s holds the primary database's files", name, name)
+	}
+	return nil
+}
+
+// MigrationsDir returns the migrations directory of a database, relative to
+// the project root: database/migrations for the primary database, the empty
+// name, and database/<name>/migrations for a named one.
+func MigrationsDir(databaseName string) string {
+	if databaseName == "" {
+		return filepath.Join("database", "migrations")
+	}
+	return filepath.Join("database", databaseName, "migrations")
+}
+
+// DatabaseEnvPrefix returns the prefix of a database's environment
+// variables, as in ANALYTICS_DB_HOST for analytics. The primary database
+// has none.
+func DatabaseEnvPrefix(databaseName string) string {
+	if databaseName == "" {
+		return ""
+	}
+	return strings.ToUpper(databaseName) + "_"
+}
+
+// useDatabase points config at the migrations of the named database.
+func useDatabase(config *UnifiedConfig, databaseName string) {
+	config.Database.Name = databaseName
+	config.Database.MigrationDirs = []string{MigrationsDir(databaseName)}
+	config.Paths.Migrations = MigrationsDir(databaseName)
+}
+
+// ExtractDatabaseName reads the connection a model was generated for from
+// its <Model>Database constant, e.g. const ReportDatabase = "analytics". It
+// returns an empty name for models of the primary database.
+func ExtractDatabaseName(modelPath, resourceName string) string {
+	content, err := os.ReadFile(modelPath)
+	if err != nil {
+		return ""
+	}
+
+	prefix := "const " + resourceName + "Database = "
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, prefix); ok {
+			return strings.Trim(value, `"`)
+		}
+	}
+
+	return ""
+}
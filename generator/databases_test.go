@@ -0,0 +1,123 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/cache"
+)
+
+func TestValidateDatabaseName(t *testing.T) {
+	for _, name := range []string{"analytics", "legacy_crm", "db2"} {
+		if err := ValidateDatabaseName(name); err != nil {
+			t.Errorf("ValidateDatabaseName(%q) = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "Analytics", "2db", "legacy-crm", "migrations", "seeds"} {
+		if err := ValidateDatabaseName(name); err == nil {
+			t.Errorf("ValidateDatabaseName(%q) accepted an invalid name", name)
+		}
+	}
+
+	if got := MigrationsDir(""); got != filepath.Join("database", "migrations") {
+		t.Errorf("MigrationsDir(\"\") = %q", got)
+	}
+	if got := MigrationsDir("analytics"); got != filepath.Join("database", "analytics", "migrations") {
+		t.Errorf("MigrationsDir(analytics) = %q", got)
+	}
+	if got := DatabaseEnvPrefix("legacy_crm"); got != "LEGACY_CRM_" {
+		t.Errorf("DatabaseEnvPrefix(legacy_crm) = %q", got)
+	}
+}
+
+func TestGenerateForNamedDatabase(t *testing.T) {
+	cache.ClearFileSystemCache()
+	t.Cleanup(cache.ClearFileSystemCache)
+
+	projectDir := t.TempDir()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := os.Chdir(originalDir); err != nil {
+			t.Fatalf("failed to restore working directory: %v", err)
+		}
+	})
+
+	migration, err := os.ReadFile(filepath.Join(modelGenerationFixtureDir(t, "controller_view_generation"), "000100_create_widgets.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeControllerViewFixtureFile(t, projectDir, "go.mod", "module testapp\n\ngo 1.26\n")
+	writeControllerViewFixtureFile(t, projectDir, "models/model.go", modelNamespaceFixture)
+	writeControllerViewFixtureFile(t, projectDir, "controllers/controller.go", controllerModuleFixture)
+	writeControllerViewFixtureFile(t, projectDir, "database/analytics/migrations/000100_create_widgets.sql", string(migration))
+	writeControllerViewFixtureFile(t, projectDir, "bin/templ", "#!/bin/sh\nexit 0\n")
+	if err := os.Chmod(filepath.Join(projectDir, "bin", "templ"), 0o755); err != nil {
+		t.Fatalf("failed to chmod fake templ binary: %v", err)
+	}
+
+	lock := layout.NewAndurelLock("test")
+	lock.DatabaseConfig = &layout.DatabaseConfig{NullType: "sql.Null"}
+	lock.ScaffoldConfig = &layout.ScaffoldConfig{ProjectName: "testapp", Database: "postgresql"}
+	if err := lock.WriteLockFile(projectDir); err != nil {
+		t.Fatalf("failed to write andurel.lock: %v", err)
+	}
+	if err := os.Chdir(projectDir); err != nil {
+		t.Fatalf("failed to enter temp project: %v", err)
+	}
+
+	coord, err := NewCoordinator()
+	if err != nil {
+		t.Fatalf("failed to create coordinator: %v", err)
+	}
+	coord.ModelManager.SetPrimaryKeyResolver(NopPrimaryKeyResolver{})
+	if err := coord.SetDatabaseName("analytics"); err != nil {
+		t.Fatalf("SetDatabaseName: %v", err)
+	}
+	if err := coord.ModelManager.GenerateModel("Widget", "", true, ""); err != nil {
+		t.Fatalf("failed to generate model: %v", err)
+	}
+	assertGeneratedFileContains(t, filepath.Join("models", "widget.go"), `const WidgetDatabase = "analytics"`)
+
+	// A later command finds the database through the model's constant.
+	coord, err = NewCoordinator()
+	if err != nil {
+		t.Fatalf("failed to create coordinator: %v", err)
+	}
+	coord.ControllerManager.SetPrimaryKeyResolver(NopPrimaryKeyResolver{})
+	if err := coord.GenerateControllerWithActions("Widget", "", "", nil, "", false); err != nil {
+		t.Fatalf("failed to generate controller: %v", err)
+	}
+	controller, err := os.ReadFile(filepath.Join("controllers", "widgets.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"testapp/database"`,
+		"func NewWidgets(databases database.Databases) (Widgets, error) {",
+		"db, err := databases.Pool(models.WidgetDatabase)",
+	} {
+		if !strings.Contains(string(controller), want) {
+			t.Errorf("controllers/widgets.go missing %q", want)
+		}
+	}
+
+	coord, err = NewCoordinator()
+	if err != nil {
+		t.Fatalf("failed to create coordinator: %v", err)
+	}
+	// Without the constant the update would look for the table in the
+	// missing database/migrations directory.
+	result, err := coord.ModelManager.UpdateModel("Widget")
+	if err != nil {
+		t.Fatalf("UpdateModel: %v", err)
+	}
+	if !strings.Contains(result.NewFileContent, `const WidgetDatabase = "analytics"`) {
+		t.Fatalf("expected the update to keep the database constant, got:\n%s", result.NewFileContent)
+	}
+}
@@ -0,0 +1,293 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// DestroyManager removes generated resources, undoing 'generate scaffold'.
+type DestroyManager struct {
+	validator *InputValidator
+	config    *UnifiedConfig
+}
+
+// NewDestroyManager creates a new destroy manager.
+func NewDestroyManager(
+	validator *InputValidator,
+	config *UnifiedConfig,
+) *DestroyManager {
+	return &DestroyManager{
+		validator: validator,
+		config:    config,
+	}
+}
+
+// DestroyResource removes what 'generate scaffold' wrote for a resource: the
+// controller, routes and views, along with the filters, feed, share links and
+// calendar feed added to it. The model, factory and serializer go too unless
+// a controller outside the resource still uses the model. The controllers are
+// taken out of controllers.Module and a removed model out of models/model.go.
+// Migrations are kept, so the table stays until a migration drops it.
+func (d *DestroyManager) DestroyResource(resourceName, namespace string) error {
+	if err := d.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+
+	// The table name override lives in the model, so resolve it first.
+	tableName := ResolveTableName(d.config.Paths.Models, resourceName)
+	paths := d.resourcePaths(resourceName, namespace, tableName)
+	// Share links and calendar feeds hang off the resource's root views.
+	if namespace == "" {
+		paths = append(paths, d.sharePaths(tableName)...)
+		paths = append(paths, d.calendarPaths(resourceName, tableName)...)
+	}
+	pagesDir := filepath.Join("resources", "js", "Pages", naming.NamespaceToPascal(namespace), resourceName)
+
+	users, err := d.modelUsers(resourceName, paths)
+	if err != nil {
+		return err
+	}
+	removeModel := len(users) == 0
+	if removeModel {
+		paths = append(paths, d.modelPaths(resourceName)...)
+	}
+
+	var existing []string
+	for _, path := range paths {
+		if pathExists(path) {
+			existing = append(existing, path)
+		}
+	}
+	hasPages := pathExists(pagesDir)
+	if len(existing) == 0 && !hasPages {
+		return fmt.Errorf("nothing to destroy: no generated files found for %s", naming.NamespaceToPascal(namespace)+resourceName)
+	}
+
+	feedControllerPath := filepath.Join(d.config.Paths.Controllers, naming.NamespaceFilePrefix(namespace)+tableName+"_feed.go")
+	if pathExists(feedControllerPath) {
+		routeName := naming.NamespaceToPascal(namespace) + resourceName
+		if err := controllers.NewMainInjector().RemoveController("", naming.ToSnakeCase(routeName+"Feed")); err != nil {
+			return fmt.Errorf("failed to unregister %sFeed controller: %w", routeName, err)
+		}
+		assetsPath := filepath.Join(d.config.Paths.Controllers, "assets.go")
+		if err := removeRouteFromSitemap(assetsPath, "routes."+routeName+"Feed"); err != nil {
+			return fmt.Errorf("failed to remove routes.%sFeed from the sitemap: %w", routeName, err)
+		}
+	}
+	if namespace == "" {
+		for _, extra := range []struct{ controller, file string }{
+			{resourceName + "Shares", tableName + "_shares.go"},
+			{resourceName + "Calendar", tableName + "_calendar.go"},
+		} {
+			if !pathExists(filepath.Join(d.config.Paths.Controllers, extra.file)) {
+				continue
+			}
+			if err := controllers.NewMainInjector().RemoveController("", naming.ToSnakeCase(extra.controller)); err != nil {
+				return fmt.Errorf("failed to unregister %s controller: %w", extra.controller, err)
+			}
+		}
+	}
+	if err := controllers.NewMainInjector().RemoveController(namespace, tableName); err != nil {
+		return fmt.Errorf("failed to unregister controller: %w", err)
+	}
+	if removeModel {
+		if err := d.unregisterNamespace(resourceName); err != nil {
+			return fmt.Errorf("failed to unregister %s in models/model.go: %w", resourceName, err)
+		}
+	}
+
+	for _, path := range existing {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	if hasPages {
+		if err := os.RemoveAll(pagesDir); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", pagesDir, err)
+		}
+	}
+
+	if !removeModel {
+		fmt.Printf("Kept the %s model, which %s still uses\n", resourceName, strings.Join(users, ", "))
+	}
+	fmt.Printf("Successfully destroyed %s; its migrations are kept\n", naming.NamespaceToPascal(namespace)+resourceName)
+	return nil
+}
+
+// pathExists stats path directly rather than through files.Manager, which
+// caches FileExists while the files are being removed.
+func pathExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// resourcePaths lists the controller, routes and view files generators write
+// for a resource in namespace, whether or not they exist.
+func (d *DestroyManager) resourcePaths(resourceName, namespace, tableName string) []string {
+	controllerDir := filepath.Join(d.config.Paths.Controllers, namespace)
+	prefix := naming.NamespaceFilePrefix(namespace)
+	views := filepath.Join(d.config.Paths.Views, prefix+tableName)
+
+	return []string{
+		filepath.Join(controllerDir, tableName+".go"),
+		filepath.Join(controllerDir, tableName+"_filters.go"),
+		filepath.Join(d.config.Paths.Controllers, prefix+tableName+"_feed.go"),
+		filepath.Join(d.config.Paths.Routes, prefix+tableName+".go"),
+		filepath.Join(d.config.Paths.Routes, prefix+tableName+"_feed.go"),
+		views + "_resource.templ",
+		views + "_resource_templ.go",
+		views + "_resource_test.go",
+		views + "_filters.templ",
+		views + "_filters_templ.go",
+	}
+}
+
+// modelPaths lists the files generators write for the model every namespace
+// of a resource shares.
+func (d *DestroyManager) modelPaths(resourceName string) []string {
+	modelsDir := d.config.Paths.Models
+	modelFile := naming.ToSnakeCase(resourceName)
+
+	return []string{
+		BuildModelPath(modelsDir, resourceName),
+		filepath.Join(modelsDir, "factories", modelFile+".go"),
+		filepath.Join(modelsDir, modelFile+"_filters.go"),
+		filepath.Join(modelsDir, modelFile+"_calendar.go"),
+		filepath.Join(serializersDir, modelFile+".go"),
+	}
+}
+
+// sharePaths lists the files 'generate share' writes for a resource. The
+// share_links model and controller every resource shares are kept.
+func (d *DestroyManager) sharePaths(tableName string) []string {
+	views := filepath.Join(d.config.Paths.Views, tableName+"_shares")
+
+	return []string{
+		filepath.Join(d.config.Paths.Controllers, tableName+"_shares.go"),
+		filepath.Join(d.config.Paths.Routes, tableName+"_shares.go"),
+		views + ".templ",
+		views + "_templ.go",
+	}
+}
+
+// calendarPaths lists the controller and routes 'generate calendar' writes
+// for a resource. Its model query is one of modelPaths.
+func (d *DestroyManager) calendarPaths(resourceName, tableName string) []string {
+	return []string{
+		filepath.Join(d.config.Paths.Controllers, tableName+"_calendar.go"),
+		filepath.Join(d.config.Paths.Routes, tableName+"_calendar.go"),
+	}
+}
+
+// modelUsers returns the controller files, other than removed, that refer to
+// the resourceName model.
+func (d *DestroyManager) modelUsers(resourceName string, removed []string) ([]string, error) {
+	skip := make(map[string]bool, len(removed))
+	for _, path := range removed {
+		skip[filepath.Clean(path)] = true
+	}
+	modelRef := regexp.MustCompile(`\bmodels\.` + regexp.QuoteMeta(resourceName) + `\b`)
+
+	var users []string
+	err := filepath.WalkDir(d.config.Paths.Controllers, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(path, ".go") || skip[filepath.Clean(path)] {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if modelRef.Match(content) {
+			users = append(users, filepath.ToSlash(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look for other uses of the %s model: %w", resourceName, err)
+	}
+	return users, nil
+}
+
+// unregisterNamespace removes the entries registerNamespace added to
+// models/model.go for resourceName.
+func (d *DestroyManager) unregisterNamespace(resourceName string) error {
+	modelGoPath := filepath.Join(d.config.Paths.Models, "model.go")
+
+	src, err := os.ReadFile(modelGoPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	namespaceType := regexp.QuoteMeta(naming.ToLowerCamelCaseFromAny(resourceName))
+	entries := regexp.MustCompile(`(?m)^\t(?:` + namespaceType + `\s+struct\{\}|` +
+		regexp.QuoteMeta(resourceName) + `\s+` + namespaceType + `)\n`)
+	updated := entries.ReplaceAllString(string(src), "")
+
+	if updated == string(src) {
+		return nil
+	}
+
+	if err := os.WriteFile(modelGoPath, []byte(updated), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	return files.FormatGoFile(modelGoPath)
+}
+
+// removeRouteFromSitemap drops route from the sitemapRoutes of
+// controllers/assets.go. A missing file or route is left alone.
+func removeRouteFromSitemap(assetsPath, route string) error {
+	content, err := os.ReadFile(assetsPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	m := sitemapRoutesDecl.FindStringSubmatchIndex(string(content))
+	if m == nil {
+		return nil
+	}
+	var entries []string
+	removed := false
+	for _, entry := range strings.Split(string(content[m[2]:m[3]]), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == route {
+			removed = true
+			continue
+		}
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	if !removed {
+		return nil
+	}
+
+	decl := "var sitemapRoutes = []routing.Route{}"
+	if len(entries) > 0 {
+		decl = "var sitemapRoutes = []routing.Route{\n\t" + strings.Join(entries, ",\n\t") + ",\n}"
+	}
+	updated := string(content[:m[0]]) + decl + string(content[m[1]:])
+	if err := os.WriteFile(assetsPath, []byte(updated), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	return files.FormatGoFile(assetsPath)
+}
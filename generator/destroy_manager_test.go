@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDestroyResourceRemovesScaffold(t *testing.T) {
+	gen := setupScaffoldGoldenProject(t, "controller_view_generation", nil, "")
+	if err := gen.GenerateScaffold("Widget", "", "", false, "", "", false); err != nil {
+		t.Fatalf("failed to generate scaffold: %v", err)
+	}
+
+	if err := gen.DestroyResource("Widget", ""); err != nil {
+		t.Fatalf("DestroyResource returned error: %v", err)
+	}
+
+	for _, path := range []string{
+		"models/widget.go",
+		"models/factories/widget.go",
+		"controllers/widgets.go",
+		"router/routes/widgets.go",
+		"views/widgets_resource.templ",
+	} {
+		assertControllerViewGoldenFileMissing(t, path)
+	}
+	assertGeneratedFileNotContains(t, "controllers/controller.go", "Widgets")
+	assertGeneratedFileNotContains(t, "models/model.go", "widget")
+	assertGeneratedFileContains(t, "models/model.go", "User  user")
+	assertGeneratedFileContains(t, "views/data_table.templ", "templ DataTableView(")
+}
+
+func TestDestroyResourceRemovesNamespacedController(t *testing.T) {
+	gen := setupScaffoldGoldenProject(t, "controller_view_generation", nil, "")
+	if err := gen.GenerateScaffold("Widget", "admin", "", true, "", "", false); err != nil {
+		t.Fatalf("failed to generate scaffold: %v", err)
+	}
+	assertGeneratedFileContains(t, "controllers/controller.go", "\"testapp/controllers/admin\"")
+
+	if err := gen.DestroyResource("Widget", "admin"); err != nil {
+		t.Fatalf("DestroyResource returned error: %v", err)
+	}
+
+	assertControllerViewGoldenFileMissing(t, "controllers/admin/widgets.go")
+	assertControllerViewGoldenFileMissing(t, "router/routes/admin_widgets.go")
+	assertControllerViewGoldenFileMissing(t, "views/admin_widgets_resource.templ")
+	assertGeneratedFileNotContains(t, "controllers/controller.go", "admin")
+}
+
+func TestDestroyResourceKeepsModelUsedByAnotherNamespace(t *testing.T) {
+	gen := setupScaffoldGoldenProject(t, "controller_view_generation", nil, "")
+	if err := gen.GenerateScaffold("Widget", "", "", false, "", "", false); err != nil {
+		t.Fatalf("failed to generate scaffold: %v", err)
+	}
+	if err := gen.GenerateScaffold("Widget", "admin", "", true, "", "", false); err != nil {
+		t.Fatalf("failed to generate admin scaffold: %v", err)
+	}
+
+	if err := gen.DestroyResource("Widget", "admin"); err != nil {
+		t.Fatalf("DestroyResource returned error: %v", err)
+	}
+
+	assertControllerViewGoldenFileMissing(t, "controllers/admin/widgets.go")
+	assertGeneratedFileContains(t, "models/widget.go", "type WidgetEntity struct")
+	assertGeneratedFileContains(t, "models/factories/widget.go", "package factories")
+	assertGeneratedFileContains(t, "models/model.go", "Widget widget")
+	assertGeneratedFileContains(t, "controllers/widgets.go", "models.Widget")
+}
+
+func TestDestroyResourceRemovesShareLinksAndCalendar(t *testing.T) {
+	gen := setupScaffoldGoldenProject(t, "controller_view_generation", nil, "")
+	if err := gen.GenerateScaffold("Widget", "", "", false, "", "", false); err != nil {
+		t.Fatalf("failed to generate scaffold: %v", err)
+	}
+	if err := gen.GenerateShare("Widget", "", 0); err != nil {
+		t.Fatalf("failed to generate share links: %v", err)
+	}
+	if err := gen.GenerateCalendar("Widget", "", CalendarColumns{StartsAt: "created_at", Title: "name"}); err != nil {
+		t.Fatalf("failed to generate calendar: %v", err)
+	}
+	assertGeneratedFileContains(t, "controllers/controller.go", "WidgetShares")
+	assertGeneratedFileContains(t, "controllers/controller.go", "WidgetCalendar")
+
+	if err := gen.DestroyResource("Widget", ""); err != nil {
+		t.Fatalf("DestroyResource returned error: %v", err)
+	}
+
+	for _, path := range []string{
+		"controllers/widgets_shares.go",
+		"router/routes/widgets_shares.go",
+		"views/widgets_shares.templ",
+		"controllers/widgets_calendar.go",
+		"router/routes/widgets_calendar.go",
+		"models/widget_calendar.go",
+		"models/widget.go",
+	} {
+		assertControllerViewGoldenFileMissing(t, path)
+	}
+	assertGeneratedFileNotContains(t, "controllers/controller.go", "WidgetShares")
+	assertGeneratedFileNotContains(t, "controllers/controller.go", "WidgetCalendar")
+	assertGeneratedFileContains(t, "controllers/share_links.go", "package controllers")
+}
+
+func TestDestroyResourceRequiresGeneratedFiles(t *testing.T) {
+	gen := setupScaffoldGoldenProject(t, "controller_view_generation", nil, "")
+
+	err := gen.DestroyResource("Widget", "")
+	if err == nil || !strings.Contains(err.Error(), "nothing to destroy") {
+		t.Fatalf("expected nothing to destroy error, got %v", err)
+	}
+}
+
+func TestRemoveRouteFromSitemap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "assets.go")
+	content := `package controllers
+
+var sitemapRoutes = []routing.Route{
+	routes.HomePage,
+	routes.PostFeed,
+}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := removeRouteFromSitemap(path, "routes.PostFeed"); err != nil {
+		t.Fatalf("removeRouteFromSitemap returned error: %v", err)
+	}
+
+	got, _ := os.ReadFile(path)
+	if want := "var sitemapRoutes = []routing.Route{\n\troutes.HomePage,\n}"; !strings.Contains(string(got), want) {
+		t.Fatalf("expected %q in:\n%s", want, got)
+	}
+}
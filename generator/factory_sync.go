@@ -3,7 +3,6 @@ package generator
 import (
 	"fmt"
 	"go/ast"
-	"go/format"
 	"go/parser"
 	"go/token"
 	"os"
@@ -12,6 +11,9 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/pkg/naming"
 	"github.com/pmezard/go-difflib/difflib"
@@ -49,7 +51,11 @@ func (m *ModelManager) SyncFactory(resourceName string, opts FactorySyncOptions)
 		return nil, err
 	}
 
-	result, err := m.planFactorySync(resourceName, tableName, genModel, opts)
+	// The catalog is optional: without readable migrations the fake values
+	// are chosen from the column names alone.
+	cat, _ := m.buildModelCatalog(resourceName, tableName)
+
+	result, err := m.planFactorySync(cat, resourceName, tableName, genModel, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +66,14 @@ func (m *ModelManager) SyncFactory(resourceName string, opts FactorySyncOptions)
 		if err := os.WriteFile(result.Path, []byte(result.newContent), 0o600); err != nil {
 			return nil, fmt.Errorf("write factory file: %w", err)
 		}
+		if err := files.FormatGoFile(result.Path); err != nil {
+			return nil, fmt.Errorf("format factory file: %w", err)
+		}
+		if rootDir, err := m.fileManager.FindGoModRoot(); err == nil {
+			if err := ensureFakeDataModule(rootDir); err != nil {
+				return nil, err
+			}
+		}
 		result.Written = true
 	}
 	return result, nil
@@ -122,6 +136,22 @@ func (m *ModelManager) factoryModelFromEntity(resourceName string) (*models.Gene
 
 	tableName := ResolveTableName(m.config.Paths.Models, resourceName)
 	genModel := generatedModelFromParsedEntity(resourceName, tableName, m.projectManager.GetModulePath(), fields)
+
+	// Fields of a type mapped in andurel.types.yaml get the mapping's
+	// factory default and import.
+	if rootDir, err := m.fileManager.FindGoModRoot(); err == nil {
+		customTypes, err := types.LoadTypeOverrides(rootDir)
+		if err != nil {
+			return nil, "", err
+		}
+		for i, field := range genModel.Fields {
+			for _, custom := range customTypes {
+				if custom.IsNamed() && strings.TrimPrefix(field.Type, "*") == custom.GoType {
+					genModel.Fields[i].ProjectType = &custom
+				}
+			}
+		}
+	}
 	return genModel, tableName, nil
 }
 
@@ -139,6 +169,10 @@ func generatedModelFromParsedEntity(resourceName, tableName, modulePath string,
 	}
 
 	for _, field := range fields {
+		if field.IsRelation {
+			genModel.Associations = append(genModel.Associations, relationAssociation(field))
+			continue
+		}
 		generated := models.GeneratedField{
 			Name:         field.Name,
 			Type:         field.TypeStr,
@@ -146,6 +180,7 @@ func generatedModelFromParsedEntity(resourceName, tableName, modulePath string,
 			IsForeignKey: field.Name != "ID" && strings.HasSuffix(field.Name, "ID"),
 			IsNullable:   strings.HasPrefix(field.TypeStr, "*") || strings.HasPrefix(field.TypeStr, "sql.Null") || strings.HasPrefix(field.TypeStr, "bun.Null"),
 			IsPrimaryKey: field.Name == "ID" || strings.Contains(field.BunTag, "pk"),
+			JSONType:     parsedJSONType(field),
 		}
 		genModel.Fields = append(genModel.Fields, generated)
 
@@ -163,6 +198,21 @@ func generatedModelFromParsedEntity(resourceName, tableName, modulePath string,
 		}
 	}
 
+	var keys []models.GeneratedKey
+	for _, field := range genModel.Fields {
+		if field.IsPrimaryKey {
+			keys = append(keys, models.GeneratedKey{GoField: field.Name, GoType: field.Type})
+		}
+	}
+	if len(keys) > 1 {
+		genModel.HasCompositeKey = true
+		genModel.PrimaryKeys = keys
+		genModel.IDType = ""
+		genModel.IDGoType = ""
+		genModel.IDGoFieldName = ""
+		return genModel
+	}
+
 	if genModel.IDGoFieldName == "" {
 		genModel.IDGoFieldName = "ID"
 		genModel.IDType = "uuid.UUID"
@@ -172,7 +222,20 @@ func generatedModelFromParsedEntity(resourceName, tableName, modulePath string,
 	return genModel
 }
 
-func (m *ModelManager) planFactorySync(resourceName, tableName string, genModel *models.GeneratedModel, opts FactorySyncOptions) (*FactorySyncResult, error) {
+// parsedJSONType returns the models struct a json/jsonb field decodes into,
+// or "" when the field keeps the raw JSON.
+func parsedJSONType(field parsedField) string {
+	if !strings.Contains(field.BunTag, "type:json") {
+		return ""
+	}
+	name := strings.TrimPrefix(field.TypeStr, "*")
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		return ""
+	}
+	return name
+}
+
+func (m *ModelManager) planFactorySync(cat *catalog.Catalog, resourceName, tableName string, genModel *models.GeneratedModel, opts FactorySyncOptions) (*FactorySyncResult, error) {
 	rootDir, err := m.fileManager.FindGoModRoot()
 	if err != nil {
 		return nil, fmt.Errorf("find project root: %w", err)
@@ -189,7 +252,7 @@ func (m *ModelManager) planFactorySync(resourceName, tableName string, genModel
 		return nil, fmt.Errorf("read factory file: %w", err)
 	}
 
-	genFactory, err := m.modelGenerator.BuildFactory(nil, models.Config{
+	genFactory, err := m.modelGenerator.BuildFactory(cat, models.Config{
 		TableName:    tableName,
 		ResourceName: resourceName,
 		PackageName:  "factories",
@@ -200,7 +263,7 @@ func (m *ModelManager) planFactorySync(resourceName, tableName string, genModel
 		return nil, fmt.Errorf("build factory metadata: %w", err)
 	}
 
-	newContent, err := renderSyncedFactoryFile(genFactory, oldContent)
+	newContent, err := renderSyncedFactoryFile(factoryPath, genFactory, oldContent)
 	if err != nil {
 		return nil, err
 	}
@@ -223,7 +286,7 @@ func (m *ModelManager) planFactorySync(resourceName, tableName string, genModel
 	return result, nil
 }
 
-func renderSyncedFactoryFile(factory *models.GeneratedFactory, oldContent string) (string, error) {
+func renderSyncedFactoryFile(path string, factory *models.GeneratedFactory, oldContent string) (string, error) {
 	generatedOptions := expectedFactoryOptionNames(factory)
 	customDecls, oldImports, err := customFactoryDecls(oldContent, factory, generatedOptions)
 	if err != nil {
@@ -233,7 +296,7 @@ func renderSyncedFactoryFile(factory *models.GeneratedFactory, oldContent string
 	var sb strings.Builder
 	sb.WriteString("package factories\n\n")
 	writeFactoryImports(&sb, factory, oldImports)
-	sb.WriteString("\n// Factory declarations below are generated by Andurel.\n\n")
+	sb.WriteString("\n" + files.GeneratedSectionMarker("factory") + "\n\n")
 	writeFactoryCore(&sb, factory)
 	sb.WriteString("\n")
 	writeFactoryOptions(&sb, factory)
@@ -243,7 +306,7 @@ func renderSyncedFactoryFile(factory *models.GeneratedFactory, oldContent string
 		sb.WriteString("\n")
 	}
 
-	formatted, err := format.Source([]byte(sb.String()))
+	formatted, err := files.FormatGoSource(path, []byte(sb.String()))
 	if err != nil {
 		return sb.String(), nil
 	}
@@ -256,9 +319,9 @@ func writeFactoryImports(sb *strings.Builder, factory *models.GeneratedFactory,
 		"fmt":                                    true,
 		factory.ModulePath + "/internal/storage": true,
 		factory.ModulePath + "/models":           true,
-		"github.com/go-faker/faker/v4":           true,
+		"github.com/brianvoe/gofakeit/v7":        true,
 	}
-	if !factory.IsAutoIncrementID && (factory.IDType == "" || factory.IDType == "uuid.UUID") {
+	if !factory.IsAutoIncrementID && !factory.HasCompositeKey && (factory.IDType == "" || factory.IDType == "uuid.UUID") {
 		imports["github.com/google/uuid"] = true
 	}
 	if factory.HasCreatedAt || factory.HasUpdatedAt {
@@ -274,6 +337,17 @@ func writeFactoryImports(sb *strings.Builder, factory *models.GeneratedFactory,
 			imports["github.com/uptrace/bun"] = true
 		case strings.HasPrefix(field.Type, "json."):
 			imports["encoding/json"] = true
+		case strings.Contains(field.Type, "uuid.UUID"):
+			imports["github.com/google/uuid"] = true
+		case strings.Contains(field.Type, "money.Money"):
+			imports[factory.ModulePath+"/internal/money"] = true
+		case strings.Contains(field.Type, types.DecimalGoType):
+			imports[types.DecimalPackage] = true
+		case strings.Contains(field.Type, types.IntervalGoType):
+			imports[factory.ModulePath+"/internal/interval"] = true
+		}
+		if field.Package != "" {
+			imports[field.Package] = true
 		}
 	}
 	for _, oldImport := range oldImports {
@@ -288,6 +362,8 @@ func writeFactoryImports(sb *strings.Builder, factory *models.GeneratedFactory,
 	}
 	slices.Sort(ordered)
 
+	// FormatGoSource groups and prunes the block, so an import the old file
+	// no longer uses is dropped.
 	sb.WriteString("import (\n")
 	for _, imp := range ordered {
 		fmt.Fprintf(sb, "\t%q\n", imp)
@@ -328,7 +404,7 @@ func writeFactoryCreateFunctions(sb *strings.Builder, factory *models.GeneratedF
 	writeFactoryFKArgs(sb, factory)
 	sb.WriteString("opts...)\n\n")
 	fmt.Fprintf(sb, "\tentity := models.%s{\n", factory.EntityName)
-	if !factory.IsAutoIncrementID && factory.IDGoFieldName != "" {
+	if !factory.IsAutoIncrementID && !factory.HasCompositeKey && factory.IDGoFieldName != "" {
 		if factory.IDType == "" || factory.IDType == "uuid.UUID" {
 			fmt.Fprintf(sb, "\t\t%s: uuid.New(),\n", factory.IDGoFieldName)
 		} else {
@@ -86,7 +86,7 @@ func CustomProductScore() int {
 }
 `
 
-	rendered, err := renderSyncedFactoryFile(factory, oldContent)
+	rendered, err := renderSyncedFactoryFile(filepath.Join(t.TempDir(), "product.go"), factory, oldContent)
 	if err != nil {
 		t.Fatalf("renderSyncedFactoryFile returned error: %v", err)
 	}
@@ -118,6 +118,25 @@ func CustomProductScore() int {
 	}
 }
 
+func TestRenderSyncedFactoryFileDerivesImportsFromSource(t *testing.T) {
+	factory := factorySyncGeneratedFactory()
+	factory.Fields = append(factory.Fields, models.FactoryField{
+		Name: "LaunchedAt", Type: "sql.NullTime", DefaultValue: "sql.NullTime{}", OptionName: "WithProductsLaunchedAt",
+	})
+
+	rendered, err := renderSyncedFactoryFile(filepath.Join(t.TempDir(), "product.go"), factory, "")
+	if err != nil {
+		t.Fatalf("renderSyncedFactoryFile returned error: %v", err)
+	}
+
+	if !strings.Contains(rendered, `"database/sql"`) {
+		t.Fatalf("expected missing database/sql import to be added:\n%s", rendered)
+	}
+	if strings.Contains(rendered, `"time"`) {
+		t.Fatalf("expected unused time import to be removed:\n%s", rendered)
+	}
+}
+
 func TestCustomFactoryDeclsReturnsParseErrorForInvalidExistingFactory(t *testing.T) {
 	_, _, err := customFactoryDecls("package factories\nfunc broken(", factorySyncGeneratedFactory(), map[string]bool{})
 	if err == nil {
@@ -326,8 +345,8 @@ func factorySyncGeneratedFactory() *models.GeneratedFactory {
 		IDGoFieldName: "ID",
 		Fields: []models.FactoryField{
 			{Name: "ID", Type: "uuid.UUID", IsAutoManaged: true, IsID: true},
-			{Name: "Name", Type: "string", DefaultValue: "faker.Name()", OptionName: "WithProductsName"},
-			{Name: "Price", Type: "int32", DefaultValue: "randomInt(1, 1000, 100)", OptionName: "WithProductsPrice"},
+			{Name: "Name", Type: "string", DefaultValue: "gofakeit.Name()", OptionName: "WithProductsName"},
+			{Name: "Price", Type: "int32", DefaultValue: "int32(gofakeit.IntRange(1, 1000))", OptionName: "WithProductsPrice"},
 		},
 	}
 }
@@ -0,0 +1,265 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// The first column a table has from each list fills that part of a feed
+// entry.
+var (
+	feedPublishedColumns = []string{"published_at", "created_at"}
+	feedUpdatedColumns   = []string{"updated_at", "published_at", "created_at"}
+	feedTitleColumns     = []string{"title", "name", "headline", "subject"}
+	feedContentColumns   = []string{"summary", "excerpt", "description", "body", "content"}
+)
+
+type feedTemplateData struct {
+	ModulePath     string
+	ResourceName   string
+	RouteName      string
+	ModelName      string
+	ControllerName string
+	ReceiverName   string
+	ResourceVar    string
+	TableName      string
+	PluralField    string
+	FeedTitle      string
+	FeedPath       string
+	FeedRouteName  string
+	OrderColumn    string
+	// ExcludeUnpublished leaves drafts and scheduled records out of feeds
+	// ordered by published_at.
+	ExcludeUnpublished bool
+	PublishedField     string
+	UpdatedField       string
+	TitleField         string
+	ContentField       string
+}
+
+// FeedManager adds Atom feeds to generated resources.
+type FeedManager struct {
+	validator        *InputValidator
+	fileManager      files.Manager
+	projectManager   *ProjectManager
+	migrationManager *MigrationManager
+	config           *UnifiedConfig
+}
+
+// NewFeedManager creates a new feed manager.
+func NewFeedManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	migrationManager *MigrationManager,
+	config *UnifiedConfig,
+) *FeedManager {
+	return &FeedManager{
+		validator:        validator,
+		fileManager:      fileManager,
+		projectManager:   projectManager,
+		migrationManager: migrationManager,
+		config:           config,
+	}
+}
+
+// GenerateFeed writes an Atom feed of the newest records under /feeds and
+// lists it in the sitemap. tableName is the --table-name override, if any.
+func (f *FeedManager) GenerateFeed(resourceName, namespace, tableName string) error {
+	if err := f.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+	pluralField := naming.Plural(resourceName)
+	if tableName != "" {
+		// Models generated with --table-name keep the singular name.
+		pluralField = resourceName
+	} else {
+		tableName = naming.DeriveTableName(resourceName)
+	}
+
+	cat, err := f.migrationManager.BuildCatalogFromMigrations(tableName, f.config)
+	if err != nil {
+		return err
+	}
+	table, err := cat.GetTable("", tableName)
+	if err != nil {
+		return fmt.Errorf("table %s not found in migrations: %w", tableName, err)
+	}
+
+	routeName := naming.NamespaceToPascal(namespace) + resourceName
+	feedName := naming.ToKebabCase(tableName)
+	feedRouteName := tableName + ".feed"
+	if namespace != "" {
+		feedName = namespace + "-" + feedName
+		feedRouteName = naming.NamespaceRouteName(namespace) + "." + feedRouteName
+	}
+	controllerName := routeName + "Feed"
+	data := feedTemplateData{
+		ModulePath:     f.projectManager.GetModulePath(),
+		ResourceName:   resourceName,
+		RouteName:      routeName,
+		ModelName:      resourceName,
+		ControllerName: controllerName,
+		ReceiverName:   naming.ToReceiverName(controllerName),
+		ResourceVar:    naming.ToLowerCamelCase(routeName),
+		TableName:      tableName,
+		PluralField:    pluralField,
+		FeedTitle:      naming.Capitalize(strings.ReplaceAll(tableName, "_", " ")),
+		FeedPath:       "/" + feedName + ".atom",
+		FeedRouteName:  feedRouteName,
+	}
+	if err := resolveFeedColumns(table, &data); err != nil {
+		return err
+	}
+
+	filePrefix := naming.NamespaceFilePrefix(namespace)
+	routesPath := filepath.Join(f.config.Paths.Routes, filePrefix+tableName+"_feed.go")
+	controllerPath := filepath.Join(f.config.Paths.Controllers, filePrefix+tableName+"_feed.go")
+	for _, path := range []string{routesPath, controllerPath} {
+		if f.fileManager.FileExists(path) {
+			return fmt.Errorf("a feed already exists for %s: %s exists", resourceName, path)
+		}
+	}
+
+	sharedFiles := []struct {
+		template string
+		path     string
+	}{
+		{"feed_route.tmpl", filepath.Join(f.config.Paths.Routes, "feeds.go")},
+		{"feed_controller.tmpl", filepath.Join(f.config.Paths.Controllers, "feeds.go")},
+	}
+	for _, file := range sharedFiles {
+		if f.fileManager.FileExists(file.path) {
+			continue
+		}
+		if err := f.render(file.template, file.path, data); err != nil {
+			return err
+		}
+	}
+	if err := f.render("feed_resource_route.tmpl", routesPath, data); err != nil {
+		return err
+	}
+	if err := f.render("feed_resource_controller.tmpl", controllerPath, data); err != nil {
+		return err
+	}
+
+	if err := controllers.NewMainInjector().InjectController(controllerName, "", naming.ToSnakeCase(controllerName)); err != nil {
+		return fmt.Errorf("failed to register %s controller: %w", controllerName, err)
+	}
+
+	assetsPath := filepath.Join(f.config.Paths.Controllers, "assets.go")
+	if err := addRouteToSitemap(assetsPath, "routes."+routeName+"Feed"); err != nil {
+		fmt.Printf("Add routes.%sFeed to the sitemap yourself: %v\n", routeName, err)
+	}
+
+	fmt.Printf("Successfully added an Atom feed to %s\n", resourceName)
+	return nil
+}
+
+// resolveFeedColumns picks the columns behind each part of a feed entry.
+func resolveFeedColumns(table *catalog.Table, data *feedTemplateData) error {
+	published := firstFeedColumn(table, feedPublishedColumns, true)
+	if published == nil {
+		return fmt.Errorf(
+			"a feed needs a %s timestamp column on %s to order entries",
+			strings.Join(feedPublishedColumns, " or "),
+			table.Name,
+		)
+	}
+	data.OrderColumn = published.Name
+	data.ExcludeUnpublished = published.Name == "published_at"
+	data.PublishedField = types.FormatFieldName(published.Name)
+	data.UpdatedField = types.FormatFieldName(firstFeedColumn(table, feedUpdatedColumns, true).Name)
+
+	if title := firstFeedColumn(table, feedTitleColumns, false); title != nil {
+		data.TitleField = types.FormatFieldName(title.Name)
+	}
+	if content := firstFeedColumn(table, feedContentColumns, false); content != nil {
+		data.ContentField = types.FormatFieldName(content.Name)
+	}
+	return nil
+}
+
+func firstFeedColumn(table *catalog.Table, candidates []string, timestamp bool) *catalog.Column {
+	for _, name := range candidates {
+		col, err := table.GetColumn(name)
+		if err != nil {
+			continue
+		}
+		if timestamp && calendarTimeKind(col.DataType) == "" {
+			continue
+		}
+		return col
+	}
+	return nil
+}
+
+var sitemapRoutesDecl = regexp.MustCompile(`var sitemapRoutes = \[\]routing\.Route\{([^}]*)\}`)
+
+// addRouteToSitemap lists route in the sitemapRoutes of controllers/assets.go.
+func addRouteToSitemap(assetsPath, route string) error {
+	content, err := os.ReadFile(assetsPath)
+	if err != nil {
+		return err
+	}
+	updated, err := addSitemapRoute(string(content), route)
+	if err != nil {
+		return fmt.Errorf("%w in %s", err, assetsPath)
+	}
+	if err := os.WriteFile(assetsPath, []byte(updated), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+	return files.FormatGoFile(assetsPath)
+}
+
+func addSitemapRoute(content, route string) (string, error) {
+	m := sitemapRoutesDecl.FindStringSubmatchIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no sitemapRoutes")
+	}
+
+	var entries []string
+	for _, entry := range strings.Split(content[m[2]:m[3]], ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	for _, entry := range entries {
+		if entry == route {
+			return content, nil
+		}
+	}
+	entries = append(entries, route)
+
+	decl := "var sitemapRoutes = []routing.Route{\n\t" + strings.Join(entries, ",\n\t") + ",\n}"
+	return content[:m[0]] + decl + content[m[1]:], nil
+}
+
+// render writes a template to path and formats it.
+func (f *FeedManager) render(templateName, path string, data feedTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := f.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := files.FormatGoFile(path); err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+func TestFeedTemplatesParse(t *testing.T) {
+	service := templates.GetGlobalTemplateService()
+
+	tests := map[string]feedTemplateData{
+		"title and content": {
+			OrderColumn:        "published_at",
+			ExcludeUnpublished: true,
+			PublishedField:     "PublishedAt",
+			UpdatedField:       "UpdatedAt",
+			TitleField:         "Title",
+			ContentField:       "Body",
+		},
+		"timestamps only": {
+			OrderColumn:    "created_at",
+			PublishedField: "CreatedAt",
+			UpdatedField:   "CreatedAt",
+		},
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			data.ModulePath = "example.com/app"
+			data.ResourceName = "Post"
+			data.RouteName = "Post"
+			data.ModelName = "Post"
+			data.ControllerName = "PostFeed"
+			data.ReceiverName = "pf"
+			data.ResourceVar = "post"
+			data.TableName = "posts"
+			data.PluralField = "Posts"
+			data.FeedTitle = "Posts"
+			data.FeedPath = "/posts.atom"
+			data.FeedRouteName = "posts.feed"
+
+			for _, name := range []string{
+				"feed_controller.tmpl",
+				"feed_route.tmpl",
+				"feed_resource_controller.tmpl",
+				"feed_resource_route.tmpl",
+			} {
+				content, err := service.RenderTemplate(name, data)
+				if err != nil {
+					t.Fatalf("render %s failed: %v", name, err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), name, content, 0); err != nil {
+					t.Fatalf("%s is not valid Go: %v\n%s", name, err, content)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveFeedColumns(t *testing.T) {
+	table := catalog.NewTable("", "posts")
+	for _, col := range []*catalog.Column{
+		catalog.NewColumn("id", "uuid"),
+		catalog.NewColumn("created_at", "timestamp with time zone"),
+		catalog.NewColumn("updated_at", "timestamp with time zone"),
+		catalog.NewColumn("published_at", "text"),
+		catalog.NewColumn("title", "text"),
+		catalog.NewColumn("body", "text"),
+	} {
+		if err := table.AddColumn(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var data feedTemplateData
+	if err := resolveFeedColumns(table, &data); err != nil {
+		t.Fatalf("resolveFeedColumns returned error: %v", err)
+	}
+	want := feedTemplateData{
+		OrderColumn:    "created_at",
+		PublishedField: "CreatedAt",
+		UpdatedField:   "UpdatedAt",
+		TitleField:     "Title",
+		ContentField:   "Body",
+	}
+	if data != want {
+		t.Fatalf("expected %+v, got %+v", want, data)
+	}
+
+	empty := catalog.NewTable("", "notes")
+	if err := resolveFeedColumns(empty, &feedTemplateData{}); err == nil || !strings.Contains(err.Error(), "published_at or created_at") {
+		t.Fatalf("expected missing timestamp error, got %v", err)
+	}
+}
+
+func TestAddSitemapRoute(t *testing.T) {
+	content := "var sitemapRoutes = []routing.Route{}\n"
+
+	updated, err := addSitemapRoute(content, "routes.PostFeed")
+	if err != nil {
+		t.Fatalf("addSitemapRoute returned error: %v", err)
+	}
+	updated, err = addSitemapRoute(updated, "routes.ArticleFeed")
+	if err != nil {
+		t.Fatalf("addSitemapRoute returned error: %v", err)
+	}
+	updated, err = addSitemapRoute(updated, "routes.PostFeed")
+	if err != nil {
+		t.Fatalf("addSitemapRoute returned error: %v", err)
+	}
+
+	want := "var sitemapRoutes = []routing.Route{\n\troutes.PostFeed,\n\troutes.ArticleFeed,\n}\n"
+	if updated != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, updated)
+	}
+
+	if _, err := addSitemapRoute("package controllers\n", "routes.PostFeed"); err == nil {
+		t.Fatal("expected an error without sitemapRoutes")
+	}
+}
@@ -143,3 +143,34 @@ func TestFindGoModRoot(t *testing.T) {
 		t.Fatalf("FindGoModRoot = %q, want %q", found, root)
 	}
 }
+
+func TestFormatGoFileFixesImports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	src := "package main\n\nimport \"os\"\n\nfunc main() {\n\tfmt.Println(strings.ToUpper(\"ok\"))\n}\n"
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatalf("write source: %v", err)
+	}
+
+	if err := FormatGoFile(path); err != nil {
+		t.Fatalf("FormatGoFile: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read formatted source: %v", err)
+	}
+	for _, want := range []string{`"fmt"`, `"strings"`} {
+		if !strings.Contains(string(got), want) {
+			t.Fatalf("formatted source should import %s\n\n%s", want, got)
+		}
+	}
+	if strings.Contains(string(got), `"os"`) {
+		t.Fatalf("formatted source should drop the unused os import\n\n%s", got)
+	}
+}
+
+func TestGeneratedSectionMarker(t *testing.T) {
+	if got := GeneratedSectionMarker("entity"); got != "// Code generated by andurel DO NOT EDIT (entity)" {
+		t.Fatalf("GeneratedSectionMarker = %q", got)
+	}
+}
@@ -0,0 +1,368 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// Filter kinds rendered by the filter templates.
+const (
+	FilterKindDateRange   = "date_range"
+	FilterKindNumberRange = "number_range"
+	FilterKindSelect      = "select"
+)
+
+// FilterField describes one column exposed as an index filter.
+type FilterField struct {
+	Column string
+	Name   string
+	Param  string
+	Label  string
+	Kind   string
+	GoType string
+}
+
+type filterTemplateData struct {
+	ModulePath        string
+	ModelName         string
+	ModelType         string
+	ReceiverName      string
+	ResourceName      string
+	ViewName          string
+	ControllerPackage string
+	Fields            []FilterField
+	HasDateRange      bool
+	HasParsedValues   bool
+	HasOptions        bool
+	CSSComponents     bool
+}
+
+// FilterManager adds index filters to a generated resource.
+type FilterManager struct {
+	validator        *InputValidator
+	fileManager      files.Manager
+	projectManager   *ProjectManager
+	migrationManager *MigrationManager
+	config           *UnifiedConfig
+}
+
+// NewFilterManager creates a new filter manager.
+func NewFilterManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	migrationManager *MigrationManager,
+	config *UnifiedConfig,
+) *FilterManager {
+	return &FilterManager{
+		validator:        validator,
+		fileManager:      fileManager,
+		projectManager:   projectManager,
+		migrationManager: migrationManager,
+		config:           config,
+	}
+}
+
+// GenerateFilters writes the Filters struct, its query parser and the filter
+// bar for a scaffolded resource, then wires them into the Index action.
+func (f *FilterManager) GenerateFilters(resourceName, namespace, tableName string, columns []string) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	if tableName == "" {
+		tableName = naming.DeriveTableName(resourceName)
+	}
+	if err := f.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+
+	cat, err := f.migrationManager.BuildCatalogFromMigrations(tableName, f.config)
+	if err != nil {
+		return err
+	}
+	table, err := cat.GetTable("", tableName)
+	if err != nil {
+		return fmt.Errorf("table %s not found in migrations: %w", tableName, err)
+	}
+
+	mapper := types.NewTypeMapper(f.config.Database.Type)
+	data := filterTemplateData{
+		ModulePath:        f.projectManager.GetModulePath(),
+		ModelName:         resourceName,
+		ModelType:         naming.ToLowerCamelCaseFromAny(resourceName),
+		ReceiverName:      naming.ToReceiverName(resourceName),
+		ResourceName:      resourceName,
+		ViewName:          naming.NamespaceToPascal(namespace) + resourceName,
+		ControllerPackage: naming.ControllerPackageName(namespace),
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, name := range columns {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		col, err := table.GetColumn(name)
+		if err != nil {
+			return fmt.Errorf("filter column %s does not exist on table %s", name, tableName)
+		}
+		goType, _, err := mapper.MapSQLTypeToGo(col.DataType, false)
+		if err != nil {
+			return fmt.Errorf("failed to map type of filter column %s: %w", name, err)
+		}
+		field, err := newFilterField(name, goType)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case field.Kind == FilterKindDateRange:
+			data.HasDateRange = true
+		case field.Kind == FilterKindNumberRange || field.GoType == "bool":
+			data.HasParsedValues = true
+		case field.Kind == FilterKindSelect:
+			data.HasOptions = true
+		}
+		data.Fields = append(data.Fields, field)
+	}
+
+	rootDir, err := f.fileManager.FindGoModRoot()
+	if err != nil {
+		return err
+	}
+	if lock, err := layout.ReadLockFile(rootDir); err == nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+
+	modelPath := filepath.Join(f.config.Paths.Models, naming.ToSnakeCase(resourceName)+"_filters.go")
+	controllerDir := filepath.Join(f.config.Paths.Controllers, namespace)
+	controllerFiltersPath := filepath.Join(controllerDir, tableName+"_filters.go")
+	viewPrefix := naming.NamespaceFilePrefix(namespace)
+	viewFiltersPath := filepath.Join(f.config.Paths.Views, viewPrefix+tableName+"_filters.templ")
+	componentsPath := filepath.Join(f.config.Paths.Views, "filters.templ")
+
+	for _, path := range []string{modelPath, controllerFiltersPath, viewFiltersPath} {
+		if f.fileManager.FileExists(path) {
+			return fmt.Errorf("filter file %s already exists", path)
+		}
+	}
+
+	if err := f.renderGoFile("filters_model.tmpl", modelPath, data); err != nil {
+		return err
+	}
+	if err := f.renderGoFile("filters_controller.tmpl", controllerFiltersPath, data); err != nil {
+		return err
+	}
+	if !f.fileManager.FileExists(componentsPath) {
+		if err := f.renderFile("filters_components.tmpl", componentsPath, data); err != nil {
+			return err
+		}
+	}
+	if err := f.renderFile("filters_resource_view.tmpl", viewFiltersPath, data); err != nil {
+		return err
+	}
+
+	controllerPath := filepath.Join(controllerDir, tableName+".go")
+	if err := patchIndexController(controllerPath, data); err != nil {
+		return err
+	}
+	if err := files.FormatGoFile(controllerPath); err != nil {
+		return fmt.Errorf("failed to format %s: %w", controllerPath, err)
+	}
+
+	viewPath := filepath.Join(f.config.Paths.Views, viewPrefix+tableName+"_resource.templ")
+	if err := patchIndexView(viewPath, data); err != nil {
+		return err
+	}
+
+	if err := compileTemplates(rootDir, componentsPath, viewFiltersPath, viewPath); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully generated filters for %s\n", resourceName)
+	return nil
+}
+
+// newFilterField picks the filter kind from the column's Go type.
+func newFilterField(column, goType string) (FilterField, error) {
+	field := FilterField{
+		Column: column,
+		Name:   types.FormatFieldName(column),
+		Param:  column,
+		Label:  types.FormatDisplayName(column),
+		GoType: goType,
+	}
+
+	switch goType {
+	case "time.Time":
+		field.Kind = FilterKindDateRange
+	case "int16", "int32", "int64":
+		field.Kind = FilterKindNumberRange
+		field.GoType = "int64"
+	case "float32", "float64":
+		field.Kind = FilterKindNumberRange
+		field.GoType = "float64"
+	case "string", "bool":
+		field.Kind = FilterKindSelect
+	default:
+		return FilterField{}, fmt.Errorf(
+			"column %s has type %s, which cannot be filtered; use a timestamp, numeric, text or boolean column",
+			column,
+			goType,
+		)
+	}
+
+	return field, nil
+}
+
+func (f *FilterManager) renderFile(templateName, path string, data filterTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := f.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+func (f *FilterManager) renderGoFile(templateName, path string, data filterTemplateData) error {
+	if err := f.renderFile(templateName, path, data); err != nil {
+		return err
+	}
+	if err := files.FormatGoFile(path); err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	return nil
+}
+
+// compileTemplates formats the given templ files and regenerates the
+// project's templ Go code.
+func compileTemplates(rootDir string, paths ...string) error {
+	templBin := filepath.Join(rootDir, "bin", "templ")
+	for _, path := range paths {
+		if err := exec.Command(templBin, "fmt", path).Run(); err != nil {
+			return fmt.Errorf("failed to run templ fmt on %s: %w", path, err)
+		}
+	}
+
+	cmd := exec.Command(templBin, "generate")
+	cmd.Dir = rootDir
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run templ generate: %w", err)
+	}
+	return nil
+}
+
+// patchIndexController threads the parsed filters through the scaffolded
+// Index action.
+func patchIndexController(path string, data filterTemplateData) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read controller %s: %w", path, err)
+	}
+
+	paginate := regexp.MustCompile(
+		`(?m)^(\t)(\w+)List, err := models\.` + regexp.QuoteMeta(data.ModelName) +
+			`\.Paginate\(\n((?:\t\t.*\n)*?)\t\)\n`,
+	)
+	loc := paginate.FindSubmatchIndex(content)
+	if loc == nil {
+		return fmt.Errorf("could not find the %s.Paginate call in %s", data.ModelName, path)
+	}
+	call := string(content[loc[0]:loc[1]])
+	call = strings.TrimSuffix(call, "\t)\n") + "\t\tfilters.Scope,\n\t)\n"
+	call = fmt.Sprintf("\tfilters := parse%sFilters(etx)\n", data.ResourceName) + call
+	updated := string(content[:loc[0]]) + call + string(content[loc[1]:])
+
+	render := fmt.Sprintf("views.%sIndex{", data.ViewName)
+	idx := strings.Index(updated, render)
+	if idx < 0 {
+		return fmt.Errorf("could not find the %sIndex render in %s", data.ViewName, path)
+	}
+	fields := "Filters: filters, "
+	if data.HasOptions {
+		fields = "Filters: filters, FilterOptions: filterOptions, "
+		m := regexp.MustCompile(`func \((\w+) \w+\) Index\(`).FindStringSubmatch(updated)
+		if m == nil {
+			return fmt.Errorf("could not find the Index action in %s", path)
+		}
+		receiver := m[1]
+		lineStart := strings.LastIndex(updated[:idx], "\n") + 1
+		options := fmt.Sprintf(
+			"\tfilterOptions, err := models.%s.FilterOptions(etx.Request().Context(), %s.db.Executor())\n"+
+				"\tif err != nil {\n\t\treturn hypermedia.RenderPage(etx, views.InternalError())\n\t}\n\n",
+			data.ModelName,
+			receiver,
+		)
+		updated = updated[:lineStart] + options + updated[lineStart:]
+		idx += len(options)
+	}
+	insertAt := idx + len(render)
+	updated = updated[:insertAt] + fields + updated[insertAt:]
+
+	return os.WriteFile(path, []byte(updated), constants.FilePermissionPrivate)
+}
+
+// patchIndexView adds the filters to the Index view data and renders the
+// filter bar above the listing.
+func patchIndexView(path string, data filterTemplateData) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read view %s: %w", path, err)
+	}
+	updated := string(content)
+
+	structDecl := regexp.MustCompile(
+		`type ` + regexp.QuoteMeta(data.ViewName) + `Index struct \{\n(\t+)Items\s+\[\]models\.\w+\n`,
+	)
+	loc := structDecl.FindStringSubmatchIndex(updated)
+	if loc == nil {
+		return fmt.Errorf("could not find the %sIndex struct in %s", data.ViewName, path)
+	}
+	indent := updated[loc[2]:loc[3]]
+	fields := fmt.Sprintf("%sFilters models.%sFilters\n", indent, data.ModelName)
+	if data.HasOptions {
+		fields += fmt.Sprintf("%sFilterOptions models.%sFilterOptions\n", indent, data.ModelName)
+	}
+	updated = updated[:loc[1]] + fields + updated[loc[1]:]
+
+	page := regexp.MustCompile(
+		`templ \((\w+) ` + regexp.QuoteMeta(data.ViewName) + `Index\) Page\(\) \{`,
+	)
+	m := page.FindStringSubmatchIndex(updated)
+	if m == nil {
+		return fmt.Errorf("could not find the %sIndex page in %s", data.ViewName, path)
+	}
+	receiver := updated[m[2]:m[3]]
+	empty := regexp.MustCompile(`(?m)^(\t*)(?:@` + regexp.QuoteMeta(receiver) + `\.Table\(\)$|if len\(` + regexp.QuoteMeta(receiver) + `\.Items\) == 0 \{)`)
+	e := empty.FindStringSubmatchIndex(updated[m[1]:])
+	if e == nil {
+		return fmt.Errorf("could not find the %sIndex listing in %s", data.ViewName, path)
+	}
+	lineStart := m[1] + e[0]
+	args := receiver + ".Filters"
+	if data.HasOptions {
+		args += ", " + receiver + ".FilterOptions"
+	}
+	bar := fmt.Sprintf("%s@%sFilterBar(%s)\n", updated[m[1]+e[2]:m[1]+e[3]], data.ViewName, args)
+	updated = updated[:lineStart] + bar + updated[lineStart:]
+
+	return os.WriteFile(path, []byte(updated), constants.FilePermissionPrivate)
+}
@@ -0,0 +1,212 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+func TestNewFilterFieldKinds(t *testing.T) {
+	tests := []struct {
+		column   string
+		goType   string
+		wantKind string
+		wantType string
+	}{
+		{column: "created_at", goType: "time.Time", wantKind: FilterKindDateRange, wantType: "time.Time"},
+		{column: "quantity", goType: "int32", wantKind: FilterKindNumberRange, wantType: "int64"},
+		{column: "price", goType: "float64", wantKind: FilterKindNumberRange, wantType: "float64"},
+		{column: "status", goType: "string", wantKind: FilterKindSelect, wantType: "string"},
+		{column: "active", goType: "bool", wantKind: FilterKindSelect, wantType: "bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.column, func(t *testing.T) {
+			field, err := newFilterField(tt.column, tt.goType)
+			if err != nil {
+				t.Fatalf("newFilterField returned error: %v", err)
+			}
+			if field.Kind != tt.wantKind || field.GoType != tt.wantType {
+				t.Fatalf("expected %s/%s, got %s/%s", tt.wantKind, tt.wantType, field.Kind, field.GoType)
+			}
+		})
+	}
+
+	if _, err := newFilterField("payload", "[]byte"); err == nil || !strings.Contains(err.Error(), "cannot be filtered") {
+		t.Fatalf("expected unsupported type error, got %v", err)
+	}
+}
+
+func TestPatchIndexControllerAndView(t *testing.T) {
+	dir := t.TempDir()
+	controllerPath := filepath.Join(dir, "products.go")
+	viewPath := filepath.Join(dir, "products_resource.templ")
+
+	controller := `func (p Products) Index(etx *echo.Context) error {
+	productsList, err := models.Product.Paginate(
+		etx.Request().Context(),
+		p.db.Executor(),
+		page,
+		perPage,
+	)
+	if err != nil {
+		return hypermedia.RenderPage(etx, views.InternalError())
+	}
+
+	return hypermedia.RenderPage(etx, views.ProductIndex{Items: productsList.Products}.Page())
+}
+`
+	view := `type ProductIndex struct {
+	Items []models.ProductEntity
+}
+
+templ (pi ProductIndex) Page() {
+	<div>
+		if len(pi.Items) == 0 {
+			<p>No products found.</p>
+		}
+	</div>
+}
+`
+	if err := os.WriteFile(controllerPath, []byte(controller), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(viewPath, []byte(view), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := filterTemplateData{ModelName: "Product", ResourceName: "Product", ViewName: "Product", HasOptions: true}
+	if err := patchIndexController(controllerPath, data); err != nil {
+		t.Fatalf("patchIndexController returned error: %v", err)
+	}
+	if err := patchIndexView(viewPath, data); err != nil {
+		t.Fatalf("patchIndexView returned error: %v", err)
+	}
+
+	gotController, _ := os.ReadFile(controllerPath)
+	for _, want := range []string{
+		"\tfilters := parseProductFilters(etx)\n\tproductsList, err := models.Product.Paginate(",
+		"\t\tperPage,\n\t\tfilters.Scope,\n\t)",
+		"filterOptions, err := models.Product.FilterOptions(etx.Request().Context(), p.db.Executor())",
+		"views.ProductIndex{Filters: filters, FilterOptions: filterOptions, Items: productsList.Products}",
+	} {
+		if !strings.Contains(string(gotController), want) {
+			t.Fatalf("controller missing %q:\n%s", want, gotController)
+		}
+	}
+
+	gotView, _ := os.ReadFile(viewPath)
+	for _, want := range []string{
+		"\tFilters models.ProductFilters\n\tFilterOptions models.ProductFilterOptions\n",
+		"\t\t@ProductFilterBar(pi.Filters, pi.FilterOptions)\n\t\tif len(pi.Items) == 0 {",
+	} {
+		if !strings.Contains(string(gotView), want) {
+			t.Fatalf("view missing %q:\n%s", want, gotView)
+		}
+	}
+}
+
+func TestPatchIndexControllerAndViewWithDataTable(t *testing.T) {
+	dir := t.TempDir()
+	controllerPath := filepath.Join(dir, "products.go")
+	viewPath := filepath.Join(dir, "products_resource.templ")
+
+	controller := `func (p Products) Index(etx *echo.Context) error {
+	table := views.NewDataTable(etx.QueryParams(), views.ProductColumns)
+
+	productsList, err := models.Product.Paginate(
+		etx.Request().Context(),
+		p.db.Executor(),
+		table.Page,
+		table.PerPage,
+		models.SortScope(table.Sort, views.ProductColumns.SortKeys()...),
+	)
+	if err != nil {
+		return err
+	}
+
+	index := views.ProductIndex{Items: productsList.Products, DataTable: table}
+	return hypermedia.RenderPage(etx, index.Page())
+}
+`
+	view := `type ProductIndex struct {
+	Items     []models.ProductEntity
+	DataTable DataTable
+}
+
+templ (pi ProductIndex) Page() {
+	<div>
+		@pi.Table()
+	</div>
+}
+`
+	if err := os.WriteFile(controllerPath, []byte(controller), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(viewPath, []byte(view), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data := filterTemplateData{ModelName: "Product", ResourceName: "Product", ViewName: "Product"}
+	if err := patchIndexController(controllerPath, data); err != nil {
+		t.Fatalf("patchIndexController returned error: %v", err)
+	}
+	if err := patchIndexView(viewPath, data); err != nil {
+		t.Fatalf("patchIndexView returned error: %v", err)
+	}
+
+	gotController, _ := os.ReadFile(controllerPath)
+	for _, want := range []string{
+		"\tfilters := parseProductFilters(etx)\n\tproductsList, err := models.Product.Paginate(",
+		"\t\tmodels.SortScope(table.Sort, views.ProductColumns.SortKeys()...),\n\t\tfilters.Scope,\n\t)",
+		"views.ProductIndex{Filters: filters, Items: productsList.Products, DataTable: table}",
+	} {
+		if !strings.Contains(string(gotController), want) {
+			t.Fatalf("controller missing %q:\n%s", want, gotController)
+		}
+	}
+
+	gotView, _ := os.ReadFile(viewPath)
+	if want := "\t\t@ProductFilterBar(pi.Filters)\n\t\t@pi.Table()"; !strings.Contains(string(gotView), want) {
+		t.Fatalf("view missing %q:\n%s", want, gotView)
+	}
+}
+
+func TestPatchIndexControllerRequiresScaffoldIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.go")
+	if err := os.WriteFile(path, []byte("package controllers\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := patchIndexController(path, filterTemplateData{ModelName: "Product", ViewName: "Product"})
+	if err == nil || !strings.Contains(err.Error(), "Paginate") {
+		t.Fatalf("expected missing Paginate error, got %v", err)
+	}
+}
+
+func TestFilterComponentsTemplateUsesCSSComponents(t *testing.T) {
+	service := templates.GetGlobalTemplateService()
+
+	plain, err := service.RenderTemplate("filters_components.tmpl", filterTemplateData{})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	styled, err := service.RenderTemplate("filters_components.tmpl", filterTemplateData{CSSComponents: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for _, content := range []string{plain, styled} {
+		for _, want := range []string{"templ DateRangeFilter(", "templ SelectFilter(", "templ NumberRangeFilter("} {
+			if !strings.Contains(content, want) {
+				t.Fatalf("expected %q in rendered components", want)
+			}
+		}
+	}
+	if !strings.Contains(styled, `class="field"`) || strings.Contains(plain, `class="field"`) {
+		t.Fatal("expected css-components classes only when the extension is installed")
+	}
+}
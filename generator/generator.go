@@ -1,6 +1,13 @@
 // Package generator orchestrates model, controller, view, and scaffold generation.
 package generator
 
+import (
+	"time"
+
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/models"
+)
+
 // Generator is the high-level facade for Andurel code generation.
 type Generator struct {
 	coordinator Coordinator
@@ -28,6 +35,18 @@ func (g *Generator) GenerateModelWithPK(resourceName string, tableNameOverride s
 	return g.coordinator.ModelManager.GenerateModel(resourceName, tableNameOverride, skipFactory, primaryKeyColumn)
 }
 
+// GenerateModelWithAssociations generates a model with belongs-to and
+// has-many relations to other models.
+func (g *Generator) GenerateModelWithAssociations(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations) error {
+	return g.coordinator.ModelManager.GenerateModelWithAssociations(resourceName, tableNameOverride, skipFactory, primaryKeyColumn, associations)
+}
+
+// GenerateModelWithJSONTypes generates a model that decodes the given
+// json/jsonb columns into structs declared in the models package.
+func (g *Generator) GenerateModelWithJSONTypes(resourceName string, tableNameOverride string, skipFactory bool, primaryKeyColumn string, associations models.Associations, jsonTypes map[string]string) error {
+	return g.coordinator.ModelManager.GenerateModelWithJSONTypes(resourceName, tableNameOverride, skipFactory, primaryKeyColumn, associations, jsonTypes)
+}
+
 // GenerateController generates controller and route files for a resource.
 func (g *Generator) GenerateController(resourceName, namespace, tableName string, inertia string, isAPI bool) error {
 	return g.coordinator.GenerateController(resourceName, namespace, tableName, inertia, isAPI)
@@ -48,6 +67,46 @@ func (g *Generator) GenerateScaffold(resourceName, namespace, tableName string,
 	return g.coordinator.GenerateScaffold(resourceName, namespace, tableName, skipFactory, primaryKeyColumn, inertia, isAPI)
 }
 
+// GenerateFilters adds query-param filters for the given columns to a
+// scaffolded resource's index.
+func (g *Generator) GenerateFilters(resourceName, namespace, tableName string, columns []string) error {
+	return g.coordinator.FilterManager.GenerateFilters(resourceName, namespace, tableName, columns)
+}
+
+// GenerateShare adds expiring public share links to a scaffolded resource.
+func (g *Generator) GenerateShare(resourceName, tableName string, ttl time.Duration) error {
+	return g.coordinator.ShareManager.GenerateShare(resourceName, tableName, ttl)
+}
+
+// GenerateCalendar adds a per-user ICS feed of upcoming records to a
+// scaffolded resource.
+func (g *Generator) GenerateCalendar(resourceName, tableName string, columns CalendarColumns) error {
+	return g.coordinator.CalendarManager.GenerateCalendar(resourceName, tableName, columns)
+}
+
+// GenerateFeed adds an Atom feed of the newest records to a scaffolded
+// resource and lists it in the sitemap.
+func (g *Generator) GenerateFeed(resourceName, namespace, tableName string) error {
+	return g.coordinator.FeedManager.GenerateFeed(resourceName, namespace, tableName)
+}
+
+// GenerateAddress adds geocoded address columns to a resource's table,
+// ahead of scaffolding it.
+func (g *Generator) GenerateAddress(resourceName, tableName string) error {
+	return g.coordinator.AddressManager.GenerateAddress(resourceName, tableName)
+}
+
+// GenerateSerializer writes a JSON serializer for an existing model.
+func (g *Generator) GenerateSerializer(resourceName string, opts SerializerOptions) error {
+	return g.coordinator.SerializerManager.GenerateSerializer(resourceName, opts)
+}
+
+// DestroyResource removes the files and registrations 'generate scaffold'
+// wrote for a resource. Migrations are kept.
+func (g *Generator) DestroyResource(resourceName, namespace string) error {
+	return g.coordinator.DestroyManager.DestroyResource(resourceName, namespace)
+}
+
 // GenerateControllerFromModel generates a controller by reading an existing model.
 func (g *Generator) GenerateControllerFromModel(resourceName string) error {
 	return g.coordinator.GenerateControllerFromModel(resourceName)
@@ -55,14 +114,58 @@ func (g *Generator) GenerateControllerFromModel(resourceName string) error {
 
 // GenerateView generates views for a resource.
 func (g *Generator) GenerateView(resourceName, tableName, namespace string) error {
+	g.coordinator.useModelDatabase(resourceName)
 	return g.coordinator.ViewManager.GenerateView(resourceName, tableName, namespace)
 }
 
 // GenerateViewFromModel generates views by reading an existing model.
 func (g *Generator) GenerateViewFromModel(resourceName string, withController bool) error {
+	g.coordinator.useModelDatabase(resourceName)
 	return g.coordinator.ViewManager.GenerateViewFromModel(resourceName, withController)
 }
 
+// SetNullablePointers generates the nullable columns of new models as
+// pointers, such as *string, instead of the null type in andurel.lock.
+// Controllers and views follow the model's field types.
+func (g *Generator) SetNullablePointers(enabled bool) {
+	nullType := ""
+	if enabled {
+		nullType = types.NullTypePointer
+	}
+	g.coordinator.ModelManager.SetNullType(nullType)
+}
+
+// SetUpsertConflictColumns makes the Upsert of new models update the row
+// matching these columns, which need a unique constraint, instead of the
+// primary key or the table's only other unique key.
+func (g *Generator) SetUpsertConflictColumns(columns []string) {
+	g.coordinator.ModelManager.SetConflictColumns(columns)
+}
+
+// SetSchemaDatabase makes model generation and updates read the table from
+// db, a connection to the project's database, instead of the migrations.
+func (g *Generator) SetSchemaDatabase(db SchemaQuerier) {
+	g.coordinator.ModelManager.SetSchemaDatabase(db)
+}
+
+// SetFromView makes model generation fail unless the table is a view.
+// Models of views only get the query methods.
+func (g *Generator) SetFromView(enabled bool) {
+	g.coordinator.ModelManager.SetFromView(enabled)
+}
+
+// SetWithTests makes model generation also write models/<name>_test.go,
+// which tests the model's queries against database.NewTestDB.
+func (g *Generator) SetWithTests(enabled bool) {
+	g.coordinator.ModelManager.SetWithTests(enabled)
+}
+
+// SetDatabaseName makes generation read the migrations of a connection
+// named in DATABASES and route the generated queries to it.
+func (g *Generator) SetDatabaseName(name string) error {
+	return g.coordinator.SetDatabaseName(name)
+}
+
 // SetControllerPKResolver overrides primary key resolution for controller generation.
 func (g *Generator) SetControllerPKResolver(resolver PrimaryKeyResolver) {
 	g.coordinator.ControllerManager.SetPrimaryKeyResolver(resolver)
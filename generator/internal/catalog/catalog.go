@@ -2,6 +2,9 @@ package catalog
 
 import (
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -9,14 +12,19 @@ import (
 type Catalog struct {
 	DefaultSchema string
 	Schemas       map[string]*Schema
-	mutex         sync.RWMutex
+	// Database is the connection named in DATABASES whose migrations built
+	// the catalog, or empty for the primary database.
+	Database string
+	mutex    sync.RWMutex
 }
 
 // Schema represents schema.
 type Schema struct {
-	Name   string
-	Tables map[string]*Table
-	Enums  map[string]*Enum
+	Name    string
+	Tables  map[string]*Table
+	Views   map[string]*Table
+	Enums   map[string]*Enum
+	Domains map[string]*Domain
 }
 
 // Enum represents enum.
@@ -26,6 +34,18 @@ type Enum struct {
 	CreatedBy string
 }
 
+// Domain is a type created with CREATE DOMAIN. BaseType is the type it is
+// based on, with the domains it is based on resolved.
+type Domain struct {
+	Name      string
+	BaseType  string
+	Length    *int32
+	Precision *int32
+	Scale     *int32
+	NotNull   bool
+	CreatedBy string
+}
+
 // NewCatalog creates a new catalog.
 func NewCatalog(defaultSchema string) *Catalog {
 	catalog := &Catalog{
@@ -34,9 +54,11 @@ func NewCatalog(defaultSchema string) *Catalog {
 	}
 
 	catalog.Schemas[defaultSchema] = &Schema{
-		Name:   defaultSchema,
-		Tables: make(map[string]*Table),
-		Enums:  make(map[string]*Enum),
+		Name:    defaultSchema,
+		Tables:  make(map[string]*Table),
+		Views:   make(map[string]*Table),
+		Enums:   make(map[string]*Enum),
+		Domains: make(map[string]*Domain),
 	}
 
 	return catalog
@@ -69,17 +91,23 @@ func (c *Catalog) CreateSchema(name string) (*Schema, error) {
 	}
 
 	schema := &Schema{
-		Name:   name,
-		Tables: make(map[string]*Table),
-		Enums:  make(map[string]*Enum),
+		Name:    name,
+		Tables:  make(map[string]*Table),
+		Views:   make(map[string]*Table),
+		Enums:   make(map[string]*Enum),
+		Domains: make(map[string]*Domain),
 	}
 
 	c.Schemas[name] = schema
 	return schema, nil
 }
 
-// GetTable returns table.
+// GetTable returns table. With an empty schemaName, a tableName qualified
+// with its schema, as in billing.invoices, is looked up in that schema.
 func (c *Catalog) GetTable(schemaName, tableName string) (*Table, error) {
+	if schemaName == "" {
+		schemaName, tableName = SplitQualifiedName(tableName)
+	}
 	schema, err := c.GetSchema(schemaName)
 	if err != nil {
 		return nil, err
@@ -97,6 +125,93 @@ func (c *Catalog) GetTable(schemaName, tableName string) (*Table, error) {
 	return table, nil
 }
 
+// AddView adds a view, replacing an existing view of the same name as
+// CREATE OR REPLACE VIEW does.
+func (c *Catalog) AddView(schemaName string, view *Table) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if schemaName == "" {
+		schemaName = c.DefaultSchema
+	}
+
+	schema, exists := c.Schemas[schemaName]
+	if !exists {
+		return fmt.Errorf("schema %s not found", schemaName)
+	}
+
+	if _, exists := schema.Tables[view.Name]; exists {
+		return fmt.Errorf(
+			"table %s already exists in schema %s",
+			view.Name,
+			schemaName,
+		)
+	}
+
+	view.Schema = schemaName
+	schema.Views[view.Name] = view
+	return nil
+}
+
+// GetView returns a view. Like GetTable, it accepts a name qualified with
+// its schema.
+func (c *Catalog) GetView(schemaName, viewName string) (*Table, error) {
+	if schemaName == "" {
+		schemaName, viewName = SplitQualifiedName(viewName)
+	}
+	schema, err := c.GetSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	view, exists := schema.Views[viewName]
+	if !exists {
+		return nil, fmt.Errorf(
+			"view %s not found in schema %s",
+			viewName,
+			schemaName,
+		)
+	}
+
+	return view, nil
+}
+
+// DropView performs the drop view operation.
+func (c *Catalog) DropView(schemaName, viewName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if schemaName == "" {
+		schemaName = c.DefaultSchema
+	}
+
+	schema, exists := c.Schemas[schemaName]
+	if !exists {
+		return fmt.Errorf("schema %s not found", schemaName)
+	}
+
+	if _, exists := schema.Views[viewName]; !exists {
+		return fmt.Errorf(
+			"view %s not found in schema %s",
+			viewName,
+			schemaName,
+		)
+	}
+
+	delete(schema.Views, viewName)
+	return nil
+}
+
+// SplitQualifiedName splits billing.invoices into its schema and name. The
+// schema of an unqualified name is empty.
+func SplitQualifiedName(name string) (string, string) {
+	schemaName, tableName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		return "", name
+	}
+	return schemaName, tableName
+}
+
 // AddTable performs the add table operation.
 func (c *Catalog) AddTable(schemaName string, table *Table) error {
 	c.mutex.Lock()
@@ -185,6 +300,14 @@ func (c *Catalog) RenameTable(schemaName, oldName, newName string) error {
 	schema.Tables[newName] = table
 	delete(schema.Tables, oldName)
 
+	for _, other := range schema.Tables {
+		for _, col := range other.Columns {
+			if col.ForeignKey != nil && col.ForeignKey.ReferencedTable == oldName {
+				col.ForeignKey.ReferencedTable = newName
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -316,6 +439,37 @@ func (c *Catalog) ListTables(schemaName string) ([]*Table, error) {
 	return tables, nil
 }
 
+// Reference is a foreign key column of a table.
+type Reference struct {
+	Table  *Table
+	Column *Column
+}
+
+// ReferencesTo returns the foreign key columns in the schema that reference
+// tableName, ordered by table name.
+func (c *Catalog) ReferencesTo(schemaName, tableName string) []Reference {
+	schema, err := c.GetSchema(schemaName)
+	if err != nil {
+		return nil
+	}
+	tables, _ := c.ListTables(schema.Name)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+	qualified := schema.Name + "." + tableName
+	var references []Reference
+	for _, table := range tables {
+		for _, col := range table.Columns {
+			if col.ForeignKey == nil {
+				continue
+			}
+			if referenced := col.ForeignKey.ReferencedTable; referenced == tableName || referenced == qualified {
+				references = append(references, Reference{Table: table, Column: col})
+			}
+		}
+	}
+	return references
+}
+
 // AddEnum performs the add enum operation.
 func (c *Catalog) AddEnum(schemaName string, enum *Enum) error {
 	c.mutex.Lock()
@@ -341,3 +495,183 @@ func (c *Catalog) AddEnum(schemaName string, enum *Enum) error {
 	schema.Enums[enum.Name] = enum
 	return nil
 }
+
+// GetEnum returns enum.
+func (c *Catalog) GetEnum(schemaName, enumName string) (*Enum, error) {
+	schema, err := c.GetSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	enum, exists := schema.Enums[enumName]
+	if !exists {
+		return nil, fmt.Errorf(
+			"enum %s not found in schema %s",
+			enumName,
+			schemaName,
+		)
+	}
+
+	return enum, nil
+}
+
+// ListEnums returns the enums of a schema sorted by name.
+func (c *Catalog) ListEnums(schemaName string) ([]*Enum, error) {
+	schema, err := c.GetSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	enums := make([]*Enum, 0, len(schema.Enums))
+	for _, enum := range schema.Enums {
+		enums = append(enums, enum)
+	}
+	sort.Slice(enums, func(i, j int) bool {
+		return enums[i].Name < enums[j].Name
+	})
+
+	return enums, nil
+}
+
+// DropEnum performs the drop enum operation.
+func (c *Catalog) DropEnum(schemaName, enumName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if schemaName == "" {
+		schemaName = c.DefaultSchema
+	}
+
+	schema, exists := c.Schemas[schemaName]
+	if !exists {
+		return fmt.Errorf("schema %s not found", schemaName)
+	}
+
+	if _, exists := schema.Enums[enumName]; !exists {
+		return fmt.Errorf(
+			"enum %s not found in schema %s",
+			enumName,
+			schemaName,
+		)
+	}
+
+	delete(schema.Enums, enumName)
+	return nil
+}
+
+// AddDomain performs the add domain operation.
+func (c *Catalog) AddDomain(schemaName string, domain *Domain) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if schemaName == "" {
+		schemaName = c.DefaultSchema
+	}
+
+	schema, exists := c.Schemas[schemaName]
+	if !exists {
+		return fmt.Errorf("schema %s not found", schemaName)
+	}
+
+	if _, exists := schema.Domains[domain.Name]; exists {
+		return fmt.Errorf(
+			"domain %s already exists in schema %s",
+			domain.Name,
+			schemaName,
+		)
+	}
+
+	schema.Domains[domain.Name] = domain
+	return nil
+}
+
+// GetDomain returns domain.
+func (c *Catalog) GetDomain(schemaName, domainName string) (*Domain, error) {
+	schema, err := c.GetSchema(schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, exists := schema.Domains[domainName]
+	if !exists {
+		return nil, fmt.Errorf(
+			"domain %s not found in schema %s",
+			domainName,
+			schemaName,
+		)
+	}
+
+	return domain, nil
+}
+
+// DropDomain performs the drop domain operation.
+func (c *Catalog) DropDomain(schemaName, domainName string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if schemaName == "" {
+		schemaName = c.DefaultSchema
+	}
+
+	schema, exists := c.Schemas[schemaName]
+	if !exists {
+		return fmt.Errorf("schema %s not found", schemaName)
+	}
+
+	if _, exists := schema.Domains[domainName]; !exists {
+		return fmt.Errorf(
+			"domain %s not found in schema %s",
+			domainName,
+			schemaName,
+		)
+	}
+
+	delete(schema.Domains, domainName)
+	return nil
+}
+
+// LookupDomain returns the domain a column type names, such as email,
+// auth.email or email[], or nil when the type is not a domain.
+func (c *Catalog) LookupDomain(dataType string) *Domain {
+	name := strings.ToLower(strings.TrimSpace(dataType))
+	for strings.HasSuffix(name, "[]") {
+		name = strings.TrimSpace(strings.TrimSuffix(name, "[]"))
+	}
+	schemaName, domainName, qualified := strings.Cut(name, ".")
+	if !qualified {
+		schemaName, domainName = "", name
+	}
+
+	domain, err := c.GetDomain(schemaName, domainName)
+	if err != nil {
+		return nil
+	}
+	return domain
+}
+
+// AddValue adds value to the enum, before or after an existing value when
+// one is given, mirroring ALTER TYPE ... ADD VALUE.
+func (e *Enum) AddValue(value, before, after string) error {
+	if slices.Contains(e.Values, value) {
+		return fmt.Errorf("enum %s already has value %s", e.Name, value)
+	}
+
+	anchor := before
+	if anchor == "" {
+		anchor = after
+	}
+	if anchor == "" {
+		e.Values = append(e.Values, value)
+		return nil
+	}
+
+	idx := slices.Index(e.Values, anchor)
+	if idx == -1 {
+		return fmt.Errorf("enum %s has no value %s", e.Name, anchor)
+	}
+	if after != "" {
+		idx++
+	}
+	e.Values = slices.Insert(e.Values, idx, value)
+	return nil
+}
@@ -58,6 +58,18 @@ func TestCatalogSchemaTableEnumLifecycleAndErrors(t *testing.T) {
 	if err := cat.AddEnum("missing", &Enum{Name: "role"}); err == nil {
 		t.Fatal("expected missing schema on AddEnum")
 	}
+	if enum, err := cat.GetEnum("", "status"); err != nil || enum.Name != "status" {
+		t.Fatalf("GetEnum = (%v, %v), want status enum", enum, err)
+	}
+	if _, err := cat.GetEnum("", "missing"); err == nil {
+		t.Fatal("expected missing enum error")
+	}
+	if err := cat.DropEnum("", "missing"); err == nil {
+		t.Fatal("expected missing enum on DropEnum")
+	}
+	if err := cat.DropEnum("", "status"); err != nil {
+		t.Fatalf("DropEnum returned error: %v", err)
+	}
 
 	if err := cat.RenameTable("", "missing", "accounts"); err == nil {
 		t.Fatal("expected missing table on RenameTable")
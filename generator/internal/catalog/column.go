@@ -1,13 +1,49 @@
 package catalog
 
 import (
+	"strings"
+
 	"github.com/mbvlabs/andurel/generator/internal/validation"
 )
 
+// Referential actions of a foreign key. A foreign key declared without an
+// action uses NoAction.
+const (
+	NoAction   = "NO ACTION"
+	Restrict   = "RESTRICT"
+	Cascade    = "CASCADE"
+	SetNull    = "SET NULL"
+	SetDefault = "SET DEFAULT"
+)
+
+// Kinds of identity columns.
+const (
+	IdentityAlways    = "ALWAYS"
+	IdentityByDefault = "BY DEFAULT"
+)
+
 // ForeignKey represents foreign key.
 type ForeignKey struct {
+	Name             string // constraint name, empty when the migration names none
 	ReferencedTable  string
 	ReferencedColumn string
+	OnDelete         string // referential action, empty for NO ACTION
+	OnUpdate         string // referential action, empty for NO ACTION
+}
+
+// DeleteAction returns the ON DELETE action, NoAction when none is declared.
+func (fk *ForeignKey) DeleteAction() string {
+	if fk.OnDelete == "" {
+		return NoAction
+	}
+	return fk.OnDelete
+}
+
+// BlocksDelete reports whether the foreign key keeps a referenced row from
+// being deleted while rows reference it.
+func (fk *ForeignKey) BlocksDelete() bool {
+	action := fk.DeleteAction()
+	return action == NoAction || action == Restrict
 }
 
 // Column represents column.
@@ -25,7 +61,11 @@ type Column struct {
 	IsPrimaryKey    bool
 	IsUnique        bool
 	IsAutoIncrement bool
+	Identity        string      // IdentityAlways or IdentityByDefault for identity columns
+	Generated       string      // expression of a GENERATED ALWAYS AS (...) STORED column
 	ForeignKey      *ForeignKey // nil if not a foreign key
+	Comment         string      // set by COMMENT ON COLUMN
+	Domain          string      // domain the column was declared with; DataType is its base type
 }
 
 // NewColumn creates a new column.
@@ -62,6 +102,22 @@ func (c *Column) SetAutoIncrement() *Column {
 	return c
 }
 
+// SetIdentity marks the column as GENERATED ALWAYS or BY DEFAULT AS
+// IDENTITY. Identity columns are filled by the database like serial ones.
+func (c *Column) SetIdentity(kind string) *Column {
+	c.Identity = kind
+	c.IsAutoIncrement = true
+	c.IsNullable = false
+	return c
+}
+
+// SetGenerated marks the column as GENERATED ALWAYS AS (expression) STORED.
+// The database computes generated columns, so models only read them.
+func (c *Column) SetGenerated(expression string) *Column {
+	c.Generated = expression
+	return c
+}
+
 // SetDefault sets default.
 func (c *Column) SetDefault(defaultValue string) *Column {
 	c.DefaultVal = &defaultValue
@@ -96,6 +152,23 @@ func (c *Column) SetForeignKey(referencedTable, referencedColumn string) *Column
 	return c
 }
 
+// SetComment sets comment.
+func (c *Column) SetComment(comment string) *Column {
+	c.Comment = comment
+	return c
+}
+
+// HasAnnotation reports whether the column comment contains the
+// "andurel:<name>" annotation as a whitespace-separated token.
+func (c *Column) HasAnnotation(name string) bool {
+	for _, token := range strings.Fields(c.Comment) {
+		if token == "andurel:"+name {
+			return true
+		}
+	}
+	return false
+}
+
 // SetCreatedBy sets created by.
 func (c *Column) SetCreatedBy(migrationFile string) *Column {
 	c.CreatedBy = migrationFile
@@ -120,6 +193,10 @@ func (c *Column) Clone() *Column {
 		IsPrimaryKey:    c.IsPrimaryKey,
 		IsUnique:        c.IsUnique,
 		IsAutoIncrement: c.IsAutoIncrement,
+		Identity:        c.Identity,
+		Generated:       c.Generated,
+		Comment:         c.Comment,
+		Domain:          c.Domain,
 	}
 
 	if c.Length != nil {
@@ -143,10 +220,8 @@ func (c *Column) Clone() *Column {
 	}
 
 	if c.ForeignKey != nil {
-		clone.ForeignKey = &ForeignKey{
-			ReferencedTable:  c.ForeignKey.ReferencedTable,
-			ReferencedColumn: c.ForeignKey.ReferencedColumn,
-		}
+		foreignKey := *c.ForeignKey
+		clone.ForeignKey = &foreignKey
 	}
 
 	return clone
@@ -2,6 +2,9 @@ package catalog
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 // Table represents table.
@@ -10,7 +13,13 @@ type Table struct {
 	Name      string
 	Columns   []*Column
 	Indexes   []*Index
+	Checks    []*Check
 	CreatedBy string // migration file that created this table
+
+	// View and Materialized are set for a view, whose columns come from
+	// its SELECT list.
+	View         bool
+	Materialized bool
 }
 
 // Index represents index.
@@ -18,9 +27,17 @@ type Index struct {
 	Name      string
 	Columns   []string
 	IsUnique  bool
+	Partial   bool // Has a WHERE clause or expression keys, so Columns alone do not describe it
 	CreatedBy string
 }
 
+// Check is a CHECK constraint on a table. Name is empty for an unnamed
+// constraint.
+type Check struct {
+	Name       string
+	Expression string // The condition inside CHECK (...)
+}
+
 // NewTable creates a new table.
 func NewTable(schema, name string) *Table {
 	return &Table{
@@ -57,11 +74,15 @@ func (t *Table) GetColumn(name string) (*Column, error) {
 	return nil, fmt.Errorf("column %s not found in table %s", name, t.Name)
 }
 
-// DropColumn performs the drop column operation.
+// DropColumn performs the drop column operation. Like Postgres, it also
+// drops the indexes on the column.
 func (t *Table) DropColumn(name string) error {
 	for i, col := range t.Columns {
 		if col.Name == name {
 			t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+			t.Indexes = slices.DeleteFunc(t.Indexes, func(index *Index) bool {
+				return slices.Contains(index.Columns, name)
+			})
 			return nil
 		}
 	}
@@ -96,6 +117,20 @@ func (t *Table) RenameColumn(oldName, newName string) error {
 	}
 
 	col.Name = newName
+
+	for _, index := range t.Indexes {
+		for i, column := range index.Columns {
+			if column == oldName {
+				index.Columns[i] = newName
+			}
+		}
+	}
+
+	// Postgres rewrites CHECK expressions to follow the renamed column.
+	pattern := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(oldName) + `\b`)
+	for _, check := range t.Checks {
+		check.Expression = pattern.ReplaceAllLiteralString(check.Expression, newName)
+	}
 	return nil
 }
 
@@ -126,6 +161,62 @@ func (t *Table) DropIndex(name string) error {
 	return fmt.Errorf("index %s not found in table %s", name, t.Name)
 }
 
+// AddCheck performs the add check operation.
+func (t *Table) AddCheck(check *Check) error {
+	if check.Name != "" {
+		if _, ok := t.GetCheck(check.Name); ok {
+			return fmt.Errorf(
+				"constraint %s already exists in table %s",
+				check.Name,
+				t.Name,
+			)
+		}
+	}
+
+	t.Checks = append(t.Checks, check)
+	return nil
+}
+
+// GetCheck returns the CHECK constraint with the given name.
+func (t *Table) GetCheck(name string) (*Check, bool) {
+	for _, check := range t.Checks {
+		if check.Name != "" && strings.EqualFold(check.Name, name) {
+			return check, true
+		}
+	}
+	return nil, false
+}
+
+// DropCheck performs the drop check operation.
+func (t *Table) DropCheck(name string) error {
+	for i, check := range t.Checks {
+		if check.Name != "" && strings.EqualFold(check.Name, name) {
+			t.Checks = append(t.Checks[:i], t.Checks[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("constraint %s not found in table %s", name, t.Name)
+}
+
+// GetForeignKey returns the column whose foreign key constraint is named
+// name. Unnamed foreign keys go by the name Postgres gives them,
+// <table>_<column>_fkey.
+func (t *Table) GetForeignKey(name string) (*Column, bool) {
+	for _, col := range t.Columns {
+		if col.ForeignKey == nil {
+			continue
+		}
+		constraintName := col.ForeignKey.Name
+		if constraintName == "" {
+			constraintName = t.Name + "_" + col.Name + "_fkey"
+		}
+		if strings.EqualFold(constraintName, name) {
+			return col, true
+		}
+	}
+	return nil, false
+}
+
 // GetPrimaryKeyColumns returns primary key columns.
 func (t *Table) GetPrimaryKeyColumns() []*Column {
 	var pkColumns []*Column
@@ -151,6 +242,7 @@ func (t *Table) Clone() *Table {
 		CreatedBy: t.CreatedBy,
 		Columns:   make([]*Column, len(t.Columns)),
 		Indexes:   make([]*Index, len(t.Indexes)),
+		Checks:    make([]*Check, len(t.Checks)),
 	}
 
 	for i, col := range t.Columns {
@@ -162,9 +254,14 @@ func (t *Table) Clone() *Table {
 			Name:      idx.Name,
 			Columns:   append([]string(nil), idx.Columns...),
 			IsUnique:  idx.IsUnique,
+			Partial:   idx.Partial,
 			CreatedBy: idx.CreatedBy,
 		}
 	}
 
+	for i, check := range t.Checks {
+		clone.Checks[i] = &Check{Name: check.Name, Expression: check.Expression}
+	}
+
 	return clone
 }
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
 )
 
 // AlterTableParser handles ALTER TABLE statements
@@ -92,11 +94,27 @@ func (p *AlterTableParser) parseAlterTableSingleOperation(
 		return p.parseRenameColumn(stmt, operation)
 	case strings.HasPrefix(operationLower, "rename to"):
 		return p.parseRenameTable(stmt, operation)
-	case strings.HasPrefix(operationLower, "add constraint"):
+	case strings.HasPrefix(operationLower, "add constraint"), strings.HasPrefix(operationLower, "add foreign key"):
 		stmt.AlterOperation = "ADD_CONSTRAINT"
+		if strings.Contains(operationLower, "foreign key") {
+			if isCompositeForeignKey(operation) {
+				return stmt, nil
+			}
+			column, foreignKey, ok := parseTableLevelForeignKey(operation)
+			if !ok {
+				return nil, unsupportedStatement(operation, "FOREIGN KEY must name one local and one referenced column")
+			}
+			stmt.ColumnName = column
+			stmt.ForeignKey = foreignKey
+			return stmt, nil
+		}
+		if expression, ok := parseCheckExpression(operation); ok {
+			stmt.Check = &catalog.Check{Name: parseConstraintName(operation), Expression: expression}
+		}
 		return stmt, nil
 	case strings.HasPrefix(operationLower, "drop constraint"):
 		stmt.AlterOperation = "DROP_CONSTRAINT"
+		stmt.ConstraintName = parseConstraintName(operation)
 		return stmt, nil
 	default:
 		return nil, unsupportedStatement(operation, "ALTER TABLE operation is not supported by model generation")
@@ -132,6 +150,9 @@ func (p *AlterTableParser) parseAddColumn(
 	stmt.AlterOperation = "ADD_COLUMN"
 	stmt.ColumnDef = column
 	stmt.ColumnName = column.Name
+	if expression, ok := parseCheckExpression(columnDef); ok {
+		stmt.Check = &catalog.Check{Expression: expression}
+	}
 
 	return stmt, nil
 }
@@ -202,6 +223,16 @@ func (p *AlterTableParser) parseAlterColumn(
 		}
 	case strings.HasPrefix(columnOpLower, "drop default"):
 		stmt.ColumnChanges["drop_default"] = true
+	case strings.HasPrefix(columnOpLower, "add generated"):
+		identity, ok := parseIdentity(columnOperation[len("add "):])
+		if !ok {
+			return nil, unsupportedStatement(operation, "ALTER COLUMN operation is not supported by model generation")
+		}
+		stmt.ColumnChanges["identity"] = identity
+	case strings.HasPrefix(columnOpLower, "drop identity"):
+		stmt.ColumnChanges["identity"] = ""
+	case strings.HasPrefix(columnOpLower, "drop expression"):
+		stmt.ColumnChanges["drop_expression"] = true
 	default:
 		return nil, unsupportedStatement(operation, "ALTER COLUMN operation is not supported by model generation")
 	}
@@ -2,6 +2,7 @@ package ddl
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
@@ -45,10 +46,17 @@ func (v *CatalogVisitor) VisitCreateTable(stmt *CreateTableStatement) error {
 	table := catalog.NewTable(schemaName, stmt.TableName).SetCreatedBy(v.migrationFile)
 
 	for _, col := range stmt.Columns {
+		v.resolveDomain(col)
+		resolveSerial(col)
 		if err := table.AddColumn(col); err != nil {
 			return fmt.Errorf("failed to add column %s: %w", col.Name, err)
 		}
 	}
+	for _, check := range stmt.Checks {
+		if err := table.AddCheck(check); err != nil {
+			return err
+		}
+	}
 
 	return v.catalog.AddTable(schemaName, table)
 }
@@ -67,7 +75,15 @@ func (v *CatalogVisitor) VisitAlterTable(stmt *AlterTableStatement) error {
 
 	switch stmt.AlterOperation {
 	case "ADD_COLUMN":
-		return table.AddColumn(stmt.ColumnDef)
+		v.resolveDomain(stmt.ColumnDef)
+		resolveSerial(stmt.ColumnDef)
+		if err := table.AddColumn(stmt.ColumnDef); err != nil {
+			return err
+		}
+		if stmt.Check != nil {
+			return table.AddCheck(stmt.Check)
+		}
+		return nil
 	case "DROP_COLUMN":
 		return table.DropColumn(stmt.ColumnName)
 	case "ALTER_COLUMN":
@@ -80,6 +96,24 @@ func (v *CatalogVisitor) VisitAlterTable(stmt *AlterTableStatement) error {
 		// FIXED: Direct access to stmt.Operations - no conversion needed!
 		return v.applyMultipleOperations(schemaName, stmt.TableName, stmt.Operations)
 	case "ADD_CONSTRAINT", "DROP_CONSTRAINT":
+		if stmt.Check != nil {
+			return table.AddCheck(stmt.Check)
+		}
+		if stmt.ForeignKey != nil {
+			column, err := table.GetColumn(stmt.ColumnName)
+			if err != nil {
+				return err
+			}
+			column.ForeignKey = stmt.ForeignKey
+			return nil
+		}
+		if _, ok := table.GetCheck(stmt.ConstraintName); ok && stmt.AlterOperation == "DROP_CONSTRAINT" {
+			return table.DropCheck(stmt.ConstraintName)
+		}
+		if column, ok := table.GetForeignKey(stmt.ConstraintName); ok && stmt.AlterOperation == "DROP_CONSTRAINT" {
+			column.ForeignKey = nil
+			return nil
+		}
 		operation := strings.ToLower(stmt.Raw)
 		if stmt.AlterOperation == "DROP_CONSTRAINT" || strings.Contains(operation, "primary key") {
 			return unsupportedStatement(stmt.Raw, "constraint operation can change the primary key used by generated models")
@@ -105,12 +139,15 @@ func (v *CatalogVisitor) applyAlterColumn(table *catalog.Table, columnName strin
 			if typeStr, ok := value.(string); ok {
 				dataType, length, precision, scale := ParseDataType(typeStr)
 				newColumn.DataType = dataType
+				newColumn.Length, newColumn.Precision, newColumn.Scale = nil, nil, nil
 				if length != nil {
 					newColumn.SetLength(*length)
 				}
 				if precision != nil && scale != nil {
 					newColumn.SetPrecisionScale(*precision, *scale)
 				}
+				newColumn.Domain = ""
+				v.resolveDomain(newColumn)
 			}
 		case "nullable":
 			if nullable, ok := value.(bool); ok {
@@ -124,6 +161,22 @@ func (v *CatalogVisitor) applyAlterColumn(table *catalog.Table, columnName strin
 			if drop, ok := value.(bool); ok && drop {
 				newColumn.DefaultVal = nil
 			}
+		case "drop_expression":
+			if drop, ok := value.(bool); ok && drop {
+				newColumn.Generated = ""
+			}
+		case "identity":
+			if identity, ok := value.(string); ok {
+				if identity == "" {
+					// DROP IDENTITY leaves serial columns alone.
+					if newColumn.Identity != "" {
+						newColumn.Identity = ""
+						newColumn.IsAutoIncrement = false
+					}
+				} else {
+					newColumn.SetIdentity(identity)
+				}
+			}
 		}
 	}
 
@@ -131,19 +184,11 @@ func (v *CatalogVisitor) applyAlterColumn(table *catalog.Table, columnName strin
 }
 
 func (v *CatalogVisitor) applyRenameTable(schemaName, oldName, newName string) error {
-	table, err := v.catalog.GetTable(schemaName, oldName)
-	if err != nil {
+	if _, err := v.catalog.GetTable(schemaName, oldName); err != nil {
 		return err
 	}
 
-	newTable := table.Clone()
-	newTable.Name = newName
-
-	if err := v.catalog.DropTable(schemaName, oldName); err != nil {
-		return err
-	}
-
-	return v.catalog.AddTable(schemaName, newTable)
+	return v.catalog.RenameTable(schemaName, oldName, newName)
 }
 
 func (v *CatalogVisitor) applyMultipleOperations(schemaName, tableName string, operations []string) error {
@@ -175,13 +220,67 @@ func (v *CatalogVisitor) VisitDropTable(stmt *DropTableStatement) error {
 	return v.catalog.DropTable(schemaName, stmt.TableName)
 }
 
-// Stub implementations for operations we don't process
-func (v *CatalogVisitor) VisitCreateIndex(stmt *CreateIndexStatement) error {
+// VisitCommentOnColumn records the column comment so generators can read
+// andurel annotations from it.
+func (v *CatalogVisitor) VisitCommentOnColumn(stmt *CommentOnColumnStatement) error {
+	schemaName := stmt.SchemaName
+	if schemaName == "" {
+		schemaName = v.catalog.DefaultSchema
+	}
+
+	table, err := v.catalog.GetTable(schemaName, stmt.TableName)
+	if err != nil {
+		return err
+	}
+	column, err := table.GetColumn(stmt.ColumnName)
+	if err != nil {
+		return err
+	}
+
+	comment := ""
+	if stmt.Comment != nil {
+		comment = *stmt.Comment
+	}
+	column.SetComment(comment)
 	return nil
 }
 
-// VisitDropIndex performs the visit drop index operation.
+// VisitCreateIndex records the index on its table. Indexes on tables the
+// catalog does not know, and expression-only indexes, are ignored.
+func (v *CatalogVisitor) VisitCreateIndex(stmt *CreateIndexStatement) error {
+	if stmt.TableName == "" || len(stmt.Columns) == 0 {
+		return nil
+	}
+	schemaName := stmt.SchemaName
+	if schemaName == "" {
+		schemaName = v.catalog.DefaultSchema
+	}
+	table, err := v.catalog.GetTable(schemaName, stmt.TableName)
+	if err != nil {
+		return nil
+	}
+	if stmt.IfNotExists && slices.ContainsFunc(table.Indexes, func(index *catalog.Index) bool { return index.Name == stmt.IndexName }) {
+		return nil
+	}
+
+	return table.AddIndex(&catalog.Index{
+		Name:      stmt.IndexName,
+		Columns:   stmt.Columns,
+		IsUnique:  stmt.Unique,
+		Partial:   stmt.Partial,
+		CreatedBy: v.migrationFile,
+	})
+}
+
+// VisitDropIndex removes the named indexes from whichever table has them.
 func (v *CatalogVisitor) VisitDropIndex(stmt *DropIndexStatement) error {
+	for _, name := range stmt.IndexNames {
+		for _, schema := range v.catalog.Schemas {
+			for _, table := range schema.Tables {
+				_ = table.DropIndex(name)
+			}
+		}
+	}
 	return nil
 }
 
@@ -195,12 +294,140 @@ func (v *CatalogVisitor) VisitDropSchema(stmt *DropSchemaStatement) error {
 	return nil
 }
 
-// VisitCreateEnum performs the visit create enum operation.
+// VisitCreateEnum performs the visit create enum operation. CREATE TYPE
+// statements for composite or range types carry no enum definition and are
+// skipped.
 func (v *CatalogVisitor) VisitCreateEnum(stmt *CreateEnumStatement) error {
-	return nil
+	if stmt.EnumDef == nil {
+		return nil
+	}
+
+	schemaName := stmt.SchemaName
+	if schemaName == "" {
+		schemaName = v.catalog.DefaultSchema
+	}
+
+	if _, err := v.catalog.GetSchema(schemaName); err != nil {
+		if _, createErr := v.catalog.CreateSchema(schemaName); createErr != nil {
+			return fmt.Errorf("failed to create schema %s: %w", schemaName, createErr)
+		}
+	}
+
+	enum := &catalog.Enum{
+		Name:      stmt.EnumDef.Name,
+		Values:    append([]string(nil), stmt.EnumDef.Values...),
+		CreatedBy: v.migrationFile,
+	}
+
+	return v.catalog.AddEnum(schemaName, enum)
 }
 
-// VisitDropEnum performs the visit drop enum operation.
+// VisitDropEnum performs the visit drop enum operation. Types the catalog
+// does not know, like dropped composite types, are ignored.
 func (v *CatalogVisitor) VisitDropEnum(stmt *DropEnumStatement) error {
+	if _, err := v.catalog.GetEnum(stmt.SchemaName, stmt.EnumName); err != nil {
+		return nil
+	}
+
+	return v.catalog.DropEnum(stmt.SchemaName, stmt.EnumName)
+}
+
+// VisitAlterEnum performs the visit alter enum operation.
+func (v *CatalogVisitor) VisitAlterEnum(stmt *AlterEnumStatement) error {
+	if stmt.AddValue == "" && stmt.RenameValue == "" {
+		return nil
+	}
+
+	enum, err := v.catalog.GetEnum(stmt.SchemaName, stmt.EnumName)
+	if err != nil {
+		return err
+	}
+
+	if stmt.AddValue != "" {
+		if stmt.IfNotExists && slices.Contains(enum.Values, stmt.AddValue) {
+			return nil
+		}
+		return enum.AddValue(stmt.AddValue, stmt.Before, stmt.After)
+	}
+
+	idx := slices.Index(enum.Values, stmt.RenameValue)
+	if idx == -1 {
+		return fmt.Errorf("enum %s has no value %s", enum.Name, stmt.RenameValue)
+	}
+	enum.Values[idx] = stmt.RenameValueTo
 	return nil
 }
+
+// VisitCreateDomain performs the visit create domain operation. A domain
+// based on another domain takes over that domain's base type, so columns
+// resolve to a built-in or enum type in one step.
+func (v *CatalogVisitor) VisitCreateDomain(stmt *CreateDomainStatement) error {
+	schemaName := stmt.SchemaName
+	if schemaName == "" {
+		schemaName = v.catalog.DefaultSchema
+	}
+
+	if _, err := v.catalog.GetSchema(schemaName); err != nil {
+		if _, createErr := v.catalog.CreateSchema(schemaName); createErr != nil {
+			return fmt.Errorf("failed to create schema %s: %w", schemaName, createErr)
+		}
+	}
+
+	base := catalog.NewColumn(stmt.DomainName, "")
+	base.DataType, base.Length, base.Precision, base.Scale = ParseDataType(stmt.BaseType)
+	v.resolveDomain(base)
+
+	return v.catalog.AddDomain(schemaName, &catalog.Domain{
+		Name:      stmt.DomainName,
+		BaseType:  base.DataType,
+		Length:    base.Length,
+		Precision: base.Precision,
+		Scale:     base.Scale,
+		NotNull:   stmt.NotNull || !base.IsNullable,
+		CreatedBy: v.migrationFile,
+	})
+}
+
+// resolveSerial marks serial columns NOT NULL, which Postgres implies for
+// them, so their model fields are not nullable.
+func resolveSerial(col *catalog.Column) {
+	switch strings.ToLower(col.DataType) {
+	case "smallserial", "serial", "bigserial":
+		col.IsNullable = false
+	}
+}
+
+// VisitDropDomain performs the visit drop domain operation. Domains the
+// catalog does not know are ignored.
+func (v *CatalogVisitor) VisitDropDomain(stmt *DropDomainStatement) error {
+	if _, err := v.catalog.GetDomain(stmt.SchemaName, stmt.DomainName); err != nil {
+		return nil
+	}
+
+	return v.catalog.DropDomain(stmt.SchemaName, stmt.DomainName)
+}
+
+// resolveDomain gives a column declared with a domain the domain's base
+// type, so it maps to Go like a column of that type, and keeps the domain's
+// name in Domain. Columns of a NOT NULL domain are not nullable; arrays of
+// one are, since NOT NULL applies to their elements.
+func (v *CatalogVisitor) resolveDomain(col *catalog.Column) {
+	domain := v.catalog.LookupDomain(col.DataType)
+	if domain == nil {
+		return
+	}
+
+	dataType := strings.ToLower(strings.TrimSpace(col.DataType))
+	col.Domain = dataType
+	col.DataType = domain.BaseType
+	array := false
+	for strings.HasSuffix(dataType, "[]") {
+		dataType = strings.TrimSpace(strings.TrimSuffix(dataType, "[]"))
+		col.DataType += "[]"
+		array = true
+	}
+	col.Length, col.Precision, col.Scale = domain.Length, domain.Precision, domain.Scale
+	if domain.NotNull && !array {
+		col.IsNullable = false
+	}
+}
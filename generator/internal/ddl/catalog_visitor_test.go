@@ -1,6 +1,7 @@
 package ddl
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -121,6 +122,9 @@ func TestCatalogVisitorErrorsConstraintsAndStubOperations(t *testing.T) {
 	if err := visitor.VisitDropTable(&DropTableStatement{TableName: "missing"}); err == nil {
 		t.Fatal("expected missing drop table error")
 	}
+	if err := visitor.VisitCommentOnColumn(&CommentOnColumnStatement{TableName: "users", ColumnName: "missing"}); err == nil {
+		t.Fatal("expected missing comment column error")
+	}
 
 	for name, err := range map[string]error{
 		"create index":  visitor.VisitCreateIndex(&CreateIndexStatement{}),
@@ -129,9 +133,380 @@ func TestCatalogVisitorErrorsConstraintsAndStubOperations(t *testing.T) {
 		"drop schema":   visitor.VisitDropSchema(&DropSchemaStatement{}),
 		"create enum":   visitor.VisitCreateEnum(&CreateEnumStatement{}),
 		"drop enum":     visitor.VisitDropEnum(&DropEnumStatement{}),
+		"alter enum":    visitor.VisitAlterEnum(&AlterEnumStatement{}),
 	} {
 		if err != nil {
 			t.Fatalf("%s stub returned error: %v", name, err)
 		}
 	}
 }
+
+func TestApplyDDLTracksCheckConstraints(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE products (
+			id UUID PRIMARY KEY,
+			name VARCHAR(100) NOT NULL CHECK (char_length(name) >= 3),
+			price INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			CHECK (price > 0),
+			CONSTRAINT products_status_check CHECK (status IN ('draft', 'live (soon)'))
+		)`,
+		`ALTER TABLE products ADD COLUMN stock INTEGER CHECK (stock >= 0)`,
+		`ALTER TABLE products ADD CONSTRAINT products_price_max CHECK (price <= 100000)`,
+		`ALTER TABLE products DROP CONSTRAINT products_status_check`,
+		`ALTER TABLE products RENAME COLUMN price TO price_cents`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_products.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "products")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, check := range table.Checks {
+		got = append(got, check.Name+": "+check.Expression)
+	}
+	want := []string{
+		": char_length(name) >= 3",
+		": price_cents > 0",
+		": stock >= 0",
+		"products_price_max: price_cents <= 100000",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("checks =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyDDLTracksIndexes(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE articles (id UUID PRIMARY KEY, slug TEXT, author_id UUID, status TEXT, rank INTEGER, title TEXT)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS articles_slug_idx ON public.articles (slug)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS articles_slug_idx ON public.articles (slug)`,
+		`CREATE INDEX ON articles USING btree (author_id, status DESC)`,
+		`CREATE INDEX articles_author_idx ON articles (author_id)`,
+		`CREATE INDEX articles_rank_idx ON articles (rank)`,
+		`CREATE UNIQUE INDEX articles_title_idx ON articles (title) WHERE rank > 0`,
+		`CREATE INDEX articles_title_lower_idx ON articles (lower(title))`,
+		`CREATE INDEX missing_idx ON missing (id)`,
+		`ALTER TABLE articles RENAME COLUMN author_id TO writer_id`,
+		`ALTER TABLE articles DROP COLUMN status`,
+		`DROP INDEX IF EXISTS articles_rank_idx, unknown_idx`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_articles.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "articles")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, index := range table.Indexes {
+		got = append(got, fmt.Sprintf("%s %v unique=%v partial=%v", index.Name, index.Columns, index.IsUnique, index.Partial))
+	}
+	want := []string{
+		"articles_slug_idx [slug] unique=true partial=false",
+		"articles_author_idx [writer_id] unique=false partial=false",
+		"articles_title_idx [title] unique=true partial=true",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("indexes =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyDDLTracksForeignKeys(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE accounts (id UUID PRIMARY KEY)`,
+		`CREATE TABLE members (
+			id UUID PRIMARY KEY,
+			account_id UUID NOT NULL CONSTRAINT members_account_fk REFERENCES accounts(id) ON DELETE CASCADE ON UPDATE RESTRICT,
+			inviter_id UUID REFERENCES members ON DELETE SET NULL,
+			team_id UUID,
+			role_id UUID,
+			FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE NO ACTION
+		)`,
+		`ALTER TABLE members ADD CONSTRAINT members_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE SET DEFAULT`,
+		`ALTER TABLE members DROP CONSTRAINT members_team_id_fkey`,
+		`ALTER TABLE accounts RENAME TO organizations`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_members.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "members")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, column := range table.Columns {
+		if fk := column.ForeignKey; fk != nil {
+			got = append(got, fmt.Sprintf("%s -> %s(%s) name=%q delete=%q update=%q", column.Name, fk.ReferencedTable, fk.ReferencedColumn, fk.Name, fk.OnDelete, fk.OnUpdate))
+		}
+	}
+	want := []string{
+		`account_id -> organizations(id) name="members_account_fk" delete="CASCADE" update="RESTRICT"`,
+		`inviter_id -> members(id) name="" delete="SET NULL" update=""`,
+		`role_id -> roles(id) name="members_role_id_fkey" delete="SET DEFAULT" update=""`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("foreign keys =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	var referencing []string
+	for _, reference := range cat.ReferencesTo("public", "organizations") {
+		referencing = append(referencing, reference.Table.Name+"."+reference.Column.Name)
+	}
+	if strings.Join(referencing, ",") != "members.account_id" {
+		t.Fatalf("references to organizations = %v", referencing)
+	}
+}
+
+func TestApplyDDLTracksIdentityColumns(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE orders (
+			id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+			number INTEGER GENERATED BY DEFAULT AS IDENTITY (START WITH 1000),
+			legacy_id SERIAL,
+			total INTEGER NOT NULL
+		)`,
+		`ALTER TABLE orders ALTER COLUMN total ADD GENERATED ALWAYS AS IDENTITY`,
+		`ALTER TABLE orders ALTER COLUMN legacy_id DROP IDENTITY IF EXISTS`,
+		`ALTER TABLE orders ADD COLUMN batch INTEGER GENERATED BY DEFAULT AS IDENTITY`,
+		`ALTER TABLE orders ALTER COLUMN batch DROP IDENTITY`,
+		`ALTER TABLE orders ADD COLUMN sequence BIGSERIAL`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_orders.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "orders")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, column := range table.Columns {
+		got = append(got, fmt.Sprintf("%s %s identity=%q auto=%t nullable=%t", column.Name, column.DataType, column.Identity, column.IsAutoIncrement, column.IsNullable))
+	}
+	want := []string{
+		`id bigint identity="ALWAYS" auto=true nullable=false`,
+		`number integer identity="BY DEFAULT" auto=true nullable=false`,
+		`legacy_id serial identity="" auto=true nullable=false`,
+		`total integer identity="ALWAYS" auto=true nullable=false`,
+		`batch integer identity="" auto=false nullable=false`,
+		`sequence bigserial identity="" auto=true nullable=false`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("columns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyDDLTracksGeneratedColumns(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE people (
+			id UUID PRIMARY KEY,
+			first_name TEXT NOT NULL,
+			last_name TEXT NOT NULL,
+			full_name TEXT GENERATED ALWAYS AS (first_name || ' ' || coalesce(last_name, '')) STORED,
+			height_cm INTEGER,
+			height_mm INTEGER GENERATED ALWAYS AS (height_cm * 10) STORED,
+			label TEXT GENERATED ALWAYS AS (coalesce(last_name, ')') || ' (it''s ' || first_name || ')') STORED
+		)`,
+		`ALTER TABLE people ADD COLUMN initials TEXT NOT NULL GENERATED ALWAYS AS (left(first_name, 1) || left(last_name, 1)) STORED`,
+		`ALTER TABLE people ALTER COLUMN height_mm DROP EXPRESSION`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_people.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "people")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, column := range table.Columns {
+		got = append(got, fmt.Sprintf("%s %s generated=%q nullable=%t", column.Name, column.DataType, column.Generated, column.IsNullable))
+	}
+	want := []string{
+		`id uuid generated="" nullable=false`,
+		`first_name text generated="" nullable=false`,
+		`last_name text generated="" nullable=false`,
+		`full_name text generated="first_name || ' ' || coalesce(last_name, '')" nullable=true`,
+		`height_cm integer generated="" nullable=true`,
+		`height_mm integer generated="" nullable=true`,
+		`label text generated="coalesce(last_name, ')') || ' (it''s ' || first_name || ')'" nullable=true`,
+		`initials text generated="left(first_name, 1) || left(last_name, 1)" nullable=false`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("columns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyDDLResolvesDomains(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE EXTENSION IF NOT EXISTS citext`,
+		`CREATE DOMAIN email AS citext NOT NULL CHECK (VALUE ~ '^[^@]+@[^@]+$')`,
+		`CREATE DOMAIN short_code varchar(8) CHECK (VALUE IS NOT NULL)`,
+		`CREATE DOMAIN work_email AS email`,
+		`CREATE DOMAIN amount AS numeric(12,2) DEFAULT 0 CONSTRAINT amount_positive CHECK (VALUE >= 0)`,
+		`CREATE DOMAIN unused AS text`,
+		`DROP DOMAIN IF EXISTS unused`,
+		`CREATE TABLE contacts (
+			id UUID PRIMARY KEY,
+			email email,
+			code short_code,
+			work work_email,
+			price amount NOT NULL,
+			aliases email[]
+		)`,
+		`ALTER TABLE contacts ADD COLUMN backup_code short_code`,
+		`ALTER TABLE contacts ALTER COLUMN code TYPE text`,
+		`ALTER DOMAIN short_code ADD CONSTRAINT short_code_upper CHECK (VALUE = upper(VALUE))`,
+	} {
+		if err := ApplyDDL(cat, sql, "001_contacts.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	if domain := cat.LookupDomain("unused"); domain != nil {
+		t.Fatalf("dropped domain still in the catalog: %#v", domain)
+	}
+
+	table, err := cat.GetTable("public", "contacts")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	var got []string
+	for _, column := range table.Columns {
+		modifier := ""
+		switch {
+		case column.Length != nil:
+			modifier = fmt.Sprintf("(%d)", *column.Length)
+		case column.Precision != nil && column.Scale != nil:
+			modifier = fmt.Sprintf("(%d,%d)", *column.Precision, *column.Scale)
+		}
+		got = append(got, fmt.Sprintf("%s %s%s domain=%q nullable=%t", column.Name, column.DataType, modifier, column.Domain, column.IsNullable))
+	}
+	want := []string{
+		`id uuid domain="" nullable=false`,
+		`email citext domain="email" nullable=false`,
+		`code text domain="" nullable=true`,
+		`work citext domain="work_email" nullable=false`,
+		`price numeric(12,2) domain="amount" nullable=false`,
+		`aliases citext[] domain="email[]" nullable=true`,
+		`backup_code varchar(8) domain="short_code" nullable=true`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("columns =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestApplyDDLResolvesViewColumns(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		`CREATE TABLE users (id UUID PRIMARY KEY, email TEXT NOT NULL, name TEXT)`,
+		`CREATE TABLE orders (
+			id BIGSERIAL PRIMARY KEY,
+			user_id UUID NOT NULL REFERENCES users(id),
+			total NUMERIC(12,2) NOT NULL DEFAULT 0,
+			placed_at TIMESTAMPTZ NOT NULL
+		)`,
+		`CREATE OR REPLACE VIEW report_rows AS
+			SELECT u.id AS user_id, u.email, u.name display_name,
+				count(o.id) AS order_count,
+				sum(o.total) AS revenue,
+				coalesce(max(o.placed_at), now()) last_order_at,
+				date_trunc('month', o.placed_at)::date AS month,
+				lower(u.email) AS normalized_email,
+				CASE WHEN count(o.id) > 0 THEN 'active' ELSE 'idle' END AS status,
+				u.name || ' <' || u.email || '>' AS label,
+				o.total * 2 AS doubled,
+				o.user_id IS NOT NULL AS has_orders
+			FROM users u
+			LEFT JOIN orders o ON o.user_id = u.id
+			GROUP BY u.id, o.placed_at, o.total, o.user_id`,
+		`CREATE MATERIALIZED VIEW user_emails (uid, address) AS SELECT * FROM users WITH NO DATA`,
+		`CREATE VIEW unused AS SELECT 1 AS one`,
+		`DROP VIEW IF EXISTS unused, missing CASCADE`,
+		`CREATE VIEW totals AS SELECT total / 2, (SELECT 1) FROM orders`,
+	} {
+		if err := ApplyDDL(cat, sql, "002_views.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	if _, err := cat.GetView("", "unused"); err == nil {
+		t.Fatal("dropped view still in the catalog")
+	}
+	if _, err := cat.GetTable("", "report_rows"); err == nil {
+		t.Fatal("view added as a table")
+	}
+
+	describe := func(name string) string {
+		view, err := cat.GetView("", name)
+		if err != nil {
+			t.Fatalf("get view %s: %v", name, err)
+		}
+		if !view.View || view.CreatedBy != "002_views.sql" {
+			t.Fatalf("view %s = %#v", name, view)
+		}
+		var got []string
+		for _, column := range view.Columns {
+			if column.IsPrimaryKey || column.ForeignKey != nil || column.DefaultVal != nil {
+				t.Fatalf("view column %s kept table constraints: %#v", column.Name, column)
+			}
+			got = append(got, fmt.Sprintf("%s %s nullable=%t", column.Name, column.DataType, column.IsNullable))
+		}
+		return strings.Join(got, "\n")
+	}
+
+	want := strings.Join([]string{
+		"user_id uuid nullable=false",
+		"email text nullable=false",
+		"display_name text nullable=true",
+		"order_count bigint nullable=false",
+		"revenue numeric nullable=true",
+		"last_order_at TIMESTAMPTZ nullable=false",
+		"month date nullable=true",
+		"normalized_email text nullable=false",
+		"status text nullable=false",
+		"label text nullable=true",
+		"doubled numeric nullable=true",
+		"has_orders boolean nullable=true",
+	}, "\n")
+	if got := describe("report_rows"); got != want {
+		t.Fatalf("report_rows columns =\n%s\nwant\n%s", got, want)
+	}
+
+	want = strings.Join([]string{
+		"uid uuid nullable=false",
+		"address text nullable=false",
+		"name text nullable=true",
+	}, "\n")
+	if got := describe("user_emails"); got != want {
+		t.Fatalf("user_emails columns =\n%s\nwant\n%s", got, want)
+	}
+	if view, _ := cat.GetView("", "user_emails"); !view.Materialized {
+		t.Fatal("user_emails is not marked materialized")
+	}
+
+	want = strings.Join([]string{
+		"?column? numeric nullable=false",
+		"?column?  nullable=true",
+	}, "\n")
+	if got := describe("totals"); got != want {
+		t.Fatalf("totals columns =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package ddl
 
 import (
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -35,7 +36,7 @@ func (p *CreateTableParser) Parse(
 		return nil, unsupportedStatement(sql, "quoted table identifiers are not supported")
 	}
 
-	columns, err := p.parseColumnDefinitions(columnDefs, migrationFile, databaseType)
+	columns, checks, err := p.parseColumnDefinitions(columnDefs, migrationFile, databaseType)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse column definitions: %w", err)
 	}
@@ -46,24 +47,22 @@ func (p *CreateTableParser) Parse(
 		TableName:   tableName,
 		IfNotExists: ifNotExists,
 		Columns:     columns,
+		Checks:      checks,
 	}, nil
 }
 
 func (p *CreateTableParser) parseColumnDefinitions(
 	columnDefs, migrationFile string,
 	databaseType string,
-) ([]*catalog.Column, error) {
+) ([]*catalog.Column, []*catalog.Check, error) {
 	var columns []*catalog.Column
+	var checks []*catalog.Check
 	var primaryKeyColumns []string
-	var foreignKeys []struct {
-		column           string
-		referencedTable  string
-		referencedColumn string
-	}
+	foreignKeys := map[string]*catalog.ForeignKey{}
 
 	defs := p.splitColumnDefinitions(columnDefs)
 	if len(defs) == 0 {
-		return nil, unsupportedStatement(columnDefs, "CREATE TABLE must contain at least one column definition")
+		return nil, nil, unsupportedStatement(columnDefs, "CREATE TABLE must contain at least one column definition")
 	}
 	seenColumns := map[string]struct{}{}
 	primaryKeyDefinitions := 0
@@ -79,16 +78,16 @@ func (p *CreateTableParser) parseColumnDefinitions(
 		if strings.HasPrefix(defLower, "primary key") {
 			primaryKeyDefinitions++
 			if primaryKeyDefinitions > 1 {
-				return nil, unsupportedStatement(def, "multiple table-level PRIMARY KEY definitions are ambiguous")
+				return nil, nil, unsupportedStatement(def, "multiple table-level PRIMARY KEY definitions are ambiguous")
 			}
 			pkCols, ok := parsePrimaryKeyColumns(def)
 			if !ok || len(pkCols) == 0 {
-				return nil, unsupportedStatement(def, "table-level PRIMARY KEY must name one or more columns")
+				return nil, nil, unsupportedStatement(def, "table-level PRIMARY KEY must name one or more columns")
 			}
 			for _, col := range pkCols {
 				name := strings.TrimSpace(col)
 				if name == "" {
-					return nil, unsupportedStatement(def, "table-level PRIMARY KEY contains an empty column name")
+					return nil, nil, unsupportedStatement(def, "table-level PRIMARY KEY contains an empty column name")
 				}
 				primaryKeyColumns = append(primaryKeyColumns, name)
 			}
@@ -99,35 +98,37 @@ func (p *CreateTableParser) parseColumnDefinitions(
 			// Parse table-level FOREIGN KEY constraint
 			// Format: FOREIGN KEY (column) REFERENCES table(column)
 			// Also handles: CONSTRAINT name FOREIGN KEY (column) REFERENCES table(column)
-			matches, ok := parseTableLevelForeignKey(def)
+			if isCompositeForeignKey(def) {
+				// The catalog records a foreign key per column.
+				continue
+			}
+			column, foreignKey, ok := parseTableLevelForeignKey(def)
 			if !ok {
-				return nil, unsupportedStatement(def, "table-level FOREIGN KEY must name one local and one referenced column")
+				return nil, nil, unsupportedStatement(def, "table-level FOREIGN KEY must name one local and one referenced column")
 			}
-			foreignKeys = append(foreignKeys, struct {
-				column           string
-				referencedTable  string
-				referencedColumn string
-			}{
-				column:           matches[1],
-				referencedTable:  matches[2],
-				referencedColumn: matches[3],
-			})
+			foreignKeys[column] = foreignKey
 			continue
 		}
 
 		if isTableConstraintDefinition(defLower) {
+			if expression, ok := parseCheckExpression(def); ok {
+				checks = append(checks, &catalog.Check{Expression: expression})
+			}
 			continue
 		}
 		if strings.HasPrefix(defLower, "constraint") {
 			if strings.Contains(defLower, " unique ") || strings.Contains(defLower, " check ") {
+				if expression, ok := parseCheckExpression(def); ok {
+					checks = append(checks, &catalog.Check{Name: parseConstraintName(def), Expression: expression})
+				}
 				continue
 			}
-			return nil, unsupportedStatement(def, "only named FOREIGN KEY, UNIQUE, and CHECK table constraints are supported")
+			return nil, nil, unsupportedStatement(def, "only named FOREIGN KEY, UNIQUE, and CHECK table constraints are supported")
 		}
 
 		col, err := p.parseColumnDefinition(def, migrationFile, databaseType)
 		if err != nil {
-			return nil, fmt.Errorf(
+			return nil, nil, fmt.Errorf(
 				"failed to parse column definition '%s': %w",
 				def,
 				err,
@@ -137,10 +138,13 @@ func (p *CreateTableParser) parseColumnDefinitions(
 		if col != nil {
 			normalizedName := strings.ToLower(col.Name)
 			if _, exists := seenColumns[normalizedName]; exists {
-				return nil, unsupportedStatement(def, "duplicate column definition for "+col.Name)
+				return nil, nil, unsupportedStatement(def, "duplicate column definition for "+col.Name)
 			}
 			seenColumns[normalizedName] = struct{}{}
 			columns = append(columns, col)
+			if expression, ok := parseCheckExpression(def); ok {
+				checks = append(checks, &catalog.Check{Expression: expression})
+			}
 		}
 	}
 
@@ -149,23 +153,20 @@ func (p *CreateTableParser) parseColumnDefinitions(
 			if col.Name == pkCol {
 				col.SetPrimaryKey()
 				if err := p.validatePrimaryKeyDatatype(col.DataType, databaseType, migrationFile, col.Name); err != nil {
-					return nil, err
+					return nil, nil, err
 				}
 			}
 		}
 	}
 
 	// Apply table-level foreign keys
-	for _, fk := range foreignKeys {
-		for _, col := range columns {
-			if col.Name == fk.column {
-				col.SetForeignKey(fk.referencedTable, fk.referencedColumn)
-				break
-			}
+	for _, col := range columns {
+		if foreignKey, ok := foreignKeys[col.Name]; ok {
+			col.ForeignKey = foreignKey
 		}
 	}
 
-	return columns, nil
+	return columns, checks, nil
 }
 
 func (p *CreateTableParser) parseColumnDefinition(
@@ -191,6 +192,8 @@ func (p *CreateTableParser) parseColumnDefinition(
 		"default",
 		"references",
 		"check",
+		"constraint",
+		"generated",
 	}
 	typeEndIndex := len(parts)
 
@@ -215,6 +218,14 @@ func (p *CreateTableParser) parseColumnDefinition(
 		col.SetAutoIncrement()
 	}
 
+	if identity, ok := parseIdentity(def); ok {
+		col.SetIdentity(identity)
+	}
+
+	if expression, ok := parseGenerated(def); ok {
+		col.SetGenerated(expression)
+	}
+
 	if length != nil {
 		col.SetLength(*length)
 	}
@@ -245,9 +256,9 @@ func (p *CreateTableParser) parseColumnDefinition(
 	}
 
 	// Parse inline REFERENCES clause:
-	// REFERENCES table(column) or REFERENCES table
-	if referencedTable, referencedColumn, ok := parseInlineReference(def); ok {
-		col.SetForeignKey(referencedTable, referencedColumn)
+	// REFERENCES table(column) or REFERENCES table, with its actions
+	if foreignKey, ok := parseInlineReference(def); ok {
+		col.ForeignKey = foreignKey
 	}
 
 	return col, nil
@@ -378,48 +389,48 @@ func parsePrimaryKeyColumns(def string) ([]string, bool) {
 	return strings.Split(def[start+1:end], ","), true
 }
 
-func parseTableLevelForeignKey(def string) ([]string, bool) {
+func parseTableLevelForeignKey(def string) (string, *catalog.ForeignKey, bool) {
 	defLower := strings.ToLower(def)
 	fkIdx := strings.Index(defLower, "foreign key")
 	if fkIdx == -1 {
-		return nil, false
+		return "", nil, false
 	}
 
 	openCol := strings.Index(def[fkIdx:], "(")
 	if openCol == -1 {
-		return nil, false
+		return "", nil, false
 	}
 	openCol += fkIdx
 	closeCol := strings.Index(def[openCol:], ")")
 	if closeCol == -1 {
-		return nil, false
+		return "", nil, false
 	}
 	closeCol += openCol
 	column := strings.TrimSpace(def[openCol+1 : closeCol])
+	if column == "" {
+		return "", nil, false
+	}
 
 	afterColsLower := strings.ToLower(def[closeCol+1:])
 	refIdxRel := strings.Index(afterColsLower, "references")
 	if refIdxRel == -1 {
-		return nil, false
+		return "", nil, false
 	}
 	refIdx := closeCol + 1 + refIdxRel
-	afterRef := strings.TrimSpace(def[refIdx+len("references"):])
-	if afterRef == "" {
-		return nil, false
-	}
 
-	refOpen := strings.Index(afterRef, "(")
-	refClose := strings.Index(afterRef, ")")
-	if refOpen == -1 || refClose == -1 || refClose <= refOpen {
-		return nil, false
-	}
-	referencedTable := strings.TrimSpace(afterRef[:refOpen])
-	referencedColumn := strings.TrimSpace(afterRef[refOpen+1 : refClose])
-	if column == "" || referencedTable == "" || referencedColumn == "" {
-		return nil, false
+	foreignKey, ok := parseReferencesClause(def[refIdx+len("references"):])
+	if !ok || foreignKey.ReferencedColumn == "" {
+		return "", nil, false
 	}
+	foreignKey.Name = parseConstraintName(def)
+
+	return column, foreignKey, true
+}
+
+var compositeForeignKeyPattern = regexp.MustCompile(`(?i)foreign\s+key\s*\([^)]*,`)
 
-	return []string{"", column, referencedTable, referencedColumn}, true
+func isCompositeForeignKey(def string) bool {
+	return compositeForeignKeyPattern.MatchString(def)
 }
 
 func isTableConstraintDefinition(defLower string) bool {
@@ -453,32 +464,150 @@ func parseDefaultValue(def string) (string, bool) {
 	return value, value != ""
 }
 
-func parseInlineReference(def string) (string, string, bool) {
+var inlineConstraintNamePattern = regexp.MustCompile(`(?i)\bconstraint\s+([A-Za-z_][A-Za-z0-9_$]*)\s+references\b`)
+
+func parseInlineReference(def string) (*catalog.ForeignKey, bool) {
 	defLower := strings.ToLower(def)
 	refIdx := strings.Index(defLower, "references")
 	if refIdx == -1 {
-		return "", "", false
+		return nil, false
+	}
+
+	foreignKey, ok := parseReferencesClause(def[refIdx+len("references"):])
+	if !ok {
+		return nil, false
+	}
+	if foreignKey.ReferencedColumn == "" {
+		foreignKey.ReferencedColumn = "id"
+	}
+	if matches := inlineConstraintNamePattern.FindStringSubmatch(def); matches != nil {
+		foreignKey.Name = matches[1]
+	}
+
+	return foreignKey, true
+}
+
+var identityPattern = regexp.MustCompile(`(?i)\bgenerated\s+(always|by\s+default)\s+as\s+identity\b`)
+
+// parseIdentity reports the kind of a GENERATED ... AS IDENTITY clause in
+// def, catalog.IdentityAlways or catalog.IdentityByDefault.
+func parseIdentity(def string) (string, bool) {
+	matches := identityPattern.FindStringSubmatch(def)
+	if matches == nil {
+		return "", false
 	}
+	return strings.ToUpper(strings.Join(strings.Fields(matches[1]), " ")), true
+}
+
+var generatedPattern = regexp.MustCompile(`(?i)\bgenerated\s+always\s+as\s*\(`)
 
-	afterRef := strings.TrimSpace(def[refIdx+len("references"):])
-	if afterRef == "" {
-		return "", "", false
+// parseGenerated returns the expression of a GENERATED ALWAYS AS (...)
+// STORED clause in def.
+func parseGenerated(def string) (string, bool) {
+	loc := generatedPattern.FindStringIndex(def)
+	if loc == nil {
+		return "", false
 	}
 
-	refOpen := strings.Index(afterRef, "(")
-	if refOpen == -1 {
-		return strings.TrimSpace(afterRef), "id", true
+	// A '' escape toggles inString twice, so it stays inside the literal.
+	depth := 1
+	inString := false
+	for i := loc[1]; i < len(def); i++ {
+		switch char := def[i]; {
+		case char == '\'':
+			inString = !inString
+		case inString:
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+			if depth == 0 {
+				return strings.TrimSpace(def[loc[1]:i]), true
+			}
+		}
+	}
+	return "", false
+}
+
+var (
+	referencesClausePattern = regexp.MustCompile(`(?is)^\s*((?:\w+\.)?\w+)\s*(?:\(\s*(\w+)\s*\))?`)
+	onDeletePattern         = regexp.MustCompile(`(?i)\bon\s+delete\s+(cascade|restrict|no\s+action|set\s+null|set\s+default)\b`)
+	onUpdatePattern         = regexp.MustCompile(`(?i)\bon\s+update\s+(cascade|restrict|no\s+action|set\s+null|set\s+default)\b`)
+)
+
+// parseReferencesClause parses what follows REFERENCES: the referenced
+// table, its column if named, and the ON DELETE and ON UPDATE actions. NO
+// ACTION is recorded as no action.
+func parseReferencesClause(clause string) (*catalog.ForeignKey, bool) {
+	matches := referencesClausePattern.FindStringSubmatch(clause)
+	if matches == nil {
+		return nil, false
 	}
-	refClose := strings.Index(afterRef, ")")
-	if refClose == -1 || refClose <= refOpen {
-		return "", "", false
+	rest := clause[len(matches[0]):]
+	if strings.HasPrefix(strings.TrimSpace(rest), "(") {
+		// Composite references are recorded per column elsewhere.
+		return nil, false
 	}
 
-	referencedTable := strings.TrimSpace(afterRef[:refOpen])
-	referencedColumn := strings.TrimSpace(afterRef[refOpen+1 : refClose])
-	if referencedTable == "" || referencedColumn == "" {
-		return "", "", false
+	foreignKey := &catalog.ForeignKey{
+		ReferencedTable:  matches[1],
+		ReferencedColumn: matches[2],
+		OnDelete:         referentialAction(onDeletePattern, rest),
+		OnUpdate:         referentialAction(onUpdatePattern, rest),
 	}
+	return foreignKey, true
+}
+
+func referentialAction(pattern *regexp.Regexp, clause string) string {
+	matches := pattern.FindStringSubmatch(clause)
+	if matches == nil {
+		return ""
+	}
+	action := strings.ToUpper(strings.Join(strings.Fields(matches[1]), " "))
+	if action == catalog.NoAction {
+		return ""
+	}
+	return action
+}
+
+var checkKeywordPattern = regexp.MustCompile(`(?i)\bcheck\s*\(`)
+
+// parseCheckExpression returns the condition of the CHECK constraint in def,
+// without the surrounding parentheses.
+func parseCheckExpression(def string) (string, bool) {
+	loc := checkKeywordPattern.FindStringIndex(def)
+	if loc == nil {
+		return "", false
+	}
+
+	start := loc[1]
+	depth := 1
+	inString := false
+	for i := start; i < len(def); i++ {
+		switch char := def[i]; {
+		case char == '\'':
+			inString = !inString
+		case inString:
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+			if depth == 0 {
+				expression := strings.TrimSpace(def[start:i])
+				return expression, expression != ""
+			}
+		}
+	}
+	return "", false
+}
 
-	return referencedTable, referencedColumn, true
+var constraintNamePattern = regexp.MustCompile(`(?i)^\s*(?:add\s+|drop\s+)?constraint\s+(?:if\s+exists\s+)?([A-Za-z_][A-Za-z0-9_$]*)`)
+
+// parseConstraintName returns the name in a "CONSTRAINT name ..." table
+// constraint or ALTER TABLE operation.
+func parseConstraintName(def string) string {
+	if matches := constraintNamePattern.FindStringSubmatch(def); matches != nil {
+		return matches[1]
+	}
+	return ""
 }
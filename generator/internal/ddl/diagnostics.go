@@ -40,15 +40,34 @@ func unknownStatementIsModelNeutral(sql string) bool {
 		return true
 	}
 	switch fields[0] {
-	case "analyze", "begin", "comment", "commit", "delete", "grant", "insert", "release", "reset", "revoke", "rollback", "savepoint", "set", "truncate", "update", "vacuum":
+	case "analyze", "begin", "comment", "commit", "delete", "grant", "insert", "refresh", "release", "reset", "revoke", "rollback", "savepoint", "set", "truncate", "update", "vacuum":
 		return true
 	case "select":
 		return !strings.Contains(strings.ToLower(sql), "into")
+	case "create", "drop", "alter":
+		return modelNeutralObject(fields)
 	default:
 		return false
 	}
 }
 
+// modelNeutralObject reports whether a CREATE, DROP or ALTER statement
+// leaves the tables and columns alone: extensions, and ALTER DOMAIN changes
+// other than renames and nullability.
+func modelNeutralObject(fields []string) bool {
+	if len(fields) < 2 {
+		return false
+	}
+	statement := withoutParentheses(strings.Join(fields, " "))
+	switch fields[1] {
+	case "extension":
+		return fields[0] != "drop" || !strings.Contains(statement, "cascade")
+	case "domain":
+		return fields[0] == "alter" && !strings.Contains(statement, "rename") && !strings.Contains(statement, " null")
+	}
+	return false
+}
+
 func validateDDLStructure(sql string) error {
 	parenDepth := 0
 	inSingleQuote := false
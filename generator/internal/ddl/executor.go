@@ -47,12 +47,14 @@ func ApplyDDL(
 
 	// Skip statements we don't process (not errors)
 	switch stmt.GetType() {
-	case DropEnum, DropSchema:
+	case DropEnum, DropDomain, DropSchema:
 		if strings.Contains(strings.ToLower(stmt.GetRaw()), "cascade") {
 			return unsupportedStatement(stmt.GetRaw(), "CASCADE can remove table columns or tables used to generate models")
 		}
-		return nil
-	case CreateEnum, CreateSchema, CreateIndex, DropIndex:
+		if stmt.GetType() == DropSchema {
+			return nil
+		}
+	case CreateSchema:
 		return nil
 	}
 
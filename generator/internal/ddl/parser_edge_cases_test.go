@@ -57,6 +57,31 @@ func TestCreateTableParserEdgeCases(t *testing.T) {
 	}
 }
 
+func TestParseDataTypeKeepsArraySuffix(t *testing.T) {
+	cases := []struct {
+		typeStr    string
+		wantType   string
+		wantLength int32
+	}{
+		{"TEXT[]", "text[]", 0},
+		{"VARCHAR(50)[]", "varchar[]", 50},
+		{"int4[]", "integer[]", 0},
+		{"UUID[][]", "uuid[]", 0},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.typeStr, func(t *testing.T) {
+			dataType, length, _, _ := ParseDataType(tt.typeStr)
+			if dataType != tt.wantType {
+				t.Fatalf("ParseDataType(%q) type = %q, want %q", tt.typeStr, dataType, tt.wantType)
+			}
+			if tt.wantLength != 0 && (length == nil || *length != tt.wantLength) {
+				t.Fatalf("ParseDataType(%q) length = %v, want %d", tt.typeStr, length, tt.wantLength)
+			}
+		})
+	}
+}
+
 func TestCreateTableParserInvalidPrimaryKeyTypeReturnsValidationError(t *testing.T) {
 	_, err := NewDDLParser().Parse(`CREATE TABLE files (
 		id BYTEA PRIMARY KEY
@@ -203,8 +228,8 @@ func TestSimpleParsersAndUnknownStatement(t *testing.T) {
 		wantName string
 	}{
 		{"DROP TABLE IF EXISTS tenant.users", DropTable, "users"},
-		{"CREATE INDEX idx_users_email ON users(email)", CreateIndex, ""},
-		{"DROP INDEX idx_users_email", DropIndex, ""},
+		{"CREATE INDEX idx_users_email ON users(email)", CreateIndex, "idx_users_email"},
+		{"DROP INDEX idx_users_email", DropIndex, "idx_users_email"},
 		{"CREATE SCHEMA IF NOT EXISTS tenant", CreateSchema, "tenant"},
 		{"DROP SCHEMA IF EXISTS tenant", DropSchema, "tenant"},
 		{"CREATE TYPE tenant.status AS ENUM ('active', 'disabled')", CreateEnum, "status"},
@@ -226,6 +251,14 @@ func TestSimpleParsersAndUnknownStatement(t *testing.T) {
 				if s.TableName != tt.wantName || s.SchemaName != "tenant" || !s.IfExists {
 					t.Fatalf("unexpected drop table statement: %#v", s)
 				}
+			case *CreateIndexStatement:
+				if s.IndexName != tt.wantName || s.TableName != "users" || strings.Join(s.Columns, ",") != "email" {
+					t.Fatalf("unexpected create index statement: %#v", s)
+				}
+			case *DropIndexStatement:
+				if strings.Join(s.IndexNames, ",") != tt.wantName {
+					t.Fatalf("IndexNames = %v, want %q", s.IndexNames, tt.wantName)
+				}
 			case *CreateSchemaStatement:
 				if s.SchemaName != tt.wantName {
 					t.Fatalf("SchemaName = %q, want %q", s.SchemaName, tt.wantName)
@@ -238,6 +271,9 @@ func TestSimpleParsersAndUnknownStatement(t *testing.T) {
 				if s.EnumName != tt.wantName || s.SchemaName != "tenant" {
 					t.Fatalf("unexpected create enum statement: %#v", s)
 				}
+				if s.EnumDef == nil || strings.Join(s.EnumDef.Values, ",") != "active,disabled" {
+					t.Fatalf("unexpected enum definition: %#v", s.EnumDef)
+				}
 			case *DropEnumStatement:
 				if s.EnumName != tt.wantName || s.SchemaName != "tenant" {
 					t.Fatalf("unexpected drop enum statement: %#v", s)
@@ -246,3 +282,89 @@ func TestSimpleParsersAndUnknownStatement(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentOnColumnAnnotatesCatalogColumn(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		"CREATE TABLE posts (id uuid PRIMARY KEY, body text NOT NULL)",
+		"COMMENT ON COLUMN posts.body IS 'andurel:richtext -- author''s notes'",
+	} {
+		if err := ApplyDDL(cat, sql, "001_posts.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	table, err := cat.GetTable("public", "posts")
+	if err != nil {
+		t.Fatalf("GetTable: %v", err)
+	}
+	body, err := table.GetColumn("body")
+	if err != nil {
+		t.Fatalf("GetColumn: %v", err)
+	}
+	if body.Comment != "andurel:richtext -- author's notes" {
+		t.Fatalf("Comment = %q", body.Comment)
+	}
+	if !body.HasAnnotation("richtext") || body.HasAnnotation("rich") {
+		t.Fatalf("HasAnnotation mismatch for comment %q", body.Comment)
+	}
+
+	if err := ApplyDDL(cat, "COMMENT ON COLUMN public.posts.body IS NULL", "002_posts.sql", "postgresql"); err != nil {
+		t.Fatalf("ApplyDDL comment removal: %v", err)
+	}
+	if body.Comment != "" || body.HasAnnotation("richtext") {
+		t.Fatalf("comment should be cleared, got %q", body.Comment)
+	}
+
+	if err := ApplyDDL(cat, `COMMENT ON COLUMN "posts"."body" IS 'andurel:richtext'`, "003_posts.sql", "postgresql"); err != nil {
+		t.Fatalf("quoted comment should be skipped, got %v", err)
+	}
+}
+
+func TestEnumStatementsMaintainCatalogEnum(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, sql := range []string{
+		"CREATE TYPE post_status AS ENUM (\n  'draft',\n  'in review',\n  'author''s pick'\n);",
+		"ALTER TYPE post_status ADD VALUE 'published'",
+		"ALTER TYPE post_status ADD VALUE IF NOT EXISTS 'published'",
+		"ALTER TYPE post_status ADD VALUE 'scheduled' BEFORE 'published'",
+		"ALTER TYPE post_status ADD VALUE 'queued' AFTER 'draft'",
+		"ALTER TYPE post_status RENAME VALUE 'in review' TO 'review'",
+		"ALTER TYPE post_status OWNER TO app",
+		"CREATE TYPE address AS (street text, city text)",
+		"DROP TYPE address",
+	} {
+		if err := ApplyDDL(cat, sql, "001_enums.sql", "postgresql"); err != nil {
+			t.Fatalf("ApplyDDL(%q): %v", sql, err)
+		}
+	}
+
+	enum, err := cat.GetEnum("", "post_status")
+	if err != nil {
+		t.Fatalf("GetEnum: %v", err)
+	}
+	want := "draft,queued,review,author's pick,scheduled,published"
+	if got := strings.Join(enum.Values, ","); got != want {
+		t.Fatalf("Values = %q, want %q", got, want)
+	}
+	if enum.CreatedBy != "001_enums.sql" {
+		t.Fatalf("CreatedBy = %q", enum.CreatedBy)
+	}
+
+	if err := ApplyDDL(cat, "ALTER TYPE post_status ADD VALUE 'draft'", "002_enums.sql", "postgresql"); err == nil {
+		t.Fatal("expected duplicate enum value error")
+	}
+	if err := ApplyDDL(cat, "ALTER TYPE post_status RENAME TO article_status", "002_enums.sql", "postgresql"); err == nil {
+		t.Fatal("expected enum type rename to be unsupported")
+	}
+	if err := ApplyDDL(cat, "DROP TYPE post_status CASCADE", "002_enums.sql", "postgresql"); err == nil {
+		t.Fatal("expected DROP TYPE CASCADE to be unsupported")
+	}
+
+	if err := ApplyDDL(cat, "DROP TYPE IF EXISTS post_status", "003_enums.sql", "postgresql"); err != nil {
+		t.Fatalf("ApplyDDL drop: %v", err)
+	}
+	if enums, err := cat.ListEnums(""); err != nil || len(enums) != 0 {
+		t.Fatalf("ListEnums after drop = (%v, %v), want none", enums, err)
+	}
+}
@@ -104,6 +104,12 @@ type DDLParser struct {
 	dropSchemaParser   *DropSchemaParser
 	createEnumParser   *CreateEnumParser
 	dropEnumParser     *DropEnumParser
+	alterEnumParser    *AlterEnumParser
+	createDomainParser *CreateDomainParser
+	dropDomainParser   *DropDomainParser
+	createViewParser   *CreateViewParser
+	dropViewParser     *DropViewParser
+	commentParser      *CommentOnColumnParser
 }
 
 // NewDDLParser creates a new d d l parser.
@@ -118,6 +124,12 @@ func NewDDLParser() *DDLParser {
 		dropSchemaParser:   NewDropSchemaParser(),
 		createEnumParser:   NewCreateEnumParser(),
 		dropEnumParser:     NewDropEnumParser(),
+		alterEnumParser:    NewAlterEnumParser(),
+		createDomainParser: NewCreateDomainParser(),
+		dropDomainParser:   NewDropDomainParser(),
+		createViewParser:   NewCreateViewParser(),
+		dropViewParser:     NewDropViewParser(),
+		commentParser:      NewCommentOnColumnParser(),
 	}
 }
 
@@ -154,6 +166,18 @@ func (p *DDLParser) Parse(sql, migrationFile string, databaseType string) (State
 		return p.createEnumParser.Parse(sql)
 	case strings.HasPrefix(sqlLower, "drop type"):
 		return p.dropEnumParser.Parse(sql)
+	case strings.HasPrefix(sqlLower, "alter type"):
+		return p.alterEnumParser.Parse(sql)
+	case strings.HasPrefix(sqlLower, "create domain"):
+		return p.createDomainParser.Parse(sql)
+	case strings.HasPrefix(sqlLower, "drop domain"):
+		return p.dropDomainParser.Parse(sql)
+	case createViewPrefix.MatchString(sqlLower):
+		return p.createViewParser.Parse(sql)
+	case dropViewPrefix.MatchString(sqlLower):
+		return p.dropViewParser.Parse(sql)
+	case strings.HasPrefix(sqlLower, "comment on column"):
+		return p.commentParser.Parse(sql)
 	default:
 		return &UnknownStatement{Raw: sql}, nil
 	}
@@ -379,7 +379,7 @@ func TestParseColumnDefinitions_PrimaryKeyValidation(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			parser := NewCreateTableParser()
-			columns, err := parser.parseColumnDefinitions(tc.columnDefs, "test.sql", tc.databaseType)
+			columns, _, err := parser.parseColumnDefinitions(tc.columnDefs, "test.sql", tc.databaseType)
 
 			if tc.expectError {
 				if err == nil {
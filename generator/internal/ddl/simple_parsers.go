@@ -1,8 +1,11 @@
 package ddl
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
 )
 
 // DropTableParser handles DROP TABLE statements
@@ -47,11 +50,68 @@ func NewCreateIndexParser() *CreateIndexParser {
 	return &CreateIndexParser{}
 }
 
-// Parse performs the parse operation.
+var (
+	createIndexRegex = regexp.MustCompile(
+		`(?is)^create\s+(unique\s+)?index\s+(?:concurrently\s+)?(if\s+not\s+exists\s+)?(?:(?:\w+\.)?(\w+)\s+)?on\s+(?:only\s+)?(?:(\w+)\.)?(\w+)\s*(?:using\s+\w+\s*)?\(`,
+	)
+	indexColumnRegex = regexp.MustCompile(`^"?(\w+)"?(?:\s|$)`)
+	indexWhereRegex  = regexp.MustCompile(`(?i)\bwhere\b`)
+)
+
+// Parse performs the parse operation. Statements it cannot read keep only
+// Raw and are ignored by the catalog.
 func (p *CreateIndexParser) Parse(sql string) (*CreateIndexStatement, error) {
-	return &CreateIndexStatement{
-		Raw: sql,
-	}, nil
+	stmt := &CreateIndexStatement{Raw: sql}
+
+	loc := createIndexRegex.FindStringSubmatchIndex(sql)
+	if loc == nil {
+		return stmt, nil
+	}
+	group := func(i int) string {
+		if loc[2*i] < 0 {
+			return ""
+		}
+		return sql[loc[2*i]:loc[2*i+1]]
+	}
+
+	// Read the key list up to its closing parenthesis; a WHERE or INCLUDE
+	// clause may follow.
+	depth, end := 1, -1
+	for i := loc[1]; i < len(sql) && end < 0; i++ {
+		switch sql[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		}
+	}
+	if end < 0 {
+		return stmt, nil
+	}
+
+	stmt.Unique = group(1) != ""
+	stmt.IfNotExists = group(2) != ""
+	stmt.IndexName = group(3)
+	stmt.SchemaName = group(4)
+	stmt.TableName = group(5)
+	stmt.Partial = indexWhereRegex.MatchString(sql[end+1:])
+	// Expressions such as lower(email) are not columns and are left out.
+	for _, key := range NewCreateTableParser().splitColumnDefinitions(sql[loc[1]:end]) {
+		key = strings.TrimSpace(key)
+		if m := indexColumnRegex.FindStringSubmatch(key); m != nil && !strings.Contains(key, "(") {
+			stmt.Columns = append(stmt.Columns, m[1])
+		} else {
+			stmt.Partial = true
+		}
+	}
+	if stmt.IndexName == "" {
+		stmt.IndexName = stmt.TableName + "_" + strings.Join(stmt.Columns, "_") + "_idx"
+	}
+
+	return stmt, nil
 }
 
 // DropIndexParser handles DROP INDEX statements
@@ -62,11 +122,29 @@ func NewDropIndexParser() *DropIndexParser {
 	return &DropIndexParser{}
 }
 
+var dropIndexRegex = regexp.MustCompile(
+	`(?is)^drop\s+index\s+(?:concurrently\s+)?(?:if\s+exists\s+)?(.+?)(?:\s+(?:cascade|restrict))?\s*;?\s*$`,
+)
+
 // Parse performs the parse operation.
 func (p *DropIndexParser) Parse(sql string) (*DropIndexStatement, error) {
-	return &DropIndexStatement{
-		Raw: sql,
-	}, nil
+	stmt := &DropIndexStatement{Raw: sql}
+
+	matches := dropIndexRegex.FindStringSubmatch(sql)
+	if matches == nil {
+		return stmt, nil
+	}
+	for name := range strings.SplitSeq(matches[1], ",") {
+		name = strings.Trim(strings.TrimSpace(name), `"`)
+		if _, unqualified, found := strings.Cut(name, "."); found {
+			name = unqualified
+		}
+		if name != "" {
+			stmt.IndexNames = append(stmt.IndexNames, name)
+		}
+	}
+
+	return stmt, nil
 }
 
 // CreateSchemaParser handles CREATE SCHEMA statements
@@ -131,25 +209,144 @@ func NewCreateEnumParser() *CreateEnumParser {
 	return &CreateEnumParser{}
 }
 
-// Parse performs the parse operation.
+// Parse performs the parse operation. Types other than enums, such as
+// composite types, are returned without an enum definition.
 func (p *CreateEnumParser) Parse(sql string) (*CreateEnumStatement, error) {
-	enumRegex, err := regexp.Compile(`(?i)create\s+type\s+(?:(\w+)\.)?(\w+)\s+as\s+enum`)
+	enumRegex, err := regexp.Compile(`(?is)create\s+type\s+(?:(\w+)\.)?(\w+)\s+as\s+enum\s*\((.*)\)`)
 	if err != nil {
 		return nil, err
 	}
 	matches := enumRegex.FindStringSubmatch(sql)
 
+	if len(matches) < 4 {
+		return &CreateEnumStatement{Raw: sql}, nil
+	}
+
+	values, err := parseEnumValues(matches[3])
+	if err != nil {
+		return nil, unsupportedStatement(sql, err.Error())
+	}
+
+	return &CreateEnumStatement{
+		Raw:        sql,
+		SchemaName: matches[1],
+		EnumName:   matches[2],
+		EnumDef: &catalog.Enum{
+			Name:   matches[2],
+			Values: values,
+		},
+	}, nil
+}
+
+// parseEnumValues splits the quoted label list of an enum definition.
+func parseEnumValues(list string) ([]string, error) {
+	labelRegex, err := regexp.Compile(`^\s*'((?:[^']|'')*)'\s*(?:,|$)`)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	rest := list
+	for strings.TrimSpace(rest) != "" {
+		matches := labelRegex.FindStringSubmatch(rest)
+		if matches == nil {
+			return nil, fmt.Errorf("enum labels must be string literals")
+		}
+		values = append(values, unquoteLabel(matches[1]))
+		rest = rest[len(matches[0]):]
+	}
+
+	return values, nil
+}
+
+// CreateDomainParser handles CREATE DOMAIN statements
+type CreateDomainParser struct{}
+
+// NewCreateDomainParser creates a new create domain parser.
+func NewCreateDomainParser() *CreateDomainParser {
+	return &CreateDomainParser{}
+}
+
+// domainConstraintStart matches the first clause after the base type of a
+// domain: its collation, default or constraints.
+var domainConstraintStart = regexp.MustCompile(`(?i)\s(collate|default|constraint|not\s+null|null|check)\b`)
+
+// Parse performs the parse operation. The constraints of the domain are not
+// kept, except whether it is NOT NULL.
+func (p *CreateDomainParser) Parse(sql string) (*CreateDomainStatement, error) {
+	domainRegex, err := regexp.Compile(`(?is)^create\s+domain\s+(?:(\w+)\.)?(\w+)\s+(?:as\s+)?(.*?)\s*;?\s*$`)
+	if err != nil {
+		return nil, err
+	}
+	matches := domainRegex.FindStringSubmatch(sql)
+	if len(matches) < 4 || strings.TrimSpace(matches[3]) == "" {
+		return nil, unsupportedStatement(sql, "the domain has no base type")
+	}
+
+	baseType, constraints := matches[3], ""
+	if loc := domainConstraintStart.FindStringIndex(baseType); loc != nil {
+		baseType, constraints = matches[3][:loc[0]], matches[3][loc[0]:]
+	}
+
+	notNullRegex, err := regexp.Compile(`(?i)\bnot\s+null\b`)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateDomainStatement{
+		Raw:        sql,
+		SchemaName: matches[1],
+		DomainName: matches[2],
+		BaseType:   strings.TrimSpace(baseType),
+		NotNull:    notNullRegex.MatchString(withoutParentheses(constraints)),
+	}, nil
+}
+
+// withoutParentheses removes the parenthesized parts of sql, such as CHECK
+// expressions.
+func withoutParentheses(sql string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range sql {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// DropDomainParser handles DROP DOMAIN statements
+type DropDomainParser struct{}
+
+// NewDropDomainParser creates a new drop domain parser.
+func NewDropDomainParser() *DropDomainParser {
+	return &DropDomainParser{}
+}
+
+// Parse performs the parse operation.
+func (p *DropDomainParser) Parse(sql string) (*DropDomainStatement, error) {
+	domainRegex, err := regexp.Compile(`(?i)drop\s+domain\s+(?:if\s+exists\s+)?(?:(\w+)\.)?(\w+)`)
+	if err != nil {
+		return nil, err
+	}
+	matches := domainRegex.FindStringSubmatch(sql)
+
 	schemaName := ""
-	enumName := ""
+	domainName := ""
 	if len(matches) > 2 {
 		schemaName = matches[1]
-		enumName = matches[2]
+		domainName = matches[2]
 	}
 
-	return &CreateEnumStatement{
+	return &DropDomainStatement{
 		Raw:        sql,
 		SchemaName: schemaName,
-		EnumName:   enumName,
+		DomainName: domainName,
 	}, nil
 }
 
@@ -182,3 +379,109 @@ func (p *DropEnumParser) Parse(sql string) (*DropEnumStatement, error) {
 		EnumName:   enumName,
 	}, nil
 }
+
+// AlterEnumParser handles ALTER TYPE (enum) statements
+type AlterEnumParser struct{}
+
+// NewAlterEnumParser creates a new alter enum parser.
+func NewAlterEnumParser() *AlterEnumParser {
+	return &AlterEnumParser{}
+}
+
+// Parse performs the parse operation. Only ADD VALUE and RENAME VALUE
+// change the labels of an enum; other forms, like OWNER TO, are returned
+// with neither set.
+func (p *AlterEnumParser) Parse(sql string) (*AlterEnumStatement, error) {
+	alterRegex, err := regexp.Compile(`(?is)^alter\s+type\s+(?:(\w+)\.)?(\w+)\s+(.*?)\s*;?\s*$`)
+	if err != nil {
+		return nil, err
+	}
+	matches := alterRegex.FindStringSubmatch(sql)
+	if len(matches) < 4 {
+		return &AlterEnumStatement{Raw: sql}, nil
+	}
+
+	stmt := &AlterEnumStatement{
+		Raw:        sql,
+		SchemaName: matches[1],
+		EnumName:   matches[2],
+	}
+
+	addRegex, err := regexp.Compile(
+		`(?is)^add\s+value\s+(if\s+not\s+exists\s+)?'((?:[^']|'')*)'(?:\s+(before|after)\s+'((?:[^']|'')*)')?$`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	renameValueRegex, err := regexp.Compile(`(?is)^rename\s+value\s+'((?:[^']|'')*)'\s+to\s+'((?:[^']|'')*)'$`)
+	if err != nil {
+		return nil, err
+	}
+
+	action := matches[3]
+	if add := addRegex.FindStringSubmatch(action); add != nil {
+		stmt.IfNotExists = add[1] != ""
+		stmt.AddValue = unquoteLabel(add[2])
+		switch strings.ToLower(add[3]) {
+		case "before":
+			stmt.Before = unquoteLabel(add[4])
+		case "after":
+			stmt.After = unquoteLabel(add[4])
+		}
+		return stmt, nil
+	}
+	if rename := renameValueRegex.FindStringSubmatch(action); rename != nil {
+		stmt.RenameValue = unquoteLabel(rename[1])
+		stmt.RenameValueTo = unquoteLabel(rename[2])
+		return stmt, nil
+	}
+
+	fields := strings.Fields(strings.ToLower(action))
+	if len(fields) > 1 && fields[0] == "rename" && fields[1] == "to" {
+		return nil, unsupportedStatement(sql, "renaming an enum type leaves columns referring to the old name")
+	}
+
+	return stmt, nil
+}
+
+func unquoteLabel(label string) string {
+	return strings.ReplaceAll(label, "''", "'")
+}
+
+// CommentOnColumnParser handles COMMENT ON COLUMN statements
+type CommentOnColumnParser struct{}
+
+// NewCommentOnColumnParser creates a new comment on column parser.
+func NewCommentOnColumnParser() *CommentOnColumnParser {
+	return &CommentOnColumnParser{}
+}
+
+// Parse performs the parse operation. Comments on quoted identifiers or built
+// from expressions carry no annotations and are returned as unknown
+// statements, which are skipped.
+func (p *CommentOnColumnParser) Parse(sql string) (Statement, error) {
+	commentRegex, err := regexp.Compile(
+		`(?is)^comment\s+on\s+column\s+(?:(\w+)\.)?(\w+)\.(\w+)\s+is\s+(null|'((?:[^']|'')*)')\s*;?\s*$`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	matches := commentRegex.FindStringSubmatch(sql)
+
+	if len(matches) < 6 {
+		return &UnknownStatement{Raw: sql}, nil
+	}
+
+	stmt := &CommentOnColumnStatement{
+		Raw:        sql,
+		SchemaName: matches[1],
+		TableName:  matches[2],
+		ColumnName: matches[3],
+	}
+	if !strings.EqualFold(matches[4], "null") {
+		comment := strings.ReplaceAll(matches[5], "''", "'")
+		stmt.Comment = &comment
+	}
+
+	return stmt, nil
+}
@@ -10,6 +10,13 @@ import (
 func ParseDataType(typeStr string) (dataType string, length *int32, precision *int32, scale *int32) {
 	typeStrLower := strings.ToLower(typeStr)
 
+	// Handle arrays such as text[] and varchar(50)[]: the element type is
+	// parsed on its own and keeps the [] suffix.
+	if elem, ok := strings.CutSuffix(strings.TrimSpace(typeStrLower), "[]"); ok {
+		elemType, length, precision, scale := ParseDataType(strings.TrimSuffix(elem, "[]"))
+		return elemType + "[]", length, precision, scale
+	}
+
 	if strings.Contains(typeStrLower, "timestamp with time zone") {
 		return "timestamp with time zone", nil, nil, nil
 	}
@@ -0,0 +1,512 @@
+package ddl
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+// UnnamedViewColumn is the name Postgres gives a view column whose
+// expression has neither an alias nor a name of its own.
+const UnnamedViewColumn = "?column?"
+
+var (
+	itemAlias       = regexp.MustCompile(`(?is)^(.*\S)\s+as\s+"?(\w+)"?$`)
+	itemBareAlias   = regexp.MustCompile(`(?is)^(.*[\w)'"\]])\s+"?([a-z_]\w*)"?$`)
+	columnReference = regexp.MustCompile(`(?is)^(?:"?(\w+)"?\.)?(?:"?(\w+)"?\.)?"?(\w+)"?$`)
+	starReference   = regexp.MustCompile(`(?is)^(?:(?:"?\w+"?\.)?"?(\w+)"?\.)?\*$`)
+	functionCall    = regexp.MustCompile(`(?is)^(\w+)\s*\(`)
+	castCall        = regexp.MustCompile(`(?is)^cast\s*\((.*)\s+as\s+([^()]+(?:\([^()]*\))?(?:\s*\[\])*)\s*\)$`)
+	windowSuffix    = regexp.MustCompile(`(?is)\s*(?:filter\s*\(_*\)\s*)?(?:over\s*(?:\(_*\)|\w+))?\s*$`)
+	caseBranch      = regexp.MustCompile(`(?i)\b(?:then|else)\b`)
+	caseBranchEnd   = regexp.MustCompile(`(?i)\b(?:when|else|end)\b`)
+	caseElse        = regexp.MustCompile(`(?i)\belse\b`)
+	comparison      = regexp.MustCompile(`(?i)(?:[<>=!]=?|<>|\b(?:is|like|ilike|in|between|and|or|not|exists)\b)`)
+	arithmetic      = regexp.MustCompile(`[+*/%]|\s-\s`)
+	integerLiteral  = regexp.MustCompile(`^-?\d+$`)
+	decimalLiteral  = regexp.MustCompile(`^-?\d*\.\d+$`)
+)
+
+// expressionKeywords are words that end an expression rather than alias it,
+// as in "a is not null" or "case ... end".
+var expressionKeywords = []string{
+	"and", "or", "not", "is", "null", "true", "false", "end", "in", "like",
+	"ilike", "between", "then", "else", "when", "case", "asc", "desc",
+	"distinct", "from", "zone", "time", "with", "without", "collate",
+}
+
+// viewColumn is a column of a view as read from its SELECT list. The
+// DataType of column is empty when it cannot be inferred.
+type viewColumn struct {
+	name   string
+	column *catalog.Column
+}
+
+// resolveViewColumns infers the columns of a view from its SELECT list and
+// the tables it selects from. A column whose type cannot be inferred is kept
+// with an empty DataType, and one without a name is named
+// UnnamedViewColumn, so only generating a model for the view reports them.
+func (v *CatalogVisitor) resolveViewColumns(stmt *CreateViewStatement) []*catalog.Column {
+	query, ok := parseViewQuery(stmt.Query)
+	if !ok {
+		var columns []*catalog.Column
+		for _, name := range stmt.Columns {
+			columns = append(columns, catalog.NewColumn(name, ""))
+		}
+		if len(columns) == 0 {
+			columns = append(columns, catalog.NewColumn(UnnamedViewColumn, ""))
+		}
+		return columns
+	}
+
+	resolver := &viewResolver{visitor: v, query: query}
+	var columns []*catalog.Column
+	for _, item := range query.items {
+		for _, resolved := range resolver.item(item) {
+			column := resolved.column
+			column.Name = resolved.name
+			if query.union {
+				column.IsNullable = true
+			}
+			columns = append(columns, column)
+		}
+	}
+
+	for i, name := range stmt.Columns {
+		if i < len(columns) {
+			columns[i].Name = name
+		}
+	}
+	return columns
+}
+
+type viewResolver struct {
+	visitor *CatalogVisitor
+	query   *viewQuery
+}
+
+// item resolves one entry of the SELECT list, which is several columns for
+// a * or alias.*.
+func (r *viewResolver) item(item string) []viewColumn {
+	if matches := starReference.FindStringSubmatch(item); matches != nil {
+		return r.star(strings.ToLower(matches[1]))
+	}
+
+	expression, alias := splitItemAlias(item)
+	column, name := r.expression(expression)
+	if alias != "" {
+		name = alias
+	}
+	if name == "" {
+		name = UnnamedViewColumn
+	}
+	return []viewColumn{{name: name, column: column}}
+}
+
+// splitItemAlias splits "expr AS alias" and "expr alias" into the expression
+// and the lower-cased alias.
+func splitItemAlias(item string) (string, string) {
+	masked := maskNested(item)
+	if loc := itemAlias.FindStringSubmatchIndex(masked); loc != nil {
+		return item[:loc[3]], strings.ToLower(item[loc[4]:loc[5]])
+	}
+	if loc := itemBareAlias.FindStringSubmatchIndex(masked); loc != nil {
+		alias := strings.ToLower(item[loc[4]:loc[5]])
+		before := strings.Fields(strings.ToLower(masked[:loc[3]]))
+		previous := ""
+		if len(before) > 0 {
+			previous = before[len(before)-1]
+		}
+		if !slices.Contains(expressionKeywords, alias) && !slices.Contains(expressionKeywords, previous) &&
+			!strings.HasSuffix(strings.TrimSpace(masked[:loc[3]]), "::") {
+			return item[:loc[3]], alias
+		}
+	}
+	return item, ""
+}
+
+// star returns the columns of every source, or of the one named alias.
+func (r *viewResolver) star(alias string) []viewColumn {
+	var columns []viewColumn
+	for _, source := range r.query.sources {
+		if alias != "" && source.alias != alias {
+			continue
+		}
+		table := r.table(source)
+		if table == nil {
+			return append(columns, viewColumn{name: UnnamedViewColumn, column: catalog.NewColumn("", "")})
+		}
+		for _, col := range table.Columns {
+			columns = append(columns, viewColumn{name: col.Name, column: viewSourceColumn(col, source.nullable)})
+		}
+	}
+	return columns
+}
+
+func (r *viewResolver) table(source viewSource) *catalog.Table {
+	if source.table == "" {
+		return nil
+	}
+	if table, err := r.visitor.catalog.GetTable(source.schema, source.table); err == nil {
+		return table
+	}
+	if view, err := r.visitor.catalog.GetView(source.schema, source.table); err == nil {
+		return view
+	}
+	return nil
+}
+
+// expression infers the type of a SELECT list expression and the name
+// Postgres gives it when it has no alias.
+func (r *viewResolver) expression(expression string) (*catalog.Column, string) {
+	expression = strings.TrimSpace(expression)
+	for strings.HasPrefix(expression, "(") && matchingParen(expression, 0) == len(expression)-1 {
+		expression = strings.TrimSpace(expression[1 : len(expression)-1])
+	}
+	masked := maskNested(expression)
+	lower := strings.ToLower(expression)
+
+	if idx := strings.LastIndex(masked, "::"); idx > 0 {
+		inner, name := r.expression(expression[:idx])
+		return castColumn(expression[idx+2:], inner.IsNullable), name
+	}
+	if matches := castCall.FindStringSubmatch(expression); matches != nil && matchingParen(expression, strings.Index(expression, "(")) == len(expression)-1 {
+		inner, name := r.expression(matches[1])
+		return castColumn(matches[2], inner.IsNullable), name
+	}
+
+	switch {
+	case strings.HasPrefix(lower, "case") && strings.HasSuffix(lower, "end"):
+		return r.caseExpression(expression, masked), "case"
+	case strings.Contains(masked, "->>") || strings.Contains(masked, "#>>"):
+		return viewExpressionColumn("text", true), ""
+	case strings.Contains(masked, "||"):
+		return r.concatenation(expression, masked), ""
+	case comparison.MatchString(masked):
+		return viewExpressionColumn("boolean", true), ""
+	case arithmetic.MatchString(masked):
+		return r.arithmetic(expression, masked), ""
+	}
+
+	if loc := functionCall.FindStringSubmatchIndex(expression); loc != nil {
+		open := loc[1] - 1
+		if closing := matchingParen(expression, open); closing >= 0 && windowSuffix.FindStringIndex(masked[closing+1:])[0] == 0 {
+			name := strings.ToLower(expression[loc[2]:loc[3]])
+			return r.function(name, expression[open+1:closing]), name
+		}
+	}
+
+	switch lower {
+	case "current_timestamp":
+		return viewExpressionColumn("timestamp with time zone", false), lower
+	case "localtimestamp":
+		return viewExpressionColumn("timestamp without time zone", false), lower
+	case "current_date":
+		return viewExpressionColumn("date", false), lower
+	case "true", "false":
+		return viewExpressionColumn("boolean", false), "bool"
+	case "null":
+		return catalog.NewColumn("", ""), ""
+	}
+	switch {
+	case strings.HasPrefix(expression, "'") && strings.HasSuffix(expression, "'"):
+		return viewExpressionColumn("text", false), ""
+	case integerLiteral.MatchString(expression):
+		return viewExpressionColumn("integer", false), ""
+	case decimalLiteral.MatchString(expression):
+		return viewExpressionColumn("numeric", false), ""
+	}
+
+	if matches := columnReference.FindStringSubmatch(expression); matches != nil {
+		return r.columnReference(matches), strings.ToLower(matches[3])
+	}
+	return catalog.NewColumn("", ""), ""
+}
+
+// columnReference resolves column, alias.column or schema.table.column.
+func (r *viewResolver) columnReference(matches []string) *catalog.Column {
+	qualifier := strings.ToLower(matches[2])
+	if qualifier == "" {
+		qualifier = strings.ToLower(matches[1])
+	}
+	name := strings.ToLower(matches[3])
+
+	var found *catalog.Column
+	for _, source := range r.query.sources {
+		if qualifier != "" && source.alias != qualifier && source.table != qualifier {
+			continue
+		}
+		table := r.table(source)
+		if table == nil {
+			continue
+		}
+		if col, err := table.GetColumn(name); err == nil {
+			if found != nil {
+				return catalog.NewColumn("", "")
+			}
+			found = viewSourceColumn(col, source.nullable)
+		}
+	}
+	if found == nil {
+		return catalog.NewColumn("", "")
+	}
+	return found
+}
+
+// function infers the result of the aggregate, window and common scalar
+// functions from their arguments.
+func (r *viewResolver) function(name, arguments string) *catalog.Column {
+	args := splitTopLevel(arguments)
+	first := catalog.NewColumn("", "")
+	if len(args) > 0 && strings.TrimSpace(args[0]) != "*" {
+		first, _ = r.expression(strings.TrimPrefix(strings.TrimSpace(args[0]), "distinct "))
+	}
+
+	switch name {
+	case "count", "row_number", "rank", "dense_rank", "ntile":
+		return viewExpressionColumn("bigint", false)
+	case "sum":
+		return viewExpressionColumn(sumType(first.DataType), true)
+	case "avg":
+		if slices.Contains([]string{"real", "float4", "double precision", "float8"}, first.DataType) {
+			return viewExpressionColumn("double precision", true)
+		}
+		if first.DataType == "" {
+			return first
+		}
+		return viewExpressionColumn("numeric", true)
+	case "min", "max", "any_value", "first_value", "last_value", "lag", "lead", "nullif", "abs", "ceil", "ceiling", "floor":
+		return resultColumn(first, true)
+	case "round", "trunc":
+		if len(args) > 1 {
+			return viewExpressionColumn("numeric", first.IsNullable)
+		}
+		return resultColumn(first, first.IsNullable)
+	case "coalesce", "greatest", "least":
+		nullable := true
+		result := catalog.NewColumn("", "")
+		for _, arg := range args {
+			col, _ := r.expression(arg)
+			if result.DataType == "" && col.DataType != "" {
+				result = col
+			}
+			if col.DataType != "" && !col.IsNullable {
+				nullable = false
+			}
+		}
+		return resultColumn(result, nullable)
+	case "date_trunc":
+		if len(args) < 2 {
+			return catalog.NewColumn("", "")
+		}
+		col, _ := r.expression(args[1])
+		return resultColumn(col, col.IsNullable)
+	case "lower", "upper", "trim", "btrim", "ltrim", "rtrim", "initcap", "substring", "substr", "replace", "left", "right", "md5", "to_char", "lpad", "rpad":
+		return viewExpressionColumn("text", first.IsNullable)
+	case "concat", "concat_ws", "format":
+		return viewExpressionColumn("text", false)
+	case "string_agg":
+		return viewExpressionColumn("text", true)
+	case "length", "char_length", "octet_length", "array_length", "cardinality":
+		return viewExpressionColumn("integer", first.IsNullable)
+	case "date_part":
+		return viewExpressionColumn("double precision", true)
+	case "extract":
+		return viewExpressionColumn("numeric", true)
+	case "bool_and", "bool_or", "every":
+		return viewExpressionColumn("boolean", true)
+	case "array_agg":
+		if first.DataType == "" {
+			return first
+		}
+		return viewExpressionColumn(first.DataType+"[]", true)
+	case "json_agg", "json_object_agg":
+		return viewExpressionColumn("json", true)
+	case "jsonb_agg", "jsonb_object_agg":
+		return viewExpressionColumn("jsonb", true)
+	case "json_build_object", "json_build_array", "row_to_json", "to_json":
+		return viewExpressionColumn("json", false)
+	case "jsonb_build_object", "jsonb_build_array", "to_jsonb":
+		return viewExpressionColumn("jsonb", false)
+	case "now", "clock_timestamp", "statement_timestamp", "transaction_timestamp":
+		return viewExpressionColumn("timestamp with time zone", false)
+	case "gen_random_uuid":
+		return viewExpressionColumn("uuid", false)
+	}
+	return catalog.NewColumn("", "")
+}
+
+// caseExpression takes the type of the first THEN or ELSE result. The
+// result is nullable unless an ELSE gives a value for the rows no WHEN
+// matches and no branch is nullable.
+func (r *viewResolver) caseExpression(expression, masked string) *catalog.Column {
+	result := catalog.NewColumn("", "")
+	nullable := !caseElse.MatchString(masked)
+	for _, loc := range caseBranch.FindAllStringIndex(masked, -1) {
+		end := len(masked)
+		if next := caseBranchEnd.FindStringIndex(masked[loc[1]:]); next != nil {
+			end = loc[1] + next[0]
+		}
+		col, _ := r.expression(expression[loc[1]:end])
+		if col.DataType == "" || col.IsNullable {
+			nullable = true
+		}
+		if result.DataType == "" && col.DataType != "" {
+			result = col
+		}
+	}
+	return resultColumn(result, nullable)
+}
+
+// arithmetic takes the widest numeric type of the operands, and the type of
+// a timestamp or date shifted by an interval.
+func (r *viewResolver) arithmetic(expression, masked string) *catalog.Column {
+	var operands []*catalog.Column
+	start := 0
+	for _, loc := range arithmetic.FindAllStringIndex(masked, -1) {
+		col, _ := r.expression(expression[start:loc[0]])
+		operands = append(operands, col)
+		start = loc[1]
+	}
+	col, _ := r.expression(expression[start:])
+	operands = append(operands, col)
+
+	nullable := false
+	best, bestRank := "", -1
+	for _, operand := range operands {
+		if operand.DataType == "" {
+			return catalog.NewColumn("", "")
+		}
+		nullable = nullable || operand.IsNullable
+		rank := slices.Index(numericTypeOrder, operand.DataType)
+		if rank < 0 {
+			if strings.HasPrefix(operands[0].DataType, "timestamp") || operands[0].DataType == "date" {
+				return viewExpressionColumn(operands[0].DataType, nullable)
+			}
+			return catalog.NewColumn("", "")
+		}
+		if rank > bestRank {
+			best, bestRank = operand.DataType, rank
+		}
+	}
+	return viewExpressionColumn(best, nullable)
+}
+
+// concatenation is text, nullable when any of the operands joined by || is.
+func (r *viewResolver) concatenation(expression, masked string) *catalog.Column {
+	nullable := false
+	start := 0
+	for {
+		idx := strings.Index(masked[start:], "||")
+		end := len(expression)
+		if idx >= 0 {
+			end = start + idx
+		}
+		col, _ := r.expression(expression[start:end])
+		nullable = nullable || col.DataType == "" || col.IsNullable
+		if idx < 0 {
+			break
+		}
+		start = end + len("||")
+	}
+	return viewExpressionColumn("text", nullable)
+}
+
+// numericTypeOrder orders the numeric types from narrowest to widest.
+var numericTypeOrder = []string{
+	"smallint", "int2", "integer", "int", "int4", "bigint", "int8",
+	"real", "float4", "double precision", "float8", "numeric", "decimal",
+}
+
+// sumType is the type sum returns for argument type dataType.
+func sumType(dataType string) string {
+	switch dataType {
+	case "":
+		return ""
+	case "smallint", "int2", "integer", "int", "int4", "serial", "smallserial":
+		return "bigint"
+	case "real", "float4", "double precision", "float8", "money", "interval":
+		return dataType
+	}
+	return "numeric"
+}
+
+func castColumn(typeName string, nullable bool) *catalog.Column {
+	col := viewExpressionColumn("", nullable)
+	col.DataType, col.Length, col.Precision, col.Scale = ParseDataType(strings.TrimSpace(typeName))
+	return col
+}
+
+func viewExpressionColumn(dataType string, nullable bool) *catalog.Column {
+	col := catalog.NewColumn("", dataType)
+	col.IsNullable = nullable
+	return col
+}
+
+// resultColumn copies the type of col with the nullability given.
+func resultColumn(col *catalog.Column, nullable bool) *catalog.Column {
+	if col.DataType == "" {
+		return catalog.NewColumn("", "")
+	}
+	result := viewExpressionColumn(col.DataType, nullable)
+	result.Length, result.Precision, result.Scale = col.Length, col.Precision, col.Scale
+	result.Domain = col.Domain
+	return result
+}
+
+// viewSourceColumn copies a column of a table a view selects from. Keys,
+// defaults and identity belong to the table, so only the type, comment and
+// nullability carry over.
+func viewSourceColumn(col *catalog.Column, nullable bool) *catalog.Column {
+	result := resultColumn(col, col.IsNullable || nullable)
+	result.IsArray = col.IsArray
+	result.Comment = col.Comment
+	return result
+}
+
+// VisitCreateView adds the view with the columns of its SELECT list.
+func (v *CatalogVisitor) VisitCreateView(stmt *CreateViewStatement) error {
+	schemaName := stmt.SchemaName
+	if schemaName == "" {
+		schemaName = v.catalog.DefaultSchema
+	}
+
+	if _, err := v.catalog.GetSchema(schemaName); err != nil {
+		if _, createErr := v.catalog.CreateSchema(schemaName); createErr != nil {
+			return createErr
+		}
+	}
+
+	if stmt.IfNotExists {
+		if _, err := v.catalog.GetView(schemaName, stmt.ViewName); err == nil {
+			return nil
+		}
+	}
+
+	view := catalog.NewTable(schemaName, stmt.ViewName).SetCreatedBy(v.migrationFile)
+	view.View = true
+	view.Materialized = stmt.Materialized
+	for _, col := range v.resolveViewColumns(stmt) {
+		col.CreatedBy = v.migrationFile
+		view.Columns = append(view.Columns, col)
+	}
+
+	return v.catalog.AddView(schemaName, view)
+}
+
+// VisitDropView performs the visit drop view operation. Views the catalog
+// does not know are ignored.
+func (v *CatalogVisitor) VisitDropView(stmt *DropViewStatement) error {
+	for _, name := range stmt.ViewNames {
+		schemaName, viewName := catalog.SplitQualifiedName(name)
+		if _, err := v.catalog.GetView(schemaName, viewName); err != nil {
+			continue
+		}
+		if err := v.catalog.DropView(schemaName, viewName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
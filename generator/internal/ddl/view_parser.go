@@ -0,0 +1,318 @@
+package ddl
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	createViewPrefix = regexp.MustCompile(`^create\s+(?:or\s+replace\s+)?(?:temp(?:orary)?\s+)?(?:recursive\s+)?(?:materialized\s+)?view\b`)
+	dropViewPrefix   = regexp.MustCompile(`^drop\s+(?:materialized\s+)?view\b`)
+)
+
+// CreateViewParser handles CREATE VIEW and CREATE MATERIALIZED VIEW
+// statements
+type CreateViewParser struct{}
+
+// NewCreateViewParser creates a new create view parser.
+func NewCreateViewParser() *CreateViewParser {
+	return &CreateViewParser{}
+}
+
+var (
+	createViewRegex = regexp.MustCompile(
+		`(?is)^create\s+(?:or\s+replace\s+)?(?:temp(?:orary)?\s+)?(?:recursive\s+)?(materialized\s+)?view\s+(if\s+not\s+exists\s+)?(?:"?(\w+)"?\.)?"?(\w+)"?\s*(\([^()]*\))?\s*(?:with\s*\([^()]*\)\s*)?as\s+(.*?)\s*;?\s*$`,
+	)
+	viewOptionSuffix = regexp.MustCompile(`(?is)\s+with\s+(?:no\s+data|data|(?:cascaded\s+|local\s+)?check\s+option)\s*$`)
+)
+
+// Parse performs the parse operation. The query is kept as written; its
+// columns are resolved against the catalog when the view is added to it.
+func (p *CreateViewParser) Parse(sql string) (*CreateViewStatement, error) {
+	matches := createViewRegex.FindStringSubmatch(sql)
+	if matches == nil {
+		return nil, unsupportedStatement(sql, "the view definition could not be parsed")
+	}
+
+	var columns []string
+	if list := strings.Trim(matches[5], "()"); strings.TrimSpace(list) != "" {
+		for column := range strings.SplitSeq(list, ",") {
+			columns = append(columns, unquoteIdentifier(column))
+		}
+	}
+
+	return &CreateViewStatement{
+		Raw:          sql,
+		SchemaName:   strings.ToLower(matches[3]),
+		ViewName:     strings.ToLower(matches[4]),
+		Columns:      columns,
+		Query:        viewOptionSuffix.ReplaceAllString(strings.TrimSpace(matches[6]), ""),
+		Materialized: matches[1] != "",
+		IfNotExists:  matches[2] != "",
+	}, nil
+}
+
+// SourceTables returns the lower-cased, unqualified names of the tables and
+// views the view selects from.
+func (s *CreateViewStatement) SourceTables() []string {
+	query, ok := parseViewQuery(s.Query)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for _, source := range query.sources {
+		if source.table != "" {
+			names = append(names, source.table)
+		}
+	}
+	return names
+}
+
+// DropViewParser handles DROP VIEW and DROP MATERIALIZED VIEW statements
+type DropViewParser struct{}
+
+// NewDropViewParser creates a new drop view parser.
+func NewDropViewParser() *DropViewParser {
+	return &DropViewParser{}
+}
+
+var dropViewRegex = regexp.MustCompile(
+	`(?is)^drop\s+(materialized\s+)?view\s+(?:if\s+exists\s+)?(.*?)(?:\s+(?:cascade|restrict))?\s*;?\s*$`,
+)
+
+// Parse performs the parse operation.
+func (p *DropViewParser) Parse(sql string) (*DropViewStatement, error) {
+	matches := dropViewRegex.FindStringSubmatch(sql)
+	if matches == nil {
+		return nil, unsupportedStatement(sql, "the view names could not be parsed")
+	}
+
+	var names []string
+	for name := range strings.SplitSeq(matches[2], ",") {
+		if name = unquoteIdentifier(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return &DropViewStatement{
+		Raw:          sql,
+		ViewNames:    names,
+		Materialized: matches[1] != "",
+	}, nil
+}
+
+// unquoteIdentifier lower-cases a possibly qualified identifier and removes
+// its double quotes.
+func unquoteIdentifier(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), `"`, ""))
+}
+
+// viewQuery is the part of a view's SELECT its columns are resolved from.
+type viewQuery struct {
+	items   []string
+	sources []viewSource
+	// union is set when the query combines several SELECTs; the columns
+	// come from the first one.
+	union bool
+}
+
+// viewSource is a table, view or subquery in the FROM clause. table is
+// empty for a subquery or function, whose columns are not known.
+type viewSource struct {
+	schema   string
+	table    string
+	alias    string
+	nullable bool // on the optional side of an outer join
+}
+
+var (
+	selectClauseEnd = regexp.MustCompile(`(?i)\b(?:where|group\s+by|having|order\s+by|limit|offset|window|union|intersect|except|fetch|for)\b`)
+	setOperation    = regexp.MustCompile(`(?i)\b(?:union|intersect|except)\b`)
+	fromKeyword     = regexp.MustCompile(`(?i)\bfrom\b`)
+	selectDistinct  = regexp.MustCompile(`(?is)^(?:distinct\s+on\s*\(_*\)|distinct|all)\s+`)
+	joinKeyword     = regexp.MustCompile(`(?i)\b(?:natural\s+)?(?:(left|right|full|inner|cross)\s+)?(?:outer\s+)?join\b`)
+	joinCondition   = regexp.MustCompile(`(?i)\s(?:on|using)\b`)
+	sourceRegex     = regexp.MustCompile(`(?is)^(?:only\s+)?(?:"?(\w+)"?\.)?"?(\w+)"?(?:\s+(?:as\s+)?"?(\w+)"?)?$`)
+	subqueryAlias   = regexp.MustCompile(`(?is)\)\s*(?:as\s+)?"?(\w+)"?(?:\s*\([^()]*\))?$`)
+)
+
+// parseViewQuery splits a SELECT into its select list and FROM sources. It
+// reports false for queries it does not read, such as ones starting with a
+// WITH clause or VALUES.
+func parseViewQuery(query string) (*viewQuery, bool) {
+	query = strings.TrimSpace(query)
+	for strings.HasPrefix(query, "(") && matchingParen(query, 0) == len(query)-1 {
+		query = strings.TrimSpace(query[1 : len(query)-1])
+	}
+	if len(query) < 7 || !strings.EqualFold(query[:6], "select") || !isSQLSpace(query[6]) {
+		return nil, false
+	}
+
+	masked := maskNested(query)
+	result := &viewQuery{union: setOperation.MatchString(masked)}
+
+	body, maskedBody := query[6:], masked[6:]
+	if loc := fromKeyword.FindStringIndex(maskedBody); loc != nil {
+		fromClause, maskedFrom := body[loc[1]:], maskedBody[loc[1]:]
+		body, maskedBody = body[:loc[0]], maskedBody[:loc[0]]
+		if end := selectClauseEnd.FindStringIndex(maskedFrom); end != nil {
+			fromClause = fromClause[:end[0]]
+		}
+		result.sources = parseViewSources(fromClause)
+	} else if end := selectClauseEnd.FindStringIndex(maskedBody); end != nil {
+		body, maskedBody = body[:end[0]], maskedBody[:end[0]]
+	}
+
+	if loc := selectDistinct.FindStringIndex(strings.TrimLeft(maskedBody, " \t\r\n")); loc != nil {
+		trimmed := len(maskedBody) - len(strings.TrimLeft(maskedBody, " \t\r\n"))
+		body = body[trimmed+loc[1]:]
+	}
+	result.items = splitTopLevel(body)
+
+	return result, true
+}
+
+// parseViewSources reads the tables of a FROM clause, marking the ones an
+// outer join can leave without a row as nullable.
+func parseViewSources(fromClause string) []viewSource {
+	var sources []viewSource
+	for _, item := range splitTopLevel(fromClause) {
+		maskedItem := maskNested(item)
+		joins := joinKeyword.FindAllStringSubmatchIndex(maskedItem, -1)
+
+		start, joinType := 0, ""
+		for i := 0; i <= len(joins); i++ {
+			end := len(item)
+			if i < len(joins) {
+				end = joins[i][0]
+			}
+			source := parseViewSource(item[start:end], maskedItem[start:end])
+			switch strings.ToLower(joinType) {
+			case "left":
+				source.nullable = true
+			case "right":
+				for j := range sources {
+					sources[j].nullable = true
+				}
+			case "full":
+				source.nullable = true
+				for j := range sources {
+					sources[j].nullable = true
+				}
+			}
+			sources = append(sources, source)
+
+			if i < len(joins) {
+				start = joins[i][1]
+				joinType = ""
+				if joins[i][2] >= 0 {
+					joinType = item[joins[i][2]:joins[i][3]]
+				}
+			}
+		}
+	}
+	return sources
+}
+
+func parseViewSource(source, masked string) viewSource {
+	if loc := joinCondition.FindStringIndex(masked); loc != nil {
+		source, masked = source[:loc[0]], masked[:loc[0]]
+	}
+	source = strings.TrimSpace(source)
+	if strings.Contains(masked, "(") {
+		if matches := subqueryAlias.FindStringSubmatch(source); matches != nil {
+			return viewSource{alias: strings.ToLower(matches[1])}
+		}
+		return viewSource{}
+	}
+
+	matches := sourceRegex.FindStringSubmatch(source)
+	if matches == nil {
+		return viewSource{}
+	}
+	table := strings.ToLower(matches[2])
+	alias := strings.ToLower(matches[3])
+	if alias == "" {
+		alias = table
+	}
+	return viewSource{schema: strings.ToLower(matches[1]), table: table, alias: alias}
+}
+
+// splitTopLevel splits sql on the commas outside parentheses and quotes.
+func splitTopLevel(sql string) []string {
+	masked := maskNested(sql)
+	var parts []string
+	start := 0
+	for i := 0; i < len(masked); i++ {
+		if masked[i] == ',' {
+			parts = append(parts, strings.TrimSpace(sql[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(sql[start:]); last != "" || len(parts) > 0 {
+		parts = append(parts, last)
+	}
+	return parts
+}
+
+// maskNested returns sql with the text inside parentheses and string
+// literals replaced by underscores, so keywords and commas found in the
+// result are at the top level. The parentheses and quotes themselves are
+// kept, as is the length.
+func maskNested(sql string) string {
+	masked := []byte(sql)
+	depth := 0
+	inString := false
+	for i := 0; i < len(masked); i++ {
+		char := sql[i]
+		switch {
+		case inString:
+			if char == '\'' {
+				if i+1 < len(sql) && sql[i+1] == '\'' {
+					masked[i], masked[i+1] = '_', '_'
+					i++
+					continue
+				}
+				inString = false
+				if depth > 0 {
+					masked[i] = '_'
+				}
+				continue
+			}
+			masked[i] = '_'
+		case char == '\'':
+			inString = true
+			if depth > 0 {
+				masked[i] = '_'
+			}
+		case char == '(':
+			if depth > 0 {
+				masked[i] = '_'
+			}
+			depth++
+		case char == ')':
+			if depth > 0 {
+				depth--
+			}
+			if depth > 0 {
+				masked[i] = '_'
+			}
+		case depth > 0:
+			masked[i] = '_'
+		}
+	}
+	return string(masked)
+}
+
+// matchingParen returns the index of the parenthesis closing the one at
+// open, or -1.
+func matchingParen(sql string, open int) int {
+	masked := maskNested(sql[open:])
+	for i := 1; i < len(masked); i++ {
+		if masked[i] == ')' {
+			return open + i
+		}
+	}
+	return -1
+}
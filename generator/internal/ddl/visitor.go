@@ -24,6 +24,18 @@ const (
 	CreateEnum
 	// DropEnum is a constant value for drop enum.
 	DropEnum
+	// AlterEnum is a constant value for alter enum.
+	AlterEnum
+	// CreateDomain is a constant value for create domain.
+	CreateDomain
+	// DropDomain is a constant value for drop domain.
+	DropDomain
+	// CommentOnColumn is a constant value for comment on column.
+	CommentOnColumn
+	// CreateView is a constant value for create view.
+	CreateView
+	// DropView is a constant value for drop view.
+	DropView
 	// Unknown is a constant value for unknown.
 	Unknown
 )
@@ -51,6 +63,24 @@ type SchemaVisitor interface {
 type EnumVisitor interface {
 	VisitCreateEnum(stmt *CreateEnumStatement) error
 	VisitDropEnum(stmt *DropEnumStatement) error
+	VisitAlterEnum(stmt *AlterEnumStatement) error
+}
+
+// DomainVisitor handles domain-related DDL operations
+type DomainVisitor interface {
+	VisitCreateDomain(stmt *CreateDomainStatement) error
+	VisitDropDomain(stmt *DropDomainStatement) error
+}
+
+// ViewVisitor handles view-related DDL operations
+type ViewVisitor interface {
+	VisitCreateView(stmt *CreateViewStatement) error
+	VisitDropView(stmt *DropViewStatement) error
+}
+
+// CommentVisitor handles comment-related DDL operations
+type CommentVisitor interface {
+	VisitCommentOnColumn(stmt *CommentOnColumnStatement) error
 }
 
 // DDLVisitor combines all DDL visitor interfaces
@@ -59,6 +89,9 @@ type DDLVisitor interface {
 	IndexVisitor
 	SchemaVisitor
 	EnumVisitor
+	DomainVisitor
+	ViewVisitor
+	CommentVisitor
 }
 
 // Base statement interfaces
@@ -75,6 +108,7 @@ type CreateTableStatement struct {
 	TableName   string
 	IfNotExists bool
 	Columns     []*catalog.Column
+	Checks      []*catalog.Check
 }
 
 // Accept performs the accept operation.
@@ -104,6 +138,9 @@ type AlterTableStatement struct {
 	ColumnDef      *catalog.Column
 	ColumnChanges  map[string]any
 	Operations     []string
+	Check          *catalog.Check      // Added with ADD CONSTRAINT ... CHECK or an ADD COLUMN with a CHECK
+	ConstraintName string              // Dropped with DROP CONSTRAINT
+	ForeignKey     *catalog.ForeignKey // Added to ColumnName with ADD CONSTRAINT ... FOREIGN KEY
 }
 
 // Accept performs the accept operation.
@@ -146,7 +183,14 @@ func (s *DropTableStatement) GetType() StatementType {
 
 // CreateIndexStatement represents create index statement.
 type CreateIndexStatement struct {
-	Raw string
+	Raw         string
+	SchemaName  string
+	TableName   string
+	IndexName   string
+	Columns     []string // Plain key columns; expressions are left out
+	Unique      bool
+	Partial     bool // Has a WHERE clause or expression keys
+	IfNotExists bool
 }
 
 // Accept performs the accept operation.
@@ -166,7 +210,8 @@ func (s *CreateIndexStatement) GetType() StatementType {
 
 // DropIndexStatement represents drop index statement.
 type DropIndexStatement struct {
-	Raw string
+	Raw        string
+	IndexNames []string
 }
 
 // Accept performs the accept operation.
@@ -271,6 +316,160 @@ func (s *DropEnumStatement) GetType() StatementType {
 	return DropEnum
 }
 
+// AlterEnumStatement represents alter enum statement. AddValue is set for
+// ADD VALUE, with Before or After naming the neighbouring label, and
+// RenameValue/RenameValueTo for RENAME VALUE.
+type AlterEnumStatement struct {
+	Raw           string
+	SchemaName    string
+	EnumName      string
+	AddValue      string
+	Before        string
+	After         string
+	IfNotExists   bool
+	RenameValue   string
+	RenameValueTo string
+}
+
+// Accept performs the accept operation.
+func (s *AlterEnumStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitAlterEnum(s)
+}
+
+// GetRaw returns raw.
+func (s *AlterEnumStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *AlterEnumStatement) GetType() StatementType {
+	return AlterEnum
+}
+
+// CreateDomainStatement represents create domain statement. BaseType is
+// the type the domain is based on as written, and NotNull is set when the
+// domain has a NOT NULL constraint.
+type CreateDomainStatement struct {
+	Raw        string
+	SchemaName string
+	DomainName string
+	BaseType   string
+	NotNull    bool
+}
+
+// Accept performs the accept operation.
+func (s *CreateDomainStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitCreateDomain(s)
+}
+
+// GetRaw returns raw.
+func (s *CreateDomainStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *CreateDomainStatement) GetType() StatementType {
+	return CreateDomain
+}
+
+// DropDomainStatement represents drop domain statement.
+type DropDomainStatement struct {
+	Raw        string
+	SchemaName string
+	DomainName string
+}
+
+// Accept performs the accept operation.
+func (s *DropDomainStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitDropDomain(s)
+}
+
+// GetRaw returns raw.
+func (s *DropDomainStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *DropDomainStatement) GetType() StatementType {
+	return DropDomain
+}
+
+// CommentOnColumnStatement represents comment on column statement. A nil
+// Comment means the comment was removed with IS NULL.
+type CommentOnColumnStatement struct {
+	Raw        string
+	SchemaName string
+	TableName  string
+	ColumnName string
+	Comment    *string
+}
+
+// Accept performs the accept operation.
+func (s *CommentOnColumnStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitCommentOnColumn(s)
+}
+
+// GetRaw returns raw.
+func (s *CommentOnColumnStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *CommentOnColumnStatement) GetType() StatementType {
+	return CommentOnColumn
+}
+
+// CreateViewStatement represents create view statement. Columns is the
+// column list written after the view name, and Query the SELECT the view
+// is defined by.
+type CreateViewStatement struct {
+	Raw          string
+	SchemaName   string
+	ViewName     string
+	Columns      []string
+	Query        string
+	Materialized bool
+	IfNotExists  bool
+}
+
+// Accept performs the accept operation.
+func (s *CreateViewStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitCreateView(s)
+}
+
+// GetRaw returns raw.
+func (s *CreateViewStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *CreateViewStatement) GetType() StatementType {
+	return CreateView
+}
+
+// DropViewStatement represents drop view statement. ViewNames are written
+// as in the statement, qualified with their schema or not.
+type DropViewStatement struct {
+	Raw          string
+	ViewNames    []string
+	Materialized bool
+}
+
+// Accept performs the accept operation.
+func (s *DropViewStatement) Accept(visitor DDLVisitor) error {
+	return visitor.VisitDropView(s)
+}
+
+// GetRaw returns raw.
+func (s *DropViewStatement) GetRaw() string {
+	return s.Raw
+}
+
+// GetType returns type.
+func (s *DropViewStatement) GetType() StatementType {
+	return DropView
+}
+
 // UnknownStatement represents unknown statement.
 type UnknownStatement struct {
 	Raw string
@@ -51,6 +51,30 @@ func (v *recordingVisitor) VisitDropEnum(*DropEnumStatement) error {
 	return v.visit("drop_enum")
 }
 
+func (v *recordingVisitor) VisitAlterEnum(*AlterEnumStatement) error {
+	return v.visit("alter_enum")
+}
+
+func (v *recordingVisitor) VisitCreateDomain(*CreateDomainStatement) error {
+	return v.visit("create_domain")
+}
+
+func (v *recordingVisitor) VisitDropDomain(*DropDomainStatement) error {
+	return v.visit("drop_domain")
+}
+
+func (v *recordingVisitor) VisitCreateView(*CreateViewStatement) error {
+	return v.visit("create_view")
+}
+
+func (v *recordingVisitor) VisitDropView(*DropViewStatement) error {
+	return v.visit("drop_view")
+}
+
+func (v *recordingVisitor) VisitCommentOnColumn(*CommentOnColumnStatement) error {
+	return v.visit("comment_on_column")
+}
+
 func TestStatementAccessorsAndAccept(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -67,6 +91,12 @@ func TestStatementAccessorsAndAccept(t *testing.T) {
 		{name: "drop schema", statement: &DropSchemaStatement{Raw: "drop schema"}, wantType: DropSchema, wantVisit: "drop_schema"},
 		{name: "create enum", statement: &CreateEnumStatement{Raw: "create enum"}, wantType: CreateEnum, wantVisit: "create_enum"},
 		{name: "drop enum", statement: &DropEnumStatement{Raw: "drop enum"}, wantType: DropEnum, wantVisit: "drop_enum"},
+		{name: "alter enum", statement: &AlterEnumStatement{Raw: "alter enum"}, wantType: AlterEnum, wantVisit: "alter_enum"},
+		{name: "create domain", statement: &CreateDomainStatement{Raw: "create domain"}, wantType: CreateDomain, wantVisit: "create_domain"},
+		{name: "drop domain", statement: &DropDomainStatement{Raw: "drop domain"}, wantType: DropDomain, wantVisit: "drop_domain"},
+		{name: "create view", statement: &CreateViewStatement{Raw: "create view"}, wantType: CreateView, wantVisit: "create_view"},
+		{name: "drop view", statement: &DropViewStatement{Raw: "drop view"}, wantType: DropView, wantVisit: "drop_view"},
+		{name: "comment on column", statement: &CommentOnColumnStatement{Raw: "comment on column"}, wantType: CommentOnColumn, wantVisit: "comment_on_column"},
 	}
 
 	for _, tt := range tests {
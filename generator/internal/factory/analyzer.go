@@ -36,10 +36,10 @@ func (fa *FieldAnalyzer) AnalyzeField(field models.GeneratedField, tableName str
 	info := FactoryFieldInfo{
 		Name:          field.Name,
 		Type:          field.Type,
-		OptionName:    fmt.Sprintf("With%s%s", naming.Capitalize(naming.ToCamelCase(tableName)), field.Name),
+		OptionName:    fmt.Sprintf("With%s%s", naming.ToPascalCase(tableName), field.Name),
 		IsID:          field.Name == "ID",
 		IsTimestamp:   field.Type == "time.Time" || strings.Contains(field.Type, "Time"),
-		IsAutoManaged: field.Name == "ID" || field.Name == "CreatedAt" || field.Name == "UpdatedAt",
+		IsAutoManaged: field.Name == "ID" || field.Name == "CreatedAt" || field.Name == "UpdatedAt" || field.IsAutoIncrement || field.IsGenerated,
 		IsFK:          field.IsForeignKey,
 	}
 
@@ -54,15 +54,15 @@ func (fa *FieldAnalyzer) determineDefault(fieldName, goType string) string {
 	// Handle by type first
 	switch goType {
 	case "string":
-		return "faker.Word()"
+		return "gofakeit.Word()"
 	case "int32", "int":
-		return "randomInt(1, 1000, 100)"
+		return "int32(gofakeit.IntRange(1, 1000))"
 	case "int64":
-		return "randomInt64(1, 1000, 100)"
+		return "int64(gofakeit.IntRange(1, 1000))"
 	case "int16":
-		return "randomInt16(1, 1000, 100)"
+		return "int16(gofakeit.IntRange(1, 1000))"
 	case "bool":
-		return "randomBool()"
+		return "gofakeit.Bool()"
 	case "time.Time":
 		return "time.Now()"
 	case "uuid.UUID":
@@ -72,11 +72,11 @@ func (fa *FieldAnalyzer) determineDefault(fieldName, goType string) string {
 	case "[]byte":
 		return "[]byte{}"
 	case "*string":
-		return "faker.Word()"
+		return "gofakeit.Word()"
 	case "*time.Time":
 		return "time.Now()"
 	case "*bool":
-		return "randomBool()"
+		return "gofakeit.Bool()"
 	}
 
 	// Default fallback
@@ -89,35 +89,34 @@ func (fa *FieldAnalyzer) stringDefault(fieldName string) string {
 	// Field name heuristics
 	switch {
 	case lower == "email":
-		return "faker.Email()"
+		return "gofakeit.Email()"
 	case lower == "name" || strings.HasSuffix(lower, "name"):
-		return "faker.Name()"
+		return "gofakeit.Name()"
 	case lower == "phone" || strings.Contains(lower, "phone"):
-		return "faker.Phonenumber()"
+		return "gofakeit.Phone()"
 	case lower == "url" || strings.Contains(lower, "url"):
-		return "faker.URL()"
+		return "gofakeit.URL()"
 	case lower == "description" || strings.HasSuffix(lower, "description"):
-		return "faker.Sentence()"
+		return "gofakeit.Sentence()"
 	case lower == "title" || strings.HasSuffix(lower, "title"):
-		return "faker.Word()"
+		return "gofakeit.Word()"
 	case lower == "address" || strings.Contains(lower, "address"):
-		return "faker.GetRealAddress().Address"
+		return "gofakeit.Street()"
 	case lower == "city":
-		return "faker.GetRealAddress().City"
+		return "gofakeit.City()"
 	case lower == "country":
-		return "faker.GetRealAddress().Country"
+		return "gofakeit.Country()"
 	case lower == "zipcode" || lower == "postalcode":
-		return "faker.GetRealAddress().PostalCode"
+		return "gofakeit.Zip()"
 	case strings.Contains(lower, "color"):
-		return "faker.GetRandomColor()"
+		return "gofakeit.Color()"
 	default:
-		return "faker.Word()"
+		return "gofakeit.Word()"
 	}
 }
 
 func (fa *FieldAnalyzer) intDefault(fieldName string) string {
-	// Use randomInt helper which handles faker.RandomInt errors
-	return "randomInt(1, 1000, 100)"
+	return "int32(gofakeit.IntRange(1, 1000))"
 }
 
 func (fa *FieldAnalyzer) pgtypeDefault(goType string) string {
@@ -142,9 +141,8 @@ func (fa *FieldAnalyzer) getGoZero(goType string) string {
 	case "[]byte":
 		return "nil"
 	default:
-		if strings.HasPrefix(goType, "[]") {
-			return "nil"
-		}
+		// Slices default to empty rather than nil so NOT NULL array
+		// columns insert '{}' instead of NULL.
 		return fmt.Sprintf("%s{}", goType)
 	}
 }
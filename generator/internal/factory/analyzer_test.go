@@ -11,16 +11,16 @@ func TestFieldAnalyzer_StringDefaults(t *testing.T) {
 		fieldName string
 		expected  string
 	}{
-		{"Email", "faker.Email()"},
-		{"Name", "faker.Name()"},
-		{"UserName", "faker.Name()"},
-		{"PhoneNumber", "faker.Phonenumber()"},
-		{"Description", "faker.Sentence()"},
-		{"Title", "faker.Word()"},
-		{"City", "faker.GetRealAddress().City"},
-		{"Address", "faker.GetRealAddress().Address"},
-		{"Country", "faker.GetRealAddress().Country"},
-		{"RandomField", "faker.Word()"},
+		{"Email", "gofakeit.Email()"},
+		{"Name", "gofakeit.Name()"},
+		{"UserName", "gofakeit.Name()"},
+		{"PhoneNumber", "gofakeit.Phone()"},
+		{"Description", "gofakeit.Sentence()"},
+		{"Title", "gofakeit.Word()"},
+		{"City", "gofakeit.City()"},
+		{"Address", "gofakeit.Street()"},
+		{"Country", "gofakeit.Country()"},
+		{"RandomField", "gofakeit.Word()"},
 	}
 
 	analyzer := NewFieldAnalyzer("postgres")
@@ -37,8 +37,8 @@ func TestFieldAnalyzer_StringDefaults(t *testing.T) {
 func TestFieldAnalyzer_IntDefaults(t *testing.T) {
 	analyzer := NewFieldAnalyzer("postgres")
 
-	// intDefault now returns a generic random int call for all fields
-	expected := "randomInt(1, 1000, 100)"
+	// intDefault returns a generic random int call for all fields
+	expected := "int32(gofakeit.IntRange(1, 1000))"
 	got := analyzer.intDefault("anyField")
 	if got != expected {
 		t.Errorf("intDefault(anyField) = %s, want %s", got, expected)
@@ -49,19 +49,19 @@ func TestFieldAnalyzer_DetermineDefaultAndGoZero(t *testing.T) {
 	analyzer := NewFieldAnalyzer("postgres")
 
 	defaults := map[string]string{
-		"string":          "faker.Word()",
-		"int32":           "randomInt(1, 1000, 100)",
-		"int":             "randomInt(1, 1000, 100)",
-		"int64":           "randomInt64(1, 1000, 100)",
-		"int16":           "randomInt16(1, 1000, 100)",
-		"bool":            "randomBool()",
+		"string":          "gofakeit.Word()",
+		"int32":           "int32(gofakeit.IntRange(1, 1000))",
+		"int":             "int32(gofakeit.IntRange(1, 1000))",
+		"int64":           "int64(gofakeit.IntRange(1, 1000))",
+		"int16":           "int16(gofakeit.IntRange(1, 1000))",
+		"bool":            "gofakeit.Bool()",
 		"time.Time":       "time.Now()",
 		"uuid.UUID":       "uuid.New()",
 		"json.RawMessage": "json.RawMessage{}",
 		"[]byte":          "[]byte{}",
-		"*string":         "faker.Word()",
+		"*string":         "gofakeit.Word()",
 		"*time.Time":      "time.Now()",
-		"*bool":           "randomBool()",
+		"*bool":           "gofakeit.Bool()",
 		"CustomType":      "CustomType{}",
 	}
 	for typ, want := range defaults {
@@ -82,7 +82,7 @@ func TestFieldAnalyzer_DetermineDefaultAndGoZero(t *testing.T) {
 		"uuid.UUID":       "uuid.UUID{}",
 		"json.RawMessage": "nil",
 		"[]byte":          "nil",
-		"[]string":        "nil",
+		"[]string":        "[]string{}",
 		"CustomType":      "CustomType{}",
 	}
 	for typ, want := range zeros {
@@ -0,0 +1,336 @@
+// Package introspect reads a table's definition from a PostgreSQL database
+// and renders it as DDL, so the catalog of a table that has no migrations is
+// built the same way as one that has.
+package introspect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Querier runs the catalog queries. *pgx.Conn satisfies it.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Table is the definition of a table or view as read from pg_catalog.
+type Table struct {
+	Schema      string
+	Name        string
+	Kind        string // pg_class.relkind: "r" or "p" for a table, "v" or "m" for a view
+	Columns     []Column
+	Constraints []Constraint
+	Indexes     []string // pg_get_indexdef of every index but the primary key
+	Enums       []Enum   // Enum types of the columns
+	Domains     []Domain // Domains of the columns
+}
+
+// Column is a column of a table.
+type Column struct {
+	Name     string
+	Type     string // format_type, e.g. "character varying(255)"
+	NotNull  bool
+	Default  string // pg_get_expr of the default, empty without one
+	Identity bool   // GENERATED ... AS IDENTITY
+}
+
+// Constraint is a primary key, foreign key or check constraint.
+type Constraint struct {
+	Name       string
+	Kind       string // pg_constraint.contype: "p", "f" or "c"
+	Columns    int    // Number of constrained columns
+	Definition string // pg_get_constraintdef, e.g. "PRIMARY KEY (id)"
+}
+
+// Domain is a domain, the type it is based on and whether it is NOT NULL.
+type Domain struct {
+	Name     string
+	BaseType string // format_type of the base type, e.g. "citext"
+	NotNull  bool
+}
+
+// Enum is an enum type and its values in sort order.
+type Enum struct {
+	Name   string
+	Values []string
+}
+
+const tableQuery = `
+SELECT c.oid, n.nspname, c.relname, c.relkind::text
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.oid = to_regclass($1) AND c.relkind IN ('r', 'p', 'v', 'm')`
+
+// Generated columns keep their expression in pg_attrdef too; they are read
+// without a default.
+const columnsQuery = `
+SELECT a.attname,
+       format_type(a.atttypid, a.atttypmod),
+       a.attnotnull,
+       CASE WHEN a.attgenerated = '' THEN COALESCE(pg_get_expr(d.adbin, d.adrelid), '') ELSE '' END,
+       a.attidentity <> ''
+FROM pg_attribute a
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`
+
+const enumsQuery = `
+SELECT format_type(t.oid, NULL), array_agg(e.enumlabel::text ORDER BY e.enumsortorder)
+FROM pg_attribute a
+JOIN pg_type t ON t.oid = a.atttypid
+JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+GROUP BY t.oid
+ORDER BY 1`
+
+// Columns of an array of a domain use the domain too.
+const domainsQuery = `
+SELECT DISTINCT format_type(t.oid, NULL), format_type(t.typbasetype, t.typtypmod), t.typnotnull
+FROM pg_attribute a
+JOIN pg_type c ON c.oid = a.atttypid
+JOIN pg_type t ON t.oid IN (c.oid, c.typelem) AND t.typtype = 'd'
+WHERE a.attrelid = $1 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY 1`
+
+const constraintsQuery = `
+SELECT conname, contype::text, cardinality(conkey), pg_get_constraintdef(oid)
+FROM pg_constraint
+WHERE conrelid = $1 AND contype IN ('p', 'f', 'c')
+ORDER BY contype, conname`
+
+const tablesQuery = `
+SELECT relname
+FROM pg_class
+WHERE relnamespace = current_schema()::regnamespace AND relkind IN ('r', 'p') AND NOT relispartition
+ORDER BY relname`
+
+const schemaEnumsQuery = `
+SELECT t.typname, array_agg(e.enumlabel::text ORDER BY e.enumsortorder)
+FROM pg_type t
+JOIN pg_enum e ON e.enumtypid = t.oid
+WHERE t.typnamespace = current_schema()::regnamespace
+GROUP BY t.typname
+ORDER BY t.typname`
+
+const indexesQuery = `
+SELECT pg_get_indexdef(i.indexrelid)
+FROM pg_index i
+JOIN pg_class c ON c.oid = i.indexrelid
+WHERE i.indrelid = $1 AND NOT i.indisprimary
+ORDER BY c.relname`
+
+// ReadTable reads the definition of the table or view called name, resolved
+// through the connection's search_path like an unqualified name in a query.
+func ReadTable(ctx context.Context, q Querier, name string) (*Table, error) {
+	rows, err := q.Query(ctx, tableQuery, name)
+	if err != nil {
+		return nil, fmt.Errorf("look up table %s: %w", name, err)
+	}
+	var (
+		oid   uint32
+		table Table
+	)
+	_, err = pgx.CollectExactlyOneRow(rows, func(row pgx.CollectableRow) (struct{}, error) {
+		return struct{}{}, row.Scan(&oid, &table.Schema, &table.Name, &table.Kind)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("table '%s' not found in the database", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("look up table %s: %w", name, err)
+	}
+
+	if table.Columns, err = collect(ctx, q, columnsQuery, oid, func(row pgx.CollectableRow) (Column, error) {
+		var column Column
+		err := row.Scan(&column.Name, &column.Type, &column.NotNull, &column.Default, &column.Identity)
+		return column, err
+	}); err != nil {
+		return nil, fmt.Errorf("read columns of %s: %w", name, err)
+	}
+	if table.Enums, err = collect(ctx, q, enumsQuery, oid, func(row pgx.CollectableRow) (Enum, error) {
+		var enum Enum
+		err := row.Scan(&enum.Name, &enum.Values)
+		return enum, err
+	}); err != nil {
+		return nil, fmt.Errorf("read enum types of %s: %w", name, err)
+	}
+	if table.Domains, err = collect(ctx, q, domainsQuery, oid, func(row pgx.CollectableRow) (Domain, error) {
+		var domain Domain
+		err := row.Scan(&domain.Name, &domain.BaseType, &domain.NotNull)
+		return domain, err
+	}); err != nil {
+		return nil, fmt.Errorf("read domains of %s: %w", name, err)
+	}
+	if table.Constraints, err = collect(ctx, q, constraintsQuery, oid, func(row pgx.CollectableRow) (Constraint, error) {
+		var constraint Constraint
+		err := row.Scan(&constraint.Name, &constraint.Kind, &constraint.Columns, &constraint.Definition)
+		return constraint, err
+	}); err != nil {
+		return nil, fmt.Errorf("read constraints of %s: %w", name, err)
+	}
+	if table.Indexes, err = collect(ctx, q, indexesQuery, oid, pgx.RowTo[string]); err != nil {
+		return nil, fmt.Errorf("read indexes of %s: %w", name, err)
+	}
+
+	return &table, nil
+}
+
+// ListTables returns the names of the tables in the connection's current
+// schema, leaving out partitions.
+func ListTables(ctx context.Context, q Querier) ([]string, error) {
+	rows, err := q.Query(ctx, tablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	return tables, nil
+}
+
+// ReadEnums reads the enum types of the connection's current schema,
+// including those no column uses.
+func ReadEnums(ctx context.Context, q Querier) ([]Enum, error) {
+	rows, err := q.Query(ctx, schemaEnumsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("read enum types: %w", err)
+	}
+	enums, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Enum, error) {
+		var enum Enum
+		err := row.Scan(&enum.Name, &enum.Values)
+		return enum, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read enum types: %w", err)
+	}
+	return enums, nil
+}
+
+func collect[T any](ctx context.Context, q Querier, query string, oid uint32, fn pgx.RowToFunc[T]) ([]T, error) {
+	rows, err := q.Query(ctx, query, oid)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, fn)
+}
+
+var sequenceDefault = regexp.MustCompile(`^nextval\('[^']+'::regclass\)$`)
+
+// serialTypes are the pseudo-types of integer columns that take their value
+// from a sequence.
+var serialTypes = map[string]string{
+	"smallint": "smallserial",
+	"integer":  "serial",
+	"bigint":   "bigserial",
+}
+
+// Statements renders the table as the statements a migration would create it
+// with: its enum types and domains, a CREATE TABLE and its indexes. Integer columns
+// filled from a sequence or generated as identity become serial columns.
+// Foreign keys over more than one column are left out, since the catalog
+// records a foreign key per column.
+func (t *Table) Statements() []string {
+	var statements []string
+	for _, enum := range t.Enums {
+		statements = append(statements, enum.Statement())
+	}
+	for _, domain := range t.Domains {
+		statements = append(statements, domain.Statement())
+	}
+	return append(statements, t.Definition()...)
+}
+
+// Definition renders the CREATE TABLE and CREATE INDEX statements of
+// Statements, without the enum types and domains.
+func (t *Table) Definition() []string {
+	if t.IsView() {
+		return t.viewDefinition()
+	}
+
+	var definitions []string
+	for _, column := range t.Columns {
+		definition := column.Name + " " + column.Type
+		serial, isInteger := serialTypes[column.Type]
+		switch {
+		case isInteger && (column.Identity || sequenceDefault.MatchString(column.Default)):
+			definition = column.Name + " " + serial
+		case column.Default != "":
+			definition += " DEFAULT " + column.Default
+		}
+		if column.NotNull {
+			definition += " NOT NULL"
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, constraint := range t.Constraints {
+		switch {
+		case constraint.Kind == "p":
+			definitions = append(definitions, constraint.Definition)
+		case constraint.Kind == "f" && constraint.Columns != 1:
+		default:
+			definitions = append(definitions, "CONSTRAINT "+constraint.Name+" "+constraint.Definition)
+		}
+	}
+	statements := []string{fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", t.Name, strings.Join(definitions, ",\n    "))}
+
+	// The table is created without its schema, in the catalog's default one,
+	// so its indexes name it the same way.
+	for _, index := range t.Indexes {
+		for _, on := range []string{" ON ", " ON ONLY "} {
+			index = strings.Replace(index, on+t.Schema+".", on, 1)
+		}
+		statements = append(statements, index)
+	}
+
+	return statements
+}
+
+// IsView reports whether the table is a view or a materialized view.
+func (t *Table) IsView() bool {
+	return t.Kind == "v" || t.Kind == "m"
+}
+
+// viewDefinition renders a view as a CREATE VIEW that selects a typed NULL
+// for each column, which is all the catalog needs to know about it.
+// Postgres does not record whether view columns can be NULL, so all of
+// them are nullable.
+func (t *Table) viewDefinition() []string {
+	columns := make([]string, len(t.Columns))
+	for i, column := range t.Columns {
+		columns[i] = fmt.Sprintf("NULL::%s AS %s", column.Type, column.Name)
+	}
+	kind := "VIEW"
+	if t.Kind == "m" {
+		kind = "MATERIALIZED VIEW"
+	}
+	return []string{fmt.Sprintf("CREATE %s %s AS SELECT %s", kind, t.Name, strings.Join(columns, ", "))}
+}
+
+// Statement renders the CREATE TYPE statement of the enum.
+func (e Enum) Statement() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = quoteLiteral(value)
+	}
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", e.Name, strings.Join(values, ", "))
+}
+
+// Statement renders the CREATE DOMAIN statement of the domain, without its
+// CHECK constraints.
+func (d Domain) Statement() string {
+	statement := fmt.Sprintf("CREATE DOMAIN %s AS %s", d.Name, d.BaseType)
+	if d.NotNull {
+		statement += " NOT NULL"
+	}
+	return statement
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,133 @@
+package introspect
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+)
+
+func customersTable() *Table {
+	return &Table{
+		Schema: "public",
+		Name:   "customers",
+		Columns: []Column{
+			{Name: "id", Type: "bigint", NotNull: true, Default: "nextval('customers_id_seq'::regclass)"},
+			{Name: "email", Type: "character varying(255)", NotNull: true},
+			{Name: "status", Type: "customer_status", NotNull: true, Default: "'active'::customer_status"},
+			{Name: "account_id", Type: "integer"},
+			{Name: "region", Type: "text"},
+			{Name: "number", Type: "integer", NotNull: true, Identity: true},
+			{Name: "contact", Type: "email_address"},
+		},
+		Constraints: []Constraint{
+			{Name: "customers_score_check", Kind: "c", Columns: 1, Definition: "CHECK ((number > 0))"},
+			{Name: "customers_account_id_fkey", Kind: "f", Columns: 1, Definition: "FOREIGN KEY (account_id) REFERENCES accounts(id)"},
+			{Name: "customers_region_fkey", Kind: "f", Columns: 2, Definition: "FOREIGN KEY (account_id, region) REFERENCES regions(account_id, code)"},
+			{Name: "customers_pkey", Kind: "p", Columns: 1, Definition: "PRIMARY KEY (id)"},
+		},
+		Indexes: []string{
+			"CREATE UNIQUE INDEX customers_email_key ON public.customers USING btree (email)",
+			"CREATE INDEX customers_region_idx ON ONLY public.customers USING btree (region)",
+		},
+		Enums:   []Enum{{Name: "customer_status", Values: []string{"active", "on 'hold'"}}},
+		Domains: []Domain{{Name: "email_address", BaseType: "citext", NotNull: true}},
+	}
+}
+
+func TestTableStatements(t *testing.T) {
+	want := []string{
+		"CREATE TYPE customer_status AS ENUM ('active', 'on ''hold''')",
+		"CREATE DOMAIN email_address AS citext NOT NULL",
+		`CREATE TABLE customers (
+    id bigserial NOT NULL,
+    email character varying(255) NOT NULL,
+    status customer_status DEFAULT 'active'::customer_status NOT NULL,
+    account_id integer,
+    region text,
+    number serial NOT NULL,
+    contact email_address,
+    CONSTRAINT customers_score_check CHECK ((number > 0)),
+    CONSTRAINT customers_account_id_fkey FOREIGN KEY (account_id) REFERENCES accounts(id),
+    PRIMARY KEY (id)
+)`,
+		"CREATE UNIQUE INDEX customers_email_key ON customers USING btree (email)",
+		"CREATE INDEX customers_region_idx ON ONLY customers USING btree (region)",
+	}
+
+	if got := customersTable().Statements(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Statements() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestTableStatementsBuildCatalog(t *testing.T) {
+	cat := catalog.NewCatalog("public")
+	for _, stmt := range customersTable().Statements() {
+		if err := ddl.ApplyDDL(cat, stmt, "database", "postgresql"); err != nil {
+			t.Fatalf("apply %q: %v", stmt, err)
+		}
+	}
+
+	table, err := cat.GetTable("", "customers")
+	if err != nil {
+		t.Fatalf("get table: %v", err)
+	}
+	id, err := table.GetColumn("id")
+	if err != nil || !id.IsPrimaryKey || !id.IsAutoIncrement {
+		t.Fatalf("expected id to be a serial primary key, got %+v (%v)", id, err)
+	}
+	contact, err := table.GetColumn("contact")
+	if err != nil || contact.DataType != "citext" || contact.Domain != "email_address" || contact.IsNullable {
+		t.Fatalf("expected contact to be a NOT NULL citext of domain email_address, got %+v (%v)", contact, err)
+	}
+	account, err := table.GetColumn("account_id")
+	if err != nil || account.ForeignKey == nil || account.ForeignKey.ReferencedTable != "accounts" {
+		t.Fatalf("expected account_id to reference accounts, got %+v (%v)", account, err)
+	}
+	if len(table.Indexes) != 2 || !table.Indexes[0].IsUnique || !reflect.DeepEqual(table.Indexes[0].Columns, []string{"email"}) {
+		t.Fatalf("expected a unique email index, got %+v", table.Indexes)
+	}
+	if _, err := cat.GetEnum("", "customer_status"); err != nil {
+		t.Fatalf("expected the customer_status enum: %v", err)
+	}
+}
+
+func TestViewStatementsBuildCatalog(t *testing.T) {
+	view := &Table{
+		Schema: "public",
+		Name:   "report_rows",
+		Kind:   "m",
+		Columns: []Column{
+			{Name: "user_id", Type: "uuid"},
+			{Name: "revenue", Type: "numeric(12,2)"},
+			{Name: "tags", Type: "text[]"},
+		},
+	}
+
+	want := []string{"CREATE MATERIALIZED VIEW report_rows AS SELECT NULL::uuid AS user_id, NULL::numeric(12,2) AS revenue, NULL::text[] AS tags"}
+	if got := view.Statements(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Statements() =\n%q\nwant\n%q", got, want)
+	}
+
+	cat := catalog.NewCatalog("public")
+	for _, stmt := range view.Statements() {
+		if err := ddl.ApplyDDL(cat, stmt, "database", "postgresql"); err != nil {
+			t.Fatalf("apply %q: %v", stmt, err)
+		}
+	}
+	got, err := cat.GetView("", "report_rows")
+	if err != nil {
+		t.Fatalf("get view: %v", err)
+	}
+	if !got.Materialized || len(got.Columns) != 3 {
+		t.Fatalf("view = %+v", got)
+	}
+	revenue := got.Columns[1]
+	if revenue.Name != "revenue" || revenue.DataType != "numeric" || revenue.Precision == nil || *revenue.Precision != 12 || !revenue.IsNullable {
+		t.Fatalf("revenue = %+v", revenue)
+	}
+	if tags := got.Columns[2]; tags.DataType != "text[]" {
+		t.Fatalf("tags = %+v", tags)
+	}
+}
@@ -0,0 +1,512 @@
+// Package schemadiff compares the default schemas of two catalogs and
+// renders the statements that change the first into the second.
+package schemadiff
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+// Diff is a migration between two catalogs. Up turns the first catalog into
+// the second and Down turns it back. Notes describe differences that are not
+// written as statements and have to be migrated by hand.
+type Diff struct {
+	Up    []string
+	Down  []string
+	Notes []string
+}
+
+// Empty reports whether the catalogs have no differences.
+func (d Diff) Empty() bool {
+	return len(d.Up) == 0 && len(d.Notes) == 0
+}
+
+// step is a change and its reversal. Down applies the steps in reverse.
+type step struct {
+	up   []string
+	down []string
+}
+
+type differ struct {
+	steps []step
+	notes []string
+}
+
+func (d *differ) add(up, down []string) {
+	d.steps = append(d.steps, step{up: up, down: down})
+}
+
+func (d *differ) note(format string, args ...any) {
+	d.notes = append(d.notes, fmt.Sprintf(format, args...))
+}
+
+// Compare diffs the tables and enum types of the default schemas. Columns
+// are compared by type, nullability and default; indexes by name, columns
+// and uniqueness. Check constraints are not compared, and changed foreign
+// keys or primary keys of existing tables are reported as notes.
+func Compare(from, to *catalog.Catalog) Diff {
+	fromSchema := from.Schemas[from.DefaultSchema]
+	toSchema := to.Schemas[to.DefaultSchema]
+	var d differ
+
+	for _, name := range sortedKeys(toSchema.Enums) {
+		enum := toSchema.Enums[name]
+		previous, ok := fromSchema.Enums[name]
+		if !ok {
+			d.add([]string{createEnum(enum)}, []string{"DROP TYPE " + name})
+			continue
+		}
+		d.compareEnum(previous, enum)
+	}
+
+	var created, dropped []*catalog.Table
+	for _, name := range sortedKeys(toSchema.Tables) {
+		if _, ok := fromSchema.Tables[name]; !ok {
+			created = append(created, toSchema.Tables[name])
+		}
+	}
+	for _, table := range orderByReferences(created) {
+		d.add(createTable(table), []string{"DROP TABLE " + table.Name})
+	}
+
+	for _, name := range sortedKeys(toSchema.Tables) {
+		if previous, ok := fromSchema.Tables[name]; ok {
+			d.compareTable(previous, toSchema.Tables[name])
+		}
+	}
+
+	for _, name := range sortedKeys(fromSchema.Tables) {
+		if _, ok := toSchema.Tables[name]; !ok {
+			dropped = append(dropped, fromSchema.Tables[name])
+		}
+	}
+	dropped = orderByReferences(dropped)
+	slices.Reverse(dropped)
+	for _, table := range dropped {
+		d.add([]string{"DROP TABLE " + table.Name}, createTable(table))
+	}
+
+	for _, name := range sortedKeys(fromSchema.Enums) {
+		if _, ok := toSchema.Enums[name]; !ok {
+			enum := fromSchema.Enums[name]
+			d.add([]string{"DROP TYPE " + name}, []string{createEnum(enum)})
+		}
+	}
+
+	diff := Diff{Notes: d.notes}
+	for _, s := range d.steps {
+		diff.Up = append(diff.Up, s.up...)
+	}
+	for _, step := range slices.Backward(d.steps) {
+		diff.Down = append(diff.Down, step.down...)
+	}
+	return diff
+}
+
+// compareEnum adds the new values of an enum type. Postgres cannot drop
+// enum values, so removed values and the values added on the way down are
+// left as notes.
+func (d *differ) compareEnum(from, to *catalog.Enum) {
+	for _, value := range to.Values {
+		if !slices.Contains(from.Values, value) {
+			d.add([]string{fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", to.Name, quoteLiteral(value))}, nil)
+			d.note("%s: value %s is added but not removed on the way down", to.Name, quoteLiteral(value))
+		}
+	}
+	for _, value := range from.Values {
+		if !slices.Contains(to.Values, value) {
+			d.note("%s: value %s is dropped, but Postgres cannot drop enum values", to.Name, quoteLiteral(value))
+		}
+	}
+}
+
+func (d *differ) compareTable(from, to *catalog.Table) {
+	fromIndexes := indexes(from)
+	toIndexes := indexes(to)
+
+	for _, index := range fromIndexes {
+		if other := findIndex(toIndexes, index.Name); other == nil || !sameIndex(index, other) {
+			if index.Partial {
+				d.note("%s: partial index %s is dropped; its definition is not known", from.Name, index.Name)
+				d.add([]string{"DROP INDEX " + index.Name}, nil)
+				continue
+			}
+			// Dropping a column drops its indexes and unique constraint,
+			// which DROP INDEX cannot remove.
+			if slices.ContainsFunc(index.Columns, func(name string) bool {
+				_, err := to.GetColumn(name)
+				return err != nil
+			}) {
+				d.add(nil, []string{createIndex(from.Name, index)})
+				continue
+			}
+			d.add([]string{"DROP INDEX " + index.Name}, []string{createIndex(from.Name, index)})
+		}
+	}
+
+	for _, column := range to.Columns {
+		if _, err := from.GetColumn(column.Name); err != nil {
+			d.add(
+				[]string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", to.Name, columnDefinition(column, true))},
+				[]string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", to.Name, column.Name)},
+			)
+		}
+	}
+
+	for _, column := range to.Columns {
+		if previous, err := from.GetColumn(column.Name); err == nil {
+			d.compareColumn(to.Name, previous, column)
+		}
+	}
+
+	for _, column := range from.Columns {
+		if _, err := to.GetColumn(column.Name); err != nil {
+			d.add(
+				[]string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", from.Name, column.Name)},
+				[]string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", from.Name, columnDefinition(column, true))},
+			)
+		}
+	}
+
+	for _, index := range toIndexes {
+		if other := findIndex(fromIndexes, index.Name); other == nil || !sameIndex(index, other) {
+			if index.Partial {
+				d.note("%s: partial index %s is not created; write its definition by hand", to.Name, index.Name)
+				continue
+			}
+			d.add([]string{createIndex(to.Name, index)}, []string{"DROP INDEX " + index.Name})
+		}
+	}
+
+	if fromKey, toKey := primaryKey(from), primaryKey(to); !slices.Equal(fromKey, toKey) {
+		d.note("%s: primary key changed from (%s) to (%s)", to.Name, strings.Join(fromKey, ", "), strings.Join(toKey, ", "))
+	}
+}
+
+func (d *differ) compareColumn(table string, from, to *catalog.Column) {
+	alter := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s ", table, to.Name)
+
+	if fromType, toType := storageType(from), storageType(to); fromType != toType {
+		d.add(
+			[]string{fmt.Sprintf("%sTYPE %s USING %s::%s", alter, toType, to.Name, toType)},
+			[]string{fmt.Sprintf("%sTYPE %s USING %s::%s", alter, fromType, to.Name, fromType)},
+		)
+	}
+
+	if from.IsNullable != to.IsNullable {
+		setNotNull, dropNotNull := alter+"SET NOT NULL", alter+"DROP NOT NULL"
+		if to.IsNullable {
+			d.add([]string{dropNotNull}, []string{setNotNull})
+		} else {
+			d.add([]string{setNotNull}, []string{dropNotNull})
+		}
+	}
+
+	if normalizeDefault(from.DefaultVal) != normalizeDefault(to.DefaultVal) {
+		d.add([]string{setDefault(alter, to.DefaultVal)}, []string{setDefault(alter, from.DefaultVal)})
+	}
+
+	if reference(from) != reference(to) {
+		d.note("%s.%s: foreign key changed from %q to %q", table, to.Name, reference(from), reference(to))
+	}
+}
+
+func setDefault(alter string, value *string) string {
+	if value == nil {
+		return alter + "DROP DEFAULT"
+	}
+	return alter + "SET DEFAULT " + *value
+}
+
+func createEnum(enum *catalog.Enum) string {
+	values := make([]string, len(enum.Values))
+	for i, value := range enum.Values {
+		values[i] = quoteLiteral(value)
+	}
+	return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)", enum.Name, strings.Join(values, ", "))
+}
+
+// createTable renders the table and its indexes. Partial indexes are left
+// out, since the catalog does not keep their WHERE clause.
+func createTable(table *catalog.Table) []string {
+	var definitions []string
+	for _, column := range table.Columns {
+		definitions = append(definitions, columnDefinition(column, false))
+	}
+	if key := primaryKey(table); len(key) > 0 {
+		definitions = append(definitions, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(key, ", ")))
+	}
+	for _, check := range table.Checks {
+		definition := fmt.Sprintf("CHECK (%s)", check.Expression)
+		if check.Name != "" {
+			definition = "CONSTRAINT " + check.Name + " " + definition
+		}
+		definitions = append(definitions, definition)
+	}
+
+	statements := []string{fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", table.Name, strings.Join(definitions, ",\n    "))}
+	for _, index := range indexes(table) {
+		if !index.Partial {
+			statements = append(statements, createIndex(table.Name, index))
+		}
+	}
+	return statements
+}
+
+func createIndex(table string, index *catalog.Index) string {
+	unique := ""
+	if index.IsUnique {
+		unique = "UNIQUE "
+	}
+	return fmt.Sprintf("CREATE %sINDEX %s ON %s (%s)", unique, index.Name, table, strings.Join(index.Columns, ", "))
+}
+
+// columnDefinition renders a column as in CREATE TABLE. Added columns carry
+// their primary key inline, since there is no table constraint to hold it.
+func columnDefinition(column *catalog.Column, added bool) string {
+	definition := column.Name + " " + columnType(column)
+	if column.DefaultVal != nil {
+		definition += " DEFAULT " + *column.DefaultVal
+	}
+	if !column.IsNullable {
+		definition += " NOT NULL"
+	}
+	if column.Identity != "" {
+		definition += " GENERATED " + column.Identity + " AS IDENTITY"
+	}
+	if column.Generated != "" {
+		definition += " GENERATED ALWAYS AS (" + column.Generated + ") STORED"
+	}
+	if added && column.IsPrimaryKey {
+		definition += " PRIMARY KEY"
+	}
+	if ref := reference(column); ref != "" {
+		definition += " REFERENCES " + ref
+	}
+	return definition
+}
+
+func reference(column *catalog.Column) string {
+	if column.ForeignKey == nil {
+		return ""
+	}
+	ref := fmt.Sprintf("%s(%s)", column.ForeignKey.ReferencedTable, column.ForeignKey.ReferencedColumn)
+	if column.ForeignKey.OnDelete != "" {
+		ref += " ON DELETE " + column.ForeignKey.OnDelete
+	}
+	if column.ForeignKey.OnUpdate != "" {
+		ref += " ON UPDATE " + column.ForeignKey.OnUpdate
+	}
+	return ref
+}
+
+func primaryKey(table *catalog.Table) []string {
+	var key []string
+	for _, column := range table.Columns {
+		if column.IsPrimaryKey {
+			key = append(key, column.Name)
+		}
+	}
+	return key
+}
+
+// indexes returns the indexes of the table, adding the index Postgres
+// creates for a UNIQUE column so both ways of writing it compare equal.
+func indexes(table *catalog.Table) []*catalog.Index {
+	result := slices.Clone(table.Indexes)
+	for _, column := range table.Columns {
+		if !column.IsUnique || column.IsPrimaryKey {
+			continue
+		}
+		covered := slices.ContainsFunc(result, func(index *catalog.Index) bool {
+			return index.IsUnique && !index.Partial && slices.Equal(index.Columns, []string{column.Name})
+		})
+		if !covered {
+			result = append(result, &catalog.Index{
+				Name:     table.Name + "_" + column.Name + "_key",
+				Columns:  []string{column.Name},
+				IsUnique: true,
+			})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+func findIndex(indexes []*catalog.Index, name string) *catalog.Index {
+	for _, index := range indexes {
+		if index.Name == name {
+			return index
+		}
+	}
+	return nil
+}
+
+func sameIndex(a, b *catalog.Index) bool {
+	return a.IsUnique == b.IsUnique && a.Partial == b.Partial && slices.Equal(a.Columns, b.Columns)
+}
+
+// orderByReferences puts tables after the tables they reference, keeping
+// name order otherwise. Tables in a reference cycle keep name order.
+func orderByReferences(tables []*catalog.Table) []*catalog.Table {
+	pending := slices.Clone(tables)
+	var ordered []*catalog.Table
+	placed := map[string]bool{}
+	for len(pending) > 0 {
+		progress := false
+		for i := 0; i < len(pending); i++ {
+			table := pending[i]
+			ready := true
+			for _, column := range table.Columns {
+				if column.ForeignKey == nil || column.ForeignKey.ReferencedTable == table.Name {
+					continue
+				}
+				waiting := slices.ContainsFunc(pending, func(other *catalog.Table) bool {
+					return other.Name == column.ForeignKey.ReferencedTable
+				})
+				if waiting && !placed[column.ForeignKey.ReferencedTable] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, table)
+				placed[table.Name] = true
+				pending = slices.Delete(pending, i, i+1)
+				i--
+				progress = true
+			}
+		}
+		if !progress {
+			return append(ordered, pending...)
+		}
+	}
+	return ordered
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// typeAliases maps the short names of types to the names format_type uses.
+var typeAliases = map[string]string{
+	"int":         "integer",
+	"int4":        "integer",
+	"int2":        "smallint",
+	"int8":        "bigint",
+	"serial4":     "serial",
+	"serial2":     "smallserial",
+	"serial8":     "bigserial",
+	"bool":        "boolean",
+	"float":       "double precision",
+	"float8":      "double precision",
+	"float4":      "real",
+	"decimal":     "numeric",
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"varbit":      "bit varying",
+	"timestamptz": "timestamp with time zone",
+	"timestamp":   "timestamp without time zone",
+	"timetz":      "time with time zone",
+	"time":        "time without time zone",
+}
+
+var serialStorage = map[string]string{
+	"serial":      "integer",
+	"bigserial":   "bigint",
+	"smallserial": "smallint",
+}
+
+var typeModifier = regexp.MustCompile(`\s*\(([0-9, ]*)\)`)
+
+// columnType renders the column's type the way format_type does, so types
+// written with aliases or modifiers compare equal. Columns declared with a
+// domain render as the domain.
+func columnType(column *catalog.Column) string {
+	if column.Domain != "" {
+		return column.Domain
+	}
+	name := strings.ToLower(strings.Join(strings.Fields(column.DataType), " "))
+	array := column.IsArray
+	for strings.HasSuffix(name, "[]") {
+		name = strings.TrimSuffix(name, "[]")
+		array = true
+	}
+
+	modifier := ""
+	if m := typeModifier.FindStringSubmatch(name); m != nil {
+		modifier = "(" + strings.ReplaceAll(m[1], " ", "") + ")"
+		name = strings.Join(strings.Fields(typeModifier.ReplaceAllString(name, " ")), " ")
+	}
+	switch {
+	case column.Length != nil:
+		modifier = fmt.Sprintf("(%d)", *column.Length)
+	case column.Precision != nil && column.Scale != nil:
+		modifier = fmt.Sprintf("(%d,%d)", *column.Precision, *column.Scale)
+	case column.Precision != nil:
+		modifier = fmt.Sprintf("(%d)", *column.Precision)
+	}
+
+	if alias, ok := typeAliases[name]; ok {
+		name = alias
+	}
+	if modifier != "" {
+		if base, zone, ok := strings.Cut(name, " with"); ok {
+			name = base + modifier + " with" + zone
+		} else {
+			name += modifier
+		}
+	}
+	if array {
+		name += "[]"
+	}
+	return name
+}
+
+// storageType is columnType with serial types replaced by the integer type
+// they are stored as.
+func storageType(column *catalog.Column) string {
+	name := columnType(column)
+	if storage, ok := serialStorage[name]; ok {
+		return storage
+	}
+	return name
+}
+
+var (
+	typeCast       = regexp.MustCompile(`::[a-z_][a-z0-9_]*(?: (?:varying|precision|with time zone|without time zone))?(?:\([0-9, ]*\))?(?:\[\])?`)
+	quotedNumber   = regexp.MustCompile(`^'(-?[0-9]+(?:\.[0-9]+)?)'$`)
+	wrappedDefault = regexp.MustCompile(`^\((.*)\)$`)
+)
+
+// normalizeDefault strips the casts and case Postgres adds when it stores a
+// default, so 'draft' and 'draft'::text compare equal.
+func normalizeDefault(value *string) string {
+	if value == nil {
+		return ""
+	}
+	parts := strings.Split(strings.TrimSpace(*value), "'")
+	for i := 0; i < len(parts); i += 2 {
+		parts[i] = strings.ToLower(parts[i])
+	}
+	normalized := typeCast.ReplaceAllString(strings.Join(parts, "'"), "")
+	if m := wrappedDefault.FindStringSubmatch(normalized); m != nil && !strings.ContainsAny(m[1], "()") {
+		normalized = m[1]
+	}
+	return quotedNumber.ReplaceAllString(normalized, "$1")
+}
@@ -0,0 +1,179 @@
+package schemadiff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+)
+
+func build(t *testing.T, statements ...string) *catalog.Catalog {
+	t.Helper()
+	cat := catalog.NewCatalog("public")
+	for _, stmt := range statements {
+		if err := ddl.ApplyDDL(cat, stmt, "test", "postgresql"); err != nil {
+			t.Fatalf("apply %q: %v", stmt, err)
+		}
+	}
+	return cat
+}
+
+var migrated = []string{
+	"CREATE TYPE order_status AS ENUM ('open', 'paid')",
+	"CREATE TYPE legacy_kind AS ENUM ('a', 'b')",
+	`CREATE TABLE customers (
+		id uuid PRIMARY KEY,
+		email varchar(255) NOT NULL UNIQUE,
+		nickname text,
+		created_at timestamptz NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE orders (
+		id serial PRIMARY KEY,
+		customer_id uuid NOT NULL REFERENCES customers(id),
+		status order_status NOT NULL DEFAULT 'open',
+		total numeric(10,2) NOT NULL,
+		note text
+	)`,
+	"CREATE INDEX orders_status_idx ON orders (status)",
+	"CREATE TABLE audit_entries (id bigserial PRIMARY KEY, message text NOT NULL)",
+}
+
+// database is the migrated schema as it reads back from pg_catalog, with
+// hand-made changes on top.
+var database = []string{
+	"CREATE TYPE order_status AS ENUM ('open', 'paid', 'refunded')",
+	`CREATE TABLE customers (
+		id uuid NOT NULL,
+		email character varying(255) NOT NULL,
+		nickname character varying(40),
+		created_at timestamp with time zone DEFAULT now() NOT NULL,
+		PRIMARY KEY (id)
+	)`,
+	"CREATE UNIQUE INDEX customers_email_key ON customers USING btree (email)",
+	`CREATE TABLE orders (
+		id serial NOT NULL,
+		customer_id uuid NOT NULL,
+		status order_status DEFAULT 'paid'::order_status NOT NULL,
+		total numeric(10,2),
+		shipped_at timestamp with time zone,
+		CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES customers(id),
+		PRIMARY KEY (id)
+	)`,
+	"CREATE INDEX orders_customer_id_idx ON orders USING btree (customer_id)",
+	`CREATE TABLE refunds (
+		id bigserial NOT NULL,
+		order_id integer NOT NULL,
+		amount numeric(10,2) NOT NULL,
+		CONSTRAINT refunds_order_id_fkey FOREIGN KEY (order_id) REFERENCES orders(id),
+		PRIMARY KEY (id)
+	)`,
+}
+
+func TestCompare(t *testing.T) {
+	diff := Compare(build(t, migrated...), build(t, database...))
+
+	wantUp := []string{
+		"ALTER TYPE order_status ADD VALUE 'refunded'",
+		"CREATE TABLE refunds (\n    id bigserial NOT NULL,\n    order_id integer NOT NULL REFERENCES orders(id),\n    amount numeric(10,2) NOT NULL,\n    PRIMARY KEY (id)\n)",
+		"ALTER TABLE customers ALTER COLUMN nickname TYPE character varying(40) USING nickname::character varying(40)",
+		"DROP INDEX orders_status_idx",
+		"ALTER TABLE orders ADD COLUMN shipped_at timestamp with time zone",
+		"ALTER TABLE orders ALTER COLUMN status SET DEFAULT 'paid'::order_status",
+		"ALTER TABLE orders ALTER COLUMN total DROP NOT NULL",
+		"ALTER TABLE orders DROP COLUMN note",
+		"CREATE INDEX orders_customer_id_idx ON orders (customer_id)",
+		"DROP TABLE audit_entries",
+		"DROP TYPE legacy_kind",
+	}
+	if !reflect.DeepEqual(diff.Up, wantUp) {
+		t.Fatalf("Up =\n%q\nwant\n%q", diff.Up, wantUp)
+	}
+
+	wantNotes := []string{"order_status: value 'refunded' is added but not removed on the way down"}
+	if !reflect.DeepEqual(diff.Notes, wantNotes) {
+		t.Fatalf("Notes = %q, want %q", diff.Notes, wantNotes)
+	}
+}
+
+func TestCompareRoundTrip(t *testing.T) {
+	diff := Compare(build(t, migrated...), build(t, database...))
+
+	up := build(t, append(append([]string{}, migrated...), diff.Up...)...)
+	if again := Compare(up, build(t, database...)); len(again.Up) != 0 {
+		t.Fatalf("applying Up left differences: %q", again.Up)
+	}
+
+	down := build(t, append(append([]string{}, database...), diff.Down...)...)
+	again := Compare(build(t, migrated...), down)
+	// The enum value added on the way up stays.
+	if want := []string{"ALTER TYPE order_status ADD VALUE 'refunded'"}; !reflect.DeepEqual(again.Up, want) {
+		t.Fatalf("applying Down left differences: %q", again.Up)
+	}
+}
+
+func TestCompareNormalizesTypesAndDefaults(t *testing.T) {
+	from := build(t, `CREATE TABLE events (
+		id int PRIMARY KEY,
+		name varchar(80) NOT NULL DEFAULT 'untitled',
+		score decimal(5,2) DEFAULT -1,
+		tags text[] DEFAULT '{}',
+		happened_at timestamp DEFAULT NOW()
+	)`)
+	to := build(t, `CREATE TABLE events (
+		id integer NOT NULL,
+		name character varying(80) DEFAULT 'untitled'::character varying NOT NULL,
+		score numeric(5,2) DEFAULT '-1'::integer,
+		tags text[] DEFAULT '{}'::text[],
+		happened_at timestamp without time zone DEFAULT now(),
+		PRIMARY KEY (id)
+	)`)
+
+	if diff := Compare(from, to); !diff.Empty() {
+		t.Fatalf("expected no differences, got %q %q", diff.Up, diff.Notes)
+	}
+}
+
+func TestCompareKeepsDomains(t *testing.T) {
+	from := build(t,
+		"CREATE DOMAIN email AS citext NOT NULL",
+		"CREATE TABLE users (id int PRIMARY KEY, email email)",
+	)
+	to := build(t,
+		"CREATE DOMAIN email AS citext NOT NULL",
+		"CREATE TABLE users (id integer NOT NULL, email email, backup email, PRIMARY KEY (id))",
+	)
+
+	diff := Compare(from, to)
+	if want := []string{"ALTER TABLE users ADD COLUMN backup email NOT NULL"}; !reflect.DeepEqual(diff.Up, want) {
+		t.Fatalf("Up = %q, want %q", diff.Up, want)
+	}
+}
+
+func TestCompareDroppedColumnTakesItsIndexes(t *testing.T) {
+	from := build(t,
+		"CREATE TABLE accounts (id int PRIMARY KEY, handle text UNIQUE, region text)",
+		"CREATE INDEX accounts_region_idx ON accounts (region)",
+	)
+	to := build(t, "CREATE TABLE accounts (id int PRIMARY KEY)")
+
+	diff := Compare(from, to)
+
+	wantUp := []string{
+		"ALTER TABLE accounts DROP COLUMN handle",
+		"ALTER TABLE accounts DROP COLUMN region",
+	}
+	if !reflect.DeepEqual(diff.Up, wantUp) {
+		t.Fatalf("Up = %q, want %q", diff.Up, wantUp)
+	}
+
+	wantDown := []string{
+		"ALTER TABLE accounts ADD COLUMN region text",
+		"ALTER TABLE accounts ADD COLUMN handle text",
+		"CREATE INDEX accounts_region_idx ON accounts (region)",
+		"CREATE UNIQUE INDEX accounts_handle_key ON accounts (handle)",
+	}
+	if !reflect.DeepEqual(diff.Down, wantDown) {
+		t.Fatalf("Down = %q, want %q", diff.Down, wantDown)
+	}
+}
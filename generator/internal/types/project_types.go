@@ -0,0 +1,137 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TypesFileName is the project file that declares custom database type to
+// Go type mappings.
+const TypesFileName = "andurel.types.yaml"
+
+type typesFile struct {
+	Types []struct {
+		DBType  string `yaml:"db_type"`
+		GoType  string `yaml:"go_type"`
+		Package string `yaml:"package"`
+		Parse   string `yaml:"parse"`
+		Format  string `yaml:"format"`
+		Factory string `yaml:"factory"`
+	} `yaml:"types"`
+}
+
+// LoadTypeOverrides reads the type mappings declared in andurel.types.yaml
+// in rootDir. A project without the file has no mappings.
+func LoadTypeOverrides(rootDir string) ([]TypeOverride, error) {
+	path := filepath.Join(rootDir, TypesFileName)
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file typesFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", TypesFileName, err)
+	}
+
+	overrides := make([]TypeOverride, 0, len(file.Types))
+	seen := make(map[string]bool, len(file.Types))
+	for i, entry := range file.Types {
+		override := TypeOverride{
+			DatabaseType: normalizeSQLType(entry.DBType),
+			GoType:       strings.TrimSpace(entry.GoType),
+			Package:      strings.TrimSpace(entry.Package),
+			Parse:        strings.TrimSpace(entry.Parse),
+			Format:       strings.TrimSpace(entry.Format),
+			Factory:      strings.TrimSpace(entry.Factory),
+		}
+		if err := validateTypeOverride(override); err != nil {
+			return nil, fmt.Errorf("%s: types[%d]: %w", TypesFileName, i, err)
+		}
+		if seen[override.DatabaseType] {
+			return nil, fmt.Errorf("%s: types[%d]: db_type %q is mapped more than once", TypesFileName, i, override.DatabaseType)
+		}
+		seen[override.DatabaseType] = true
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func validateTypeOverride(override TypeOverride) error {
+	if override.DatabaseType == "" {
+		return errors.New("db_type is required")
+	}
+	if override.GoType == "" {
+		return errors.New("go_type is required")
+	}
+
+	qualifier, name, qualified := strings.Cut(override.GoType, ".")
+	if !qualified {
+		name = qualifier
+	}
+	if (qualified && !token.IsIdentifier(qualifier)) || !token.IsIdentifier(name) {
+		return fmt.Errorf("go_type %q must be a type name, optionally qualified by its package", override.GoType)
+	}
+	if qualified && override.Package == "" {
+		return fmt.Errorf("go_type %q needs the import path of %s in package", override.GoType, qualifier)
+	}
+	if !qualified && override.Package != "" {
+		return fmt.Errorf("go_type %q must be qualified by the package it is imported from", override.GoType)
+	}
+
+	for key, expr := range map[string]string{"parse": override.Parse, "format": override.Format} {
+		if expr != "" && !strings.Contains(expr, "%s") {
+			return fmt.Errorf("%s %q must use %%s for the value", key, expr)
+		}
+	}
+	return nil
+}
+
+// IsNamed reports whether o maps to a type the generators have no built-in
+// handling for: one declared in the models package or qualified by another
+// package. Mappings to builtins such as string are handled like any other
+// column of that type.
+func (o TypeOverride) IsNamed() bool {
+	return strings.Contains(o.GoType, ".") || token.IsExported(o.GoType)
+}
+
+// QualifiedGoType returns the Go type of o as referenced from outside the
+// models package.
+func (o TypeOverride) QualifiedGoType() string {
+	if strings.Contains(o.GoType, ".") {
+		return o.GoType
+	}
+	return "models." + o.GoType
+}
+
+// LoadProjectTypes loads the mappings in andurel.types.yaml in rootDir into
+// tm, replacing the ones loaded before.
+func (tm *TypeMapper) LoadProjectTypes(rootDir string) error {
+	overrides, err := LoadTypeOverrides(rootDir)
+	if err != nil {
+		return err
+	}
+	tm.ProjectTypes = overrides
+	return nil
+}
+
+// ProjectType returns the andurel.types.yaml mapping of sqlType to a named
+// Go type, if there is one.
+func (tm *TypeMapper) ProjectType(sqlType string) (TypeOverride, bool) {
+	normalized := normalizeSQLType(sqlType)
+	for _, override := range tm.ProjectTypes {
+		if override.DatabaseType == normalized && override.IsNamed() {
+			return override, true
+		}
+	}
+	return TypeOverride{}, false
+}
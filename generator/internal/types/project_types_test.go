@@ -0,0 +1,119 @@
+package types
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTypesFile(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, TypesFileName), []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", TypesFileName, err)
+	}
+	return dir
+}
+
+func TestLoadTypeOverrides(t *testing.T) {
+	dir := writeTypesFile(t, `types:
+  - db_type: INET
+    go_type: netip.Addr
+    package: net/netip
+    parse: netip.ParseAddr(%s)
+    format: "%s.String()"
+    factory: netip.MustParseAddr("127.0.0.1")
+  - db_type: citext
+    go_type: Email
+`)
+
+	overrides, err := LoadTypeOverrides(dir)
+	if err != nil {
+		t.Fatalf("LoadTypeOverrides: %v", err)
+	}
+	want := []TypeOverride{
+		{
+			DatabaseType: "inet",
+			GoType:       "netip.Addr",
+			Package:      "net/netip",
+			Parse:        "netip.ParseAddr(%s)",
+			Format:       "%s.String()",
+			Factory:      `netip.MustParseAddr("127.0.0.1")`,
+		},
+		{DatabaseType: "citext", GoType: "Email"},
+	}
+	if len(overrides) != len(want) {
+		t.Fatalf("LoadTypeOverrides = %+v, want %+v", overrides, want)
+	}
+	for i := range want {
+		if overrides[i] != want[i] {
+			t.Errorf("override %d = %+v, want %+v", i, overrides[i], want[i])
+		}
+	}
+}
+
+func TestLoadTypeOverrides_MissingFile(t *testing.T) {
+	overrides, err := LoadTypeOverrides(t.TempDir())
+	if err != nil || overrides != nil {
+		t.Fatalf("LoadTypeOverrides without file = %v, %v, want nil, nil", overrides, err)
+	}
+}
+
+func TestLoadTypeOverrides_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries string
+		wantErr string
+	}{
+		{"missing db type", "  - go_type: string\n", "db_type is required"},
+		{"missing go type", "  - db_type: inet\n", "go_type is required"},
+		{"not a type name", "  - db_type: inet\n    go_type: \"[]byte\"\n", "must be a type name"},
+		{"qualified without package", "  - db_type: inet\n    go_type: netip.Addr\n", "needs the import path of netip"},
+		{"package without qualifier", "  - db_type: inet\n    go_type: Addr\n    package: net/netip\n", "must be qualified"},
+		{"parse without value", "  - db_type: citext\n    go_type: Email\n    parse: ParseEmail()\n", "must use %s"},
+		{"duplicate", "  - db_type: citext\n    go_type: Email\n  - db_type: CITEXT\n    go_type: string\n", "mapped more than once"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTypeOverrides(writeTypesFile(t, "types:\n"+tt.entries))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("LoadTypeOverrides error = %v, want %q", err, tt.wantErr)
+			}
+			if !strings.HasPrefix(err.Error(), TypesFileName+": types[") {
+				t.Errorf("error %q does not point at the entry", err)
+			}
+		})
+	}
+}
+
+func TestMapSQLTypeToGo_ProjectTypes(t *testing.T) {
+	tm := NewTypeMapper("postgresql")
+	tm.NullType = NullTypeSQL
+	tm.ProjectTypes = []TypeOverride{
+		{DatabaseType: "inet", GoType: "netip.Addr", Package: "net/netip"},
+		{DatabaseType: "citext", GoType: "Email"},
+		{DatabaseType: "ltree", GoType: "string"},
+	}
+
+	goType, pkg, err := tm.MapSQLTypeToGo("INET", false)
+	if err != nil || goType != "netip.Addr" || pkg != "net/netip" {
+		t.Fatalf("MapSQLTypeToGo(INET) = %q, %q, %v", goType, pkg, err)
+	}
+	goType, _, err = tm.MapSQLTypeToGo("citext", true)
+	if err != nil || goType != "*Email" {
+		t.Fatalf("MapSQLTypeToGo(citext, nullable) = %q, %v", goType, err)
+	}
+	goType, _, err = tm.MapSQLTypeToGo("ltree", true)
+	if err != nil || goType != "sql.NullString" {
+		t.Fatalf("MapSQLTypeToGo(ltree, nullable) = %q, %v", goType, err)
+	}
+
+	if custom, ok := tm.ProjectType("citext"); !ok || custom.QualifiedGoType() != "models.Email" {
+		t.Errorf("ProjectType(citext) = %+v, %v", custom, ok)
+	}
+	if _, ok := tm.ProjectType("ltree"); ok {
+		t.Error("ProjectType(ltree) reported a named type for a builtin mapping")
+	}
+}
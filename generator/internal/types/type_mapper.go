@@ -1,9 +1,14 @@
 package types
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
 	"strings"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/pkg/naming"
 )
 
 // TypeOverride lets users map a SQL database type to a custom Go type.
@@ -13,24 +18,108 @@ type TypeOverride struct {
 	DatabaseType string
 	GoType       string
 	Package      string
+	Parse        string // turns the form value %s into (GoType, error)
+	Format       string // turns the value %s into a string for views
+	Factory      string // default value in generated factories
 }
 
 // TypeMapper represents type mapper.
 type TypeMapper struct {
 	DatabaseType string
 	NullType     string // "pointer", "sql.Null", or "bun.Null"
+	DecimalType  string // "float64" (default) or "decimal"
 	Overrides    []TypeOverride
+	ProjectTypes []TypeOverride // from andurel.types.yaml
 }
 
+// DecimalGoType and DecimalPackage are the Go type and import path of
+// numeric columns when DecimalType is "decimal".
+const (
+	DecimalGoType  = "decimal.Decimal"
+	DecimalPackage = "github.com/shopspring/decimal"
+)
+
 // NewTypeMapper creates a new type mapper.
 func NewTypeMapper(databaseType string) *TypeMapper {
 	return &TypeMapper{
 		DatabaseType: databaseType,
-		NullType:     "sql.Null",
+		NullType:     NullTypeSQL,
 		Overrides:    make([]TypeOverride, 0),
 	}
 }
 
+// Null type strategies for TypeMapper.NullType.
+const (
+	NullTypePointer = "pointer"
+	NullTypeSQL     = "sql.Null"
+	NullTypeBun     = "bun.Null"
+)
+
+// DetectNullType returns the null type strategy that produced the given
+// entity field types, or "" when none of them gives it away. sql.Null and
+// bun.Null wrappers name their strategy; a pointer to a type that has a
+// sql.Null wrapper, such as *string, only comes from NullTypePointer.
+func DetectNullType(fieldTypes []string) string {
+	pointer := false
+	for _, goType := range fieldTypes {
+		switch {
+		case strings.HasPrefix(goType, "sql.Null"):
+			return NullTypeSQL
+		case strings.HasPrefix(goType, "bun.Null"):
+			return NullTypeBun
+		case strings.HasPrefix(goType, "*"):
+			if _, ok := sqlNullTypeMap[goType[1:]]; ok {
+				pointer = true
+			}
+		}
+	}
+	if pointer {
+		return NullTypePointer
+	}
+	return ""
+}
+
+// ModelNullType returns the null type strategy of the entity struct in an
+// existing model file, so controllers, views and updates match the types the
+// model was generated with. It returns fallback when the file does not exist
+// or its fields do not give the strategy away.
+func ModelNullType(modelPath, entityName, fallback string) string {
+	src, err := os.ReadFile(modelPath)
+	if err != nil {
+		return fallback
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), modelPath, src, parser.SkipObjectResolution)
+	if err != nil {
+		return fallback
+	}
+
+	var fieldTypes []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok || typeSpec.Name.Name != entityName {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			for _, field := range structType.Fields.List {
+				fieldTypes = append(fieldTypes, string(src[field.Type.Pos()-1:field.Type.End()-1]))
+			}
+		}
+	}
+
+	if nullType := DetectNullType(fieldTypes); nullType != "" {
+		return nullType
+	}
+	return fallback
+}
+
 // sqlNullTypeMap maps base Go types to their database/sql null equivalent.
 var sqlNullTypeMap = map[string]string{
 	"string":    "sql.NullString",
@@ -55,7 +144,8 @@ var bunNullTypeMap = map[string]string{
 // MapSQLTypeToGo returns the Go type for a SQL column. Nullable columns are
 // wrapped according to tm.NullType ("pointer" → *string, "sql.Null" →
 // sql.NullString, "bun.Null" → bun.NullString). The second return value is
-// the import path required for the type, or "" if it is a builtin.
+// the import path required for the type, or "" if it is a builtin or lives
+// in the generated project, like IntervalGoType.
 func (tm *TypeMapper) MapSQLTypeToGo(
 	sqlType string,
 	nullable bool,
@@ -67,6 +157,15 @@ func (tm *TypeMapper) MapSQLTypeToGo(
 			return tm.wrapNullable(override.GoType, nullable), override.Package, nil
 		}
 	}
+	for _, override := range tm.ProjectTypes {
+		if override.DatabaseType == normalized {
+			return tm.wrapNullable(override.GoType, nullable), override.Package, nil
+		}
+	}
+
+	if elem, ok := strings.CutSuffix(normalized, "[]"); ok {
+		return tm.arrayType(elem)
+	}
 
 	base, pkg := tm.basePostgresType(normalized)
 	if base == "" {
@@ -76,6 +175,38 @@ func (tm *TypeMapper) MapSQLTypeToGo(
 	return tm.wrapNullable(base, nullable), pkg, nil
 }
 
+// MoneyGoType is the Go type of money columns, from the generated
+// internal/money package.
+const MoneyGoType = "money.Money"
+
+// IntervalGoType is the Go type of interval columns, a time.Duration from
+// the generated internal/interval package.
+const IntervalGoType = "interval.Duration"
+
+// IsMoneyColumn reports whether col holds an amount in cents: an integer or
+// numeric column whose name ends in _cents.
+func IsMoneyColumn(col *catalog.Column) bool {
+	if !strings.HasSuffix(col.Name, "_cents") || strings.HasSuffix(col.DataType, "[]") {
+		return false
+	}
+	switch normalizeSQLType(col.DataType) {
+	case "smallint", "integer", "bigint", "numeric", "decimal":
+		return true
+	}
+	return false
+}
+
+// MapColumnToGo returns the Go type for col. Money columns map to
+// money.Money instead of an integer or float64; other columns map as
+// MapSQLTypeToGo does. The package of money.Money depends on the module, so
+// it is not returned.
+func (tm *TypeMapper) MapColumnToGo(col *catalog.Column) (goType, packageName string, err error) {
+	if IsMoneyColumn(col) {
+		return tm.wrapNullable(MoneyGoType, col.IsNullable), "", nil
+	}
+	return tm.MapSQLTypeToGo(col.DataType, col.IsNullable)
+}
+
 // BuildBunTag returns the value of the `bun:"..."` struct tag for a column.
 // Only emits attributes that affect query/marshaling behavior — column name,
 // primary-key marker, and a `type:` hint where bun's default mapping would
@@ -117,11 +248,11 @@ func (tm *TypeMapper) wrapNullable(goType string, nullable bool) string {
 	}
 
 	switch tm.NullType {
-	case "sql.Null":
+	case NullTypeSQL:
 		if nt, ok := sqlNullTypeMap[goType]; ok {
 			return nt
 		}
-	case "bun.Null":
+	case NullTypeBun:
 		if nt, ok := bunNullTypeMap[goType]; ok {
 			return nt
 		}
@@ -130,21 +261,29 @@ func (tm *TypeMapper) wrapNullable(goType string, nullable bool) string {
 	return "*" + goType
 }
 
+// basePostgresType returns the Go type of a built-in type. Types qualified
+// with their schema, like extensions.citext from an extension installed in
+// its own schema, map like the unqualified type.
 func (tm *TypeMapper) basePostgresType(
 	normalized string,
 ) (goType, packageName string) {
+	if _, name, qualified := strings.Cut(normalized, "."); qualified {
+		return tm.basePostgresType(normalizeSQLType(name))
+	}
+
 	switch normalized {
 	case "uuid":
 		return "uuid.UUID", "github.com/google/uuid"
-	case "varchar", "text", "char",
+	case "varchar", "text", "char", "citext",
 		"xml", "tsvector", "tsquery",
 		"inet", "cidr", "macaddr", "macaddr8",
 		"point", "lseg", "box", "path", "polygon", "circle",
 		"int4range", "int8range", "numrange",
 		"tsrange", "tstzrange", "daterange",
-		"money", "bit", "varbit",
-		"interval":
+		"money", "bit", "varbit":
 		return "string", ""
+	case "interval":
+		return IntervalGoType, ""
 	case "bytea":
 		return "[]byte", ""
 	case "boolean":
@@ -160,6 +299,9 @@ func (tm *TypeMapper) basePostgresType(
 	case "double precision":
 		return "float64", ""
 	case "decimal", "numeric":
+		if tm.DecimalType == "decimal" {
+			return DecimalGoType, DecimalPackage
+		}
 		return "float64", ""
 	case "timestamp", "timestamp without time zone",
 		"timestamptz", "timestamp with time zone",
@@ -167,17 +309,47 @@ func (tm *TypeMapper) basePostgresType(
 		return "time.Time", "time"
 	case "json", "jsonb":
 		return "json.RawMessage", "encoding/json"
-	case "_integer":
-		return "[]int32", ""
-	case "_text":
-		return "[]string", ""
 	}
 
 	return "", ""
 }
 
+// arrayElementTypes are the Go element types an array column can map to.
+// bun encodes and scans these with the array tag.
+var arrayElementTypes = map[string]bool{
+	"string":    true,
+	"bool":      true,
+	"int16":     true,
+	"int32":     true,
+	"int64":     true,
+	"float32":   true,
+	"float64":   true,
+	"uuid.UUID": true,
+}
+
+// arrayType returns the slice type of an array column with the given
+// normalized element type. Nullable arrays are not wrapped: a nil slice is
+// stored as NULL. Element types without a slice mapping map to any.
+func (tm *TypeMapper) arrayType(elem string) (goType, packageName string, err error) {
+	base, pkg := tm.basePostgresType(elem)
+	if !arrayElementTypes[base] {
+		return "any", "", nil
+	}
+	return "[]" + base, pkg, nil
+}
+
 func normalizeSQLType(sqlType string) string {
-	normalizedType := strings.ToLower(sqlType)
+	normalizedType := strings.ToLower(strings.TrimSpace(sqlType))
+
+	// Arrays normalize their element type, so varchar(50)[] and _varchar
+	// both become varchar[]. Multidimensional arrays map like a single
+	// dimension.
+	if elem, ok := strings.CutSuffix(normalizedType, "[]"); ok {
+		return normalizeSQLType(strings.TrimRight(elem, "[]")) + "[]"
+	}
+	if elem, ok := strings.CutPrefix(normalizedType, "_"); ok {
+		return normalizeSQLType(elem) + "[]"
+	}
 
 	if idx := strings.Index(normalizedType, "("); idx != -1 {
 		normalizedType = normalizedType[:idx]
@@ -202,14 +374,14 @@ func normalizeSQLType(sqlType string) string {
 		return "boolean"
 	case "time with time zone":
 		return "timetz"
+	case "time without time zone":
+		return "time"
+	case "bit varying":
+		return "varbit"
 	case "character varying", "varying character":
 		return "varchar"
 	case "character":
 		return "char"
-	case "integer[]", "integer[][]":
-		return "_integer"
-	case "text[]":
-		return "_text"
 	case "native character", "nchar":
 		return "char"
 	case "nvarchar":
@@ -238,8 +410,7 @@ func FormatFieldName(dbColumnName string) string {
 		}
 
 		if len(part) > 0 && part != "id" {
-			builder.WriteString(strings.ToUpper(part[:1]))
-			builder.WriteString(strings.ToLower(part[1:]))
+			builder.WriteString(naming.CapitalizeWord(part))
 		}
 	}
 
@@ -277,10 +448,7 @@ func FormatCamelCase(dbColumnName string) string {
 
 	builder.WriteString(strings.ToLower(parts[0]))
 	for i := 1; i < len(parts); i++ {
-		if len(parts[i]) > 0 {
-			builder.WriteString(strings.ToUpper(parts[i][:1]))
-			builder.WriteString(strings.ToLower(parts[i][1:]))
-		}
+		builder.WriteString(naming.CapitalizeWord(parts[i]))
 	}
 	return builder.String()
 }
@@ -1,6 +1,9 @@
 package types
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
@@ -16,6 +19,8 @@ func TestMapSQLTypeToGo_NonNullableTypes(t *testing.T) {
 		{"varchar", "varchar", "string", ""},
 		{"text", "text", "string", ""},
 		{"char", "char", "string", ""},
+		{"character varying", "character varying(255)", "string", ""},
+		{"bit varying", "bit varying(8)", "string", ""},
 
 		{"uuid", "uuid", "uuid.UUID", "github.com/google/uuid"},
 
@@ -45,6 +50,7 @@ func TestMapSQLTypeToGo_NonNullableTypes(t *testing.T) {
 		{"timestamp with time zone", "timestamp with time zone", "time.Time", "time"},
 		{"date", "date", "time.Time", "time"},
 		{"time", "time", "time.Time", "time"},
+		{"time without time zone", "time without time zone", "time.Time", "time"},
 
 		{"bytea", "bytea", "[]byte", ""},
 		{"jsonb", "jsonb", "json.RawMessage", "encoding/json"},
@@ -283,3 +289,198 @@ func TestBuildBunTag(t *testing.T) {
 		})
 	}
 }
+
+func TestMapColumnToGo_MoneyColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		dataType string
+		nullable bool
+		expected string
+	}{
+		{"price_cents", "bigint", false, "money.Money"},
+		{"total_cents", "integer", false, "money.Money"},
+		{"fee_cents", "numeric(12,0)", true, "*money.Money"},
+		{"price_cents", "text", false, "string"},
+		{"price_cents", "bigint[]", false, "[]int64"},
+		{"price", "numeric", false, "float64"},
+		{"cents", "bigint", false, "int64"},
+	}
+
+	tm := NewTypeMapper("postgresql")
+	for _, tt := range tests {
+		t.Run(tt.name+" "+tt.dataType, func(t *testing.T) {
+			col := catalog.NewColumn(tt.name, tt.dataType)
+			col.IsNullable = tt.nullable
+
+			goType, _, err := tm.MapColumnToGo(col)
+			if err != nil {
+				t.Fatalf("MapColumnToGo() error = %v", err)
+			}
+			if goType != tt.expected {
+				t.Errorf("MapColumnToGo(%s %s) = %q, want %q", tt.name, tt.dataType, goType, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMapSQLTypeToGo_DecimalType(t *testing.T) {
+	tests := []struct {
+		dataType string
+		nullable bool
+		nullType string
+		expected string
+	}{
+		{"numeric", false, "sql.Null", "decimal.Decimal"},
+		{"numeric(12,2)", false, "sql.Null", "decimal.Decimal"},
+		{"decimal", true, "sql.Null", "*decimal.Decimal"},
+		{"numeric", true, "bun.Null", "*decimal.Decimal"},
+		{"numeric", true, "pointer", "*decimal.Decimal"},
+		{"double precision", false, "sql.Null", "float64"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dataType+" "+tt.nullType, func(t *testing.T) {
+			tm := NewTypeMapper("postgresql")
+			tm.NullType = tt.nullType
+			tm.DecimalType = "decimal"
+
+			goType, pkg, err := tm.MapSQLTypeToGo(tt.dataType, tt.nullable)
+			if err != nil {
+				t.Fatalf("MapSQLTypeToGo() error = %v", err)
+			}
+			if goType != tt.expected {
+				t.Errorf("MapSQLTypeToGo(%q) = %q, want %q", tt.dataType, goType, tt.expected)
+			}
+			if strings.Contains(goType, "decimal.") && pkg != DecimalPackage {
+				t.Errorf("MapSQLTypeToGo(%q) package = %q, want %q", tt.dataType, pkg, DecimalPackage)
+			}
+		})
+	}
+
+	// Money columns keep their own type.
+	tm := NewTypeMapper("postgresql")
+	tm.DecimalType = "decimal"
+	goType, _, err := tm.MapColumnToGo(catalog.NewColumn("fee_cents", "numeric"))
+	if err != nil || goType != "*money.Money" {
+		t.Fatalf("MapColumnToGo(fee_cents numeric) = %q, %v", goType, err)
+	}
+}
+
+func TestMapSQLTypeToGo_Interval(t *testing.T) {
+	tm := NewTypeMapper("postgresql")
+	tm.NullType = "sql.Null"
+
+	goType, pkg, err := tm.MapSQLTypeToGo("interval", false)
+	if err != nil || goType != IntervalGoType || pkg != "" {
+		t.Fatalf("MapSQLTypeToGo(interval) = %q, %q, %v", goType, pkg, err)
+	}
+
+	goType, _, err = tm.MapSQLTypeToGo("INTERVAL", true)
+	if err != nil || goType != "*"+IntervalGoType {
+		t.Fatalf("MapSQLTypeToGo(INTERVAL, nullable) = %q, %v", goType, err)
+	}
+}
+
+func TestMapSQLTypeToGo_Citext(t *testing.T) {
+	tm := NewTypeMapper("postgresql")
+	tm.NullType = "sql.Null"
+
+	goType, pkg, err := tm.MapSQLTypeToGo("CITEXT", false)
+	if err != nil || goType != "string" || pkg != "" {
+		t.Fatalf("MapSQLTypeToGo(CITEXT) = %q, %q, %v", goType, pkg, err)
+	}
+
+	goType, _, err = tm.MapSQLTypeToGo("citext", true)
+	if err != nil || goType != "sql.NullString" {
+		t.Fatalf("MapSQLTypeToGo(citext, nullable) = %q, %v", goType, err)
+	}
+
+	goType, _, err = tm.MapSQLTypeToGo("extensions.citext", false)
+	if err != nil || goType != "string" {
+		t.Fatalf("MapSQLTypeToGo(extensions.citext) = %q, %v", goType, err)
+	}
+}
+
+func TestMapSQLTypeToGo_Arrays(t *testing.T) {
+	tests := []struct {
+		sqlType     string
+		nullable    bool
+		expectedGo  string
+		expectedPkg string
+	}{
+		{"text[]", false, "[]string", ""},
+		{"varchar(50)[]", false, "[]string", ""},
+		{"VARCHAR[]", true, "[]string", ""},
+		{"integer[]", false, "[]int32", ""},
+		{"int4[]", true, "[]int32", ""},
+		{"integer[][]", false, "[]int32", ""},
+		{"_int4", false, "[]int32", ""},
+		{"bigint[]", false, "[]int64", ""},
+		{"smallint[]", false, "[]int16", ""},
+		{"boolean[]", false, "[]bool", ""},
+		{"double precision[]", false, "[]float64", ""},
+		{"uuid[]", true, "[]uuid.UUID", "github.com/google/uuid"},
+		{"timestamptz[]", false, "any", ""},
+		{"jsonb[]", false, "any", ""},
+	}
+
+	tm := NewTypeMapper("postgresql")
+	for _, tt := range tests {
+		t.Run(tt.sqlType, func(t *testing.T) {
+			goType, pkg, err := tm.MapSQLTypeToGo(tt.sqlType, tt.nullable)
+			if err != nil || goType != tt.expectedGo || pkg != tt.expectedPkg {
+				t.Fatalf("MapSQLTypeToGo(%s) = %q, %q, %v, want %q, %q", tt.sqlType, goType, pkg, err, tt.expectedGo, tt.expectedPkg)
+			}
+		})
+	}
+}
+
+func TestDetectNullType(t *testing.T) {
+	tests := []struct {
+		name       string
+		fieldTypes []string
+		want       string
+	}{
+		{"sql null", []string{"uuid.UUID", "sql.NullString"}, NullTypeSQL},
+		{"bun null", []string{"bun.NullTime", "string"}, NullTypeBun},
+		{"pointer", []string{"uuid.UUID", "*int32"}, NullTypePointer},
+		{"struct pointer is not a null type", []string{"*Company"}, ""},
+		{"no nullable columns", []string{"uuid.UUID", "string"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectNullType(tt.fieldTypes); got != tt.want {
+				t.Errorf("DetectNullType(%v) = %q, want %q", tt.fieldTypes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModelNullType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gadget.go")
+	source := `package models
+
+import "time"
+
+type GadgetEntity struct {
+	ID        string
+	Nickname  *string
+	UpdatedAt *time.Time
+	Company   *CompanyEntity
+}
+`
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatalf("write model: %v", err)
+	}
+
+	if got := ModelNullType(path, "GadgetEntity", NullTypeSQL); got != NullTypePointer {
+		t.Errorf("ModelNullType = %q, want %q", got, NullTypePointer)
+	}
+	if got := ModelNullType(path, "MissingEntity", NullTypeSQL); got != NullTypeSQL {
+		t.Errorf("ModelNullType for missing entity = %q, want fallback %q", got, NullTypeSQL)
+	}
+	if got := ModelNullType(filepath.Join(t.TempDir(), "nope.go"), "GadgetEntity", NullTypeBun); got != NullTypeBun {
+		t.Errorf("ModelNullType for missing file = %q, want fallback %q", got, NullTypeBun)
+	}
+}
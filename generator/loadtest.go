@@ -0,0 +1,302 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	gotypes "go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+)
+
+// LoadTestModel is a model the load test seed creates records of with its
+// factory.
+type LoadTestModel struct {
+	Name    string `json:"name"`   // Model name, e.g. "Product" for factories.CreateProduct
+	Entity  string `json:"entity"` // Entity type in the models package, e.g. "ProductEntity"
+	Table   string `json:"table"`
+	IDField string `json:"id_field,omitempty"` // Go field of the primary key, empty for composite keys
+	// Parents are the records the factory takes as arguments, in order.
+	Parents []LoadTestParent `json:"parents,omitempty"`
+}
+
+// LoadTestParent is a foreign key argument of a factory, filled with a
+// record of the referenced table.
+type LoadTestParent struct {
+	Argument string `json:"argument"` // Factory argument, e.g. "categoryID"
+	Table    string `json:"table"`    // Referenced table
+	Field    string `json:"field"`    // Go field of the referenced column
+	Pointer  bool   `json:"pointer,omitempty"`
+}
+
+// LoadTestSkip is a factory the load test seed leaves out.
+type LoadTestSkip struct {
+	Model  string `json:"model"`
+	Reason string `json:"reason"`
+}
+
+var entityTablePattern = regexp.MustCompile("type (\\w+) struct \\{\\s*bun\\.BaseModel\\s+`bun:\"table:(\\w+)")
+
+// LoadTestModels returns the models of modelsDir with a Create factory in
+// factoriesDir, ordered so that every model comes after the tables its
+// factory needs records of. Factories the seed cannot call, such as those of
+// tables no migration creates or that reference a table without a factory,
+// are returned as skipped with the reason.
+func LoadTestModels(modelsDir, factoriesDir string, migrationDirs []string) ([]LoadTestModel, []LoadTestSkip, error) {
+	cat, err := migratedCatalog(migrationDirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entityTables, err := modelEntityTables(modelsDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factories, err := parseCreateFactories(factoriesDir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var skipped []LoadTestSkip
+	candidates := make(map[string]LoadTestModel, len(factories))
+	for _, factory := range factories {
+		model, reason := loadTestModel(cat, entityTables, factory)
+		if reason != "" {
+			skipped = append(skipped, LoadTestSkip{Model: factory.name, Reason: reason})
+			continue
+		}
+		candidates[model.Table] = model
+	}
+
+	ordered, unresolved := orderLoadTestModels(candidates)
+	skipped = append(skipped, unresolved...)
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Model < skipped[j].Model })
+
+	return ordered, skipped, nil
+}
+
+// createFactory is a Create function of a factory file.
+type createFactory struct {
+	name   string // e.g. "Product"
+	entity string // e.g. "ProductEntity"
+	args   []factoryArg
+}
+
+type factoryArg struct {
+	name string
+	typ  string
+}
+
+// parseCreateFactories returns the single record Create functions of the
+// factories in dir: those taking a context and an executor, then the
+// foreign keys and options.
+func parseCreateFactories(dir string) ([]createFactory, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read factories: %w", err)
+	}
+
+	var factories []createFactory
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parse factory %s: %w", path, err)
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "Create") {
+				continue
+			}
+			if factory, ok := createFactoryFromFunc(fn); ok {
+				factories = append(factories, factory)
+			}
+		}
+	}
+
+	sort.Slice(factories, func(i, j int) bool { return factories[i].name < factories[j].name })
+	return factories, nil
+}
+
+func createFactoryFromFunc(fn *ast.FuncDecl) (createFactory, bool) {
+	if fn.Type.Results == nil || len(fn.Type.Results.List) != 2 {
+		return createFactory{}, false
+	}
+	result, ok := fn.Type.Results.List[0].Type.(*ast.SelectorExpr)
+	if !ok || gotypes.ExprString(result.X) != "models" {
+		return createFactory{}, false
+	}
+
+	var args []factoryArg
+	for _, field := range fn.Type.Params.List {
+		typ := gotypes.ExprString(field.Type)
+		for _, name := range field.Names {
+			args = append(args, factoryArg{name: name.Name, typ: typ})
+		}
+	}
+	if len(args) < 3 || args[0].typ != "context.Context" || args[1].typ != "storage.Executor" || !strings.HasPrefix(args[len(args)-1].typ, "...") {
+		return createFactory{}, false
+	}
+	args = args[2 : len(args)-1]
+	for _, arg := range args {
+		// The Create function for several records takes a count.
+		if arg.name == "count" && arg.typ == "int" {
+			return createFactory{}, false
+		}
+	}
+
+	return createFactory{
+		name:   strings.TrimPrefix(fn.Name.Name, "Create"),
+		entity: result.Sel.Name,
+		args:   args,
+	}, true
+}
+
+// modelEntityTables maps the entity types of the models in dir to their
+// tables.
+func modelEntityTables(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models: %w", err)
+	}
+
+	tables := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range entityTablePattern.FindAllSubmatch(content, -1) {
+			tables[string(match[1])] = string(match[2])
+		}
+	}
+
+	return tables, nil
+}
+
+// loadTestModel matches the arguments of factory to the foreign keys of its
+// table, or returns why the seed cannot call it.
+func loadTestModel(cat *catalog.Catalog, entityTables map[string]string, factory createFactory) (LoadTestModel, string) {
+	tableName, ok := entityTables[factory.entity]
+	if !ok {
+		return LoadTestModel{}, fmt.Sprintf("models.%s has no bun table tag", factory.entity)
+	}
+	table, err := cat.GetTable("public", tableName)
+	if err != nil {
+		return LoadTestModel{}, fmt.Sprintf("no migration creates %s", tableName)
+	}
+
+	model := LoadTestModel{Name: factory.name, Entity: factory.entity, Table: table.Name}
+	if pk := table.GetPrimaryKeyColumns(); len(pk) == 1 {
+		model.IDField = types.FormatFieldName(pk[0].Name)
+	}
+
+	for _, arg := range factory.args {
+		var column *catalog.Column
+		for _, col := range table.Columns {
+			if strings.EqualFold(types.FormatFieldName(col.Name), arg.name) {
+				column = col
+				break
+			}
+		}
+		if column == nil || column.ForeignKey == nil {
+			return LoadTestModel{}, fmt.Sprintf("argument %s is not a foreign key of %s", arg.name, table.Name)
+		}
+
+		referencedColumn := column.ForeignKey.ReferencedColumn
+		if referencedColumn == "" {
+			referencedColumn = "id"
+		}
+		parent := LoadTestParent{
+			Argument: arg.name,
+			Table:    strings.TrimPrefix(column.ForeignKey.ReferencedTable, "public."),
+			Field:    types.FormatFieldName(referencedColumn),
+		}
+		switch {
+		case strings.HasPrefix(arg.typ, "*"):
+			parent.Pointer = true
+		case strings.Contains(arg.typ, "Null"):
+			return LoadTestModel{}, fmt.Sprintf("argument %s has type %s", arg.name, arg.typ)
+		}
+		if parent.Table == table.Name {
+			return LoadTestModel{}, fmt.Sprintf("%s references itself", table.Name)
+		}
+		model.Parents = append(model.Parents, parent)
+	}
+
+	return model, ""
+}
+
+// orderLoadTestModels orders models so that each comes after its parents,
+// by table name where the order is free. Models whose parents have no
+// factory are returned as skipped.
+func orderLoadTestModels(models map[string]LoadTestModel) ([]LoadTestModel, []LoadTestSkip) {
+	tables := make([]string, 0, len(models))
+	for table := range models {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var ordered []LoadTestModel
+	created := make(map[string]bool, len(models))
+	for len(created) < len(models) {
+		progressed := false
+		for _, table := range tables {
+			if created[table] {
+				continue
+			}
+			ready := true
+			for _, parent := range models[table].Parents {
+				if !created[parent.Table] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, models[table])
+				created[table] = true
+				progressed = true
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	var skipped []LoadTestSkip
+	for _, table := range tables {
+		if created[table] {
+			continue
+		}
+		for _, parent := range models[table].Parents {
+			if !created[parent.Table] {
+				skipped = append(skipped, LoadTestSkip{
+					Model:  models[table].Name,
+					Reason: fmt.Sprintf("needs records of %s, which has no factory the seed can call", parent.Table),
+				})
+				break
+			}
+		}
+	}
+
+	return ordered, skipped
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadTestModels(t *testing.T) {
+	root := t.TempDir()
+	migrationsDir := filepath.Join(root, "database", "migrations")
+	modelsDir := filepath.Join(root, "models")
+	factoriesDir := filepath.Join(modelsDir, "factories")
+	for _, dir := range []string{migrationsDir, factoriesDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entity := func(name, table string) string {
+		return "package models\n\ntype " + name + " struct {\n\tbun.BaseModel `bun:\"table:" + table + ",alias:" + table + "\"`\n}\n"
+	}
+	factory := func(name, args string) string {
+		return "package factories\n\n" +
+			"func Create" + name + "(ctx context.Context, exec storage.Executor, " + args + "opts ..." + name + "Option) (models." + name + "Entity, error) {}\n\n" +
+			"func Create" + name + "s(ctx context.Context, exec storage.Executor, " + args + "count int, opts ..." + name + "Option) ([]models." + name + "Entity, error) {}\n"
+	}
+
+	files := map[string]string{
+		filepath.Join(migrationsDir, "20260101000000_create_shop.sql"): `-- +goose Up
+CREATE TABLE users (
+    id UUID PRIMARY KEY
+);
+CREATE TABLE products (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL
+);
+CREATE TABLE comments (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    author_id UUID REFERENCES users(id),
+    body TEXT NOT NULL
+);
+CREATE TABLE categories (
+    id UUID PRIMARY KEY,
+    parent_id UUID NOT NULL REFERENCES categories(id)
+);
+CREATE TABLE audits (
+    id SERIAL PRIMARY KEY,
+    category_id UUID NOT NULL REFERENCES categories(id)
+);
+-- +goose Down
+DROP TABLE audits;
+DROP TABLE categories;
+DROP TABLE comments;
+DROP TABLE products;
+DROP TABLE users;
+`,
+		filepath.Join(modelsDir, "user.go"):         entity("UserEntity", "users"),
+		filepath.Join(modelsDir, "product.go"):      entity("ProductEntity", "products"),
+		filepath.Join(modelsDir, "comment.go"):      entity("CommentEntity", "comments"),
+		filepath.Join(modelsDir, "category.go"):     entity("CategoryEntity", "categories"),
+		filepath.Join(modelsDir, "audit.go"):        entity("AuditEntity", "audits"),
+		filepath.Join(factoriesDir, "user.go"):      factory("User", ""),
+		filepath.Join(factoriesDir, "product.go"):   factory("Product", ""),
+		filepath.Join(factoriesDir, "comment.go"):   factory("Comment", "productID uuid.UUID, authorID *uuid.UUID, "),
+		filepath.Join(factoriesDir, "category.go"):  factory("Category", "parentID uuid.UUID, "),
+		filepath.Join(factoriesDir, "audit.go"):     factory("Audit", "categoryID uuid.UUID, "),
+		filepath.Join(factoriesDir, "factories.go"): "package factories\n\nfunc defaultPassword() string { return \"\" }\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	models, skipped, err := LoadTestModels(modelsDir, factoriesDir, []string{migrationsDir})
+	if err != nil {
+		t.Fatalf("LoadTestModels: %v", err)
+	}
+
+	want := []LoadTestModel{
+		{Name: "Product", Entity: "ProductEntity", Table: "products", IDField: "ID"},
+		{Name: "User", Entity: "UserEntity", Table: "users", IDField: "ID"},
+		{Name: "Comment", Entity: "CommentEntity", Table: "comments", IDField: "ID", Parents: []LoadTestParent{
+			{Argument: "productID", Table: "products", Field: "ID"},
+			{Argument: "authorID", Table: "users", Field: "ID", Pointer: true},
+		}},
+	}
+	if !reflect.DeepEqual(models, want) {
+		t.Fatalf("models =\n%#v\nwant\n%#v", models, want)
+	}
+
+	wantSkipped := []LoadTestSkip{
+		{Model: "Audit", Reason: "needs records of categories, which has no factory the seed can call"},
+		{Model: "Category", Reason: "categories references itself"},
+	}
+	if !reflect.DeepEqual(skipped, wantSkipped) {
+		t.Fatalf("skipped =\n%#v\nwant\n%#v", skipped, wantSkipped)
+	}
+}
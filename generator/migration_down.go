@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+	"github.com/mbvlabs/andurel/generator/internal/migrations"
+	"github.com/mbvlabs/andurel/generator/internal/schemadiff"
+)
+
+// DownMigration is the Down section synthesized for a migration that has
+// none.
+type DownMigration struct {
+	Statements []string
+	// Notes list the Up statements the Down section does not revert.
+	Notes []string
+}
+
+// Section renders the Down section in goose format, notes first as
+// comments.
+func (d *DownMigration) Section() string {
+	var b strings.Builder
+	b.WriteString("-- +goose Down\n")
+	for _, note := range d.Notes {
+		fmt.Fprintf(&b, "-- %s\n", note)
+	}
+	writeStatements(&b, d.Statements)
+	return b.String()
+}
+
+// untrackedStatement matches a statement the catalog does not track, with
+// the statement that drops what it creates.
+type untrackedStatement struct {
+	pattern *regexp.Regexp
+	inverse string
+}
+
+var (
+	renameColumnStatement = regexp.MustCompile(`(?is)^\s*alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(?:\w+\.)?(\w+)\s+rename\s+column\s+(\w+)\s+to\s+(\w+)\s*;?\s*$`)
+	renameTableStatement  = regexp.MustCompile(`(?is)^\s*alter\s+table\s+(?:if\s+exists\s+)?(?:only\s+)?(?:\w+\.)?(\w+)\s+rename\s+to\s+(\w+)\s*;?\s*$`)
+
+	// Objects that depend on tables are dropped before the tables are
+	// changed, the others after.
+	dependentStatements = []untrackedStatement{
+		{regexp.MustCompile(`(?is)^\s*create\s+(?:or\s+replace\s+)?(?:constraint\s+)?trigger\s+(\w+)\s+.*?\son\s+((?:\w+\.)?\w+)`), "DROP TRIGGER IF EXISTS $1 ON $2"},
+		{regexp.MustCompile(`(?is)^\s*create\s+materialized\s+view\s+(?:if\s+not\s+exists\s+)?((?:\w+\.)?\w+)`), "DROP MATERIALIZED VIEW IF EXISTS $1"},
+		{regexp.MustCompile(`(?is)^\s*create\s+(?:or\s+replace\s+)?(?:temp\s+|temporary\s+)?view\s+((?:\w+\.)?\w+)`), "DROP VIEW IF EXISTS $1"},
+	}
+	prerequisiteStatements = []untrackedStatement{
+		{regexp.MustCompile(`(?is)^\s*create\s+extension\s+(?:if\s+not\s+exists\s+)?"?([\w-]+)"?`), `DROP EXTENSION IF EXISTS "$1"`},
+		{regexp.MustCompile(`(?is)^\s*create\s+schema\s+(?:if\s+not\s+exists\s+)?(\w+)`), "DROP SCHEMA IF EXISTS $1"},
+		{regexp.MustCompile(`(?is)^\s*create\s+sequence\s+(?:if\s+not\s+exists\s+)?((?:\w+\.)?\w+)`), "DROP SEQUENCE IF EXISTS $1"},
+	}
+)
+
+// SynthesizeDown builds the Down section of the migration at path from the
+// schema the migrations before it build and the schema it leaves behind.
+// Created tables, columns, indexes and enum types are dropped, dropped and
+// changed ones are restored from their earlier definition, and renames are
+// reverted. Statements it cannot revert, such as data changes, are listed
+// as notes. The result is a starting point to review, not a guarantee.
+func SynthesizeDown(migrationDirs []string, path string) (*DownMigration, error) {
+	migrationsList, err := migrations.DiscoverMigrations(migrationDirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover migrations: %w", err)
+	}
+
+	target := slices.IndexFunc(migrationsList, func(m migrations.Migration) bool {
+		return filepath.Base(m.FilePath) == filepath.Base(path)
+	})
+	if target < 0 {
+		return nil, fmt.Errorf("migration %s not found", filepath.Base(path))
+	}
+
+	// before is the schema ahead of the migration with its renames applied,
+	// so the comparison below sees renamed tables and columns as unchanged.
+	before := catalog.NewCatalog("public")
+	after := catalog.NewCatalog("public")
+	for _, migration := range migrationsList[:target] {
+		for _, stmt := range migration.Statements {
+			if isSchemaStatement(stmt) {
+				_ = ddl.ApplyDDL(before, stmt, migration.FilePath, "postgresql")
+				_ = ddl.ApplyDDL(after, stmt, migration.FilePath, "postgresql")
+			}
+		}
+	}
+
+	migration := migrationsList[target]
+	down := &DownMigration{}
+	var dependents, renames, prerequisites []string
+	for _, stmt := range migration.Statements {
+		if inverse, ok := invertUntracked(stmt, dependentStatements); ok {
+			dependents = append(dependents, inverse)
+			continue
+		}
+		if inverse, ok := invertUntracked(stmt, prerequisiteStatements); ok {
+			prerequisites = append(prerequisites, inverse)
+			continue
+		}
+		if !isSchemaStatement(stmt) || !tracksStatement(stmt) {
+			down.Notes = append(down.Notes, "revert by hand: "+statementSummary(stmt))
+			continue
+		}
+		if err := ddl.ApplyDDL(after, stmt, migration.FilePath, "postgresql"); err != nil {
+			down.Notes = append(down.Notes, "revert by hand: "+statementSummary(stmt))
+			continue
+		}
+
+		if m := renameColumnStatement.FindStringSubmatch(stmt); m != nil {
+			_ = ddl.ApplyDDL(before, stmt, migration.FilePath, "postgresql")
+			renames = append(renames, fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", m[1], m[3], m[2]))
+		} else if m := renameTableStatement.FindStringSubmatch(stmt); m != nil {
+			_ = ddl.ApplyDDL(before, stmt, migration.FilePath, "postgresql")
+			renames = append(renames, fmt.Sprintf("ALTER TABLE %s RENAME TO %s", m[2], m[1]))
+		}
+	}
+
+	diff := schemadiff.Compare(after, before)
+	slices.Reverse(dependents)
+	slices.Reverse(renames)
+	slices.Reverse(prerequisites)
+
+	down.Statements = append(down.Statements, dependents...)
+	down.Statements = append(down.Statements, diff.Up...)
+	down.Statements = append(down.Statements, renames...)
+	down.Statements = append(down.Statements, prerequisites...)
+	down.Notes = append(down.Notes, diff.Notes...)
+	return down, nil
+}
+
+// tracksStatement reports whether the catalog records the changes of a
+// statement, as opposed to skipping it.
+func tracksStatement(stmt string) bool {
+	parsed, err := ddl.NewDDLParser().Parse(stmt, "", "postgresql")
+	if err != nil || parsed == nil {
+		// ApplyDDL reports the error.
+		return err != nil
+	}
+	switch parsed.GetType() {
+	case ddl.Unknown, ddl.CreateSchema, ddl.DropSchema, ddl.CreateView, ddl.DropView:
+		return false
+	}
+	return true
+}
+
+func invertUntracked(stmt string, statements []untrackedStatement) (string, bool) {
+	stmt = ddl.StripComments(stmt)
+	for _, candidate := range statements {
+		if m := candidate.pattern.FindStringSubmatchIndex(stmt); m != nil {
+			return string(candidate.pattern.ExpandString(nil, candidate.inverse, stmt, m)), true
+		}
+	}
+	return "", false
+}
+
+// statementSummary returns the first line of a statement for notes.
+func statementSummary(stmt string) string {
+	line, _, more := strings.Cut(strings.TrimSpace(ddl.StripComments(stmt)), "\n")
+	line = strings.TrimSpace(line)
+	if more {
+		line += " ..."
+	}
+	return line
+}
@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSynthesizeDown(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	write("20260101000000_create_customers.sql", `-- +goose Up
+CREATE TABLE customers (
+    id uuid PRIMARY KEY,
+    email text NOT NULL,
+    nickname varchar(40),
+    legacy_code text
+);
+
+-- +goose Down
+DROP TABLE customers;
+`)
+	path := write("20260102000000_reshape_customers.sql", `-- +goose Up
+CREATE EXTENSION IF NOT EXISTS citext;
+CREATE TYPE customer_tier AS ENUM ('free', 'pro');
+CREATE TABLE orders (
+    id uuid PRIMARY KEY,
+    customer_id uuid NOT NULL REFERENCES customers(id)
+);
+CREATE INDEX orders_customer_id_idx ON orders (customer_id);
+ALTER TABLE customers ADD COLUMN tier customer_tier NOT NULL DEFAULT 'free';
+ALTER TABLE customers DROP COLUMN legacy_code;
+ALTER TABLE customers RENAME COLUMN nickname TO display_name;
+ALTER TABLE customers RENAME TO clients;
+CREATE TRIGGER clients_touch BEFORE UPDATE ON clients FOR EACH ROW EXECUTE FUNCTION touch();
+UPDATE clients SET tier = 'pro';
+`)
+
+	down, err := SynthesizeDown([]string{dir}, path)
+	if err != nil {
+		t.Fatalf("SynthesizeDown: %v", err)
+	}
+
+	wantStatements := []string{
+		"DROP TRIGGER IF EXISTS clients_touch ON clients",
+		"ALTER TABLE clients ADD COLUMN legacy_code text",
+		"ALTER TABLE clients DROP COLUMN tier",
+		"DROP TABLE orders",
+		"DROP TYPE customer_tier",
+		"ALTER TABLE clients RENAME TO customers",
+		"ALTER TABLE customers RENAME COLUMN display_name TO nickname",
+		`DROP EXTENSION IF EXISTS "citext"`,
+	}
+	if !reflect.DeepEqual(down.Statements, wantStatements) {
+		t.Fatalf("Statements =\n%q\nwant\n%q", down.Statements, wantStatements)
+	}
+
+	wantNotes := []string{"revert by hand: UPDATE clients SET tier = 'pro';"}
+	if !reflect.DeepEqual(down.Notes, wantNotes) {
+		t.Fatalf("Notes = %q, want %q", down.Notes, wantNotes)
+	}
+
+	if _, err := SynthesizeDown([]string{dir}, filepath.Join(dir, "20260103000000_missing.sql")); err == nil {
+		t.Fatal("expected an error for a migration that does not exist")
+	}
+}
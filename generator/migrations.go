@@ -1,12 +1,14 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
 	"github.com/mbvlabs/andurel/generator/internal/ddl"
+	"github.com/mbvlabs/andurel/generator/internal/introspect"
 	"github.com/mbvlabs/andurel/generator/internal/migrations"
 )
 
@@ -30,7 +32,9 @@ func (mm *MigrationManager) BuildCatalogFromMigrations(
 	}
 
 	cat := catalog.NewCatalog("public")
-	relevantNames := collectRelevantNames(migrationsList, tableName)
+	cat.Database = config.Database.Name
+	_, unqualified := catalog.SplitQualifiedName(tableName)
+	relevantNames := collectRelevantNames(migrationsList, unqualified)
 
 	for _, migration := range migrationsList {
 		for _, stmt := range migration.Statements {
@@ -46,7 +50,10 @@ func (mm *MigrationManager) BuildCatalogFromMigrations(
 		}
 	}
 
-	if _, err := cat.GetTable(cat.DefaultSchema, tableName); err != nil {
+	if _, err := cat.GetView("", tableName); err == nil {
+		return cat, nil
+	}
+	if _, err := cat.GetTable("", tableName); err != nil {
 		return nil, fmt.Errorf(
 			"table '%s' not found in any migration. Create a migration for this table or use --table-name to specify a different table name",
 			tableName,
@@ -56,6 +63,37 @@ func (mm *MigrationManager) BuildCatalogFromMigrations(
 	return cat, nil
 }
 
+// SchemaQuerier reads a table's definition from a live database. *pgx.Conn
+// satisfies it.
+type SchemaQuerier = introspect.Querier
+
+// BuildCatalogFromDatabase builds the catalog of tableName from the table in
+// the database instead of the migrations. Its definition is read from
+// pg_catalog and applied as DDL, so columns map to the same types.
+func (mm *MigrationManager) BuildCatalogFromDatabase(
+	ctx context.Context,
+	db SchemaQuerier,
+	tableName string,
+	config *UnifiedConfig,
+) (*catalog.Catalog, error) {
+	table, err := introspect.ReadTable(ctx, db, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	// The table is created unqualified, so the catalog's default schema is
+	// the one it was found in.
+	cat := catalog.NewCatalog(table.Schema)
+	cat.Database = config.Database.Name
+	for _, stmt := range table.Statements() {
+		if err := ddl.ApplyDDL(cat, stmt, "database", config.Database.Type); err != nil {
+			return nil, fmt.Errorf("failed to apply the definition of %s from the database: %w", tableName, err)
+		}
+	}
+
+	return cat, nil
+}
+
 func collectRelevantNames(
 	migrationsList []migrations.Migration,
 	targetTable string,
@@ -71,6 +109,15 @@ func collectRelevantNames(
 		changed := false
 		for _, migration := range migrationsList {
 			for _, stmt := range migration.Statements {
+				// A view needs the tables it selects from to infer its
+				// columns.
+				for _, source := range viewSources(stmt, names) {
+					if !names[source] {
+						names[source] = true
+						changed = true
+					}
+				}
+
 				matches := renameRe.FindStringSubmatch(stmt)
 				if len(matches) > 2 {
 					srcName := strings.ToLower(matches[1])
@@ -93,9 +140,52 @@ func collectRelevantNames(
 func isRelevantForTable(stmt string, relevantNames map[string]bool) bool {
 	stmtLower := strings.ToLower(stmt)
 
+	// Enum types and domains can be used by any table, so their definitions
+	// always apply.
+	if isTypeStatement(stmtLower) {
+		return true
+	}
+	// DROP INDEX names no table, and DROP VIEW can name several; indexes
+	// and views the catalog does not have are ignored.
+	fields := strings.Fields(ddl.StripComments(stmtLower))
+	if len(fields) > 1 && fields[0] == "drop" && (fields[1] == "index" || fields[1] == "view" || fields[1] == "materialized") {
+		return true
+	}
+
+	tableName := statementTable(stmt)
+	return tableName != "" && relevantNames[tableName]
+}
+
+// statementTable returns the lower-cased name of the table a CREATE TABLE,
+// ALTER TABLE, DROP TABLE, CREATE INDEX or COMMENT ON COLUMN statement
+// changes, or of the view a CREATE VIEW creates, or an empty string for
+// other statements.
+func statementTable(stmt string) string {
+	stmtLower := strings.ToLower(stmt)
+	fields := strings.Fields(ddl.StripComments(stmtLower))
+
 	var tableName string
 
 	switch {
+	case createViewStatement.MatchString(stmtLower):
+		matches := createViewStatement.FindStringSubmatch(stmtLower)
+		tableName = matches[1]
+	case strings.HasPrefix(stmtLower, "comment on column"):
+		re := regexp.MustCompile(
+			`(?i)comment\s+on\s+column\s+(?:\w+\.)?(\w+)\.\w+\s+is`,
+		)
+		matches := re.FindStringSubmatch(stmt)
+		if len(matches) > 1 {
+			tableName = strings.ToLower(matches[1])
+		}
+	case len(fields) > 1 && fields[0] == "create" && (fields[1] == "index" || fields[1] == "unique"):
+		re := regexp.MustCompile(
+			`(?is)create\s+(?:unique\s+)?index\b.*?\son\s+(?:only\s+)?(?:\w+\.)?"?(\w+)`,
+		)
+		matches := re.FindStringSubmatch(stmt)
+		if len(matches) > 1 {
+			tableName = strings.ToLower(matches[1])
+		}
 	case strings.Contains(stmtLower, "create table"):
 		re := regexp.MustCompile(
 			`(?i)create\s+table(?:\s+if\s+not\s+exists)?\s+(?:\w+\.)?(\w+)`,
@@ -122,5 +212,38 @@ func isRelevantForTable(stmt string, relevantNames map[string]bool) bool {
 		}
 	}
 
-	return tableName != "" && relevantNames[tableName]
+	return tableName
+}
+
+var createViewStatement = regexp.MustCompile(
+	`^\s*create\s+(?:or\s+replace\s+)?(?:temp(?:orary)?\s+)?(?:recursive\s+)?(?:materialized\s+)?view\s+(?:if\s+not\s+exists\s+)?(?:"?\w+"?\.)?"?(\w+)"?`,
+)
+
+// viewSources returns the tables and views a CREATE VIEW statement for one
+// of names selects from.
+func viewSources(stmt string, names map[string]bool) []string {
+	if !names[statementTable(stmt)] || !createViewStatement.MatchString(strings.ToLower(stmt)) {
+		return nil
+	}
+	parsed, err := ddl.NewDDLParser().Parse(stmt, "", "postgresql")
+	if err != nil {
+		return nil
+	}
+	view, ok := parsed.(*ddl.CreateViewStatement)
+	if !ok {
+		return nil
+	}
+	return view.SourceTables()
+}
+
+func isTypeStatement(stmtLower string) bool {
+	fields := strings.Fields(ddl.StripComments(stmtLower))
+	if len(fields) < 2 || (fields[1] != "type" && fields[1] != "domain") {
+		return false
+	}
+	switch fields[0] {
+	case "create", "alter", "drop":
+		return true
+	}
+	return false
 }
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/migrations"
+)
+
+func TestIsRelevantForTableIncludesColumnComments(t *testing.T) {
+	relevant := map[string]bool{"posts": true}
+
+	for stmt, want := range map[string]bool{
+		"COMMENT ON COLUMN posts.body IS 'andurel:richtext';":        true,
+		"COMMENT ON COLUMN public.posts.body IS NULL;":               true,
+		"COMMENT ON COLUMN users.bio IS 'andurel:richtext';":         false,
+		"COMMENT ON COLUMN users.bio IS 'create table posts (...)';": false,
+	} {
+		if got := isRelevantForTable(stmt, relevant); got != want {
+			t.Errorf("isRelevantForTable(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
+
+func TestIsRelevantForTableIncludesEnumTypes(t *testing.T) {
+	relevant := map[string]bool{"posts": true}
+
+	for stmt, want := range map[string]bool{
+		"CREATE TYPE post_status AS ENUM ('draft', 'published');":   true,
+		"ALTER TYPE post_status ADD VALUE 'archived';":              true,
+		"-- states\nDROP TYPE IF EXISTS post_status;":               true,
+		"CREATE DOMAIN email AS citext NOT NULL;":                   true,
+		"DROP DOMAIN IF EXISTS email;":                              true,
+		"CREATE TABLE users (id uuid PRIMARY KEY, role user_role);": false,
+	} {
+		if got := isRelevantForTable(stmt, relevant); got != want {
+			t.Errorf("isRelevantForTable(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
+
+func TestIsRelevantForTableIncludesIndexes(t *testing.T) {
+	relevant := map[string]bool{"posts": true}
+
+	for stmt, want := range map[string]bool{
+		"CREATE INDEX posts_author_idx ON posts (author_id);":                              true,
+		"-- lookups\nCREATE UNIQUE INDEX IF NOT EXISTS posts_slug ON public.posts (slug);": true,
+		"CREATE INDEX CONCURRENTLY ON ONLY \"posts\" USING btree (created_at);":            true,
+		"CREATE INDEX users_email_idx ON users (email);":                                   false,
+		"DROP INDEX IF EXISTS posts_author_idx;":                                           true,
+	} {
+		if got := isRelevantForTable(stmt, relevant); got != want {
+			t.Errorf("isRelevantForTable(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
+
+func TestCollectRelevantNamesIncludesViewSources(t *testing.T) {
+	migrationsList := []migrations.Migration{{Statements: []string{
+		"CREATE TABLE users (id uuid PRIMARY KEY, email text NOT NULL);",
+		"CREATE TABLE orders (id uuid PRIMARY KEY, user_id uuid NOT NULL);",
+		"CREATE TABLE audits (id uuid PRIMARY KEY);",
+		"CREATE VIEW order_counts AS SELECT user_id, count(*) AS orders FROM orders GROUP BY user_id;",
+		"CREATE OR REPLACE VIEW report_rows AS SELECT u.email, c.orders FROM users u LEFT JOIN order_counts c ON c.user_id = u.id;",
+	}}}
+
+	names := collectRelevantNames(migrationsList, "report_rows")
+	for _, name := range []string{"report_rows", "users", "order_counts", "orders"} {
+		if !names[name] {
+			t.Errorf("relevant names %v should include %s", names, name)
+		}
+	}
+	if names["audits"] {
+		t.Errorf("relevant names %v should not include audits", names)
+	}
+
+	for stmt, want := range map[string]bool{
+		"CREATE MATERIALIZED VIEW IF NOT EXISTS public.report_rows AS SELECT 1 AS one;": true,
+		"DROP VIEW IF EXISTS report_rows, order_counts;":                                true,
+		"CREATE VIEW audit_log AS SELECT * FROM audits;":                                false,
+	} {
+		if got := isRelevantForTable(stmt, names); got != want {
+			t.Errorf("isRelevantForTable(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
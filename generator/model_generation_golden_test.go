@@ -8,6 +8,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/pkg/cache"
 	"github.com/sebdah/goldie/v2"
 )
@@ -26,6 +29,34 @@ func TestModelGenerationGoldens(t *testing.T) {
 		g.Assert(t, "product_initial", content)
 	})
 
+	t.Run("nullable_pointers_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_initial")
+		manager.SetNullType(types.NullTypePointer)
+
+		if err := manager.GenerateModel("Product", "", false, ""); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+
+		factory, err := os.ReadFile(filepath.Join("models", "factories", "product.go"))
+		if err != nil {
+			t.Fatalf("failed to read factory: %v", err)
+		}
+
+		g.Assert(t, "product_nullable_pointers", readModelGoldenFile(t, manager, "Product"))
+		g.Assert(t, "product_nullable_pointers_factory", factory)
+
+		manager.SetNullType("")
+		result, err := manager.UpdateModel("Product")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		for _, pattern := range []string{`Description\s+\*string`, `LaunchedAt\s+\*time\.Time`} {
+			if !regexp.MustCompile(pattern).MatchString(result.NewFileContent) {
+				t.Fatalf("expected update to keep pointer field %s, got:\n%s", pattern, result.NewFileContent)
+			}
+		}
+	})
+
 	t.Run("update_generation", func(t *testing.T) {
 		manager := setupModelGoldenProject(t, "model_generation_initial")
 
@@ -54,6 +85,133 @@ func TestModelGenerationGoldens(t *testing.T) {
 		g.Assert(t, "product_update_diff", []byte(diff))
 	})
 
+	t.Run("update_marks_entity_section_once", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_initial")
+
+		if err := manager.GenerateModel("Product", "", true, ""); err != nil {
+			t.Fatalf("failed to generate initial model: %v", err)
+		}
+
+		marker := files.GeneratedSectionMarker("entity")
+		modelPath := BuildModelPath(manager.config.Paths.Models, "Product")
+		content, err := os.ReadFile(modelPath)
+		if err != nil {
+			t.Fatalf("failed to read generated model: %v", err)
+		}
+		if got := strings.Count(string(content), marker); got != 1 {
+			t.Fatalf("generated model has %d entity markers, want 1", got)
+		}
+
+		unmarked := strings.Replace(string(content), marker+"\n\n", "", 1)
+		if err := os.WriteFile(modelPath, []byte(unmarked), 0o600); err != nil {
+			t.Fatalf("failed to write unmarked model: %v", err)
+		}
+
+		manager.config.Database.MigrationDirs = []string{
+			modelGenerationFixtureDir(t, "model_generation_updated"),
+		}
+
+		result, err := manager.UpdateModel("Product")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		if got := strings.Count(result.NewFileContent, marker); got != 1 {
+			t.Fatalf("updated model has %d entity markers, want 1\n\n%s", got, result.NewFileContent)
+		}
+		if !strings.Contains(result.NewFileContent, marker+"\n\ntype ProductEntity struct {") {
+			t.Fatalf("entity marker should sit above the struct, apart from its doc comment\n\n%s", result.NewFileContent)
+		}
+	})
+
+	t.Run("update_keeps_user_code", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_initial")
+
+		if err := manager.GenerateModel("Product", "", true, ""); err != nil {
+			t.Fatalf("failed to generate initial model: %v", err)
+		}
+
+		modelPath := BuildModelPath(manager.config.Paths.Models, "Product")
+		content, err := os.ReadFile(modelPath)
+		if err != nil {
+			t.Fatalf("failed to read generated model: %v", err)
+		}
+		edited := strings.Replace(string(content), "import (", "import (\n\t\"strconv\"\n", 1)
+		edited = strings.Replace(edited, "func (e *ProductEntity) Validate() error {", `// Label keeps "{" and "}" in strings, which must not confuse the update.
+func (e ProductEntity) Label() string {
+	return "{" + e.Name + strconv.Itoa(1) + "}"
+}
+
+// Validate is documented by the user.
+func (e *ProductEntity) Validate() error {`, 1)
+		edited = strings.Replace(edited, "type CreateProductData struct {", `// Custom sits between generated blocks.
+func (p product) Custom() string { return "}" }
+
+type CreateProductData struct {`, 1)
+		edited += "\n// ExtraUserFunc closes the file.\nfunc ExtraUserFunc() {}\n"
+		if err := os.WriteFile(modelPath, []byte(edited), 0o600); err != nil {
+			t.Fatalf("failed to write edited model: %v", err)
+		}
+
+		manager.config.Database.MigrationDirs = []string{
+			modelGenerationFixtureDir(t, "model_generation_updated"),
+		}
+
+		result, err := manager.UpdateModel("Product")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		for _, want := range []string{
+			`"strconv"`,
+			"// Label keeps",
+			`return "{" + e.Name + strconv.Itoa(1) + "}"`,
+			"// Validate is documented by the user.",
+			"// Custom sits between generated blocks.\nfunc (p product) Custom() string",
+			"// ExtraUserFunc closes the file.\nfunc ExtraUserFunc() {}",
+			"Rating      float64",
+		} {
+			if !strings.Contains(result.NewFileContent, want) {
+				t.Errorf("updated model lost %q\n\n%s", want, result.NewFileContent)
+			}
+		}
+	})
+
+	t.Run("update_adds_unique_finders", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_initial")
+
+		if err := manager.GenerateModel("Product", "", true, ""); err != nil {
+			t.Fatalf("failed to generate initial model: %v", err)
+		}
+
+		modelPath := BuildModelPath(manager.config.Paths.Models, "Product")
+		content, err := os.ReadFile(modelPath)
+		if err != nil {
+			t.Fatalf("failed to read generated model: %v", err)
+		}
+		start, end, err := findFuncOffsets(content, "product", "FindBySku")
+		if err != nil {
+			t.Fatalf("generated model has no FindBySku: %v", err)
+		}
+		withoutFinder := string(content[:start]) + string(content[end:])
+		if err := os.WriteFile(modelPath, []byte(withoutFinder), 0o600); err != nil {
+			t.Fatalf("failed to write model without finder: %v", err)
+		}
+
+		result, err := manager.UpdateModel("Product")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		if got := strings.Count(result.NewFileContent, "func (p product) FindBySku("); got != 1 {
+			t.Fatalf("updated model has %d FindBySku methods, want 1\n\n%s", got, result.NewFileContent)
+		}
+		newStart, newEnd, err := findFuncOffsets([]byte(result.NewFileContent), "product", "FindBySku")
+		if err != nil {
+			t.Fatalf("updated model has no FindBySku: %v", err)
+		}
+		if got, want := result.NewFileContent[newStart:newEnd], string(content[start:end]); got != want {
+			t.Fatalf("updated FindBySku = %s\nwant %s", got, want)
+		}
+	})
+
 	t.Run("update_preserves_custom_typed_fields", func(t *testing.T) {
 		manager := setupModelGoldenProject(t, "model_generation_initial")
 
@@ -109,6 +267,164 @@ func TestModelGenerationGoldens(t *testing.T) {
 		}
 	})
 
+	t.Run("association_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_associations")
+
+		if err := manager.GenerateModelWithAssociations("Post", "", true, "", models.Associations{HasMany: []string{"Comment"}}); err != nil {
+			t.Fatalf("failed to generate has-many model: %v", err)
+		}
+		if err := manager.GenerateModelWithAssociations("Comment", "", true, "", models.Associations{BelongsTo: []string{"Post"}}); err != nil {
+			t.Fatalf("failed to generate belongs-to model: %v", err)
+		}
+
+		g.Assert(t, "post_has_many", readModelGoldenFile(t, manager, "Post"))
+		g.Assert(t, "comment_belongs_to", readModelGoldenFile(t, manager, "Comment"))
+	})
+
+	t.Run("association_update_keeps_relations", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_associations")
+
+		if err := manager.GenerateModelWithAssociations("Comment", "", false, "", models.Associations{BelongsTo: []string{"Post"}}); err != nil {
+			t.Fatalf("failed to generate belongs-to model: %v", err)
+		}
+
+		result, err := manager.UpdateModel("Comment")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		if !regexp.MustCompile("\n\n\tPost\\s+\\*PostEntity\\s+`bun:\"rel:belongs-to,join:post_id=id\"`").MatchString(result.NewFileContent) {
+			t.Fatalf("updated model should keep the Post relation\n\n%s", result.NewFileContent)
+		}
+		if strings.Contains(result.NewFactoryContent, "PostEntity") {
+			t.Fatalf("factory should not set the Post relation\n\n%s", result.NewFactoryContent)
+		}
+	})
+
+	t.Run("association_requires_foreign_key", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_associations")
+
+		err := manager.GenerateModelWithAssociations("Post", "", true, "", models.Associations{BelongsTo: []string{"Comment"}})
+		if err == nil || !strings.Contains(err.Error(), "requires a comment_id column on posts") {
+			t.Fatalf("expected missing foreign key error, got %v", err)
+		}
+		if _, statErr := os.Stat(BuildModelPath(manager.config.Paths.Models, "Post")); !os.IsNotExist(statErr) {
+			t.Fatalf("expected no model file after a failed association, got %v", statErr)
+		}
+	})
+
+	t.Run("enum_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_enums")
+
+		if err := manager.GenerateModel("Ticket", "", false, ""); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+
+		enums, err := os.ReadFile(filepath.Join(manager.config.Paths.Models, models.EnumsFileName))
+		if err != nil {
+			t.Fatalf("failed to read enums file: %v", err)
+		}
+		factory, err := os.ReadFile(filepath.Join("models", "factories", "ticket.go"))
+		if err != nil {
+			t.Fatalf("failed to read factory: %v", err)
+		}
+
+		g.Assert(t, "ticket_enums", readModelGoldenFile(t, manager, "Ticket"))
+		g.Assert(t, "ticket_enum_types", enums)
+		g.Assert(t, "ticket_enums_factory", factory)
+	})
+
+	t.Run("soft_delete_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_soft_delete")
+
+		if err := manager.GenerateModel("Document", "", false, ""); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+
+		g.Assert(t, "document_soft_delete", readModelGoldenFile(t, manager, "Document"))
+	})
+
+	t.Run("composite_primary_key_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_composite_pk")
+		manager.SetPrimaryKeyResolver(NopPrimaryKeyResolver{})
+
+		if err := manager.GenerateModel("Membership", "users_organizations", false, ""); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+
+		factory, err := os.ReadFile(filepath.Join("models", "factories", "membership.go"))
+		if err != nil {
+			t.Fatalf("failed to read factory: %v", err)
+		}
+
+		g.Assert(t, "membership_composite_pk", readModelGoldenFile(t, manager, "Membership"))
+		g.Assert(t, "membership_composite_pk_factory", factory)
+	})
+
+	t.Run("json_type_generation", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_json_types")
+
+		jsonTypes := map[string]string{"settings": "AccountSettings", "billing_address": "Address"}
+		if err := manager.GenerateModelWithJSONTypes("Account", "", false, "", models.Associations{}, jsonTypes); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+
+		factory, err := os.ReadFile(filepath.Join("models", "factories", "account.go"))
+		if err != nil {
+			t.Fatalf("failed to read factory: %v", err)
+		}
+
+		g.Assert(t, "account_json_types", readModelGoldenFile(t, manager, "Account"))
+		g.Assert(t, "account_json_types_factory", factory)
+	})
+
+	t.Run("json_type_requires_json_column", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_json_types")
+
+		err := manager.GenerateModelWithJSONTypes("Account", "", true, "", models.Associations{}, map[string]string{"name": "AccountName"})
+		if err == nil || !strings.Contains(err.Error(), "not json or jsonb") {
+			t.Fatalf("expected json column error, got %v", err)
+		}
+	})
+
+	t.Run("enum_update_refreshes_values", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_enums")
+
+		if err := manager.GenerateModel("Ticket", "", true, ""); err != nil {
+			t.Fatalf("failed to generate model: %v", err)
+		}
+		enumsPath := filepath.Join(manager.config.Paths.Models, models.EnumsFileName)
+		stale, err := os.ReadFile(enumsPath)
+		if err != nil {
+			t.Fatalf("failed to read enums file: %v", err)
+		}
+		stale = []byte(strings.ReplaceAll(string(stale), "\t\tTicketStatusBlocked,\n", ""))
+		if err := os.WriteFile(enumsPath, stale, 0o600); err != nil {
+			t.Fatalf("failed to write enums file: %v", err)
+		}
+
+		result, err := manager.UpdateModel("Ticket")
+		if err != nil {
+			t.Fatalf("failed to update model: %v", err)
+		}
+		if !result.EnumsHasChanges || !strings.Contains(result.NewEnumsContent, "\t\tTicketStatusBlocked,\n") {
+			t.Fatalf("update should restore the blocked status\n\n%s", result.NewEnumsContent)
+		}
+	})
+
+	t.Run("enum_file_not_overwritten_when_handwritten", func(t *testing.T) {
+		manager := setupModelGoldenProject(t, "model_generation_enums")
+
+		enumsPath := filepath.Join(manager.config.Paths.Models, models.EnumsFileName)
+		if err := os.WriteFile(enumsPath, []byte("package models\n"), 0o600); err != nil {
+			t.Fatalf("failed to write enums file: %v", err)
+		}
+
+		err := manager.GenerateModel("Ticket", "", true, "")
+		if err == nil || !strings.Contains(err.Error(), "was not generated by andurel") {
+			t.Fatalf("expected hand-written enums file error, got %v", err)
+		}
+	})
+
 	t.Run("custom_primary_key_generation", func(t *testing.T) {
 		manager := setupModelGoldenProject(t, "model_generation_custom_pk")
 		manager.SetPrimaryKeyResolver(NopPrimaryKeyResolver{})
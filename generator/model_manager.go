@@ -1,15 +1,22 @@
 package generator
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/mbvlabs/andurel/generator/files"
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/generator/models"
 	"github.com/mbvlabs/andurel/layout"
+	layouttemplates "github.com/mbvlabs/andurel/layout/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
 
@@ -22,6 +29,11 @@ type ModelManager struct {
 	migrationManager *MigrationManager
 	config           *UnifiedConfig
 	pkResolver       PrimaryKeyResolver
+	nullType         string
+	conflictColumns  []string
+	schemaDB         SchemaQuerier
+	fromView         bool
+	withTests        bool
 }
 
 type modelSetupContext struct {
@@ -58,6 +70,60 @@ func (m *ModelManager) SetPrimaryKeyResolver(resolver PrimaryKeyResolver) {
 	m.pkResolver = resolver
 }
 
+// SetNullType overrides the nullable type strategy from andurel.lock for the
+// models this manager generates, e.g. types.NullTypePointer.
+func (m *ModelManager) SetNullType(nullType string) {
+	m.nullType = nullType
+}
+
+// SetSchemaDatabase makes the manager read tables from db instead of the
+// migrations. A nil db reads the migrations again.
+func (m *ModelManager) SetSchemaDatabase(db SchemaQuerier) {
+	m.schemaDB = db
+}
+
+// buildCatalog builds the catalog of tableName from the database set with
+// SetSchemaDatabase, or else from the migrations.
+func (m *ModelManager) buildCatalog(tableName string) (*catalog.Catalog, error) {
+	if m.schemaDB != nil {
+		return m.migrationManager.BuildCatalogFromDatabase(context.Background(), m.schemaDB, tableName, m.config)
+	}
+	return m.migrationManager.BuildCatalogFromMigrations(tableName, m.config)
+}
+
+// buildModelCatalog builds the catalog of an existing model's table like
+// buildCatalog, from the migrations of the database the model was generated
+// for when none was chosen.
+func (m *ModelManager) buildModelCatalog(resourceName, tableName string) (*catalog.Catalog, error) {
+	if m.schemaDB == nil && m.config.Database.Name == "" {
+		modelPath := BuildModelPath(m.config.Paths.Models, resourceName)
+		if name := ExtractDatabaseName(modelPath, resourceName); name != "" {
+			config := *m.config
+			useDatabase(&config, name)
+			return m.migrationManager.BuildCatalogFromMigrations(tableName, &config)
+		}
+	}
+	return m.buildCatalog(tableName)
+}
+
+// SetFromView makes model generation require the table to be a view, as
+// with --from-view.
+func (m *ModelManager) SetFromView(enabled bool) {
+	m.fromView = enabled
+}
+
+// SetWithTests makes model generation write models/<name>_test.go next to
+// the model, as with --with-tests.
+func (m *ModelManager) SetWithTests(enabled bool) {
+	m.withTests = enabled
+}
+
+// SetConflictColumns sets the columns the Upsert of generated models detects
+// an existing row by, instead of inferring them from the table's keys.
+func (m *ModelManager) SetConflictColumns(columns []string) {
+	m.conflictColumns = columns
+}
+
 func (m *ModelManager) setupModelContext(
 	resourceName, tableName string,
 	tableNameOverridden bool,
@@ -108,6 +174,38 @@ func (m *ModelManager) GenerateModel(
 	skipFactory bool,
 	primaryKeyColumn string,
 ) error {
+	return m.GenerateModelWithAssociations(resourceName, tableNameOverride, skipFactory, primaryKeyColumn, models.Associations{})
+}
+
+// GenerateModelWithAssociations generates model files with bun relations,
+// foreign key queries and preload scopes for the given associations.
+func (m *ModelManager) GenerateModelWithAssociations(
+	resourceName string,
+	tableNameOverride string,
+	skipFactory bool,
+	primaryKeyColumn string,
+	associations models.Associations,
+) error {
+	return m.GenerateModelWithJSONTypes(resourceName, tableNameOverride, skipFactory, primaryKeyColumn, associations, nil)
+}
+
+// GenerateModelWithJSONTypes generates model files where the json/jsonb
+// columns in jsonTypes are decoded into structs declared in the models
+// package, e.g. {"settings": "UserSettings"}.
+func (m *ModelManager) GenerateModelWithJSONTypes(
+	resourceName string,
+	tableNameOverride string,
+	skipFactory bool,
+	primaryKeyColumn string,
+	associations models.Associations,
+	jsonTypes map[string]string,
+) error {
+	for _, name := range append(append([]string{}, associations.BelongsTo...), associations.HasMany...) {
+		if err := m.validator.ValidateResourceName(name); err != nil {
+			return fmt.Errorf("invalid association %q: %w", name, err)
+		}
+	}
+
 	tableName := tableNameOverride
 	if tableName == "" {
 		tableName = naming.DeriveTableName(resourceName)
@@ -127,13 +225,35 @@ func (m *ModelManager) GenerateModel(
 	if err := m.fileManager.ValidateFileNotExists(ctx.ModelPath); err != nil {
 		return err
 	}
+	if err := validateModelIdentifiers(ctx.ResourceName); err != nil {
+		return err
+	}
+	if err := validateModelNamesAvailable(m.config.Paths.Models, ctx.ResourceName, ctx.ModelPath); err != nil {
+		return err
+	}
 
-	cat, err := m.migrationManager.BuildCatalogFromMigrations(ctx.TableName, m.config)
+	cat, err := m.buildCatalog(ctx.TableName)
 	if err != nil {
 		return err
 	}
+	if err := m.addRelatedTables(cat, associations.HasMany); err != nil {
+		return err
+	}
+
+	_, viewErr := cat.GetView("", ctx.TableName)
+	isView := viewErr == nil
+	if m.fromView && !isView {
+		return fmt.Errorf("%s is a table, not a view; drop --from-view to generate a model for it", ctx.TableName)
+	}
+	if m.withTests && isView {
+		return fmt.Errorf("%s is a view, which --with-tests cannot insert records into", ctx.TableName)
+	}
+	if m.withTests && skipFactory {
+		return fmt.Errorf("--with-tests creates records with the model's factory; drop --skip-factory")
+	}
 
-	// Resolve primary key
+	// Resolve primary key. Views have none, so a view's model is only keyed
+	// by a column passed with --primary-key.
 	var pkInfo PrimaryKeyInfo
 	if primaryKeyColumn != "" {
 		pkInfo = PrimaryKeyInfo{
@@ -141,7 +261,7 @@ func (m *ModelManager) GenerateModel(
 			Found:      true,
 			IsNamedID:  primaryKeyColumn == "id",
 		}
-	} else {
+	} else if !isView {
 		var err error
 		pkInfo, err = m.resolvePrimaryKey(cat, ctx.TableName)
 		if err != nil {
@@ -150,23 +270,48 @@ func (m *ModelManager) GenerateModel(
 	}
 
 	nullType := m.readNullType(ctx.RootDir)
+	decimalType := readDecimalType(ctx.RootDir)
+	customTypes, err := types.LoadTypeOverrides(ctx.RootDir)
+	if err != nil {
+		return err
+	}
 
-	if err := m.modelGenerator.GenerateModel(cat, ctx.ResourceName, ctx.TableName, ctx.ModelPath, ctx.ModulePath, tableNameOverride, nullType, pkInfo.ColumnName, !pkInfo.Found); err != nil {
+	if err := m.modelGenerator.GenerateModel(cat, ctx.ResourceName, ctx.TableName, ctx.ModelPath, ctx.ModulePath, tableNameOverride, nullType, decimalType, pkInfo.ColumnName, !pkInfo.Found, associations, jsonTypes, customTypes, m.conflictColumns); err != nil {
 		return fmt.Errorf("failed to generate model: %w", err)
 	}
+	if model, err := os.ReadFile(ctx.ModelPath); err == nil {
+		if err := ensureDecimalModule(ctx.RootDir, string(model)); err != nil {
+			return err
+		}
+	}
 
 	if err := m.registerNamespace(ctx.ResourceName); err != nil {
 		return fmt.Errorf("failed to register namespace in models/model.go: %w", err)
 	}
+	if err := m.ensureTxHelper(ctx.ModulePath); err != nil {
+		return fmt.Errorf("failed to write models/tx.go: %w", err)
+	}
 
-	// Generate factory (unless skipped)
-	if !skipFactory {
-		if err := m.generateFactory(cat, ctx, pkInfo); err != nil {
+	// Generate factory (unless skipped). Rows cannot be inserted into a
+	// view, so it gets none.
+	if !skipFactory && !isView {
+		genModel, genFactory, err := m.generateFactory(cat, ctx, pkInfo, jsonTypes)
+		if err != nil {
+			if m.withTests {
+				return fmt.Errorf("failed to generate the factory the model test uses: %w", err)
+			}
 			// Log the error but don't fail the entire generation
 			fmt.Printf("Warning: failed to generate factory: %v\n", err)
 		} else {
 			fmt.Printf("✓ Generated factory: models/factories/%s.go\n", strings.ToLower(ctx.ResourceName))
 		}
+
+		if m.withTests {
+			if err := m.generateModelTest(ctx, genModel, genFactory); err != nil {
+				return fmt.Errorf("failed to generate model test: %w", err)
+			}
+			fmt.Printf("✓ Generated model test: models/%s_test.go\n", naming.ToSnakeCase(ctx.ResourceName))
+		}
 	}
 
 	fmt.Printf(
@@ -176,6 +321,31 @@ func (m *ModelManager) GenerateModel(
 	return nil
 }
 
+// addRelatedTables loads the tables of has-many models into cat, since the
+// catalog only holds the generated table and the types it uses.
+func (m *ModelManager) addRelatedTables(cat *catalog.Catalog, names []string) error {
+	for _, name := range names {
+		tableName := naming.DeriveTableName(name)
+		if _, err := cat.GetTable("", tableName); err == nil {
+			continue
+		}
+
+		related, err := m.buildCatalog(tableName)
+		if err != nil {
+			return fmt.Errorf("has-many %s: %w", name, err)
+		}
+		table, err := related.GetTable("", tableName)
+		if err != nil {
+			return fmt.Errorf("has-many %s: %w", name, err)
+		}
+		if err := cat.AddTable("", table); err != nil {
+			return fmt.Errorf("has-many %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
 // resolvePrimaryKey inspects the catalog for the table's primary key and
 // interacts with the user if the PK is non-standard or missing.
 func (m *ModelManager) resolvePrimaryKey(cat *catalog.Catalog, tableName string) (PrimaryKeyInfo, error) {
@@ -203,15 +373,21 @@ func (m *ModelManager) resolvePrimaryKey(cat *catalog.Catalog, tableName string)
 	return pkInfo, nil
 }
 
-// generateFactory creates a factory file for the model
-func (m *ModelManager) generateFactory(cat *catalog.Catalog, ctx *modelSetupContext, pkInfo PrimaryKeyInfo) error {
+// generateFactory creates a factory file for the model and returns the model
+// and factory it was built from.
+func (m *ModelManager) generateFactory(cat *catalog.Catalog, ctx *modelSetupContext, pkInfo PrimaryKeyInfo, jsonTypes map[string]string) (*models.GeneratedModel, *models.GeneratedFactory, error) {
 	// Get root directory
 	rootDir, err := m.fileManager.FindGoModRoot()
 	if err != nil {
-		return fmt.Errorf("failed to find go.mod root: %w", err)
+		return nil, nil, fmt.Errorf("failed to find go.mod root: %w", err)
 	}
 
 	nullType := m.readNullType(rootDir)
+	decimalType := readDecimalType(rootDir)
+	customTypes, err := types.LoadTypeOverrides(rootDir)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	// Build the model first
 	genModel, err := m.modelGenerator.Build(cat, models.Config{
@@ -221,11 +397,14 @@ func (m *ModelManager) generateFactory(cat *catalog.Catalog, ctx *modelSetupCont
 		DatabaseType:      m.config.Database.Type,
 		ModulePath:        ctx.ModulePath,
 		NullType:          nullType,
+		DecimalType:       decimalType,
 		PrimaryKeyColumn:  pkInfo.ColumnName,
 		GenerateWithoutPK: !pkInfo.Found,
+		JSONTypes:         jsonTypes,
+		CustomTypes:       customTypes,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to build model for factory: %w", err)
+		return nil, nil, fmt.Errorf("failed to build model for factory: %w", err)
 	}
 
 	// Build factory metadata
@@ -236,19 +415,34 @@ func (m *ModelManager) generateFactory(cat *catalog.Catalog, ctx *modelSetupCont
 		DatabaseType:      m.config.Database.Type,
 		ModulePath:        ctx.ModulePath,
 		NullType:          nullType,
+		DecimalType:       decimalType,
 		PrimaryKeyColumn:  pkInfo.ColumnName,
 		GenerateWithoutPK: !pkInfo.Found,
 	}, genModel)
 	if err != nil {
-		return fmt.Errorf("failed to build factory: %w", err)
+		return nil, nil, fmt.Errorf("failed to build factory: %w", err)
 	}
 
 	// Write factory file
 	if err := m.modelGenerator.WriteFactoryFile(genFactory, rootDir); err != nil {
-		return fmt.Errorf("failed to write factory: %w", err)
+		return nil, nil, fmt.Errorf("failed to write factory: %w", err)
 	}
 
-	return nil
+	if err := ensureFakeDataModule(rootDir); err != nil {
+		return nil, nil, err
+	}
+
+	return genModel, genFactory, nil
+}
+
+// generateModelTest writes the _test.go file of a model and its factory,
+// which runs against the database from database.NewTestDB.
+func (m *ModelManager) generateModelTest(ctx *modelSetupContext, genModel *models.GeneratedModel, genFactory *models.GeneratedFactory) error {
+	test, err := m.modelGenerator.BuildModelTest(genModel, genFactory, ctx.RootDir)
+	if err != nil {
+		return err
+	}
+	return m.modelGenerator.WriteModelTestFile(test, ctx.RootDir)
 }
 
 // registerNamespace ensures the project's models/model.go declares the
@@ -278,7 +472,38 @@ func (m *ModelManager) registerNamespace(resourceName string) error {
 		return nil
 	}
 
-	return os.WriteFile(modelGoPath, []byte(updated), 0o644)
+	if err := os.WriteFile(modelGoPath, []byte(updated), 0o644); err != nil {
+		return err
+	}
+	return files.FormatGoFile(modelGoPath)
+}
+
+// ensureTxHelper writes models/tx.go with models.WithTx to projects created
+// before new projects got it. An existing file is left alone.
+func (m *ModelManager) ensureTxHelper(modulePath string) error {
+	modelsDir := m.config.Paths.Models
+	if _, err := os.Stat(filepath.Join(modelsDir, "model.go")); err != nil {
+		return nil
+	}
+	txPath := filepath.Join(modelsDir, "tx.go")
+	if _, err := os.Stat(txPath); err == nil {
+		return nil
+	}
+
+	content, err := layouttemplates.Files.ReadFile("models_tx.tmpl")
+	if err != nil {
+		return err
+	}
+	tmpl, err := template.New("models_tx").Parse(string(content))
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ ModuleName string }{modulePath}); err != nil {
+		return err
+	}
+
+	return os.WriteFile(txPath, buf.Bytes(), constants.FilePermissionPrivate)
 }
 
 // ensureLineInBlock inserts entry as a new line just before the `)` that
@@ -304,11 +529,77 @@ func ensureLineInBlock(src, blockHeader, entry string) string {
 	return src[:insertAt] + entry + "\n" + src[insertAt:]
 }
 
-// readNullType reads the nullable type strategy from andurel.lock.
-// Defaults to "sql.Null" when not configured.
+// readNullType returns the nullable type strategy set with SetNullType, or
+// the one in andurel.lock. Defaults to "sql.Null" when not configured.
 func (m *ModelManager) readNullType(rootDir string) string {
+	if m.nullType != "" {
+		return m.nullType
+	}
 	if lock, err := layout.ReadLockFile(rootDir); err == nil && lock.DatabaseConfig != nil && lock.DatabaseConfig.NullType != "" {
 		return lock.DatabaseConfig.NullType
 	}
 	return "sql.Null"
 }
+
+// readDecimalType reads the Go type of numeric columns from andurel.lock.
+// Defaults to "float64" when not configured.
+func readDecimalType(rootDir string) string {
+	lock, err := layout.ReadLockFile(rootDir)
+	if err != nil {
+		return layout.DecimalTypeFloat64
+	}
+	return lock.DecimalType()
+}
+
+// decimalModuleVersion is the shopspring/decimal release added to projects
+// that map numeric columns to decimal.Decimal.
+const decimalModuleVersion = "v1.4.0"
+
+var runGoGet = func(rootDir, module string) error {
+	cmd := exec.Command("go", "get", module)
+	cmd.Dir = rootDir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// fakeDataModule is the gofakeit release generated factories fill their
+// fields with.
+const fakeDataModule = "github.com/brianvoe/gofakeit/v7@v7.14.0"
+
+// ensureFakeDataModule adds gofakeit to go.mod for projects created while
+// factories used go-faker, which new projects no longer require.
+func ensureFakeDataModule(rootDir string) error {
+	goMod, err := os.ReadFile(filepath.Join(rootDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	module, _, _ := strings.Cut(fakeDataModule, "@")
+	if !strings.Contains(string(goMod), "github.com/go-faker/faker/v4 ") || strings.Contains(string(goMod), module+" ") {
+		return nil
+	}
+	if err := runGoGet(rootDir, fakeDataModule); err != nil {
+		return fmt.Errorf("failed to add %s to go.mod: %w", module, err)
+	}
+	return nil
+}
+
+// ensureDecimalModule adds shopspring/decimal to go.mod the first time a
+// generated model uses it.
+func ensureDecimalModule(rootDir, model string) error {
+	if !strings.Contains(model, types.DecimalGoType) {
+		return nil
+	}
+	goMod, err := os.ReadFile(filepath.Join(rootDir, "go.mod"))
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %w", err)
+	}
+	if strings.Contains(string(goMod), types.DecimalPackage+" ") {
+		return nil
+	}
+	if err := runGoGet(rootDir, types.DecimalPackage+"@"+decimalModuleVersion); err != nil {
+		return fmt.Errorf("failed to add %s to go.mod: %w", types.DecimalPackage, err)
+	}
+	return nil
+}
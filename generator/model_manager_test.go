@@ -109,3 +109,120 @@ func TestSetupModelContext(t *testing.T) {
 		}
 	})
 }
+
+func TestEnsureTxHelper(t *testing.T) {
+	manager, cleanup := setupModelManagerTest(t)
+	defer cleanup()
+
+	txPath := filepath.Join("models", "tx.go")
+	if err := manager.ensureTxHelper("example.com/app"); err != nil {
+		t.Fatalf("ensureTxHelper without model.go: %v", err)
+	}
+	if _, err := os.Stat(txPath); !os.IsNotExist(err) {
+		t.Fatalf("expected no tx.go outside a project models package, stat err = %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join("models", "model.go"), []byte("package models\n"), 0o644); err != nil {
+		t.Fatalf("write model.go: %v", err)
+	}
+	if err := manager.ensureTxHelper("example.com/app"); err != nil {
+		t.Fatalf("ensureTxHelper: %v", err)
+	}
+	content, err := os.ReadFile(txPath)
+	if err != nil {
+		t.Fatalf("read tx.go: %v", err)
+	}
+	for _, want := range []string{`"example.com/app/internal/storage"`, "func WithTx(ctx context.Context, db storage.Pool"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("tx.go missing %q:\n%s", want, content)
+		}
+	}
+
+	if err := os.WriteFile(txPath, []byte("package models\n"), 0o644); err != nil {
+		t.Fatalf("write tx.go: %v", err)
+	}
+	if err := manager.ensureTxHelper("example.com/app"); err != nil {
+		t.Fatalf("ensureTxHelper with existing tx.go: %v", err)
+	}
+	if content, _ := os.ReadFile(txPath); string(content) != "package models\n" {
+		t.Fatalf("expected existing tx.go to be kept, got:\n%s", content)
+	}
+}
+
+func TestEnsureFakeDataModule(t *testing.T) {
+	var calls []string
+	orig := runGoGet
+	runGoGet = func(_, module string) error {
+		calls = append(calls, module)
+		return nil
+	}
+	t.Cleanup(func() { runGoGet = orig })
+
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	for _, content := range []string{
+		"module example.com/app\n\ngo 1.25\n",
+		"module example.com/app\n\nrequire github.com/brianvoe/gofakeit/v7 v7.14.0\n",
+	} {
+		if err := os.WriteFile(goMod, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := ensureFakeDataModule(dir); err != nil {
+			t.Fatalf("ensureFakeDataModule() error = %v", err)
+		}
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no go get without go-faker in go.mod, got %v", calls)
+	}
+
+	if err := os.WriteFile(goMod, []byte("module example.com/app\n\nrequire github.com/go-faker/faker/v4 v4.9.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ensureFakeDataModule(dir); err != nil {
+		t.Fatalf("ensureFakeDataModule() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != fakeDataModule {
+		t.Fatalf("unexpected go get calls: %v", calls)
+	}
+}
+
+func TestEnsureDecimalModule(t *testing.T) {
+	var calls []string
+	orig := runGoGet
+	runGoGet = func(_, module string) error {
+		calls = append(calls, module)
+		return nil
+	}
+	t.Cleanup(func() { runGoGet = orig })
+
+	dir := t.TempDir()
+	goMod := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(goMod, []byte("module example.com/app\n\ngo 1.25\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ensureDecimalModule(dir, "type Product struct {\n\tPrice float64\n}\n"); err != nil {
+		t.Fatalf("ensureDecimalModule() error = %v", err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("expected no go get for models without decimals, got %v", calls)
+	}
+
+	model := "type Product struct {\n\tPrice decimal.Decimal\n}\n"
+	if err := ensureDecimalModule(dir, model); err != nil {
+		t.Fatalf("ensureDecimalModule() error = %v", err)
+	}
+	if len(calls) != 1 || calls[0] != "github.com/shopspring/decimal@"+decimalModuleVersion {
+		t.Fatalf("unexpected go get calls: %v", calls)
+	}
+
+	if err := os.WriteFile(goMod, []byte("module example.com/app\n\nrequire github.com/shopspring/decimal v1.4.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ensureDecimalModule(dir, model); err != nil {
+		t.Fatalf("ensureDecimalModule() error = %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected go get to be skipped once go.mod requires decimal, got %v", calls)
+	}
+}
@@ -3,7 +3,6 @@ package generator
 import (
 	"fmt"
 	"go/ast"
-	"go/format"
 	"go/parser"
 	"go/token"
 	"os"
@@ -12,7 +11,10 @@ import (
 	"strings"
 
 	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 	"github.com/mbvlabs/andurel/generator/models"
+	"github.com/mbvlabs/andurel/generator/templates"
 	"github.com/mbvlabs/andurel/pkg/naming"
 	"github.com/pmezard/go-difflib/difflib"
 )
@@ -29,12 +31,18 @@ var standardGoTypes = map[string]bool{
 	"float64": true, "*float64": true,
 	"time.Time": true, "*time.Time": true,
 	"uuid.UUID": true, "*uuid.UUID": true,
-	"[]byte":           true,
-	"json.RawMessage":  true,
-	"*json.RawMessage": true,
-	"[]int32":          true,
-	"[]string":         true,
-	"any":              true,
+	"[]byte":             true,
+	"json.RawMessage":    true,
+	"*json.RawMessage":   true,
+	"decimal.Decimal":    true,
+	"*decimal.Decimal":   true,
+	"[]int32":            true,
+	"[]string":           true,
+	"any":                true,
+	"money.Money":        true,
+	"*money.Money":       true,
+	"interval.Duration":  true,
+	"*interval.Duration": true,
 	// sql.Null types
 	"sql.NullString":  true,
 	"sql.NullBool":    true,
@@ -53,10 +61,11 @@ var standardGoTypes = map[string]bool{
 }
 
 type parsedField struct {
-	Name     string
-	TypeStr  string
-	BunTag   string
-	IsCustom bool
+	Name       string
+	TypeStr    string
+	BunTag     string
+	IsCustom   bool
+	IsRelation bool
 }
 
 // UpdateModelResult holds the before/after state for a model update.
@@ -72,6 +81,11 @@ type UpdateModelResult struct {
 	OldFactoryContent string
 	NewFactoryContent string
 	FactoryHasChanges bool
+
+	EnumsPath       string
+	OldEnumsContent string
+	NewEnumsContent string
+	EnumsHasChanges bool
 }
 
 // Diff returns a unified diff of the struct definitions and method bodies
@@ -101,6 +115,18 @@ func (r *UpdateModelResult) FactoryDiff() (string, error) {
 	return difflib.GetUnifiedDiffString(d)
 }
 
+// EnumsDiff returns a unified diff of the old vs new enums file content.
+func (r *UpdateModelResult) EnumsDiff() (string, error) {
+	d := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(r.OldEnumsContent),
+		B:        difflib.SplitLines(r.NewEnumsContent),
+		FromFile: "current",
+		ToFile:   "updated",
+		Context:  2,
+	}
+	return difflib.GetUnifiedDiffString(d)
+}
+
 // dropBaseModelLine removes the bun.BaseModel embedding line (and any blank
 // line immediately following it) from a struct string before diffing.
 func dropBaseModelLine(structStr string) string {
@@ -149,13 +175,20 @@ func (m *ModelManager) UpdateModel(resourceName string) (*UpdateModelResult, err
 
 	tableName := ResolveTableName(m.config.Paths.Models, resourceName)
 
-	cat, err := m.migrationManager.BuildCatalogFromMigrations(tableName, m.config)
+	cat, err := m.buildModelCatalog(resourceName, tableName)
 	if err != nil {
 		return nil, err
 	}
 
 	rootDir, _ := m.fileManager.FindGoModRoot()
 	nullType := m.readNullType(rootDir)
+	if m.nullType == "" {
+		nullType = types.ModelNullType(modelPath, entityName, nullType)
+	}
+	customTypes, err := types.LoadTypeOverrides(rootDir)
+	if err != nil {
+		return nil, err
+	}
 	newModel, err := m.modelGenerator.Build(cat, models.Config{
 		TableName:    tableName,
 		ResourceName: resourceName,
@@ -163,14 +196,21 @@ func (m *ModelManager) UpdateModel(resourceName string) (*UpdateModelResult, err
 		DatabaseType: m.config.Database.Type,
 		ModulePath:   m.projectManager.GetModulePath(),
 		NullType:     nullType,
+		DecimalType:  readDecimalType(rootDir),
+		CustomTypes:  customTypes,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to build model: %w", err)
 	}
 
-	// Collect custom-typed fields from the existing struct.
+	// Collect custom-typed fields from the existing struct. Bun relations are
+	// kept as associations so they stay out of the data structs and factory.
 	customFields := make(map[string]parsedField)
 	for _, f := range existingFields {
+		if f.IsRelation {
+			newModel.Associations = append(newModel.Associations, relationAssociation(f))
+			continue
+		}
 		if f.IsCustom {
 			customFields[f.Name] = f
 		}
@@ -183,17 +223,19 @@ func (m *ModelManager) UpdateModel(resourceName string) (*UpdateModelResult, err
 	}
 
 	// Override generated types with user-customized ones for fields that exist
-	// in both the old and new model.
+	// in both the old and new model. Fields mapped in andurel.types.yaml
+	// follow the file instead.
 	for i, field := range newModel.Fields {
-		if custom, ok := customFields[field.Name]; ok {
+		if custom, ok := customFields[field.Name]; ok && field.ProjectType == nil {
 			newModel.Fields[i].Type = custom.TypeStr
+			newModel.Fields[i].Enum = nil
 		}
 	}
 
 	// Preserve custom-typed fields (e.g. enums) that exist in the current file
 	// but are not produced by the migration-derived model.
 	for _, f := range existingFields {
-		if f.IsCustom && !newFieldNames[f.Name] {
+		if f.IsCustom && !f.IsRelation && !newFieldNames[f.Name] {
 			newModel.Fields = append(newModel.Fields, models.GeneratedField{
 				Name:   f.Name,
 				Type:   f.TypeStr,
@@ -202,27 +244,76 @@ func (m *ModelManager) UpdateModel(resourceName string) (*UpdateModelResult, err
 		}
 	}
 
-	newEntityStr := renderEntityStruct(entityName, tableName, newModel.Fields)
+	newEntityStr := renderEntityStruct(entityName, tableName, newModel.Fields, newModel.Associations)
 
 	content := string(src)
 	content = content[:structStart] + newEntityStr + content[structEnd:]
-
-	formatted, err := format.Source([]byte(content))
-	if err != nil {
-		formatted = []byte(content)
+	entityMarker := files.GeneratedSectionMarker("entity")
+	docStart := docCommentStart(content, structStart)
+	if !strings.HasSuffix(strings.TrimRight(content[:docStart], " \t\n"), entityMarker) && !strings.Contains(content[docStart:structStart], entityMarker) {
+		content = content[:docStart] + entityMarker + "\n\n" + content[docStart:]
 	}
 
 	oldParts := string(src[structStart:structEnd])
 	newParts := newEntityStr
 
+	table, err := cat.GetTable("", tableName)
+	if err != nil {
+		view, viewErr := cat.GetView("", tableName)
+		if viewErr != nil {
+			return nil, err
+		}
+		table = view
+	}
+	newModel.Validations = models.BuildValidations(table, newModel.Fields)
+	content, oldValidations, newValidations, err := m.refreshDataValidations(content, newModel)
+	if err != nil {
+		return nil, err
+	}
+	oldParts += oldValidations
+	newParts += newValidations
+
+	content, oldFinders, newFinders, err := m.refreshFinders(content, newModel)
+	if err != nil {
+		return nil, err
+	}
+	oldParts += oldFinders
+	newParts += newFinders
+
+	formatted, err := files.FormatGoSource(modelPath, []byte(content))
+	if err != nil {
+		formatted = []byte(content)
+	}
+
 	factoryPath := fmt.Sprintf("%s/models/factories/%s.go", rootDir, naming.ToSnakeCase(resourceName))
 	var oldFactoryContent, newFactoryContent string
 	if existingSrc, err := os.ReadFile(factoryPath); err == nil {
 		oldFactoryContent = string(existingSrc)
 	}
-	if factoryPlan, factoryErr := m.planFactorySync(resourceName, tableName, newModel, FactorySyncOptions{}); factoryErr == nil {
-		factoryPath = factoryPlan.Path
-		newFactoryContent = factoryPlan.newContent
+	// Views have no factory.
+	if !newModel.ReadOnly {
+		if factoryPlan, factoryErr := m.planFactorySync(cat, resourceName, tableName, newModel, FactorySyncOptions{}); factoryErr == nil {
+			factoryPath = factoryPlan.Path
+			newFactoryContent = factoryPlan.newContent
+		}
+	}
+
+	enumsPath := filepath.Join(m.config.Paths.Models, models.EnumsFileName)
+	var oldEnumsContent string
+	if existing, err := os.ReadFile(enumsPath); err == nil {
+		oldEnumsContent = string(existing)
+	}
+	newEnumsContent, err := m.modelGenerator.RenderEnumsFile(cat)
+	if err != nil {
+		return nil, err
+	}
+	if newEnumsContent != "" {
+		if err := models.CheckEnumsFileGenerated(enumsPath); err != nil {
+			return nil, err
+		}
+		if formattedEnums, err := files.FormatGoSource(enumsPath, []byte(newEnumsContent)); err == nil {
+			newEnumsContent = string(formattedEnums)
+		}
 	}
 
 	return &UpdateModelResult{
@@ -237,11 +328,127 @@ func (m *ModelManager) UpdateModel(resourceName string) (*UpdateModelResult, err
 		OldFactoryContent: oldFactoryContent,
 		NewFactoryContent: newFactoryContent,
 		FactoryHasChanges: oldFactoryContent != newFactoryContent,
+
+		EnumsPath:       enumsPath,
+		OldEnumsContent: oldEnumsContent,
+		NewEnumsContent: newEnumsContent,
+		EnumsHasChanges: newEnumsContent != "" && oldEnumsContent != newEnumsContent,
 	}, nil
 }
 
+// docCommentStart returns the offset of the comment lines directly above
+// the declaration at declStart, or declStart when it has none.
+func docCommentStart(content string, declStart int) int {
+	start := declStart
+	for start > 0 {
+		lineStart := strings.LastIndexByte(content[:start-1], '\n') + 1
+		if !strings.HasPrefix(strings.TrimSpace(content[lineStart:start]), "//") {
+			break
+		}
+		start = lineStart
+	}
+	return start
+}
+
+// refreshDataValidations replaces the Validate methods of the Create and
+// Update data structs in content with the ones generated for model. The data
+// structs are left as they are, so rules on fields a struct does not declare
+// are dropped. Returns the updated content and the old and new methods.
+func (m *ModelManager) refreshDataValidations(content string, model *models.GeneratedModel) (string, string, string, error) {
+	templateContent, err := templates.Files.ReadFile("model.tmpl")
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to read model template: %w", err)
+	}
+
+	var oldMethods, newMethods strings.Builder
+	for _, structName := range []string{"Create" + model.Name + "Data", "Update" + model.Name + "Data"} {
+		oldStart, oldEnd, err := findFuncOffsets([]byte(content), structName, "Validate")
+		if err != nil {
+			continue
+		}
+		fields, _, _, err := parseEntityStruct([]byte(content), structName)
+		if err != nil {
+			continue
+		}
+		declared := make(map[string]bool, len(fields))
+		for _, field := range fields {
+			declared[field.Name] = true
+		}
+
+		structModel := *model
+		structModel.Validations = nil
+		for _, validation := range model.Validations {
+			if declared[validation.Field] {
+				structModel.Validations = append(structModel.Validations, validation)
+			}
+		}
+		rendered, err := m.modelGenerator.GenerateModelFile(&structModel, string(templateContent))
+		if err != nil {
+			return "", "", "", fmt.Errorf("failed to render model file: %w", err)
+		}
+		newStart, newEnd, err := findFuncOffsets([]byte(rendered), structName, "Validate")
+		if err != nil {
+			continue
+		}
+
+		method := rendered[newStart:newEnd]
+		oldMethods.WriteString("\n\n" + content[oldStart:oldEnd])
+		newMethods.WriteString("\n\n" + method)
+		content = content[:oldStart] + method + content[oldEnd:]
+	}
+	return content, oldMethods.String(), newMethods.String(), nil
+}
+
+// refreshFinders replaces the unique key finders in content with the ones
+// generated for model, and adds the missing ones after Find. Finders for keys
+// the table no longer has are left in place. Returns the updated content and
+// the old and new methods.
+func (m *ModelManager) refreshFinders(content string, model *models.GeneratedModel) (string, string, string, error) {
+	finders := model.UniqueFinders()
+	if len(finders) == 0 {
+		return content, "", "", nil
+	}
+
+	templateContent, err := templates.Files.ReadFile("model.tmpl")
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to read model template: %w", err)
+	}
+	rendered, err := m.modelGenerator.GenerateModelFile(model, string(templateContent))
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to render model file: %w", err)
+	}
+
+	var oldMethods, newMethods strings.Builder
+	for _, finder := range finders {
+		newStart, newEnd, err := findFuncOffsets([]byte(rendered), model.NamespaceType, finder.Method)
+		if err != nil {
+			continue
+		}
+		method := rendered[newStart:newEnd]
+
+		if oldStart, oldEnd, err := findFuncOffsets([]byte(content), model.NamespaceType, finder.Method); err == nil {
+			if content[oldStart:oldEnd] == method {
+				continue
+			}
+			oldMethods.WriteString("\n\n" + content[oldStart:oldEnd])
+			content = content[:oldStart] + method + content[oldEnd:]
+		} else if _, findEnd, err := findFuncOffsets([]byte(content), model.NamespaceType, "Find"); err == nil {
+			content = content[:findEnd] + "\n\n" + method + content[findEnd:]
+		} else {
+			content = strings.TrimRight(content, "\n") + "\n\n" + method + "\n"
+		}
+		newMethods.WriteString("\n\n" + method)
+	}
+	return content, oldMethods.String(), newMethods.String(), nil
+}
+
 // ApplyModelUpdate writes the updated model and factory file content and runs the Go formatter.
 func (m *ModelManager) ApplyModelUpdate(result *UpdateModelResult) error {
+	if rootDir, err := m.fileManager.FindGoModRoot(); err == nil {
+		if err := ensureDecimalModule(rootDir, result.NewFileContent); err != nil {
+			return err
+		}
+	}
 	if err := os.WriteFile(result.ModelPath, []byte(result.NewFileContent), 0o600); err != nil {
 		return fmt.Errorf("failed to write model file: %w", err)
 	}
@@ -249,6 +456,15 @@ func (m *ModelManager) ApplyModelUpdate(result *UpdateModelResult) error {
 		return fmt.Errorf("failed to format model file: %w", err)
 	}
 
+	if result.EnumsHasChanges {
+		if err := os.WriteFile(result.EnumsPath, []byte(result.NewEnumsContent), 0o600); err != nil {
+			return fmt.Errorf("failed to write enums file: %w", err)
+		}
+		if err := files.FormatGoFile(result.EnumsPath); err != nil {
+			return fmt.Errorf("failed to format enums file: %w", err)
+		}
+	}
+
 	// Write updated factory file if we have new content
 	if result.NewFactoryContent != "" {
 		factoryDir := filepath.Dir(result.FactoryPath)
@@ -313,10 +529,11 @@ func parseEntityStruct(src []byte, entityName string) ([]parsedField, int, int,
 				}
 
 				fields = append(fields, parsedField{
-					Name:     fieldName,
-					TypeStr:  typeStr,
-					BunTag:   bunTag,
-					IsCustom: !standardGoTypes[typeStr],
+					Name:       fieldName,
+					TypeStr:    typeStr,
+					BunTag:     bunTag,
+					IsCustom:   !standardGoTypes[typeStr],
+					IsRelation: strings.HasPrefix(bunTag, "rel:"),
 				})
 			}
 
@@ -327,19 +544,39 @@ func parseEntityStruct(src []byte, entityName string) ([]parsedField, int, int,
 	return nil, 0, 0, fmt.Errorf("entity struct %q not found in file", entityName)
 }
 
-// renderEntityStruct generates the "type X struct { ... }" text for the given fields.
-func renderEntityStruct(entityName, tableName string, fields []models.GeneratedField) string {
+// renderEntityStruct generates the "type X struct { ... }" text for the given
+// fields, followed by the association fields.
+func renderEntityStruct(entityName, tableName string, fields []models.GeneratedField, associations []models.GeneratedAssociation) string {
 	var sb strings.Builder
 	fmt.Fprintf(&sb, "type %s struct {\n", entityName)
-	fmt.Fprintf(&sb, "\tbun.BaseModel `bun:\"table:%s,alias:%s\"`\n", tableName, tableName)
+	_, alias := catalog.SplitQualifiedName(tableName)
+	fmt.Fprintf(&sb, "\tbun.BaseModel `bun:\"table:%s,alias:%s\"`\n", tableName, alias)
 	sb.WriteString("\n")
 	for _, f := range fields {
 		fmt.Fprintf(&sb, "\t%s %s `bun:\"%s\"`\n", f.Name, f.Type, f.BunTag)
 	}
+	if len(associations) > 0 {
+		sb.WriteString("\n")
+		for _, a := range associations {
+			fmt.Fprintf(&sb, "\t%s %s `bun:\"%s\"`\n", a.FieldName, a.Type, a.BunTag)
+		}
+	}
 	sb.WriteString("}")
 	return sb.String()
 }
 
+// relationAssociation converts a parsed bun relation field back into an
+// association.
+func relationAssociation(f parsedField) models.GeneratedAssociation {
+	kind, _, _ := strings.Cut(strings.TrimPrefix(f.BunTag, "rel:"), ",")
+	return models.GeneratedAssociation{
+		Kind:      kind,
+		FieldName: f.Name,
+		Type:      f.TypeStr,
+		BunTag:    f.BunTag,
+	}
+}
+
 // renderCreateDataStruct generates the "type CreateXData struct { ... }" text.
 func renderCreateDataStruct(resourceName string, model *models.GeneratedModel) string {
 	var sb strings.Builder
@@ -349,7 +586,7 @@ func renderCreateDataStruct(resourceName string, model *models.GeneratedModel) s
 	}
 	fmt.Fprintf(&sb, "type Create%sData struct {\n", resourceName)
 	for _, f := range model.Fields {
-		if f.Name == idGoField || f.Name == "CreatedAt" || f.Name == "UpdatedAt" {
+		if f.Name == idGoField || f.IsAutoIncrement || f.IsGenerated || f.Name == "CreatedAt" || f.Name == "UpdatedAt" {
 			continue
 		}
 		fmt.Fprintf(&sb, "\t%s %s\n", f.Name, f.Type)
@@ -375,7 +612,7 @@ func renderUpdateDataStruct(resourceName string, model *models.GeneratedModel) s
 	fmt.Fprintf(&sb, "type Update%sData struct {\n", resourceName)
 	fmt.Fprintf(&sb, "\t%s %s\n", idGoField, idType)
 	for _, f := range model.Fields {
-		if f.Name == idGoField || f.Name == "CreatedAt" {
+		if f.Name == idGoField || f.IsAutoIncrement || f.IsGenerated || f.Name == "CreatedAt" {
 			continue
 		}
 		fmt.Fprintf(&sb, "\t%s %s\n", f.Name, f.Type)
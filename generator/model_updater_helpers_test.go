@@ -41,12 +41,15 @@ func TestUpdateModelResultDiffsAndStructHelpers(t *testing.T) {
 	rendered := renderEntityStruct("ProductEntity", "products", []models.GeneratedField{
 		{Name: "ID", Type: "uuid.UUID", BunTag: "id,pk,type:uuid"},
 		{Name: "Name", Type: "ProductName", BunTag: "name"},
+	}, []models.GeneratedAssociation{
+		{FieldName: "Reviews", Type: "[]ReviewEntity", BunTag: "rel:has-many,join:id=product_id"},
 	})
 	for _, want := range []string{
 		"type ProductEntity struct",
 		"bun.BaseModel `bun:\"table:products,alias:products\"`",
 		"ID uuid.UUID `bun:\"id,pk,type:uuid\"`",
 		"Name ProductName `bun:\"name\"`",
+		"\n\n\tReviews []ReviewEntity `bun:\"rel:has-many,join:id=product_id\"`",
 	} {
 		if !strings.Contains(rendered, want) {
 			t.Fatalf("rendered struct missing %q:\n%s", want, rendered)
@@ -0,0 +1,304 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// nullWrapper is a sql.Null or bun.Null type and the value it wraps.
+type nullWrapper struct {
+	base  string // Go type of the wrapped value, e.g. "string"
+	field string // Field holding the value, e.g. "String"
+}
+
+var nullWrappers = map[string]nullWrapper{
+	"sql.NullString":  {"string", "String"},
+	"sql.NullBool":    {"bool", "Bool"},
+	"sql.NullInt16":   {"int16", "Int16"},
+	"sql.NullInt32":   {"int32", "Int32"},
+	"sql.NullInt64":   {"int64", "Int64"},
+	"sql.NullFloat64": {"float64", "Float64"},
+	"sql.NullTime":    {"time.Time", "Time"},
+	"bun.NullString":  {"string", "String"},
+	"bun.NullBool":    {"bool", "Bool"},
+	"bun.NullInt32":   {"int32", "Int32"},
+	"bun.NullInt64":   {"int64", "Int64"},
+	"bun.NullFloat64": {"float64", "Float64"},
+	"bun.NullTime":    {"time.Time", "Time"},
+}
+
+// fakeFactoryDefault returns a realistic gofakeit value for a string, number,
+// bool, time or UUID field, chosen from its column name and from the CHECK
+// constraints of table, which may be nil. It reports false for other types,
+// which keep their type-based default.
+func fakeFactoryDefault(table *catalog.Table, field GeneratedField) (string, bool) {
+	base, wrapper := field.Type, nullWrapper{}
+	if w, ok := nullWrappers[field.Type]; ok {
+		base, wrapper = w.base, w
+	}
+
+	column := fakeColumnName(field)
+	constraints := fakeConstraints(table, column)
+
+	var value string
+	switch base {
+	case "string":
+		value = fakeString(column, constraints)
+	case "int16", "int32", "int64", "float32", "float64":
+		value = fakeNumber(base, column, constraints)
+	case "bool":
+		value = "gofakeit.Bool()"
+	case "time.Time":
+		value = fakeTime(column)
+	case "uuid.UUID":
+		value = "uuid.New()"
+	default:
+		return "", false
+	}
+
+	if wrapper.field != "" {
+		return fmt.Sprintf("%s{%s: %s, Valid: true}", field.Type, wrapper.field, value), true
+	}
+	return value, true
+}
+
+// fakeColumnName returns the column of field, read from its bun tag.
+func fakeColumnName(field GeneratedField) string {
+	if column, _, _ := strings.Cut(field.BunTag, ","); column != "" {
+		return column
+	}
+	return naming.ToSnakeCase(field.Name)
+}
+
+// columnConstraints are the limits a fake value of one column has to meet.
+type columnConstraints struct {
+	allowed  []string // Values of a CHECK ... IN list
+	min, max *int     // Bounds of CHECK comparisons and BETWEEN
+	length   int      // varchar(n) length, 0 when unlimited
+}
+
+func fakeConstraints(table *catalog.Table, column string) columnConstraints {
+	var constraints columnConstraints
+	if table == nil {
+		return constraints
+	}
+
+	if col, err := table.GetColumn(column); err == nil && col.Length != nil && isCharacterType(col.DataType) {
+		constraints.length = int(*col.Length)
+	}
+
+	setMin := func(bound int) {
+		if constraints.min == nil || bound > *constraints.min {
+			constraints.min = &bound
+		}
+	}
+	setMax := func(bound int) {
+		if constraints.max == nil || bound < *constraints.max {
+			constraints.max = &bound
+		}
+	}
+
+	for _, check := range table.Checks {
+		for _, condition := range splitConjunction(check.Expression) {
+			condition = trimParens(condition)
+			if m := checkComparison.FindStringSubmatch(condition); m != nil && strings.EqualFold(m[1], column) {
+				literal, err := strconv.ParseFloat(m[3], 64)
+				if err != nil {
+					continue
+				}
+				switch m[2] {
+				case ">":
+					setMin(int(math.Floor(literal)) + 1)
+				case ">=":
+					setMin(int(math.Ceil(literal)))
+				case "<":
+					setMax(int(math.Ceil(literal)) - 1)
+				case "<=":
+					setMax(int(math.Floor(literal)))
+				case "=":
+					setMin(int(math.Ceil(literal)))
+					setMax(int(math.Floor(literal)))
+				}
+			}
+			if m := checkBetween.FindStringSubmatch(condition); m != nil && strings.EqualFold(m[1], column) {
+				lower, lowerErr := strconv.ParseFloat(m[2], 64)
+				upper, upperErr := strconv.ParseFloat(m[3], 64)
+				if lowerErr == nil && upperErr == nil {
+					setMin(int(math.Ceil(lower)))
+					setMax(int(math.Floor(upper)))
+				}
+			}
+			if m := checkIn.FindStringSubmatch(condition); m != nil && strings.EqualFold(m[1], column) {
+				for _, quoted := range checkInValue.FindAllStringSubmatch(m[2], -1) {
+					constraints.allowed = append(constraints.allowed, strings.ReplaceAll(quoted[1], "''", "'"))
+				}
+			}
+		}
+	}
+	return constraints
+}
+
+// fakeString picks a gofakeit generator from the words of a column name. Text
+// generators are only used for columns that fit a sentence.
+func fakeString(column string, constraints columnConstraints) string {
+	if len(constraints.allowed) > 0 {
+		return strconv.Quote(constraints.allowed[0])
+	}
+
+	words := strings.Split(strings.ToLower(column), "_")
+	last := words[len(words)-1]
+	has := func(candidates ...string) bool {
+		for _, word := range words {
+			if slices.Contains(candidates, word) {
+				return true
+			}
+		}
+		return false
+	}
+	fitsText := constraints.length == 0 || constraints.length >= 255
+
+	switch {
+	case has("email"):
+		return "gofakeit.Email()"
+	case column == "first_name" || column == "firstname" || column == "given_name":
+		return "gofakeit.FirstName()"
+	case column == "last_name" || column == "lastname" || column == "surname" || column == "family_name":
+		return "gofakeit.LastName()"
+	case column == "username" || column == "user_name" || last == "handle":
+		return "gofakeit.Username()"
+	case column == "name" || column == "full_name" || column == "display_name" || column == "contact_name":
+		return "gofakeit.Name()"
+	case has("url", "website", "homepage", "link"):
+		return "gofakeit.URL()"
+	case has("phone", "mobile"):
+		return "gofakeit.Phone()"
+	case has("ip"):
+		return "gofakeit.IPv4Address()"
+	case has("domain", "hostname"):
+		return "gofakeit.DomainName()"
+	case has("password"):
+		return "gofakeit.Password(true, true, true, false, false, 16)"
+	case has("address", "street"):
+		return "gofakeit.Street()"
+	case last == "city":
+		return "gofakeit.City()"
+	case last == "state":
+		return "gofakeit.State()"
+	case has("zip", "zipcode", "postcode") || strings.HasSuffix(column, "postal_code"):
+		return "gofakeit.Zip()"
+	case column == "country_code":
+		return "gofakeit.CountryAbr()"
+	case last == "country":
+		return "gofakeit.Country()"
+	case has("currency"):
+		return "gofakeit.CurrencyShort()"
+	case has("timezone") || strings.HasSuffix(column, "time_zone"):
+		return "gofakeit.TimeZoneRegion()"
+	case fitsText && has("title", "subject", "headline", "description", "summary", "bio", "excerpt", "caption"):
+		return "gofakeit.Sentence()"
+	case fitsText && has("body", "content", "notes", "text", "message", "comment"):
+		return "gofakeit.Paragraph()"
+	}
+	return "gofakeit.Word()"
+}
+
+// fakeNumber returns a random number in the range of the column's CHECK
+// constraints, narrowed by a range typical for its name.
+func fakeNumber(goType, column string, constraints columnConstraints) string {
+	if goType == "float64" {
+		switch column {
+		case "latitude", "lat":
+			return "gofakeit.Latitude()"
+		case "longitude", "lng", "lon":
+			return "gofakeit.Longitude()"
+		}
+	}
+
+	lower, upper := fakeRange(column)
+	span := upper - lower
+	switch {
+	case constraints.min != nil && constraints.max != nil:
+		lower, upper = *constraints.min, *constraints.max
+	case constraints.min != nil:
+		lower = max(lower, *constraints.min)
+		if upper < lower {
+			upper = lower + span
+		}
+	case constraints.max != nil:
+		upper = min(upper, *constraints.max)
+		if lower > upper {
+			lower = upper - span
+		}
+	}
+	if upper < lower {
+		upper = lower
+	}
+
+	switch goType {
+	case "float32", "float64":
+		generator := "Float64Range"
+		if isMoneyColumn(column) {
+			generator = "Price"
+		}
+		value := fmt.Sprintf("gofakeit.%s(%d, %d)", generator, lower, upper)
+		if goType == "float32" {
+			return "float32(" + value + ")"
+		}
+		return value
+	}
+	return fmt.Sprintf("%s(gofakeit.IntRange(%d, %d))", goType, lower, upper)
+}
+
+// moneyWords are the column name words fakeRange gives a price range.
+var moneyWords = []string{"price", "amount", "cost", "total", "fee", "balance", "cents"}
+
+func isMoneyColumn(column string) bool {
+	for word := range strings.SplitSeq(strings.ToLower(column), "_") {
+		if slices.Contains(moneyWords, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// fakeTime returns a date in the future for columns named after deadlines
+// and schedules, and one in the past otherwise.
+func fakeTime(column string) string {
+	for word := range strings.SplitSeq(strings.ToLower(column), "_") {
+		switch word {
+		case "expires", "expiry", "due", "deadline", "scheduled", "starts", "ends", "until":
+			return "gofakeit.FutureDate()"
+		}
+	}
+	return "gofakeit.PastDate()"
+}
+
+// fakeRange returns a plausible range for a number column from its name.
+func fakeRange(column string) (int, int) {
+	for word := range strings.SplitSeq(strings.ToLower(column), "_") {
+		if slices.Contains(moneyWords, word) {
+			return 100, 10000
+		}
+		switch word {
+		case "quantity", "qty", "count", "stock":
+			return 1, 100
+		case "age":
+			return 18, 80
+		case "rating", "stars":
+			return 1, 5
+		case "score", "percent", "percentage":
+			return 0, 100
+		case "year":
+			return 2000, 2030
+		case "position", "rank":
+			return 1, 100
+		}
+	}
+	return 1, 1000
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func TestFakeFactoryDefaultFromColumnNames(t *testing.T) {
+	defaults := map[string]string{
+		"email:string":             "gofakeit.Email()",
+		"contact_email:string":     "gofakeit.Email()",
+		"first_name:string":        "gofakeit.FirstName()",
+		"name:string":              "gofakeit.Name()",
+		"product_name:string":      "gofakeit.Word()",
+		"avatar_url:string":        "gofakeit.URL()",
+		"phone_number:string":      "gofakeit.Phone()",
+		"ip_address:string":        "gofakeit.IPv4Address()",
+		"street_address:string":    "gofakeit.Street()",
+		"city:string":              "gofakeit.City()",
+		"postal_code:string":       "gofakeit.Zip()",
+		"country:string":           "gofakeit.Country()",
+		"title:string":             "gofakeit.Sentence()",
+		"body:string":              "gofakeit.Paragraph()",
+		"misc:string":              "gofakeit.Word()",
+		"bio:sql.NullString":       "sql.NullString{String: gofakeit.Sentence(), Valid: true}",
+		"price_cents:int64":        "int64(gofakeit.IntRange(100, 10000))",
+		"quantity:int32":           "int32(gofakeit.IntRange(1, 100))",
+		"age:int16":                "int16(gofakeit.IntRange(18, 80))",
+		"page:int32":               "int32(gofakeit.IntRange(1, 1000))",
+		"weight:float64":           "gofakeit.Float64Range(1, 1000)",
+		"latitude:float64":         "gofakeit.Latitude()",
+		"rating:bun.NullFloat64":   "bun.NullFloat64{Float64: gofakeit.Float64Range(1, 5), Valid: true}",
+		"amount:float64":           "gofakeit.Price(100, 10000)",
+		"is_active:bool":           "gofakeit.Bool()",
+		"published_at:time.Time":   "gofakeit.PastDate()",
+		"expires_at:sql.NullTime":  "sql.NullTime{Time: gofakeit.FutureDate(), Valid: true}",
+		"external_id:uuid.UUID":    "uuid.New()",
+		"nickname:*string":         "",
+		"settings:json.RawMessage": "",
+	}
+	for key, want := range defaults {
+		column, goType, _ := strings.Cut(key, ":")
+		got, ok := fakeFactoryDefault(nil, GeneratedField{Type: goType, BunTag: column + ",notnull"})
+		if got != want || ok != (want != "") {
+			t.Errorf("fakeFactoryDefault(%s) = %q, %v; want %q", key, got, ok, want)
+		}
+	}
+}
+
+func TestFakeFactoryDefaultFollowsConstraints(t *testing.T) {
+	table := tableWithColumns(t, "products",
+		catalog.NewColumn("status", "text").SetNotNull(),
+		catalog.NewColumn("price", "integer").SetNotNull(),
+		catalog.NewColumn("stock", "bigint").SetNotNull(),
+		catalog.NewColumn("discount", "integer").SetNotNull(),
+		catalog.NewColumn("rating", "double precision").SetNotNull(),
+		catalog.NewColumn("budget", "bigint").SetNotNull(),
+		catalog.NewColumn("summary", "varchar").SetLength(80).SetNotNull(),
+	)
+	for _, expression := range []string{
+		"status IN ('draft', 'live')",
+		"price > 0 AND price < 500",
+		"stock >= 1000",
+		"discount <= 0",
+		"(rating BETWEEN 0.5 AND 5)",
+		"budget BETWEEN 0 AND 1000000",
+	} {
+		if err := table.AddCheck(&catalog.Check{Expression: expression}); err != nil {
+			t.Fatalf("add check: %v", err)
+		}
+	}
+
+	g := NewGenerator("postgresql")
+	want := map[string]string{
+		"status":   `"draft"`,
+		"price":    "int32(gofakeit.IntRange(1, 499))",
+		"stock":    "int64(gofakeit.IntRange(1000, 1099))",
+		"discount": "int32(gofakeit.IntRange(-999, 0))",
+		"rating":   "gofakeit.Float64Range(1, 5)",
+		"budget":   "int64(gofakeit.IntRange(0, 1000000))",
+		"summary":  "gofakeit.Word()",
+	}
+	for _, col := range table.Columns {
+		field, err := g.buildField(col)
+		if err != nil {
+			t.Fatalf("buildField(%s): %v", col.Name, err)
+		}
+		got, _ := fakeFactoryDefault(table, field)
+		if got != want[col.Name] {
+			t.Errorf("fakeFactoryDefault(%s) = %q, want %q", col.Name, got, want[col.Name])
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+// Filter kinds of a GeneratedFilter.
+const (
+	FilterEquals = "equals" // Matches one value
+	FilterRange  = "range"  // Matches values after and before a time
+)
+
+// GeneratedFilter is one field of the generated XFilter struct.
+type GeneratedFilter struct {
+	Name   string // Go field of the filter, e.g. "Email" or "Created" for CreatedAfter and CreatedBefore
+	Column string // SQL column name
+	GoType string // Type compared with the column, e.g. "string", "uuid.UUID", "time.Time"
+	Kind   string // FilterEquals or FilterRange
+}
+
+// Pointer reports whether the filter field is a pointer, nil when unset.
+// Strings use "" and times the zero time instead.
+func (f GeneratedFilter) Pointer() bool {
+	return f.GoType != "string" && f.GoType != "time.Time"
+}
+
+// filterBaseTypes maps nullable Go types to the type a filter compares with.
+var filterBaseTypes = map[string]string{
+	"sql.NullString":  "string",
+	"sql.NullBool":    "bool",
+	"sql.NullInt16":   "int16",
+	"sql.NullInt32":   "int32",
+	"sql.NullInt64":   "int64",
+	"sql.NullFloat64": "float64",
+	"sql.NullTime":    "time.Time",
+	"bun.NullString":  "string",
+	"bun.NullBool":    "bool",
+	"bun.NullInt32":   "int32",
+	"bun.NullInt64":   "int64",
+	"bun.NullFloat64": "float64",
+	"bun.NullTime":    "time.Time",
+	"uuid.NullUUID":   "uuid.UUID",
+}
+
+// BuildFilters returns the filter fields of a model: every unique or indexed
+// column, so a filtered query can use an index, and created_at. Times filter
+// by range and other scalar types by equality; the single-column primary key,
+// deleted_at, and JSON, array, and project types are left out.
+func BuildFilters(table *catalog.Table, fields []GeneratedField, idColumn string) []GeneratedFilter {
+	indexed := map[string]bool{"created_at": true}
+	for _, index := range table.Indexes {
+		for _, column := range index.Columns {
+			indexed[column] = true
+		}
+	}
+	for _, col := range table.Columns {
+		if col.IsUnique || col.IsPrimaryKey {
+			indexed[col.Name] = true
+		}
+	}
+
+	var filters []GeneratedFilter
+	for _, field := range fields {
+		column, _, _ := strings.Cut(field.BunTag, ",")
+		if !indexed[column] || column == idColumn || field.IsSoftDelete || field.JSONType != "" || field.ProjectType != nil {
+			continue
+		}
+
+		goType, ok := filterType(field)
+		if !ok {
+			continue
+		}
+		filter := GeneratedFilter{Name: field.Name, Column: column, GoType: goType, Kind: FilterEquals}
+		if goType == "time.Time" {
+			filter.Name = strings.TrimSuffix(field.Name, "At")
+			filter.Kind = FilterRange
+		}
+		// A field named Scope would clash with the Scope method.
+		if filter.Name == "Scope" {
+			continue
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
+// filterType returns the type a filter on field compares with, reporting
+// false for types that have no simple equality.
+func filterType(field GeneratedField) (string, bool) {
+	if field.Enum != nil {
+		return field.Enum.Name, true
+	}
+
+	goType := strings.TrimPrefix(field.Type, "*")
+	if base, ok := filterBaseTypes[goType]; ok {
+		goType = base
+	}
+	switch goType {
+	case "string", "bool", "int16", "int32", "int64", "float32", "float64", "uuid.UUID", "time.Time":
+		return goType, true
+	}
+	return "", false
+}
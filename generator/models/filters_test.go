@@ -0,0 +1,63 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func TestBuildFiltersUsesIndexedColumns(t *testing.T) {
+	table := tableWithColumns(t, "posts",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("slug", "text").SetUnique(),
+		catalog.NewColumn("author_id", "uuid"),
+		catalog.NewColumn("status", "post_status"),
+		catalog.NewColumn("title", "text"),
+		catalog.NewColumn("published_at", "timestamptz"),
+		catalog.NewColumn("settings", "jsonb"),
+		catalog.NewColumn("view_count", "integer"),
+		catalog.NewColumn("created_at", "timestamptz"),
+		catalog.NewColumn("deleted_at", "timestamptz"),
+	)
+	for _, index := range []*catalog.Index{
+		{Name: "posts_author_status_idx", Columns: []string{"author_id", "status"}},
+		{Name: "posts_published_at_idx", Columns: []string{"published_at"}},
+		{Name: "posts_settings_idx", Columns: []string{"settings"}},
+		{Name: "posts_deleted_at_idx", Columns: []string{"deleted_at"}},
+	} {
+		if err := table.AddIndex(index); err != nil {
+			t.Fatalf("add index: %v", err)
+		}
+	}
+
+	fields := []GeneratedField{
+		{Name: "ID", Type: "uuid.UUID", BunTag: "id,pk"},
+		{Name: "Slug", Type: "string", BunTag: "slug,notnull"},
+		{Name: "AuthorID", Type: "uuid.NullUUID", BunTag: "author_id"},
+		{Name: "Status", Type: "*PostStatus", BunTag: "status", Enum: &GeneratedEnum{Name: "PostStatus"}},
+		{Name: "Title", Type: "string", BunTag: "title,notnull"},
+		{Name: "PublishedAt", Type: "sql.NullTime", BunTag: "published_at"},
+		{Name: "Settings", Type: "json.RawMessage", BunTag: "settings"},
+		{Name: "ViewCount", Type: "int32", BunTag: "view_count,notnull"},
+		{Name: "CreatedAt", Type: "time.Time", BunTag: "created_at,notnull"},
+		{Name: "DeletedAt", Type: "sql.NullTime", BunTag: "deleted_at,soft_delete,nullzero", IsSoftDelete: true},
+	}
+
+	want := []GeneratedFilter{
+		{Name: "Slug", Column: "slug", GoType: "string", Kind: FilterEquals},
+		{Name: "AuthorID", Column: "author_id", GoType: "uuid.UUID", Kind: FilterEquals},
+		{Name: "Status", Column: "status", GoType: "PostStatus", Kind: FilterEquals},
+		{Name: "Published", Column: "published_at", GoType: "time.Time", Kind: FilterRange},
+		{Name: "Created", Column: "created_at", GoType: "time.Time", Kind: FilterRange},
+	}
+	if got := BuildFilters(table, fields, "id"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("BuildFilters() = %+v\nwant %+v", got, want)
+	}
+
+	for _, filter := range want {
+		if pointer := filter.GoType == "uuid.UUID" || filter.GoType == "PostStatus"; filter.Pointer() != pointer {
+			t.Errorf("%s.Pointer() = %v, want %v", filter.Name, filter.Pointer(), pointer)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"go/token"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// GeneratedFinder is a method that finds the row with the given values of a
+// unique key, e.g. FindByEmail for UNIQUE (email).
+type GeneratedFinder struct {
+	Method string         // e.g. "FindByTenantIDAndEmail"
+	Keys   []GeneratedKey // Key columns in constraint order
+}
+
+// finderLocals are the names the finder body declares or takes besides the
+// key arguments.
+var finderLocals = map[string]bool{"ctx": true, "db": true, "query": true, "entity": true, "err": true}
+
+// BuildFinders returns a finder for every unique column and unique index of
+// the table other than the primary key. Partial and expression indexes are
+// left out, since the columns alone do not identify a row.
+func BuildFinders(table *catalog.Table, model *GeneratedModel) []GeneratedFinder {
+	if !model.HasPrimaryKey {
+		return nil
+	}
+
+	var finders []GeneratedFinder
+	for _, columns := range uniqueKeys(table, model.IDFieldName) {
+		if model.HasCompositeKey && isPrimaryKey(model, columns) {
+			continue
+		}
+
+		finder := GeneratedFinder{Method: "FindBy"}
+		for i, column := range columns {
+			field, ok := fieldForColumn(model.Fields, column)
+			if !ok {
+				finder.Keys = nil
+				break
+			}
+			arg := naming.ToLowerCamelCase(field.Name)
+			if token.IsKeyword(arg) || finderLocals[arg] {
+				arg += "Value"
+			}
+			finder.Keys = append(finder.Keys, GeneratedKey{
+				Column:  column,
+				GoField: field.Name,
+				GoType:  finderType(field),
+				ArgName: arg,
+			})
+			if i > 0 {
+				finder.Method += "And"
+			}
+			finder.Method += field.Name
+		}
+		if len(finder.Keys) > 0 {
+			finders = append(finders, finder)
+		}
+	}
+	return finders
+}
+
+// UniqueFinders returns the finders that do not share a name with the
+// FindBy method of a belongs-to association, which returns every row with a
+// foreign key.
+func (m GeneratedModel) UniqueFinders() []GeneratedFinder {
+	var finders []GeneratedFinder
+	for _, finder := range m.Finders {
+		shadowed := false
+		for _, association := range m.Associations {
+			if association.IsBelongsTo() && finder.Method == "FindBy"+association.ForeignKeyField {
+				shadowed = true
+				break
+			}
+		}
+		if !shadowed {
+			finders = append(finders, finder)
+		}
+	}
+	return finders
+}
+
+// finderType is the argument type of a key column: the field's type, or
+// for a nullable field the type it wraps, since NULL never equals a value.
+func finderType(field GeneratedField) string {
+	if !field.IsNullable {
+		return field.Type
+	}
+	goType := strings.TrimPrefix(field.Type, "*")
+	if base, ok := filterBaseTypes[goType]; ok {
+		return base
+	}
+	return goType
+}
+
+func isPrimaryKey(model *GeneratedModel, columns []string) bool {
+	keys := make([]string, 0, len(model.PrimaryKeys))
+	for _, key := range model.PrimaryKeys {
+		keys = append(keys, key.Column)
+	}
+	return sameColumns(keys, columns)
+}
+
+func fieldForColumn(fields []GeneratedField, column string) (GeneratedField, bool) {
+	for _, field := range fields {
+		if name, _, _ := strings.Cut(field.BunTag, ","); name == column {
+			return field, true
+		}
+	}
+	return GeneratedField{}, false
+}
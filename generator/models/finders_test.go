@@ -0,0 +1,63 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func TestBuildFinders(t *testing.T) {
+	table := tableWithColumns(t, "users",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("email", "text").SetUnique(),
+		catalog.NewColumn("tenant_id", "uuid"),
+		catalog.NewColumn("type", "text"),
+		catalog.NewColumn("handle", "text"),
+	)
+	for _, index := range []*catalog.Index{
+		{Name: "users_tenant_type_key", Columns: []string{"tenant_id", "type"}, IsUnique: true},
+		{Name: "users_handle_key", Columns: []string{"handle"}, IsUnique: true, Partial: true},
+		{Name: "users_tenant_idx", Columns: []string{"tenant_id"}},
+	} {
+		if err := table.AddIndex(index); err != nil {
+			t.Fatalf("add index: %v", err)
+		}
+	}
+	model := &GeneratedModel{
+		HasPrimaryKey: true,
+		IDFieldName:   "id",
+		Fields: []GeneratedField{
+			{Name: "ID", Type: "uuid.UUID", BunTag: "id,pk,type:uuid"},
+			{Name: "Email", Type: "string", BunTag: "email"},
+			{Name: "TenantID", Type: "uuid.UUID", BunTag: "tenant_id"},
+			{Name: "Type", Type: "string", BunTag: "type"},
+			{Name: "Handle", Type: "string", BunTag: "handle"},
+		},
+	}
+
+	want := []GeneratedFinder{
+		{Method: "FindByEmail", Keys: []GeneratedKey{{Column: "email", GoField: "Email", GoType: "string", ArgName: "email"}}},
+		{Method: "FindByTenantIDAndType", Keys: []GeneratedKey{
+			{Column: "tenant_id", GoField: "TenantID", GoType: "uuid.UUID", ArgName: "tenantID"},
+			{Column: "type", GoField: "Type", GoType: "string", ArgName: "typeValue"},
+		}},
+	}
+	if got := BuildFinders(table, model); !reflect.DeepEqual(got, want) {
+		t.Fatalf("BuildFinders() = %+v, want %+v", got, want)
+	}
+}
+
+func TestUniqueFindersSkipsBelongsToFinders(t *testing.T) {
+	model := GeneratedModel{
+		Finders: []GeneratedFinder{{Method: "FindByUserID"}, {Method: "FindBySlug"}},
+		Associations: []GeneratedAssociation{
+			{Kind: AssociationBelongsTo, Name: "User", ForeignKeyField: "UserID"},
+		},
+	}
+
+	got := model.UniqueFinders()
+	if len(got) != 1 || got[0].Method != "FindBySlug" {
+		t.Fatalf("UniqueFinders() = %+v, want only FindBySlug", got)
+	}
+}
@@ -3,12 +3,15 @@ package models
 
 import (
 	"fmt"
+	"go/token"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"text/template"
 
-	"github.com/jinzhu/inflection"
 	"github.com/mbvlabs/andurel/generator/files"
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
 	"github.com/mbvlabs/andurel/generator/internal/ddl"
@@ -19,18 +22,91 @@ import (
 	"github.com/mbvlabs/andurel/pkg/constants"
 	"github.com/mbvlabs/andurel/pkg/errors"
 	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/mbvlabs/andurel/pkg/templatefuncs"
 )
 
 // GeneratedField describes one model field derived from a database column.
 type GeneratedField struct {
-	Name         string
-	Type         string
-	Comment      string
-	Package      string
-	BunTag       string // Full bun struct tag (e.g., `bun:"id,pk,type:uuid"`)
-	IsForeignKey bool
-	IsNullable   bool
-	IsPrimaryKey bool
+	Name            string
+	Type            string
+	Comment         string
+	Package         string
+	BunTag          string // Full bun struct tag (e.g., `bun:"id,pk,type:uuid"`)
+	IsForeignKey    bool
+	References      string // Foreign key target and actions, e.g. "users(id) ON DELETE CASCADE"
+	IsNullable      bool
+	IsPrimaryKey    bool
+	IsAutoIncrement bool                // serial or identity column filled by the database on insert
+	IsGenerated     bool                // GENERATED ALWAYS AS (...) STORED column computed by the database
+	IsSoftDelete    bool                // The nullable deleted_at timestamp managed by SoftDestroy and Restore
+	Enum            *GeneratedEnum      // Set when the column uses a Postgres enum type
+	JSONType        string              // Struct a json/jsonb column is decoded into (e.g., "UserSettings")
+	ProjectType     *types.TypeOverride // Set when andurel.types.yaml maps the column to a named type
+}
+
+// GeneratedEnum is the Go string type generated for a Postgres enum type.
+type GeneratedEnum struct {
+	Name    string // Go type (e.g., "PostStatus")
+	SQLName string // Enum type, schema-qualified outside the default schema (e.g., "post_status")
+	Values  []GeneratedEnumValue
+}
+
+// GeneratedEnumValue is one label of a generated enum.
+type GeneratedEnumValue struct {
+	Name  string // Go constant (e.g., "PostStatusDraft")
+	Value string // Enum label (e.g., "draft")
+}
+
+// GeneratedDependent is a foreign key of another table that references the
+// model's primary key.
+type GeneratedDependent struct {
+	Table    string // Referencing table (e.g., "comments")
+	Column   string // Referencing column (e.g., "post_id")
+	OnDelete string // ON DELETE action (e.g., "CASCADE", "NO ACTION")
+	Blocks   bool   // The action keeps the record from being deleted while rows reference it
+}
+
+// GeneratedKey is one column of a composite primary key.
+type GeneratedKey struct {
+	Column  string // SQL column name (e.g., "user_id")
+	GoField string // Go struct field name (e.g., "UserID")
+	GoType  string // Go field type (e.g., "uuid.UUID")
+	ArgName string // Parameter name in Find and Destroy (e.g., "userID")
+}
+
+// Association kinds supported by the model generator.
+const (
+	AssociationBelongsTo = "belongs-to"
+	AssociationHasMany   = "has-many"
+)
+
+// Associations names the models a generated model is related to, e.g.
+// BelongsTo: []string{"Post"} for a Comment with a post_id column.
+type Associations struct {
+	BelongsTo []string
+	HasMany   []string
+}
+
+// IsEmpty reports whether no associations were requested.
+func (a Associations) IsEmpty() bool {
+	return len(a.BelongsTo) == 0 && len(a.HasMany) == 0
+}
+
+// GeneratedAssociation describes one bun relation of a generated model.
+type GeneratedAssociation struct {
+	Kind            string // AssociationBelongsTo or AssociationHasMany
+	Name            string // Related model (e.g., "Post")
+	FieldName       string // Struct field (e.g., "Post", "Comments")
+	Type            string // Field type (e.g., "*PostEntity", "[]CommentEntity")
+	BunTag          string // Relation tag (e.g., "rel:belongs-to,join:post_id=id")
+	ForeignKey      string // Foreign key column (e.g., "post_id")
+	ForeignKeyField string // Go field of the foreign key on this model (belongs-to only)
+	ForeignKeyType  string // Go type of ForeignKeyField (belongs-to only)
+}
+
+// IsBelongsTo reports whether the association is a belongs-to relation.
+func (a GeneratedAssociation) IsBelongsTo() bool {
+	return a.Kind == AssociationBelongsTo
 }
 
 // GeneratedModel contains the template data for a generated model file.
@@ -39,26 +115,66 @@ type GeneratedModel struct {
 	PluralName          string // The pluralized form of Name for function names (respects --table-name override)
 	Package             string
 	Fields              []GeneratedField
+	Associations        []GeneratedAssociation
+	Enums               []GeneratedEnum       // Enums used by Fields, validated by Entity.Validate
+	Validations         []GeneratedValidation // Column constraints checked by the data structs' Validate
 	StandardImports     []string
 	ExternalImports     []string
 	Imports             []string
 	TableName           string
+	TableAlias          string // TableName without its schema
 	TableNameOverride   string
 	TableNameOverridden bool
 	ModulePath          string
 	DatabaseType        string
+	DatabaseName        string // Connection named in DATABASES that stores the table, empty for the primary database
 	IDType              string // "uuid.UUID", "int32", "int64", "string"
 	IDGoType            string // Same as IDType (for template clarity)
-	IsAutoIncrementID   bool   // True for serial/bigserial
+	IsAutoIncrementID   bool   // True for serial/bigserial and identity keys
 	IDFieldName         string // SQL column name of PK (e.g., "id", "user_id")
 	IDGoFieldName       string // Go struct field name of PK (e.g., "ID", "UserID")
 	HasPrimaryKey       bool   // Whether the table has any primary key
+	ReadOnly            bool   // Backed by a view, so only the query methods are generated
+	Materialized        bool   // Backed by a materialized view, which Refresh recomputes
 	EntityName          string // ServerEntity (resource name + "Entity")
 	NamespaceVar        string // Server (exported, package-scope)
 	NamespaceType       string // server (unexported receiver type)
 	ReceiverName        string // s (for the namespace methods)
 	HasCreatedAt        bool
 	HasUpdatedAt        bool
+	HasSoftDelete       bool              // Table has a nullable deleted_at timestamp
+	HasCursorPagination bool              // created_at is NOT NULL and the key is one column, so PaginateAfter can seek on both
+	HasCompositeKey     bool              // Keyed by more than one column, e.g. a join table; the ID fields are then empty
+	Filters             []GeneratedFilter // Fields of the XFilter struct, from indexed columns
+	ConflictColumns     []string          // ON CONFLICT target of Upsert, e.g. ["email"]
+	Finders             []GeneratedFinder // FindBy methods for the unique keys, see UniqueFinders
+	// PrimaryKeys lists the key columns of a composite key.
+	PrimaryKeys []GeneratedKey
+	// Dependents are the foreign keys that reference the primary key, which
+	// decide what deleting a record does to the rows that reference it.
+	Dependents []GeneratedDependent
+	// Warnings describe foreign keys that point at tables no migration
+	// creates.
+	Warnings []string
+}
+
+// HasBlockingDependents reports whether a foreign key keeps records from
+// being deleted while rows reference them.
+func (m GeneratedModel) HasBlockingDependents() bool {
+	return slices.ContainsFunc(m.Dependents, func(d GeneratedDependent) bool { return d.Blocks })
+}
+
+// GeneratedColumns returns the columns the database computes, which the
+// model reads but leaves out of its inserts.
+func (m GeneratedModel) GeneratedColumns() []string {
+	var columns []string
+	for _, field := range m.Fields {
+		if field.IsGenerated {
+			column, _, _ := strings.Cut(field.BunTag, ",")
+			columns = append(columns, column)
+		}
+	}
+	return columns
 }
 
 // Config controls model generation for a database table.
@@ -69,9 +185,13 @@ type Config struct {
 	DatabaseType      string
 	ModulePath        string
 	NullType          string
-	CustomTypes       []types.TypeOverride
-	PrimaryKeyColumn  string // Override PK column name (empty = auto-detect)
-	GenerateWithoutPK bool   // Force generation without PK handling
+	DecimalType       string               // "float64" (default) or "decimal"
+	CustomTypes       []types.TypeOverride // mappings from andurel.types.yaml
+	PrimaryKeyColumn  string               // Override PK column name (empty = auto-detect)
+	GenerateWithoutPK bool                 // Force generation without PK handling
+	Associations      Associations
+	JSONTypes         map[string]string // json/jsonb column → struct declared in the models package
+	ConflictColumns   []string          // Upsert conflict target (empty = inferred, see BuildConflictColumns)
 }
 
 // BunModelConfig holds configuration for bun model generation
@@ -121,13 +241,21 @@ func (g *Generator) BuildCatalogFromMigrations(tableName string, migrationDirs [
 func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel, error) {
 	table, err := cat.GetTable("", config.TableName)
 	if err != nil {
-		return nil, errors.NewDatabaseError("get table", config.TableName, err)
+		view, viewErr := cat.GetView("", config.TableName)
+		if viewErr != nil {
+			return nil, errors.NewDatabaseError("get table", config.TableName, err)
+		}
+		if err := checkViewColumns(view); err != nil {
+			return nil, err
+		}
+		table = view
 	}
 
-	g.typeMapper.Overrides = append(g.typeMapper.Overrides, config.CustomTypes...)
+	g.typeMapper.ProjectTypes = config.CustomTypes
 	if config.NullType != "" {
 		g.typeMapper.NullType = config.NullType
 	}
+	g.typeMapper.DecimalType = config.DecimalType
 
 	entityName := config.ResourceName + "Entity"
 	namespaceVar := config.ResourceName
@@ -136,13 +264,17 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 
 	model := &GeneratedModel{
 		Name:            config.ResourceName,
-		PluralName:      inflection.Plural(config.ResourceName),
+		PluralName:      naming.Plural(config.ResourceName),
 		EntityName:      entityName,
 		NamespaceVar:    namespaceVar,
 		NamespaceType:   namespaceType,
 		ReceiverName:    receiverName,
 		Package:         config.PackageName,
 		TableName:       config.TableName,
+		TableAlias:      table.Name,
+		DatabaseName:    cat.Database,
+		ReadOnly:        table.View,
+		Materialized:    table.Materialized,
 		ModulePath:      config.ModulePath,
 		DatabaseType:    g.typeMapper.GetDatabaseType(),
 		Fields:          make([]GeneratedField, 0, len(table.Columns)),
@@ -157,6 +289,7 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 	importSet := make(map[string]bool)
 	importSet["context"] = true
 	importSet["errors"] = true
+	importSet["fmt"] = true
 	importSet["time"] = true
 	importSet["github.com/uptrace/bun"] = true
 	if config.ModulePath != "" {
@@ -164,11 +297,35 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 		importSet[config.ModulePath+"/internal/validation"] = true
 	}
 
+	if err := validateJSONTypes(table, config.JSONTypes); err != nil {
+		return nil, err
+	}
+
+	enumsByType := make(map[string]*GeneratedEnum)
+	for _, enum := range BuildEnums(cat) {
+		g.typeMapper.Overrides = append(g.typeMapper.Overrides, types.TypeOverride{
+			DatabaseType: enum.SQLName,
+			GoType:       enum.Name,
+		})
+		enumsByType[enum.Name] = &enum
+	}
+
 	for _, col := range table.Columns {
 		field, err := g.buildField(col)
 		if err != nil {
 			return nil, errors.NewGeneratorError("build field", col.Name, err)
 		}
+		if jsonType, ok := config.JSONTypes[col.Name]; ok {
+			setJSONType(&field, jsonType)
+		} else if custom, ok := g.typeMapper.ProjectType(col.DataType); ok && strings.TrimPrefix(field.Type, "*") == custom.GoType {
+			field.ProjectType = &custom
+		}
+		if enum, ok := enumsByType[strings.TrimPrefix(field.Type, "*")]; ok {
+			field.Enum = enum
+			if !slices.ContainsFunc(model.Enums, func(e GeneratedEnum) bool { return e.Name == enum.Name }) {
+				model.Enums = append(model.Enums, *enum)
+			}
+		}
 
 		if field.Package != "" {
 			importSet[field.Package] = true
@@ -178,11 +335,24 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 		for imp := range typeImports {
 			importSet[imp] = true
 		}
+		if strings.Contains(field.Type, types.MoneyGoType) && config.ModulePath != "" {
+			importSet[config.ModulePath+"/internal/money"] = true
+		}
+		if strings.Contains(field.Type, types.IntervalGoType) && config.ModulePath != "" {
+			importSet[config.ModulePath+"/internal/interval"] = true
+		}
+
+		if isSoftDeleteColumn(col, field) {
+			field.IsSoftDelete = true
+			field.BunTag += ",soft_delete,nullzero"
+			model.HasSoftDelete = true
+		}
 
 		model.Fields = append(model.Fields, field)
 
 		if col.Name == "created_at" {
 			model.HasCreatedAt = true
+			model.HasCursorPagination = field.Type == "time.Time"
 		}
 		if col.Name == "updated_at" {
 			model.HasUpdatedAt = true
@@ -193,6 +363,8 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 	// 1. Use config override if provided
 	// 2. Look for column named "id" that is primary key
 	// 3. Fall back to any column with IsPrimaryKey flag
+	// Without an override, a multi-column primary key keys the model by all
+	// of its columns.
 	if config.PrimaryKeyColumn != "" {
 		col := findColumn(table, config.PrimaryKeyColumn)
 		if col != nil {
@@ -201,6 +373,8 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 			model.IDGoFieldName = types.FormatFieldName(col.Name)
 			model.HasPrimaryKey = true
 		}
+	} else if pkColumns := table.GetPrimaryKeyColumns(); !config.GenerateWithoutPK && len(pkColumns) > 1 {
+		setModelCompositePK(model, pkColumns)
 	} else if !config.GenerateWithoutPK {
 		for _, col := range table.Columns {
 			if col.Name == "id" && col.IsPrimaryKey {
@@ -228,6 +402,48 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 		importSet["github.com/google/uuid"] = true
 	}
 
+	model.HasCursorPagination = model.HasCursorPagination && model.HasPrimaryKey && !model.HasCompositeKey
+	if model.HasCursorPagination {
+		importSet["encoding/base64"] = true
+		importSet["encoding/json"] = true
+	}
+
+	idColumn := ""
+	if model.HasPrimaryKey && !model.HasCompositeKey {
+		idColumn = model.IDFieldName
+	}
+	model.Filters = BuildFilters(table, model.Fields, idColumn)
+
+	conflictColumns, err := BuildConflictColumns(table, model, config.ConflictColumns)
+	if err != nil {
+		return nil, err
+	}
+	model.ConflictColumns = conflictColumns
+	model.Finders = BuildFinders(table, model)
+	for _, finder := range model.Finders {
+		for _, key := range finder.Keys {
+			for imp := range g.addModelTypeImports(key.GoType) {
+				importSet[imp] = true
+			}
+		}
+	}
+
+	model.Warnings = unknownReferences(cat, table)
+	if model.HasPrimaryKey && !model.HasCompositeKey {
+		model.Dependents = buildDependents(cat, table, model.IDFieldName)
+	}
+
+	associations, err := buildAssociations(cat, table, model, config.Associations)
+	if err != nil {
+		return nil, errors.NewGeneratorError("build associations", config.TableName, err)
+	}
+	model.Associations = associations
+
+	model.Validations = BuildValidations(table, model.Fields)
+	for imp := range validationImports(model.Validations) {
+		importSet[imp] = true
+	}
+
 	stdImports, extImports := groupAndSortImports(importSet)
 	model.StandardImports = stdImports
 	model.ExternalImports = extImports
@@ -238,6 +454,214 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedModel,
 	return model, nil
 }
 
+// BuildEnums returns the Go types for the enum types in cat, ordered by
+// schema and name. Enums outside the default schema are qualified with their
+// schema, both in SQL and in the Go type name.
+func BuildEnums(cat *catalog.Catalog) []GeneratedEnum {
+	schemaNames := make([]string, 0, len(cat.Schemas))
+	for name := range cat.Schemas {
+		schemaNames = append(schemaNames, name)
+	}
+	sort.Strings(schemaNames)
+
+	var enums []GeneratedEnum
+	for _, schemaName := range schemaNames {
+		schemaEnums, err := cat.ListEnums(schemaName)
+		if err != nil {
+			continue
+		}
+		for _, enum := range schemaEnums {
+			sqlName := enum.Name
+			if schemaName != cat.DefaultSchema {
+				sqlName = schemaName + "." + enum.Name
+			}
+			goName := types.FormatFieldName(strings.ReplaceAll(strings.ToLower(sqlName), ".", "_"))
+
+			generated := GeneratedEnum{Name: goName, SQLName: strings.ToLower(sqlName)}
+			seen := make(map[string]bool, len(enum.Values))
+			for i, value := range enum.Values {
+				name := goName + enumValueName(value)
+				if name == goName || seen[name] {
+					name = fmt.Sprintf("%sValue%d", goName, i+1)
+				}
+				seen[name] = true
+				generated.Values = append(generated.Values, GeneratedEnumValue{Name: name, Value: value})
+			}
+			enums = append(enums, generated)
+		}
+	}
+
+	return enums
+}
+
+// enumValueName turns an enum label into the suffix of its Go constant,
+// e.g. "in-review" into "InReview". Characters that cannot appear in a Go
+// identifier are dropped.
+func enumValueName(value string) string {
+	parts := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return types.FormatFieldName(strings.Join(parts, "_"))
+}
+
+// buildAssociations resolves the requested associations against the
+// catalog. A belongs-to needs a <model>_id column on the table, and a has-many
+// needs a <this model>_id column on the related model's table.
+func buildAssociations(
+	cat *catalog.Catalog,
+	table *catalog.Table,
+	model *GeneratedModel,
+	requested Associations,
+) ([]GeneratedAssociation, error) {
+	var associations []GeneratedAssociation
+	fieldNames := make(map[string]bool, len(model.Fields))
+	for _, field := range model.Fields {
+		fieldNames[field.Name] = true
+	}
+	addField := func(name string) error {
+		if fieldNames[name] {
+			return fmt.Errorf("association field %s conflicts with an existing field", name)
+		}
+		fieldNames[name] = true
+		return nil
+	}
+
+	for _, name := range requested.BelongsTo {
+		foreignKey := naming.ToSnakeCase(name) + "_id"
+		col := findColumn(table, foreignKey)
+		if col == nil {
+			return nil, fmt.Errorf("belongs-to %s requires a %s column on %s", name, foreignKey, table.Name)
+		}
+		if err := addField(name); err != nil {
+			return nil, err
+		}
+
+		foreignKeyField := types.FormatFieldName(foreignKey)
+		var foreignKeyType string
+		for _, field := range model.Fields {
+			if field.Name == foreignKeyField {
+				foreignKeyType = field.Type
+			}
+		}
+
+		associations = append(associations, GeneratedAssociation{
+			Kind:            AssociationBelongsTo,
+			Name:            name,
+			FieldName:       name,
+			Type:            "*" + name + "Entity",
+			BunTag:          fmt.Sprintf("rel:belongs-to,join:%s=%s", foreignKey, referencedColumn(col)),
+			ForeignKey:      foreignKey,
+			ForeignKeyField: foreignKeyField,
+			ForeignKeyType:  foreignKeyType,
+		})
+	}
+
+	for _, name := range requested.HasMany {
+		relatedTable := naming.DeriveTableName(name)
+		related, err := cat.GetTable("", relatedTable)
+		if err != nil {
+			return nil, fmt.Errorf("has-many %s requires a %s table: %w", name, relatedTable, err)
+		}
+		foreignKey := naming.ToSnakeCase(model.Name) + "_id"
+		col := findColumn(related, foreignKey)
+		if col == nil {
+			return nil, fmt.Errorf("has-many %s requires a %s column on %s", name, foreignKey, relatedTable)
+		}
+		fieldName := naming.Plural(name)
+		if err := addField(fieldName); err != nil {
+			return nil, err
+		}
+
+		associations = append(associations, GeneratedAssociation{
+			Kind:       AssociationHasMany,
+			Name:       name,
+			FieldName:  fieldName,
+			Type:       "[]" + name + "Entity",
+			BunTag:     fmt.Sprintf("rel:has-many,join:%s=%s", referencedColumn(col), foreignKey),
+			ForeignKey: foreignKey,
+		})
+	}
+
+	return associations, nil
+}
+
+// checkViewColumns reports the columns of a view whose name or type could
+// not be read from its SELECT list.
+func checkViewColumns(view *catalog.Table) error {
+	for i, col := range view.Columns {
+		switch {
+		case col.Name == ddl.UnnamedViewColumn:
+			return fmt.Errorf(
+				"column %d of view %s has no name: give it an alias (expression AS name) in the view definition",
+				i+1,
+				view.Name,
+			)
+		case col.DataType == "":
+			return fmt.Errorf(
+				"cannot infer the type of column %s of view %s: cast it in the view definition (expression::type) or read the view from the database with --from-db",
+				col.Name,
+				view.Name,
+			)
+		}
+	}
+	return nil
+}
+
+// unknownReferences describes the foreign keys of table that point at
+// tables the catalog does not have.
+func unknownReferences(cat *catalog.Catalog, table *catalog.Table) []string {
+	var warnings []string
+	for _, col := range table.Columns {
+		if col.ForeignKey == nil {
+			continue
+		}
+		schemaName, tableName, qualified := strings.Cut(col.ForeignKey.ReferencedTable, ".")
+		if !qualified {
+			schemaName, tableName = "", schemaName
+		}
+		if _, err := cat.GetTable(schemaName, tableName); err != nil {
+			warnings = append(warnings, fmt.Sprintf(
+				"%s.%s references %s, which no migration creates",
+				table.Name,
+				col.Name,
+				col.ForeignKey.ReferencedTable,
+			))
+		}
+	}
+	return warnings
+}
+
+// buildDependents returns the foreign keys in cat that reference the
+// primary key column of table.
+func buildDependents(cat *catalog.Catalog, table *catalog.Table, primaryKey string) []GeneratedDependent {
+	var dependents []GeneratedDependent
+	for _, reference := range cat.ReferencesTo(table.Schema, table.Name) {
+		if reference.Column.ForeignKey.ReferencedColumn != primaryKey {
+			continue
+		}
+		dependentTable := reference.Table.Name
+		if reference.Table.Schema != cat.DefaultSchema {
+			dependentTable = reference.Table.Schema + "." + dependentTable
+		}
+		dependents = append(dependents, GeneratedDependent{
+			Table:    dependentTable,
+			Column:   reference.Column.Name,
+			OnDelete: reference.Column.ForeignKey.DeleteAction(),
+			Blocks:   reference.Column.ForeignKey.BlocksDelete(),
+		})
+	}
+	return dependents
+}
+
+// referencedColumn returns the column a foreign key points at, defaulting to
+// id when the migration declares no REFERENCES clause.
+func referencedColumn(col *catalog.Column) string {
+	if col.ForeignKey != nil && col.ForeignKey.ReferencedColumn != "" {
+		return col.ForeignKey.ReferencedColumn
+	}
+	return "id"
+}
+
 func groupAndSortImports(importSet map[string]bool) (stdImports []string, extImports []string) {
 	for imp := range importSet {
 		if strings.Contains(imp, ".") {
@@ -255,7 +679,73 @@ func setModelPK(model *GeneratedModel, col *catalog.Column) {
 	pkType, _ := validation.ClassifyPrimaryKeyType(col.DataType)
 	model.IDType = validation.GoType(pkType)
 	model.IDGoType = model.IDType
-	model.IsAutoIncrementID = validation.IsAutoIncrement(col.DataType)
+	model.IsAutoIncrementID = col.IsAutoIncrement || validation.IsAutoIncrement(col.DataType)
+}
+
+// validateJSONTypes checks that every column in jsonTypes is a json or jsonb
+// column of table and maps to an exported type name.
+func validateJSONTypes(table *catalog.Table, jsonTypes map[string]string) error {
+	columns := make([]string, 0, len(jsonTypes))
+	for column := range jsonTypes {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	for _, column := range columns {
+		col := findColumn(table, column)
+		if col == nil {
+			return fmt.Errorf("json type for %q: table %s has no such column", column, table.Name)
+		}
+		if dataType := strings.ToLower(col.DataType); dataType != "json" && dataType != "jsonb" {
+			return fmt.Errorf("json type for %q: column is %s, not json or jsonb", column, col.DataType)
+		}
+		if goType := jsonTypes[column]; !token.IsIdentifier(goType) || !token.IsExported(goType) {
+			return fmt.Errorf("json type for %q: %q must be an exported type declared in the models package", column, goType)
+		}
+	}
+	return nil
+}
+
+// setJSONType decodes field into the struct jsonType instead of
+// json.RawMessage. bun marshals struct fields tagged type:json or type:jsonb
+// with encoding/json, so no conversion code is generated.
+func setJSONType(field *GeneratedField, jsonType string) {
+	field.JSONType = jsonType
+	field.Type = jsonType
+	if field.IsNullable {
+		field.Type = "*" + jsonType
+	}
+	field.Package = ""
+}
+
+// setModelCompositePK keys model by every column in pkColumns. Find and
+// Destroy then take one argument per column, and the key values are part of
+// the create data instead of being generated.
+func setModelCompositePK(model *GeneratedModel, pkColumns []*catalog.Column) {
+	model.HasPrimaryKey = true
+	model.HasCompositeKey = true
+	for _, col := range pkColumns {
+		goField := types.FormatFieldName(col.Name)
+		var goType string
+		for _, field := range model.Fields {
+			if field.Name == goField {
+				goType = field.Type
+				break
+			}
+		}
+		model.PrimaryKeys = append(model.PrimaryKeys, GeneratedKey{
+			Column:  col.Name,
+			GoField: goField,
+			GoType:  goType,
+			ArgName: naming.ToLowerCamelCase(goField),
+		})
+	}
+}
+
+// isSoftDeleteColumn reports whether col follows the deleted_at soft delete
+// convention: a nullable timestamp bun can fill in when a row is deleted.
+func isSoftDeleteColumn(col *catalog.Column, field GeneratedField) bool {
+	return col.Name == "deleted_at" && col.IsNullable && strings.Contains(field.Type, "Time")
 }
 
 func findColumn(table *catalog.Table, name string) *catalog.Column {
@@ -268,7 +758,7 @@ func findColumn(table *catalog.Table, name string) *catalog.Column {
 }
 
 func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
-	goType, pkg, err := g.typeMapper.MapSQLTypeToGo(col.DataType, col.IsNullable)
+	goType, pkg, err := g.typeMapper.MapColumnToGo(col)
 	if err != nil {
 		return GeneratedField{}, err
 	}
@@ -276,13 +766,23 @@ func (g *Generator) buildField(col *catalog.Column) (GeneratedField, error) {
 	bunTag := g.typeMapper.BuildBunTag(col)
 
 	field := GeneratedField{
-		Name:         types.FormatFieldName(col.Name),
-		Type:         goType,
-		Package:      pkg,
-		BunTag:       bunTag,
-		IsForeignKey: col.ForeignKey != nil,
-		IsNullable:   col.IsNullable,
-		IsPrimaryKey: col.IsPrimaryKey,
+		Name:            types.FormatFieldName(col.Name),
+		Type:            goType,
+		Package:         pkg,
+		BunTag:          bunTag,
+		IsForeignKey:    col.ForeignKey != nil,
+		IsNullable:      col.IsNullable,
+		IsPrimaryKey:    col.IsPrimaryKey,
+		IsAutoIncrement: col.IsAutoIncrement,
+		IsGenerated:     col.Generated != "",
+	}
+	if col.ForeignKey != nil {
+		field.References = fmt.Sprintf(
+			"%s(%s) ON DELETE %s",
+			col.ForeignKey.ReferencedTable,
+			col.ForeignKey.ReferencedColumn,
+			col.ForeignKey.DeleteAction(),
+		)
 	}
 
 	return field, nil
@@ -307,18 +807,17 @@ func (g *Generator) addModelTypeImports(goType string) map[string]bool {
 
 // GenerateModelFile renders model template data into Go source.
 func (g *Generator) GenerateModelFile(model *GeneratedModel, templateStr string) (string, error) {
-	funcMap := template.FuncMap{
-		"lower": func(s string) string {
-			return strings.ToLower(s)
-		},
-		"Plural": inflection.Plural,
+	funcMap := templatefuncs.FuncMap()
+	maps.Copy(funcMap, template.FuncMap{
+		"Plural":     naming.Plural,
+		"lowerCamel": naming.ToLowerCamelCase,
 		"columnName": func(bunTag string) string {
 			if before, _, ok := strings.Cut(bunTag, ","); ok {
 				return before
 			}
 			return bunTag
 		},
-	}
+	})
 
 	tmpl, err := template.New("model").Funcs(funcMap).Parse(templateStr)
 	if err != nil {
@@ -342,8 +841,13 @@ func (g *Generator) GenerateModel(
 	modulePath string,
 	tableNameOverride string,
 	nullType string,
+	decimalType string,
 	primaryKeyColumn string,
 	generateWithoutPK bool,
+	associations Associations,
+	jsonTypes map[string]string,
+	customTypes []types.TypeOverride,
+	conflictColumns []string,
 ) error {
 	tableName := pluralName
 	if tableNameOverride != "" {
@@ -357,20 +861,30 @@ func (g *Generator) GenerateModel(
 		DatabaseType:      g.typeMapper.GetDatabaseType(),
 		ModulePath:        modulePath,
 		NullType:          nullType,
+		DecimalType:       decimalType,
 		PrimaryKeyColumn:  primaryKeyColumn,
 		GenerateWithoutPK: generateWithoutPK,
+		Associations:      associations,
+		JSONTypes:         jsonTypes,
+		CustomTypes:       customTypes,
+		ConflictColumns:   conflictColumns,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to build model: %w", err)
 	}
 
+	for _, warning := range model.Warnings {
+		fmt.Printf("⚠️  %s\n", warning)
+	}
+
 	model.TableNameOverride = tableNameOverride
 	model.TableNameOverridden = tableNameOverride != ""
-	// When table name is overridden, don't pluralize the resource name for function names
-	if tableNameOverride != "" {
+	// When table name is overridden, don't pluralize the resource name for
+	// function names. A schema alone does not override the name.
+	if tableNameOverride != "" && model.TableAlias != naming.DeriveTableName(resourceName) {
 		model.PluralName = resourceName
 	} else {
-		model.PluralName = inflection.Plural(resourceName)
+		model.PluralName = naming.Plural(resourceName)
 	}
 
 	templateContent, err := templates.Files.ReadFile("model.tmpl")
@@ -391,6 +905,82 @@ func (g *Generator) GenerateModel(
 		return fmt.Errorf("failed to format model file: %w", err)
 	}
 
+	if err := g.WriteEnumsFile(cat, filepath.Dir(modelPath)); err != nil {
+		return fmt.Errorf("failed to write enums file: %w", err)
+	}
+
+	return nil
+}
+
+// EnumsFileName is the file in the models package holding the Go types of
+// the project's enum types. It is rewritten whenever a model is generated.
+const EnumsFileName = "enums.go"
+
+// enumsFileHeader marks EnumsFileName as generated, so a hand-written file
+// of the same name is never overwritten.
+const enumsFileHeader = "// Code generated by andurel from the enum types in your migrations; DO NOT EDIT."
+
+// RenderEnumsFile renders the Go types for the enum types in cat. It returns
+// an empty string when there are none.
+func (g *Generator) RenderEnumsFile(cat *catalog.Catalog) (string, error) {
+	enums := BuildEnums(cat)
+	if len(enums) == 0 {
+		return "", nil
+	}
+
+	templateContent, err := templates.Files.ReadFile("enums.tmpl")
+	if err != nil {
+		return "", fmt.Errorf("failed to read enums template: %w", err)
+	}
+
+	tmpl, err := template.New("enums").Parse(string(templateContent))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse enums template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, map[string]any{
+		"Header": enumsFileHeader,
+		"Enums":  enums,
+	}); err != nil {
+		return "", fmt.Errorf("failed to execute enums template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// WriteEnumsFile writes EnumsFileName to modelsDir when cat has enum types.
+func (g *Generator) WriteEnumsFile(cat *catalog.Catalog, modelsDir string) error {
+	content, err := g.RenderEnumsFile(cat)
+	if err != nil || content == "" {
+		return err
+	}
+
+	path := filepath.Join(modelsDir, EnumsFileName)
+	if err := CheckEnumsFileGenerated(path); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return err
+	}
+
+	return files.FormatGoFile(path)
+}
+
+// CheckEnumsFileGenerated returns an error when path exists but was not
+// written by andurel.
+func CheckEnumsFileGenerated(path string) error {
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(string(existing), enumsFileHeader) {
+		return fmt.Errorf("%s exists and was not generated by andurel; rename it so enum types can be generated", path)
+	}
 	return nil
 }
 
@@ -409,7 +999,8 @@ type GeneratedFactory struct {
 	ForeignKeyFields  []FactoryField // All FK fields
 	IDType            string         // "uuid.UUID", "int32", "int64", "string"
 	IDGoFieldName     string         // Go field name for the primary key (e.g., "ID")
-	IsAutoIncrementID bool           // True for serial/bigserial
+	IsAutoIncrementID bool           // True for serial/bigserial and identity keys
+	HasCompositeKey   bool           // Key columns are filled by Build, so Create generates no ID
 	HasCreatedAt      bool
 	HasUpdatedAt      bool
 }
@@ -426,15 +1017,21 @@ type FactoryField struct {
 	IsTimestamp   bool
 	IsID          bool
 	IsAutoManaged bool
+	Package       string // Import path of a type mapped in andurel.types.yaml
 }
 
 // BuildFactory generates factory metadata from a model
 func (g *Generator) BuildFactory(cat *catalog.Catalog, config Config, genModel *GeneratedModel) (*GeneratedFactory, error) {
-	// Import the factory analyzer
-	factoryFields := make([]FactoryField, 0, len(genModel.Fields))
+	// Fake values follow the table's CHECK constraints when the catalog is
+	// known; without it they are chosen from the column names alone.
+	var table *catalog.Table
+	if cat != nil {
+		table, _ = cat.GetTable("", config.TableName)
+	}
 
+	factoryFields := make([]FactoryField, 0, len(genModel.Fields))
 	for _, field := range genModel.Fields {
-		fieldInfo := g.analyzeFactoryField(field, config.TableName)
+		fieldInfo := g.analyzeFactoryField(field, config.TableName, table)
 		factoryFields = append(factoryFields, fieldInfo)
 	}
 
@@ -449,11 +1046,18 @@ func (g *Generator) BuildFactory(cat *catalog.Catalog, config Config, genModel *
 	// Collect imports - context and fmt are already in the template
 	standardImports := []string{}
 	externalImports := []string{
-		"github.com/go-faker/faker/v4",
+		"github.com/brianvoe/gofakeit/v7",
 	}
 
-	// Only add uuid import if ID type uses UUID
-	if genModel.IDType == "uuid.UUID" || genModel.IDType == "" {
+	// Only add uuid import if ID type uses UUID or a field is filled with one
+	fillsUUID := slices.ContainsFunc(factoryFields, func(f FactoryField) bool {
+		return !f.IsAutoManaged && !f.IsFK && strings.Contains(f.DefaultValue, "uuid.New()")
+	})
+	if genModel.HasCompositeKey {
+		if fillsUUID || slices.ContainsFunc(factoryFields, func(f FactoryField) bool { return strings.Contains(f.Type, "uuid.UUID") }) {
+			externalImports = append(externalImports, "github.com/google/uuid")
+		}
+	} else if fillsUUID || genModel.IDType == "uuid.UUID" || genModel.IDType == "" {
 		externalImports = append(externalImports, "github.com/google/uuid")
 	}
 
@@ -464,6 +1068,29 @@ func (g *Generator) BuildFactory(cat *catalog.Catalog, config Config, genModel *
 			break
 		}
 	}
+	for _, field := range factoryFields {
+		if strings.Contains(field.Type, types.MoneyGoType) {
+			externalImports = append(externalImports, config.ModulePath+"/internal/money")
+			break
+		}
+	}
+	for _, field := range factoryFields {
+		if strings.Contains(field.Type, types.IntervalGoType) {
+			externalImports = append(externalImports, config.ModulePath+"/internal/interval")
+			break
+		}
+	}
+	for _, field := range factoryFields {
+		if strings.Contains(field.Type, types.DecimalGoType) {
+			externalImports = append(externalImports, types.DecimalPackage)
+			break
+		}
+	}
+	for _, field := range factoryFields {
+		if field.Package != "" && !slices.Contains(externalImports, field.Package) {
+			externalImports = append(externalImports, field.Package)
+		}
+	}
 
 	// Default IDGoFieldName if not set
 	idGoFieldName := genModel.IDGoFieldName
@@ -486,131 +1113,172 @@ func (g *Generator) BuildFactory(cat *catalog.Catalog, config Config, genModel *
 		IDType:            genModel.IDType,
 		IDGoFieldName:     idGoFieldName,
 		IsAutoIncrementID: genModel.IsAutoIncrementID,
+		HasCompositeKey:   genModel.HasCompositeKey,
 		HasCreatedAt:      genModel.HasCreatedAt,
 		HasUpdatedAt:      genModel.HasUpdatedAt,
 	}, nil
 }
 
 // analyzeFactoryField analyzes a field and returns factory metadata
-func (g *Generator) analyzeFactoryField(field GeneratedField, tableName string) FactoryField {
+func (g *Generator) analyzeFactoryField(field GeneratedField, tableName string, table *catalog.Table) FactoryField {
+	_, unqualifiedTable := catalog.SplitQualifiedName(tableName)
 	info := FactoryField{
 		Name:          field.Name,
 		ArgumentName:  naming.ToLowerCamelCase(field.Name),
 		Type:          field.Type,
-		OptionName:    fmt.Sprintf("With%s%s", naming.Capitalize(naming.ToCamelCase(tableName)), field.Name),
+		OptionName:    fmt.Sprintf("With%s%s", naming.ToPascalCase(unqualifiedTable), field.Name),
 		IsID:          field.Name == "ID",
 		IsTimestamp:   field.Type == "time.Time" || strings.Contains(field.Type, "Time"),
-		IsAutoManaged: field.Name == "ID" || field.Name == "CreatedAt" || field.Name == "UpdatedAt",
+		IsAutoManaged: field.Name == "ID" || field.Name == "CreatedAt" || field.Name == "UpdatedAt" || field.IsAutoIncrement || field.IsGenerated,
 		IsFK:          field.IsForeignKey,
 	}
 
+	if field.Enum != nil {
+		return enumFactoryField(info, field)
+	}
+	if field.JSONType != "" {
+		return jsonFactoryField(info, field)
+	}
+	if field.ProjectType != nil {
+		return projectFactoryField(info, field)
+	}
+
 	// Determine default value
 	info.DefaultValue = g.determineFactoryDefault(field.Name, field.Type)
+	if fake, ok := fakeFactoryDefault(table, field); ok && !info.IsFK {
+		info.DefaultValue = fake
+	}
 	info.GoZero = g.getFactoryGoZero(field.Type)
 
 	return info
 }
 
+// enumFactoryField qualifies an enum field's type with the models package
+// and defaults it to the enum's first value.
+func enumFactoryField(info FactoryField, field GeneratedField) FactoryField {
+	if strings.HasPrefix(field.Type, "*") {
+		info.Type = "*models." + field.Enum.Name
+		info.DefaultValue = "nil"
+		info.GoZero = "nil"
+		return info
+	}
+
+	info.Type = "models." + field.Enum.Name
+	info.DefaultValue = `""`
+	if len(field.Enum.Values) > 0 {
+		info.DefaultValue = "models." + field.Enum.Values[0].Name
+	}
+	info.GoZero = `""`
+	return info
+}
+
+// jsonFactoryField qualifies the struct of a typed json column with the
+// models package and defaults it to the zero value.
+func jsonFactoryField(info FactoryField, field GeneratedField) FactoryField {
+	if strings.HasPrefix(field.Type, "*") {
+		info.Type = "*models." + field.JSONType
+		info.DefaultValue = "nil"
+		info.GoZero = "nil"
+		return info
+	}
+
+	info.Type = "models." + field.JSONType
+	info.DefaultValue = "models." + field.JSONType + "{}"
+	info.GoZero = info.DefaultValue
+	return info
+}
+
+// projectFactoryField qualifies a field mapped in andurel.types.yaml and
+// defaults it to the mapping's factory expression, or to the zero value.
+func projectFactoryField(info FactoryField, field GeneratedField) FactoryField {
+	goType := field.ProjectType.QualifiedGoType()
+	info.Package = field.ProjectType.Package
+	if strings.HasPrefix(field.Type, "*") {
+		info.Type = "*" + goType
+		info.DefaultValue = "nil"
+		info.GoZero = "nil"
+		return info
+	}
+
+	info.Type = goType
+	info.GoZero = "*new(" + goType + ")"
+	info.DefaultValue = info.GoZero
+	if field.ProjectType.Factory != "" {
+		info.DefaultValue = field.ProjectType.Factory
+	}
+	return info
+}
+
 func (g *Generator) determineFactoryDefault(fieldName, goType string) string {
 	// Handle by type first
 	switch goType {
 	case "string":
-		return "faker.Word()"
+		return "gofakeit.Word()"
 	case "int32", "int":
-		return "randomInt(1, 1000, 100)"
+		return "int32(gofakeit.IntRange(1, 1000))"
 	case "int64":
-		return "randomInt64(1, 1000, 100)"
+		return "int64(gofakeit.IntRange(1, 1000))"
 	case "int16":
-		return "randomInt16(1, 1000, 100)"
+		return "int16(gofakeit.IntRange(1, 1000))"
 	case "bool":
-		return "randomBool()"
+		return "gofakeit.Bool()"
 	case "time.Time":
-		return "time.Time{}"
+		return "gofakeit.PastDate()"
 	case "uuid.UUID":
-		return "uuid.UUID{}"
+		return "uuid.New()"
 	case "json.RawMessage":
 		return "json.RawMessage{}"
 	case "[]byte":
 		return "[]byte{}"
+	case types.MoneyGoType:
+		return "money.FromCents(int64(gofakeit.IntRange(100, 10000)))"
+	case "*" + types.MoneyGoType:
+		return "nil"
+	case types.DecimalGoType:
+		return "decimal.NewFromFloat(gofakeit.Price(1, 1000))"
+	case "*" + types.DecimalGoType:
+		return "nil"
+	case types.IntervalGoType:
+		return "interval.Duration(gofakeit.IntRange(1, 1000)) * interval.Minute"
+	case "*" + types.IntervalGoType:
+		return "nil"
 	// sql.Null types
 	case "sql.NullString":
-		return "sql.NullString{String: faker.Word(), Valid: true}"
+		return "sql.NullString{String: gofakeit.Word(), Valid: true}"
 	case "sql.NullBool":
-		return "sql.NullBool{Bool: randomBool(), Valid: true}"
+		return "sql.NullBool{Bool: gofakeit.Bool(), Valid: true}"
 	case "sql.NullInt16":
-		return "sql.NullInt16{Int16: randomInt16(1, 1000, 100), Valid: true}"
+		return "sql.NullInt16{Int16: int16(gofakeit.IntRange(1, 1000)), Valid: true}"
 	case "sql.NullInt32":
-		return "sql.NullInt32{Int32: randomInt(1, 1000, 100), Valid: true}"
+		return "sql.NullInt32{Int32: int32(gofakeit.IntRange(1, 1000)), Valid: true}"
 	case "sql.NullInt64":
-		return "sql.NullInt64{Int64: randomInt64(1, 1000, 100), Valid: true}"
+		return "sql.NullInt64{Int64: int64(gofakeit.IntRange(1, 1000)), Valid: true}"
 	case "sql.NullFloat64":
-		return "sql.NullFloat64{Float64: float64(randomInt(1, 1000, 100)), Valid: true}"
+		return "sql.NullFloat64{Float64: gofakeit.Float64Range(1, 1000), Valid: true}"
 	case "sql.NullTime":
-		return "sql.NullTime{Time: time.Now(), Valid: true}"
+		return "sql.NullTime{Time: gofakeit.PastDate(), Valid: true}"
 	// bun.Null types
 	case "bun.NullString":
-		return "bun.NullString{String: faker.Word(), Valid: true}"
+		return "bun.NullString{String: gofakeit.Word(), Valid: true}"
 	case "bun.NullBool":
-		return "bun.NullBool{Bool: randomBool(), Valid: true}"
+		return "bun.NullBool{Bool: gofakeit.Bool(), Valid: true}"
 	case "bun.NullInt32":
-		return "bun.NullInt32{Int32: randomInt(1, 1000, 100), Valid: true}"
+		return "bun.NullInt32{Int32: int32(gofakeit.IntRange(1, 1000)), Valid: true}"
 	case "bun.NullInt64":
-		return "bun.NullInt64{Int64: randomInt64(1, 1000, 100), Valid: true}"
+		return "bun.NullInt64{Int64: int64(gofakeit.IntRange(1, 1000)), Valid: true}"
 	case "bun.NullFloat64":
-		return "bun.NullFloat64{Float64: float64(randomInt(1, 1000, 100)), Valid: true}"
+		return "bun.NullFloat64{Float64: gofakeit.Float64Range(1, 1000), Valid: true}"
 	case "bun.NullTime":
-		return "bun.NullTime{Time: time.Now(), Valid: true}"
+		return "bun.NullTime{Time: gofakeit.PastDate(), Valid: true}"
 	}
 
-	// Default fallback
-	return fmt.Sprintf("%s{}", goType)
-}
-
-func (g *Generator) stringFactoryDefault(fieldName string) string {
-	lower := strings.ToLower(fieldName)
-
-	// Field name heuristics
-	switch {
-	case lower == "email":
-		return "faker.Email()"
-	case lower == "name" || strings.HasSuffix(lower, "name"):
-		return "faker.Name()"
-	case lower == "phone" || strings.Contains(lower, "phone"):
-		return "faker.Phonenumber()"
-	case lower == "url" || strings.Contains(lower, "url"):
-		return "faker.URL()"
-	case lower == "description" || strings.HasSuffix(lower, "description"):
-		return "faker.Sentence()"
-	case lower == "title" || strings.HasSuffix(lower, "title"):
-		return "faker.Word()"
-	case lower == "address" || strings.Contains(lower, "address"):
-		return "faker.GetRealAddress().Address"
-	case lower == "city":
-		return "faker.GetRealAddress().City"
-	case lower == "country":
-		return "faker.GetRealAddress().Country"
-	case lower == "zipcode" || lower == "postalcode":
-		return "faker.GetRealAddress().PostalCode"
-	case strings.Contains(lower, "color"):
-		return "faker.GetRandomColor()"
-	default:
-		return "faker.Word()"
+	// Nullable columns generated as pointers default to NULL.
+	if strings.HasPrefix(goType, "*") {
+		return "nil"
 	}
-}
 
-func (g *Generator) intFactoryDefault(fieldName string) string {
-	lower := strings.ToLower(fieldName)
-
-	switch {
-	case strings.Contains(lower, "price") || strings.Contains(lower, "amount"):
-		return "faker.RandomInt(100, 10000)" // Price in cents
-	case strings.Contains(lower, "count") || strings.Contains(lower, "quantity"):
-		return "faker.RandomInt(1, 100)"
-	case strings.Contains(lower, "age"):
-		return "faker.RandomInt(18, 80)"
-	default:
-		return "faker.RandomInt(1, 1000)"
-	}
+	// Default fallback
+	return fmt.Sprintf("%s{}", goType)
 }
 
 func (g *Generator) getFactoryGoZero(goType string) string {
@@ -637,20 +1305,19 @@ func (g *Generator) getFactoryGoZero(goType string) string {
 		"bun.NullFloat64", "bun.NullTime":
 		return fmt.Sprintf("%s{}", goType)
 	default:
-		if strings.HasPrefix(goType, "[]") {
+		if strings.HasPrefix(goType, "*") {
 			return "nil"
 		}
+		// Slices default to empty rather than nil so NOT NULL array
+		// columns insert '{}' instead of NULL.
 		return fmt.Sprintf("%s{}", goType)
 	}
 }
 
 // GenerateFactoryFile renders a factory file from a template
 func (g *Generator) GenerateFactoryFile(factory *GeneratedFactory, templateStr string) (string, error) {
-	funcMap := template.FuncMap{
-		"toLower": func(s string) string {
-			return strings.ToLower(s)
-		},
-	}
+	funcMap := templatefuncs.FuncMap()
+	funcMap["toLower"] = strings.ToLower
 
 	tmpl, err := template.New("factory").Funcs(funcMap).Parse(templateStr)
 	if err != nil {
@@ -1,13 +1,19 @@
 package models
 
 import (
+	"go/format"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
 	"strings"
 	"testing"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/pgdialect"
 )
 
 func TestBuildUUIDImports(t *testing.T) {
@@ -97,17 +103,23 @@ func TestGeneratorFactoryDefaultsAndZeroValues(t *testing.T) {
 	g := NewGenerator("postgresql")
 
 	defaults := map[string]string{
-		"Email:string":             "faker.Word()",
-		"Name:string":              "faker.Word()",
-		"Age:int32":                "randomInt(1, 1000, 100)",
-		"Enabled:bool":             "randomBool()",
-		"CreatedAt:time.Time":      "time.Time{}",
-		"ID:uuid.UUID":             "uuid.UUID{}",
+		"Email:string":             "gofakeit.Word()",
+		"Name:string":              "gofakeit.Word()",
+		"Age:int32":                "int32(gofakeit.IntRange(1, 1000))",
+		"Enabled:bool":             "gofakeit.Bool()",
+		"CreatedAt:time.Time":      "gofakeit.PastDate()",
+		"ID:uuid.UUID":             "uuid.New()",
 		"Metadata:json.RawMessage": "json.RawMessage{}",
 		"Payload:[]byte":           "[]byte{}",
-		"Maybe:sql.NullString":     "sql.NullString{String: faker.Word(), Valid: true}",
-		"Maybe:bun.NullInt64":      "bun.NullInt64{Int64: randomInt64(1, 1000, 100), Valid: true}",
+		"Maybe:sql.NullString":     "sql.NullString{String: gofakeit.Word(), Valid: true}",
+		"Maybe:bun.NullInt64":      "bun.NullInt64{Int64: int64(gofakeit.IntRange(1, 1000)), Valid: true}",
 		"Custom:Money":             "Money{}",
+		"Price:money.Money":        "money.FromCents(int64(gofakeit.IntRange(100, 10000)))",
+		"Fee:*money.Money":         "nil",
+		"Rate:decimal.Decimal":     "decimal.NewFromFloat(gofakeit.Price(1, 1000))",
+		"Cap:*decimal.Decimal":     "nil",
+		"Length:interval.Duration": "interval.Duration(gofakeit.IntRange(1, 1000)) * interval.Minute",
+		"Delay:*interval.Duration": "nil",
 	}
 	for key, want := range defaults {
 		parts := strings.Split(key, ":")
@@ -116,38 +128,6 @@ func TestGeneratorFactoryDefaultsAndZeroValues(t *testing.T) {
 		}
 	}
 
-	stringDefaults := map[string]string{
-		"email":       "faker.Email()",
-		"full_name":   "faker.Name()",
-		"phoneNumber": "faker.Phonenumber()",
-		"avatar_url":  "faker.URL()",
-		"description": "faker.Sentence()",
-		"title":       "faker.Word()",
-		"address":     "faker.GetRealAddress().Address",
-		"city":        "faker.GetRealAddress().City",
-		"country":     "faker.GetRealAddress().Country",
-		"zipcode":     "faker.GetRealAddress().PostalCode",
-		"theme_color": "faker.GetRandomColor()",
-		"misc":        "faker.Word()",
-	}
-	for name, want := range stringDefaults {
-		if got := g.stringFactoryDefault(name); got != want {
-			t.Fatalf("stringFactoryDefault(%q) = %q, want %q", name, got, want)
-		}
-	}
-
-	intDefaults := map[string]string{
-		"price_cents": "faker.RandomInt(100, 10000)",
-		"quantity":    "faker.RandomInt(1, 100)",
-		"age":         "faker.RandomInt(18, 80)",
-		"rank":        "faker.RandomInt(1, 1000)",
-	}
-	for name, want := range intDefaults {
-		if got := g.intFactoryDefault(name); got != want {
-			t.Fatalf("intFactoryDefault(%q) = %q, want %q", name, got, want)
-		}
-	}
-
 	zeros := map[string]string{
 		"string":          `""`,
 		"int64":           "0",
@@ -157,7 +137,7 @@ func TestGeneratorFactoryDefaultsAndZeroValues(t *testing.T) {
 		"uuid.UUID":       "uuid.UUID{}",
 		"json.RawMessage": "nil",
 		"[]byte":          "nil",
-		"[]string":        "nil",
+		"[]string":        "[]string{}",
 		"sql.NullString":  "sql.NullString{}",
 		"bun.NullTime":    "bun.NullTime{}",
 		"Money":           "Money{}",
@@ -280,11 +260,17 @@ func TestBuildModelPrimaryKeyOverridesAndImports(t *testing.T) {
 	if !model.HasCreatedAt || !model.HasUpdatedAt {
 		t.Fatalf("timestamps were not detected: %#v", model)
 	}
-	for _, want := range []string{"encoding/json", "github.com/google/uuid", "example.com/app/internal/storage", "example.com/app/internal/validation"} {
+	if !model.HasCursorPagination {
+		t.Fatal("NOT NULL created_at and a single key should enable cursor pagination")
+	}
+	for _, want := range []string{"encoding/base64", "encoding/json", "github.com/google/uuid", "example.com/app/internal/storage", "example.com/app/internal/validation"} {
 		if !slices.Contains(model.Imports, want) {
 			t.Fatalf("model imports missing %q: %#v", want, model.Imports)
 		}
 	}
+	if slices.Contains(model.Imports, "example.com/app/internal/money") {
+		t.Fatalf("model without money columns imports money: %#v", model.Imports)
+	}
 	if findColumn(table, "missing") != nil {
 		t.Fatal("missing column lookup returned a value")
 	}
@@ -293,7 +279,7 @@ func TestBuildModelPrimaryKeyOverridesAndImports(t *testing.T) {
 	if err != nil {
 		t.Fatalf("build without primary key: %v", err)
 	}
-	if withoutPK.HasPrimaryKey {
+	if withoutPK.HasPrimaryKey || withoutPK.HasCursorPagination {
 		t.Fatalf("GenerateWithoutPK selected a primary key: %#v", withoutPK)
 	}
 	if _, err := g.Build(cat, Config{TableName: "missing", ResourceName: "Missing"}); err == nil {
@@ -301,6 +287,47 @@ func TestBuildModelPrimaryKeyOverridesAndImports(t *testing.T) {
 	}
 }
 
+func TestBuildModelMapsCentsColumnsToMoney(t *testing.T) {
+	table := tableWithColumns(t, "orders",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("total_cents", "bigint").SetNotNull(),
+		catalog.NewColumn("discount_cents", "numeric"),
+		catalog.NewColumn("item_count", "integer").SetNotNull(),
+	)
+	cat := catalog.NewCatalog("public")
+	if err := cat.AddTable("public", table); err != nil {
+		t.Fatalf("add table: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	config := Config{TableName: "orders", ResourceName: "Order", PackageName: "models", ModulePath: "example.com/app", NullType: "pointer"}
+	model, err := g.Build(cat, config)
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+
+	fieldTypes := map[string]string{}
+	for _, field := range model.Fields {
+		fieldTypes[field.Name] = field.Type
+	}
+	want := map[string]string{"TotalCents": "money.Money", "DiscountCents": "*money.Money", "ItemCount": "int32"}
+	for name, typ := range want {
+		if fieldTypes[name] != typ {
+			t.Fatalf("%s type = %q, want %q", name, fieldTypes[name], typ)
+		}
+	}
+	if !slices.Contains(model.Imports, "example.com/app/internal/money") {
+		t.Fatalf("model imports missing money: %#v", model.Imports)
+	}
+
+	factory, err := g.BuildFactory(cat, config, model)
+	if err != nil {
+		t.Fatalf("BuildFactory: %v", err)
+	}
+	if !slices.Contains(factory.ExternalImports, "example.com/app/internal/money") {
+		t.Fatalf("factory imports missing money: %#v", factory.ExternalImports)
+	}
+}
+
 func TestGenerateModelAndFactoryFiles(t *testing.T) {
 	root := t.TempDir()
 	cat := catalog.NewCatalog("public")
@@ -313,7 +340,7 @@ func TestGenerateModelAndFactoryFiles(t *testing.T) {
 	}
 	g := NewGenerator("postgresql")
 	modelPath := filepath.Join(root, "product.go")
-	if err := g.GenerateModel(cat, "Product", "products", modelPath, "example.com/app", "", "sql.Null", "id", false); err != nil {
+	if err := g.GenerateModel(cat, "Product", "products", modelPath, "example.com/app", "", "sql.Null", "", "id", false, Associations{}, nil, nil, nil); err != nil {
 		t.Fatalf("generate model: %v", err)
 	}
 	modelContent, err := os.ReadFile(modelPath)
@@ -363,3 +390,431 @@ DROP TABLE widgets;
 		t.Fatal("expected migration discovery error")
 	}
 }
+
+func TestGenerateModelForTableInSchema(t *testing.T) {
+	directory := t.TempDir()
+	migration := `-- +goose Up
+CREATE SCHEMA billing;
+CREATE TABLE billing.invoices (
+    id UUID PRIMARY KEY,
+    number TEXT NOT NULL
+);
+CREATE TABLE billing.invoice_lines (
+    id UUID PRIMARY KEY,
+    invoice_id UUID NOT NULL REFERENCES billing.invoices(id) ON DELETE CASCADE
+);
+-- +goose Down
+DROP SCHEMA billing CASCADE;
+`
+	if err := os.WriteFile(filepath.Join(directory, "20261018120000_create_billing.sql"), []byte(migration), 0o600); err != nil {
+		t.Fatalf("write migration: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	cat, err := g.BuildCatalogFromMigrations("billing.invoices", []string{directory})
+	if err != nil {
+		t.Fatalf("build catalog from migrations: %v", err)
+	}
+
+	modelPath := filepath.Join(t.TempDir(), "invoice.go")
+	if err := g.GenerateModel(cat, "Invoice", "billing.invoices", modelPath, "example.com/app", "billing.invoices", "sql.Null", "", "id", false, Associations{}, nil, nil, nil); err != nil {
+		t.Fatalf("generate model: %v", err)
+	}
+	content, err := os.ReadFile(modelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"bun.BaseModel `bun:\"table:billing.invoices,alias:invoices\"`",
+		"type PaginatedInvoices struct",
+		"//   - billing.invoice_lines.invoice_id: deleted with it (ON DELETE CASCADE)",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Fatalf("model should contain %q\n\n%s", want, content)
+		}
+	}
+
+	model, err := g.Build(cat, Config{TableName: "billing.invoices", ResourceName: "Invoice", ModulePath: "example.com/app"})
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+	factory, err := g.BuildFactory(cat, Config{TableName: "billing.invoices", ModulePath: "example.com/app"}, model)
+	if err != nil {
+		t.Fatalf("build factory: %v", err)
+	}
+	if !slices.ContainsFunc(factory.Fields, func(f FactoryField) bool { return f.OptionName == "WithInvoicesNumber" }) {
+		t.Fatalf("factory options should be named after the table without its schema: %#v", factory.Fields)
+	}
+}
+
+func TestGenerateModelForView(t *testing.T) {
+	directory := t.TempDir()
+	migration := `-- +goose Up
+CREATE TABLE users (
+    id UUID PRIMARY KEY,
+    email TEXT NOT NULL
+);
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    total NUMERIC(12,2) NOT NULL
+);
+CREATE MATERIALIZED VIEW report_rows AS
+    SELECT u.id AS user_id, u.email, count(o.id) AS order_count, sum(o.total)::numeric(12,2) AS revenue
+    FROM users u LEFT JOIN orders o ON o.user_id = u.id
+    GROUP BY u.id, u.email;
+CREATE VIEW order_totals AS SELECT user_id, total * 1.2 FROM orders;
+-- +goose Down
+DROP VIEW order_totals;
+DROP MATERIALIZED VIEW report_rows;
+DROP TABLE orders;
+DROP TABLE users;
+`
+	if err := os.WriteFile(filepath.Join(directory, "20261018130000_create_reports.sql"), []byte(migration), 0o600); err != nil {
+		t.Fatalf("write migration: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	cat, err := g.BuildCatalogFromMigrations("report_rows", []string{directory})
+	if err != nil {
+		t.Fatalf("build catalog from migrations: %v", err)
+	}
+
+	modelPath := filepath.Join(t.TempDir(), "report_row.go")
+	if err := g.GenerateModel(cat, "ReportRow", "report_rows", modelPath, "example.com/app", "", "sql.Null", "", "", true, Associations{}, nil, nil, nil); err != nil {
+		t.Fatalf("generate model: %v", err)
+	}
+	content, err := os.ReadFile(modelPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"// ReportRowEntity is a row of the report_rows materialized view.",
+		"bun.BaseModel `bun:\"table:report_rows,alias:report_rows\"`",
+		"OrderCount    int64           `bun:\"order_count\"`",
+		"func (rr reportRow) All(",
+		"func (rr reportRow) Paginate(",
+		"func (rr reportRow) Refresh(ctx context.Context, db storage.Executor) error {",
+		`db.NewRaw("REFRESH MATERIALIZED VIEW ?", bun.Ident("report_rows"))`,
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Fatalf("model should contain %q\n\n%s", want, content)
+		}
+	}
+	for _, unwanted := range []string{"CreateReportRowData", ") Create(", ") Update(", ") Upsert(", ") Destroy(", ") BulkCreate("} {
+		if strings.Contains(string(content), unwanted) {
+			t.Fatalf("read-only model should not contain %q\n\n%s", unwanted, content)
+		}
+	}
+
+	_, err = g.Build(cat, Config{TableName: "order_totals", ResourceName: "OrderTotal", ModulePath: "example.com/app"})
+	if err == nil || !strings.Contains(err.Error(), "column 2 of view order_totals has no name") {
+		t.Fatalf("expected an error for the unnamed column, got %v", err)
+	}
+}
+
+func TestBuildModelMapsIntervalColumnsToDuration(t *testing.T) {
+	table := tableWithColumns(t, "shifts",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("length", "interval").SetNotNull(),
+		catalog.NewColumn("break_time", "interval"),
+	)
+	cat := catalog.NewCatalog("public")
+	if err := cat.AddTable("public", table); err != nil {
+		t.Fatalf("add table: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	config := Config{TableName: "shifts", ResourceName: "Shift", PackageName: "models", ModulePath: "example.com/app", NullType: "sql.Null"}
+	model, err := g.Build(cat, config)
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+
+	fieldTypes := map[string]string{}
+	for _, field := range model.Fields {
+		fieldTypes[field.Name] = field.Type
+	}
+	if fieldTypes["Length"] != "interval.Duration" || fieldTypes["BreakTime"] != "*interval.Duration" {
+		t.Fatalf("field types = %#v", fieldTypes)
+	}
+	if !slices.Contains(model.Imports, "example.com/app/internal/interval") {
+		t.Fatalf("model imports missing interval: %#v", model.Imports)
+	}
+
+	factory, err := g.BuildFactory(cat, config, model)
+	if err != nil {
+		t.Fatalf("BuildFactory: %v", err)
+	}
+	if !slices.Contains(factory.ExternalImports, "example.com/app/internal/interval") {
+		t.Fatalf("factory imports missing interval: %#v", factory.ExternalImports)
+	}
+}
+
+func TestBuildModelMapsProjectTypes(t *testing.T) {
+	table := tableWithColumns(t, "hosts",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("address", "inet").SetNotNull(),
+		catalog.NewColumn("fallback", "inet"),
+		catalog.NewColumn("contact", "citext").SetNotNull(),
+	)
+	cat := catalog.NewCatalog("public")
+	if err := cat.AddTable("public", table); err != nil {
+		t.Fatalf("add table: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	config := Config{
+		TableName: "hosts", ResourceName: "Host", PackageName: "models", ModulePath: "example.com/app", NullType: "sql.Null",
+		CustomTypes: []types.TypeOverride{
+			{DatabaseType: "inet", GoType: "netip.Addr", Package: "net/netip", Factory: `netip.MustParseAddr("127.0.0.1")`},
+			{DatabaseType: "citext", GoType: "Email"},
+		},
+	}
+	model, err := g.Build(cat, config)
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+
+	fieldTypes := map[string]string{}
+	for _, field := range model.Fields {
+		fieldTypes[field.Name] = field.Type
+	}
+	want := map[string]string{"Address": "netip.Addr", "Fallback": "*netip.Addr", "Contact": "Email"}
+	for name, typ := range want {
+		if fieldTypes[name] != typ {
+			t.Fatalf("%s type = %q, want %q", name, fieldTypes[name], typ)
+		}
+	}
+	if !slices.Contains(model.Imports, "net/netip") {
+		t.Fatalf("model imports missing net/netip: %#v", model.Imports)
+	}
+
+	factory, err := g.BuildFactory(cat, config, model)
+	if err != nil {
+		t.Fatalf("BuildFactory: %v", err)
+	}
+	defaults := map[string]FactoryField{}
+	for _, field := range factory.Fields {
+		defaults[field.Name] = field
+	}
+	if got := defaults["Address"].DefaultValue; got != `netip.MustParseAddr("127.0.0.1")` {
+		t.Errorf("Address default = %q, want the factory expression", got)
+	}
+	if got := defaults["Fallback"].DefaultValue; got != "nil" {
+		t.Errorf("Fallback default = %q, want nil", got)
+	}
+	if got := defaults["Contact"]; got.Type != "models.Email" || got.DefaultValue != "*new(models.Email)" {
+		t.Errorf("Contact = %q with default %q, want models.Email with its zero value", got.Type, got.DefaultValue)
+	}
+	if !slices.Contains(factory.ExternalImports, "net/netip") {
+		t.Fatalf("factory imports missing net/netip: %#v", factory.ExternalImports)
+	}
+}
+
+func TestBuildModelForeignKeys(t *testing.T) {
+	directory := t.TempDir()
+	migration := `-- +goose Up
+CREATE TABLE users (
+    id UUID PRIMARY KEY
+);
+CREATE TABLE posts (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    CONSTRAINT invoices_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE RESTRICT
+);
+CREATE TABLE notes (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users ON DELETE SET NULL,
+    folder_id UUID REFERENCES folders(id)
+);
+-- +goose Down
+DROP TABLE notes;
+DROP TABLE invoices;
+DROP TABLE posts;
+DROP TABLE users;
+`
+	if err := os.WriteFile(filepath.Join(directory, "20260713120000_create_users.sql"), []byte(migration), 0o600); err != nil {
+		t.Fatalf("write migration: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	cat, err := g.BuildCatalogFromMigrations("users", []string{directory})
+	if err != nil {
+		t.Fatalf("build catalog from migrations: %v", err)
+	}
+
+	users, err := g.Build(cat, Config{TableName: "users", ResourceName: "User", PackageName: "models", ModulePath: "example.com/app", NullType: "pointer"})
+	if err != nil {
+		t.Fatalf("build users: %v", err)
+	}
+	want := []GeneratedDependent{
+		{Table: "invoices", Column: "user_id", OnDelete: "RESTRICT", Blocks: true},
+		{Table: "notes", Column: "user_id", OnDelete: "SET NULL"},
+		{Table: "posts", Column: "user_id", OnDelete: "CASCADE"},
+	}
+	if !slices.Equal(users.Dependents, want) {
+		t.Fatalf("dependents = %#v, want %#v", users.Dependents, want)
+	}
+	if !users.HasBlockingDependents() {
+		t.Fatal("expected invoices to block deleting users")
+	}
+
+	templateContent, err := templates.Files.ReadFile("model.tmpl")
+	if err != nil {
+		t.Fatalf("read model template: %v", err)
+	}
+	content, err := g.GenerateModelFile(users, string(templateContent))
+	if err != nil {
+		t.Fatalf("GenerateModelFile: %v", err)
+	}
+	if _, err := format.Source([]byte(content)); err != nil {
+		t.Fatalf("generated model does not parse: %v\n%s", err, content)
+	}
+	for _, fragment := range []string{
+		"//   - invoices.user_id: blocking the delete (ON DELETE RESTRICT)",
+		"//   - posts.user_id: deleted with it (ON DELETE CASCADE)",
+		"CountDependents(ctx context.Context, db storage.Executor, id uuid.UUID) (map[string]int, error)",
+		`{"invoices", "user_id"},`,
+	} {
+		if !strings.Contains(content, fragment) {
+			t.Fatalf("generated model missing %q:\n%s", fragment, content)
+		}
+	}
+
+	notes, err := g.Build(cat, Config{TableName: "notes", ResourceName: "Note", PackageName: "models", ModulePath: "example.com/app", NullType: "pointer"})
+	if err != nil {
+		t.Fatalf("build notes: %v", err)
+	}
+	if !slices.Equal(notes.Warnings, []string{"notes.folder_id references folders, which no migration creates"}) {
+		t.Fatalf("warnings = %#v", notes.Warnings)
+	}
+	references := map[string]string{}
+	for _, field := range notes.Fields {
+		references[field.Name] = field.References
+	}
+	if references["UserID"] != "users(id) ON DELETE SET NULL" || references["FolderID"] != "folders(id) ON DELETE NO ACTION" {
+		t.Fatalf("references = %#v", references)
+	}
+}
+
+func TestBuildModelIdentityColumns(t *testing.T) {
+	directory := t.TempDir()
+	migration := `-- +goose Up
+CREATE TABLE orders (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    number INTEGER GENERATED BY DEFAULT AS IDENTITY,
+    email TEXT NOT NULL UNIQUE,
+    total INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose Down
+DROP TABLE orders;
+`
+	if err := os.WriteFile(filepath.Join(directory, "20260713120000_create_orders.sql"), []byte(migration), 0o600); err != nil {
+		t.Fatalf("write migration: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	cat, err := g.BuildCatalogFromMigrations("orders", []string{directory})
+	if err != nil {
+		t.Fatalf("build catalog from migrations: %v", err)
+	}
+
+	orders, err := g.Build(cat, Config{TableName: "orders", ResourceName: "Order", PackageName: "models", ModulePath: "example.com/app", NullType: "pointer"})
+	if err != nil {
+		t.Fatalf("build orders: %v", err)
+	}
+	if !orders.IsAutoIncrementID || orders.IDType != "int64" {
+		t.Fatalf("id: auto increment = %t, type = %q", orders.IsAutoIncrementID, orders.IDType)
+	}
+	if got := orders.UpsertSetColumns(); !slices.Equal(got, []string{"total"}) {
+		t.Fatalf("upsert set columns = %v, want [total]", got)
+	}
+
+	templateContent, err := templates.Files.ReadFile("model.tmpl")
+	if err != nil {
+		t.Fatalf("read model template: %v", err)
+	}
+	content, err := g.GenerateModelFile(orders, string(templateContent))
+	if err != nil {
+		t.Fatalf("GenerateModelFile: %v", err)
+	}
+	if _, err := format.Source([]byte(content)); err != nil {
+		t.Fatalf("generated model does not parse: %v\n%s", err, content)
+	}
+	if !strings.Contains(content, `bun:"number,autoincrement"`) {
+		t.Fatalf("generated model does not tag number as autoincrement:\n%s", content)
+	}
+	for _, fragment := range []string{"Number: data.Number", "Number int32\n\tEmail"} {
+		if strings.Contains(content, fragment) {
+			t.Fatalf("generated model writes the identity column (%q):\n%s", fragment, content)
+		}
+	}
+}
+
+func TestBuildModelGeneratedColumns(t *testing.T) {
+	directory := t.TempDir()
+	migration := `-- +goose Up
+CREATE TABLE people (
+    id UUID PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    first_name TEXT NOT NULL,
+    last_name TEXT NOT NULL,
+    full_name TEXT NOT NULL GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+-- +goose Down
+DROP TABLE people;
+`
+	if err := os.WriteFile(filepath.Join(directory, "20260714120000_create_people.sql"), []byte(migration), 0o600); err != nil {
+		t.Fatalf("write migration: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	cat, err := g.BuildCatalogFromMigrations("people", []string{directory})
+	if err != nil {
+		t.Fatalf("build catalog from migrations: %v", err)
+	}
+
+	people, err := g.Build(cat, Config{TableName: "people", ResourceName: "Person", PackageName: "models", ModulePath: "example.com/app", NullType: "pointer"})
+	if err != nil {
+		t.Fatalf("build people: %v", err)
+	}
+	if got := people.UpsertSetColumns(); !slices.Equal(got, []string{"first_name", "last_name"}) {
+		t.Fatalf("upsert set columns = %v, want [first_name last_name]", got)
+	}
+
+	templateContent, err := templates.Files.ReadFile("model.tmpl")
+	if err != nil {
+		t.Fatalf("read model template: %v", err)
+	}
+	content, err := g.GenerateModelFile(people, string(templateContent))
+	if err != nil {
+		t.Fatalf("GenerateModelFile: %v", err)
+	}
+	if _, err := format.Source([]byte(content)); err != nil {
+		t.Fatalf("generated model does not parse: %v\n%s", err, content)
+	}
+	for _, fragment := range []string{`bun:"full_name"`, `ExcludeColumn("full_name")`, `BulkInsert(ctx, db, entities, "full_name")`} {
+		if !strings.Contains(content, fragment) {
+			t.Fatalf("generated model does not read full_name and leave it out of inserts (%q):\n%s", fragment, content)
+		}
+	}
+	for _, fragment := range []string{"FullName: data.FullName", "\tColumn(\"full_name\")", "FullName  string\n"} {
+		if strings.Contains(content, fragment) {
+			t.Fatalf("generated model writes the generated column (%q):\n%s", fragment, content)
+		}
+	}
+
+	// bun selects the columns of the entity's fields, so a struct with the
+	// generated tags must select full_name.
+	fields := []reflect.StructField{{Name: "BaseModel", Type: reflect.TypeFor[bun.BaseModel](), Tag: `bun:"table:people"`, Anonymous: true}}
+	for _, field := range people.Fields {
+		fields = append(fields, reflect.StructField{Name: field.Name, Type: reflect.TypeFor[string](), Tag: reflect.StructTag(`bun:"` + field.BunTag + `"`)})
+	}
+	entity := reflect.New(reflect.StructOf(fields)).Interface()
+	query := bun.NewDB(nil, pgdialect.New()).NewSelect().Model(entity).String()
+	if !strings.Contains(query, `"people"."full_name"`) {
+		t.Fatalf("select does not read full_name: %s", query)
+	}
+}
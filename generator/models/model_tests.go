@@ -0,0 +1,177 @@
+package models
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/mbvlabs/andurel/pkg/templatefuncs"
+)
+
+// GeneratedModelTest is the data of a model's generated _test.go file.
+type GeneratedModelTest struct {
+	ModulePath    string
+	Name          string
+	NamespaceVar  string
+	PluralName    string
+	IDGoFieldName string
+	ForeignKeys   []ModelTestForeignKey
+	// FKArgs are the foreign key arguments the factories take, e.g.
+	// "userID, teamID".
+	FKArgs string
+	// DataFields are the fields of the CreateData and UpdateData structs
+	// the test fills from a built factory.
+	DataFields []string
+	// ComparedFields are the data fields compared with != after Update.
+	ComparedFields []string
+}
+
+// ModelTestForeignKey is a foreign key the factories take. A required one is
+// filled from a record the parent's factory creates, a nullable one is left
+// NULL.
+type ModelTestForeignKey struct {
+	ArgumentName string
+	Type         string
+	GoZero       string
+	Parent       string // Model the key references, empty when nullable
+	ParentField  string // Field of Parent the key holds, e.g. "ID"
+}
+
+// comparableTestTypes are the field types the generated test compares
+// with != after an update.
+var comparableTestTypes = map[string]bool{
+	"string":    true,
+	"bool":      true,
+	"int16":     true,
+	"int32":     true,
+	"int64":     true,
+	"uuid.UUID": true,
+}
+
+// BuildModelTest returns the data of the _test.go file of a model and its
+// factory. The test needs a model with a single uuid or serial key whose
+// factory can insert it, and factories for the models its required foreign
+// keys reference, which are looked up in rootDir.
+func (g *Generator) BuildModelTest(model *GeneratedModel, factory *GeneratedFactory, rootDir string) (*GeneratedModelTest, error) {
+	switch {
+	case model.ReadOnly:
+		return nil, fmt.Errorf("%s is backed by a view, which a test cannot insert into", model.Name)
+	case !model.HasPrimaryKey:
+		return nil, fmt.Errorf("%s has no primary key to find and destroy records by", model.Name)
+	case model.HasCompositeKey:
+		return nil, fmt.Errorf("%s has a composite primary key, which tests are not generated for", model.Name)
+	case model.IDType != "uuid.UUID" && !model.IsAutoIncrementID:
+		return nil, fmt.Errorf("%s has a %s primary key the factory does not fill; tests are only generated for uuid and serial keys", model.Name, model.IDType)
+	case !factory.HasCreateFunction:
+		return nil, fmt.Errorf("the %s factory cannot create records", model.Name)
+	}
+
+	data := &GeneratedModelTest{
+		ModulePath:    model.ModulePath,
+		Name:          model.Name,
+		NamespaceVar:  model.NamespaceVar,
+		PluralName:    model.PluralName,
+		IDGoFieldName: model.IDGoFieldName,
+	}
+
+	fields := make(map[string]GeneratedField, len(model.Fields))
+	for _, field := range model.Fields {
+		fields[field.Name] = field
+		if field.IsPrimaryKey || field.IsSoftDelete || field.IsAutoIncrement || field.IsGenerated || field.Name == "CreatedAt" || field.Name == "UpdatedAt" {
+			continue
+		}
+		data.DataFields = append(data.DataFields, field.Name)
+		if comparableTestTypes[field.Type] {
+			data.ComparedFields = append(data.ComparedFields, field.Name)
+		}
+	}
+
+	var args []string
+	for _, fk := range factory.ForeignKeyFields {
+		foreignKey := ModelTestForeignKey{ArgumentName: fk.ArgumentName, Type: fk.Type, GoZero: fk.GoZero}
+		if field := fields[fk.Name]; !field.IsNullable {
+			table, rest, _ := strings.Cut(field.References, "(")
+			column, _, _ := strings.Cut(rest, ")")
+			if table == "" || column == "" {
+				return nil, fmt.Errorf("cannot tell which table %s.%s references", model.Name, fk.Name)
+			}
+			if _, name, ok := strings.Cut(table, "."); ok {
+				table = name
+			}
+			foreignKey.Parent = naming.DeriveResourceName(strings.TrimSpace(table))
+			foreignKey.ParentField = types.FormatFieldName(strings.TrimSpace(column))
+			if err := checkParentFactory(rootDir, foreignKey.Parent); err != nil {
+				return nil, err
+			}
+		}
+		data.ForeignKeys = append(data.ForeignKeys, foreignKey)
+		args = append(args, fk.ArgumentName)
+	}
+	data.FKArgs = strings.Join(args, ", ")
+
+	return data, nil
+}
+
+// checkParentFactory reports an error unless the factory of parent can
+// create a record without foreign keys of its own.
+func checkParentFactory(rootDir, parent string) error {
+	path := filepath.Join("models", "factories", naming.ToSnakeCase(parent)+".go")
+	content, err := os.ReadFile(filepath.Join(rootDir, path))
+	if err != nil {
+		return fmt.Errorf("the test creates a %s for the foreign key, but %s could not be read; generate the %s model first: %w", parent, path, parent, err)
+	}
+	if !strings.Contains(string(content), "func Create"+parent+"(ctx context.Context, exec storage.Executor, opts ") {
+		return fmt.Errorf("the test creates a %s for the foreign key, but Create%s in %s takes foreign keys of its own", parent, parent, path)
+	}
+	return nil
+}
+
+// GenerateModelTestFile renders a model's _test.go file.
+func (g *Generator) GenerateModelTestFile(test *GeneratedModelTest) (string, error) {
+	templateContent, err := templates.Files.ReadFile("model_test.tmpl")
+	if err != nil {
+		return "", fmt.Errorf("failed to read model test template: %w", err)
+	}
+
+	tmpl, err := template.New("model_test").Funcs(templatefuncs.FuncMap()).Parse(string(templateContent))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse model test template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, test); err != nil {
+		return "", fmt.Errorf("failed to execute model test template: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// WriteModelTestFile writes models/<name>_test.go, which must not exist yet.
+func (g *Generator) WriteModelTestFile(test *GeneratedModelTest, outputDir string) error {
+	outputPath := filepath.Join(outputDir, "models", naming.ToSnakeCase(test.Name)+"_test.go")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists", outputPath)
+	}
+
+	content, err := g.GenerateModelTestFile(test)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write model test file: %w", err)
+	}
+
+	if err := files.FormatGoFile(outputPath); err != nil {
+		return fmt.Errorf("failed to format model test file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func buildModelTestFixture(t *testing.T, table *catalog.Table, resourceName string) (*GeneratedModel, *GeneratedFactory) {
+	t.Helper()
+
+	cat := catalog.NewCatalog("public")
+	if err := cat.AddTable("public", table); err != nil {
+		t.Fatalf("add table: %v", err)
+	}
+	g := NewGenerator("postgresql")
+	config := Config{
+		TableName:    table.Name,
+		ResourceName: resourceName,
+		PackageName:  "models",
+		ModulePath:   "example.com/app",
+	}
+	model, err := g.Build(cat, config)
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+	config.PackageName = "factories"
+	factory, err := g.BuildFactory(cat, config, model)
+	if err != nil {
+		t.Fatalf("build factory: %v", err)
+	}
+	return model, factory
+}
+
+func TestBuildModelTestFillsForeignKeysFromParentFactories(t *testing.T) {
+	model, factory := buildModelTestFixture(t, tableWithColumns(t, "posts",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("author_id", "uuid").SetNotNull().SetForeignKey("users", "id"),
+		catalog.NewColumn("editor_id", "uuid").SetForeignKey("users", "id"),
+		catalog.NewColumn("title", "text").SetNotNull(),
+		catalog.NewColumn("rating", "numeric").SetNotNull(),
+		catalog.NewColumn("created_at", "timestamptz").SetNotNull(),
+		catalog.NewColumn("updated_at", "timestamptz").SetNotNull(),
+	), "Post")
+
+	rootDir := t.TempDir()
+	g := NewGenerator("postgresql")
+	if _, err := g.BuildModelTest(model, factory, rootDir); err == nil || !strings.Contains(err.Error(), "generate the User model first") {
+		t.Fatalf("expected missing parent factory error, got %v", err)
+	}
+
+	factoryPath := filepath.Join(rootDir, "models", "factories", "user.go")
+	if err := os.MkdirAll(filepath.Dir(factoryPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(factoryPath, []byte("package factories\n\nfunc CreateUser(ctx context.Context, exec storage.Executor, opts ...UserOption) (models.UserEntity, error) {\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	test, err := g.BuildModelTest(model, factory, rootDir)
+	if err != nil {
+		t.Fatalf("BuildModelTest: %v", err)
+	}
+	if test.FKArgs != "authorID, editorID" {
+		t.Fatalf("FKArgs = %q", test.FKArgs)
+	}
+	if got := test.ForeignKeys[0]; got.Parent != "User" || got.ParentField != "ID" {
+		t.Fatalf("author_id should be filled from a User, got %#v", got)
+	}
+	if got := test.ForeignKeys[1]; got.Parent != "" {
+		t.Fatalf("nullable editor_id should be left NULL, got %#v", got)
+	}
+	if strings.Join(test.DataFields, ",") != "AuthorID,EditorID,Title,Rating" {
+		t.Fatalf("DataFields = %v", test.DataFields)
+	}
+	if strings.Join(test.ComparedFields, ",") != "AuthorID,Title" {
+		t.Fatalf("ComparedFields = %v", test.ComparedFields)
+	}
+
+	content, err := g.GenerateModelTestFile(test)
+	if err != nil {
+		t.Fatalf("GenerateModelTestFile: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "post_test.go", content, 0); err != nil {
+		t.Fatalf("generated test does not parse: %v\n\n%s", err, content)
+	}
+	for _, want := range []string{
+		"package models_test",
+		"func createPostArgs(t *testing.T, ctx context.Context, db storage.Executor) (uuid.UUID, *uuid.UUID) {",
+		"authorIDParent, err := factories.CreateUser(ctx, db)",
+		"return authorIDParent.ID, nil",
+		"db := database.NewTestDB(t).Executor()",
+		"created, err := models.Post.Create(ctx, db, models.CreatePostData{",
+		"\t\tTitle: built.Title,",
+		"if _, err := models.Post.Update(ctx, db, models.UpdatePostData{\n\t\tID: created.ID,",
+		"if found.Title != changed.Title {",
+		"!errors.Is(err, sql.ErrNoRows)",
+		"factories.CreatePosts(ctx, db, authorID, editorID, 3)",
+		"page, err := models.Post.Paginate(ctx, db, 1, 2)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated test missing %q\n\n%s", want, content)
+		}
+	}
+}
+
+func TestBuildModelTestRejectsUnsupportedKeys(t *testing.T) {
+	g := NewGenerator("postgresql")
+	for name, table := range map[string]*catalog.Table{
+		"composite": tableWithColumns(t, "memberships",
+			catalog.NewColumn("team_id", "uuid").SetPrimaryKey(),
+			catalog.NewColumn("user_id", "uuid").SetPrimaryKey(),
+		),
+		"text key": tableWithColumns(t, "memberships",
+			catalog.NewColumn("code", "text").SetPrimaryKey(),
+		),
+	} {
+		model, factory := buildModelTestFixture(t, table, "Membership")
+		if _, err := g.BuildModelTest(model, factory, t.TempDir()); err == nil {
+			t.Errorf("%s: expected BuildModelTest to fail", name)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+// IsConflictColumn reports whether column is part of the ON CONFLICT target
+// of Upsert. Upsert leaves these columns as stored.
+func (m GeneratedModel) IsConflictColumn(column string) bool {
+	return slices.Contains(m.ConflictColumns, column)
+}
+
+// UpsertSetColumns returns the columns Upsert overwrites on a conflict: all
+// but the key, the conflict target, deleted_at, serial, identity and
+// generated columns, and the timestamps. When none are left it sets the first conflict column to
+// itself, since bun would set every column, the key included, for a DO UPDATE
+// without SET.
+func (m GeneratedModel) UpsertSetColumns() []string {
+	var columns []string
+	for _, field := range m.Fields {
+		column, _, _ := strings.Cut(field.BunTag, ",")
+		if field.IsPrimaryKey || field.IsAutoIncrement || field.IsGenerated || field.IsSoftDelete || m.IsConflictColumn(column) || column == "created_at" || column == "updated_at" {
+			continue
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 && len(m.ConflictColumns) > 0 {
+		columns = m.ConflictColumns[:1]
+	}
+	return columns
+}
+
+// BuildConflictColumns returns the columns Upsert detects an existing row by.
+// requested comes from --conflict-on and must name columns of the table.
+// Without it, a model that generates its own key, a uuid or serial, upserts
+// on the table's only unique key other than the primary key, since a new key
+// never conflicts; every other model upserts on its primary key.
+func BuildConflictColumns(table *catalog.Table, model *GeneratedModel, requested []string) ([]string, error) {
+	if len(requested) > 0 {
+		if !model.HasPrimaryKey {
+			return nil, fmt.Errorf("--conflict-on needs a primary key: Upsert is only generated for tables with one")
+		}
+		var columns []string
+		for _, column := range requested {
+			column = strings.TrimSpace(column)
+			if findColumn(table, column) == nil {
+				return nil, fmt.Errorf("--conflict-on: table %s has no column %q", table.Name, column)
+			}
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+		return columns, nil
+	}
+
+	if !model.HasPrimaryKey {
+		return nil, nil
+	}
+	if model.HasCompositeKey {
+		columns := make([]string, 0, len(model.PrimaryKeys))
+		for _, key := range model.PrimaryKeys {
+			columns = append(columns, key.Column)
+		}
+		return columns, nil
+	}
+
+	generatedKey := model.IsAutoIncrementID || model.IDType == "" || model.IDType == "uuid.UUID"
+	if keys := uniqueKeys(table, model.IDFieldName); generatedKey && len(keys) == 1 {
+		return keys[0], nil
+	}
+	return []string{model.IDFieldName}, nil
+}
+
+// uniqueKeys lists the column sets of the table's unique columns and unique
+// indexes, leaving out idColumn and indexes an ON CONFLICT target cannot name
+// by columns alone.
+func uniqueKeys(table *catalog.Table, idColumn string) [][]string {
+	var keys [][]string
+	add := func(columns []string) {
+		if slices.Equal(columns, []string{idColumn}) {
+			return
+		}
+		if slices.ContainsFunc(keys, func(key []string) bool { return sameColumns(key, columns) }) {
+			return
+		}
+		keys = append(keys, columns)
+	}
+
+	for _, col := range table.Columns {
+		if col.IsUnique && !col.IsPrimaryKey {
+			add([]string{col.Name})
+		}
+	}
+	for _, index := range table.Indexes {
+		if index.IsUnique && !index.Partial && len(index.Columns) > 0 {
+			add(index.Columns)
+		}
+	}
+
+	return keys
+}
+
+func sameColumns(a, b []string) bool {
+	return len(a) == len(b) && !slices.ContainsFunc(a, func(column string) bool { return !slices.Contains(b, column) })
+}
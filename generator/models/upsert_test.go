@@ -0,0 +1,101 @@
+package models
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func TestBuildConflictColumns(t *testing.T) {
+	uuidModel := &GeneratedModel{HasPrimaryKey: true, IDFieldName: "id", IDType: "uuid.UUID"}
+	stringModel := &GeneratedModel{HasPrimaryKey: true, IDFieldName: "code", IDType: "string"}
+
+	tests := []struct {
+		name      string
+		columns   []*catalog.Column
+		indexes   []*catalog.Index
+		model     *GeneratedModel
+		requested []string
+		want      []string
+	}{
+		{
+			name:    "primary key without other unique keys",
+			columns: []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("name", "text")},
+			model:   uuidModel,
+			want:    []string{"id"},
+		},
+		{
+			name:    "only unique column of a generated key",
+			columns: []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("email", "text").SetUnique()},
+			model:   uuidModel,
+			want:    []string{"email"},
+		},
+		{
+			name:    "only unique index of a generated key",
+			columns: []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("tenant_id", "uuid"), catalog.NewColumn("email", "text")},
+			indexes: []*catalog.Index{
+				{Name: "users_tenant_email_key", Columns: []string{"tenant_id", "email"}, IsUnique: true},
+				{Name: "users_email_idx", Columns: []string{"email"}},
+			},
+			model: uuidModel,
+			want:  []string{"tenant_id", "email"},
+		},
+		{
+			name:    "several unique keys",
+			columns: []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("email", "text").SetUnique(), catalog.NewColumn("handle", "text").SetUnique()},
+			model:   uuidModel,
+			want:    []string{"id"},
+		},
+		{
+			name:    "partial unique index",
+			columns: []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("email", "text")},
+			indexes: []*catalog.Index{{Name: "users_email_key", Columns: []string{"email"}, IsUnique: true, Partial: true}},
+			model:   uuidModel,
+			want:    []string{"id"},
+		},
+		{
+			name:    "caller supplied key",
+			columns: []*catalog.Column{catalog.NewColumn("code", "text").SetPrimaryKey(), catalog.NewColumn("email", "text").SetUnique()},
+			model:   stringModel,
+			want:    []string{"code"},
+		},
+		{
+			name:      "requested columns",
+			columns:   []*catalog.Column{catalog.NewColumn("id", "uuid").SetPrimaryKey(), catalog.NewColumn("email", "text").SetUnique(), catalog.NewColumn("handle", "text").SetUnique()},
+			model:     uuidModel,
+			requested: []string{"handle", " handle"},
+			want:      []string{"handle"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tableWithColumns(t, "users", tt.columns...)
+			for _, index := range tt.indexes {
+				if err := table.AddIndex(index); err != nil {
+					t.Fatalf("add index: %v", err)
+				}
+			}
+
+			got, err := BuildConflictColumns(table, tt.model, tt.requested)
+			if err != nil {
+				t.Fatalf("BuildConflictColumns() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("BuildConflictColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildConflictColumnsRejectsUnknownColumn(t *testing.T) {
+	table := tableWithColumns(t, "users", catalog.NewColumn("id", "uuid").SetPrimaryKey())
+	model := &GeneratedModel{HasPrimaryKey: true, IDFieldName: "id", IDType: "uuid.UUID"}
+
+	_, err := BuildConflictColumns(table, model, []string{"email"})
+	if err == nil || !strings.Contains(err.Error(), `no column "email"`) {
+		t.Fatalf("expected unknown column error, got %v", err)
+	}
+}
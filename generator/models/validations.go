@@ -0,0 +1,393 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+)
+
+// GeneratedValidation is one rule the Validate method of the Create and
+// Update data structs checks, derived from a column constraint.
+type GeneratedValidation struct {
+	Column    string // Column name reported as the error field
+	Field     string // Go field of the data struct the rule reads
+	Code      string // Error code, e.g. "required" or "max_length"
+	Message   string // Go string literal of the error message
+	Condition string // Go expression over d that is true when the value is invalid
+	Params    string // Go map literal of the rule parameters, if any
+}
+
+// validatedValue describes how a field's value is read in a Validate method.
+type validatedValue struct {
+	field string // Go field name, e.g. "Name"
+	guard string // Condition under which the value is set, e.g. "d.Name.Valid"
+	expr  string // The value, e.g. "d.Name.String"
+	kind  string // "string", "int", "float", "decimal" or "uuid"
+}
+
+// BuildValidations derives the rules of the Create and Update data structs
+// from the NOT NULL, varchar length and CHECK constraints of table. Fields are
+// matched to columns by their bun tag, so custom types the rules cannot read
+// are skipped. CHECK conditions other than simple comparisons, BETWEEN, IN
+// lists and length checks are left to the database.
+func BuildValidations(table *catalog.Table, fields []GeneratedField) []GeneratedValidation {
+	values := make(map[string]validatedValue, len(fields))
+	for _, field := range fields {
+		if field.IsPrimaryKey || field.IsAutoIncrement || field.IsGenerated || field.IsSoftDelete || field.Enum != nil {
+			continue
+		}
+		column, _, _ := strings.Cut(field.BunTag, ",")
+		if column == "created_at" || column == "updated_at" {
+			continue
+		}
+		if value, ok := fieldValue(field); ok {
+			values[column] = value
+		}
+	}
+
+	var validations []GeneratedValidation
+	seen := make(map[string]bool)
+	add := func(column string, value validatedValue, validation GeneratedValidation) {
+		key := column + "\x00" + validation.Code
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		validation.Column = column
+		validation.Field = value.field
+		if value.guard != "" {
+			validation.Condition = value.guard + " && " + validation.Condition
+		}
+		validations = append(validations, validation)
+	}
+
+	for _, col := range table.Columns {
+		value, ok := values[col.Name]
+		if !ok {
+			continue
+		}
+		if !col.IsNullable && col.DefaultVal == nil {
+			switch value.kind {
+			case "string":
+				add(col.Name, value, requiredValidation(value.expr+` == ""`))
+			case "uuid":
+				add(col.Name, value, requiredValidation(value.expr+" == uuid.Nil"))
+			}
+		}
+		if col.Length != nil && value.kind == "string" && isCharacterType(col.DataType) {
+			add(col.Name, value, lengthValidation(value.expr, "<=", int(*col.Length)))
+		}
+	}
+
+	for _, check := range table.Checks {
+		for _, condition := range splitConjunction(check.Expression) {
+			for _, rule := range checkRules(condition) {
+				value, ok := values[rule.column]
+				if !ok {
+					continue
+				}
+				if validation, ok := rule.validation(value); ok {
+					add(rule.column, value, validation)
+				}
+			}
+		}
+	}
+
+	return validations
+}
+
+// validationImports returns the packages the conditions of validations use.
+func validationImports(validations []GeneratedValidation) map[string]bool {
+	importSet := map[string]bool{}
+	for _, validation := range validations {
+		if strings.Contains(validation.Condition, "utf8.") {
+			importSet["unicode/utf8"] = true
+		}
+		if strings.Contains(validation.Condition, "slices.") {
+			importSet["slices"] = true
+		}
+		if strings.Contains(validation.Condition, "uuid.") {
+			importSet["github.com/google/uuid"] = true
+		}
+		if strings.Contains(validation.Condition, "decimal.") {
+			importSet[types.DecimalPackage] = true
+		}
+	}
+	return importSet
+}
+
+func fieldValue(field GeneratedField) (validatedValue, bool) {
+	name := "d." + field.Name
+	typ := field.Type
+	if base, ok := strings.CutPrefix(typ, "*"); ok {
+		switch kind := valueKind(base); kind {
+		case "", "uuid":
+			return validatedValue{}, false
+		case "decimal":
+			// Cmp is in the method set of the pointer, so it is not dereferenced.
+			return validatedValue{field: field.Name, guard: name + " != nil", expr: name, kind: kind}, true
+		default:
+			return validatedValue{field: field.Name, guard: name + " != nil", expr: "*" + name, kind: kind}, true
+		}
+	}
+	if kind := valueKind(typ); kind != "" {
+		return validatedValue{field: field.Name, expr: name, kind: kind}, true
+	}
+
+	nullType, ok := strings.CutPrefix(typ, "sql.Null")
+	if !ok {
+		nullType, ok = strings.CutPrefix(typ, "bun.Null")
+	}
+	if !ok {
+		return validatedValue{}, false
+	}
+	kind := valueKind(strings.ToLower(nullType))
+	if kind == "" || kind == "uuid" {
+		return validatedValue{}, false
+	}
+	return validatedValue{field: field.Name, guard: name + ".Valid", expr: name + "." + nullType, kind: kind}, true
+}
+
+func valueKind(goType string) string {
+	switch goType {
+	case "string":
+		return "string"
+	case "int16", "int32", "int64":
+		return "int"
+	case "float32", "float64":
+		return "float"
+	case types.DecimalGoType:
+		return "decimal"
+	case "uuid.UUID":
+		return "uuid"
+	}
+	return ""
+}
+
+func isCharacterType(dataType string) bool {
+	switch strings.ToLower(dataType) {
+	case "varchar", "character varying", "char", "character":
+		return true
+	}
+	return false
+}
+
+func requiredValidation(condition string) GeneratedValidation {
+	return GeneratedValidation{Code: "required", Message: strconv.Quote("is required"), Condition: condition}
+}
+
+// negatedOperators maps a SQL comparison to the Go operator of its failure.
+var negatedOperators = map[string]string{
+	">":  "<=",
+	">=": "<",
+	"<":  ">=",
+	"<=": ">",
+	"=":  "!=",
+	"<>": "==",
+	"!=": "==",
+}
+
+func comparisonValidation(expr, operator, literal string) GeneratedValidation {
+	validation := GeneratedValidation{Condition: expr + " " + negatedOperators[operator] + " " + literal}
+	switch operator {
+	case ">":
+		validation.Code, validation.Message, validation.Params = "greater_than", "must be greater than "+literal, "value"
+	case ">=":
+		validation.Code, validation.Message, validation.Params = "min", "must be at least "+literal, "min"
+	case "<":
+		validation.Code, validation.Message, validation.Params = "less_than", "must be less than "+literal, "value"
+	case "<=":
+		validation.Code, validation.Message, validation.Params = "max", "must be at most "+literal, "max"
+	case "=":
+		validation.Code, validation.Message, validation.Params = "equal", "must be "+literal, "value"
+	default:
+		validation.Code, validation.Message, validation.Params = "not_equal", "must not be "+literal, "value"
+	}
+	validation.Message = strconv.Quote(validation.Message)
+	validation.Params = fmt.Sprintf("map[string]any{%q: %s}", validation.Params, literal)
+	return validation
+}
+
+func lengthValidation(expr, operator string, length int) GeneratedValidation {
+	count := "utf8.RuneCountInString(" + expr + ")"
+	switch operator {
+	case ">":
+		operator, length = ">=", length+1
+	case "<":
+		operator, length = "<=", length-1
+	}
+	validation := GeneratedValidation{Condition: fmt.Sprintf("%s %s %d", count, negatedOperators[operator], length)}
+	switch operator {
+	case ">=":
+		validation.Code = "min_length"
+		validation.Message = fmt.Sprintf("must be at least %d characters", length)
+		validation.Params = fmt.Sprintf("map[string]any{\"min\": %d}", length)
+	case "<=":
+		validation.Code = "max_length"
+		validation.Message = fmt.Sprintf("must be at most %d characters", length)
+		validation.Params = fmt.Sprintf("map[string]any{\"max\": %d}", length)
+	case "=":
+		validation.Code = "length"
+		validation.Message = fmt.Sprintf("must be exactly %d characters", length)
+		validation.Params = fmt.Sprintf("map[string]any{\"length\": %d}", length)
+	default:
+		return GeneratedValidation{}
+	}
+	validation.Message = strconv.Quote(validation.Message)
+	return validation
+}
+
+// checkRule is a rule on one column read from a CHECK condition. validation
+// renders it for the column's value, or reports false when the rule does not
+// apply to the column's type.
+type checkRule struct {
+	column     string
+	validation func(validatedValue) (GeneratedValidation, bool)
+}
+
+var (
+	checkComparison = regexp.MustCompile(`(?i)^([a-z_][a-z0-9_]*)\s*(>=|<=|<>|!=|>|<|=)\s*(-?\d+(?:\.\d+)?)$`)
+	checkBetween    = regexp.MustCompile(`(?i)^([a-z_][a-z0-9_]*)\s+between\s+(-?\d+(?:\.\d+)?)\s+and\s+(-?\d+(?:\.\d+)?)$`)
+	checkIn         = regexp.MustCompile(`(?i)^([a-z_][a-z0-9_]*)\s+in\s*\((\s*'(?:[^']|'')*'\s*(?:,\s*'(?:[^']|'')*'\s*)*)\)$`)
+	checkInValue    = regexp.MustCompile(`'((?:[^']|'')*)'`)
+	checkLength     = regexp.MustCompile(`(?i)^(?:char_length|character_length|length)\s*\(\s*([a-z_][a-z0-9_]*)\s*\)\s*(>=|<=|>|<|=)\s*(\d+)$`)
+	checkNotEmpty   = regexp.MustCompile(`(?i)^([a-z_][a-z0-9_]*)\s*(?:<>|!=)\s*''$`)
+)
+
+func checkRules(condition string) []checkRule {
+	condition = trimParens(condition)
+
+	if m := checkNotEmpty.FindStringSubmatch(condition); m != nil {
+		return []checkRule{{column: strings.ToLower(m[1]), validation: func(value validatedValue) (GeneratedValidation, bool) {
+			return requiredValidation(value.expr + ` == ""`), value.kind == "string"
+		}}}
+	}
+	if m := checkComparison.FindStringSubmatch(condition); m != nil {
+		return []checkRule{numberRule(m[1], m[2], m[3])}
+	}
+	if m := checkBetween.FindStringSubmatch(condition); m != nil {
+		return []checkRule{numberRule(m[1], ">=", m[2]), numberRule(m[1], "<=", m[3])}
+	}
+	if m := checkLength.FindStringSubmatch(condition); m != nil {
+		length, err := strconv.Atoi(m[3])
+		if err != nil {
+			return nil
+		}
+		return []checkRule{{column: strings.ToLower(m[1]), validation: func(value validatedValue) (GeneratedValidation, bool) {
+			validation := lengthValidation(value.expr, m[2], length)
+			return validation, value.kind == "string" && validation.Code != ""
+		}}}
+	}
+	if m := checkIn.FindStringSubmatch(condition); m != nil {
+		var values []string
+		for _, quoted := range checkInValue.FindAllStringSubmatch(m[2], -1) {
+			values = append(values, strings.ReplaceAll(quoted[1], "''", "'"))
+		}
+		return []checkRule{{column: strings.ToLower(m[1]), validation: func(value validatedValue) (GeneratedValidation, bool) {
+			return oneOfValidation(value.expr, values), value.kind == "string"
+		}}}
+	}
+	return nil
+}
+
+func numberRule(column, operator, literal string) checkRule {
+	return checkRule{column: strings.ToLower(column), validation: func(value validatedValue) (GeneratedValidation, bool) {
+		switch value.kind {
+		case "int":
+			return comparisonValidation(value.expr, operator, literal), !strings.Contains(literal, ".")
+		case "float":
+			return comparisonValidation(value.expr, operator, literal), true
+		case "decimal":
+			validation := comparisonValidation(value.expr, operator, literal)
+			validation.Condition = fmt.Sprintf("%s.Cmp(decimal.RequireFromString(%q)) %s 0", value.expr, literal, negatedOperators[operator])
+			return validation, true
+		}
+		return GeneratedValidation{}, false
+	}}
+}
+
+func oneOfValidation(expr string, values []string) GeneratedValidation {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	list := strings.Join(quoted, ", ")
+	return GeneratedValidation{
+		Code:      "one_of",
+		Message:   strconv.Quote("must be one of " + strings.Join(values, ", ")),
+		Condition: "!slices.Contains([]string{" + list + "}, " + expr + ")",
+		Params:    "map[string]any{\"values\": []string{" + list + "}}",
+	}
+}
+
+// splitConjunction splits a CHECK condition on its top-level ANDs, leaving
+// the AND of a BETWEEN in place.
+func splitConjunction(condition string) []string {
+	condition = trimParens(condition)
+	var parts []string
+	depth, start, betweens := 0, 0, 0
+	inString := false
+	lower := strings.ToLower(condition)
+	for i := 0; i < len(condition); i++ {
+		switch char := condition[i]; {
+		case char == '\'':
+			inString = !inString
+		case inString:
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+		case depth == 0 && isKeywordAt(lower, i, "between"):
+			betweens++
+		case depth == 0 && isKeywordAt(lower, i, "and"):
+			if betweens > 0 {
+				betweens--
+				continue
+			}
+			parts = append(parts, strings.TrimSpace(condition[start:i]))
+			start = i + len("and")
+		}
+	}
+	return append(parts, strings.TrimSpace(condition[start:]))
+}
+
+func isKeywordAt(s string, i int, keyword string) bool {
+	if !strings.HasPrefix(s[i:], keyword) {
+		return false
+	}
+	isIdent := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+	}
+	end := i + len(keyword)
+	return (i == 0 || !isIdent(s[i-1])) && (end == len(s) || !isIdent(s[end]))
+}
+
+// trimParens removes parentheses wrapping the whole of condition.
+func trimParens(condition string) string {
+	condition = strings.TrimSpace(condition)
+	for strings.HasPrefix(condition, "(") && strings.HasSuffix(condition, ")") {
+		depth := 0
+		wrapped := true
+		for i := 0; i < len(condition)-1; i++ {
+			switch condition[i] {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				wrapped = false
+				break
+			}
+		}
+		if !wrapped {
+			break
+		}
+		condition = strings.TrimSpace(condition[1 : len(condition)-1])
+	}
+	return condition
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+)
+
+func TestBuildValidationsFromConstraints(t *testing.T) {
+	table := tableWithColumns(t, "products",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("name", "varchar").SetLength(100).SetNotNull(),
+		catalog.NewColumn("sku", "text").SetNotNull().SetDefault("''"),
+		catalog.NewColumn("status", "text").SetNotNull(),
+		catalog.NewColumn("price", "integer").SetNotNull(),
+		catalog.NewColumn("rating", "double precision"),
+		catalog.NewColumn("nickname", "varchar").SetLength(20),
+		catalog.NewColumn("owner_id", "uuid").SetNotNull(),
+		catalog.NewColumn("created_at", "timestamptz").SetNotNull(),
+	)
+	for _, expression := range []string{
+		"char_length(name) >= 3",
+		"price > 0 AND price <= 100000",
+		"rating BETWEEN 0.5 AND 5",
+		"status IN ('draft', 'live', 'it''s out')",
+		"(sku <> '')",
+		"price > rating",
+		"created_at < now()",
+	} {
+		if err := table.AddCheck(&catalog.Check{Expression: expression}); err != nil {
+			t.Fatalf("add check: %v", err)
+		}
+	}
+
+	g := NewGenerator("postgresql")
+	g.typeMapper.NullType = "sql.Null"
+	var fields []GeneratedField
+	for _, col := range table.Columns {
+		field, err := g.buildField(col)
+		if err != nil {
+			t.Fatalf("buildField(%s): %v", col.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	var got []string
+	for _, validation := range BuildValidations(table, fields) {
+		got = append(got, validation.Column+" "+validation.Code+": "+validation.Condition)
+	}
+	want := []string{
+		`name required: d.Name == ""`,
+		`name max_length: utf8.RuneCountInString(d.Name) > 100`,
+		`status required: d.Status == ""`,
+		`nickname max_length: d.Nickname.Valid && utf8.RuneCountInString(d.Nickname.String) > 20`,
+		`owner_id required: d.OwnerID == uuid.Nil`,
+		`name min_length: utf8.RuneCountInString(d.Name) < 3`,
+		`price greater_than: d.Price <= 0`,
+		`price max: d.Price > 100000`,
+		`rating min: d.Rating.Valid && d.Rating.Float64 < 0.5`,
+		`rating max: d.Rating.Valid && d.Rating.Float64 > 5`,
+		`status one_of: !slices.Contains([]string{"draft", "live", "it's out"}, d.Status)`,
+		`sku required: d.Sku == ""`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("validations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestBuildModelImportsValidationPackages(t *testing.T) {
+	table := tableWithColumns(t, "tickets",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("title", "varchar").SetLength(80).SetNotNull(),
+		catalog.NewColumn("priority", "text").SetNotNull(),
+	)
+	if err := table.AddCheck(&catalog.Check{Expression: "priority IN ('low', 'high')"}); err != nil {
+		t.Fatalf("add check: %v", err)
+	}
+	cat := catalog.NewCatalog("public")
+	if err := cat.AddTable("public", table); err != nil {
+		t.Fatalf("add table: %v", err)
+	}
+
+	model, err := NewGenerator("postgresql").Build(cat, Config{
+		TableName:    "tickets",
+		ResourceName: "Ticket",
+		PackageName:  "models",
+		DatabaseType: "postgresql",
+		ModulePath:   "github.com/example/shop",
+	})
+	if err != nil {
+		t.Fatalf("build model: %v", err)
+	}
+	for _, imp := range []string{"unicode/utf8", "slices"} {
+		if !hasImport(model.StandardImports, imp) {
+			t.Errorf("model imports missing %s: %#v", imp, model.StandardImports)
+		}
+	}
+	if len(model.Validations) != 4 {
+		t.Errorf("validations = %+v, want 4", model.Validations)
+	}
+}
+
+func TestBuildValidationsComparesDecimals(t *testing.T) {
+	table := tableWithColumns(t, "invoices",
+		catalog.NewColumn("id", "uuid").SetPrimaryKey(),
+		catalog.NewColumn("total", "numeric").SetNotNull(),
+		catalog.NewColumn("discount", "numeric"),
+	)
+	for _, expression := range []string{"total >= 0", "discount BETWEEN 0 AND 99.5"} {
+		if err := table.AddCheck(&catalog.Check{Expression: expression}); err != nil {
+			t.Fatalf("add check: %v", err)
+		}
+	}
+
+	g := NewGenerator("postgresql")
+	g.typeMapper.DecimalType = "decimal"
+	var fields []GeneratedField
+	for _, col := range table.Columns {
+		field, err := g.buildField(col)
+		if err != nil {
+			t.Fatalf("buildField(%s): %v", col.Name, err)
+		}
+		fields = append(fields, field)
+	}
+
+	validations := BuildValidations(table, fields)
+	var got []string
+	for _, validation := range validations {
+		got = append(got, validation.Column+" "+validation.Code+": "+validation.Condition)
+	}
+	want := []string{
+		`total min: d.Total.Cmp(decimal.RequireFromString("0")) < 0`,
+		`discount min: d.Discount != nil && d.Discount.Cmp(decimal.RequireFromString("0")) < 0`,
+		`discount max: d.Discount != nil && d.Discount.Cmp(decimal.RequireFromString("99.5")) > 0`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("validations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !validationImports(validations)["github.com/shopspring/decimal"] {
+		t.Fatalf("validation imports missing the decimal package")
+	}
+}
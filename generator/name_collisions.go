@@ -0,0 +1,283 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// modelMethodParams are the parameters of the methods generated on a
+// model's namespace type, which its receiver must not share.
+var modelMethodParams = []string{"ctx", "db", "id", "data", "rows", "page", "cursor", "filter", "scopes"}
+
+// modelImports are the packages every generated model file imports, which
+// its namespace type must not be named after.
+var modelImports = []string{"context", "errors", "fmt", "time", "bun", "storage", "validation"}
+
+// controllerMethodParams are the parameters of the methods generated on a
+// controller, which its receiver must not share.
+var controllerMethodParams = []string{"r", "etx", "id"}
+
+var routeNameSuffixes = []string{
+	"Prefix",
+	"Index", "IndexURL",
+	"Show", "ShowURL",
+	"New", "NewURL",
+	"Create", "CreateURL",
+	"Edit", "EditURL",
+	"Update", "UpdateURL",
+	"Destroy", "DestroyURL",
+}
+
+var majorVersionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// declaration is a package-level name found in a Go file.
+type declaration struct {
+	file     string
+	imported bool
+}
+
+// validateModelIdentifiers checks that the identifiers a model of
+// resourceName is generated with compile: its namespace type, such as event
+// for Event, and the receiver of its methods.
+func validateModelIdentifiers(resourceName string) error {
+	namespaceType := naming.ToLowerCamelCaseFromAny(resourceName)
+	if err := checkIdentifier(resourceName, "model type", namespaceType); err != nil {
+		return err
+	}
+	if types.Universe.Lookup(namespaceType) != nil {
+		return fmt.Errorf(
+			"resource name '%s' cannot be used: the model type %s would shadow Go's predeclared %s; choose another name",
+			resourceName,
+			namespaceType,
+			namespaceType,
+		)
+	}
+	if slices.Contains(modelImports, namespaceType) {
+		return fmt.Errorf(
+			"resource name '%s' cannot be used: the model type %s would clash with the package %s that models import; choose another name",
+			resourceName,
+			namespaceType,
+			namespaceType,
+		)
+	}
+
+	return checkReceiver(resourceName, "model", modelMethodParams)
+}
+
+// validateControllerIdentifiers checks the receiver of the methods of the
+// controller generated for resourceName.
+func validateControllerIdentifiers(resourceName string) error {
+	return checkReceiver(resourceName, "controller", controllerMethodParams)
+}
+
+func checkIdentifier(resourceName, role, name string) error {
+	if token.IsKeyword(name) {
+		return fmt.Errorf(
+			"resource name '%s' cannot be used: the %s would be named %s, a Go keyword; choose another name",
+			resourceName,
+			role,
+			name,
+		)
+	}
+	return nil
+}
+
+func checkReceiver(resourceName, kind string, params []string) error {
+	receiver := naming.ToReceiverName(resourceName)
+	if err := checkIdentifier(resourceName, kind+" receiver", receiver); err != nil {
+		return err
+	}
+	if slices.Contains(params, receiver) {
+		return fmt.Errorf(
+			"resource name '%s' cannot be used: the %s receiver %s would clash with the %s parameter of its generated methods; choose another name",
+			resourceName,
+			kind,
+			receiver,
+			receiver,
+		)
+	}
+	return nil
+}
+
+// validateModelNamesAvailable checks that none of the package-level names a
+// model of resourceName declares is already declared in the models package,
+// other than in the model's own file. The model's namespace entries in
+// model.go are left to registerNamespace.
+func validateModelNamesAvailable(modelsDir, resourceName, modelPath string) error {
+	declared, err := declaredNames(modelsDir, modelPath)
+	if err != nil {
+		return err
+	}
+
+	namespaceType := naming.ToLowerCamelCaseFromAny(resourceName)
+	plural := naming.Plural(resourceName)
+	names := []string{
+		resourceName,
+		namespaceType,
+		resourceName + "Entity",
+		resourceName + "Database",
+		"Create" + resourceName + "Data",
+		"Update" + resourceName + "Data",
+		"Paginated" + plural,
+		resourceName + "Cursor",
+		"Parse" + resourceName + "Cursor",
+		plural + "Page",
+		resourceName + "Filter",
+	}
+	namespaceFile := filepath.Join(modelsDir, "model.go")
+	for _, name := range names {
+		decl, ok := declared[name]
+		if !ok {
+			continue
+		}
+		if decl.file == namespaceFile && !decl.imported && (name == resourceName || name == namespaceType) {
+			continue
+		}
+		return collisionError(resourceName, "model", name, decl)
+	}
+
+	return nil
+}
+
+// validateControllerNamesAvailable checks that the controller type and the
+// route variables generated for resourceName are not already declared in the
+// controllers and routes packages, other than in the files the generator
+// writes, which it extends with new actions.
+func validateControllerNamesAvailable(ctx *controllerValidationContext, namespace string) error {
+	controllers, err := declaredNames(filepath.Dir(ctx.ControllerPath), ctx.ControllerPath)
+	if err != nil {
+		return err
+	}
+	plural := naming.Plural(ctx.ResourceName)
+	for _, name := range []string{plural, "New" + plural} {
+		if decl, ok := controllers[name]; ok {
+			return collisionError(ctx.ResourceName, "controller", name, decl)
+		}
+	}
+
+	routes, err := declaredNames(filepath.Dir(ctx.IndividualRoutePath), ctx.IndividualRoutePath)
+	if err != nil {
+		return err
+	}
+	prefix := naming.NamespaceToPascal(namespace) + ctx.ResourceName
+	for _, suffix := range routeNameSuffixes {
+		if decl, ok := routes[prefix+suffix]; ok {
+			return collisionError(ctx.ResourceName, "route", prefix+suffix, decl)
+		}
+	}
+
+	return nil
+}
+
+func collisionError(resourceName, kind, name string, decl declaration) error {
+	if decl.imported {
+		return fmt.Errorf(
+			"resource name '%s' cannot be used: the %s name %s clashes with the package %s imported in %s; choose another name",
+			resourceName,
+			kind,
+			name,
+			name,
+			decl.file,
+		)
+	}
+	return fmt.Errorf(
+		"resource name '%s' cannot be used: the %s name %s is already declared in %s; choose another name",
+		resourceName,
+		kind,
+		name,
+		decl.file,
+	)
+}
+
+// declaredNames returns the package-level names declared in the Go files of
+// dir, and the names of the packages they import, which a package-level
+// name must not reuse either. Files in skip, test files and files that do
+// not parse are ignored. A missing dir declares nothing.
+func declaredNames(dir string, skip ...string) (map[string]declaration, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	declared := make(map[string]declaration)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		filePath := filepath.Join(dir, name)
+		if slices.Contains(skip, filePath) {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filePath, nil, parser.SkipObjectResolution)
+		if err != nil {
+			continue
+		}
+		add := func(name string, isImport bool) {
+			if name == "_" || name == "." {
+				return
+			}
+			if _, ok := declared[name]; !ok {
+				declared[name] = declaration{file: filePath, imported: isImport}
+			}
+		}
+
+		for _, spec := range file.Imports {
+			if spec.Name != nil {
+				add(spec.Name.Name, true)
+				continue
+			}
+			if importPath, err := strconv.Unquote(spec.Path.Value); err == nil {
+				add(importName(importPath), true)
+			}
+		}
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					add(decl.Name.Name, false)
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						add(spec.Name.Name, false)
+					case *ast.ValueSpec:
+						for _, ident := range spec.Names {
+							add(ident.Name, false)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return declared, nil
+}
+
+// importName guesses the name of an imported package from its path, as in
+// echo for github.com/labstack/echo/v5.
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersionSuffix.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return strings.TrimPrefix(name, "go-")
+}
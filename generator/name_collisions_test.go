@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateResourceIdentifiers(t *testing.T) {
+	for _, name := range []string{"Event", "Product", "SalesPerson", "Question"} {
+		if err := validateModelIdentifiers(name); err != nil {
+			t.Errorf("validateModelIdentifiers(%q) = %v", name, err)
+		}
+		if err := validateControllerIdentifiers(name); err != nil {
+			t.Errorf("validateControllerIdentifiers(%q) = %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		validate func(string) error
+		resource string
+		want     string
+	}{
+		{name: "keyword model type", validate: validateModelIdentifiers, resource: "Type", want: "Go keyword"},
+		{name: "predeclared model type", validate: validateModelIdentifiers, resource: "String", want: "predeclared string"},
+		{name: "imported package", validate: validateModelIdentifiers, resource: "Context", want: "package context"},
+		{name: "keyword receiver", validate: validateModelIdentifiers, resource: "ImageFile", want: "receiver would be named if"},
+		{name: "model receiver clashes with db", validate: validateModelIdentifiers, resource: "DeliveryBatch", want: "receiver db would clash"},
+		{name: "controller receiver clashes with r", validate: validateControllerIdentifiers, resource: "Review", want: "controller receiver r would clash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.resource)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNamesAvailable(t *testing.T) {
+	root := t.TempDir()
+	withWorkingDir(t, root)
+
+	writeGeneratorTestFile(t, root, "models/model.go", `package models
+
+type (
+	user  struct{}
+	event struct{}
+)
+
+var (
+	User  user
+	Event event
+)
+`)
+	writeGeneratorTestFile(t, root, "models/user.go", `package models
+
+import "github.com/uptrace/bun"
+
+type PasswordPair struct{}
+
+type UserEntity struct {
+	bun.BaseModel
+}
+`)
+	writeGeneratorTestFile(t, root, "controllers/reports.go", `package controllers
+
+type ReportsPage struct{}
+
+func NewAuditLogs() {}
+`)
+	writeGeneratorTestFile(t, root, "router/routes/admin.go", `package routes
+
+const AuditLogPrefix = "/admin/audit"
+`)
+
+	// A registered namespace without its model file is regenerated.
+	if err := validateModelNamesAvailable("models", "Event", "models/event.go"); err != nil {
+		t.Fatalf("validateModelNamesAvailable(Event) = %v", err)
+	}
+	if err := validateModelNamesAvailable("models", "User", "models/user.go"); err != nil {
+		t.Fatalf("validateModelNamesAvailable(User) ignoring its own file = %v", err)
+	}
+
+	err := validateModelNamesAvailable("models", "PasswordPair", "models/password_pair.go")
+	if err == nil || !strings.Contains(err.Error(), "model name PasswordPair is already declared in models/user.go") {
+		t.Fatalf("expected a collision with PasswordPair, got %v", err)
+	}
+	err = validateModelNamesAvailable("models", "Bun", "models/bun.go")
+	if err == nil || !strings.Contains(err.Error(), "clashes with the package bun imported in models/user.go") {
+		t.Fatalf("expected a collision with the bun import, got %v", err)
+	}
+
+	config := &UnifiedConfig{}
+	config.Paths.Controllers = "controllers"
+	if err := validateControllerNamesAvailable(newControllerValidationContext("Report", "reports", "", config), ""); err != nil {
+		t.Fatalf("validateControllerNamesAvailable(Report) ignoring its own file = %v", err)
+	}
+	err = validateControllerNamesAvailable(newControllerValidationContext("AuditLog", "audit_logs", "", config), "")
+	if err == nil || !strings.Contains(err.Error(), "controller name NewAuditLogs is already declared in controllers/reports.go") {
+		t.Fatalf("expected a controller collision, got %v", err)
+	}
+	writeGeneratorTestFile(t, root, "controllers/reports.go", "package controllers\n")
+	err = validateControllerNamesAvailable(newControllerValidationContext("AuditLog", "audit_logs", "", config), "")
+	if err == nil || !strings.Contains(err.Error(), "route name AuditLogPrefix is already declared in router/routes/admin.go") {
+		t.Fatalf("expected a route collision, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
@@ -27,6 +28,7 @@ func TestDetectPrimaryKey(t *testing.T) {
 				GoType:      "uuid.UUID",
 				Found:       true,
 				IsNamedID:   true,
+				Columns:     []string{"id"},
 			},
 		},
 		{
@@ -42,6 +44,7 @@ func TestDetectPrimaryKey(t *testing.T) {
 				DataType:    "uuid",
 				GoType:      "uuid.UUID",
 				Found:       true,
+				Columns:     []string{"order_id"},
 			},
 		},
 		{
@@ -59,6 +62,20 @@ func TestDetectPrimaryKey(t *testing.T) {
 				IsAutoIncrement: true,
 				Found:           true,
 				IsNamedID:       true,
+				Columns:         []string{"id"},
+			},
+		},
+		{
+			name:      "composite primary key",
+			tableName: "users_organizations",
+			cat: catalogWithTable(t, "users_organizations",
+				catalog.NewColumn("user_id", "uuid").SetPrimaryKey(),
+				catalog.NewColumn("organization_id", "uuid").SetPrimaryKey(),
+				catalog.NewColumn("role", "text").SetNotNull(),
+			),
+			want: PrimaryKeyInfo{
+				Found:   true,
+				Columns: []string{"user_id", "organization_id"},
 			},
 		},
 		{
@@ -81,7 +98,7 @@ func TestDetectPrimaryKey(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			got := DetectPrimaryKey(tt.cat, tt.tableName)
-			if got != tt.want {
+			if !reflect.DeepEqual(got, tt.want) {
 				t.Fatalf("DetectPrimaryKey() = %+v, want %+v", got, tt.want)
 			}
 		})
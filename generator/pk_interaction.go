@@ -20,6 +20,14 @@ type PrimaryKeyInfo struct {
 	IsAutoIncrement bool
 	Found           bool
 	IsNamedID       bool // Whether the PK column is named "id"
+	// Columns lists every primary key column. It holds more than one column
+	// for a composite key, in which case the fields above are left empty.
+	Columns []string
+}
+
+// IsComposite reports whether the primary key spans more than one column.
+func (info PrimaryKeyInfo) IsComposite() bool {
+	return len(info.Columns) > 1
 }
 
 // PrimaryKeyResolver represents primary key resolver.
@@ -28,14 +36,28 @@ type PrimaryKeyResolver interface {
 	ConfirmNoPK(tableName string) (bool, error)
 }
 
+// errCompositePrimaryKey is returned by generators that route or queue
+// records by a single key column when the table has a composite key.
+func errCompositePrimaryKey(tableName string, info PrimaryKeyInfo) error {
+	return fmt.Errorf(
+		"table %q has a composite primary key (%s); generate the model with 'andurel generate model' and write the controller by hand",
+		tableName,
+		strings.Join(info.Columns, ", "),
+	)
+}
+
 // DefaultPrimaryKeyResolver represents default primary key resolver.
 type DefaultPrimaryKeyResolver struct{}
 
 // ResolveAlternatePK resolves alternate primary key.
 func (DefaultPrimaryKeyResolver) ResolveAlternatePK(info PrimaryKeyInfo, tableName string) (PrimaryKeyInfo, error) {
 	fmt.Printf("\nDetected primary key for table %q:\n", tableName)
-	fmt.Printf("  Column: %s (%s)\n", info.ColumnName, info.DataType)
-	fmt.Printf("  Go type: %s\n", info.GoType)
+	if info.IsComposite() {
+		fmt.Printf("  Columns: %s (composite key)\n", strings.Join(info.Columns, ", "))
+	} else {
+		fmt.Printf("  Column: %s (%s)\n", info.ColumnName, info.DataType)
+		fmt.Printf("  Go type: %s\n", info.GoType)
+	}
 	fmt.Print("Is this correct? [Y/n]: ")
 
 	reader := bufio.NewReader(os.Stdin)
@@ -87,6 +109,15 @@ func DetectPrimaryKey(cat *catalog.Catalog, tableName string) PrimaryKeyInfo {
 		return PrimaryKeyInfo{Found: false}
 	}
 
+	pkColumns := table.GetPrimaryKeyColumns()
+	if len(pkColumns) > 1 {
+		info := PrimaryKeyInfo{Found: true}
+		for _, col := range pkColumns {
+			info.Columns = append(info.Columns, col.Name)
+		}
+		return info
+	}
+
 	var foundIDPK *catalog.Column
 	var foundAnyPK *catalog.Column
 
@@ -118,8 +149,9 @@ func DetectPrimaryKey(cat *catalog.Catalog, tableName string) PrimaryKeyInfo {
 		GoFieldName:     types.FormatFieldName(pkCol.Name),
 		DataType:        pkCol.DataType,
 		GoType:          validation.GoType(pkType),
-		IsAutoIncrement: validation.IsAutoIncrement(pkCol.DataType),
+		IsAutoIncrement: pkCol.IsAutoIncrement || validation.IsAutoIncrement(pkCol.DataType),
 		Found:           true,
 		IsNamedID:       pkCol.Name == "id",
+		Columns:         []string{pkCol.Name},
 	}
 }
@@ -0,0 +1,454 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+	"github.com/mbvlabs/andurel/generator/internal/migrations"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/models"
+)
+
+// DefaultLargeTableRows is the estimated row count from which ExplainQuery
+// flags a sequential scan.
+const DefaultLargeTableRows = 10_000
+
+// ModelQuery is a read query of a generated model function, in the form
+// ExplainQuery runs it.
+type ModelQuery struct {
+	Name  string `json:"name"` // The storage.StartQuery name, e.g. "User.FindByEmail"
+	Table string `json:"table"`
+	SQL   string `json:"sql"` // With $1, $2, ... for the parameters
+	// Columns are the columns the parameters are compared with, in order.
+	// An index on them serves the query.
+	Columns []string `json:"columns,omitempty"`
+}
+
+// ExplainOptions controls ExplainQuery.
+type ExplainOptions struct {
+	Analyze        bool  // Run the query to report actual rows and times
+	LargeTableRows int64 // Flag sequential scans of tables with at least this many rows; zero uses DefaultLargeTableRows
+}
+
+// QueryPlan is the plan Postgres chose for a model query.
+type QueryPlan struct {
+	Query       ModelQuery `json:"query"`
+	Params      []string   `json:"params,omitempty"` // Sample values, taken from a row of the table
+	Plan        string     `json:"plan"`             // Indented like EXPLAIN's text output
+	ExecutionMS float64    `json:"execution_ms,omitempty"`
+	SeqScans    []SeqScan  `json:"seq_scans,omitempty"`
+}
+
+// SeqScan is a sequential scan of a large table in a plan.
+type SeqScan struct {
+	Table  string `json:"table"`
+	Rows   int64  `json:"rows"` // Estimated rows in the table
+	Filter string `json:"filter,omitempty"`
+	// Suggestion is a CREATE INDEX statement for the query's columns, or a
+	// note when an index already covers them.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+var (
+	modelTablePattern = regexp.MustCompile(`bun:"table:(\w+)`)
+	modelQueryPattern = regexp.MustCompile(`storage\.StartQuery\(ctx, "(\w+)\.(\w+)"\)`)
+)
+
+// ModelQueries returns the read queries of the generated models in
+// modelsDir: Find, the FindBy finders, All, Paginate and PaginateAfter. The
+// tables come from the migrations, so the SQL matches what bun builds for
+// them. Models whose table no migration creates are left out.
+func ModelQueries(modelsDir string, migrationDirs []string) ([]ModelQuery, error) {
+	cat, err := migratedCatalog(migrationDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(modelsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read models: %w", err)
+	}
+
+	var queries []ModelQuery
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(modelsDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		tableMatch := modelTablePattern.FindSubmatch(content)
+		if tableMatch == nil {
+			continue
+		}
+		table, err := cat.GetTable("public", string(tableMatch[1]))
+		if err != nil {
+			continue
+		}
+
+		var model *models.GeneratedModel
+		for _, match := range modelQueryPattern.FindAllSubmatch(content, -1) {
+			if model == nil {
+				model, err = models.NewGenerator("postgresql").Build(cat, models.Config{
+					TableName:    table.Name,
+					ResourceName: string(match[1]),
+					PackageName:  "models",
+				})
+				if err != nil {
+					break
+				}
+			}
+			if query, ok := modelQuery(model, table, string(match[2])); ok {
+				queries = append(queries, query)
+			}
+		}
+	}
+
+	sort.Slice(queries, func(i, j int) bool { return queries[i].Name < queries[j].Name })
+	return queries, nil
+}
+
+// migratedCatalog applies the schema statements of every migration in
+// migrationDirs, skipping those it cannot parse.
+func migratedCatalog(migrationDirs []string) (*catalog.Catalog, error) {
+	migrationsList, err := migrations.DiscoverMigrations(migrationDirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover migrations: %w", err)
+	}
+	cat := catalog.NewCatalog("public")
+	for _, migration := range migrationsList {
+		for _, stmt := range migration.Statements {
+			if isSchemaStatement(stmt) {
+				_ = ddl.ApplyDDL(cat, stmt, migration.FilePath, "postgresql")
+			}
+		}
+	}
+	return cat, nil
+}
+
+// modelQuery builds the SQL of the model's read function method, or reports
+// false for writes and functions whose SQL depends on the caller, such as
+// Filter.
+func modelQuery(model *models.GeneratedModel, table *catalog.Table, method string) (ModelQuery, bool) {
+	query := ModelQuery{Name: model.Name + "." + method, Table: table.Name}
+	var orderBy, limit string
+	switch {
+	case method == "Find":
+		for _, column := range table.GetPrimaryKeyColumns() {
+			query.Columns = append(query.Columns, column.Name)
+		}
+		if len(query.Columns) == 0 {
+			return ModelQuery{}, false
+		}
+	case strings.HasPrefix(method, "FindBy"):
+		query.Columns = finderColumns(model, table, strings.TrimPrefix(method, "FindBy"))
+		if query.Columns == nil {
+			return ModelQuery{}, false
+		}
+	case method == "All":
+	case method == "Paginate":
+		limit = " LIMIT 10 OFFSET 0"
+	case method == "PaginateAfter" && model.HasCursorPagination:
+		query.Columns = []string{"created_at", model.IDFieldName}
+		orderBy = fmt.Sprintf(" ORDER BY created_at DESC, %s DESC", model.IDFieldName)
+		limit = " LIMIT 11"
+	default:
+		return ModelQuery{}, false
+	}
+
+	var conditions []string
+	if method == "PaginateAfter" {
+		conditions = append(conditions, fmt.Sprintf("(created_at, %s) < ($1, $2)", model.IDFieldName))
+	} else {
+		for i, column := range query.Columns {
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", column, i+1))
+		}
+	}
+	if model.HasSoftDelete {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+
+	query.SQL = "SELECT * FROM " + table.Name
+	if len(conditions) > 0 {
+		query.SQL += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query.SQL += orderBy + limit
+	return query, true
+}
+
+// finderColumns returns the key columns of the FindBy finder named suffix:
+// a unique key, or the foreign key column of a belongs-to association.
+func finderColumns(model *models.GeneratedModel, table *catalog.Table, suffix string) []string {
+	for _, finder := range model.Finders {
+		if finder.Method != "FindBy"+suffix {
+			continue
+		}
+		columns := make([]string, 0, len(finder.Keys))
+		for _, key := range finder.Keys {
+			columns = append(columns, key.Column)
+		}
+		return columns
+	}
+	for _, column := range table.Columns {
+		if types.FormatFieldName(column.Name) == suffix {
+			return []string{column.Name}
+		}
+	}
+	return nil
+}
+
+// ExplainQuery runs EXPLAIN for query on db, with parameters taken from a
+// row of its table, or NULL when the table is empty. With opts.Analyze the
+// query runs, so the plan reports actual rows and times. Sequential scans of
+// tables with at least opts.LargeTableRows rows are flagged, with an index
+// on the query's columns when none covers them.
+func ExplainQuery(ctx context.Context, db SchemaQuerier, query ModelQuery, opts ExplainOptions) (*QueryPlan, error) {
+	if opts.LargeTableRows == 0 {
+		opts.LargeTableRows = DefaultLargeTableRows
+	}
+
+	args, err := sampleParams(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+
+	options := "FORMAT JSON"
+	if opts.Analyze {
+		options = "ANALYZE, " + options
+	}
+	rows, err := db.Query(ctx, fmt.Sprintf("EXPLAIN (%s) %s", options, query.SQL), args...)
+	if err != nil {
+		return nil, fmt.Errorf("explain %s: %w", query.Name, err)
+	}
+	output, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[[]byte])
+	if err != nil {
+		return nil, fmt.Errorf("explain %s: %w", query.Name, err)
+	}
+
+	explained, err := parseExplain(output)
+	if err != nil {
+		return nil, fmt.Errorf("explain %s: %w", query.Name, err)
+	}
+
+	plan := &QueryPlan{Query: query, Plan: explained.Plan.render(), ExecutionMS: explained.ExecutionTime}
+	for _, arg := range args {
+		plan.Params = append(plan.Params, formatParam(arg))
+	}
+
+	for _, node := range explained.Plan.seqScans() {
+		tableRows, err := estimatedRows(ctx, db, node.RelationName)
+		if err != nil {
+			return nil, err
+		}
+		if tableRows < opts.LargeTableRows {
+			continue
+		}
+		scan := SeqScan{Table: node.RelationName, Rows: tableRows, Filter: node.Filter}
+		if node.RelationName == query.Table && len(query.Columns) > 0 {
+			indexes, err := indexColumns(ctx, db, query.Table)
+			if err != nil {
+				return nil, err
+			}
+			scan.Suggestion = suggestIndex(query, indexes)
+		}
+		plan.SeqScans = append(plan.SeqScans, scan)
+	}
+
+	return plan, nil
+}
+
+// sampleParams reads the query's columns from a row of its table.
+func sampleParams(ctx context.Context, db SchemaQuerier, query ModelQuery) ([]any, error) {
+	if len(query.Columns) == 0 {
+		return nil, nil
+	}
+
+	rows, err := db.Query(ctx, fmt.Sprintf("SELECT %s FROM %s LIMIT 1", strings.Join(query.Columns, ", "), query.Table))
+	if err != nil {
+		return nil, fmt.Errorf("read sample parameters from %s: %w", query.Table, err)
+	}
+	defer rows.Close()
+
+	args := make([]any, len(query.Columns))
+	if rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("read sample parameters from %s: %w", query.Table, err)
+		}
+		copy(args, values)
+	}
+	return args, rows.Err()
+}
+
+func estimatedRows(ctx context.Context, db SchemaQuerier, table string) (int64, error) {
+	rows, err := db.Query(ctx, "SELECT greatest(reltuples, 0)::bigint FROM pg_class WHERE oid = to_regclass($1)", table)
+	if err != nil {
+		return 0, fmt.Errorf("estimate rows of %s: %w", table, err)
+	}
+	count, err := pgx.CollectExactlyOneRow(rows, pgx.RowTo[int64])
+	if err != nil {
+		return 0, fmt.Errorf("estimate rows of %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// indexColumns returns the columns of every index on table, in index order.
+func indexColumns(ctx context.Context, db SchemaQuerier, table string) ([][]string, error) {
+	rows, err := db.Query(ctx, `SELECT array_agg(a.attname::text ORDER BY k.n)
+FROM pg_index i
+CROSS JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, n)
+JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+WHERE i.indrelid = to_regclass($1)
+GROUP BY i.indexrelid`, table)
+	if err != nil {
+		return nil, fmt.Errorf("read indexes of %s: %w", table, err)
+	}
+	indexes, err := pgx.CollectRows(rows, pgx.RowTo[[]string])
+	if err != nil {
+		return nil, fmt.Errorf("read indexes of %s: %w", table, err)
+	}
+	return indexes, nil
+}
+
+// suggestIndex returns a CREATE INDEX statement for the query's columns, or
+// a note when an index already starts with them.
+func suggestIndex(query ModelQuery, indexes [][]string) string {
+	for _, index := range indexes {
+		if len(index) >= len(query.Columns) && slices.Equal(index[:len(query.Columns)], query.Columns) {
+			return fmt.Sprintf(
+				"an index on (%s) exists; run ANALYZE %s if the table grew since its statistics were collected",
+				strings.Join(index, ", "),
+				query.Table,
+			)
+		}
+	}
+	return fmt.Sprintf(
+		"CREATE INDEX %s_%s_idx ON %s (%s);",
+		query.Table,
+		strings.Join(query.Columns, "_"),
+		query.Table,
+		strings.Join(query.Columns, ", "),
+	)
+}
+
+func formatParam(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case time.Time:
+		return "'" + v.Format(time.RFC3339Nano) + "'"
+	case [16]byte:
+		return fmt.Sprintf("'%x-%x-%x-%x-%x'", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// explainOutput is one statement of EXPLAIN (FORMAT JSON).
+type explainOutput struct {
+	Plan          planNode `json:"Plan"`
+	ExecutionTime float64  `json:"Execution Time"`
+}
+
+type planNode struct {
+	NodeType     string     `json:"Node Type"`
+	RelationName string     `json:"Relation Name"`
+	Alias        string     `json:"Alias"`
+	IndexName    string     `json:"Index Name"`
+	StartupCost  float64    `json:"Startup Cost"`
+	TotalCost    float64    `json:"Total Cost"`
+	PlanRows     float64    `json:"Plan Rows"`
+	ActualStart  float64    `json:"Actual Startup Time"`
+	ActualTime   *float64   `json:"Actual Total Time"`
+	ActualRows   float64    `json:"Actual Rows"`
+	ActualLoops  float64    `json:"Actual Loops"`
+	Filter       string     `json:"Filter"`
+	IndexCond    string     `json:"Index Cond"`
+	SortKey      []string   `json:"Sort Key"`
+	Plans        []planNode `json:"Plans"`
+}
+
+func parseExplain(output []byte) (explainOutput, error) {
+	var statements []explainOutput
+	if err := json.Unmarshal(output, &statements); err != nil {
+		return explainOutput{}, err
+	}
+	if len(statements) != 1 {
+		return explainOutput{}, fmt.Errorf("expected one plan, got %d", len(statements))
+	}
+	return statements[0], nil
+}
+
+// render formats the plan like EXPLAIN's text output.
+func (n planNode) render() string {
+	var sb strings.Builder
+	n.write(&sb, 0)
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+func (n planNode) write(sb *strings.Builder, depth int) {
+	indent := strings.Repeat("      ", depth)
+	prefix := indent
+	if depth > 0 {
+		prefix = strings.Repeat("      ", depth-1) + "  ->  "
+	}
+
+	label := n.NodeType
+	if n.IndexName != "" {
+		label += " using " + n.IndexName
+	}
+	if n.RelationName != "" {
+		label += " on " + n.RelationName
+		if n.Alias != "" && n.Alias != n.RelationName {
+			label += " " + n.Alias
+		}
+	}
+	fmt.Fprintf(sb, "%s%s  (cost=%.2f..%.2f rows=%.0f)", prefix, label, n.StartupCost, n.TotalCost, n.PlanRows)
+	if n.ActualTime != nil {
+		fmt.Fprintf(sb, " (actual time=%.3f..%.3f rows=%.0f loops=%.0f)", n.ActualStart, *n.ActualTime, n.ActualRows, n.ActualLoops)
+	}
+	sb.WriteString("\n")
+
+	detailIndent := indent + "  "
+	if depth > 0 {
+		detailIndent = strings.Repeat("      ", depth) + "  "
+	}
+	if n.IndexCond != "" {
+		fmt.Fprintf(sb, "%sIndex Cond: %s\n", detailIndent, n.IndexCond)
+	}
+	if n.Filter != "" {
+		fmt.Fprintf(sb, "%sFilter: %s\n", detailIndent, n.Filter)
+	}
+	if len(n.SortKey) > 0 {
+		fmt.Fprintf(sb, "%sSort Key: %s\n", detailIndent, strings.Join(n.SortKey, ", "))
+	}
+
+	for _, child := range n.Plans {
+		child.write(sb, depth+1)
+	}
+}
+
+func (n planNode) seqScans() []planNode {
+	var scans []planNode
+	if n.NodeType == "Seq Scan" {
+		scans = append(scans, n)
+	}
+	for _, child := range n.Plans {
+		scans = append(scans, child.seqScans()...)
+	}
+	return scans
+}
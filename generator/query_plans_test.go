@@ -0,0 +1,120 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestModelQueries(t *testing.T) {
+	root := t.TempDir()
+	migrationsDir := filepath.Join(root, "database", "migrations")
+	modelsDir := filepath.Join(root, "models")
+	for _, dir := range []string{migrationsDir, modelsDir} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(migrationsDir, "20260101000000_create_orders.sql"): `-- +goose Up
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY
+);
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id),
+    number TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    deleted_at TIMESTAMPTZ
+);
+-- +goose Down
+DROP TABLE orders;
+DROP TABLE organizations;
+`,
+		filepath.Join(modelsDir, "order.go"): `package models
+
+type OrderEntity struct {
+	bun.BaseModel ` + "`" + `bun:"table:orders,alias:orders"` + "`" + `
+}
+
+func (o order) Find() { ctx, query := storage.StartQuery(ctx, "Order.Find") }
+func (o order) FindByNumber() { ctx, query := storage.StartQuery(ctx, "Order.FindByNumber") }
+func (o order) FindByOrganizationID() { ctx, query := storage.StartQuery(ctx, "Order.FindByOrganizationID") }
+func (o order) Create() { ctx, query := storage.StartQuery(ctx, "Order.Create") }
+func (o order) Paginate() { ctx, query := storage.StartQuery(ctx, "Order.Paginate") }
+func (o order) PaginateAfter() { ctx, query := storage.StartQuery(ctx, "Order.PaginateAfter") }
+`,
+		filepath.Join(modelsDir, "model.go"): "package models\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	queries, err := ModelQueries(modelsDir, []string{migrationsDir})
+	if err != nil {
+		t.Fatalf("ModelQueries: %v", err)
+	}
+
+	var got []string
+	for _, query := range queries {
+		got = append(got, query.Name+": "+query.SQL+" "+strings.Join(query.Columns, ","))
+	}
+	want := []string{
+		"Order.Find: SELECT * FROM orders WHERE id = $1 AND deleted_at IS NULL id",
+		"Order.FindByNumber: SELECT * FROM orders WHERE number = $1 AND deleted_at IS NULL number",
+		"Order.FindByOrganizationID: SELECT * FROM orders WHERE organization_id = $1 AND deleted_at IS NULL organization_id",
+		"Order.Paginate: SELECT * FROM orders WHERE deleted_at IS NULL LIMIT 10 OFFSET 0 ",
+		"Order.PaginateAfter: SELECT * FROM orders WHERE (created_at, id) < ($1, $2) AND deleted_at IS NULL ORDER BY created_at DESC, id DESC LIMIT 11 created_at,id",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("queries =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestExplainPlanRendering(t *testing.T) {
+	explained, err := parseExplain([]byte(`[{
+		"Plan": {
+			"Node Type": "Limit", "Startup Cost": 0, "Total Cost": 25.5, "Plan Rows": 11,
+			"Actual Startup Time": 0.01, "Actual Total Time": 4.2, "Actual Rows": 11, "Actual Loops": 1,
+			"Plans": [{
+				"Node Type": "Seq Scan", "Relation Name": "orders", "Alias": "orders",
+				"Startup Cost": 0, "Total Cost": 2310, "Plan Rows": 1000,
+				"Actual Startup Time": 0.01, "Actual Total Time": 4.1, "Actual Rows": 11, "Actual Loops": 1,
+				"Filter": "(organization_id = $1)"
+			}]
+		},
+		"Execution Time": 4.3
+	}]`))
+	if err != nil {
+		t.Fatalf("parseExplain: %v", err)
+	}
+
+	want := `Limit  (cost=0.00..25.50 rows=11) (actual time=0.010..4.200 rows=11 loops=1)
+  ->  Seq Scan on orders  (cost=0.00..2310.00 rows=1000) (actual time=0.010..4.100 rows=11 loops=1)
+        Filter: (organization_id = $1)`
+	if got := explained.Plan.render(); got != want {
+		t.Fatalf("render =\n%s\nwant\n%s", got, want)
+	}
+	if scans := explained.Plan.seqScans(); len(scans) != 1 || scans[0].RelationName != "orders" {
+		t.Fatalf("seq scans = %#v", scans)
+	}
+	if explained.ExecutionTime != 4.3 {
+		t.Fatalf("execution time = %v", explained.ExecutionTime)
+	}
+}
+
+func TestSuggestIndex(t *testing.T) {
+	query := ModelQuery{Name: "Order.FindByOrganizationID", Table: "orders", Columns: []string{"organization_id"}}
+
+	if got := suggestIndex(query, [][]string{{"id"}, {"number"}}); got != "CREATE INDEX orders_organization_id_idx ON orders (organization_id);" {
+		t.Fatalf("suggestion = %q", got)
+	}
+	if got := suggestIndex(query, [][]string{{"organization_id", "created_at"}}); !strings.Contains(got, "ANALYZE orders") {
+		t.Fatalf("suggestion with a covering index = %q", got)
+	}
+}
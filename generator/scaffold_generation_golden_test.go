@@ -8,6 +8,7 @@ import (
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/pkg/cache"
 	"github.com/mbvlabs/andurel/pkg/naming"
+	testsuite "github.com/mbvlabs/andurel/pkg/testing"
 	"github.com/sebdah/goldie/v2"
 )
 
@@ -97,6 +98,7 @@ func TestScaffoldGenerationGoldens(t *testing.T) {
 			}
 
 			assertScaffoldArtifacts(t, g, scenario.name, scenario.resourceName, scenario.tableName, scenario.skipFactory, scenario.inertia)
+			testsuite.AssertControllerRoutes(t, "controllers")
 		})
 	}
 }
@@ -137,6 +139,7 @@ func TestScaffoldGenerationNamespaced(t *testing.T) {
 	assertGeneratedFileContains(t, filepath.Join("router", "routes", "admin_widgets.go"), `"admin.widgets.index"`)
 	assertGeneratedFileContains(t, filepath.Join("controllers", "controller.go"), `"testapp/controllers/admin"`)
 	assertGeneratedFileContains(t, filepath.Join("views", "admin_widgets_resource.templ"), "type AdminWidgetIndex struct")
+	testsuite.AssertControllerRoutes(t, filepath.Join("controllers", "admin"))
 }
 
 func setupScaffoldGoldenProject(t *testing.T, migrationsFixture string, extensions []string, inertia string) Generator {
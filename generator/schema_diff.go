@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+	"github.com/mbvlabs/andurel/generator/internal/introspect"
+	"github.com/mbvlabs/andurel/generator/internal/migrations"
+	"github.com/mbvlabs/andurel/generator/internal/schemadiff"
+)
+
+// gooseTable is the table goose records applied migrations in. It has no
+// migration of its own, so the diff leaves it out.
+const gooseTable = "goose_db_version"
+
+// SchemaDiff is the migration that brings the schema the migrations build in
+// line with the database.
+type SchemaDiff struct {
+	Up   []string
+	Down []string
+	// Notes describe differences the migration does not cover.
+	Notes []string
+	// Skipped lists the tables and enum types left out of the comparison
+	// because the migrations or the database define them with statements the
+	// catalog cannot apply, such as River's tables.
+	Skipped []string
+}
+
+// Empty reports whether the migrations match the database.
+func (d *SchemaDiff) Empty() bool {
+	return len(d.Up) == 0 && len(d.Notes) == 0
+}
+
+// Migration renders the diff as a goose migration, one statement per block.
+// The notes head the Up section as comments.
+func (d *SchemaDiff) Migration() string {
+	var b strings.Builder
+	b.WriteString("-- +goose Up\n")
+	for _, note := range d.Notes {
+		fmt.Fprintf(&b, "-- %s\n", note)
+	}
+	writeStatements(&b, d.Up)
+	b.WriteString("\n-- +goose Down\n")
+	writeStatements(&b, d.Down)
+	return b.String()
+}
+
+func writeStatements(b *strings.Builder, statements []string) {
+	for i, stmt := range statements {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "-- +goose StatementBegin\n%s;\n-- +goose StatementEnd\n", stmt)
+	}
+}
+
+var (
+	typeStatementName     = regexp.MustCompile(`(?i)^\s*(?:create|alter|drop)\s+type\s+(?:if\s+exists\s+)?(?:\w+\.)?(\w+)`)
+	unloggedStatementName = regexp.MustCompile(`(?i)^\s*create\s+(?:unlogged|temp|temporary)\s+table\s+(?:if\s+not\s+exists\s+)?(?:\w+\.)?(\w+)`)
+)
+
+// statementObject returns the lower-cased name of the table or enum type a
+// statement changes, or an empty string for other statements.
+func statementObject(stmt string) string {
+	stmt = ddl.StripComments(stmt)
+	for _, pattern := range []*regexp.Regexp{typeStatementName, unloggedStatementName} {
+		if m := pattern.FindStringSubmatch(stmt); m != nil {
+			return strings.ToLower(m[1])
+		}
+	}
+	return statementTable(stmt)
+}
+
+// DiffDatabase compares the schema the migrations in migrationDirs build
+// with the tables and enum types of the database's current schema, and
+// returns the migration that changes the first into the second.
+func DiffDatabase(ctx context.Context, db SchemaQuerier, migrationDirs []string) (*SchemaDiff, error) {
+	migrationsList, err := migrations.DiscoverMigrations(migrationDirs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover migrations: %w", err)
+	}
+
+	skipped := map[string]string{}
+	migrated := catalog.NewCatalog("public")
+	for _, migration := range migrationsList {
+		for _, stmt := range migration.Statements {
+			if !isSchemaStatement(stmt) {
+				continue
+			}
+			if err := ddl.ApplyDDL(migrated, stmt, migration.FilePath, "postgresql"); err != nil {
+				if name := statementObject(stmt); name != "" {
+					skipped[name] = fmt.Sprintf("%s: not supported in %s", name, filepath.Base(migration.FilePath))
+				}
+			}
+		}
+	}
+
+	database := catalog.NewCatalog("public")
+	enums, err := introspect.ReadEnums(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, enum := range enums {
+		if err := ddl.ApplyDDL(database, enum.Statement(), "database", "postgresql"); err != nil {
+			skipped[enum.Name] = fmt.Sprintf("%s: not supported in the database", enum.Name)
+		}
+	}
+
+	tables, err := introspect.ListTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range tables {
+		if name == gooseTable || skipped[name] != "" {
+			continue
+		}
+		table, err := introspect.ReadTable(ctx, db, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, domain := range table.Domains {
+			if database.LookupDomain(domain.Name) != nil {
+				continue
+			}
+			if err := ddl.ApplyDDL(database, domain.Statement(), "database", "postgresql"); err != nil {
+				skipped[domain.Name] = fmt.Sprintf("%s: not supported in the database", domain.Name)
+			}
+		}
+		for _, stmt := range table.Definition() {
+			if err := ddl.ApplyDDL(database, stmt, "database", "postgresql"); err != nil {
+				skipped[name] = fmt.Sprintf("%s: not supported in the database", name)
+				break
+			}
+		}
+	}
+
+	for _, cat := range []*catalog.Catalog{migrated, database} {
+		schema := cat.Schemas[cat.DefaultSchema]
+		delete(schema.Tables, gooseTable)
+		for name := range skipped {
+			delete(schema.Tables, name)
+			delete(schema.Enums, name)
+		}
+	}
+
+	diff := schemadiff.Compare(migrated, database)
+	result := &SchemaDiff{Up: diff.Up, Down: diff.Down, Notes: diff.Notes}
+	for _, reason := range skipped {
+		result.Skipped = append(result.Skipped, reason)
+	}
+	sort.Strings(result.Skipped)
+	return result, nil
+}
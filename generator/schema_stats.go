@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/ddl"
+	"github.com/mbvlabs/andurel/generator/internal/migrations"
+)
+
+// SchemaStats counts the schema objects the migrations define.
+type SchemaStats struct {
+	Tables  int `json:"tables"`
+	Columns int `json:"columns"`
+	Enums   int `json:"enums"`
+	// SkippedStatements counts schema statements the catalog cannot apply,
+	// such as functions and triggers. They are left out rather than failing.
+	SkippedStatements int `json:"skipped_statements,omitempty"`
+}
+
+// CollectSchemaStats applies every migration in migrationDirs and counts the
+// tables, columns and enum types left in all schemas.
+func CollectSchemaStats(migrationDirs []string) (SchemaStats, error) {
+	migrationsList, err := migrations.DiscoverMigrations(migrationDirs)
+	if err != nil {
+		return SchemaStats{}, fmt.Errorf("failed to discover migrations: %w", err)
+	}
+
+	var stats SchemaStats
+	cat := catalog.NewCatalog("public")
+	for _, migration := range migrationsList {
+		for _, stmt := range migration.Statements {
+			if !isSchemaStatement(stmt) {
+				continue
+			}
+			if err := ddl.ApplyDDL(cat, stmt, migration.FilePath, "postgresql"); err != nil {
+				stats.SkippedStatements++
+			}
+		}
+	}
+
+	for _, schema := range cat.Schemas {
+		stats.Tables += len(schema.Tables)
+		stats.Enums += len(schema.Enums)
+		for _, table := range schema.Tables {
+			stats.Columns += len(table.Columns)
+		}
+	}
+
+	return stats, nil
+}
+
+// isSchemaStatement reports whether stmt is a CREATE, ALTER or DROP
+// statement, leaving out data changes like UPDATE and INSERT.
+func isSchemaStatement(stmt string) bool {
+	fields := strings.Fields(strings.ToLower(ddl.StripComments(stmt)))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "create", "alter", "drop":
+		return true
+	}
+	return false
+}
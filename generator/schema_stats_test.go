@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectSchemaStats(t *testing.T) {
+	stats, err := CollectSchemaStats([]string{filepath.Join("testdata", "migrations", "model_generation_enums")})
+	if err != nil {
+		t.Fatalf("CollectSchemaStats: %v", err)
+	}
+
+	want := SchemaStats{Tables: 1, Columns: 6, Enums: 2}
+	if stats != want {
+		t.Fatalf("stats = %#v, want %#v", stats, want)
+	}
+}
+
+func TestIsSchemaStatement(t *testing.T) {
+	cases := map[string]bool{
+		"CREATE TABLE users (id UUID PRIMARY KEY);":          true,
+		"-- add a column\nALTER TABLE users ADD name TEXT;":  true,
+		"drop type user_role;":                               true,
+		"UPDATE users SET name = '';":                        false,
+		"INSERT INTO users (id) VALUES (gen_random_uuid());": false,
+	}
+	for stmt, want := range cases {
+		if got := isSchemaStatement(stmt); got != want {
+			t.Errorf("isSchemaStatement(%q) = %v, want %v", stmt, got, want)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package generator
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+const serializersDir = "serializers"
+
+// SerializerOptions selects what a generated serializer exposes.
+type SerializerOptions struct {
+	// Only lists the columns to expose. Empty exposes every column except
+	// the sensitive ones.
+	Only []string
+	// Except lists columns to leave out.
+	Except []string
+	// Computed lists fields derived from the entity, as name or name:type.
+	Computed []string
+	// Include lists models to nest. A model with a matching foreign key on
+	// this entity nests as a single object, any other as a list.
+	Include []string
+}
+
+type serializerField struct {
+	Name     string
+	Type     string
+	JSONName string
+	Value    string
+}
+
+type serializerComputed struct {
+	Name     string
+	Type     string
+	JSONName string
+	FuncName string
+}
+
+type serializerInclude struct {
+	Name       string
+	Model      string
+	EntityName string
+	JSONName   string
+	Many       bool
+}
+
+type serializerTemplateData struct {
+	ModulePath string
+	Name       string
+	EntityName string
+	// StandardImports and ExternalImports are quoted import specs.
+	StandardImports []string
+	ExternalImports []string
+	Fields          []serializerField
+	Computed        []serializerComputed
+	Includes        []serializerInclude
+}
+
+var computedFieldPattern = regexp.MustCompile(`^([a-z][a-z0-9_]*)(?::(.+))?$`)
+
+// sensitiveColumnSuffixes mark columns left out of serializers unless they
+// are listed with Only.
+var sensitiveColumnSuffixes = []string{"_token", "_secret", "_digest"}
+
+// SerializerManager writes presentation structs that API controllers,
+// exports and webhooks use instead of serializing model entities.
+type SerializerManager struct {
+	validator      *InputValidator
+	fileManager    files.Manager
+	projectManager *ProjectManager
+	config         *UnifiedConfig
+}
+
+// NewSerializerManager creates a new serializer manager.
+func NewSerializerManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	config *UnifiedConfig,
+) *SerializerManager {
+	return &SerializerManager{
+		validator:      validator,
+		fileManager:    fileManager,
+		projectManager: projectManager,
+		config:         config,
+	}
+}
+
+// SerializerPath returns the file a model's serializer is written to.
+func (s *SerializerManager) SerializerPath(resourceName string) string {
+	return filepath.Join(serializersDir, naming.ToSnakeCase(resourceName)+".go")
+}
+
+// GenerateSerializer writes serializers/<model>.go for an existing model.
+// Included models get a default serializer when they lack one.
+func (s *SerializerManager) GenerateSerializer(resourceName string, opts SerializerOptions) error {
+	if err := s.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+
+	serializerPath := s.SerializerPath(resourceName)
+	if s.fileManager.FileExists(serializerPath) {
+		return fmt.Errorf("serializer for %s already exists at %s", resourceName, serializerPath)
+	}
+
+	data, err := s.buildSerializer(resourceName, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, include := range data.Includes {
+		if include.Model == resourceName {
+			continue
+		}
+		if err := s.EnsureSerializer(include.Model); err != nil {
+			return fmt.Errorf("failed to generate serializer for included %s: %w", include.Model, err)
+		}
+	}
+
+	if err := s.writeSerializer(serializerPath, data); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully generated serializer %s at %s\n", resourceName, serializerPath)
+	return nil
+}
+
+// EnsureSerializer writes a default serializer for the model unless one
+// already exists.
+func (s *SerializerManager) EnsureSerializer(resourceName string) error {
+	serializerPath := s.SerializerPath(resourceName)
+	if s.fileManager.FileExists(serializerPath) {
+		return nil
+	}
+
+	data, err := s.buildSerializer(resourceName, SerializerOptions{})
+	if err != nil {
+		return err
+	}
+
+	return s.writeSerializer(serializerPath, data)
+}
+
+func (s *SerializerManager) buildSerializer(resourceName string, opts SerializerOptions) (serializerTemplateData, error) {
+	modelPath := BuildModelPath(s.config.Paths.Models, resourceName)
+	src, err := os.ReadFile(modelPath)
+	if err != nil {
+		return serializerTemplateData{}, fmt.Errorf(
+			"no model at %s; generate it with 'andurel generate model %s' first",
+			modelPath,
+			resourceName,
+		)
+	}
+
+	entityName := resourceName + "Entity"
+	parsed, _, _, err := parseEntityStruct(src, entityName)
+	if err != nil {
+		return serializerTemplateData{}, err
+	}
+
+	data := serializerTemplateData{
+		ModulePath: s.projectManager.GetModulePath(),
+		Name:       resourceName,
+		EntityName: entityName,
+	}
+
+	columns := make(map[string]bool, len(parsed))
+	fieldNames := make(map[string]bool, len(parsed))
+	for _, field := range parsed {
+		columns[serializerColumnName(field)] = true
+		fieldNames[field.Name] = true
+	}
+	for _, column := range append(slices.Clone(opts.Only), opts.Except...) {
+		if !columns[column] {
+			return serializerTemplateData{}, fmt.Errorf("%s has no column %q", entityName, column)
+		}
+	}
+
+	for _, field := range parsed {
+		column := serializerColumnName(field)
+		if column == "-" || slices.Contains(opts.Except, column) {
+			continue
+		}
+		if len(opts.Only) > 0 {
+			if !slices.Contains(opts.Only, column) {
+				continue
+			}
+		} else if isSensitiveColumn(column) {
+			continue
+		}
+
+		goType, value := serializerFieldValue(field.TypeStr, "entity."+field.Name)
+		data.Fields = append(data.Fields, serializerField{
+			Name:     field.Name,
+			Type:     goType,
+			JSONName: naming.ToCamelCase(column),
+			Value:    value,
+		})
+	}
+
+	taken := make(map[string]bool, len(data.Fields))
+	for _, field := range data.Fields {
+		taken[field.Name] = true
+	}
+
+	for _, computed := range opts.Computed {
+		match := computedFieldPattern.FindStringSubmatch(strings.TrimSpace(computed))
+		if match == nil {
+			return serializerTemplateData{}, fmt.Errorf("invalid computed field %q: use snake_case name or name:type", computed)
+		}
+		fieldType := strings.TrimSpace(match[2])
+		if fieldType == "" {
+			fieldType = "string"
+		}
+		name := naming.ToPascalCase(match[1])
+		if taken[name] {
+			return serializerTemplateData{}, fmt.Errorf("computed field %s clashes with an existing field", match[1])
+		}
+		taken[name] = true
+		data.Computed = append(data.Computed, serializerComputed{
+			Name:     name,
+			Type:     fieldType,
+			JSONName: naming.ToCamelCase(match[1]),
+			FuncName: naming.ToLowerCamelCase(resourceName) + name,
+		})
+	}
+
+	for _, include := range opts.Include {
+		model := naming.ToPascalCase(naming.ToSnakeCase(strings.TrimSpace(include)))
+		if err := s.validator.ValidateResourceName(model); err != nil {
+			return serializerTemplateData{}, err
+		}
+		if !s.fileManager.FileExists(BuildModelPath(s.config.Paths.Models, model)) {
+			return serializerTemplateData{}, fmt.Errorf("cannot include %s: no model at %s", model, BuildModelPath(s.config.Paths.Models, model))
+		}
+
+		nested := serializerInclude{
+			Name:       model,
+			Model:      model,
+			EntityName: model + "Entity",
+			Many:       !fieldNames[model+"ID"],
+		}
+		if nested.Many {
+			nested.Name = naming.Plural(model)
+		}
+		nested.JSONName = naming.ToCamelCase(naming.ToSnakeCase(nested.Name))
+		if taken[nested.Name] {
+			return serializerTemplateData{}, fmt.Errorf("included %s clashes with an existing field", nested.Name)
+		}
+		taken[nested.Name] = true
+		data.Includes = append(data.Includes, nested)
+	}
+
+	data.StandardImports, data.ExternalImports = serializerImports(src, data)
+	return data, nil
+}
+
+func (s *SerializerManager) writeSerializer(serializerPath string, data serializerTemplateData) error {
+	if err := s.fileManager.EnsureDir(serializersDir); err != nil {
+		return err
+	}
+
+	sharedPath := filepath.Join(serializersDir, "serializers.go")
+	if !s.fileManager.FileExists(sharedPath) {
+		if err := s.render("serializers_shared.tmpl", sharedPath, data); err != nil {
+			return err
+		}
+	}
+
+	return s.render("serializer.tmpl", serializerPath, data)
+}
+
+// render writes a template to path and formats it.
+func (s *SerializerManager) render(templateName, path string, data serializerTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := files.FormatGoFile(path); err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+	return nil
+}
+
+func serializerColumnName(field parsedField) string {
+	column, _, _ := strings.Cut(field.BunTag, ",")
+	if column == "" {
+		return naming.ToSnakeCase(field.Name)
+	}
+	return column
+}
+
+func isSensitiveColumn(column string) bool {
+	if strings.Contains(column, "password") || strings.HasPrefix(column, "encrypted_") {
+		return true
+	}
+	for _, suffix := range sensitiveColumnSuffixes {
+		if strings.HasSuffix(column, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// serializerFieldValue returns the serialized type of a model field and the
+// expression converting source to it. Null wrappers become pointers so NULL
+// columns encode as JSON null rather than {"String":"","Valid":false}.
+func serializerFieldValue(goType, source string) (string, string) {
+	switch goType {
+	case "sql.NullString", "bun.NullString":
+		return "*string", "nullable(" + source + ".String, " + source + ".Valid)"
+	case "sql.NullBool", "bun.NullBool":
+		return "*bool", "nullable(" + source + ".Bool, " + source + ".Valid)"
+	case "sql.NullInt16":
+		return "*int16", "nullable(" + source + ".Int16, " + source + ".Valid)"
+	case "sql.NullInt32", "bun.NullInt32":
+		return "*int32", "nullable(" + source + ".Int32, " + source + ".Valid)"
+	case "sql.NullInt64", "bun.NullInt64":
+		return "*int64", "nullable(" + source + ".Int64, " + source + ".Valid)"
+	case "sql.NullFloat64", "bun.NullFloat64":
+		return "*float64", "nullable(" + source + ".Float64, " + source + ".Valid)"
+	case "sql.NullTime":
+		return "*time.Time", "nullable(" + source + ".Time, " + source + ".Valid)"
+	case "uuid.NullUUID":
+		return "*uuid.UUID", "nullable(" + source + ".UUID, " + source + ".Valid)"
+	case "bun.NullTime":
+		return "*time.Time", "nullable(" + source + ".Time, !" + source + ".IsZero())"
+	}
+	return goType, source
+}
+
+// serializerImports copies the model file's imports that the serializer's
+// field types still reference, split into standard library and other
+// packages.
+func serializerImports(modelSrc []byte, data serializerTemplateData) ([]string, []string) {
+	used := make(map[string]bool)
+	qualifier := regexp.MustCompile(`\b([a-z][A-Za-z0-9_]*)\.`)
+	for _, field := range data.Fields {
+		for _, match := range qualifier.FindAllStringSubmatch(field.Type, -1) {
+			used[match[1]] = true
+		}
+	}
+	for _, computed := range data.Computed {
+		for _, match := range qualifier.FindAllStringSubmatch(computed.Type, -1) {
+			used[match[1]] = true
+		}
+	}
+
+	var standard []string
+	external := []string{strconv.Quote(data.ModulePath + "/models")}
+	file, err := parser.ParseFile(token.NewFileSet(), "", modelSrc, parser.ImportsOnly)
+	if err != nil {
+		return standard, external
+	}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !used[name] {
+			continue
+		}
+		importSpec := spec.Path.Value
+		if spec.Name != nil {
+			importSpec = spec.Name.Name + " " + importSpec
+		}
+		firstElem, _, _ := strings.Cut(importPath, "/")
+		if strings.Contains(firstElem, ".") {
+			external = append(external, importSpec)
+		} else {
+			standard = append(standard, importSpec)
+		}
+		delete(used, name)
+	}
+	if used["time"] {
+		standard = append(standard, `"time"`)
+	}
+	slices.Sort(standard)
+	slices.Sort(external)
+	return standard, external
+}
@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+const serializerOrderModel = `package models
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type OrderEntity struct {
+	bun.BaseModel ` + "`bun:\"table:orders,alias:orders\"`" + `
+	ID            uuid.UUID      ` + "`bun:\"id,pk,type:uuid\"`" + `
+	CreatedAt     time.Time      ` + "`bun:\"created_at\"`" + `
+	CustomerID    uuid.UUID      ` + "`bun:\"customer_id\"`" + `
+	Notes         sql.NullString ` + "`bun:\"notes\"`" + `
+	ShippedAt     sql.NullTime   ` + "`bun:\"shipped_at\"`" + `
+	AccessToken   string         ` + "`bun:\"access_token\"`" + `
+}
+`
+
+func newTestSerializerManager(t *testing.T) *SerializerManager {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	for path, content := range map[string]string{
+		"models/order.go":     serializerOrderModel,
+		"models/customer.go":  "package models\n\ntype CustomerEntity struct {\n\tName string `bun:\"name\"`\n}\n",
+		"models/line_item.go": "package models\n\ntype LineItemEntity struct {\n\tSku string `bun:\"sku\"`\n}\n",
+	} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fileManager := files.NewUnifiedFileManager()
+	return NewSerializerManager(
+		NewInputValidator(),
+		fileManager,
+		&ProjectManager{modulePath: "example.com/app", fileManager: fileManager},
+		&UnifiedConfig{Paths: PathConfig{Models: "models"}},
+	)
+}
+
+func TestBuildSerializerWhitelistsFieldsAndNests(t *testing.T) {
+	manager := newTestSerializerManager(t)
+
+	data, err := manager.buildSerializer("Order", SerializerOptions{
+		Except:   []string{"created_at"},
+		Computed: []string{"summary", "item_count:int"},
+		Include:  []string{"Customer", "LineItem"},
+	})
+	if err != nil {
+		t.Fatalf("buildSerializer: %v", err)
+	}
+
+	content, err := templates.GetGlobalTemplateService().RenderTemplate("serializer.tmpl", data)
+	if err != nil {
+		t.Fatalf("render serializer: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "order.go", content, 0); err != nil {
+		t.Fatalf("serializer is not valid Go: %v\n%s", err, content)
+	}
+
+	for _, want := range []string{
+		"\"example.com/app/models\"",
+		"\"time\"",
+		"ID uuid.UUID `json:\"id\"`",
+		"CustomerID uuid.UUID `json:\"customerId\"`",
+		"Notes *string `json:\"notes\"`",
+		"Notes: nullable(entity.Notes.String, entity.Notes.Valid),",
+		"ShippedAt *time.Time `json:\"shippedAt\"`",
+		"ItemCount int `json:\"itemCount\"`",
+		"Summary: orderSummary(entity),",
+		"Customer *Customer `json:\"customer,omitempty\"`",
+		"LineItems []LineItem `json:\"lineItems,omitempty\"`",
+		"func NewOrderList(entities []models.OrderEntity) []Order",
+		"func (s Order) WithCustomer(entity models.CustomerEntity) Order",
+		"func (s Order) WithLineItems(entities []models.LineItemEntity) Order",
+		"func orderItemCount(entity models.OrderEntity) int",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("serializer missing %q\n%s", want, content)
+		}
+	}
+	for _, unwanted := range []string{"CreatedAt", "AccessToken"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("serializer should leave out %s\n%s", unwanted, content)
+		}
+	}
+}
+
+func TestBuildSerializerOnlyExposesListedColumns(t *testing.T) {
+	manager := newTestSerializerManager(t)
+
+	data, err := manager.buildSerializer("Order", SerializerOptions{Only: []string{"id", "access_token"}})
+	if err != nil {
+		t.Fatalf("buildSerializer: %v", err)
+	}
+	var names []string
+	for _, field := range data.Fields {
+		names = append(names, field.Name)
+	}
+	if got := strings.Join(names, ","); got != "ID,AccessToken" {
+		t.Fatalf("fields = %s, want ID,AccessToken", got)
+	}
+
+	if _, err := manager.buildSerializer("Order", SerializerOptions{Only: []string{"total"}}); err == nil {
+		t.Fatal("expected unknown column error")
+	}
+	if _, err := manager.buildSerializer("Order", SerializerOptions{Computed: []string{"Notes"}}); err == nil {
+		t.Fatal("expected invalid computed field error")
+	}
+	if _, err := manager.buildSerializer("Order", SerializerOptions{Include: []string{"Invoice"}}); err == nil {
+		t.Fatal("expected missing included model error")
+	}
+}
@@ -0,0 +1,306 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mbvlabs/andurel/generator/controllers"
+	"github.com/mbvlabs/andurel/generator/files"
+	"github.com/mbvlabs/andurel/generator/internal/types"
+	"github.com/mbvlabs/andurel/generator/templates"
+	"github.com/mbvlabs/andurel/generator/views"
+	"github.com/mbvlabs/andurel/layout"
+	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+type shareTemplateData struct {
+	ModulePath     string
+	ResourceName   string
+	ModelName      string
+	ControllerName string
+	ReceiverName   string
+	ResourceVar    string
+	TableName      string
+	SharedPrefix   string
+	IDType         string
+	IDRoute        string
+	TTL            string
+	CSSComponents  bool
+}
+
+// shareSharedFiles are generated once per project and reused by every
+// resource with share links.
+var shareSharedFiles = []struct {
+	template string
+	path     string
+}{
+	{"share_link_model.tmpl", filepath.Join("models", "share_link.go")},
+	{"share_link_controller.tmpl", filepath.Join("controllers", "share_links.go")},
+	{"share_link_view.tmpl", filepath.Join("views", "share_links.templ")},
+	{"public_layout.tmpl", filepath.Join("views", "public_layout.templ")},
+}
+
+var shareLinkNow = time.Now
+
+// ShareManager adds public share links to a generated resource.
+type ShareManager struct {
+	validator        *InputValidator
+	fileManager      files.Manager
+	projectManager   *ProjectManager
+	migrationManager *MigrationManager
+	viewGenerator    *views.Generator
+	config           *UnifiedConfig
+}
+
+// NewShareManager creates a new share manager.
+func NewShareManager(
+	validator *InputValidator,
+	fileManager files.Manager,
+	projectManager *ProjectManager,
+	migrationManager *MigrationManager,
+	viewGenerator *views.Generator,
+	config *UnifiedConfig,
+) *ShareManager {
+	return &ShareManager{
+		validator:        validator,
+		fileManager:      fileManager,
+		projectManager:   projectManager,
+		migrationManager: migrationManager,
+		viewGenerator:    viewGenerator,
+		config:           config,
+	}
+}
+
+// GenerateShare writes the share link routes, controller and public page for
+// a scaffolded resource and adds the share panel to its show page. Links
+// created from the panel expire after ttl, or never when ttl is zero.
+func (s *ShareManager) GenerateShare(resourceName, tableName string, ttl time.Duration) error {
+	if err := s.validator.ValidateResourceName(resourceName); err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return fmt.Errorf("share link expiry cannot be negative")
+	}
+	if tableName == "" {
+		tableName = naming.DeriveTableName(resourceName)
+	}
+
+	showViewPath := filepath.Join(s.config.Paths.Views, tableName+"_resource.templ")
+	if !s.fileManager.FileExists(showViewPath) {
+		return fmt.Errorf(
+			"no Templ resource view at %s; generate the resource with 'andurel generate scaffold %s' first",
+			showViewPath,
+			resourceName,
+		)
+	}
+
+	cat, err := s.migrationManager.BuildCatalogFromMigrations(tableName, s.config)
+	if err != nil {
+		return err
+	}
+	modulePath := s.projectManager.GetModulePath()
+	rootDir, err := s.fileManager.FindGoModRoot()
+	if err != nil {
+		return err
+	}
+	lock, _ := layout.ReadLockFile(rootDir)
+	projectTypes, err := types.LoadTypeOverrides(rootDir)
+	if err != nil {
+		return err
+	}
+	view, err := s.viewGenerator.Build(cat, views.Config{
+		ResourceName: resourceName,
+		EntityName:   resourceName + "Entity",
+		PluralName:   tableName,
+		TableName:    tableName,
+		ModulePath:   modulePath,
+		DecimalType:  lock.DecimalType(),
+		ProjectTypes: projectTypes,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read %s columns: %w", tableName, err)
+	}
+
+	controllerName := resourceName + "Shares"
+	data := shareTemplateData{
+		ModulePath:     modulePath,
+		ResourceName:   resourceName,
+		ModelName:      resourceName,
+		ControllerName: controllerName,
+		ReceiverName:   naming.ToReceiverName(controllerName),
+		ResourceVar:    naming.ToLowerCamelCase(resourceName),
+		TableName:      tableName,
+		SharedPrefix:   "/shared/" + naming.ToKebabCase(tableName),
+		IDType:         view.IDType,
+		IDRoute:        shareIDRoute(view.IDType),
+		TTL:            shareTTLExpr(ttl),
+	}
+
+	if lock != nil {
+		_, data.CSSComponents = lock.Extensions["css-components"]
+	}
+
+	routesPath := filepath.Join(s.config.Paths.Routes, tableName+"_shares.go")
+	controllerPath := filepath.Join(s.config.Paths.Controllers, tableName+"_shares.go")
+	sharedViewPath := filepath.Join(s.config.Paths.Views, tableName+"_shares.templ")
+	for _, path := range []string{routesPath, controllerPath, sharedViewPath} {
+		if s.fileManager.FileExists(path) {
+			return fmt.Errorf("share links are already enabled for %s: %s exists", resourceName, path)
+		}
+	}
+
+	showView, err := os.ReadFile(showViewPath)
+	if err != nil {
+		return fmt.Errorf("failed to read view %s: %w", showViewPath, err)
+	}
+	updatedShowView, err := addShareLinksToShowView(string(showView), resourceName)
+	if err != nil {
+		return fmt.Errorf("failed to add share links to %s: %w", showViewPath, err)
+	}
+
+	if err := s.generateMigration(); err != nil {
+		return fmt.Errorf("failed to generate share_links migration: %w", err)
+	}
+
+	for _, file := range shareSharedFiles {
+		if s.fileManager.FileExists(file.path) {
+			continue
+		}
+		if err := s.render(file.template, file.path, data); err != nil {
+			return err
+		}
+	}
+
+	if err := s.render("share_resource_route.tmpl", routesPath, data); err != nil {
+		return err
+	}
+	if err := s.render("share_resource_controller.tmpl", controllerPath, data); err != nil {
+		return err
+	}
+	sharedView, err := s.viewGenerator.GenerateShareViewFile(view)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(sharedViewPath, []byte(sharedView), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", sharedViewPath, err)
+	}
+	if err := os.WriteFile(showViewPath, []byte(updatedShowView), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", showViewPath, err)
+	}
+
+	if err := controllers.NewMainInjector().InjectController(resourceName+"Share", "", naming.ToSnakeCase(controllerName)); err != nil {
+		return fmt.Errorf("failed to register %s controller: %w", controllerName, err)
+	}
+
+	if err := compileTemplates(
+		rootDir,
+		filepath.Join(s.config.Paths.Views, "share_links.templ"),
+		filepath.Join(s.config.Paths.Views, "public_layout.templ"),
+		sharedViewPath,
+		showViewPath,
+	); err != nil {
+		return err
+	}
+
+	fmt.Printf("Successfully added share links to %s\n", resourceName)
+	return nil
+}
+
+// render writes a template to path, formatting Go output.
+func (s *ShareManager) render(templateName, path string, data shareTemplateData) error {
+	content, err := templates.GetGlobalTemplateService().RenderTemplate(templateName, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", templateName, err)
+	}
+	if err := s.fileManager.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if strings.HasSuffix(path, ".go") {
+		if err := files.FormatGoFile(path); err != nil {
+			return fmt.Errorf("failed to format %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (s *ShareManager) generateMigration() error {
+	existing, err := filepath.Glob(filepath.Join(s.config.Paths.Migrations, "*_create_share_links_table.sql"))
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	path := filepath.Join(
+		s.config.Paths.Migrations,
+		shareLinkNow().UTC().Format("20060102150405")+"_create_share_links_table.sql",
+	)
+	return s.render("share_link_migration.tmpl", path, shareTemplateData{})
+}
+
+// addShareLinksToShowView renders the share panel loader at the bottom of
+// the scaffolded Show page.
+func addShareLinksToShowView(content, resourceName string) (string, error) {
+	if strings.Contains(content, "@ShareLinksLoader(") {
+		return "", fmt.Errorf("share links are already enabled")
+	}
+
+	page := regexp.MustCompile(`templ \((\w+) ` + regexp.QuoteMeta(resourceName) + `Show\) Page\(\) \{`)
+	m := page.FindStringSubmatchIndex(content)
+	if m == nil {
+		return "", fmt.Errorf("no %sShow page found", resourceName)
+	}
+	receiver := content[m[2]:m[3]]
+
+	closing := regexp.MustCompile(`\n(\t*)</div>\n\t*</main>`)
+	c := closing.FindStringSubmatchIndex(content[m[1]:])
+	if c == nil {
+		return "", fmt.Errorf("no closing </main> found in the %sShow page", resourceName)
+	}
+	indent := content[m[1]+c[2] : m[1]+c[3]]
+	insertAt := m[1] + c[0] + 1
+
+	loader := fmt.Sprintf(
+		"%s\t@ShareLinksLoader(routes.%sShareIndex.URL(%s.Item.ID))\n",
+		indent,
+		resourceName,
+		receiver,
+	)
+	return content[:insertAt] + loader + content[insertAt:], nil
+}
+
+func shareIDRoute(idType string) string {
+	switch idType {
+	case "int32":
+		return "NewRouteWithSerialID"
+	case "int64":
+		return "NewRouteWithBigSerialID"
+	case "string":
+		return "NewRouteWithStringID"
+	default:
+		return "NewRouteWithUUIDID"
+	}
+}
+
+// shareTTLExpr renders ttl as a Go constant expression.
+func shareTTLExpr(ttl time.Duration) string {
+	switch {
+	case ttl == 0:
+		return "time.Duration(0)"
+	case ttl%time.Hour == 0:
+		return fmt.Sprintf("%d * time.Hour", ttl/time.Hour)
+	case ttl%time.Minute == 0:
+		return fmt.Sprintf("%d * time.Minute", ttl/time.Minute)
+	default:
+		return fmt.Sprintf("%d * time.Second", ttl/time.Second)
+	}
+}
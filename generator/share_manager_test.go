@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mbvlabs/andurel/generator/templates"
+)
+
+func TestShareResourceControllerParsesEveryIDType(t *testing.T) {
+	service := templates.GetGlobalTemplateService()
+
+	for _, idType := range []string{"uuid.UUID", "int32", "int64", "string"} {
+		t.Run(idType, func(t *testing.T) {
+			data := shareTemplateData{
+				ModulePath:     "example.com/app",
+				ResourceName:   "Invoice",
+				ModelName:      "Invoice",
+				ControllerName: "InvoiceShares",
+				ReceiverName:   "is",
+				ResourceVar:    "invoice",
+				TableName:      "invoices",
+				SharedPrefix:   "/shared/invoices",
+				IDType:         idType,
+				IDRoute:        shareIDRoute(idType),
+				TTL:            shareTTLExpr(24 * time.Hour),
+			}
+			for _, name := range []string{"share_resource_controller.tmpl", "share_resource_route.tmpl"} {
+				content, err := service.RenderTemplate(name, data)
+				if err != nil {
+					t.Fatalf("render %s failed: %v", name, err)
+				}
+				if _, err := parser.ParseFile(token.NewFileSet(), name, content, 0); err != nil {
+					t.Fatalf("%s is not valid Go: %v\n%s", name, err, content)
+				}
+			}
+		})
+	}
+}
+
+func TestAddShareLinksToShowView(t *testing.T) {
+	view := `templ (ps ProductShow) Page() {
+	@base() {
+		<main>
+			<div class="mx-auto">
+				<div class="card"></div>
+			</div>
+		</main>
+	}
+}
+`
+	updated, err := addShareLinksToShowView(view, "Product")
+	if err != nil {
+		t.Fatalf("addShareLinksToShowView returned error: %v", err)
+	}
+	want := "\t\t\t\t<div class=\"card\"></div>\n\t\t\t\t@ShareLinksLoader(routes.ProductShareIndex.URL(ps.Item.ID))\n\t\t\t</div>\n\t\t</main>"
+	if !strings.Contains(updated, want) {
+		t.Fatalf("expected loader before the closing container:\n%s", updated)
+	}
+
+	if _, err := addShareLinksToShowView(updated, "Product"); err == nil || !strings.Contains(err.Error(), "already enabled") {
+		t.Fatalf("expected already enabled error, got %v", err)
+	}
+	if _, err := addShareLinksToShowView("package views\n", "Product"); err == nil || !strings.Contains(err.Error(), "ProductShow") {
+		t.Fatalf("expected missing show page error, got %v", err)
+	}
+}
+
+func TestShareTTLExpr(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                  "time.Duration(0)",
+		168 * time.Hour:    "168 * time.Hour",
+		90 * time.Minute:   "90 * time.Minute",
+		1500 * time.Second: "25 * time.Minute",
+		45 * time.Second:   "45 * time.Second",
+	}
+	for ttl, want := range tests {
+		if got := shareTTLExpr(ttl); got != want {
+			t.Fatalf("shareTTLExpr(%s) = %q, want %q", ttl, got, want)
+		}
+	}
+}
@@ -7,9 +7,9 @@ import (
 	"text/template"
 	"unicode"
 
-	"github.com/jinzhu/inflection"
 	"github.com/mbvlabs/andurel/pkg/errors"
 	"github.com/mbvlabs/andurel/pkg/naming"
+	"github.com/mbvlabs/andurel/pkg/templatefuncs"
 )
 
 // TemplateData represents the unified data structure for all templates
@@ -207,7 +207,8 @@ func (ts *TemplateService) renderTemplateWithCustomFunctionsAndPartials(
 
 // getDefaultTemplateFunctions returns the default template function map
 func getDefaultTemplateFunctions() template.FuncMap {
-	return template.FuncMap{
+	functions := templatefuncs.FuncMap()
+	maps.Copy(functions, template.FuncMap{
 		"ToLower":          strings.ToLower,
 		"ToUpper":          strings.ToUpper,
 		"ToSnakeCase":      naming.ToSnakeCase,
@@ -217,7 +218,7 @@ func getDefaultTemplateFunctions() template.FuncMap {
 		"ToReceiverName":   naming.ToReceiverName,
 		"Capitalize":       naming.Capitalize,
 		"DeriveTableName":  naming.DeriveTableName,
-		"Plural":           inflection.Plural,
+		"Plural":           naming.Plural,
 		"DatabaseType": func(data any) string {
 			if td, ok := data.(*TemplateData); ok {
 				return td.Database.Type
@@ -239,7 +240,8 @@ func getDefaultTemplateFunctions() template.FuncMap {
 		"toLowerCamelCase": toLowerCamelCase,
 		"toCamelCase":      toCamelCase,
 		"hasPrefix":        strings.HasPrefix,
-	}
+	})
+	return functions
 }
 
 // toCamelCase converts snake_case to camelCase for use in templates
@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE TYPE ticket_status AS ENUM ('open', 'in-progress', 'closed');
+CREATE TYPE ticket_priority AS ENUM ('low', 'high');
+
+-- +goose Down
+DROP TYPE ticket_priority;
+DROP TYPE ticket_status;
@@ -0,0 +1,6 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+ALTER TYPE ticket_status ADD VALUE 'blocked' BEFORE 'closed';
+
+-- +goose Down
+SELECT 1;
@@ -0,0 +1,11 @@
+-- +goose Up
+CREATE TABLE accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    settings JSONB NOT NULL DEFAULT '{}',
+    billing_address JSONB,
+    metadata JSONB NOT NULL DEFAULT '{}'
+);
+
+-- +goose Down
+DROP TABLE accounts;
@@ -7,7 +7,7 @@ import (
 	"slices"
 	"strings"
 
-	"github.com/jinzhu/inflection"
+	"github.com/mbvlabs/andurel/generator/internal/catalog"
 	"github.com/mbvlabs/andurel/pkg/naming"
 )
 
@@ -51,7 +51,7 @@ func (v *InputValidator) ValidateResourceName(resourceName string) error {
 			return fmt.Errorf("resource name '%s' contains an empty segment", resourceName)
 		}
 
-		singular := inflection.Singular(part)
+		singular := naming.Singular(part)
 		if idx == 0 {
 			if singular != part {
 				return fmt.Errorf(
@@ -92,7 +92,7 @@ func (v *InputValidator) ValidateTableName(tableName string) error {
 		)
 	}
 
-	if inflection.Plural(tableName) != tableName {
+	if naming.Plural(tableName) != tableName {
 		return fmt.Errorf("table name '%s' must be plural snake_case", tableName)
 	}
 
@@ -126,6 +126,15 @@ func (v *InputValidator) ValidateTableNameOverride(resourceName, tableNameOverri
 	if err != nil {
 		return fmt.Errorf("failed to compile table name override pattern: %w", err)
 	}
+	// A table outside the default schema is named with its schema, as in
+	// billing.invoices.
+	schemaName, tableNameOverride := catalog.SplitQualifiedName(tableNameOverride)
+	if schemaName != "" && !validSQLIdentifier.MatchString(schemaName) {
+		return fmt.Errorf(
+			"schema name '%s' must be snake_case using lowercase letters, numbers, and underscores",
+			schemaName,
+		)
+	}
 	if !validSQLIdentifier.MatchString(tableNameOverride) {
 		return fmt.Errorf(
 			"table name '%s' must be snake_case using lowercase letters, numbers, and underscores",
@@ -154,7 +163,7 @@ func (v *InputValidator) ValidateTableNameOverride(resourceName, tableNameOverri
 			fmt.Printf("⚠️  Ensure migration creates the '%s' table\n", tableNameOverride)
 		}
 
-		if inflection.Plural(tableNameOverride) != tableNameOverride {
+		if naming.Plural(tableNameOverride) != tableNameOverride {
 			fmt.Printf("⚠️  Table name '%s' does not appear to be plural. Convention suggests using plural names.\n", tableNameOverride)
 		}
 	}
@@ -162,6 +171,20 @@ func (v *InputValidator) ValidateTableNameOverride(resourceName, tableNameOverri
 	return nil
 }
 
+// validateDefaultSchema rejects a table outside the default schema, such as
+// billing.invoices. Controllers and views are named after the table, so they
+// are only generated for tables in the default schema.
+func validateDefaultSchema(tableName string) error {
+	if schemaName, _ := catalog.SplitQualifiedName(tableName); schemaName != "" {
+		return fmt.Errorf(
+			"table %s is in schema %s; controllers and views are only generated for tables in the default schema",
+			tableName,
+			schemaName,
+		)
+	}
+	return nil
+}
+
 func (v *InputValidator) shouldWarnTableOverride(resourceName, tableNameOverride string) bool {
 	if v == nil {
 		return true
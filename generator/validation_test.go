@@ -135,6 +135,20 @@ func TestValidateTableNameOverride(t *testing.T) {
 			wantError:     true,
 			wantWarning:   false,
 		},
+		{
+			name:          "valid override in a schema",
+			resourceName:  "Invoice",
+			tableOverride: "billing.invoices",
+			wantError:     false,
+			wantWarning:   false,
+		},
+		{
+			name:          "invalid override - uppercase schema",
+			resourceName:  "Invoice",
+			tableOverride: "Billing.invoices",
+			wantError:     true,
+			wantWarning:   false,
+		},
 		{
 			name:          "valid singular override with warning",
 			resourceName:  "User",
@@ -76,6 +76,9 @@ func (v *ViewManager) generateViewWithActionsForModel(resourceName, modelName, t
 		return fmt.Errorf("model name validation failed: %w", err)
 	}
 
+	if err := validateDefaultSchema(tableName); err != nil {
+		return err
+	}
 	if tableNameOverridden {
 		if err := v.validator.ValidateTableNameOverride(resourceName, tableName); err != nil {
 			return fmt.Errorf("table name validation failed: %w", err)
@@ -134,6 +137,9 @@ func (v *ViewManager) GenerateViewFromModel(resourceName string, withController
 
 	tableName, tableNameOverridden := ResolveTableNameWithFlag(v.config.Paths.Models, resourceName)
 
+	if err := validateDefaultSchema(tableName); err != nil {
+		return err
+	}
 	if tableNameOverridden {
 		if err := v.validator.ValidateTableNameOverride(resourceName, tableName); err != nil {
 			return fmt.Errorf("table name validation failed: %w", err)
@@ -161,6 +167,12 @@ func (v *ViewManager) GenerateViewFromModel(resourceName string, withController
 		if err := validateControllerNotExists(validationCtx); err != nil {
 			return err
 		}
+		if err := validateControllerIdentifiers(resourceName); err != nil {
+			return err
+		}
+		if err := validateControllerNamesAvailable(validationCtx, ""); err != nil {
+			return err
+		}
 	}
 
 	cat, err := v.migrationManager.BuildCatalogFromMigrations(tableName, v.config)
@@ -168,6 +180,10 @@ func (v *ViewManager) GenerateViewFromModel(resourceName string, withController
 		return err
 	}
 
+	if pkInfo := DetectPrimaryKey(cat, tableName); withController && pkInfo.IsComposite() {
+		return errCompositePrimaryKey(tableName, pkInfo)
+	}
+
 	if err := v.viewGenerator.GenerateViewWithController(cat, resourceName, tableName, modulePath, withController, "", ""); err != nil {
 		return fmt.Errorf("failed to generate view: %w", err)
 	}
@@ -175,7 +191,7 @@ func (v *ViewManager) GenerateViewFromModel(resourceName string, withController
 	if withController {
 		controllerType := controllers.ResourceController // with views since we're generating both
 		fileGen := controllers.NewFileGenerator()
-		nullType := ReadNullType()
+		nullType := ReadModelNullType(v.config.Paths.Models, resourceName)
 		inertia := ""
 		pkInfo := DetectPrimaryKey(cat, tableName)
 		if err := fileGen.GenerateController(cat, resourceName, "", tableName, controllerType, modulePath, v.config.Database.Type, tableNameOverridden, nullType, pkInfo.ColumnName, inertia); err != nil {
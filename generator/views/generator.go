@@ -34,6 +34,11 @@ type ViewField struct {
 	DBName          string
 	CamelCase       string
 	IsSystemField   bool
+	IsRichText      bool
+	IsDuration      bool
+	// ProjectPackage is the import path of a type mapped in
+	// andurel.types.yaml, if it lives outside the models package.
+	ProjectPackage string
 }
 
 // InertiaPageData wraps generated view data with an Inertia component name.
@@ -57,6 +62,7 @@ type GeneratedView struct {
 	IDFieldName      string
 	Actions          []string
 	AvailableActions []string
+	HasLocation      bool // Geocoded latitude and longitude columns
 }
 
 // Config controls view generation for a resource.
@@ -71,6 +77,9 @@ type Config struct {
 	Namespace       string
 	ModulePath      string
 	Actions         []string
+	DecimalType     string               // "float64" (default) or "decimal"
+	NullType        string               // "sql.Null" (default), "bun.Null" or "pointer"
+	ProjectTypes    []types.TypeOverride // mappings from andurel.types.yaml
 }
 
 // Generator builds view template data and writes view files.
@@ -89,6 +98,12 @@ func NewGenerator(databaseType string) *Generator {
 
 // Build converts catalog metadata and config into generated view data.
 func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedView, error) {
+	g.typeMapper.DecimalType = config.DecimalType
+	g.typeMapper.NullType = config.NullType
+	g.typeMapper.ProjectTypes = config.ProjectTypes
+	if g.typeMapper.NullType == "" {
+		g.typeMapper.NullType = types.NullTypeSQL
+	}
 	modelName := config.ModelName
 	if modelName == "" {
 		modelName = config.ResourceName
@@ -143,6 +158,14 @@ func (g *Generator) Build(cat *catalog.Catalog, config Config) (*GeneratedView,
 		view.Fields = append(view.Fields, field)
 	}
 
+	// Columns annotated with COMMENT ON COLUMN ... IS 'andurel:geocoded' are
+	// filled by the geocode job; show pages render latitude and longitude as
+	// a map.
+	latitude, latErr := table.GetColumn("latitude")
+	longitude, lngErr := table.GetColumn("longitude")
+	view.HasLocation = latErr == nil && lngErr == nil &&
+		latitude.HasAnnotation("geocoded") && longitude.HasAnnotation("geocoded")
+
 	return view, nil
 }
 
@@ -178,12 +201,25 @@ func hasNullFields(fields []ViewField) bool {
 }
 
 func isNullType(goType string) bool {
+	if _, ok := pointerZeroValues[strings.TrimPrefix(goType, "*")]; ok && strings.HasPrefix(goType, "*") {
+		return true
+	}
 	return strings.HasPrefix(goType, "sql.Null") || strings.HasPrefix(goType, "bun.Null")
 }
 
+// pointerZeroValues lists the pointer field types the view data struct
+// dereferences, with the value shown when the pointer is nil, as it is for
+// the matching sql.Null wrapper. Pointers to numbers stay pointers so forms
+// show NULL as an empty field rather than 0.
+var pointerZeroValues = map[string]string{
+	"string":    `""`,
+	"bool":      "false",
+	"time.Time": "time.Time{}",
+}
+
 func usesViewDataType(fields []ViewField, goType string) bool {
 	for _, field := range fields {
-		if strings.TrimPrefix(viewDataType(field), "*") == goType {
+		if strings.TrimLeft(viewDataType(field), "*[]") == goType {
 			return true
 		}
 	}
@@ -214,6 +250,10 @@ func viewDataValue(field ViewField, source string) string {
 	case "sql.NullTime", "bun.NullTime":
 		return "func() time.Time { if !" + source + ".Valid { return time.Time{} }; return " + source + ".Time }()"
 	default:
+		if zero, ok := pointerZeroValues[strings.TrimPrefix(field.GoType, "*")]; ok && isNullType(field.GoType) {
+			base := strings.TrimPrefix(field.GoType, "*")
+			return "func() " + base + " { if " + source + " == nil { return " + zero + " }; return *" + source + " }()"
+		}
 		return source
 	}
 }
@@ -263,6 +303,9 @@ func viewDataImports(fields []ViewField) string {
 	if usesViewDataType(fields, "uuid.UUID") {
 		b.WriteString("\t\"github.com/google/uuid\"\n")
 	}
+	if usesViewDataType(fields, types.DecimalGoType) {
+		b.WriteString("\t\"" + types.DecimalPackage + "\"\n")
+	}
 	return b.String()
 }
 
@@ -360,7 +403,7 @@ func inertiaReactDisplay(field ViewField, objRef string) string {
 }
 
 func (g *Generator) buildViewField(col *catalog.Column) (ViewField, error) {
-	goType, _, err := g.typeMapper.MapSQLTypeToGo(col.DataType, col.IsNullable)
+	goType, _, err := g.typeMapper.MapColumnToGo(col)
 	if err != nil {
 		goType = "string"
 	}
@@ -372,7 +415,7 @@ func (g *Generator) buildViewField(col *catalog.Column) (ViewField, error) {
 		DisplayName:   types.FormatDisplayName(col.Name),
 		DBName:        col.Name,
 		CamelCase:     types.FormatCamelCase(col.Name),
-		IsSystemField: col.Name == "created_at" || col.Name == "updated_at",
+		IsSystemField: col.Name == "created_at" || col.Name == "updated_at" || col.Name == "deleted_at" || col.IsAutoIncrement || col.Generated != "" || col.HasAnnotation("geocoded"),
 		GoType:        goType,
 	}
 
@@ -405,21 +448,59 @@ func (g *Generator) buildViewField(col *catalog.Column) (ViewField, error) {
 	case "uuid.UUID":
 		field.InputType = "text"
 		field.StringConverter = "%s.String()"
+		if goType != viewGoType {
+			field.StringConverter = "func() string { if %s == nil { return \"\" }; return %s.String() }()"
+		}
 	case "[]byte":
 		field.InputType = "text"
 		field.StringConverter = "string(%s)"
-	case "[]int32":
-		field.InputType = "text"
-		field.StringConverter = "fmt.Sprintf(\"%v\", %s)"
 	case "[]string":
+		// Arrays are edited as a comma-separated list, which the
+		// controller splits again when the form is submitted.
 		field.InputType = "text"
 		field.StringConverter = "strings.Join(%s, \", \")"
+	case "[]bool", "[]int16", "[]int32", "[]int64", "[]float32", "[]float64", "[]uuid.UUID":
+		field.InputType = "text"
+		field.StringConverter = "strings.Join(strings.Fields(strings.Trim(fmt.Sprint(%s), \"[]\")), \", \")"
 	case "interface{}":
 		field.InputType = "text"
 		field.StringConverter = "fmt.Sprintf(\"%v\", %s)"
+	case types.DecimalGoType:
+		field.InputType = "text"
+		field.StringConverter = "%s.String()"
+		if goType != viewGoType {
+			field.StringConverter = "func() string { if %s == nil { return \"\" }; return %s.String() }()"
+		}
+	case types.IntervalGoType:
+		// Durations are spelled out, such as "1 hour 30 minutes", which
+		// interval.Parse reads back when the form is submitted.
+		field.InputType = "text"
+		field.IsDuration = true
+		field.StringConverter = "interval.Format(%s)"
+		if goType != viewGoType {
+			field.StringConverter = "interval.FormatOptional(%s)"
+		}
+	case types.MoneyGoType:
+		// Amounts are shown in the request locale; money.Parse reads the
+		// formatted value back when the form is submitted.
+		field.InputType = "text"
+		field.StringConverter = "money.Format(ctx, %s)"
+		if goType != viewGoType {
+			field.StringConverter = "money.FormatOptional(ctx, %s)"
+		}
 	default:
 		field.InputType = "text"
 		field.StringConverter = "fmt.Sprintf(\"%v\", %s)"
+		if custom, ok := g.typeMapper.ProjectType(col.DataType); ok && viewGoType == custom.GoType {
+			setProjectType(&field, custom, goType != viewGoType)
+		}
+	}
+
+	// Columns annotated with COMMENT ON COLUMN ... IS 'andurel:richtext' hold
+	// HTML: tables show the plain text, show pages render it sanitized.
+	if viewGoType == "string" && col.HasAnnotation("richtext") {
+		field.IsRichText = true
+		field.StringConverter = "htmlsanitize.Text(%s)"
 	}
 
 	switch viewGoType {
@@ -441,9 +522,56 @@ func (g *Generator) buildViewField(col *catalog.Column) (ViewField, error) {
 		field.GoFormType = "string"
 	}
 
+	// Nullable numbers held in pointers render nil as an empty field and are
+	// submitted as text, so the controller can store an empty field as NULL.
+	if strings.HasPrefix(goType, "*") && field.InputType == "number" {
+		field.StringConverter = "func() string { if %s == nil { return \"\" }; return " +
+			strings.ReplaceAll(field.StringConverter, "%s", "*%s") + " }()"
+		field.GoFormType = "string"
+	}
+
 	return field, nil
 }
 
+// setProjectType shows a field mapped in andurel.types.yaml with the
+// mapping's format expression, or fmt otherwise. Forms edit it as text that
+// the controller converts back with the parse expression.
+func setProjectType(field *ViewField, custom types.TypeOverride, isPointer bool) {
+	field.GoType = custom.QualifiedGoType()
+	field.ProjectPackage = custom.Package
+	converter := custom.Format
+	if converter == "" {
+		converter = "fmt.Sprintf(\"%v\", %s)"
+	}
+	field.StringConverter = converter
+	if isPointer {
+		field.GoType = "*" + field.GoType
+		field.StringConverter = "func() string { if %s == nil { return \"\" }; return " +
+			strings.ReplaceAll(converter, "%s", "(*%s)") + " }()"
+	}
+}
+
+// projectTypeImports returns the import lines for the packages of types
+// mapped in andurel.types.yaml that the view refers to, either through a
+// field's converter or, when withData is set, the view data struct.
+func projectTypeImports(fields []ViewField, withData bool) string {
+	withData = withData && hasNullFields(fields)
+	var b strings.Builder
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if field.ProjectPackage == "" || seen[field.ProjectPackage] {
+			continue
+		}
+		qualifier, _, _ := strings.Cut(strings.TrimLeft(field.GoType, "*[]"), ".")
+		if !withData && !strings.Contains(field.StringConverter, qualifier+".") {
+			continue
+		}
+		seen[field.ProjectPackage] = true
+		fmt.Fprintf(&b, "%q\n\t", field.ProjectPackage)
+	}
+	return b.String()
+}
+
 func (g *Generator) templatePrefix(lock *layout.AndurelLock) string {
 	hasCssComponents := false
 
@@ -462,8 +590,103 @@ func (g *Generator) templatePrefix(lock *layout.AndurelLock) string {
 
 // GenerateViewFile renders a server-rendered view template.
 func (g *Generator) GenerateViewFile(view *GeneratedView, withController bool, templatePrefix string) (string, error) {
-	// Custom template functions for view-specific operations
-	customFuncs := template.FuncMap{
+	templateName := templatePrefix + "resource_view_no_controller.tmpl"
+	if withController {
+		templateName = templatePrefix + "resource_view.tmpl"
+	}
+
+	// Use the unified template service with custom functions
+	service := templates.GetGlobalTemplateService()
+	result, err := service.RenderTemplateWithCustomFunctions(templateName, view, viewTemplateFuncs(view))
+	if err != nil {
+		return "", errors.WrapTemplateError(err, "render view", templateName)
+	}
+	return result, nil
+}
+
+// GenerateViewTestFile renders the snapshot test of a resource's templ pages.
+// snapshotPrefix names the view file and the golden files, e.g. "admin_posts".
+func (g *Generator) GenerateViewTestFile(view *GeneratedView, snapshotPrefix string) (string, error) {
+	data := struct {
+		*GeneratedView
+		SnapshotPrefix string
+	}{view, snapshotPrefix}
+
+	service := templates.GetGlobalTemplateService()
+	result, err := service.RenderTemplateWithCustomFunctions("resource_view_test.tmpl", data, viewTemplateFuncs(view))
+	if err != nil {
+		return "", errors.WrapTemplateError(err, "render view test", "resource_view_test.tmpl")
+	}
+	return result, nil
+}
+
+// hasPageActions reports whether the view has a page the snapshot test
+// renders.
+func hasPageActions(view *GeneratedView) bool {
+	if len(view.Actions) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(view.Actions, func(action string) bool {
+		return action == "index" || action == "show" || action == "new" || action == "edit"
+	})
+}
+
+func hasIndexAction(view *GeneratedView) bool {
+	return len(view.Actions) == 0 || slices.Contains(view.Actions, "index")
+}
+
+var dataTableViewPath = filepath.Join("views", "data_table.templ")
+
+// ensureDataTableComponents writes views/data_table.templ, which index pages
+// render their table with, unless it exists.
+func (g *Generator) ensureDataTableComponents(modulePath string, cssComponents bool) error {
+	if _, err := os.Stat(dataTableViewPath); err == nil {
+		return nil
+	}
+
+	data := struct {
+		ModulePath    string
+		CSSComponents bool
+	}{modulePath, cssComponents}
+	content, err := templates.GetGlobalTemplateService().RenderTemplate("data_table_view.tmpl", data)
+	if err != nil {
+		return errors.WrapTemplateError(err, "render data table", "data_table_view.tmpl")
+	}
+	if err := os.WriteFile(dataTableViewPath, []byte(content), constants.FilePermissionPrivate); err != nil {
+		return fmt.Errorf("failed to write %s: %w", dataTableViewPath, err)
+	}
+	if err := g.formatTemplFile(dataTableViewPath); err != nil {
+		return fmt.Errorf("failed to format %s: %w", dataTableViewPath, err)
+	}
+	return nil
+}
+
+// hasViewTestPackage reports whether the project has internal/viewtest,
+// which projects created before it get from 'andurel upgrade'.
+func (g *Generator) hasViewTestPackage() bool {
+	rootDir, err := g.fileManager.FindGoModRoot()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(filepath.Join(rootDir, "internal", "viewtest", "viewtest.go"))
+	return err == nil
+}
+
+// GenerateShareViewFile renders the public page shown for a resource's share
+// links.
+func (g *Generator) GenerateShareViewFile(view *GeneratedView) (string, error) {
+	service := templates.GetGlobalTemplateService()
+	result, err := service.RenderTemplateWithCustomFunctions("share_resource_view.tmpl", view, viewTemplateFuncs(view))
+	if err != nil {
+		return "", errors.WrapTemplateError(err, "render view", "share_resource_view.tmpl")
+	}
+	return result, nil
+}
+
+// viewTemplateFuncs returns the custom template functions for view-specific
+// operations.
+func viewTemplateFuncs(view *GeneratedView) template.FuncMap {
+	return template.FuncMap{
 		"HasNullFields":    hasNullFields,
 		"UsesViewDataType": usesViewDataType,
 		"ViewDataType":     viewDataType,
@@ -473,6 +696,7 @@ func (g *Generator) GenerateViewFile(view *GeneratedView, withController bool, t
 		"ViewDataLoop":     viewDataLoopAssignment,
 		"ViewDataImports":  viewDataImports,
 		"ViewData":         viewDataDefinition,
+		"ProjectImports":   projectTypeImports,
 		"UsesPackage": func(fields []ViewField, packageName string) bool {
 			for _, field := range fields {
 				if strings.Contains(field.StringConverter, packageName+".") {
@@ -485,6 +709,9 @@ func (g *Generator) GenerateViewFile(view *GeneratedView, withController bool, t
 			return fmt.Sprintf("%s.%s", objRef, field.Name)
 		},
 		"StringDisplay": func(field ViewField, objRef string) string {
+			if field.IsRichText {
+				return fmt.Sprintf("@RichText(%s.%s)", objRef, field.Name)
+			}
 			if field.StringConverter == "" {
 				return fmt.Sprintf(
 					"{ %s.%s }",
@@ -528,6 +755,7 @@ func (g *Generator) GenerateViewFile(view *GeneratedView, withController bool, t
 				fmt.Sprintf("%s.%s", objRef, field.Name),
 			)
 		},
+		"SortKey": sortKey,
 		"HasAction": func(action string) bool {
 			if len(view.Actions) == 0 {
 				return true
@@ -535,19 +763,15 @@ func (g *Generator) GenerateViewFile(view *GeneratedView, withController bool, t
 			return slices.Contains(view.Actions, action)
 		},
 	}
+}
 
-	templateName := templatePrefix + "resource_view_no_controller.tmpl"
-	if withController {
-		templateName = templatePrefix + "resource_view.tmpl"
-	}
-
-	// Use the unified template service with custom functions
-	service := templates.GetGlobalTemplateService()
-	result, err := service.RenderTemplateWithCustomFunctions(templateName, view, customFuncs)
-	if err != nil {
-		return "", errors.WrapTemplateError(err, "render view", templateName)
+// sortKey is the column the index table sorts a field's column by, or ""
+// when the column cannot be sorted.
+func sortKey(field ViewField) string {
+	if strings.HasPrefix(field.GoType, "[]") {
+		return ""
 	}
-	return result, nil
+	return field.DBName
 }
 
 // GenerateInertiaViewFiles renders Inertia page components for a resource.
@@ -700,7 +924,8 @@ func (g *Generator) GenerateViewWithControllerActionsForModel(
 	}
 	pluralName := naming.DeriveTableName(resourceName)
 	modelPluralName := naming.DeriveTableName(modelName)
-	viewPath := filepath.Join("views", namespacePrefix(namespace)+tableName+"_resource.templ")
+	snapshotPrefix := namespacePrefix(namespace) + tableName
+	viewPath := filepath.Join("views", snapshotPrefix+"_resource.templ")
 
 	viewExists := false
 	if _, err := os.Stat(viewPath); err == nil {
@@ -721,11 +946,15 @@ func (g *Generator) GenerateViewWithControllerActionsForModel(
 	// Read lock file to determine extensions and view layer.
 	templatePrefix := ""
 	var lock *layout.AndurelLock
+	var projectTypes []types.TypeOverride
 	if rootDir, err := g.fileManager.FindGoModRoot(); err == nil {
 		if projectLock, err := layout.ReadLockFile(rootDir); err == nil {
 			lock = projectLock
 			templatePrefix = g.templatePrefix(lock)
 		}
+		if projectTypes, err = types.LoadTypeOverrides(rootDir); err != nil {
+			return err
+		}
 	}
 
 	// Override inertia mode from parameter if explicitly set
@@ -744,6 +973,13 @@ func (g *Generator) GenerateViewWithControllerActionsForModel(
 		Namespace:       namespace,
 		ModulePath:      modulePath,
 		Actions:         renderActions,
+		DecimalType:     lock.DecimalType(),
+		NullType: types.ModelNullType(
+			filepath.Join("models", naming.ToSnakeCase(modelName)+".go"),
+			modelName+"Entity",
+			lock.NullType(),
+		),
+		ProjectTypes: projectTypes,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to build view: %w", err)
@@ -782,6 +1018,26 @@ func (g *Generator) GenerateViewWithControllerActionsForModel(
 		return fmt.Errorf("failed to format view file: %w", err)
 	}
 
+	if withController && hasIndexAction(view) {
+		if err := g.ensureDataTableComponents(modulePath, templatePrefix == "css_components_"); err != nil {
+			return err
+		}
+	}
+
+	if hasPageActions(view) && g.hasViewTestPackage() {
+		testContent, err := g.GenerateViewTestFile(view, snapshotPrefix)
+		if err != nil {
+			return fmt.Errorf("failed to render view test: %w", err)
+		}
+		testPath := filepath.Join("views", snapshotPrefix+"_resource_test.go")
+		if err := os.WriteFile(testPath, []byte(testContent), constants.FilePermissionPrivate); err != nil {
+			return fmt.Errorf("failed to write view test: %w", err)
+		}
+		if err := files.FormatGoFile(testPath); err != nil {
+			return fmt.Errorf("failed to format view test: %w", err)
+		}
+	}
+
 	if err := g.runCompileTemplates(); err != nil {
 		return fmt.Errorf("failed to compile templates: %w", err)
 	}
@@ -1,10 +1,13 @@
 package views
 
 import (
+	"go/parser"
+	"go/token"
 	"strings"
 	"testing"
 
 	"github.com/mbvlabs/andurel/generator/internal/catalog"
+	"github.com/mbvlabs/andurel/generator/internal/types"
 )
 
 func TestBuildViewField_StringConverter(t *testing.T) {
@@ -108,12 +111,12 @@ func TestBuildViewField_StringConverter(t *testing.T) {
 			expectedInputType:       "text",
 		},
 		{
-			name:                    "[]int32 array uses fmt.Sprintf with %v",
+			name:                    "[]int32 array joins elements with commas",
 			columnName:              "scores",
 			dataType:                "integer[]",
 			isNullable:              false,
 			expectedGoType:          "[]int32",
-			expectedStringConverter: `fmt.Sprintf("%v", %s)`,
+			expectedStringConverter: `strings.Join(strings.Fields(strings.Trim(fmt.Sprint(%s), "[]")), ", ")`,
 			expectedInputType:       "text",
 		},
 		{
@@ -125,6 +128,15 @@ func TestBuildViewField_StringConverter(t *testing.T) {
 			expectedStringConverter: `strings.Join(%s, ", ")`,
 			expectedInputType:       "text",
 		},
+		{
+			name:                    "[]uuid.UUID array joins elements with commas",
+			columnName:              "reviewer_ids",
+			dataType:                "uuid[]",
+			isNullable:              true,
+			expectedGoType:          "[]uuid.UUID",
+			expectedStringConverter: `strings.Join(strings.Fields(strings.Trim(fmt.Sprint(%s), "[]")), ", ")`,
+			expectedInputType:       "text",
+		},
 	}
 
 	for _, tt := range tests {
@@ -157,6 +169,98 @@ func TestBuildViewField_StringConverter(t *testing.T) {
 	}
 }
 
+func TestBuildViewField_ProjectTypes(t *testing.T) {
+	generator := NewGenerator("postgresql")
+	generator.typeMapper.NullType = "pointer"
+	generator.typeMapper.ProjectTypes = []types.TypeOverride{
+		{DatabaseType: "inet", GoType: "netip.Addr", Package: "net/netip", Format: "%s.String()"},
+		{DatabaseType: "citext", GoType: "Email"},
+	}
+
+	tests := []struct {
+		col                     *catalog.Column
+		expectedGoType          string
+		expectedStringConverter string
+	}{
+		{&catalog.Column{Name: "address", DataType: "inet"}, "netip.Addr", "%s.String()"},
+		{&catalog.Column{Name: "fallback", DataType: "inet", IsNullable: true}, "*netip.Addr", `func() string { if %s == nil { return "" }; return (*%s).String() }()`},
+		{&catalog.Column{Name: "contact", DataType: "citext"}, "models.Email", `fmt.Sprintf("%v", %s)`},
+	}
+	var fields []ViewField
+	for _, tt := range tests {
+		field, err := generator.buildViewField(tt.col)
+		if err != nil {
+			t.Fatalf("buildViewField(%s) returned error: %v", tt.col.Name, err)
+		}
+		if field.GoType != tt.expectedGoType || field.StringConverter != tt.expectedStringConverter {
+			t.Errorf("%s = %q, %q, want %q, %q", tt.col.Name, field.GoType, field.StringConverter, tt.expectedGoType, tt.expectedStringConverter)
+		}
+		if field.InputType != "text" || field.GoFormType != "string" {
+			t.Errorf("%s input = %q/%q, want text/string", tt.col.Name, field.InputType, field.GoFormType)
+		}
+		fields = append(fields, field)
+	}
+
+	if got := projectTypeImports(fields, true); got != "" {
+		t.Errorf("projectTypeImports without a view data struct = %q, want none", got)
+	}
+	fields = append(fields, ViewField{Name: "Note", GoType: "*string"})
+	if got := projectTypeImports(fields, true); got != "\"net/netip\"\n\t" {
+		t.Errorf("projectTypeImports with a view data struct = %q, want the net/netip import once", got)
+	}
+	fields[0].StringConverter = "netip.Addr.String(%s)"
+	if got := projectTypeImports(fields, false); got != "\"net/netip\"\n\t" {
+		t.Errorf("projectTypeImports with a converter using netip = %q, want the net/netip import once", got)
+	}
+}
+
+func TestBuildViewField_NullablePointers(t *testing.T) {
+	tests := []struct {
+		name                    string
+		dataType                string
+		expectedGoType          string
+		expectedStringConverter string
+		expectedGoFormType      string
+	}{
+		{
+			name:                    "pointer int32 renders nil as empty",
+			dataType:                "integer",
+			expectedGoType:          "*int32",
+			expectedStringConverter: `func() string { if %s == nil { return "" }; return fmt.Sprintf("%d", *%s) }()`,
+			expectedGoFormType:      "string",
+		},
+		{
+			name:                    "pointer uuid renders nil as empty",
+			dataType:                "uuid",
+			expectedGoType:          "*uuid.UUID",
+			expectedStringConverter: `func() string { if %s == nil { return "" }; return %s.String() }()`,
+			expectedGoFormType:      "string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewGenerator("postgresql")
+			generator.typeMapper.NullType = "pointer"
+
+			field, err := generator.buildViewField(&catalog.Column{Name: "value", DataType: tt.dataType, IsNullable: true})
+			if err != nil {
+				t.Fatalf("buildViewField returned error: %v", err)
+			}
+
+			if field.GoType != tt.expectedGoType {
+				t.Errorf("GoType = %q, want %q", field.GoType, tt.expectedGoType)
+			}
+			if field.StringConverter != tt.expectedStringConverter {
+				t.Errorf("StringConverter = %q, want %q", field.StringConverter, tt.expectedStringConverter)
+			}
+			if field.GoFormType != tt.expectedGoFormType {
+				t.Errorf("GoFormType = %q, want %q", field.GoFormType, tt.expectedGoFormType)
+			}
+		})
+	}
+}
+
 func TestBuildViewField_UnknownTypeHasConverter(t *testing.T) {
 	generator := NewGenerator("postgresql")
 
@@ -179,6 +283,201 @@ func TestBuildViewField_UnknownTypeHasConverter(t *testing.T) {
 	}
 }
 
+func TestBuildViewField_RichTextAnnotation(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	body := catalog.NewColumn("body", "text").SetComment("andurel:richtext")
+	field, err := generator.buildViewField(body)
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if !field.IsRichText || field.StringConverter != "htmlsanitize.Text(%s)" || field.InputType != "text" {
+		t.Fatalf("annotated text column = %#v", field)
+	}
+
+	count := catalog.NewColumn("count", "integer").SetComment("andurel:richtext")
+	field, err = generator.buildViewField(count)
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.IsRichText {
+		t.Fatal("only string columns can be rich text")
+	}
+}
+
+func TestBuildViewField_MoneyColumns(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	price := catalog.NewColumn("price_cents", "bigint")
+	price.IsNullable = false
+	field, err := generator.buildViewField(price)
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.GoType != "money.Money" || field.StringConverter != "money.Format(ctx, %s)" || field.InputType != "text" {
+		t.Fatalf("price_cents = %#v", field)
+	}
+
+	field, err = generator.buildViewField(catalog.NewColumn("fee_cents", "numeric"))
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.GoType != "*money.Money" || field.StringConverter != "money.FormatOptional(ctx, %s)" {
+		t.Fatalf("fee_cents = %#v", field)
+	}
+}
+
+func TestBuildViewField_DecimalColumns(t *testing.T) {
+	generator := NewGenerator("postgresql")
+	generator.typeMapper.DecimalType = "decimal"
+
+	rate := catalog.NewColumn("rate", "numeric(10,4)")
+	rate.IsNullable = false
+	field, err := generator.buildViewField(rate)
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.GoType != "decimal.Decimal" || field.StringConverter != "%s.String()" || field.GoFormType != "string" {
+		t.Fatalf("rate = %#v", field)
+	}
+
+	field, err = generator.buildViewField(catalog.NewColumn("cap", "numeric"))
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.GoType != "*decimal.Decimal" || !strings.Contains(field.StringConverter, "== nil") {
+		t.Fatalf("cap = %#v", field)
+	}
+
+	fields := []ViewField{field, {Name: "Note", GoType: "sql.NullString"}}
+	if imports := viewDataImports(fields); !strings.Contains(imports, `"github.com/shopspring/decimal"`) {
+		t.Fatalf("viewDataImports() = %q, want decimal import", imports)
+	}
+}
+
+func TestGenerateViewFile_MoneyFieldsFormatPerLocale(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	view := &GeneratedView{
+		ResourceName: "Product",
+		PluralName:   "products",
+		ModulePath:   "github.com/example/myapp",
+		Fields: []ViewField{
+			{
+				Name:            "PriceCents",
+				GoType:          "money.Money",
+				DisplayName:     "Price Cents",
+				InputType:       "text",
+				StringConverter: "money.Format(ctx, %s)",
+				DBName:          "price_cents",
+				CamelCase:       "priceCents",
+			},
+		},
+	}
+
+	for _, prefix := range []string{"", "css_components_"} {
+		for _, withController := range []bool{true, false} {
+			content, err := generator.GenerateViewFile(view, withController, prefix)
+			if err != nil {
+				t.Fatalf("GenerateViewFile(%q, %v) returned error: %v", prefix, withController, err)
+			}
+			for _, want := range []string{
+				`"github.com/example/myapp/internal/money"`,
+				"{ money.Format(ctx, product.PriceCents) }",
+			} {
+				if !strings.Contains(content, want) {
+					t.Fatalf("GenerateViewFile(%q, %v) should contain %q, got:\n%s", prefix, withController, want, content)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateViewFile_IntervalFieldsUseDurationInput(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	col := catalog.NewColumn("length", "interval")
+	col.IsNullable = false
+	field, err := generator.buildViewField(col)
+	if err != nil {
+		t.Fatalf("buildViewField returned error: %v", err)
+	}
+	if field.GoType != "interval.Duration" || !field.IsDuration || field.StringConverter != "interval.Format(%s)" {
+		t.Fatalf("length = %#v", field)
+	}
+
+	view := &GeneratedView{
+		ResourceName: "Shift",
+		PluralName:   "shifts",
+		ModulePath:   "github.com/example/myapp",
+		Fields:       []ViewField{field},
+	}
+
+	for _, prefix := range []string{"", "css_components_"} {
+		for _, withController := range []bool{true, false} {
+			content, err := generator.GenerateViewFile(view, withController, prefix)
+			if err != nil {
+				t.Fatalf("GenerateViewFile(%q, %v) returned error: %v", prefix, withController, err)
+			}
+			for _, want := range []string{
+				`"github.com/example/myapp/internal/interval"`,
+				"{ interval.Format(shift.Length) }",
+				`@DurationInput("length", "", templ.Attributes{`,
+				`@DurationInput("length", interval.Format(`,
+			} {
+				if !strings.Contains(content, want) {
+					t.Fatalf("GenerateViewFile(%q, %v) should contain %q, got:\n%s", prefix, withController, want, content)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateViewFile_RichTextFieldsRenderSanitized(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	view := &GeneratedView{
+		ResourceName: "Article",
+		PluralName:   "articles",
+		ModulePath:   "github.com/example/myapp",
+		Fields: []ViewField{
+			{
+				Name:            "Body",
+				GoType:          "string",
+				DisplayName:     "Body",
+				InputType:       "text",
+				StringConverter: "htmlsanitize.Text(%s)",
+				DBName:          "body",
+				CamelCase:       "body",
+				IsRichText:      true,
+			},
+		},
+	}
+
+	for _, prefix := range []string{"", "css_components_"} {
+		for _, withController := range []bool{true, false} {
+			content, err := generator.GenerateViewFile(view, withController, prefix)
+			if err != nil {
+				t.Fatalf("GenerateViewFile(%q, %v) returned error: %v", prefix, withController, err)
+			}
+			for _, want := range []string{
+				`"github.com/example/myapp/internal/htmlsanitize"`,
+				"{ htmlsanitize.Text(article.Body) }",
+				"@RichText(",
+				`@RichTextEditor("body", "")`,
+				`@RichTextEditor("body", `,
+			} {
+				if !strings.Contains(content, want) {
+					t.Fatalf("GenerateViewFile(%q, %v) should contain %q, got:\n%s", prefix, withController, want, content)
+				}
+			}
+			if strings.Contains(content, `data-bind="body"`) {
+				t.Fatalf("rich text field should not render a plain input, got:\n%s", content)
+			}
+		}
+	}
+}
+
 func TestViewDataLoopAssignment(t *testing.T) {
 	t.Run("plain loop opens a templ control block", func(t *testing.T) {
 		got := viewDataLoopAssignment("", "Article", "article", false)
@@ -609,3 +908,120 @@ func assertContainsAll(t *testing.T, content string, values []string) {
 		}
 	}
 }
+
+func TestBuild_HasLocationNeedsGeocodedCoordinates(t *testing.T) {
+	tests := map[string]struct {
+		comment string
+		want    bool
+	}{
+		"geocoded":  {comment: "andurel:geocoded", want: true},
+		"plain":     {comment: "", want: false},
+		"other tag": {comment: "andurel:richtext", want: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			table := catalog.NewTable("", "stores")
+			id := catalog.NewColumn("id", "uuid")
+			id.IsPrimaryKey = true
+			for _, col := range []*catalog.Column{
+				id,
+				catalog.NewColumn("latitude", "double precision").SetComment(tt.comment),
+				catalog.NewColumn("longitude", "double precision").SetComment(tt.comment),
+			} {
+				if err := table.AddColumn(col); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cat := catalog.NewCatalog("public")
+			if err := cat.AddTable("", table); err != nil {
+				t.Fatal(err)
+			}
+
+			view, err := NewGenerator("postgresql").Build(cat, Config{
+				ResourceName: "Store",
+				PluralName:   "stores",
+				TableName:    "stores",
+			})
+			if err != nil {
+				t.Fatalf("Build returned error: %v", err)
+			}
+			if view.HasLocation != tt.want {
+				t.Fatalf("HasLocation = %v, want %v", view.HasLocation, tt.want)
+			}
+			for _, field := range view.Fields {
+				if field.IsSystemField != (tt.comment == "andurel:geocoded" || field.DBName == "id") {
+					t.Fatalf("%s IsSystemField = %v", field.DBName, field.IsSystemField)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateViewFile_LocationRendersMapOnShowPage(t *testing.T) {
+	view := &GeneratedView{
+		ResourceName: "Store",
+		PluralName:   "stores",
+		ModulePath:   "github.com/example/myapp",
+		HasLocation:  true,
+	}
+
+	for _, prefix := range []string{"", "css_components_"} {
+		content, err := NewGenerator("postgresql").GenerateViewFile(view, true, prefix)
+		if err != nil {
+			t.Fatalf("GenerateViewFile(%q) returned error: %v", prefix, err)
+		}
+		if !strings.Contains(content, `@MapEmbed(`) || !strings.Contains(content, `.Item.Latitude, `) {
+			t.Fatalf("GenerateViewFile(%q) should embed a map, got:\n%s", prefix, content)
+		}
+	}
+}
+
+func TestGenerateViewTestFile_SnapshotsRenderedPages(t *testing.T) {
+	generator := NewGenerator("postgresql")
+
+	view := &GeneratedView{
+		ResourceName:    "Article",
+		EntityName:      "ArticleEntity",
+		PluralName:      "articles",
+		Namespace:       "admin",
+		NamespacePascal: "Admin",
+		ModulePath:      "github.com/example/myapp",
+		Actions:         []string{"index", "show", "create"},
+		Fields: []ViewField{
+			{Name: "Title", GoType: "string", DisplayName: "Title"},
+			{Name: "Views", GoType: "int32", DisplayName: "Views"},
+			{Name: "CreatedAt", GoType: "time.Time", DisplayName: "Created At", IsSystemField: true},
+		},
+	}
+
+	content, err := generator.GenerateViewTestFile(view, "admin_articles")
+	if err != nil {
+		t.Fatalf("GenerateViewTestFile returned error: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "admin_articles_resource_test.go", content, 0); err != nil {
+		t.Fatalf("generated test does not parse: %v\n%s", err, content)
+	}
+
+	for _, want := range []string{
+		`"github.com/example/myapp/internal/viewtest"`,
+		"func TestAdminArticleViews(t *testing.T)",
+		`Title: "Sample Title",`,
+		`"admin_articles_index": AdminArticleIndex{Items: []models.ArticleEntity{item}},`,
+		`"admin_articles_show":  AdminArticleShow{Item: item},`,
+		"viewtest.Snapshot(t, name, page.Page(), page.PageFragment())",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated test missing %q:\n%s", want, content)
+		}
+	}
+	for _, unwanted := range []string{"Views:", "CreatedAt:", "AdminArticleNew", "AdminArticleEdit"} {
+		if strings.Contains(content, unwanted) {
+			t.Errorf("generated test should not contain %q:\n%s", unwanted, content)
+		}
+	}
+
+	view.Actions = []string{"create", "update"}
+	if hasPageActions(view) {
+		t.Error("hasPageActions should be false without index, show, new or edit")
+	}
+}
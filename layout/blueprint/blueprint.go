@@ -2,7 +2,10 @@
 // support additive merges from multiple extensions without conflicts.
 package blueprint
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+)
 
 // Blueprint holds all structured configuration for a scaffold project. Each
 // section supports additive operations that maintain uniqueness and ordering.
@@ -30,6 +33,9 @@ type Blueprint struct {
 
 	// Cookies section for router/cookies package
 	Cookies CookiesSection
+
+	// Middleware section for the global middleware in router/router.go
+	Middleware MiddlewareSection
 }
 
 // ControllerSection holds controller-related configuration.
@@ -166,6 +172,50 @@ type CookiesSection struct {
 	GetSessionCode    string
 }
 
+// MiddlewareSection holds the global Echo middleware of router/router.go.
+type MiddlewareSection struct {
+	// Import paths needed by middleware expressions
+	Imports *OrderedSet
+
+	// Middleware entries, rendered by Sorted
+	Middleware []Middleware
+}
+
+// Priorities of the base middleware. Extensions pick a priority between two
+// of them to run between those middleware, e.g. MiddlewarePrioritySession - 10
+// to rate limit before the session is loaded.
+const (
+	MiddlewarePriorityTracing     = 100
+	MiddlewarePriorityLogging     = 200
+	MiddlewarePriorityBodyLimit   = 250
+	MiddlewarePriorityTimeout     = 270
+	MiddlewarePrioritySession     = 300
+	MiddlewarePriorityRequestMeta = 400
+	MiddlewarePriorityCurrentUser = 500
+	MiddlewarePriorityFrontend    = 600
+	MiddlewarePriorityCORS        = 700
+	MiddlewarePriorityCSRF        = 800
+	MiddlewarePriorityRecover     = 900
+)
+
+// Middleware represents a global middleware entry. Entries run in ascending
+// Priority, and entries with equal priority run in registration order.
+type Middleware struct {
+	// Name identifies the entry for ordering constraints (e.g., "session")
+	Name string
+	// Expression evaluates to an echo.MiddlewareFunc inside
+	// SetupGlobalMiddleware (e.g., "session.Middleware(sessionStore)")
+	Expression string
+	// Priority positions the entry; lower runs first
+	Priority int
+	// After names entries that must run before this one
+	After []string
+	// Before names entries that must run after this one
+	Before []string
+	// Order for deterministic rendering
+	Order int
+}
+
 // Constant represents a const declaration
 type Constant struct {
 	Name  string
@@ -331,6 +381,10 @@ func New() *Blueprint {
 			AppFields: make([]Field, 0),
 			Functions: make([]Function, 0),
 		},
+		Middleware: MiddlewareSection{
+			Imports:    NewOrderedSet(),
+			Middleware: make([]Middleware, 0),
+		},
 	}
 }
 
@@ -507,3 +561,44 @@ func (cs *CookiesSection) SortedFunctions() []Function {
 	})
 	return functions
 }
+
+// Sorted returns the middleware entries by priority and registration order.
+// It fails when the order breaks an entry's After or Before constraint.
+// Constraints naming an unregistered entry are ignored, so extensions can
+// order themselves against optional middleware.
+func (ms *MiddlewareSection) Sorted() ([]Middleware, error) {
+	middleware := make([]Middleware, len(ms.Middleware))
+	copy(middleware, ms.Middleware)
+	sort.SliceStable(middleware, func(i, j int) bool {
+		if middleware[i].Priority != middleware[j].Priority {
+			return middleware[i].Priority < middleware[j].Priority
+		}
+		return middleware[i].Order < middleware[j].Order
+	})
+
+	positions := make(map[string]int, len(middleware))
+	for i, m := range middleware {
+		positions[m.Name] = i
+	}
+
+	for i, m := range middleware {
+		for _, name := range m.After {
+			if j, ok := positions[name]; ok && j > i {
+				return nil, fmt.Errorf(
+					"blueprint: middleware %q must run after %q, but its priority %d is below %d",
+					m.Name, name, m.Priority, middleware[j].Priority,
+				)
+			}
+		}
+		for _, name := range m.Before {
+			if j, ok := positions[name]; ok && j < i {
+				return nil, fmt.Errorf(
+					"blueprint: middleware %q must run before %q, but its priority %d is above %d",
+					m.Name, name, m.Priority, middleware[j].Priority,
+				)
+			}
+		}
+	}
+
+	return middleware, nil
+}
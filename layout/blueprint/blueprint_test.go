@@ -193,3 +193,81 @@ func TestMigrationSection_SortedMigrations(t *testing.T) {
 		t.Errorf("expected first migration to be '001_first', got '%s'", sorted[0].Name)
 	}
 }
+
+func TestMiddlewareSection_Sorted(t *testing.T) {
+	ms := blueprint.MiddlewareSection{
+		Middleware: []blueprint.Middleware{
+			{Name: "recover", Expression: "middleware.Recover()", Priority: blueprint.MiddlewarePriorityRecover, Order: 0},
+			{Name: "session", Expression: "session.Middleware(store)", Priority: blueprint.MiddlewarePrioritySession, Order: 1},
+			{Name: "rate-limit", Expression: "ratelimit.Middleware()", Priority: blueprint.MiddlewarePrioritySession - 10, Order: 3},
+			{Name: "audit", Expression: "audit.Middleware()", Priority: blueprint.MiddlewarePrioritySession - 10, Order: 2},
+		},
+	}
+
+	sorted, err := ms.Sorted()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var names []string
+	for _, m := range sorted {
+		names = append(names, m.Name)
+	}
+	want := []string{"audit", "rate-limit", "session", "recover"}
+	if len(names) != len(want) {
+		t.Fatalf("expected %v, got %v", want, names)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, names)
+		}
+	}
+}
+
+func TestMiddlewareSection_SortedConstraints(t *testing.T) {
+	tests := []struct {
+		name       string
+		middleware []blueprint.Middleware
+		wantErr    bool
+	}{
+		{
+			name: "after satisfied",
+			middleware: []blueprint.Middleware{
+				{Name: "session", Priority: 300},
+				{Name: "csrf", Priority: 800, After: []string{"session"}},
+			},
+		},
+		{
+			name: "after violated",
+			middleware: []blueprint.Middleware{
+				{Name: "session", Priority: 300},
+				{Name: "rate-limit", Priority: 200, After: []string{"session"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "before violated",
+			middleware: []blueprint.Middleware{
+				{Name: "session", Priority: 300},
+				{Name: "rate-limit", Priority: 400, Before: []string{"session"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown names are ignored",
+			middleware: []blueprint.Middleware{
+				{Name: "rate-limit", Priority: 200, After: []string{"auth"}, Before: []string{"inertia"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := blueprint.MiddlewareSection{Middleware: tt.middleware}
+			_, err := ms.Sorted()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ type Builder struct {
 	nextCookiesConstantOrder      int
 	nextCookiesAppFieldOrder      int
 	nextCookiesFunctionOrder      int
+	nextMiddlewareOrder           int
 	// Track current registration function being built
 	currentRegistrationFunction *RegistrationFunction
 }
@@ -633,6 +634,42 @@ func (b *Builder) SetCookiesGetSessionCode(code string) *Builder {
 	return b
 }
 
+// AddMiddlewareImport adds an import path to router/router.go for
+// middleware expressions.
+func (b *Builder) AddMiddlewareImport(importPath string) *Builder {
+	if importPath == "" {
+		return b
+	}
+
+	if b.bp.Middleware.Imports == nil {
+		b.bp.Middleware.Imports = NewOrderedSet()
+	}
+	b.bp.Middleware.Imports.Add(importPath)
+	return b
+}
+
+// AddMiddleware registers a global middleware. A later entry with the same
+// name replaces the expression, priority and constraints of the first one
+// but keeps its registration order.
+func (b *Builder) AddMiddleware(middleware Middleware) *Builder {
+	if middleware.Name == "" || middleware.Expression == "" {
+		return b
+	}
+
+	for i, existing := range b.bp.Middleware.Middleware {
+		if existing.Name == middleware.Name {
+			middleware.Order = existing.Order
+			b.bp.Middleware.Middleware[i] = middleware
+			return b
+		}
+	}
+
+	middleware.Order = b.nextMiddlewareOrder
+	b.bp.Middleware.Middleware = append(b.bp.Middleware.Middleware, middleware)
+	b.nextMiddlewareOrder++
+	return b
+}
+
 // Merge combines another blueprint into this one, maintaining uniqueness and
 // order. Items from the other blueprint are added after existing items.
 func (b *Builder) Merge(other *Blueprint) error {
@@ -739,5 +776,14 @@ func (b *Builder) Merge(other *Blueprint) error {
 		b.SetCookiesGetSessionCode(other.Cookies.GetSessionCode)
 	}
 
+	// Merge middleware section
+	if b.bp.Middleware.Imports == nil {
+		b.bp.Middleware.Imports = NewOrderedSet()
+	}
+	b.bp.Middleware.Imports.Merge(other.Middleware.Imports)
+	for _, m := range other.Middleware.Middleware {
+		b.AddMiddleware(m)
+	}
+
 	return nil
 }
@@ -692,3 +692,65 @@ func TestBuilder_MergeRouteRegistrationsAndCookies(t *testing.T) {
 		t.Fatalf("GetSessionCode = %q", got)
 	}
 }
+
+func TestBuilder_AddMiddleware(t *testing.T) {
+	builder := blueprint.NewBuilder(nil)
+
+	builder.AddMiddlewareImport("example.com/app/ratelimit").
+		AddMiddlewareImport("example.com/app/ratelimit").
+		AddMiddleware(blueprint.Middleware{Name: "session", Expression: "session.Middleware(store)", Priority: blueprint.MiddlewarePrioritySession}).
+		AddMiddleware(blueprint.Middleware{Name: "rate-limit", Expression: "ratelimit.Middleware()", Priority: blueprint.MiddlewarePrioritySession}).
+		AddMiddleware(blueprint.Middleware{Name: "session", Expression: "session.Middleware(otherStore)", Priority: blueprint.MiddlewarePrioritySession}).
+		AddMiddleware(blueprint.Middleware{Name: "empty"})
+
+	bp := builder.Blueprint()
+	if bp.Middleware.Imports.Len() != 1 {
+		t.Errorf("expected 1 middleware import, got %d", bp.Middleware.Imports.Len())
+	}
+	if len(bp.Middleware.Middleware) != 2 {
+		t.Fatalf("expected 2 middleware, got %d", len(bp.Middleware.Middleware))
+	}
+
+	sorted, err := bp.Middleware.Sorted()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sorted[0].Name != "session" || sorted[0].Expression != "session.Middleware(otherStore)" {
+		t.Errorf("expected replaced session middleware first, got %+v", sorted[0])
+	}
+	if sorted[1].Name != "rate-limit" {
+		t.Errorf("expected rate-limit second, got %q", sorted[1].Name)
+	}
+}
+
+func TestBuilder_MergeMiddleware(t *testing.T) {
+	builder := blueprint.NewBuilder(nil)
+	builder.AddMiddleware(blueprint.Middleware{Name: "session", Expression: "session.Middleware(store)", Priority: blueprint.MiddlewarePrioritySession})
+
+	other := blueprint.NewBuilder(nil).
+		AddMiddlewareImport("example.com/app/ratelimit").
+		AddMiddleware(blueprint.Middleware{
+			Name:       "rate-limit",
+			Expression: "ratelimit.Middleware()",
+			Priority:   blueprint.MiddlewarePrioritySession - 10,
+			Before:     []string{"session"},
+		}).
+		Blueprint()
+
+	if err := builder.Merge(other); err != nil {
+		t.Fatalf("unexpected merge error: %v", err)
+	}
+
+	bp := builder.Blueprint()
+	if bp.Middleware.Imports.Len() != 1 {
+		t.Errorf("expected 1 middleware import, got %d", bp.Middleware.Imports.Len())
+	}
+
+	sorted, err := bp.Middleware.Sorted()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sorted) != 2 || sorted[0].Name != "rate-limit" {
+		t.Errorf("expected rate-limit before session, got %+v", sorted)
+	}
+}
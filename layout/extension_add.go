@@ -58,7 +58,7 @@ func LoadProjectContext(rootDir string) (*TemplateData, *AndurelLock, error) {
 		Inertia:              lock.ScaffoldConfig.Inertia,
 	}
 
-	bp := initializeBlueprint(moduleName)
+	bp := initializeBlueprint(moduleName, lock.ScaffoldConfig.Inertia)
 	td.SetBlueprint(bp)
 
 	if err := registerBuiltinExtensions(); err != nil {
@@ -168,6 +168,40 @@ func TestAwsSesApply(t *testing.T) {
 	}
 }
 
+func TestGeocodingApply(t *testing.T) {
+	data := &testTemplateData{}
+	var rendered []string
+	ctx := &Context{
+		Data: data,
+		ProcessTemplate: func(templateFile, targetPath string, tmplData TemplateData) error {
+			rendered = append(rendered, templateFile+"=>"+targetPath)
+			return nil
+		},
+	}
+
+	if err := (Geocoding{}).Apply(ctx); err != nil {
+		t.Fatalf("Geocoding Apply failed: %v", err)
+	}
+
+	bp := data.bp
+	if len(bp.Config.Fields) != 1 || bp.Config.Fields[0].Name != "Geocoding" {
+		t.Fatalf("expected Geocoding config field, got %+v", bp.Config.Fields)
+	}
+	if len(bp.Config.EnvVars) != 3 || bp.Config.EnvVars[0].DefaultValue != "nominatim" {
+		t.Fatalf("expected geocoding env vars, got %+v", bp.Config.EnvVars)
+	}
+	for _, want := range []string{
+		"templates/geocoding/clients_geocoding_geocoding.tmpl=>clients/geocoding/geocoding.go",
+		"templates/geocoding/clients_geocoding_nominatim.tmpl=>clients/geocoding/nominatim.go",
+		"templates/geocoding/clients_geocoding_google.tmpl=>clients/geocoding/google.go",
+		"templates/geocoding/config_geocoding.tmpl=>config/geocoding.go",
+	} {
+		if !slices.Contains(rendered, want) {
+			t.Fatalf("expected render call %q in %v", want, rendered)
+		}
+	}
+}
+
 func TestCssComponentsApply(t *testing.T) {
 	var rendered []string
 	ctx := &Context{
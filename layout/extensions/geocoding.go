@@ -0,0 +1,54 @@
+package extensions
+
+import "fmt"
+
+// Geocoding adds a geocoding client with Nominatim and Google drivers.
+type Geocoding struct{}
+
+// Name returns the extension name used in lock files and CLI flags.
+func (e Geocoding) Name() string {
+	return "geocoding"
+}
+
+// Apply adds geocoding configuration and client files.
+func (e Geocoding) Apply(ctx *Context) error {
+	if ctx == nil || ctx.Data == nil {
+		return fmt.Errorf("geocoding: context or data is nil")
+	}
+
+	builder := ctx.Builder()
+	builder.AddConfigField("Geocoding", "geocoding")
+
+	builder.AddEnvVar("GEOCODING_DRIVER", "Geocoding", "nominatim")
+	builder.AddEnvVar("GEOCODING_NOMINATIM_URL", "Geocoding", "https://nominatim.openstreetmap.org")
+	builder.AddEnvVar("GOOGLE_MAPS_API_KEY", "Geocoding", "")
+
+	if err := e.renderTemplates(ctx); err != nil {
+		return fmt.Errorf("geocoding: failed to render templates: %w", err)
+	}
+
+	return nil
+}
+
+// Dependencies returns extension names that must be applied first.
+func (e Geocoding) Dependencies() []string {
+	return nil
+}
+
+func (e Geocoding) renderTemplates(ctx *Context) error {
+	templates := map[string]string{
+		"clients_geocoding_geocoding.tmpl": "clients/geocoding/geocoding.go",
+		"clients_geocoding_nominatim.tmpl": "clients/geocoding/nominatim.go",
+		"clients_geocoding_google.tmpl":    "clients/geocoding/google.go",
+		"config_geocoding.tmpl":            "config/geocoding.go",
+	}
+
+	for tmpl, target := range templates {
+		templatePath := fmt.Sprintf("templates/geocoding/%s", tmpl)
+		if err := ctx.ProcessTemplate(templatePath, target, nil); err != nil {
+			return fmt.Errorf("failed to process %s: %w", tmpl, err)
+		}
+	}
+
+	return nil
+}
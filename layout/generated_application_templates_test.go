@@ -88,7 +88,7 @@ func TestGeneratedAuthenticationTemplates(t *testing.T) {
 	authentication := readGeneratedApplicationTemplate(t, "services_authentication.tmpl")
 	for _, want := range []string{
 		"verifyPasswordWithPeppers",
-		"models.HashPassword(data.Password, i.pepper)",
+		"models.HashPassword(data.Password, i.pepper, i.passwordParams)",
 		"models.User.Update(ctx, i.db.Executor()",
 		"persist password rehash",
 	} {
@@ -107,13 +107,116 @@ func TestGeneratedAuthenticationTemplates(t *testing.T) {
 	}
 }
 
+func TestGeneratedCurrentUserLoader(t *testing.T) {
+	currentUser := readGeneratedApplicationTemplate(t, "router_auth_current_user.tmpl")
+	for _, want := range []string{
+		"func CurrentUser(ctx context.Context) (models.UserEntity, error)",
+		"cu.once.Do(func() {",
+		"errors.Is(cu.err, models.ErrNotFound)",
+	} {
+		if !strings.Contains(currentUser, want) {
+			t.Errorf("router_auth_current_user.tmpl missing %q", want)
+		}
+	}
+
+	authMiddleware := readGeneratedApplicationTemplate(t, "router_middleware_auth.tmpl")
+	for _, want := range []string{
+		"func LoadCurrentUser(db storage.Pool) echo.MiddlewareFunc",
+		"models.User.Find(ctx, db.Executor(), appCookie.UserID)",
+		"errors.Is(err, auth.ErrUnauthenticated)",
+	} {
+		if !strings.Contains(authMiddleware, want) {
+			t.Errorf("router_middleware_auth.tmpl missing %q", want)
+		}
+	}
+
+	middleware, err := initializeBlueprint("example.com/app", "").Middleware.Sorted()
+	if err != nil {
+		t.Fatalf("sort middleware: %v", err)
+	}
+	var expressions []string
+	for _, m := range middleware {
+		expressions = append(expressions, m.Expression)
+	}
+	if !strings.Contains(strings.Join(expressions, ","), "middleware.RegisterRequestMeta,middleware.LoadCurrentUser(db)") {
+		t.Errorf("the current user is not loaded after request meta: %v", expressions)
+	}
+}
+
+func TestGeneratedRichTextTemplates(t *testing.T) {
+	sanitize := readGeneratedApplicationTemplate(t, "framework_elements_htmlsanitize_htmlsanitize.tmpl")
+	for _, want := range []string{
+		"p := bluemonday.NewPolicy()",
+		`p.AllowAttrs("href").OnElements("a")`,
+		"return templ.Raw(Sanitize(s))",
+	} {
+		if !strings.Contains(sanitize, want) {
+			t.Errorf("framework_elements_htmlsanitize_htmlsanitize.tmpl missing %q", want)
+		}
+	}
+
+	view := readGeneratedApplicationTemplate(t, "views_rich_text.tmpl")
+	for _, want := range []string{
+		"templ RichTextEditor(signal string, value string)",
+		`routes.Script.URL("richtext.js")`,
+		"data-bind={ signal } value={ htmlsanitize.Sanitize(value) }",
+		"templ RichText(value string)",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("views_rich_text.tmpl missing %q", want)
+		}
+	}
+	if strings.Contains(view, "templ.Raw(") {
+		t.Error("views_rich_text.tmpl must render HTML through htmlsanitize")
+	}
+}
+
+func TestGeneratedCodesTemplates(t *testing.T) {
+	qr := readGeneratedApplicationTemplate(t, "framework_elements_codes_qr.tmpl")
+	for _, want := range []string{
+		"func QR(content string, opts ...Option) (SVG, error)",
+		"return nil, ErrTooLong",
+		"m.drawFormatBits(level, best)",
+	} {
+		if !strings.Contains(qr, want) {
+			t.Errorf("framework_elements_codes_qr.tmpl missing %q", want)
+		}
+	}
+
+	code128 := readGeneratedApplicationTemplate(t, "framework_elements_codes_code128.tmpl")
+	if !strings.Contains(code128, "func Code128(content string, opts ...Option) (SVG, error)") {
+		t.Error("framework_elements_codes_code128.tmpl missing Code128")
+	}
+
+	svg := readGeneratedApplicationTemplate(t, "framework_elements_codes_svg.tmpl")
+	for _, want := range []string{
+		"func (s SVG) DataURI() string",
+		"func Render(etx *echo.Context, s SVG) error",
+		`html.EscapeString(o.label)`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("framework_elements_codes_svg.tmpl missing %q", want)
+		}
+	}
+
+	view := readGeneratedApplicationTemplate(t, "views_codes.tmpl")
+	for _, want := range []string{
+		"templ QRCode(content string, opts ...codes.Option)",
+		"templ Barcode(content string, opts ...codes.Option)",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("views_codes.tmpl missing %q", want)
+		}
+	}
+}
+
 func TestGeneratedRateLimiterAndLifecycleTemplates(t *testing.T) {
 	rateLimiter := readGeneratedApplicationTemplate(t, "router_middleware_auth.tmpl")
 	for _, want := range []string{
 		"MaximumSize:      1000",
 		"ExpiryCreating[string, int32](10 * time.Minute)",
 		"cache.Compute(ip",
-		"if hits >= limit",
+		"if hits >= maxHits",
 		"return hits + 1, otter.WriteOp",
 	} {
 		if !strings.Contains(rateLimiter, want) {
@@ -207,3 +310,589 @@ func readGeneratedApplicationTemplate(t *testing.T, name string) string {
 	}
 	return string(content)
 }
+
+func TestGeneratedSitemapListsSitemapRoutes(t *testing.T) {
+	assets := readGeneratedApplicationTemplate(t, "controllers_assets.tmpl")
+	for _, want := range []string{
+		"var sitemapRoutes = []routing.Route{}",
+		"createSitemap(sitemapRoutes)",
+		"Loc:        baseURL + route.URL(),",
+	} {
+		if !strings.Contains(assets, want) {
+			t.Errorf("controllers_assets.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedMoneyTemplate(t *testing.T) {
+	money := readGeneratedApplicationTemplate(t, "framework_elements_money_money.tmpl")
+	for _, want := range []string{
+		"func Parse(s, currency string) (Money, error)",
+		"func (m Money) FormatLocale(locale string) string",
+		"func Format(ctx context.Context, m Money) string",
+		"func (m Money) Value() (driver.Value, error)",
+		"func (m *Money) UnmarshalText(text []byte) error",
+	} {
+		if !strings.Contains(money, want) {
+			t.Errorf("framework_elements_money_money.tmpl missing %q", want)
+		}
+	}
+	if strings.Contains(money, "float64(") {
+		t.Error("framework_elements_money_money.tmpl must not convert amounts to float64")
+	}
+}
+
+func TestGeneratedIntervalTemplates(t *testing.T) {
+	interval := readGeneratedApplicationTemplate(t, "framework_elements_interval_interval.tmpl")
+	for _, want := range []string{
+		"type Duration time.Duration",
+		"func Parse(s string) (Duration, error)",
+		"func (d Duration) Value() (driver.Value, error)",
+		"func (d *Duration) Scan(src any) error",
+		"func FormatOptional(d *Duration) string",
+	} {
+		if !strings.Contains(interval, want) {
+			t.Errorf("framework_elements_interval_interval.tmpl missing %q", want)
+		}
+	}
+
+	view := readGeneratedApplicationTemplate(t, "views_duration.tmpl")
+	for _, want := range []string{
+		"templ DurationInput(signal string, value string, attrs templ.Attributes)",
+		`list="duration-suggestions"`,
+		"data-bind={ signal }",
+	} {
+		if !strings.Contains(view, want) {
+			t.Errorf("views_duration.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedBuildInfo(t *testing.T) {
+	buildinfo := readGeneratedApplicationTemplate(t, "framework_elements_buildinfo_buildinfo.tmpl")
+	for _, want := range []string{
+		`Framework = "{{.FrameworkVersion}}"`,
+		`case "vcs.revision":`,
+		"func (i Info) LogValue() slog.Value",
+	} {
+		if !strings.Contains(buildinfo, want) {
+			t.Errorf("framework_elements_buildinfo_buildinfo.tmpl missing %q", want)
+		}
+	}
+
+	api := readGeneratedApplicationTemplate(t, "controllers_api.tmpl")
+	if !strings.Contains(api, "return etx.JSON(http.StatusOK, buildinfo.Get())") {
+		t.Error("controllers_api.tmpl should serve the build information")
+	}
+	main := readGeneratedApplicationTemplate(t, "cmd_app_main.tmpl")
+	if !strings.Contains(main, "buildinfo.Get(),") {
+		t.Error("cmd_app_main.tmpl should log the build information at startup")
+	}
+	layout := readGeneratedApplicationTemplate(t, "views_layout.tmpl")
+	if !strings.Contains(layout, "SessionCookieKey).IsAdmin {\n\t\t\t\t\t\t@buildInfoLine(buildinfo.Get())") {
+		t.Error("views_layout.tmpl should show the build information to admins")
+	}
+}
+
+func TestGeneratedRuntimeConfigReload(t *testing.T) {
+	runtime := readGeneratedApplicationTemplate(t, "config_runtime.tmpl")
+	for _, want := range []string{
+		`env:"LOG_LEVEL" envDefault:"info"`,
+		`env:"AUTH_RATE_LIMIT" envDefault:"5"`,
+		"signal.Notify(hangup, syscall.SIGHUP)",
+		"func (w *Watcher) Reload() (Runtime, error)",
+	} {
+		if !strings.Contains(runtime, want) {
+			t.Errorf("config_runtime.tmpl missing %q", want)
+		}
+	}
+
+	config := readGeneratedApplicationTemplate(t, "config_config.tmpl")
+	if !strings.Contains(config, "fx.Provide(NewConfig, NewWatcher)") {
+		t.Error("config_config.tmpl should provide the runtime config watcher")
+	}
+	main := readGeneratedApplicationTemplate(t, "cmd_app_main.tmpl")
+	if !strings.Contains(main, "fx.Invoke(watchRuntimeConfig),") {
+		t.Error("cmd_app_main.tmpl should reload the runtime config on SIGHUP")
+	}
+	api := readGeneratedApplicationTemplate(t, "controllers_api.tmpl")
+	if !strings.Contains(api, "subtle.ConstantTimeCompare([]byte(token), []byte(a.reloadToken))") {
+		t.Error("controllers_api.tmpl should check the reload token in constant time")
+	}
+	for _, name := range []string{
+		"controllers_sessions.tmpl",
+		"controllers_sessions_inertia.tmpl",
+		"controllers_registrations.tmpl",
+		"controllers_registrations_inertia.tmpl",
+	} {
+		controller := readGeneratedApplicationTemplate(t, name)
+		if !strings.Contains(controller, "middleware.IPRateLimiter(") || strings.Contains(controller, "IPRateLimiter(5,") {
+			t.Errorf("%s should rate limit with AUTH_RATE_LIMIT", name)
+		}
+	}
+}
+
+func TestGeneratedUserEmailsAreCaseInsensitive(t *testing.T) {
+	migration := readGeneratedApplicationTemplate(t, "database_migrations_users.tmpl")
+	for _, want := range []string{
+		"CREATE EXTENSION IF NOT EXISTS citext;",
+		"email CITEXT NOT NULL UNIQUE,",
+	} {
+		if !strings.Contains(migration, want) {
+			t.Errorf("database_migrations_users.tmpl missing %q", want)
+		}
+	}
+
+	model := readGeneratedApplicationTemplate(t, "models_user.tmpl")
+	if !strings.Contains(model, `Where("email = ?", strings.TrimSpace(email))`) {
+		t.Error("models_user.tmpl FindByEmail should rely on citext for case-insensitive matching")
+	}
+	if strings.Contains(model, "strings.ToLower") {
+		t.Error("models_user.tmpl should not lowercase emails now that the column is citext")
+	}
+}
+
+func TestGeneratedQueryTimeoutTemplates(t *testing.T) {
+	query := readGeneratedApplicationTemplate(t, "framework_elements_storage_query.tmpl")
+	for _, want := range []string{
+		"func StartQuery(ctx context.Context, op string) (context.Context, *Query)",
+		"func WithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context",
+		"func (e *QueryTimeoutError) Is(target error) bool",
+		`span.AddEvent("slow query"`,
+	} {
+		if !strings.Contains(query, want) {
+			t.Errorf("framework_elements_storage_query.tmpl missing %q", want)
+		}
+	}
+
+	database := readGeneratedApplicationTemplate(t, "psql_database.tmpl")
+	for _, want := range []string{
+		"storage.DefaultQueryTimeout = cfg.DB.QueryTimeout",
+		"storage.SlowQueryThreshold = cfg.DB.SlowQueryThreshold",
+	} {
+		if !strings.Contains(database, want) {
+			t.Errorf("psql_database.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedBulkInsertTemplate(t *testing.T) {
+	bulk := readGeneratedApplicationTemplate(t, "framework_elements_storage_bulk.tmpl")
+	for _, want := range []string{
+		"func BulkInsert[T any](ctx context.Context, db Executor, rows []T, excludeColumns ...string) error",
+		"query = query.ExcludeColumn(excludeColumns...)",
+		"pgxConn.CopyFrom(ctx, name, columns, pgx.CopyFromSlice(",
+		"bunDB.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {",
+		"conn.LoadType(ctx, columnType.Name)",
+	} {
+		if !strings.Contains(bulk, want) {
+			t.Errorf("framework_elements_storage_bulk.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedRetryTemplate(t *testing.T) {
+	retry := readGeneratedApplicationTemplate(t, "framework_elements_storage_retry.tmpl")
+	for _, want := range []string{
+		`SQLStateSerializationFailure = "40001"`,
+		`SQLStateDeadlockDetected     = "40P01"`,
+		"func Retry(ctx context.Context, op string, fn func(ctx context.Context) error) error",
+		"func RetryWrite(ctx context.Context, db Executor, op string, fn func(ctx context.Context) error) error",
+		`"db_retries_total"`,
+	} {
+		if !strings.Contains(retry, want) {
+			t.Errorf("framework_elements_storage_retry.tmpl missing %q", want)
+		}
+	}
+
+	database := readGeneratedApplicationTemplate(t, "psql_database.tmpl")
+	if !strings.Contains(database, "storage.DefaultRetryPolicy.MaxAttempts = cfg.DB.RetryAttempts") {
+		t.Error("psql_database.tmpl should apply DB_RETRY_ATTEMPTS to the default retry policy")
+	}
+}
+
+func TestGeneratedDatabasePoolSettings(t *testing.T) {
+	config := readGeneratedApplicationTemplate(t, "config_database.tmpl")
+	for _, want := range []string{
+		`env:"DB_MAX_CONNS" envDefault:"25"`,
+		`env:"DB_MIN_CONNS" envDefault:"2"`,
+		`env:"DB_MAX_CONN_LIFETIME" envDefault:"1h"`,
+		`env:"DB_STATEMENT_CACHE_MODE" envDefault:"cache_statement"`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config_database.tmpl missing %q", want)
+		}
+	}
+
+	database := readGeneratedApplicationTemplate(t, "psql_database.tmpl")
+	for _, want := range []string{
+		"sqldb.SetMaxOpenConns(dbCfg.MaxConns)",
+		"pgxCfg.DefaultQueryExecMode, err = queryExecMode(dbCfg.StatementCacheMode)",
+		"warmUp(ctx, db, dbCfg.MinConns, dbCfg.MaxConns)",
+	} {
+		if !strings.Contains(database, want) {
+			t.Errorf("psql_database.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedNamedDatabases(t *testing.T) {
+	config := readGeneratedApplicationTemplate(t, "config_database.tmpl")
+	for _, want := range []string{
+		`os.Getenv("DATABASES")`,
+		`Prefix:          strings.ToUpper(name) + "_",`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config_database.tmpl missing %q", want)
+		}
+	}
+
+	database := readGeneratedApplicationTemplate(t, "psql_database.tmpl")
+	for _, want := range []string{
+		"type Databases map[string]storage.Pool",
+		"func NewDatabases(ctx context.Context, cfg config.Config) (Databases, error)",
+		"func (d Databases) Pool(name string) (storage.Pool, error)",
+		"fx.As(new(storage.Pool))), NewDatabases)",
+	} {
+		if !strings.Contains(database, want) {
+			t.Errorf("psql_database.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedQueueConfiguration(t *testing.T) {
+	config := readGeneratedApplicationTemplate(t, "config_queue.tmpl")
+	for _, want := range []string{
+		`env:"QUEUE_WORKERS" envDefault:""`,
+		`env:"QUEUE_MAX_ATTEMPTS" envDefault:"25"`,
+		`env:"QUEUE_RETRY_MAX_DELAY" envDefault:"24h"`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config_queue.tmpl missing %q", want)
+		}
+	}
+
+	queues := readGeneratedApplicationTemplate(t, "psql_queue_queues.tmpl")
+	for _, want := range []string{
+		"var queues = map[string]int{",
+		"func queueConfigs(workers map[string]int) map[string]river.QueueConfig",
+		"func (p *cappedRetryPolicy) NextRetry(job *rivertype.JobRow) time.Time",
+	} {
+		if !strings.Contains(queues, want) {
+			t.Errorf("psql_queue_queues.tmpl missing %q", want)
+		}
+	}
+
+	processor := readGeneratedApplicationTemplate(t, "psql_queue_queue.tmpl")
+	for _, want := range []string{
+		"Queues:       queueConfigs(params.Config.Queue.Workers),",
+		"RetryPolicy:  &cappedRetryPolicy{maxDelay: params.Config.Queue.RetryMaxDelay},",
+	} {
+		if !strings.Contains(processor, want) {
+			t.Errorf("psql_queue_queue.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedJobEncryption(t *testing.T) {
+	jobcrypt := readGeneratedApplicationTemplate(t, "framework_elements_jobcrypt_jobcrypt.tmpl")
+	for _, want := range []string{
+		"func SetKey(hexKey string) error",
+		"cipher.NewGCM(block)",
+		"type Sensitive[T any] struct",
+		"func (s Sensitive[T]) MarshalJSON() ([]byte, error)",
+		"func (s *Sensitive[T]) UnmarshalJSON(data []byte) error",
+	} {
+		if !strings.Contains(jobcrypt, want) {
+			t.Errorf("framework_elements_jobcrypt_jobcrypt.tmpl missing %q", want)
+		}
+	}
+
+	queue := readGeneratedApplicationTemplate(t, "psql_queue_queue.tmpl")
+	for _, want := range []string{
+		"jobcrypt.SetKey(cfg.App.SessionEncryptionKey)",
+		"fx.Invoke(configureJobEncryption)",
+	} {
+		if !strings.Contains(queue, want) {
+			t.Errorf("psql_queue_queue.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedURLSigning(t *testing.T) {
+	signing := readGeneratedApplicationTemplate(t, "framework_elements_signing_signing.tmpl")
+	for _, want := range []string{
+		"func SetKey(tokenSigningKey string) error",
+		"func URL(purpose, rawURL string, ttl time.Duration) (string, error)",
+		"func VerifyRequest(purpose string, r *http.Request) error",
+		"func VerifyToken(purpose, token string) (string, error)",
+		"hmac.Equal(given, expected)",
+	} {
+		if !strings.Contains(signing, want) {
+			t.Errorf("framework_elements_signing_signing.tmpl missing %q", want)
+		}
+	}
+
+	config := readGeneratedApplicationTemplate(t, "config_config.tmpl")
+	for _, want := range []string{
+		"signing.SetKey(cfg.App.TokenSigningKey)",
+		"fx.Invoke(configureURLSigning)",
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config_config.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedLoggingConfiguration(t *testing.T) {
+	config := readGeneratedApplicationTemplate(t, "config_telemetry.tmpl")
+	for _, want := range []string{
+		`env:"LOG_FORMAT" envDefault:""`,
+		`env:"LOG_SOURCE" envDefault:"true"`,
+		`env:"LOG_SAMPLE_INITIAL" envDefault:"0"`,
+	} {
+		if !strings.Contains(config, want) {
+			t.Errorf("config_telemetry.tmpl missing %q", want)
+		}
+	}
+
+	telemetry := readGeneratedApplicationTemplate(t, "telemetry_telemetry.tmpl")
+	for _, want := range []string{
+		"runtime.Subscribe(func(settings config.Runtime) {",
+		"logFormat = LogFormatJSON",
+		"newSamplingHandler(finalHandler, t.config.logSampleInitial, t.config.logSampleThereafter)",
+	} {
+		if !strings.Contains(telemetry, want) {
+			t.Errorf("telemetry_telemetry.tmpl missing %q", want)
+		}
+	}
+
+	exporters := readGeneratedApplicationTemplate(t, "telemetry_log_exporters.tmpl")
+	if !strings.Contains(exporters, "slog.NewJSONHandler(os.Stdout") {
+		t.Error("telemetry_log_exporters.tmpl should support the json format")
+	}
+
+	for _, name := range []string{"cmd_app_main.tmpl", "cmd_seeds_main.tmpl"} {
+		if content := readGeneratedApplicationTemplate(t, name); strings.Contains(content, "log.Fatal") {
+			t.Errorf("%s should log through slog instead of log.Fatal", name)
+		}
+	}
+}
+
+func TestGeneratedQueueTracePropagation(t *testing.T) {
+	tracing := readGeneratedApplicationTemplate(t, "psql_queue_tracing.tmpl")
+	for _, want := range []string{
+		"otel.GetTextMapPropagator().Inject(ctx, carrier)",
+		"metadata[traceMetadataKey] = carrier",
+		"otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(metadata.TraceContext))",
+		"trace.WithSpanKind(trace.SpanKindConsumer)",
+	} {
+		if !strings.Contains(tracing, want) {
+			t.Errorf("psql_queue_tracing.tmpl missing %q", want)
+		}
+	}
+
+	queue := readGeneratedApplicationTemplate(t, "psql_queue_queue.tmpl")
+	if strings.Count(queue, "[]rivertype.Middleware{&tracingMiddleware{}}") != 2 {
+		t.Error("psql_queue_queue.tmpl should add the tracing middleware to the processor and insert-only clients")
+	}
+
+	telemetry := readGeneratedApplicationTemplate(t, "telemetry_telemetry.tmpl")
+	if !strings.Contains(telemetry, "otel.SetTextMapPropagator(") {
+		t.Error("telemetry_telemetry.tmpl should register a text map propagator")
+	}
+
+	email := readGeneratedApplicationTemplate(t, "email_email.tmpl")
+	for _, want := range []string{
+		`annotateSend(ctx, "transactional", sender, recipients, err)`,
+		`annotateSend(ctx, "marketing", sender, len(data.To), err)`,
+	} {
+		if !strings.Contains(email, want) {
+			t.Errorf("email_email.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedViewLinkHelper(t *testing.T) {
+	links := readGeneratedApplicationTemplate(t, "views_link.tmpl")
+	for _, want := range []string{
+		"func link[P any](route paramRoute[P], param P, opts ...linkOption) templ.Component",
+		"func linkTo(route routing.SimpleRoute, opts ...linkOption) templ.Component",
+		"request.ExtractContext[cookies.App](ctx, request.SessionCookieKey).CurrentPath",
+		`aria-current="page"`,
+	} {
+		if !strings.Contains(links, want) {
+			t.Errorf("views_link.tmpl missing %q", want)
+		}
+	}
+
+	layout := readGeneratedApplicationTemplate(t, "views_layout.tmpl")
+	for _, want := range []string{"@linkTo(routes.SessionNew,", "@linkTo(routes.RegistrationNew,"} {
+		if !strings.Contains(layout, want) {
+			t.Errorf("views_layout.tmpl missing %q", want)
+		}
+	}
+}
+
+func TestGeneratedBodyLimits(t *testing.T) {
+	bodyLimits := readGeneratedApplicationTemplate(t, "router_body_limit.tmpl")
+	for _, want := range []string{
+		"func WithBodyLimit(limitBytes int64) RouteOption",
+		"func WithoutBodyLimit() RouteOption",
+		"Skipper:    b.overridden,",
+	} {
+		if !strings.Contains(bodyLimits, want) {
+			t.Errorf("router_body_limit.tmpl missing %q", want)
+		}
+	}
+
+	appConfig := readGeneratedApplicationTemplate(t, "config_app.tmpl")
+	if !strings.Contains(appConfig, `env:"MAX_BODY_BYTES" envDefault:"4194304"`) {
+		t.Error("config_app.tmpl missing the MAX_BODY_BYTES default")
+	}
+
+	middleware, err := initializeBlueprint("example.com/app", "").Middleware.Sorted()
+	if err != nil {
+		t.Fatalf("sort middleware: %v", err)
+	}
+	var expressions []string
+	for _, m := range middleware {
+		expressions = append(expressions, m.Expression)
+	}
+	if !strings.Contains(strings.Join(expressions, ","), "middleware.Logger(tel),bodyLimitMiddleware,timeoutMiddleware,session.Middleware(sessionStore)") {
+		t.Errorf("the body limit and timeout do not run between logging and the session: %v", expressions)
+	}
+}
+
+func TestGeneratedRequestTimeouts(t *testing.T) {
+	for name, wants := range map[string][]string{
+		"router_timeout.tmpl": {
+			"func WithTimeout(timeout time.Duration) RouteOption",
+			"func WithoutTimeout() RouteOption",
+			"type RequestTimeoutError struct",
+			"context.WithValue(ctx, request.TimeoutKey, timer.Stop)",
+		},
+		"router_router.tmpl": {
+			"timeouts.middleware(cfg.App.RequestTimeout)",
+			"func (r *Router) SetTimeoutPage(timeoutHandler echo.HandlerFunc)",
+			"timeouts.renderPage(c)",
+		},
+		"config_app.tmpl":                                 {`env:"REQUEST_TIMEOUT" envDefault:"25s"`},
+		"framework_elements_request_request.tmpl":         {"func StopTimeout(ctx context.Context) bool"},
+		"framework_elements_hypermedia_broadcaster.tmpl":  {"request.StopTimeout(c.Request().Context())"},
+		"controllers_pages.tmpl":                          {"r.SetTimeoutPage(p.Timeout)", "hypermedia.WithStatus(http.StatusServiceUnavailable)"},
+		"controllers_pages_inertia.tmpl":                  {"r.SetTimeoutPage(p.Timeout)", `inertia.Page(etx, "Errors/Timeout", inertia.Props{}, inertia.WithStatus(http.StatusServiceUnavailable))`},
+		"inertia_page_options.tmpl":                       {"func WithStatus(status int) PageOption"},
+		"views_timeout.tmpl":                              {"templ Timeout()", ">503<"},
+		"inertia_assets_pages_errors_timeout.tmpl":        {">503<"},
+		"inertia_react_assets_pages_errors_timeout.tmpl":  {"export default function Timeout()"},
+		"inertia_svelte_assets_pages_errors_timeout.tmpl": {">503<"},
+		"env.tmpl": {"REQUEST_TIMEOUT=25s"},
+	} {
+		content := readGeneratedApplicationTemplate(t, name)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", name, want)
+			}
+		}
+	}
+}
+
+func TestGeneratedPanicRecovery(t *testing.T) {
+	for name, wants := range map[string][]string{
+		"router_middleware_recover.tmpl": {
+			"func Recover(tel *telemetry.Telemetry) echo.MiddlewareFunc",
+			"type PanicError struct",
+			"semconv.ExceptionStacktrace(string(panicErr.Stack))",
+			"if recovered == http.ErrAbortHandler",
+		},
+		"router_errors.tmpl": {
+			"func parseStack(stack []byte) []stackFrame",
+			"func readSource(file string, line int) []sourceLine",
+			`Delims("[[", "]]")`,
+		},
+		"router_router.tmpl": {
+			"newErrorPages(config.Env == server.DevEnvironment)",
+			"errors.AsType[*middleware.PanicError](err)",
+			"func (r *Router) SetInternalErrorPage(internalErrorHandler echo.HandlerFunc)",
+		},
+		"telemetry_metrics.tmpl":                                 {`"http_panics_total"`},
+		"framework_elements_request_request.tmpl":                {"func RequestID(ctx context.Context) string"},
+		"controllers_pages.tmpl":                                 {"r.SetInternalErrorPage(p.InternalError)", "hypermedia.WithStatus(http.StatusInternalServerError)"},
+		"controllers_pages_inertia.tmpl":                         {"r.SetInternalErrorPage(p.InternalError)", `inertia.Props{"requestId": request.RequestID(etx.Request().Context())}`},
+		"views_internal_error.tmpl":                              {"request.RequestID(ctx)"},
+		"inertia_assets_pages_errors_internal_error.tmpl":        {"requestId?: string"},
+		"inertia_react_assets_pages_errors_internal_error.tmpl":  {"InternalError({ requestId }: InternalErrorProps)"},
+		"inertia_svelte_assets_pages_errors_internal_error.tmpl": {"let { requestId }"},
+	} {
+		content := readGeneratedApplicationTemplate(t, name)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", name, want)
+			}
+		}
+	}
+}
+
+func TestGeneratedDevelopmentErrorPage(t *testing.T) {
+	for name, wants := range map[string][]string{
+		"framework_elements_storage_query.tmpl": {
+			"type QueryError struct",
+			"func (e *QueryError) PgError() *pgconn.PgError",
+			"runtime.Caller(1)",
+		},
+		"router_errors.tmpl": {
+			"func (p *errorPages) renderError(c *echo.Context, err error) bool",
+			"errors.AsType[templ.Error](err)",
+			"errors.AsType[*storage.QueryError](err)",
+			`var EditorURL = "vscode://file/%s:%d:%d"`,
+			"routes.APIPrefix",
+		},
+		"router_router.tmpl":                             {"pages.renderError(c, err)"},
+		"framework_elements_hypermedia_render.tmpl":      {"%w"},
+		"framework_elements_hypermedia_broadcaster.tmpl": {"%w"},
+	} {
+		content := readGeneratedApplicationTemplate(t, name)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", name, want)
+			}
+		}
+	}
+}
+
+func TestGeneratedOnboardingChecklist(t *testing.T) {
+	for name, wants := range map[string][]string{
+		"views_onboarding.tmpl": {
+			"type Onboarding struct",
+			"routes.OnboardingMigrations.URL()",
+			"routes.OnboardingResources.URL()",
+			"routes.OnboardingEnv.URL()",
+			"andurel generate page home",
+		},
+		"controllers_onboarding.tmpl": {
+			"config.Env != server.DevEnvironment",
+			`onboardingSignals("migrations", step)`,
+			`strings.CutSuffix(route.Name, ".index")`,
+			`envFileKeys(".env.example")`,
+		},
+		"controllers_controller.tmpl":    {"NewOnboarding,", "c Onboarding) error"},
+		"controllers_pages.tmpl":         {"views.Onboarding{Check: config.Env == server.DevEnvironment}.Page()"},
+		"controllers_pages_inertia.tmpl": {"views.Onboarding{Check: config.Env == server.DevEnvironment}.Page()"},
+		"router_routes_onboarding.tmpl":  {`"onboarding.migrations"`, `"onboarding.resources"`, `"onboarding.env"`},
+	} {
+		content := readGeneratedApplicationTemplate(t, name)
+		for _, want := range wants {
+			if !strings.Contains(content, want) {
+				t.Errorf("%s missing %q", name, want)
+			}
+		}
+	}
+
+	controller := readGeneratedApplicationTemplate(t, "controllers_onboarding.tmpl")
+	for _, migration := range baseMigrations {
+		if !strings.Contains(controller, `"`+migration.name+`",`) {
+			t.Errorf("controllers_onboarding.tmpl frameworkMigrations missing %q", migration.name)
+		}
+	}
+}
@@ -35,7 +35,8 @@ func TestScaffoldReactInertiaAssets(t *testing.T) {
 	assertFileContains(t, projectDir, "views/bad_request.templ", "templ BadRequest()")
 	assertFileContains(t, projectDir, "views/internal_error.templ", "templ InternalError()")
 	assertFileContains(t, projectDir, "views/not_found.templ", "templ NotFound()")
-	assertFileContains(t, projectDir, "views/welcome.templ", "type Welcome struct{}")
+	assertFileContains(t, projectDir, "views/onboarding.templ", "type Onboarding struct")
+	assertFileContains(t, projectDir, "controllers/pages.go", "views.Onboarding{Check: config.Env == server.DevEnvironment}.Page()")
 	assertFileContains(t, projectDir, "package.json", "@vitejs/plugin-react")
 	assertFileContains(t, projectDir, "vite.config.ts", "resources/js/app.tsx")
 	assertFileContains(t, projectDir, "tsconfig.json", "resources/js/**/*.tsx")
@@ -13,7 +13,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -26,6 +25,7 @@ import (
 	"github.com/mbvlabs/andurel/layout/templates"
 	"github.com/mbvlabs/andurel/layout/versions"
 	"github.com/mbvlabs/andurel/pkg/constants"
+	"github.com/mbvlabs/andurel/pkg/templatefuncs"
 )
 
 // Element describes a directory tree node to create during scaffolding.
@@ -53,7 +53,7 @@ func Scaffold(
 		return fmt.Errorf("failed to generate scaffold secrets: %w", err)
 	}
 
-	blueprint := initializeBlueprint(moduleName)
+	blueprint := initializeBlueprint(moduleName, inertia)
 	templateData := TemplateData{
 		AppName:              projectName,
 		ProjectName:          projectName,
@@ -112,6 +112,7 @@ func Scaffold(
 		Database:          database,
 		Inertia:           inertia,
 		JavaScriptRuntime: javascriptRuntime,
+		GoVersion:         goVersion,
 	}
 	if err := generateLockFile(targetDir, version, scaffoldConfig, extensionNames); err != nil {
 		fmt.Printf("Warning: failed to generate lock file: %v\n", err)
@@ -238,18 +239,25 @@ var baseStyleTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"css_base.tmpl": "css/base.css",
 
 	// Views
-	"views_layout.tmpl":  "views/layout.templ",
-	"views_welcome.tmpl": "views/welcome.templ",
+	"views_layout.tmpl":     "views/layout.templ",
+	"views_onboarding.tmpl": "views/onboarding.templ",
 
 	// Views - Pages
 	"views_bad_request.tmpl":    "views/bad_request.templ",
 	"views_internal_error.tmpl": "views/internal_error.templ",
 	"views_not_found.tmpl":      "views/not_found.templ",
+	"views_timeout.tmpl":        "views/timeout.templ",
 	"views_confirm_email.tmpl":  "views/confirm_email.templ",
 	"views_login.tmpl":          "views/login.templ",
 	"views_registration.tmpl":   "views/registration.templ",
 	"views_reset_password.tmpl": "views/reset_password.templ",
 
+	// Views - Components
+	"views_rich_text.tmpl": "views/rich_text.templ",
+	"views_codes.tmpl":     "views/codes.templ",
+	"views_duration.tmpl":  "views/duration.templ",
+	"views_link.tmpl":      "views/link.templ",
+
 	// Views
 	"views_head.tmpl": "views/head.templ",
 }
@@ -267,6 +275,9 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"framework_elements_server_server.tmpl":          "internal/server/server.go",
 	"framework_elements_storage_psql.tmpl":           "internal/storage/psql.go",
 	"framework_elements_storage_queue.tmpl":          "internal/storage/queue.go",
+	"framework_elements_storage_retry.tmpl":          "internal/storage/retry.go",
+	"framework_elements_storage_query.tmpl":          "internal/storage/query.go",
+	"framework_elements_storage_bulk.tmpl":           "internal/storage/bulk.go",
 	"framework_elements_hypermedia_signals.tmpl":     "internal/hypermedia/signals.go",
 	"framework_elements_hypermedia_core.tmpl":        "internal/hypermedia/core.go",
 	"framework_elements_hypermedia_options.tmpl":     "internal/hypermedia/options.go",
@@ -275,17 +286,46 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"framework_elements_hypermedia_sse.tmpl":         "internal/hypermedia/sse.go",
 	"framework_elements_hypermedia_broadcaster.tmpl": "internal/hypermedia/broadcaster.go",
 	"framework_elements_hypermedia_helpers.tmpl":     "internal/hypermedia/helpers.go",
+	"framework_elements_hypermedia_longpoll.tmpl":    "internal/hypermedia/longpoll.go",
 
 	// Validation
 	"framework_elements_validation_validation.tmpl": "internal/validation/validation.go",
 	"framework_elements_validation_rules.tmpl":      "internal/validation/rules.go",
 	"framework_elements_validation_helpers.tmpl":    "internal/validation/helpers.go",
 
+	// HTML sanitization
+	"framework_elements_htmlsanitize_htmlsanitize.tmpl": "internal/htmlsanitize/htmlsanitize.go",
+
+	// View snapshot tests
+	"framework_elements_viewtest_viewtest.tmpl": "internal/viewtest/viewtest.go",
+
+	// QR codes and barcodes
+	"framework_elements_codes_qr.tmpl":      "internal/codes/qr.go",
+	"framework_elements_codes_code128.tmpl": "internal/codes/code128.go",
+	"framework_elements_codes_svg.tmpl":     "internal/codes/svg.go",
+
+	// Money
+	"framework_elements_money_money.tmpl": "internal/money/money.go",
+
+	// Intervals
+	"framework_elements_interval_interval.tmpl": "internal/interval/interval.go",
+
+	// Job args encryption
+	"framework_elements_jobcrypt_jobcrypt.tmpl": "internal/jobcrypt/jobcrypt.go",
+
+	// Signed URLs
+	"framework_elements_signing_signing.tmpl": "internal/signing/signing.go",
+
+	// Build information
+	"framework_elements_buildinfo_buildinfo.tmpl": "internal/buildinfo/buildinfo.go",
+
 	// Assets
 	"assets_assets.tmpl":      "assets/assets.go",
 	"assets_css_style.tmpl":   "assets/css/style.css",
 	"assets_js_scripts.tmpl":  "assets/js/scripts.js",
 	"assets_js_datastar.tmpl": "assets/js/datastar_1-0-1.min.js",
+	"assets_js_richtext.tmpl": "assets/js/richtext.js",
+	"assets_js_longpoll.tmpl": "assets/js/longpoll.js",
 
 	// Commands
 	"cmd_app_main.tmpl":      "cmd/app/main.go",
@@ -293,11 +333,14 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"cmd_seeds_main.tmpl":    "cmd/seeds/main.go",
 
 	// Config
-	"config_app.tmpl":       "config/app.go",
-	"config_config.tmpl":    "config/config.go",
-	"config_database.tmpl":  "config/database.go",
-	"config_telemetry.tmpl": "config/telemetry.go",
-	"config_email.tmpl":     "config/email.go",
+	"config_app.tmpl":          "config/app.go",
+	"config_config.tmpl":       "config/config.go",
+	"config_database.tmpl":     "config/database.go",
+	"config_telemetry.tmpl":    "config/telemetry.go",
+	"config_email.tmpl":        "config/email.go",
+	"config_queue.tmpl":        "config/queue.go",
+	"config_runtime.tmpl":      "config/runtime.go",
+	"config_runtime_test.tmpl": "config/runtime_test.go",
 
 	// Clients
 	"clients_email_mailpit.tmpl": "clients/email/mailpit.go",
@@ -307,15 +350,19 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"controllers_assets.tmpl":     "controllers/assets.go",
 	"controllers_cache.tmpl":      "controllers/cache.go",
 	"controllers_controller.tmpl": "controllers/controller.go",
+	"controllers_onboarding.tmpl": "controllers/onboarding.go",
 	"controllers_pages.tmpl":      "controllers/pages.go",
 
 	// Database
 	"database_migrations_gitkeep.tmpl": "database/migrations/.gitkeep",
 	"database_seeds_seeds.tmpl":        "database/seeds/seeds.go",
+	"database_test_helper.tmpl":        "database/test_helper.go",
 	"psql_database.tmpl":               "database/database.go",
 
 	// Queue package
 	"psql_queue_queue.tmpl":                            "queue/queue.go",
+	"psql_queue_queues.tmpl":                           "queue/queues.go",
+	"psql_queue_tracing.tmpl":                          "queue/tracing.go",
 	"psql_queue_jobs_send_transactional_email.tmpl":    "queue/jobs/send_transactional_email.go",
 	"psql_queue_jobs_send_marketing_email.tmpl":        "queue/jobs/send_marketing_email.go",
 	"psql_queue_workers_workers.tmpl":                  "queue/workers.go",
@@ -331,6 +378,7 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"models_errors.tmpl": "models/errors.go",
 	"models_model.tmpl":  "models/model.go",
 	"models_token.tmpl":  "models/token.go",
+	"models_tx.tmpl":     "models/tx.go",
 	"models_user.tmpl":   "models/user.go",
 
 	"models_factories_factories.tmpl": "models/factories/factories.go",
@@ -340,16 +388,21 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	// Router
 	"router_router.tmpl":                     "router/router.go",
 	"router_router_test.tmpl":                "router/router_test.go",
+	"router_body_limit.tmpl":                 "router/body_limit.go",
+	"router_timeout.tmpl":                    "router/timeout.go",
+	"router_errors.tmpl":                     "router/errors.go",
 	"router_cookies_cookies.tmpl":            "router/cookies/cookies.go",
 	"router_cookies_flash.tmpl":              "router/cookies/flash.go",
 	"router_cookies_session.tmpl":            "router/cookies/session.go",
 	"router_middleware_middleware.tmpl":      "router/middleware/middleware.go",
 	"router_middleware_middleware_test.tmpl": "router/middleware/middleware_test.go",
+	"router_middleware_recover.tmpl":         "router/middleware/recover.go",
 
 	// Routes
-	"router_routes_api.tmpl":    "router/routes/api.go",
-	"router_routes_assets.tmpl": "router/routes/assets.go",
-	"router_routes_pages.tmpl":  "router/routes/pages.go",
+	"router_routes_api.tmpl":        "router/routes/api.go",
+	"router_routes_assets.tmpl":     "router/routes/assets.go",
+	"router_routes_onboarding.tmpl": "router/routes/onboarding.go",
+	"router_routes_pages.tmpl":      "router/routes/pages.go",
 
 	// Telemetry
 	"telemetry_telemetry.tmpl":        "telemetry/telemetry.go",
@@ -378,11 +431,14 @@ var baseTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"services_authentication_test.tmpl": "services/authentication_test.go",
 	"services_registration.tmpl":        "services/registration.go",
 	"services_reset_password.tmpl":      "services/reset_password.go",
+	"services_reset_password_test.tmpl": "services/reset_password_test.go",
 
 	// Auth - Router
-	"router_routes_users.tmpl":         "router/routes/users.go",
-	"router_middleware_auth.tmpl":      "router/middleware/auth.go",
-	"router_middleware_auth_test.tmpl": "router/middleware/auth_test.go",
+	"router_routes_users.tmpl":           "router/routes/users.go",
+	"router_middleware_auth.tmpl":        "router/middleware/auth.go",
+	"router_middleware_auth_test.tmpl":   "router/middleware/auth_test.go",
+	"router_auth_current_user.tmpl":      "router/auth/current_user.go",
+	"router_auth_current_user_test.tmpl": "router/auth/current_user_test.go",
 
 	// Auth - Email
 	"email_reset_password.tmpl": "email/reset_password.templ",
@@ -408,6 +464,7 @@ var inertiaVueTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"inertia_assets_pages_errors_bad_request.tmpl":          "resources/js/Pages/Errors/BadRequest.vue",
 	"inertia_assets_pages_errors_internal_error.tmpl":       "resources/js/Pages/Errors/InternalError.vue",
 	"inertia_assets_pages_errors_not_found.tmpl":            "resources/js/Pages/Errors/NotFound.vue",
+	"inertia_assets_pages_errors_timeout.tmpl":              "resources/js/Pages/Errors/Timeout.vue",
 	"inertia_assets_vite_config.tmpl":                       "vite.config.ts",
 	"inertia_assets_package_json.tmpl":                      "package.json",
 	"inertia_assets_tsconfig.tmpl":                          "tsconfig.json",
@@ -424,6 +481,7 @@ var inertiaReactTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"inertia_react_assets_pages_errors_bad_request.tmpl":          "resources/js/Pages/Errors/BadRequest.tsx",
 	"inertia_react_assets_pages_errors_internal_error.tmpl":       "resources/js/Pages/Errors/InternalError.tsx",
 	"inertia_react_assets_pages_errors_not_found.tmpl":            "resources/js/Pages/Errors/NotFound.tsx",
+	"inertia_react_assets_pages_errors_timeout.tmpl":              "resources/js/Pages/Errors/Timeout.tsx",
 	"inertia_react_assets_vite_config.tmpl":                       "vite.config.ts",
 	"inertia_react_assets_package_json.tmpl":                      "package.json",
 	"inertia_react_assets_tsconfig.tmpl":                          "tsconfig.json",
@@ -441,6 +499,7 @@ var inertiaSvelteTemplateMappings = map[TmplTarget]TmplTargetPath{
 	"inertia_svelte_assets_pages_errors_bad_request.tmpl":          "resources/js/Pages/Errors/BadRequest.svelte",
 	"inertia_svelte_assets_pages_errors_internal_error.tmpl":       "resources/js/Pages/Errors/InternalError.svelte",
 	"inertia_svelte_assets_pages_errors_not_found.tmpl":            "resources/js/Pages/Errors/NotFound.svelte",
+	"inertia_svelte_assets_pages_errors_timeout.tmpl":              "resources/js/Pages/Errors/Timeout.svelte",
 	"inertia_svelte_assets_vite_config.tmpl":                       "vite.config.ts",
 	"inertia_svelte_assets_package_json.tmpl":                      "package.json",
 	"inertia_svelte_assets_tsconfig.tmpl":                          "tsconfig.json",
@@ -452,6 +511,9 @@ var inertiaSkippedTemplates = map[TmplTarget]bool{
 	"views_login.tmpl":          true,
 	"views_registration.tmpl":   true,
 	"views_reset_password.tmpl": true,
+	"views_rich_text.tmpl":      true,
+	"views_codes.tmpl":          true,
+	"views_duration.tmpl":       true,
 }
 
 func inertiaAdapterTemplateMappings(adapter string) map[TmplTarget]TmplTargetPath {
@@ -579,6 +641,43 @@ func processTemplatedFiles(targetDir string, data extensions.TemplateData) error
 	return nil
 }
 
+// baseMigrations are the migrations every new project starts with. The
+// onboarding controller lists their names to tell them apart from the
+// project's own.
+var baseMigrations = []struct {
+	template string
+	name     string
+	offset   time.Duration
+}{
+	// River queue migrations
+	{"psql_riverqueue_migration_one.tmpl", "create_river_migration_table", 0},
+	{
+		"psql_riverqueue_migration_two.tmpl",
+		"create_river_job_and_leader_tables",
+		1 * time.Second,
+	},
+	{"psql_riverqueue_migration_three.tmpl", "alter_river_job_tags", 2 * time.Second},
+	{
+		"psql_riverqueue_migration_four.tmpl",
+		"alter_river_job_args_metadata_add_queue",
+		3 * time.Second,
+	},
+	{
+		"psql_riverqueue_migration_five.tmpl",
+		"add_river_job_unique_key_and_clients",
+		4 * time.Second,
+	},
+	{"psql_riverqueue_migration_six.tmpl", "add_river_job_unique_states", 5 * time.Second},
+	// Auth migrations
+	{"database_migrations_users.tmpl", "create_users_table", 6 * time.Second},
+	{"database_migrations_tokens.tmpl", "create_tokens_table", 7 * time.Second},
+	{
+		"database_migrations_tokens_scope_hash_index.tmpl",
+		"add_tokens_scope_hash_index",
+		8 * time.Second,
+	},
+}
+
 func processMigrations(
 	targetDir string,
 	data extensions.TemplateData,
@@ -589,37 +688,8 @@ func processMigrations(
 		baseTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	}
 
-	migrations := []struct {
-		template string
-		name     string
-		offset   time.Duration
-	}{
-		// River queue migrations
-		{"psql_riverqueue_migration_one.tmpl", "create_river_migration_table", 0},
-		{
-			"psql_riverqueue_migration_two.tmpl",
-			"create_river_job_and_leader_tables",
-			1 * time.Second,
-		},
-		{"psql_riverqueue_migration_three.tmpl", "alter_river_job_tags", 2 * time.Second},
-		{
-			"psql_riverqueue_migration_four.tmpl",
-			"alter_river_job_args_metadata_add_queue",
-			3 * time.Second,
-		},
-		{
-			"psql_riverqueue_migration_five.tmpl",
-			"add_river_job_unique_key_and_clients",
-			4 * time.Second,
-		},
-		{"psql_riverqueue_migration_six.tmpl", "add_river_job_unique_states", 5 * time.Second},
-		// Auth migrations
-		{"database_migrations_users.tmpl", "create_users_table", 6 * time.Second},
-		{"database_migrations_tokens.tmpl", "create_tokens_table", 7 * time.Second},
-	}
-
 	var lastTime time.Time
-	for _, migration := range migrations {
+	for _, migration := range baseMigrations {
 		lastTime = baseTime.Add(migration.offset)
 		timestamp := lastTime.Format("20060102150405")
 		targetPath := fmt.Sprintf("database/migrations/%s_%s.sql", timestamp, migration.name)
@@ -657,6 +727,7 @@ func rerenderBlueprintTemplates(targetDir string, data extensions.TemplateData)
 	blueprintTemplates = append(blueprintTemplates,
 		"cmd_app_main.tmpl",
 		"controllers_controller.tmpl",
+		"router_router.tmpl",
 	)
 
 	for _, tmplName := range blueprintTemplates {
@@ -720,7 +791,7 @@ func renderTemplate(
 	contentStr := string(content)
 
 	tmpl, err := template.New(templateFile).
-		Funcs(templateFuncMap()).
+		Funcs(templatefuncs.FuncMap()).
 		Parse(contentStr)
 	if err != nil {
 		return fmt.Errorf("failed to parse template %s: %w", templateFile, err)
@@ -784,23 +855,13 @@ func renderTemplate(
 	return nil
 }
 
-func templateFuncMap() template.FuncMap {
-	return template.FuncMap{
-		"hasExtension": hasExtension,
-		"lower":        strings.ToLower,
-	}
-}
-
-func hasExtension(extensions []string, name string) bool {
-	return slices.Contains(extensions, name)
-}
-
 func registerBuiltinExtensions() error {
 	registerBuiltinOnce.Do(func() {
 		builtin := []extensions.Extension{
 			extensions.AwsSes{},
 			extensions.Docker{},
 			extensions.CssComponents{},
+			extensions.Geocoding{},
 		}
 
 		for _, ext := range builtin {
@@ -1088,14 +1149,34 @@ func generateRandomHex(reader io.Reader, bytes int) (string, error) {
 
 // initializeBlueprint creates a blueprint with default base configuration
 // for controllers, routes, and other scaffold components.
-func initializeBlueprint(moduleName string) *blueprint.Blueprint {
+func initializeBlueprint(moduleName, inertia string) *blueprint.Blueprint {
 	builder := blueprint.NewBuilder(nil)
 
+	// Global middleware, in execution order. Recover runs last to catch
+	// panics from all preceding middleware.
+	builder.AddMiddleware(blueprint.Middleware{Name: "trace-route", Expression: "middleware.TraceRouteAttributes(tel)", Priority: blueprint.MiddlewarePriorityTracing})
+	builder.AddMiddleware(blueprint.Middleware{Name: "logger", Expression: "middleware.Logger(tel)", Priority: blueprint.MiddlewarePriorityLogging})
+	builder.AddMiddleware(blueprint.Middleware{Name: "body-limit", Expression: "bodyLimitMiddleware", Priority: blueprint.MiddlewarePriorityBodyLimit})
+	builder.AddMiddleware(blueprint.Middleware{Name: "timeout", Expression: "timeoutMiddleware", Priority: blueprint.MiddlewarePriorityTimeout})
+	builder.AddMiddleware(blueprint.Middleware{Name: "session", Expression: "session.Middleware(sessionStore)", Priority: blueprint.MiddlewarePrioritySession})
+	builder.AddMiddleware(blueprint.Middleware{Name: "validate-session", Expression: "middleware.ValidateSession", Priority: blueprint.MiddlewarePrioritySession, After: []string{"session"}})
+	builder.AddMiddleware(blueprint.Middleware{Name: "request-meta", Expression: "middleware.RegisterRequestMeta", Priority: blueprint.MiddlewarePriorityRequestMeta})
+	builder.AddMiddleware(blueprint.Middleware{Name: "current-user", Expression: "middleware.LoadCurrentUser(db)", Priority: blueprint.MiddlewarePriorityCurrentUser, After: []string{"session", "request-meta"}})
+	if inertia != "" {
+		builder.AddMiddleware(blueprint.Middleware{Name: "inertia", Expression: "inertia.Middleware()", Priority: blueprint.MiddlewarePriorityFrontend})
+	}
+	builder.AddMiddleware(blueprint.Middleware{Name: "cors", Expression: "echomw.CORSWithConfig(corsConfig)", Priority: blueprint.MiddlewarePriorityCORS})
+	builder.AddMiddleware(blueprint.Middleware{Name: "csrf", Expression: "csrfMiddleware", Priority: blueprint.MiddlewarePriorityCSRF, After: []string{"session"}})
+	builder.AddMiddleware(blueprint.Middleware{Name: "long-polling", Expression: "hypermedia.LongPolling()", Priority: blueprint.MiddlewarePriorityRecover - 10})
+	builder.AddMiddleware(blueprint.Middleware{Name: "recover", Expression: "middleware.Recover(tel)", Priority: blueprint.MiddlewarePriorityRecover})
+	builder.AddMiddlewareImport(fmt.Sprintf("%s/internal/hypermedia", moduleName))
+
 	builder.AddControllerImport(fmt.Sprintf("%s/controllers", moduleName))
 	builder.AddControllerImport(fmt.Sprintf("%s/config", moduleName))
 
 	builder.AddConfigField("Email", "email")
 	builder.AddConfigField("Auth", "auth")
+	builder.AddConfigField("Queue", "queue")
 
 	builder.AddWorkerDependency("transactionalSender", "email.TransactionalSender")
 	builder.AddWorkerDependency("marketingSender", "email.MarketingSender")
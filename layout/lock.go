@@ -31,6 +31,35 @@ type AndurelLock struct {
 // DatabaseConfig records database generation settings.
 type DatabaseConfig struct {
 	NullType string `json:"nullType"`
+	// DecimalType selects the Go type of numeric and decimal columns:
+	// DecimalTypeFloat64 (the default) or DecimalTypeDecimal.
+	DecimalType string `json:"decimalType,omitempty"`
+}
+
+const (
+	// DecimalTypeFloat64 maps numeric columns to float64.
+	DecimalTypeFloat64 = "float64"
+	// DecimalTypeDecimal maps numeric columns to shopspring/decimal, which
+	// keeps their exact value.
+	DecimalTypeDecimal = "decimal"
+)
+
+// DecimalType returns the configured Go type of numeric columns, defaulting
+// to DecimalTypeFloat64.
+func (l *AndurelLock) DecimalType() string {
+	if l == nil || l.DatabaseConfig == nil || l.DatabaseConfig.DecimalType == "" {
+		return DecimalTypeFloat64
+	}
+	return l.DatabaseConfig.DecimalType
+}
+
+// NullType returns the configured nullable type strategy, defaulting to
+// "sql.Null".
+func (l *AndurelLock) NullType() string {
+	if l == nil || l.DatabaseConfig == nil || l.DatabaseConfig.NullType == "" {
+		return "sql.Null"
+	}
+	return l.DatabaseConfig.NullType
 }
 
 // ScaffoldConfig records the options used to create a project.
@@ -39,6 +68,19 @@ type ScaffoldConfig struct {
 	Database          string `json:"database"`
 	Inertia           string `json:"inertia,omitempty"`
 	JavaScriptRuntime string `json:"javascriptRuntime,omitempty"`
+	// GoVersion is the Go version the project targets, as in the go
+	// directive of go.mod. Projects created before it was recorded leave it
+	// empty, and templates then use the version the CLI scaffolds.
+	GoVersion string `json:"goVersion,omitempty"`
+}
+
+// ProjectGoVersion returns the Go version recorded in the lock, or the
+// version new projects are created with.
+func (c *ScaffoldConfig) ProjectGoVersion() string {
+	if c == nil || c.GoVersion == "" {
+		return goVersion
+	}
+	return c.GoVersion
 }
 
 // Extension records when an extension was applied.
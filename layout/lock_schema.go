@@ -94,6 +94,13 @@ func validateSchema1Lock(lock *AndurelLock) error {
 	if lock.DatabaseConfig != nil && strings.TrimSpace(lock.DatabaseConfig.NullType) == "" {
 		return fmt.Errorf("databaseConfig.nullType is required")
 	}
+	if lock.DatabaseConfig != nil {
+		switch lock.DatabaseConfig.DecimalType {
+		case "", DecimalTypeFloat64, DecimalTypeDecimal:
+		default:
+			return fmt.Errorf("databaseConfig.decimalType must be %q or %q", DecimalTypeFloat64, DecimalTypeDecimal)
+		}
+	}
 	return nil
 }
 
@@ -81,6 +81,7 @@ func TestValidateSchema1RequiredFields(t *testing.T) {
 		{name: "scaffold project", mutate: func(lock *AndurelLock) { lock.ScaffoldConfig.ProjectName = "" }, want: "projectName"},
 		{name: "scaffold database", mutate: func(lock *AndurelLock) { lock.ScaffoldConfig.Database = "" }, want: "scaffoldConfig.database"},
 		{name: "database null type", mutate: func(lock *AndurelLock) { lock.DatabaseConfig.NullType = "" }, want: "databaseConfig.nullType"},
+		{name: "database decimal type", mutate: func(lock *AndurelLock) { lock.DatabaseConfig.DecimalType = "big.Rat" }, want: "databaseConfig.decimalType"},
 		{name: "extension name", mutate: func(lock *AndurelLock) { lock.Extensions[""] = lock.Extensions["example"] }, want: "must have appliedAt"},
 		{name: "extension value", mutate: func(lock *AndurelLock) { lock.Extensions["example"] = nil }, want: "must have appliedAt"},
 		{name: "extension applied at", mutate: func(lock *AndurelLock) { lock.Extensions["example"].AppliedAt = "" }, want: "appliedAt"},
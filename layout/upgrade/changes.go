@@ -0,0 +1,127 @@
+package upgrade
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/mbvlabs/andurel/layout"
+	"golang.org/x/mod/semver"
+)
+
+//go:embed changelog.json
+var changelogJSON []byte
+
+// ChangelogEntry holds the upgrade notes for one release.
+type ChangelogEntry struct {
+	Version     string   `json:"version"`
+	Breaking    []string `json:"breaking,omitempty"`
+	ManualSteps []string `json:"manual_steps,omitempty"`
+	// Files lists the application-owned files the manual steps touch.
+	Files []string `json:"files,omitempty"`
+}
+
+// ManualFile is a project file that andurel upgrade leaves for the user to
+// migrate.
+type ManualFile struct {
+	Path    string `json:"path"`
+	Version string `json:"version,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// ChangesReport lists what changes between the project's framework version
+// and a target version.
+type ChangesReport struct {
+	FromVersion string           `json:"from_version"`
+	ToVersion   string           `json:"to_version"`
+	Changes     []ChangelogEntry `json:"changes"`
+	ManualFiles []ManualFile     `json:"manual_files"`
+}
+
+// Changelog returns the embedded changelog, oldest release first.
+func Changelog() ([]ChangelogEntry, error) {
+	var entries []ChangelogEntry
+	if err := json.Unmarshal(changelogJSON, &entries); err != nil {
+		return nil, fmt.Errorf("parse embedded changelog: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return semver.Compare(entries[i].Version, entries[j].Version) < 0
+	})
+	return entries, nil
+}
+
+// BuildChangesReport reads the project's lock file and collects the
+// changelog entries released after its framework version, up to and
+// including toVersion. It also flags the files andurel upgrade will not
+// migrate: application-owned files named by those entries, and framework
+// files that no longer carry the andurel version marker.
+func BuildChangesReport(projectRoot, toVersion string) (*ChangesReport, error) {
+	lock, err := layout.ReadLockFile(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	report := &ChangesReport{
+		FromVersion: lock.Version,
+		ToVersion:   toVersion,
+		Changes:     []ChangelogEntry{},
+		ManualFiles: []ManualFile{},
+	}
+
+	entries, err := Changelog()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !crossesVersion(lock.Version, toVersion, entry.Version) {
+			continue
+		}
+		report.Changes = append(report.Changes, entry)
+		for _, path := range entry.Files {
+			report.ManualFiles = append(report.ManualFiles, ManualFile{
+				Path:    path,
+				Version: entry.Version,
+				Reason:  "application-owned; apply the manual steps by hand",
+			})
+		}
+	}
+
+	if lock.ScaffoldConfig == nil {
+		return report, nil
+	}
+	rendered, err := NewTemplateGenerator(toVersion).RenderFrameworkTemplates(
+		projectRoot,
+		*lock.ScaffoldConfig,
+		lock.ExtensionNames(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("render framework templates: %w", err)
+	}
+	paths := make([]string, 0, len(rendered))
+	for path := range rendered {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		current, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(path)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(current, rendered[path]) || hasAndurelVersionMarker(current) {
+			continue
+		}
+		report.ManualFiles = append(report.ManualFiles, ManualFile{
+			Path:   path,
+			Reason: "framework file without the andurel version marker; upgrade leaves it unchanged",
+		})
+	}
+
+	return report, nil
+}
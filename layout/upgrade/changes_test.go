@@ -0,0 +1,123 @@
+package upgrade
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mbvlabs/andurel/layout"
+	"golang.org/x/mod/semver"
+)
+
+func TestChangelogIsOrderedAndValid(t *testing.T) {
+	entries, err := Changelog()
+	if err != nil {
+		t.Fatalf("Changelog returned error: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("expected embedded changelog entries")
+	}
+	for i, entry := range entries {
+		if semver.Canonical(entry.Version) != entry.Version {
+			t.Errorf("entry %d version %q is not a canonical release", i, entry.Version)
+		}
+		if i > 0 && semver.Compare(entries[i-1].Version, entry.Version) >= 0 {
+			t.Errorf("entry %s is not after %s", entry.Version, entries[i-1].Version)
+		}
+		if len(entry.Breaking) == 0 && len(entry.ManualSteps) == 0 {
+			t.Errorf("entry %s has no breaking changes or manual steps", entry.Version)
+		}
+	}
+}
+
+func TestBuildChangesReportSelectsReleasesInRange(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want []string
+	}{
+		{name: "crosses session recovery", from: "v1.5.2", to: "v1.5.9", want: []string{"v1.5.4"}},
+		{name: "crosses every later note", from: "v1.5.2", to: "v1.6.0", want: []string{"v1.5.4", "v1.6.0"}},
+		{name: "release candidate", from: "v1.0.0-rc.3", to: "v1.6.0", want: []string{"v1.0.0", "v1.5.4", "v1.6.0"}},
+		{name: "already past every note", from: "v1.6.0", to: "v1.7.0", want: []string{}},
+		{name: "target before note", from: "v1.5.2", to: "v1.5.3", want: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			projectRoot := t.TempDir()
+			lock := &layout.AndurelLock{SchemaVersion: 1, Version: test.from, Tools: map[string]*layout.Tool{}}
+			if err := lock.WriteLockFile(projectRoot); err != nil {
+				t.Fatalf("failed to write lock file: %v", err)
+			}
+
+			report, err := BuildChangesReport(projectRoot, test.to)
+			if err != nil {
+				t.Fatalf("BuildChangesReport returned error: %v", err)
+			}
+			got := []string{}
+			for _, entry := range report.Changes {
+				got = append(got, entry.Version)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Fatalf("changes = %v, want %v", got, test.want)
+			}
+			if report.FromVersion != test.from || report.ToVersion != test.to {
+				t.Fatalf("report versions = %s -> %s", report.FromVersion, report.ToVersion)
+			}
+		})
+	}
+}
+
+func TestBuildChangesReportFlagsFilesUpgradeCannotMigrate(t *testing.T) {
+	projectRoot := newGitUpgradeProject(t)
+	lock := &layout.AndurelLock{
+		SchemaVersion:  1,
+		Version:        "v1.5.0",
+		Tools:          map[string]*layout.Tool{},
+		ScaffoldConfig: &layout.ScaffoldConfig{ProjectName: "myapp", Database: "postgres"},
+	}
+	if err := lock.WriteLockFile(projectRoot); err != nil {
+		t.Fatalf("failed to write lock file: %v", err)
+	}
+
+	rendered, err := NewTemplateGenerator("v1.6.0").RenderFrameworkTemplates(projectRoot, *lock.ScaffoldConfig, nil)
+	if err != nil {
+		t.Fatalf("RenderFrameworkTemplates returned error: %v", err)
+	}
+	const unmarked = "internal/storage/psql.go"
+	const marked = "internal/storage/retry.go"
+	for path, content := range map[string][]byte{
+		unmarked: []byte("package storage\n\n// Hand-edited connection setup.\n"),
+		marked:   append([]byte("// Code generated by andurel v1.5.0; DO NOT EDIT.\n"), rendered[marked]...),
+	} {
+		fullPath := filepath.Join(projectRoot, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(fullPath, content, 0o644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+
+	report, err := BuildChangesReport(projectRoot, "v1.6.0")
+	if err != nil {
+		t.Fatalf("BuildChangesReport returned error: %v", err)
+	}
+
+	paths := map[string]ManualFile{}
+	for _, file := range report.ManualFiles {
+		paths[file.Path] = file
+	}
+	if file, ok := paths["router/middleware/middleware.go"]; !ok || file.Version != "v1.5.4" {
+		t.Fatalf("expected the v1.5.4 middleware file to be flagged, got %#v", report.ManualFiles)
+	}
+	if file, ok := paths[unmarked]; !ok || file.Version != "" {
+		t.Fatalf("expected %s without a version marker to be flagged, got %#v", unmarked, report.ManualFiles)
+	}
+	if _, ok := paths[marked]; ok {
+		t.Fatalf("%s carries the version marker and should be upgraded automatically", marked)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/mbvlabs/andurel/layout"
 	"github.com/mbvlabs/andurel/layout/templates"
+	"github.com/mbvlabs/andurel/pkg/templatefuncs"
 )
 
 // FrameworkTemplate represents a framework element template and its target path
@@ -92,6 +93,7 @@ func (g *TemplateGenerator) buildTemplateData(
 		ProjectName:      config.ProjectName,
 		ModuleName:       modulePath,
 		Database:         config.Database,
+		GoVersion:        config.ProjectGoVersion(),
 		Extensions:       extensions,
 		RunToolVersion:   layout.GetRunToolVersion(),
 		FrameworkVersion: frameworkVersion,
@@ -137,12 +139,8 @@ func renderTemplateToBytes(
 		return nil, fmt.Errorf("failed to read template %s: %w", templateFile, err)
 	}
 
-	funcMap := template.FuncMap{
-		"lower": strings.ToLower,
-	}
-
 	tmpl, err := template.New(templateFile).
-		Funcs(funcMap).
+		Funcs(templatefuncs.FuncMap()).
 		Parse(string(content))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template %s: %w", templateFile, err)
@@ -0,0 +1,237 @@
+// Package htmlcheck validates rendered HTML and applies basic accessibility
+// rules: images need alt text, form controls need a label, and headings must
+// not skip levels.
+package htmlcheck
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Kind classifies a Problem.
+type Kind string
+
+const (
+	// Markup marks an end tag without a matching start tag, or an element
+	// that is never closed.
+	Markup Kind = "markup"
+	// DuplicateID marks an id attribute used by more than one element.
+	DuplicateID Kind = "duplicate_id"
+	// MissingAlt marks an image without an alt attribute. An empty alt is
+	// allowed, since it marks a decorative image.
+	MissingAlt Kind = "missing_alt"
+	// UnlabeledControl marks an input, select or textarea without a label,
+	// aria-label, aria-labelledby or title.
+	UnlabeledControl Kind = "unlabeled_control"
+	// LabelTarget marks a label whose for attribute names no element.
+	LabelTarget Kind = "label_target"
+	// HeadingOrder marks a heading more than one level below the heading
+	// before it, such as an h4 after an h2.
+	HeadingOrder Kind = "heading_order"
+)
+
+// Problem is a single issue found in a document.
+type Problem struct {
+	Kind    Kind   `json:"kind"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// Blocking reports whether the problem makes the document invalid HTML.
+// Accessibility problems are not blocking.
+func (p Problem) Blocking() bool {
+	return p.Kind == Markup || p.Kind == DuplicateID
+}
+
+// String formats the problem as line N: message.
+func (p Problem) String() string {
+	return fmt.Sprintf("line %d: %s", p.Line, p.Message)
+}
+
+// voidElements never have an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// optionalEndTags are closed implicitly by their parent's end tag.
+var optionalEndTags = map[string]bool{
+	"p": true, "li": true, "dt": true, "dd": true, "option": true,
+	"optgroup": true, "tr": true, "td": true, "th": true, "thead": true,
+	"tbody": true, "tfoot": true, "colgroup": true,
+}
+
+// unlabeledInputTypes are input types that need no label: they are hidden or
+// labeled by their value.
+var unlabeledInputTypes = map[string]bool{
+	"hidden": true, "submit": true, "button": true, "reset": true, "image": true,
+}
+
+type element struct {
+	name string
+	line int
+}
+
+type control struct {
+	name    string
+	id      string
+	line    int
+	labeled bool
+}
+
+type labelFor struct {
+	target string
+	line   int
+}
+
+// Check reads an HTML document or fragment from r and returns its problems
+// ordered by line.
+func Check(r io.Reader) ([]Problem, error) {
+	var (
+		problems []Problem
+		open     []element
+		ids      = map[string]int{}
+		controls []control
+		labels   []labelFor
+		heading  int
+		inLabel  int
+	)
+	add := func(kind Kind, line int, format string, args ...any) {
+		problems = append(problems, Problem{Kind: kind, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	tokenizer := html.NewTokenizer(r)
+	line := 1
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == html.ErrorToken {
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			break
+		}
+		start := line
+		line += strings.Count(string(tokenizer.Raw()), "\n")
+
+		token := tokenizer.Token()
+		name := token.Data
+		switch tokenType {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			attrs := attributes(token)
+
+			if id, ok := attrs["id"]; ok && id != "" {
+				if first, seen := ids[id]; seen {
+					add(DuplicateID, start, "id %q is already used on line %d", id, first)
+				} else {
+					ids[id] = start
+				}
+			}
+
+			switch name {
+			case "img":
+				if _, ok := attrs["alt"]; !ok {
+					add(MissingAlt, start, "<img> has no alt text")
+				}
+			case "input", "select", "textarea":
+				if name == "input" && unlabeledInputTypes[strings.ToLower(attrs["type"])] {
+					break
+				}
+				controls = append(controls, control{
+					name:    name,
+					id:      attrs["id"],
+					line:    start,
+					labeled: inLabel > 0 || attrs["aria-label"] != "" || attrs["aria-labelledby"] != "" || attrs["title"] != "",
+				})
+			case "label":
+				if target, ok := attrs["for"]; ok {
+					labels = append(labels, labelFor{target: target, line: start})
+				}
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				level := int(name[1] - '0')
+				if heading > 0 && level > heading+1 {
+					add(HeadingOrder, start, "<%s> follows <h%d> and skips a heading level", name, heading)
+				}
+				heading = level
+			}
+
+			if tokenType == html.SelfClosingTagToken || voidElements[name] {
+				break
+			}
+			if name == "label" {
+				inLabel++
+			}
+			open = append(open, element{name: name, line: start})
+
+		case html.EndTagToken:
+			if voidElements[name] {
+				break
+			}
+			match := -1
+			for i, e := range slices.Backward(open) {
+				if e.name == name {
+					match = i
+					break
+				}
+			}
+			if match < 0 {
+				add(Markup, start, "</%s> has no matching <%s>", name, name)
+				break
+			}
+			for _, inner := range open[match+1:] {
+				if !optionalEndTags[inner.name] {
+					add(Markup, inner.line, "<%s> is not closed before </%s> on line %d", inner.name, name, start)
+				}
+			}
+			for _, closed := range open[match:] {
+				if closed.name == "label" {
+					inLabel--
+				}
+			}
+			open = open[:match]
+		}
+	}
+
+	for _, unclosed := range open {
+		if !optionalEndTags[unclosed.name] {
+			add(Markup, unclosed.line, "<%s> is never closed", unclosed.name)
+		}
+	}
+
+	labeled := map[string]bool{}
+	for _, label := range labels {
+		if _, ok := ids[label.target]; !ok {
+			add(LabelTarget, label.line, "<label for=%q> names no element", label.target)
+		}
+		labeled[label.target] = true
+	}
+	for _, c := range controls {
+		if !c.labeled && (c.id == "" || !labeled[c.id]) {
+			add(UnlabeledControl, c.line, "<%s> has no label", describe(c))
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems, nil
+}
+
+func attributes(token html.Token) map[string]string {
+	attrs := make(map[string]string, len(token.Attr))
+	for _, attr := range token.Attr {
+		attrs[attr.Key] = attr.Val
+	}
+	return attrs
+}
+
+func describe(c control) string {
+	if c.id != "" {
+		return fmt.Sprintf("%s id=%q", c.name, c.id)
+	}
+	return c.name
+}
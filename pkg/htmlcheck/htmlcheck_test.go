@@ -0,0 +1,79 @@
+package htmlcheck
+
+import (
+	"strings"
+	"testing"
+)
+
+func check(t *testing.T, document string) []Problem {
+	t.Helper()
+	problems, err := Check(strings.NewReader(document))
+	if err != nil {
+		t.Fatalf("Check returned an error: %v", err)
+	}
+	return problems
+}
+
+func TestCheckPassesAccessibleMarkup(t *testing.T) {
+	problems := check(t, `<main>
+<h1>Products</h1>
+<img src="/logo.svg" alt="">
+<form>
+<input type="hidden" name="csrf">
+<label for="name">Name</label>
+<input id="name" name="name">
+<label>Notes <textarea name="notes"></textarea></label>
+<select name="status" aria-label="Status"><option>Draft<option>Published</select>
+<h2>Details</h2>
+<h3>Price</h3>
+<h2>Stock</h2>
+<ul><li>One<li>Two</ul>
+<p>Text
+<svg viewBox="0 0 1 1"><path d="M0 0"/></svg>
+<button type="submit">Save</button>
+</form>
+</main>`)
+
+	if len(problems) != 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+}
+
+func TestCheckReportsProblems(t *testing.T) {
+	problems := check(t, `<main>
+<h1>Products</h1>
+<img src="/logo.svg">
+<h3>Details</h3>
+<input id="name" name="name">
+<label for="missing">Price</label>
+<div id="name"><span>
+</div>
+</section>
+<div>`)
+
+	want := []struct {
+		kind Kind
+		line int
+	}{
+		{Markup, 1},
+		{MissingAlt, 3},
+		{HeadingOrder, 4},
+		{UnlabeledControl, 5},
+		{LabelTarget, 6},
+		{DuplicateID, 7},
+		{Markup, 7},
+		{Markup, 9},
+		{Markup, 10},
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), problems)
+	}
+	for i, w := range want {
+		if problems[i].Kind != w.kind || problems[i].Line != w.line {
+			t.Errorf("problem %d = %s (%s), want %s on line %d", i, problems[i], problems[i].Kind, w.kind, w.line)
+		}
+	}
+	if !problems[0].Blocking() || problems[1].Blocking() {
+		t.Fatalf("expected only invalid markup to block, got %v and %v", problems[0], problems[1])
+	}
+}
@@ -0,0 +1,183 @@
+package naming
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jinzhu/inflection"
+	"gopkg.in/yaml.v3"
+)
+
+// InflectionsFileName is the project file that declares irregular plurals,
+// uncountable words and acronyms.
+const InflectionsFileName = "andurel.inflections.yaml"
+
+type inflectionsFile struct {
+	Acronyms    []string          `yaml:"acronyms"`
+	Irregular   map[string]string `yaml:"irregular"`
+	Uncountable []string          `yaml:"uncountable"`
+}
+
+var (
+	inflectionWord = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+	acronymWord    = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+	builtinIrregular = inflection.GetIrregular()
+
+	acronymsMu sync.RWMutex
+	acronyms   = map[string]string{}
+)
+
+// Plural returns the plural of a snake_case or PascalCase name. A declared
+// acronym ending the name keeps its spelling, so ItemSKU becomes ItemSKUs
+// where the inflection rules alone would give ItemSKUS.
+func Plural(name string) string {
+	if prefix, acronym, ok := trailingAcronym(name); ok {
+		return prefix + CapitalizeWord(inflection.Plural(strings.ToLower(acronym)))
+	}
+	return inflection.Plural(name)
+}
+
+// Singular returns the singular of a snake_case or PascalCase name, the
+// inverse of Plural.
+func Singular(name string) string {
+	acronymsMu.RLock()
+	defer acronymsMu.RUnlock()
+
+	longest := ""
+	for word, acronym := range acronyms {
+		plural := pluralAcronym(word, acronym)
+		if plural != acronym && strings.HasSuffix(name, plural) && len(plural) > len(longest) {
+			longest = plural
+		}
+	}
+	if longest != "" {
+		singular := inflection.Singular(strings.ToLower(longest))
+		return strings.TrimSuffix(name, longest) + acronyms[singular]
+	}
+	return inflection.Singular(name)
+}
+
+// trailingAcronym splits name before the longest declared acronym it ends
+// with, spelled as declared.
+func trailingAcronym(name string) (string, string, bool) {
+	acronymsMu.RLock()
+	defer acronymsMu.RUnlock()
+
+	longest := ""
+	for _, acronym := range acronyms {
+		if strings.HasSuffix(name, acronym) && len(acronym) > len(longest) {
+			longest = acronym
+		}
+	}
+	return strings.TrimSuffix(name, longest), longest, longest != ""
+}
+
+// pluralAcronym spells the plural of an acronym by appending the suffix the
+// inflection rules add to the lower-case word: SKU becomes SKUs.
+func pluralAcronym(word, acronym string) string {
+	plural := inflection.Plural(word)
+	if !strings.HasPrefix(plural, word) {
+		return acronym
+	}
+	return acronym + plural[len(word):]
+}
+
+// LoadInflections applies the rules declared in andurel.inflections.yaml in
+// rootDir, replacing the ones loaded before. Irregular and uncountable words
+// match the end of a name, so person: people also pluralizes sales_person,
+// and take precedence over the built-in rules. A project without the file
+// uses the built-in rules.
+func LoadInflections(rootDir string) error {
+	content, err := os.ReadFile(filepath.Join(rootDir, InflectionsFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		content = nil
+	} else if err != nil {
+		return err
+	}
+
+	var file inflectionsFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return fmt.Errorf("%s: %w", InflectionsFileName, err)
+	}
+
+	loadedAcronyms := make(map[string]string, len(file.Acronyms))
+	for i, acronym := range file.Acronyms {
+		acronym = strings.TrimSpace(acronym)
+		if !acronymWord.MatchString(acronym) {
+			return fmt.Errorf("%s: acronyms[%d]: %q must be a single word of letters and digits starting with a capital", InflectionsFileName, i, acronym)
+		}
+		loadedAcronyms[strings.ToLower(acronym)] = acronym
+	}
+
+	singulars := make([]string, 0, len(file.Irregular))
+	for singular := range file.Irregular {
+		singulars = append(singulars, singular)
+	}
+	sort.Strings(singulars)
+
+	for _, singular := range singulars {
+		for _, word := range []string{singular, file.Irregular[singular]} {
+			if !inflectionWord.MatchString(word) {
+				return fmt.Errorf("%s: irregular: %q must be a lower-case word", InflectionsFileName, word)
+			}
+		}
+	}
+	uncountable := make([]string, 0, len(file.Uncountable))
+	for i, word := range file.Uncountable {
+		word = strings.TrimSpace(word)
+		if !inflectionWord.MatchString(word) {
+			return fmt.Errorf("%s: uncountable[%d]: %q must be a lower-case word", InflectionsFileName, i, word)
+		}
+		uncountable = append(uncountable, word)
+	}
+
+	// The library checks irregular words in order, so the project's go
+	// first: a declared human: humans must win over the built-in man: men.
+	// Uncountable words are irregular words with the same plural, since the
+	// library's own uncountable list only matches whole names.
+	inflection.SetIrregular(nil)
+	for _, singular := range singulars {
+		inflection.AddIrregular(singular, file.Irregular[singular])
+	}
+	for _, word := range uncountable {
+		inflection.AddIrregular(word, word)
+	}
+	inflection.SetIrregular(append(inflection.GetIrregular(), builtinIrregular...))
+
+	acronymsMu.Lock()
+	acronyms = loadedAcronyms
+	acronymsMu.Unlock()
+
+	return nil
+}
+
+// CapitalizeWord returns a lower-case word as it appears in a Go
+// identifier: the spelling of a declared acronym, such as SKU for sku and
+// SKUs for skus, or the word with its first letter upper-cased.
+func CapitalizeWord(word string) string {
+	if word == "" {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+
+	acronymsMu.RLock()
+	defer acronymsMu.RUnlock()
+	if acronym, ok := acronyms[lower]; ok {
+		return acronym
+	}
+	if singular := inflection.Singular(lower); singular != lower {
+		if acronym, ok := acronyms[singular]; ok {
+			return pluralAcronym(singular, acronym)
+		}
+	}
+
+	return strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+}
@@ -0,0 +1,98 @@
+package naming
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadInflections(t *testing.T) {
+	rootDir := t.TempDir()
+	writeInflections(t, rootDir, `acronyms:
+  - SKU
+  - OAuth
+irregular:
+  human: humans
+  criterion: criteria
+uncountable:
+  - metadata
+`)
+	t.Cleanup(func() {
+		if err := LoadInflections(t.TempDir()); err != nil {
+			t.Fatalf("reset inflections: %v", err)
+		}
+	})
+
+	if err := LoadInflections(rootDir); err != nil {
+		t.Fatalf("LoadInflections: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "project irregular wins over a built-in one", got: DeriveTableName("Human"), want: "humans"},
+		{name: "irregular in a compound name", got: DeriveTableName("SearchCriterion"), want: "search_criteria"},
+		{name: "irregular singular", got: DeriveResourceName("search_criteria"), want: "SearchCriterion"},
+		{name: "uncountable in a compound name", got: DeriveTableName("ProductMetadata"), want: "product_metadata"},
+		{name: "built-in irregular", got: DeriveTableName("SalesPerson"), want: "sales_people"},
+		{name: "acronym in a resource name", got: DeriveResourceName("skus"), want: "SKU"},
+		{name: "acronym in pascal case", got: ToPascalCase("item_sku"), want: "ItemSKU"},
+		{name: "acronym spelling", got: ToPascalCase("oauth_client"), want: "OAuthClient"},
+		{name: "acronym in camel case", got: ToCamelCase("item_sku"), want: "itemSKU"},
+		{name: "plural acronym", got: ToPascalCase("skus"), want: "SKUs"},
+		{name: "plural of a name ending in an acronym", got: Plural("ItemSKU"), want: "ItemSKUs"},
+		{name: "singular of a name ending in an acronym", got: Singular("ItemSKUs"), want: "ItemSKU"},
+		{name: "table of a name ending in an acronym", got: DeriveTableName("ItemSKU"), want: "item_skus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Fatalf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+
+	if err := LoadInflections(t.TempDir()); err != nil {
+		t.Fatalf("LoadInflections without a file: %v", err)
+	}
+	if got := DeriveTableName("Human"); got != "humen" {
+		t.Fatalf("DeriveTableName(Human) after reset = %q, want the built-in humen", got)
+	}
+	if got := ToPascalCase("item_sku"); got != "ItemSku" {
+		t.Fatalf("ToPascalCase(item_sku) after reset = %q, want ItemSku", got)
+	}
+}
+
+func TestLoadInflectionsRejectsInvalidWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "acronym with a separator", content: "acronyms: [\"S-KU\"]\n", want: `acronyms[0]: "S-KU"`},
+		{name: "acronym starting lower case", content: "acronyms: [\"iOS\"]\n", want: `acronyms[0]: "iOS"`},
+		{name: "irregular with upper case", content: "irregular:\n  Person: people\n", want: `irregular: "Person"`},
+		{name: "uncountable with a space", content: "uncountable: [\"meta data\"]\n", want: `uncountable[0]: "meta data"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootDir := t.TempDir()
+			writeInflections(t, rootDir, tt.content)
+
+			err := LoadInflections(rootDir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("LoadInflections error = %v, want it to mention %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func writeInflections(t *testing.T, rootDir, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(rootDir, InflectionsFileName), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
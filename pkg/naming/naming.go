@@ -6,14 +6,12 @@ import (
 	"regexp"
 	"strings"
 	"unicode"
-
-	"github.com/jinzhu/inflection"
 )
 
 // DeriveTableName converts a CamelCase resource name into a snake_case plural table name.
 func DeriveTableName(resourceName string) string {
 	snake := ToSnakeCase(resourceName)
-	return inflection.Plural(snake)
+	return Plural(snake)
 }
 
 // ToSnakeCase converts a CamelCase identifier into snake_case.
@@ -64,12 +62,7 @@ func ToCamelCase(s string) string {
 
 	// Capitalize first letter of remaining parts
 	for i := 1; i < len(parts); i++ {
-		if len(parts[i]) > 0 {
-			builder.WriteString(strings.ToUpper(parts[i][:1]))
-			if len(parts[i]) > 1 {
-				builder.WriteString(strings.ToLower(parts[i][1:]))
-			}
-		}
+		builder.WriteString(CapitalizeWord(parts[i]))
 	}
 
 	return builder.String()
@@ -138,12 +131,7 @@ func ToPascalCase(s string) string {
 
 	// Capitalize first letter of each part
 	for _, part := range parts {
-		if len(part) > 0 {
-			builder.WriteString(strings.ToUpper(part[:1]))
-			if len(part) > 1 {
-				builder.WriteString(strings.ToLower(part[1:]))
-			}
-		}
+		builder.WriteString(CapitalizeWord(part))
 	}
 
 	return builder.String()
@@ -253,7 +241,7 @@ func NamespaceFilePrefix(namespace string) string {
 // DeriveResourceName converts a snake_case plural table name into a PascalCase singular resource name.
 // Examples: "user_roles" -> "UserRole", "products" -> "Product", "admin_users" -> "AdminUser"
 func DeriveResourceName(tableName string) string {
-	singular := inflection.Singular(tableName)
+	singular := Singular(tableName)
 	return ToPascalCase(singular)
 }
 
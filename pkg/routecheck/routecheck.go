@@ -0,0 +1,317 @@
+// Package routecheck finds drift between the actions of generated controllers
+// and the routes their RegisterRoutes methods add.
+package routecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const echoImportPath = "github.com/labstack/echo/v5"
+
+// Kind classifies a Problem.
+type Kind string
+
+const (
+	// MissingHandler marks a route whose handler is not a method of the
+	// controller.
+	MissingHandler Kind = "missing_handler"
+	// HandlerSignature marks a route whose handler method does not have the
+	// func(*echo.Context) error signature.
+	HandlerSignature Kind = "handler_signature"
+	// DuplicateRoute marks a handler added more than once with the same
+	// method and path.
+	DuplicateRoute Kind = "duplicate_route"
+	// UnregisteredAction marks an exported handler method that
+	// RegisterRoutes never references.
+	UnregisteredAction Kind = "unregistered_action"
+)
+
+// Problem is a single mismatch between a controller and its routes.
+type Problem struct {
+	Kind       Kind   `json:"kind"`
+	Controller string `json:"controller"`
+	Action     string `json:"action"`
+	File       string `json:"file"`
+	Line       int    `json:"line"`
+	Message    string `json:"message"`
+}
+
+// Blocking reports whether the problem breaks routing. Unregistered actions
+// are dead code rather than broken routes.
+func (p Problem) Blocking() bool {
+	return p.Kind != UnregisteredAction
+}
+
+// String formats the problem as file:line: message.
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+}
+
+type method struct {
+	handler bool
+	file    string
+	line    int
+}
+
+type controller struct {
+	name      string
+	methods   map[string]method
+	register  *ast.FuncDecl
+	file      string
+	src       []byte
+	fset      *token.FileSet
+	echoNames map[string]bool
+}
+
+// Check parses the Go files in dir, usually a project's controllers
+// directory, and returns the problems of every type with a RegisterRoutes
+// method, ordered by file and line. Test files are skipped. A missing
+// directory has no problems.
+func Check(dir string) ([]Problem, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	controllers := map[string]*controller{}
+	lookup := func(name string) *controller {
+		c, ok := controllers[name]
+		if !ok {
+			c = &controller{name: name, methods: map[string]method{}, fset: fset}
+			controllers[name] = c
+		}
+		return c
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		path := filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		echoNames := echoImportNames(file)
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+				continue
+			}
+			typeName := receiverTypeName(fn.Recv.List[0].Type)
+			if typeName == "" {
+				continue
+			}
+
+			c := lookup(typeName)
+			c.methods[fn.Name.Name] = method{
+				handler: isHandlerSignature(fn.Type, echoNames),
+				file:    path,
+				line:    fset.Position(fn.Pos()).Line,
+			}
+			if fn.Name.Name == "RegisterRoutes" {
+				c.register = fn
+				c.file = path
+				c.src = src
+				c.echoNames = echoNames
+			}
+		}
+	}
+
+	var problems []Problem
+	for _, c := range controllers {
+		if c.register != nil {
+			problems = append(problems, c.check()...)
+		}
+	}
+
+	sort.Slice(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+
+	return problems, nil
+}
+
+func (c *controller) check() []Problem {
+	var problems []Problem
+	receiver := receiverName(c.register)
+	referenced := map[string]bool{}
+	seen := map[string]bool{}
+
+	ast.Inspect(c.register.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CompositeLit:
+			if !isEchoRoute(node.Type, c.echoNames) {
+				return true
+			}
+
+			fields := map[string]ast.Expr{}
+			for _, elt := range node.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fields[key.Name] = kv.Value
+				}
+			}
+
+			action, ok := receiverSelector(fields["Handler"], receiver)
+			if !ok {
+				return true
+			}
+			line := c.fset.Position(fields["Handler"].Pos()).Line
+
+			m, exists := c.methods[action]
+			switch {
+			case !exists:
+				problems = append(problems, c.problem(MissingHandler, action, c.file, line,
+					"route handler %s.%s is not a method of %s", receiver, action, c.name))
+			case !m.handler:
+				problems = append(problems, c.problem(HandlerSignature, action, c.file, line,
+					"route handler %s.%s must have the signature func(*echo.Context) error", c.name, action))
+			}
+
+			key := action + " " + c.exprString(fields["Method"]) + " " + c.exprString(fields["Path"])
+			if seen[key] {
+				problems = append(problems, c.problem(DuplicateRoute, action, c.file, line,
+					"%s.%s is added more than once for %s %s", c.name, action, c.exprString(fields["Method"]), c.exprString(fields["Path"])))
+			}
+			seen[key] = true
+
+		case *ast.SelectorExpr:
+			if action, ok := receiverSelector(node, receiver); ok {
+				referenced[action] = true
+			}
+		}
+		return true
+	})
+
+	for action, m := range c.methods {
+		if !m.handler || !ast.IsExported(action) || referenced[action] {
+			continue
+		}
+		problems = append(problems, c.problem(UnregisteredAction, action, m.file, m.line,
+			"%s.%s is not registered in RegisterRoutes", c.name, action))
+	}
+
+	return problems
+}
+
+func (c *controller) problem(kind Kind, action, file string, line int, format string, args ...any) Problem {
+	return Problem{
+		Kind:       kind,
+		Controller: c.name,
+		Action:     action,
+		File:       file,
+		Line:       line,
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+func (c *controller) exprString(expr ast.Expr) string {
+	if expr == nil {
+		return ""
+	}
+	start := c.fset.Position(expr.Pos()).Offset
+	end := c.fset.Position(expr.End()).Offset
+	return string(c.src[start:end])
+}
+
+func echoImportNames(file *ast.File) map[string]bool {
+	names := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || path != echoImportPath {
+			continue
+		}
+		if spec.Name != nil {
+			names[spec.Name.Name] = true
+		} else {
+			names["echo"] = true
+		}
+	}
+	return names
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if names := fn.Recv.List[0].Names; len(names) == 1 {
+		return names[0].Name
+	}
+	return ""
+}
+
+func receiverSelector(expr ast.Expr, receiver string) (string, bool) {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || receiver == "" {
+		return "", false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok || ident.Name != receiver {
+		return "", false
+	}
+	return sel.Sel.Name, true
+}
+
+func isEchoSelector(expr ast.Expr, name string, echoNames map[string]bool) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && echoNames[pkg.Name]
+}
+
+func isEchoRoute(expr ast.Expr, echoNames map[string]bool) bool {
+	return isEchoSelector(expr, "Route", echoNames)
+}
+
+func isHandlerSignature(fn *ast.FuncType, echoNames map[string]bool) bool {
+	if fn.TypeParams != nil || fn.Params == nil || fn.Results == nil {
+		return false
+	}
+	if len(fn.Params.List) != 1 || len(fn.Params.List[0].Names) > 1 {
+		return false
+	}
+	star, ok := fn.Params.List[0].Type.(*ast.StarExpr)
+	if !ok || !isEchoSelector(star.X, "Context", echoNames) {
+		return false
+	}
+	if len(fn.Results.List) != 1 || len(fn.Results.List[0].Names) > 1 {
+		return false
+	}
+	result, ok := fn.Results.List[0].Type.(*ast.Ident)
+	return ok && result.Name == "error"
+}
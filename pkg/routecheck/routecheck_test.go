@@ -0,0 +1,125 @@
+package routecheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const productsController = `package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+type Products struct{}
+
+func (p Products) RegisterRoutes(r *router.Router) error {
+	_, err := r.AddRoute(echo.Route{
+		Method:  http.MethodGet,
+		Path:    routes.ProductIndex.Path(),
+		Handler: p.Index,
+	})
+	_, err = r.AddRoute(echo.Route{
+		Method:  http.MethodHead,
+		Path:    routes.ProductIndex.Path(),
+		Handler: p.Index,
+	})
+	_ = r.AddRouteNotFound(p.NotFound)
+	return err
+}
+
+func (p Products) Index(etx *echo.Context) error { return nil }
+
+func (p Products) NotFound(etx *echo.Context) error { return nil }
+
+func (p Products) render(etx *echo.Context) error { return nil }
+`
+
+func writeController(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestCheckPassesRegisteredControllers(t *testing.T) {
+	dir := t.TempDir()
+	writeController(t, dir, "products.go", productsController)
+	writeController(t, dir, "helpers.go", `package controllers
+
+type Cache struct{}
+
+func (c Cache) Get(key string) string { return key }
+`)
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check returned an error: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Fatalf("expected no problems, got %v", problems)
+	}
+}
+
+func TestCheckReportsDrift(t *testing.T) {
+	dir := t.TempDir()
+	writeController(t, dir, "orders.go", `package controllers
+
+import (
+	"net/http"
+
+	ec "github.com/labstack/echo/v5"
+)
+
+type Orders struct{}
+
+func (o *Orders) RegisterRoutes(r *router.Router) error {
+	_, _ = r.AddRoute(ec.Route{Method: http.MethodGet, Path: routes.OrderIndex.Path(), Handler: o.List})
+	_, _ = r.AddRoute(ec.Route{Method: http.MethodGet, Path: routes.OrderShow.Path(), Handler: o.Show})
+	_, _ = r.AddRoute(ec.Route{Method: http.MethodGet, Path: routes.OrderShow.Path(), Handler: o.Show})
+	_, _ = r.AddRoute(ec.Route{Method: http.MethodPost, Path: routes.OrderCreate.Path(), Handler: o.Create})
+	return nil
+}
+
+func (o *Orders) Show(etx *ec.Context) error { return nil }
+
+func (o *Orders) Create(etx *ec.Context) (string, error) { return "", nil }
+`)
+	writeController(t, dir, "orders_export.go", `package controllers
+
+import "github.com/labstack/echo/v5"
+
+func (o *Orders) Export(etx *echo.Context) error { return nil }
+`)
+
+	problems, err := Check(dir)
+	if err != nil {
+		t.Fatalf("Check returned an error: %v", err)
+	}
+
+	want := []Kind{MissingHandler, DuplicateRoute, HandlerSignature, UnregisteredAction}
+	if len(problems) != len(want) {
+		t.Fatalf("expected %d problems, got %v", len(want), problems)
+	}
+	for i, kind := range want {
+		if problems[i].Kind != kind {
+			t.Errorf("problem %d kind = %q, want %q (%s)", i, problems[i].Kind, kind, problems[i])
+		}
+	}
+	if problems[3].Action != "Export" || problems[3].Blocking() {
+		t.Errorf("expected a non-blocking unregistered Export action, got %+v", problems[3])
+	}
+	if problems[0].Line != 12 {
+		t.Errorf("missing handler line = %d, want 12", problems[0].Line)
+	}
+}
+
+func TestCheckMissingDirectory(t *testing.T) {
+	problems, err := Check(filepath.Join(t.TempDir(), "controllers"))
+	if err != nil || problems != nil {
+		t.Fatalf("expected no problems and no error, got %v, %v", problems, err)
+	}
+}
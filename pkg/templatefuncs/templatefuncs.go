@@ -0,0 +1,128 @@
+// Package templatefuncs provides the helper functions shared by layout,
+// extension, and generator templates.
+package templatefuncs
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/mbvlabs/andurel/pkg/naming"
+)
+
+// FuncMap returns a new map of the shared template functions. Callers may add
+// their own functions to it before parsing a template.
+//
+//	lower, upper      change the case of a string
+//	camelCase         "blog_post" -> "blogPost"
+//	pascalCase        "blog_post" -> "BlogPost"
+//	snakeCase         "BlogPost" -> "blog_post"
+//	kebabCase         "BlogPost" -> "blog-post"
+//	title             "blog_post" -> "Blog Post"
+//	plural, singular  inflect an English noun, "post" <-> "posts"
+//	quote             a double-quoted Go string literal
+//	hasExtension      whether a list of extension names contains one
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"lower":        strings.ToLower,
+		"upper":        strings.ToUpper,
+		"camelCase":    CamelCase,
+		"pascalCase":   PascalCase,
+		"snakeCase":    SnakeCase,
+		"kebabCase":    KebabCase,
+		"title":        Title,
+		"plural":       naming.Plural,
+		"singular":     naming.Singular,
+		"quote":        strconv.Quote,
+		"hasExtension": HasExtension,
+	}
+}
+
+// HasExtension reports whether extensions contains name.
+func HasExtension(extensions []string, name string) bool {
+	return slices.Contains(extensions, name)
+}
+
+// CamelCase joins the words of s in camelCase.
+func CamelCase(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(word)
+			continue
+		}
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// PascalCase joins the words of s in PascalCase.
+func PascalCase(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase joins the lowercased words of s with underscores.
+func SnakeCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "_"))
+}
+
+// KebabCase joins the lowercased words of s with hyphens.
+func KebabCase(s string) string {
+	return strings.ToLower(strings.Join(Words(s), "-"))
+}
+
+// Title capitalizes the words of s and joins them with spaces.
+func Title(s string) string {
+	words := Words(s)
+	for i, word := range words {
+		words[i] = capitalize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Words splits s into words at underscores, hyphens, spaces, and case
+// changes, keeping acronyms together: "HTTPServer_config" is "HTTP",
+// "Server", "config".
+func Words(s string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = current[:0]
+		}
+	}
+
+	for i, r := range runes {
+		if r == '_' || r == '-' || unicode.IsSpace(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && len(current) > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+	return words
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	if len(runes) == 0 {
+		return word
+	}
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
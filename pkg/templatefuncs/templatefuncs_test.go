@@ -0,0 +1,69 @@
+package templatefuncs
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestCaseConversions(t *testing.T) {
+	tests := []struct {
+		input  string
+		camel  string
+		pascal string
+		snake  string
+		kebab  string
+		title  string
+	}{
+		{"blog_post", "blogPost", "BlogPost", "blog_post", "blog-post", "Blog Post"},
+		{"BlogPost", "blogPost", "BlogPost", "blog_post", "blog-post", "Blog Post"},
+		{"blogPost", "blogPost", "BlogPost", "blog_post", "blog-post", "Blog Post"},
+		{"aws-ses", "awsSes", "AwsSes", "aws_ses", "aws-ses", "Aws Ses"},
+		{"APIKey", "apiKey", "ApiKey", "api_key", "api-key", "Api Key"},
+		{"order item2 count", "orderItem2Count", "OrderItem2Count", "order_item2_count", "order-item2-count", "Order Item2 Count"},
+		{"", "", "", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := CamelCase(tt.input); got != tt.camel {
+				t.Errorf("CamelCase = %q, want %q", got, tt.camel)
+			}
+			if got := PascalCase(tt.input); got != tt.pascal {
+				t.Errorf("PascalCase = %q, want %q", got, tt.pascal)
+			}
+			if got := SnakeCase(tt.input); got != tt.snake {
+				t.Errorf("SnakeCase = %q, want %q", got, tt.snake)
+			}
+			if got := KebabCase(tt.input); got != tt.kebab {
+				t.Errorf("KebabCase = %q, want %q", got, tt.kebab)
+			}
+			if got := Title(tt.input); got != tt.title {
+				t.Errorf("Title = %q, want %q", got, tt.title)
+			}
+		})
+	}
+}
+
+func TestFuncMapInTemplate(t *testing.T) {
+	const text = `{{plural "category"}} {{singular "people"}} {{quote "say \"hi\""}} ` +
+		`{{if hasExtension .Extensions "docker"}}docker{{end}} {{upper (snakeCase .Name)}}`
+
+	tmpl, err := template.New("test").Funcs(FuncMap()).Parse(text)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var out strings.Builder
+	data := struct {
+		Name       string
+		Extensions []string
+	}{Name: "LineItem", Extensions: []string{"aws-ses", "docker"}}
+	if err := tmpl.Execute(&out, data); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	want := `categories person "say \"hi\"" docker LINE_ITEM`
+	if out.String() != want {
+		t.Fatalf("rendered %q, want %q", out.String(), want)
+	}
+}
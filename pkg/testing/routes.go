@@ -0,0 +1,21 @@
+package testing
+
+import (
+	"testing"
+
+	"github.com/mbvlabs/andurel/pkg/routecheck"
+)
+
+// AssertControllerRoutes fails t for every routecheck problem in the
+// controllers directory dir, including actions RegisterRoutes never adds.
+func AssertControllerRoutes(t testing.TB, dir string) {
+	t.Helper()
+
+	problems, err := routecheck.Check(dir)
+	if err != nil {
+		t.Fatalf("check controller routes: %v", err)
+	}
+	for _, problem := range problems {
+		t.Errorf("%s", problem)
+	}
+}
@@ -0,0 +1,29 @@
+package testing
+
+import (
+	"os"
+	"path/filepath"
+	gotesting "testing"
+)
+
+func TestAssertControllerRoutes(t *gotesting.T) {
+	dir := t.TempDir()
+	content := `package controllers
+
+import "github.com/labstack/echo/v5"
+
+type Pages struct{}
+
+func (p Pages) RegisterRoutes(r *router.Router) error {
+	_, err := r.AddRoute(echo.Route{Method: "GET", Path: "/", Handler: p.Home})
+	return err
+}
+
+func (p Pages) Home(etx *echo.Context) error { return nil }
+`
+	if err := os.WriteFile(filepath.Join(dir, "pages.go"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write controller: %v", err)
+	}
+
+	AssertControllerRoutes(t, dir)
+}